@@ -0,0 +1,626 @@
+# Code generated by cmd/clientgen. DO NOT EDIT.
+from __future__ import annotations
+
+from typing import Any, Dict, List, Optional, TypedDict
+
+
+class AdditionCheck(TypedDict, total=False):
+    added: bool
+    can_add: bool
+    order_id: str
+    reasons: List[str]
+    remaining: RemainingCapacity
+    truck_id: str
+
+
+class AlgorithmCapabilities(TypedDict, total=False):
+    constraints: List[str]
+    description: str
+    deterministic: bool
+    max_orders: int
+    measured_at: str
+    name: str
+    optimality: str
+    optimality_note: str
+    parameters: List[str]
+    runtime_curve: List[RuntimePoint]
+    time_limit_ms: int
+
+
+class AlgorithmOutcome(TypedDict, total=False):
+    algorithm: str
+    compute_time_ms: int
+    optimal: bool
+    orders_selected: int
+    score: int
+    selected: bool
+    status: str
+
+
+class AppliedBonus(TypedDict, total=False):
+    bonus_cents: int
+    order_id: str
+    reason: str
+
+
+class AxleInput(TypedDict, total=False):
+    drive_empty_lbs: int
+    drive_max_lbs: int
+    fifth_wheel_offset_in: int
+    kingpin_in: int
+    on_violation: str
+    steer_empty_lbs: int
+    steer_max_lbs: int
+    tandem_empty_lbs: int
+    tandem_in: int
+    tandem_max_lbs: int
+    wheelbase_in: int
+
+
+class AxleLoads(TypedDict, total=False):
+    drive_lbs: int
+    steer_lbs: int
+    tandem_lbs: int
+
+
+class BackhaulPair(TypedDict, total=False):
+    backhaul_order_ids: List[str]
+    backhaul_payout_cents: int
+    rank: int
+    round_trip_net_profit_cents: int
+    round_trip_payout_cents: int
+
+
+class BackhaulRequest(TypedDict, total=False):
+    currency: str
+    dim_factor: int
+    dispatch_thresholds: DispatchThresholds
+    duplicate_policy: str
+    facilities: List[FacilityInput]
+    k: int
+    lane_risks: List[LaneRiskInput]
+    min_total_payout_cents: int
+    min_utilization_percent: float
+    multi_stop: MultiStopInput
+    must_exclude_order_ids: List[str]
+    must_include_order_ids: List[str]
+    optimization_config: OptimizationConfig
+    orders: List[OrderInput]
+    outbound_order_ids: List[str]
+    previous_order_ids: List[str]
+    radius_miles: int
+    rules: List[RuleInput]
+    truck: TruckInput
+
+
+class BackhaulResponse(TypedDict, total=False):
+    backhaul: OptimizeResponse
+    candidate_order_ids: List[str]
+    destination: str
+    home: str
+    outbound: OptimizeResponse
+    pairs: List[BackhaulPair]
+    payout_redacted: bool
+
+
+class BatchItem(TypedDict, total=False):
+    error: ErrorDetail
+    index: int
+    response: OptimizeResponse
+    status: int
+
+
+class BatchRequest(TypedDict, total=False):
+    requests: List[OptimizeRequest]
+
+
+class BatchResponse(TypedDict, total=False):
+    failed: int
+    results: List[BatchItem]
+    succeeded: int
+
+
+class BidLevel(TypedDict, total=False):
+    payout_cents: int
+    win_probability: float
+
+
+class BidRequest(TypedDict, total=False):
+    bids: List[OrderBids]
+    currency: str
+    dim_factor: int
+    dispatch_thresholds: DispatchThresholds
+    duplicate_policy: str
+    facilities: List[FacilityInput]
+    k: int
+    lane_risks: List[LaneRiskInput]
+    min_total_payout_cents: int
+    min_utilization_percent: float
+    multi_stop: MultiStopInput
+    must_exclude_order_ids: List[str]
+    must_include_order_ids: List[str]
+    optimization_config: OptimizationConfig
+    orders: List[OrderInput]
+    previous_order_ids: List[str]
+    rules: List[RuleInput]
+    truck: TruckInput
+
+
+class BidResponse(TypedDict, total=False):
+    bids: List[ChosenBid]
+    expected_cost_cents: int
+    expected_payout_cents: int
+    expected_profit_cents: int
+    plan: OptimizeResponse
+    win_any_probability: float
+
+
+class ChosenBid(TypedDict, total=False):
+    expected_payout_cents: int
+    order_id: str
+    payout_cents: int
+    win_probability: float
+
+
+class CompartmentInput(TypedDict, total=False):
+    equipment_type: str
+    id: str
+    max_volume_cuft: int
+    max_weight_lbs: int
+
+
+class CompartmentLoad(TypedDict, total=False):
+    compartment_id: str
+    order_ids: List[str]
+    volume_cuft: int
+    weight_lbs: int
+
+
+class CostBreakdown(TypedDict, total=False):
+    driver_cents: int
+    fixed_cents: int
+    fuel_cents: int
+    mileage_cents: int
+    stop_fee_cents: int
+    toll_cents: int
+    tolls_unavailable: List[str]
+    total_cents: int
+
+
+class CsvUpload(TypedDict, total=False):
+    cost_per_mile_cents: int
+    equipment: str
+    equipment_type: str
+    fixed_cost_cents: int
+    interior_height_in: int
+    interior_length_in: int
+    interior_width_in: int
+    max_linear_feet: int
+    max_orders: int
+    max_pallet_positions: int
+    max_volume_cuft: int
+    max_weight_lbs: int
+    orders: str
+    stop_fee_cents: int
+    truck_id: str
+
+
+class Deadhead(TypedDict, total=False):
+    from_last_delivery_miles: int
+    to_first_pickup_miles: int
+    total_miles: int
+
+
+class DisabledRules(TypedDict, total=False):
+    disabled_rules: List[str]
+    tenant_id: str
+    toggleable: List[str]
+
+
+class DispatchThresholds(TypedDict, total=False):
+    min_margin_percent: float
+    min_payout_cents: int
+    min_utilization_percent: float
+
+
+class DriverPayInput(TypedDict, total=False):
+    hourly_cents: int
+    per_mile_cents: int
+    per_stop_cents: int
+
+
+class ErrorDetail(TypedDict, total=False):
+    code: int
+    message: str
+
+
+class ErrorResponse(TypedDict, total=False):
+    error: ErrorDetail
+
+
+class ExcludedOrder(TypedDict, total=False):
+    order_id: str
+    reason: str
+
+
+class Excursion(TypedDict, total=False):
+    excess_hours: float
+    exposure_hours: float
+    safe_exposure_hours: float
+
+
+class Explanation(TypedDict, total=False):
+    applied_bonuses: List[AppliedBonus]
+    excluded_orders: List[ExcludedOrder]
+
+
+class FacilityInput(TypedDict, total=False):
+    dock_door_types: List[str]
+    location: str
+    windows: List[FacilityWindowInput]
+
+
+FacilityWindowInput = TypedDict(
+    "FacilityWindowInput",
+    {
+        "from": "str",
+        "max_trucks": "int",
+        "to": "str",
+    },
+    total=False,
+)
+
+
+class FuelInput(TypedDict, total=False):
+    miles_per_gallon: float
+    price_cents_per_gallon: int
+
+
+class HealthDetails(TypedDict, total=False):
+    reasons: List[str]
+    result_cache: ResultCacheStats
+    shadow: ShadowStats
+    status: str
+    windows: List[HealthWindow]
+
+
+class HealthWindow(TypedDict, total=False):
+    average_gap_percent: float
+    average_measured_gap_percent: float
+    error_rate: float
+    errors: int
+    gap_samples: int
+    measured_gap_samples: int
+    solves: int
+    timeout_rate: float
+    timeouts: int
+    window: str
+
+
+class Job(TypedDict, total=False):
+    api_key: str
+    created_at: str
+    error: ErrorDetail
+    finished_at: Optional[str]
+    id: str
+    request: OptimizeRequest
+    response: OptimizeResponse
+    started_at: Optional[str]
+    status: str
+    tenant_id: str
+
+
+class LaneRiskInput(TypedDict, total=False):
+    destination: str
+    origin: str
+    risk_factor: float
+
+
+class LatLng(TypedDict, total=False):
+    lat: float
+    lng: float
+
+
+class MultiStopInput(TypedDict, total=False):
+    corridor_miles: float
+    lifo: str
+    max_detour_miles: float
+    max_detour_percent: float
+    max_stops: int
+
+
+class NoAcceptablePlan(TypedDict, total=False):
+    reasons: List[str]
+
+
+class OptimizationConfig(TypedDict, total=False):
+    algorithm: str
+    deadhead_weight: float
+    emissions_weight: float
+    excursion_weight: float
+    objective: str
+    priority_mode: str
+    revenue_weight: float
+    stability_weight: float
+    tabu_neighborhood: int
+    tabu_tenure: int
+    utilization_weight: float
+
+
+class OptimizeRequest(TypedDict, total=False):
+    currency: str
+    dim_factor: int
+    dispatch_thresholds: DispatchThresholds
+    duplicate_policy: str
+    facilities: List[FacilityInput]
+    k: int
+    lane_risks: List[LaneRiskInput]
+    min_total_payout_cents: int
+    min_utilization_percent: float
+    multi_stop: MultiStopInput
+    must_exclude_order_ids: List[str]
+    must_include_order_ids: List[str]
+    optimization_config: OptimizationConfig
+    orders: List[OrderInput]
+    previous_order_ids: List[str]
+    rules: List[RuleInput]
+    truck: TruckInput
+
+
+class OptimizeResponse(TypedDict, total=False):
+    alternatives: List[PlanSummary]
+    axle_loads: AxleLoads
+    cached: bool
+    compartments: List[CompartmentLoad]
+    cost_breakdown: CostBreakdown
+    currency: str
+    deadhead: Deadhead
+    emissions_kg_co2: float
+    excursion: Excursion
+    explanation: Explanation
+    fixed_cost_cents: int
+    loading_sequence: List[str]
+    net_profit_cents: int
+    no_acceptable_plan: NoAcceptablePlan
+    partial_orders: List[PartialOrder]
+    payout_redacted: bool
+    plan_changes: PlanChanges
+    portfolio: List[AlgorithmOutcome]
+    problem_fingerprint: str
+    recommendation: str
+    recommendation_reasons: List[str]
+    score: int
+    selected_order_ids: List[str]
+    solution_id: str
+    stopped_early: bool
+    stops: List[Stop]
+    total_linear_feet: int
+    total_pallet_positions: int
+    total_pallets: int
+    total_payout_cents: int
+    total_scale_weight_lbs: int
+    total_volume_cuft: int
+    total_weight_lbs: int
+    truck_id: str
+    utilization_volume_percent: float
+    utilization_weight_percent: float
+    warnings: List[ValidationWarning]
+
+
+class OrderBids(TypedDict, total=False):
+    levels: List[BidLevel]
+    order_id: str
+
+
+class OrderInput(TypedDict, total=False):
+    commodity_type: str
+    delivery_date: str
+    delivery_window_end: str
+    delivery_window_start: str
+    destination: str
+    destination_point: LatLng
+    equipment_requirements: List[str]
+    exclusive_group: str
+    hazmat_class: str
+    height_in: int
+    id: str
+    is_hazmat: bool
+    length_in: int
+    linear_feet: int
+    max_exposure_hours: float
+    miles: int
+    origin: str
+    origin_point: LatLng
+    pallet_count: int
+    payout_cents: int
+    payout_encrypted: str
+    pickup_date: str
+    pickup_window_end: str
+    pickup_window_start: str
+    priority: int
+    shipper: str
+    splittable: bool
+    stackable: bool
+    temperature_max_f: Optional[float]
+    temperature_min_f: Optional[float]
+    volume_cuft: int
+    weight_lbs: int
+    width_in: int
+
+
+class OrderRevenue(TypedDict, total=False):
+    earliest_recognized: str
+    latest_recognized: str
+    order_id: str
+    payout_minor: int
+
+
+class ParetoPage(TypedDict, total=False):
+    count: int
+    exact: bool
+    next_cursor: str
+    payout_redacted: bool
+    solutions: List[ParetoSolution]
+    total: int
+    truck_id: str
+
+
+class ParetoSolution(TypedDict, total=False):
+    emissions_kg_co2: float
+    order_ids: List[str]
+    score: float
+    total_payout_cents: int
+    total_volume_cuft: int
+    total_weight_lbs: int
+    utilization_volume_percent: float
+    utilization_weight_percent: float
+
+
+class PartialOrder(TypedDict, total=False):
+    fraction: float
+    order_id: str
+    payout_cents: int
+    volume_cuft: int
+    weight_lbs: int
+
+
+class PlanChanges(TypedDict, total=False):
+    added: List[str]
+    removed: List[str]
+
+
+class PlanState(TypedDict, total=False):
+    committed_at: str
+    order_ids: List[str]
+    remaining: RemainingCapacity
+    truck_id: str
+    updated_at: str
+
+
+class PlanSummary(TypedDict, total=False):
+    emissions_kg_co2: float
+    net_profit_cents: int
+    rank: int
+    selected_order_ids: List[str]
+    total_payout_cents: int
+    total_volume_cuft: int
+    total_weight_lbs: int
+    utilization_volume_percent: float
+    utilization_weight_percent: float
+
+
+class RemainingCapacity(TypedDict, total=False):
+    linear_feet: Optional[int]
+    orders: Optional[int]
+    pallet_positions: Optional[int]
+    volume_cuft: int
+    weight_lbs: int
+
+
+class ResultCacheStats(TypedDict, total=False):
+    entries: Optional[int]
+    hit_rate: float
+    hits: int
+    misses: int
+
+
+class RuleInput(TypedDict, total=False):
+    days: int
+    limit: int
+    order_ids: List[str]
+    type: str
+
+
+class RuntimePoint(TypedDict, total=False):
+    median_ms: float
+    orders: int
+
+
+class ShadowStats(TypedDict, total=False):
+    algorithm: str
+    average_payout_delta_cents: float
+    average_primary_compute_ms: float
+    average_shadow_compute_ms: float
+    better: int
+    payout_delta_cents: int
+    runs: int
+    same: int
+    skipped: int
+    worse: int
+
+
+class SolutionRecord(TypedDict, total=False):
+    algorithm: str
+    api_key: str
+    cache_hit: bool
+    compute_time_ms: int
+    created_at: str
+    currency: str
+    measured_gap_percent: Optional[float]
+    net_profit_minor: int
+    optimal: bool
+    orders_considered: int
+    orders_selected: int
+    payout_redacted: bool
+    problem_fingerprint: str
+    recommendation: str
+    revenue: List[OrderRevenue]
+    solution_id: str
+    tenant_id: str
+    total_cost_minor: int
+    total_payout_minor: int
+    total_volume: int
+    total_weight: int
+    truck_id: str
+    utilization_volume_percent: float
+    utilization_weight_percent: float
+    volume_unit: str
+    weight_unit: str
+
+
+class Stop(TypedDict, total=False):
+    location: str
+    miles_from_previous: Optional[int]
+    order_ids: List[str]
+    point: LatLng
+    sequence: int
+    type: str
+
+
+class TenantPurge(TypedDict, total=False):
+    cutoff: str
+    deleted_jobs: int
+    deleted_solutions: int
+    history_days: int
+    job_ids: List[str]
+    solution_ids: List[str]
+    tenant_id: str
+
+
+class TruckInput(TypedDict, total=False):
+    axles: AxleInput
+    compartments: List[CompartmentInput]
+    cost_per_mile_cents: int
+    driver_pay: DriverPayInput
+    equipment: List[str]
+    equipment_type: str
+    fixed_cost_cents: int
+    fuel: FuelInput
+    id: str
+    interior_height_in: int
+    interior_length_in: int
+    interior_width_in: int
+    max_linear_feet: int
+    max_orders: int
+    max_pallet_positions: int
+    max_volume_cuft: int
+    max_weight_lbs: int
+    next_position: LatLng
+    position: LatLng
+    stop_fee_cents: int
+
+
+class ValidationWarning(TypedDict, total=False):
+    code: str
+    field: str
+    message: str
@@ -0,0 +1,618 @@
+// Code generated by cmd/clientgen. DO NOT EDIT.
+
+export interface AdditionCheck {
+  added?: boolean;
+  can_add?: boolean;
+  order_id?: string;
+  reasons?: string[];
+  remaining?: RemainingCapacity;
+  truck_id?: string;
+}
+
+export interface AlgorithmCapabilities {
+  constraints?: string[];
+  description?: string;
+  deterministic?: boolean;
+  max_orders?: number;
+  measured_at?: string;
+  name?: string;
+  optimality?: string;
+  optimality_note?: string;
+  parameters?: string[];
+  runtime_curve?: RuntimePoint[];
+  time_limit_ms?: number;
+}
+
+export interface AlgorithmOutcome {
+  algorithm?: string;
+  compute_time_ms?: number;
+  optimal?: boolean;
+  orders_selected?: number;
+  score?: number;
+  selected?: boolean;
+  status?: string;
+}
+
+export interface AppliedBonus {
+  bonus_cents?: number;
+  order_id?: string;
+  reason?: string;
+}
+
+export interface AxleInput {
+  drive_empty_lbs?: number;
+  drive_max_lbs?: number;
+  fifth_wheel_offset_in?: number;
+  kingpin_in?: number;
+  on_violation?: string;
+  steer_empty_lbs?: number;
+  steer_max_lbs?: number;
+  tandem_empty_lbs?: number;
+  tandem_in?: number;
+  tandem_max_lbs?: number;
+  wheelbase_in?: number;
+}
+
+export interface AxleLoads {
+  drive_lbs?: number;
+  steer_lbs?: number;
+  tandem_lbs?: number;
+}
+
+export interface BackhaulPair {
+  backhaul_order_ids?: string[];
+  backhaul_payout_cents?: number;
+  rank?: number;
+  round_trip_net_profit_cents?: number;
+  round_trip_payout_cents?: number;
+}
+
+export interface BackhaulRequest {
+  currency?: string;
+  dim_factor?: number;
+  dispatch_thresholds?: DispatchThresholds;
+  duplicate_policy?: string;
+  facilities?: FacilityInput[];
+  k?: number;
+  lane_risks?: LaneRiskInput[];
+  min_total_payout_cents?: number;
+  min_utilization_percent?: number;
+  multi_stop?: MultiStopInput;
+  must_exclude_order_ids?: string[];
+  must_include_order_ids?: string[];
+  optimization_config?: OptimizationConfig;
+  orders?: OrderInput[];
+  outbound_order_ids?: string[];
+  previous_order_ids?: string[];
+  radius_miles?: number;
+  rules?: RuleInput[];
+  truck?: TruckInput;
+}
+
+export interface BackhaulResponse {
+  backhaul?: OptimizeResponse;
+  candidate_order_ids?: string[];
+  destination?: string;
+  home?: string;
+  outbound?: OptimizeResponse;
+  pairs?: BackhaulPair[];
+  payout_redacted?: boolean;
+}
+
+export interface BatchItem {
+  error?: ErrorDetail;
+  index?: number;
+  response?: OptimizeResponse;
+  status?: number;
+}
+
+export interface BatchRequest {
+  requests?: OptimizeRequest[];
+}
+
+export interface BatchResponse {
+  failed?: number;
+  results?: BatchItem[];
+  succeeded?: number;
+}
+
+export interface BidLevel {
+  payout_cents?: number;
+  win_probability?: number;
+}
+
+export interface BidRequest {
+  bids?: OrderBids[];
+  currency?: string;
+  dim_factor?: number;
+  dispatch_thresholds?: DispatchThresholds;
+  duplicate_policy?: string;
+  facilities?: FacilityInput[];
+  k?: number;
+  lane_risks?: LaneRiskInput[];
+  min_total_payout_cents?: number;
+  min_utilization_percent?: number;
+  multi_stop?: MultiStopInput;
+  must_exclude_order_ids?: string[];
+  must_include_order_ids?: string[];
+  optimization_config?: OptimizationConfig;
+  orders?: OrderInput[];
+  previous_order_ids?: string[];
+  rules?: RuleInput[];
+  truck?: TruckInput;
+}
+
+export interface BidResponse {
+  bids?: ChosenBid[];
+  expected_cost_cents?: number;
+  expected_payout_cents?: number;
+  expected_profit_cents?: number;
+  plan?: OptimizeResponse;
+  win_any_probability?: number;
+}
+
+export interface ChosenBid {
+  expected_payout_cents?: number;
+  order_id?: string;
+  payout_cents?: number;
+  win_probability?: number;
+}
+
+export interface CompartmentInput {
+  equipment_type?: string;
+  id?: string;
+  max_volume_cuft?: number;
+  max_weight_lbs?: number;
+}
+
+export interface CompartmentLoad {
+  compartment_id?: string;
+  order_ids?: string[];
+  volume_cuft?: number;
+  weight_lbs?: number;
+}
+
+export interface CostBreakdown {
+  driver_cents?: number;
+  fixed_cents?: number;
+  fuel_cents?: number;
+  mileage_cents?: number;
+  stop_fee_cents?: number;
+  toll_cents?: number;
+  tolls_unavailable?: string[];
+  total_cents?: number;
+}
+
+export interface CsvUpload {
+  cost_per_mile_cents?: number;
+  equipment?: string;
+  equipment_type?: string;
+  fixed_cost_cents?: number;
+  interior_height_in?: number;
+  interior_length_in?: number;
+  interior_width_in?: number;
+  max_linear_feet?: number;
+  max_orders?: number;
+  max_pallet_positions?: number;
+  max_volume_cuft?: number;
+  max_weight_lbs?: number;
+  orders?: string;
+  stop_fee_cents?: number;
+  truck_id?: string;
+}
+
+export interface Deadhead {
+  from_last_delivery_miles?: number;
+  to_first_pickup_miles?: number;
+  total_miles?: number;
+}
+
+export interface DisabledRules {
+  disabled_rules?: string[];
+  tenant_id?: string;
+  toggleable?: string[];
+}
+
+export interface DispatchThresholds {
+  min_margin_percent?: number;
+  min_payout_cents?: number;
+  min_utilization_percent?: number;
+}
+
+export interface DriverPayInput {
+  hourly_cents?: number;
+  per_mile_cents?: number;
+  per_stop_cents?: number;
+}
+
+export interface ErrorDetail {
+  code?: number;
+  message?: string;
+}
+
+export interface ErrorResponse {
+  error?: ErrorDetail;
+}
+
+export interface ExcludedOrder {
+  order_id?: string;
+  reason?: string;
+}
+
+export interface Excursion {
+  excess_hours?: number;
+  exposure_hours?: number;
+  safe_exposure_hours?: number;
+}
+
+export interface Explanation {
+  applied_bonuses?: AppliedBonus[];
+  excluded_orders?: ExcludedOrder[];
+}
+
+export interface FacilityInput {
+  dock_door_types?: string[];
+  location?: string;
+  windows?: FacilityWindowInput[];
+}
+
+export interface FacilityWindowInput {
+  from?: string;
+  max_trucks?: number;
+  to?: string;
+}
+
+export interface FuelInput {
+  miles_per_gallon?: number;
+  price_cents_per_gallon?: number;
+}
+
+export interface HealthDetails {
+  reasons?: string[];
+  result_cache?: ResultCacheStats;
+  shadow?: ShadowStats;
+  status?: string;
+  windows?: HealthWindow[];
+}
+
+export interface HealthWindow {
+  average_gap_percent?: number;
+  average_measured_gap_percent?: number;
+  error_rate?: number;
+  errors?: number;
+  gap_samples?: number;
+  measured_gap_samples?: number;
+  solves?: number;
+  timeout_rate?: number;
+  timeouts?: number;
+  window?: string;
+}
+
+export interface Job {
+  api_key?: string;
+  created_at?: string;
+  error?: ErrorDetail;
+  finished_at?: string | null;
+  id?: string;
+  request?: OptimizeRequest;
+  response?: OptimizeResponse;
+  started_at?: string | null;
+  status?: string;
+  tenant_id?: string;
+}
+
+export interface LaneRiskInput {
+  destination?: string;
+  origin?: string;
+  risk_factor?: number;
+}
+
+export interface LatLng {
+  lat?: number;
+  lng?: number;
+}
+
+export interface MultiStopInput {
+  corridor_miles?: number;
+  lifo?: string;
+  max_detour_miles?: number;
+  max_detour_percent?: number;
+  max_stops?: number;
+}
+
+export interface NoAcceptablePlan {
+  reasons?: string[];
+}
+
+export interface OptimizationConfig {
+  algorithm?: string;
+  deadhead_weight?: number;
+  emissions_weight?: number;
+  excursion_weight?: number;
+  objective?: string;
+  priority_mode?: string;
+  revenue_weight?: number;
+  stability_weight?: number;
+  tabu_neighborhood?: number;
+  tabu_tenure?: number;
+  utilization_weight?: number;
+}
+
+export interface OptimizeRequest {
+  currency?: string;
+  dim_factor?: number;
+  dispatch_thresholds?: DispatchThresholds;
+  duplicate_policy?: string;
+  facilities?: FacilityInput[];
+  k?: number;
+  lane_risks?: LaneRiskInput[];
+  min_total_payout_cents?: number;
+  min_utilization_percent?: number;
+  multi_stop?: MultiStopInput;
+  must_exclude_order_ids?: string[];
+  must_include_order_ids?: string[];
+  optimization_config?: OptimizationConfig;
+  orders?: OrderInput[];
+  previous_order_ids?: string[];
+  rules?: RuleInput[];
+  truck?: TruckInput;
+}
+
+export interface OptimizeResponse {
+  alternatives?: PlanSummary[];
+  axle_loads?: AxleLoads;
+  cached?: boolean;
+  compartments?: CompartmentLoad[];
+  cost_breakdown?: CostBreakdown;
+  currency?: string;
+  deadhead?: Deadhead;
+  emissions_kg_co2?: number;
+  excursion?: Excursion;
+  explanation?: Explanation;
+  fixed_cost_cents?: number;
+  loading_sequence?: string[];
+  net_profit_cents?: number;
+  no_acceptable_plan?: NoAcceptablePlan;
+  partial_orders?: PartialOrder[];
+  payout_redacted?: boolean;
+  plan_changes?: PlanChanges;
+  portfolio?: AlgorithmOutcome[];
+  problem_fingerprint?: string;
+  recommendation?: string;
+  recommendation_reasons?: string[];
+  score?: number;
+  selected_order_ids?: string[];
+  solution_id?: string;
+  stopped_early?: boolean;
+  stops?: Stop[];
+  total_linear_feet?: number;
+  total_pallet_positions?: number;
+  total_pallets?: number;
+  total_payout_cents?: number;
+  total_scale_weight_lbs?: number;
+  total_volume_cuft?: number;
+  total_weight_lbs?: number;
+  truck_id?: string;
+  utilization_volume_percent?: number;
+  utilization_weight_percent?: number;
+  warnings?: ValidationWarning[];
+}
+
+export interface OrderBids {
+  levels?: BidLevel[];
+  order_id?: string;
+}
+
+export interface OrderInput {
+  commodity_type?: string;
+  delivery_date?: string;
+  delivery_window_end?: string;
+  delivery_window_start?: string;
+  destination?: string;
+  destination_point?: LatLng;
+  equipment_requirements?: string[];
+  exclusive_group?: string;
+  hazmat_class?: string;
+  height_in?: number;
+  id?: string;
+  is_hazmat?: boolean;
+  length_in?: number;
+  linear_feet?: number;
+  max_exposure_hours?: number;
+  miles?: number;
+  origin?: string;
+  origin_point?: LatLng;
+  pallet_count?: number;
+  payout_cents?: number;
+  payout_encrypted?: string;
+  pickup_date?: string;
+  pickup_window_end?: string;
+  pickup_window_start?: string;
+  priority?: number;
+  shipper?: string;
+  splittable?: boolean;
+  stackable?: boolean;
+  temperature_max_f?: number | null;
+  temperature_min_f?: number | null;
+  volume_cuft?: number;
+  weight_lbs?: number;
+  width_in?: number;
+}
+
+export interface OrderRevenue {
+  earliest_recognized?: string;
+  latest_recognized?: string;
+  order_id?: string;
+  payout_minor?: number;
+}
+
+export interface ParetoPage {
+  count?: number;
+  exact?: boolean;
+  next_cursor?: string;
+  payout_redacted?: boolean;
+  solutions?: ParetoSolution[];
+  total?: number;
+  truck_id?: string;
+}
+
+export interface ParetoSolution {
+  emissions_kg_co2?: number;
+  order_ids?: string[];
+  score?: number;
+  total_payout_cents?: number;
+  total_volume_cuft?: number;
+  total_weight_lbs?: number;
+  utilization_volume_percent?: number;
+  utilization_weight_percent?: number;
+}
+
+export interface PartialOrder {
+  fraction?: number;
+  order_id?: string;
+  payout_cents?: number;
+  volume_cuft?: number;
+  weight_lbs?: number;
+}
+
+export interface PlanChanges {
+  added?: string[];
+  removed?: string[];
+}
+
+export interface PlanState {
+  committed_at?: string;
+  order_ids?: string[];
+  remaining?: RemainingCapacity;
+  truck_id?: string;
+  updated_at?: string;
+}
+
+export interface PlanSummary {
+  emissions_kg_co2?: number;
+  net_profit_cents?: number;
+  rank?: number;
+  selected_order_ids?: string[];
+  total_payout_cents?: number;
+  total_volume_cuft?: number;
+  total_weight_lbs?: number;
+  utilization_volume_percent?: number;
+  utilization_weight_percent?: number;
+}
+
+export interface RemainingCapacity {
+  linear_feet?: number | null;
+  orders?: number | null;
+  pallet_positions?: number | null;
+  volume_cuft?: number;
+  weight_lbs?: number;
+}
+
+export interface ResultCacheStats {
+  entries?: number | null;
+  hit_rate?: number;
+  hits?: number;
+  misses?: number;
+}
+
+export interface RuleInput {
+  days?: number;
+  limit?: number;
+  order_ids?: string[];
+  type?: string;
+}
+
+export interface RuntimePoint {
+  median_ms?: number;
+  orders?: number;
+}
+
+export interface ShadowStats {
+  algorithm?: string;
+  average_payout_delta_cents?: number;
+  average_primary_compute_ms?: number;
+  average_shadow_compute_ms?: number;
+  better?: number;
+  payout_delta_cents?: number;
+  runs?: number;
+  same?: number;
+  skipped?: number;
+  worse?: number;
+}
+
+export interface SolutionRecord {
+  algorithm?: string;
+  api_key?: string;
+  cache_hit?: boolean;
+  compute_time_ms?: number;
+  created_at?: string;
+  currency?: string;
+  measured_gap_percent?: number | null;
+  net_profit_minor?: number;
+  optimal?: boolean;
+  orders_considered?: number;
+  orders_selected?: number;
+  payout_redacted?: boolean;
+  problem_fingerprint?: string;
+  recommendation?: string;
+  revenue?: OrderRevenue[];
+  solution_id?: string;
+  tenant_id?: string;
+  total_cost_minor?: number;
+  total_payout_minor?: number;
+  total_volume?: number;
+  total_weight?: number;
+  truck_id?: string;
+  utilization_volume_percent?: number;
+  utilization_weight_percent?: number;
+  volume_unit?: string;
+  weight_unit?: string;
+}
+
+export interface Stop {
+  location?: string;
+  miles_from_previous?: number | null;
+  order_ids?: string[];
+  point?: LatLng;
+  sequence?: number;
+  type?: string;
+}
+
+export interface TenantPurge {
+  cutoff?: string;
+  deleted_jobs?: number;
+  deleted_solutions?: number;
+  history_days?: number;
+  job_ids?: string[];
+  solution_ids?: string[];
+  tenant_id?: string;
+}
+
+export interface TruckInput {
+  axles?: AxleInput;
+  compartments?: CompartmentInput[];
+  cost_per_mile_cents?: number;
+  driver_pay?: DriverPayInput;
+  equipment?: string[];
+  equipment_type?: string;
+  fixed_cost_cents?: number;
+  fuel?: FuelInput;
+  id?: string;
+  interior_height_in?: number;
+  interior_length_in?: number;
+  interior_width_in?: number;
+  max_linear_feet?: number;
+  max_orders?: number;
+  max_pallet_positions?: number;
+  max_volume_cuft?: number;
+  max_weight_lbs?: number;
+  next_position?: LatLng;
+  position?: LatLng;
+  stop_fee_cents?: number;
+}
+
+export interface ValidationWarning {
+  code?: string;
+  field?: string;
+  message?: string;
+}
@@ -0,0 +1,265 @@
+// Command clientgen generates the Python and TypeScript clients under
+// clients/ from the server's OpenAPI document, so every client follows the
+// same schema as /openapi.json.
+package main
+
+//go:generate go run . -out ../../clients
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"smart-load/internal/api"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func main() {
+	out := flag.String("out", "clients", "directory to write the clients to")
+	flag.Parse()
+	
+	files, err := generate()
+	if err != nil {
+		log.Fatalf("Failed to generate clients: %v", err)
+	}
+	for name, content := range files {
+		path := filepath.Join(*out, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatalf("Failed to generate clients: %v", err)
+		}
+		if err := os.WriteFile(path, content, 0o644); err != nil {
+			log.Fatalf("Failed to generate clients: %v", err)
+		}
+	}
+}
+
+// generate returns every generated file by its path under the clients
+// directory
+func generate() (map[string][]byte, error) {
+	app := fiber.New()
+	api.SetupRoutes(app, service.NewOptimizerService())
+	raw, err := json.MarshalIndent(api.OpenAPIDocument(app.GetRoutes(true)), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	endpoints, err := doc.endpoints()
+	if err != nil {
+		return nil, err
+	}
+	
+	files := map[string][]byte{"openapi.json": append(raw, '\n')}
+	for name, content := range pythonClient(doc, endpoints) {
+		files[filepath.Join("python", name)] = content
+	}
+	for name, content := range typeScriptClient(doc, endpoints) {
+		files[filepath.Join("typescript", name)] = content
+	}
+	return files, nil
+}
+
+// document is the part of an OpenAPI document the clients are built from
+type document struct {
+	Info struct {
+		Title   string `json:"title"`
+		Version string `json:"version"`
+	} `json:"info"`
+	Paths      map[string]map[string]operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string `json:"operationId"`
+	Summary     string `json:"summary"`
+	Parameters  []struct {
+		Name string `json:"name"`
+		In   string `json:"in"`
+	} `json:"parameters"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+	Responses map[string]struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Nullable             bool               `json:"nullable"`
+	Items                *schema            `json:"items"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+}
+
+// refName is the component a schema refers to, empty when it is inline
+func (s *schema) refName() string {
+	return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+}
+
+// endpoint is an operation as the clients call it
+type endpoint struct {
+	ID      string
+	Summary string
+	Method  string
+	Path    string
+	// Params are the path parameters, in the order they appear
+	Params []string
+	// Body is the JSON request body, nil when there is none or it is not
+	// JSON; BodyType is then the body's media type, if any
+	Body     *schema
+	BodyType string
+	// Response is the JSON success body, nil when it is not described
+	Response *schema
+	// NoContent is set when success is a 204 without a body
+	NoContent bool
+}
+
+// endpoints lists the operations that have an operationId, by path and
+// then method; routes left undocumented, and form uploads, are left out of
+// the clients
+func (d document) endpoints() ([]endpoint, error) {
+	var endpoints []endpoint
+	for path, item := range d.Paths {
+		for method, op := range item {
+			if op.OperationID == "" {
+				continue
+			}
+			e := endpoint{ID: op.OperationID, Summary: op.Summary, Method: strings.ToUpper(method), Path: path}
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					e.Params = append(e.Params, param.Name)
+				}
+			}
+			if op.RequestBody != nil {
+				// Form uploads need a multipart encoder the clients do not have
+				if _, ok := op.RequestBody.Content[fiber.MIMEMultipartForm]; ok {
+					continue
+				}
+				// Clients send JSON where it is taken, else the one other type
+				for mediaType, content := range op.RequestBody.Content {
+					if mediaType == fiber.MIMEApplicationJSON {
+						e.Body = content.Schema
+					} else if len(op.RequestBody.Content) == 1 {
+						e.BodyType = mediaType
+					}
+				}
+			}
+			for status, response := range op.Responses {
+				if status == "204" {
+					e.NoContent = true
+				}
+				if strings.HasPrefix(status, "2") {
+					if content, ok := response.Content[fiber.MIMEApplicationJSON]; ok {
+						e.Response = content.Schema
+					}
+				}
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+	seen := make(map[string]bool, len(endpoints))
+	for _, e := range endpoints {
+		if seen[e.ID] {
+			return nil, fmt.Errorf("operationId %s is used twice", e.ID)
+		}
+		seen[e.ID] = true
+	}
+	return endpoints, nil
+}
+
+// schemaNames lists the components by name
+func (d document) schemaNames() []string {
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// propertyNames lists an object's properties by name
+func propertyNames(s *schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// typeName turns a component name, which may be qualified by its package as
+// in history.Record, into an identifier
+func typeName(component string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range component {
+		if r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// pathSegments splits a path template into its literal text and parameter
+// names: "/trucks/{truckId}/plan" is "/trucks/", "truckId", "/plan". Even
+// indexes are literal text.
+func pathSegments(path string) []string {
+	var segments []string
+	for {
+		start := strings.IndexByte(path, '{')
+		end := strings.IndexByte(path, '}')
+		if start < 0 || end < start {
+			return append(segments, path)
+		}
+		segments = append(segments, path[:start], path[start+1:end])
+		path = path[end+1:]
+	}
+}
+
+// snakeCase turns a camelCase name into snake_case, keeping acronyms
+// together: optimizeXML is optimize_xml
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			lowerBefore := unicode.IsLower(runes[i-1])
+			lowerAfter := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if lowerBefore || (unicode.IsUpper(runes[i-1]) && lowerAfter) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
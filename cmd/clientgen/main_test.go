@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestClientsUpToDate fails when the committed clients no longer match the
+// API; run go generate ./cmd/clientgen after changing it
+func TestClientsUpToDate(t *testing.T) {
+	files, err := generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range files {
+		got, err := os.ReadFile(filepath.Join("..", "..", "clients", name))
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("clients/%s is stale", name)
+		}
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"optimize":       "optimize",
+		"optimizeXML":    "optimize_xml",
+		"truckId":        "truck_id",
+		"getXMLResponse": "get_xml_response",
+	} {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestPathSegments(t *testing.T) {
+	got := pathSegments("/trucks/{truckId}/plan")
+	want := []string{"/trucks/", "truckId", "/plan"}
+	if len(got) != len(want) {
+		t.Fatalf("pathSegments = %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("pathSegments = %q, want %q", got, want)
+		}
+	}
+}
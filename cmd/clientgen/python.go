@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const pythonHeader = "# Code generated by cmd/clientgen. DO NOT EDIT.\n"
+
+// pythonKeywords are the property names a TypedDict class body cannot
+// declare
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// pythonClient is the smartload package: TypedDicts for the schemas and a
+// Client with a method per operation, on the standard library only
+func pythonClient(doc document, endpoints []endpoint) map[string][]byte {
+	return map[string][]byte{
+		"pyproject.toml":        []byte(pythonProject(doc)),
+		"smartload/__init__.py": []byte(pythonInit()),
+		"smartload/models.py":   []byte(pythonModels(doc)),
+		"smartload/client.py":   []byte(pythonClientModule(endpoints)),
+		"smartload/py.typed":    nil,
+		"smartload/_version.py": []byte(pythonHeader + fmt.Sprintf("__version__ = %q\n", doc.Info.Version)),
+	}
+}
+
+func pythonProject(doc document) string {
+	return pythonHeader + fmt.Sprintf(`[build-system]
+requires = ["setuptools>=61"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = "smartload"
+version = %q
+description = "Client for the %s"
+requires-python = ">=3.8"
+
+[tool.setuptools.package-data]
+smartload = ["py.typed"]
+`, doc.Info.Version, doc.Info.Title)
+}
+
+func pythonInit() string {
+	return pythonHeader + `from ._version import __version__
+from .client import ApiError, Client
+from .models import *  # noqa: F401,F403
+`
+}
+
+func pythonModels(doc document) string {
+	var b strings.Builder
+	b.WriteString(pythonHeader)
+	b.WriteString("from __future__ import annotations\n\n")
+	b.WriteString("from typing import Any, Dict, List, Optional, TypedDict\n")
+	for _, name := range doc.schemaNames() {
+		s := doc.Components.Schemas[name]
+		b.WriteString("\n\n")
+		properties := propertyNames(s)
+		functional := false
+		for _, property := range properties {
+			if pythonKeywords[property] {
+				functional = true
+			}
+		}
+		if functional {
+			// A property named after a keyword needs the functional form,
+			// which evaluates its types, so they are quoted
+			fmt.Fprintf(&b, "%s = TypedDict(\n    %q,\n    {\n", typeName(name), typeName(name))
+			for _, property := range properties {
+				fmt.Fprintf(&b, "        %q: %q,\n", property, pythonType(s.Properties[property]))
+			}
+			b.WriteString("    },\n    total=False,\n)\n")
+			continue
+		}
+		fmt.Fprintf(&b, "class %s(TypedDict, total=False):\n", typeName(name))
+		if len(properties) == 0 {
+			b.WriteString("    pass\n")
+		}
+		for _, property := range properties {
+			fmt.Fprintf(&b, "    %s: %s\n", property, pythonType(s.Properties[property]))
+		}
+	}
+	return b.String()
+}
+
+// pythonType is the annotation for values of a schema
+func pythonType(s *schema) string {
+	var t string
+	switch {
+	case s.Ref != "":
+		t = typeName(s.refName())
+	case s.Type == "string":
+		t = "str"
+	case s.Type == "integer":
+		t = "int"
+	case s.Type == "number":
+		t = "float"
+	case s.Type == "boolean":
+		t = "bool"
+	case s.Type == "array":
+		t = "List[" + pythonType(s.Items) + "]"
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		t = "Dict[str, " + pythonType(s.AdditionalProperties) + "]"
+	case s.Type == "object":
+		t = "Dict[str, Any]"
+	default:
+		t = "Any"
+	}
+	if s.Nullable {
+		return "Optional[" + t + "]"
+	}
+	return t
+}
+
+func pythonClientModule(endpoints []endpoint) string {
+	var b strings.Builder
+	b.WriteString(pythonHeader)
+	b.WriteString(`from __future__ import annotations
+
+import json
+import urllib.error
+import urllib.parse
+import urllib.request
+from typing import Any, Dict, List, Optional
+
+from .models import *  # noqa: F401,F403
+
+
+class ApiError(Exception):
+    """A response other than 2xx. body is the decoded ErrorResponse when the
+    server sent one, else the raw text."""
+
+    def __init__(self, status: int, body: Any):
+        super().__init__(f"SmartLoad API error {status}: {body}")
+        self.status = status
+        self.body = body
+
+
+class Client:
+    """Calls the SmartLoad Optimizer API. api_key is sent as X-API-Key and
+    tenant_id as X-Tenant-ID on every request that has them."""
+
+    def __init__(
+        self,
+        base_url: str = "http://localhost:8080",
+        api_key: Optional[str] = None,
+        tenant_id: Optional[str] = None,
+        timeout: float = 30.0,
+    ):
+        self.base_url = base_url.rstrip("/")
+        self.api_key = api_key
+        self.tenant_id = tenant_id
+        self.timeout = timeout
+
+    def _request(self, method: str, path: str, body: Any = None, content_type: str = "application/json") -> Any:
+        headers = {"Accept": "application/json"}
+        if self.api_key:
+            headers["X-API-Key"] = self.api_key
+        if self.tenant_id:
+            headers["X-Tenant-ID"] = self.tenant_id
+        data = None
+        if body is not None:
+            headers["Content-Type"] = content_type
+            data = body.encode() if isinstance(body, str) else json.dumps(body).encode()
+        request = urllib.request.Request(self.base_url + path, data=data, headers=headers, method=method)
+        try:
+            with urllib.request.urlopen(request, timeout=self.timeout) as response:
+                payload = response.read()
+        except urllib.error.HTTPError as err:
+            payload = err.read()
+            try:
+                detail = json.loads(payload)
+            except ValueError:
+                detail = payload.decode(errors="replace")
+            raise ApiError(err.code, detail) from None
+        return json.loads(payload) if payload else None
+`)
+	for _, e := range endpoints {
+		args := []string{"self"}
+		for _, param := range e.Params {
+			args = append(args, snakeCase(param)+": str")
+		}
+		body := ""
+		switch {
+		case e.Body != nil:
+			args = append(args, "body: "+pythonType(e.Body))
+			body = ", body"
+		case e.BodyType != "":
+			args = append(args, "body: str")
+			body = fmt.Sprintf(", body, %q", e.BodyType)
+		}
+		result := "Any"
+		if e.Response != nil {
+			result = pythonType(e.Response)
+		} else if e.NoContent {
+			result = "None"
+		}
+
+		var path []string
+		for i, segment := range pathSegments(e.Path) {
+			if i%2 == 0 {
+				if segment != "" {
+					path = append(path, fmt.Sprintf("%q", segment))
+				}
+				continue
+			}
+			path = append(path, fmt.Sprintf("urllib.parse.quote(%s, safe=\"\")", snakeCase(segment)))
+		}
+		call := fmt.Sprintf("self._request(%q, %s%s)", e.Method, strings.Join(path, " + "), body)
+
+		fmt.Fprintf(&b, "\n    def %s(%s) -> %s:\n", snakeCase(e.ID), strings.Join(args, ", "), result)
+		if e.Summary != "" {
+			fmt.Fprintf(&b, "        \"\"\"%s\"\"\"\n", e.Summary)
+		}
+		fmt.Fprintf(&b, "        return %s\n", call)
+	}
+	return b.String()
+}
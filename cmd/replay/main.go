@@ -0,0 +1,191 @@
+// Command replay solves recorded requests again with this build and diffs the
+// answers against the recorded ones, to check an algorithm change against
+// production traffic before it ships. Each request is solved as of when it
+// was recorded, under the tenant settings it was recorded with.
+//
+//	go run ./cmd/replay [flags] recordings/...
+//
+// Recordings in object storage are replayed from a local copy, such as one
+// made with aws s3 sync. Recordings replayed together must have been made
+// under the same RECORDING_PSEUDONYM_KEY_FILE, or the same tenants and
+// shippers go by different pseudonyms in them.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"smart-load/internal/clock"
+	"smart-load/internal/distance"
+	"smart-load/internal/geo"
+	"smart-load/internal/recording"
+	"smart-load/internal/service"
+	"smart-load/internal/tenant"
+	"smart-load/internal/tolls"
+)
+
+// result is one replayed entry, as printed with -json
+type result struct {
+	ID    string          `json:"id"`
+	Error string          `json:"error,omitempty"`
+	Diff  *recording.Diff `json:"diff,omitempty"`
+}
+
+// summary totals a replay
+type summary struct {
+	Replayed int
+	Changed  int
+	Better   int
+	Worse    int
+	Failed   int
+	
+	PayoutDeltaCents    int64
+	NetProfitDeltaCents int64
+	RecordedComputeMs   int64
+	ReplayedComputeMs   int64
+}
+
+func main() {
+	distances := flag.String("distances", "", "distance matrix file to solve with, as DISTANCE_MATRIX_FILE")
+	tollTable := flag.String("tolls", "", "toll table file to solve with, as TOLL_TABLE_FILE")
+	geocodes := flag.String("geocodes", "", "geocoding table file to solve with, as GEOCODE_TABLE_FILE")
+	timeout := flag.Duration("timeout", 0, "solve timeout; 0 keeps the service default")
+	asJSON := flag.Bool("json", false, "print every replayed entry as NDJSON instead of only the changed ones")
+	failOnWorse := flag.Bool("fail-on-worse", false, "exit 1 when any replay earns less than its recording or fails")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatalf("Usage: replay [flags] file-or-directory...")
+	}
+	
+	entries, err := recording.ReadPaths(flag.Args())
+	if err != nil {
+		log.Fatalf("Failed to read recordings: %v", err)
+	}
+	
+	frozen := clock.NewAdjustable(clock.System{})
+	tenants := tenant.NewMemoryStore()
+	opts := []service.Option{service.WithClock(frozen), service.WithTenantStore(tenants)}
+	if *timeout > 0 {
+		opts = append(opts, service.WithSolveTimeout(*timeout))
+	}
+	if *distances != "" {
+		matrix, err := distance.LoadStaticMatrix(*distances)
+		if err != nil {
+			log.Fatalf("Failed to load distance matrix: %v", err)
+		}
+		opts = append(opts, service.WithDistanceProvider(matrix))
+	}
+	if *tollTable != "" {
+		table, err := tolls.LoadStaticTable(*tollTable)
+		if err != nil {
+			log.Fatalf("Failed to load toll table: %v", err)
+		}
+		opts = append(opts, service.WithTollProvider(table))
+	}
+	if *geocodes != "" {
+		table, err := geo.LoadStaticTable(*geocodes)
+		if err != nil {
+			log.Fatalf("Failed to load geocoding table: %v", err)
+		}
+		opts = append(opts, service.WithGeocoder(table))
+	}
+	optimizerService := service.NewOptimizerService(opts...)
+	
+	log.SetOutput(io.Discard)
+	total := replay(optimizerService, frozen, tenants, entries, os.Stdout, *asJSON)
+	printSummary(os.Stderr, total)
+	if *failOnWorse && (total.Worse > 0 || total.Failed > 0) {
+		os.Exit(1)
+	}
+}
+
+// replay solves every entry again and writes a line for each that changed,
+// or for every entry as NDJSON with asJSON
+func replay(
+	optimizerService *service.OptimizerService,
+	frozen *clock.Adjustable,
+	tenants *tenant.MemoryStore,
+	entries []recording.Entry,
+	out io.Writer,
+	asJSON bool,
+) summary {
+	var total summary
+	encoder := json.NewEncoder(out)
+	for _, entry := range entries {
+		replayed, err := solve(optimizerService, frozen, tenants, entry)
+		outcome := result{ID: entry.ID}
+		total.Replayed++
+		if err != nil {
+			total.Failed++
+			outcome.Error = err.Error()
+		} else {
+			diff := recording.Compare(entry.Outcome, replayed)
+			outcome.Diff = &diff
+			total.PayoutDeltaCents += diff.PayoutDeltaCents
+			total.NetProfitDeltaCents += diff.NetProfitDeltaCents
+			total.RecordedComputeMs += entry.Outcome.ComputeTimeMs
+			total.ReplayedComputeMs += replayed.ComputeTimeMs
+			if diff.Changed() {
+				total.Changed++
+			}
+			switch {
+			case diff.Worse():
+				total.Worse++
+			case diff.PayoutDeltaCents > 0 || diff.NetProfitDeltaCents > 0:
+				total.Better++
+			}
+		}
+		
+		switch {
+		case asJSON:
+			encoder.Encode(outcome)
+		case outcome.Error != "":
+			fmt.Fprintf(out, "%s  failed: %s\n", entry.ID, outcome.Error)
+		case outcome.Diff.Changed():
+			diff := outcome.Diff
+			fmt.Fprintf(out, "%s  payout %+d  net profit %+d  utilization %+.2f%%  added %v  removed %v\n",
+				entry.ID, diff.PayoutDeltaCents, diff.NetProfitDeltaCents, diff.UtilizationWeightDelta, diff.Added, diff.Removed)
+		}
+	}
+	return total
+}
+
+// solve answers an entry's request as of when it was recorded, under the
+// settings it was recorded with
+func solve(
+	optimizerService *service.OptimizerService,
+	frozen *clock.Adjustable,
+	tenants *tenant.MemoryStore,
+	entry recording.Entry,
+) (recording.Outcome, error) {
+	frozen.Freeze(entry.RecordedAt)
+	tenants.Update(entry.TenantID, func(settings *tenant.Settings) {
+		*settings = entry.Settings
+	})
+	request := entry.Request
+	request.TenantID = entry.TenantID
+	
+	response, err := optimizerService.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		return recording.Outcome{}, err
+	}
+	record, _ := optimizerService.Solve(response.SolutionID)
+	return recording.OutcomeOf(response, record.Algorithm, record.ComputeTimeMs), nil
+}
+
+func printSummary(out io.Writer, total summary) {
+	fmt.Fprintf(out, "Replayed %d: %d changed, %d better, %d worse, %d failed\n",
+		total.Replayed, total.Changed, total.Better, total.Worse, total.Failed)
+	fmt.Fprintf(out, "Payout %+d cents, net profit %+d cents\n", total.PayoutDeltaCents, total.NetProfitDeltaCents)
+	if solved := total.Replayed - total.Failed; solved > 0 {
+		fmt.Fprintf(out, "Mean compute time %s recorded, %s replayed\n",
+			time.Duration(total.RecordedComputeMs/int64(solved))*time.Millisecond,
+			time.Duration(total.ReplayedComputeMs/int64(solved))*time.Millisecond)
+	}
+}
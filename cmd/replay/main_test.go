@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"smart-load/internal/clock"
+	"smart-load/internal/domain"
+	"smart-load/internal/recording"
+	"smart-load/internal/service"
+	"smart-load/internal/tenant"
+)
+
+// entries keeps the solves recorded to it
+type entries []recording.Entry
+
+func (e *entries) Publish(key string, event interface{}) {
+	*e = append(*e, event.(recording.Entry))
+}
+
+func (e *entries) Close() error {
+	return nil
+}
+
+func TestReplay(t *testing.T) {
+	recorded := &entries{}
+	recorder := service.NewOptimizerService(service.WithRecorder(recorded, 1, true, []byte("replay-test-key-0123456789abcdefgh")))
+	request := domain.OptimizeRequest{
+		Truck: domain.TruckInput{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+		Orders: []domain.OrderInput{
+			{ID: "light", PayoutCents: 100000, WeightLbs: 5000, VolumeCuft: 100, Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: "2030-01-01", DeliveryDate: "2030-01-03", Shipper: "Globex"},
+			{ID: "heavy", PayoutCents: 90000, WeightLbs: 9500, VolumeCuft: 100, Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: "2030-01-01", DeliveryDate: "2030-01-03"},
+		},
+		TenantID: "acme",
+	}
+	if _, err := recorder.OptimizeLoad(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if len(*recorded) != 1 {
+		t.Fatalf("recorded %d solves, want 1", len(*recorded))
+	}
+	
+	frozen := clock.NewAdjustable(clock.System{})
+	tenants := tenant.NewMemoryStore()
+	replayer := service.NewOptimizerService(service.WithClock(frozen), service.WithTenantStore(tenants))
+	var out bytes.Buffer
+	total := replay(replayer, frozen, tenants, *recorded, &out, false)
+	if total.Replayed != 1 || total.Changed != 0 || total.Failed != 0 || out.Len() != 0 {
+		t.Errorf("same build: summary %+v, output %q; want no changes", total, out.String())
+	}
+	
+	changed := append(entries{}, *recorded...)
+	changed[0].Outcome.TotalPayoutCents += 5000
+	changed[0].Outcome.SelectedOrderIDs = []string{"heavy"}
+	total = replay(replayer, frozen, tenants, changed, &out, false)
+	if total.Changed != 1 || total.Worse != 1 || !strings.Contains(out.String(), "payout -5000") {
+		t.Errorf("worse replay: summary %+v, output %q", total, out.String())
+	}
+	
+	out.Reset()
+	changed[0].Request.Truck.MaxWeightLbs = -1
+	total = replay(replayer, frozen, tenants, changed, &out, true)
+	if total.Failed != 1 || !strings.Contains(out.String(), `"error"`) {
+		t.Errorf("invalid request: summary %+v, output %q", total, out.String())
+	}
+}
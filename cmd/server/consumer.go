@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"smart-load/internal/consume"
+	"smart-load/internal/publish"
+	"smart-load/internal/service"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+)
+
+// runConsumer solves requests from the queue mode names until the process
+// is signalled to stop, or the queue fails
+func runConsumer(mode string, optimizerService *service.OptimizerService) error {
+	queue, source := openQueue(mode)
+	consumer := consume.NewConsumer(queue, optimizerService)
+	
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	log.Printf("SmartLoad consuming requests from %s...\n", source)
+	err := consumer.Run(ctx)
+	log.Println("Shutting down gracefully...")
+	if err := consumer.Close(); err != nil {
+		log.Printf("Failed to close %s consumer: %v", mode, err)
+	}
+	return err
+}
+
+// openQueue connects to the queue of a consumer mode from the environment,
+// returning it with a description for the logs
+func openQueue(mode string) (consume.Queue, string) {
+	switch mode {
+	case "kafka":
+		brokers := os.Getenv("KAFKA_BROKERS")
+		if brokers == "" {
+			log.Fatalf("KAFKA_BROKERS is required in kafka mode")
+		}
+		acks, err := publish.ParseAcks(os.Getenv("KAFKA_ACKS"))
+		if err != nil {
+			log.Fatalf("Invalid KAFKA_ACKS: %v", err)
+		}
+		kafkaConfig := consume.KafkaConfig{
+			Brokers:       strings.Split(brokers, ","),
+			RequestsTopic: getEnvOrDefault("KAFKA_REQUESTS_TOPIC", "smartload.requests"),
+			ResultsTopic:  getEnvOrDefault("KAFKA_RESPONSES_TOPIC", "smartload.responses"),
+			GroupID:       getEnvOrDefault("KAFKA_CONSUMER_GROUP", "smartload-optimizer"),
+			Acks:          acks,
+		}
+		return consume.NewKafkaQueue(kafkaConfig), "Kafka topic " + kafkaConfig.RequestsTopic
+	case "sqs":
+		requests, results := os.Getenv("SQS_REQUESTS_QUEUE_URL"), os.Getenv("SQS_RESPONSES_QUEUE_URL")
+		if requests == "" || results == "" {
+			log.Fatalf("SQS_REQUESTS_QUEUE_URL and SQS_RESPONSES_QUEUE_URL are required in sqs mode")
+		}
+		awsConfig, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to load AWS configuration: %v", err)
+		}
+		return consume.NewSQSQueue(sqs.NewFromConfig(awsConfig), requests, results), "SQS queue " + requests
+	case "amqp":
+		url := os.Getenv("AMQP_URL")
+		if url == "" {
+			log.Fatalf("AMQP_URL is required in amqp mode")
+		}
+		requests := getEnvOrDefault("AMQP_REQUESTS_QUEUE", "smartload.requests")
+		queue, err := consume.DialAMQPQueue(url, requests, getEnvOrDefault("AMQP_RESPONSES_QUEUE", "smartload.responses"))
+		if err != nil {
+			log.Fatalf("Failed to connect to AMQP broker: %v", err)
+		}
+		return queue, "AMQP queue " + requests
+	}
+	log.Fatalf("Invalid RUN_MODE: %s", mode)
+	return nil, ""
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// openListener opens every socket the server takes connections on: TCP on
+// PORT, bound to BIND_ADDRESS over LISTEN_NETWORK (tcp4 by default, tcp for
+// dual-stack IPv4 and IPv6, tcp6 for IPv6 only), and a Unix domain socket at
+// UNIX_SOCKET when it is set. PORT=off leaves TCP out, for sidecars that only
+// talk over the socket.
+func openListener() (net.Listener, error) {
+	var listeners []net.Listener
+	closeAll := func() {
+		for _, ln := range listeners {
+			ln.Close()
+		}
+	}
+
+	if port := getEnvOrDefault("PORT", "8080"); port != "off" {
+		network := getEnvOrDefault("LISTEN_NETWORK", "tcp4")
+		switch network {
+		case "tcp", "tcp4", "tcp6":
+		default:
+			return nil, fmt.Errorf("invalid LISTEN_NETWORK: %s (must be tcp, tcp4, or tcp6)", network)
+		}
+		ln, err := net.Listen(network, net.JoinHostPort(os.Getenv("BIND_ADDRESS"), port))
+		if err != nil {
+			return nil, err
+		}
+		listeners = append(listeners, ln)
+	}
+
+	if path := os.Getenv("UNIX_SOCKET"); path != "" {
+		mode, err := strconv.ParseUint(getEnvOrDefault("UNIX_SOCKET_MODE", "0660"), 8, 32)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("invalid UNIX_SOCKET_MODE: %w", err)
+		}
+		ln, err := listenUnix(path, os.FileMode(mode))
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+		listeners = append(listeners, ln)
+	}
+
+	switch len(listeners) {
+	case 0:
+		return nil, errors.New("nothing to listen on: PORT is off and UNIX_SOCKET is not set")
+	case 1:
+		return listeners[0], nil
+	}
+	return newMultiListener(listeners), nil
+}
+
+// listenUnix listens on a Unix domain socket at path, replacing a socket left
+// behind by a server that did not shut down cleanly. Any other file at path is
+// left alone. Closing the listener removes the socket.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("UNIX_SOCKET %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// multiListener accepts connections from several listeners at once, so one
+// fiber app serves them all. Its Addr is the first listener's.
+type multiListener struct {
+	listeners []net.Listener
+	conns     chan net.Conn
+	errs      chan error
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newMultiListener(listeners []net.Listener) *multiListener {
+	m := &multiListener{
+		listeners: listeners,
+		conns:     make(chan net.Conn),
+		errs:      make(chan error, len(listeners)),
+		done:      make(chan struct{}),
+	}
+	for _, ln := range listeners {
+		go m.acceptFrom(ln)
+	}
+	return m
+}
+
+func (m *multiListener) acceptFrom(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			m.errs <- err
+			return
+		}
+		select {
+		case m.conns <- conn:
+		case <-m.done:
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Accept returns the next connection from any listener. The first listener
+// to fail fails Accept, which stops the server.
+func (m *multiListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-m.conns:
+		return conn, nil
+	case err := <-m.errs:
+		return nil, err
+	case <-m.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (m *multiListener) Close() error {
+	var errs []error
+	m.closeOnce.Do(func() {
+		close(m.done)
+		for _, ln := range m.listeners {
+			if err := ln.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+func (m *multiListener) Addr() net.Addr {
+	return m.listeners[0].Addr()
+}
+
+// addrs lists every address the listener serves, for the startup log
+func addrs(ln net.Listener) []string {
+	m, ok := ln.(*multiListener)
+	if !ok {
+		return []string{ln.Addr().String()}
+	}
+	list := make([]string, len(m.listeners))
+	for i, l := range m.listeners {
+		list[i] = l.Addr().String()
+	}
+	return list
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenListenerServesTCPAndUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "smartload.sock")
+	// A socket left behind by an unclean shutdown is replaced
+	stale, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+	
+	t.Setenv("PORT", "0")
+	t.Setenv("BIND_ADDRESS", "127.0.0.1")
+	t.Setenv("UNIX_SOCKET", socket)
+	ln, err := openListener()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	if got := addrs(ln); len(got) != 2 || got[1] != socket {
+		t.Fatalf("addrs = %v, want a TCP address and %s", got, socket)
+	}
+	if info, err := os.Stat(socket); err != nil || info.Mode().Perm() != 0660 {
+		t.Errorf("socket mode = %v (%v), want 0660", info.Mode().Perm(), err)
+	}
+	
+	for _, dial := range [][2]string{{"tcp", ln.Addr().String()}, {"unix", socket}} {
+		client, err := net.Dial(dial[0], dial[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := ln.Accept()
+		if err != nil {
+			t.Fatalf("%s: %v", dial[0], err)
+		}
+		conn.Close()
+		client.Close()
+	}
+	
+	ln.Close()
+	if _, err := ln.Accept(); err == nil {
+		t.Error("Accept succeeded after Close")
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("socket left behind after Close: %v", err)
+	}
+}
+
+func TestOpenListenerRejects(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not-a-socket")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		env  map[string]string
+	}{
+		{"nothing to listen on", map[string]string{"PORT": "off", "UNIX_SOCKET": ""}},
+		{"unknown network", map[string]string{"PORT": "0", "LISTEN_NETWORK": "udp"}},
+		{"regular file at socket path", map[string]string{"PORT": "off", "UNIX_SOCKET": file}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+			if ln, err := openListener(); err == nil {
+				ln.Close()
+				t.Error("listener opened")
+			}
+		})
+	}
+	if _, err := os.Stat(file); err != nil {
+		t.Errorf("regular file removed: %v", err)
+	}
+}
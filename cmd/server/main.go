@@ -1,43 +1,164 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"io"
 	"log"
+	"net"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"smart-load/internal/api"
+	"smart-load/internal/auth"
+	"smart-load/internal/cache"
+	"smart-load/internal/clock"
+	"smart-load/internal/distance"
+	"smart-load/internal/domain"
+	"smart-load/internal/geo"
+	"smart-load/internal/grpcapi"
+	"smart-load/internal/i18n"
+	"smart-load/internal/jobs"
+	"smart-load/internal/metrics"
+	"smart-load/internal/publish"
+	"smart-load/internal/sealing"
 	"smart-load/internal/service"
+	"smart-load/internal/signing"
+	"smart-load/internal/tolls"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
+	demoMode := flag.Bool("demo", os.Getenv("DEMO_MODE") == "true", "seed synthetic freight and serve /api/v1/demo")
+	mode := flag.String("mode", getEnvOrDefault("RUN_MODE", "http"), "http to serve the API, or kafka, sqs or amqp to solve requests from that queue")
+	flag.Parse()
+	switch *mode {
+	case "http", "kafka", "sqs", "amqp":
+	default:
+		log.Fatalf("Invalid RUN_MODE: %s (must be http, kafka, sqs, or amqp)", *mode)
+	}
+
 	app := fiber.New(fiber.Config{
 		AppName:      "SmartLoad Optimizer v1.0",
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		BodyLimit:    1 * 1024 * 1024, // 1MB max request body
 		ErrorHandler: customErrorHandler,
+		// Bodies over the limit reach the handlers as streams, which only
+		// NDJSON, batch and CSV requests read; RequestSizeLimiter rejects
+		// the others. CSV uploads read their form as it arrives, so it is
+		// not parsed up front.
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
 	})
 
 	// Middleware
+	registry := metrics.New()
 	app.Use(recover.New())
 	app.Use(logger.New(logger.Config{
 		Format:     "[${time}] ${status} - ${latency} ${method} ${path}\n",
 		TimeFormat: "2006-01-02 15:04:05",
 	}))
+	app.Use(api.Metrics(registry))
 	app.Use(api.RequestSizeLimiter(1 * 1024 * 1024))
+	app.Use(api.JSONParsing(getEnvOrDefault("JSON_PARSING", "lenient") == "strict"))
+
+	var keys *auth.KeyStore
+	if path := os.Getenv("API_KEYS_FILE"); path != "" {
+		var err error
+		keys, err = auth.LoadKeyStore(path)
+		if err != nil {
+			log.Fatalf("Failed to load API keys: %v", err)
+		}
+		app.Use("/api", api.APIKeyAuth(keys))
+	}
+
+	if path := os.Getenv("RESPONSE_SIGNING_KEY_FILE"); path != "" {
+		signer, err := signing.LoadSigner(path)
+		if err != nil {
+			log.Fatalf("Failed to load response signing key: %v", err)
+		}
+		app.Get("/.well-known/jwks.json", api.JWKSHandler(signer))
+		app.Use("/api", api.SignResponses(signer))
+	}
+
+	idempotencyWindow, err := time.ParseDuration(getEnvOrDefault("IDEMPOTENCY_WINDOW", "24h"))
+	if err != nil || idempotencyWindow <= 0 {
+		log.Fatalf("Invalid IDEMPOTENCY_WINDOW: %s (must be a positive duration)", os.Getenv("IDEMPOTENCY_WINDOW"))
+	}
+	idempotencyKeys := getEnvInt("IDEMPOTENCY_MAX_KEYS", 100000)
+	if idempotencyKeys <= 0 {
+		log.Fatalf("Invalid IDEMPOTENCY_MAX_KEYS: %d (must be positive)", idempotencyKeys)
+	}
+	app.Use("/api", api.Idempotency(idempotencyWindow, idempotencyKeys))
 
 	// Initialize services
-	optimizerService := service.NewOptimizerService()
+	opts, closers := serviceOptions()
+	opts = append(opts, service.WithSolveObserver(registry))
+	optimizerService := service.NewOptimizerService(opts...)
+	registry.WatchResultCache(optimizerService.ResultCacheStats)
+	if resumed, err := optimizerService.ResumeJobs(context.Background()); err != nil {
+		log.Fatalf("Failed to resume jobs: %v", err)
+	} else if resumed > 0 {
+		log.Printf("Resumed %d unfinished jobs", resumed)
+	}
 	
 	// Setup routes
 	api.SetupRoutes(app, optimizerService)
+	app.Get("/metrics", api.MetricsHandler(registry))
+	
+	// Seed the demo tenant with synthetic freight
+	if *demoMode {
+		api.SetupDemoRoutes(app, optimizerService)
+		seed, err := optimizerService.ResetDemo(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to seed demo data: %v", err)
+		}
+		log.Printf("Demo mode: seeded %d trucks for tenant %s\n", len(seed.Trucks), seed.TenantID)
+	}
+	
+	// Purge data past its retention period in the background
+	purgeInterval, err := time.ParseDuration(getEnvOrDefault("PURGE_INTERVAL", "1h"))
+	if err != nil || purgeInterval <= 0 {
+		log.Fatalf("Invalid PURGE_INTERVAL: %s", os.Getenv("PURGE_INTERVAL"))
+	}
+	jobs, stopJobs := context.WithCancel(context.Background())
+	go optimizerService.RunPurgeJob(jobs, purgeInterval)
+	
+	// In the queue modes requests arrive from a queue instead of over HTTP or gRPC
+	if *mode != "http" {
+		err := runConsumer(*mode, optimizerService)
+		stopJobs()
+		closeIntegrations(closers)
+		if err != nil {
+			log.Fatalf("Failed to consume requests: %v", err)
+		}
+		return
+	}
 
+	// Serve gRPC on its own port, next to HTTP
+	grpcServer := grpcapi.NewServer(optimizerService, keys)
+	if port := os.Getenv("GRPC_PORT"); port != "" {
+		grpcListener, err := net.Listen("tcp", net.JoinHostPort(os.Getenv("BIND_ADDRESS"), port))
+		if err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+		log.Printf("SmartLoad gRPC API starting on %s...\n", grpcListener.Addr())
+		go func() {
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Fatalf("Failed to start gRPC server: %v", err)
+			}
+		}()
+	}
+	
 	// Graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -45,16 +166,31 @@ func main() {
 		<-sigChan
 		
 		log.Println("Shutting down gracefully...")
+		stopJobs()
+		grpcServer.GracefulStop()
 		_ = app.Shutdown()
 	}()
 
 	// Start server
-	port := getEnvOrDefault("PORT", "8080")
-	log.Printf("SmartLoad API starting on port %s...\n", port)
+	ln, err := openListener()
+	if err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+	log.Printf("SmartLoad API starting on %s...\n", strings.Join(addrs(ln), ", "))
 	
-	if err := app.Listen(":" + port); err != nil {
+	if err := app.Listener(ln); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
+	closeIntegrations(closers)
+}
+
+// closeIntegrations closes what serviceOptions opened, flushing buffered work
+func closeIntegrations(closers []io.Closer) {
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			log.Printf("Failed to close integration: %v", err)
+		}
+	}
 }
 
 func customErrorHandler(c *fiber.Ctx, err error) error {
@@ -66,14 +202,204 @@ func customErrorHandler(c *fiber.Ctx, err error) error {
 		message = e.Message
 	}
 
+	language := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Set(fiber.HeaderContentLanguage, language)
+	c.Vary(fiber.HeaderAcceptLanguage)
 	return c.Status(code).JSON(fiber.Map{
 		"error": fiber.Map{
 			"code":    code,
-			"message": message,
+			"message": i18n.Translate(language, message),
 		},
 	})
 }
 
+// serviceOptions wires optional integrations from the environment. The returned
+// closers must be closed after the server stops so buffered work is flushed.
+func serviceOptions() ([]service.Option, []io.Closer) {
+	opts := make([]service.Option, 0)
+	closers := make([]io.Closer, 0)
+	
+	if timeout := os.Getenv("SOLVE_TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			log.Fatalf("Invalid SOLVE_TIMEOUT: %v", err)
+		}
+		opts = append(opts, service.WithSolveTimeout(d))
+	}
+	
+	switch mode := getEnvOrDefault("CLOCK_MODE", "system"); mode {
+	case "system":
+	case "adjustable":
+		log.Println("Clock is adjustable through /api/v1/admin/clock; do not use in production")
+		opts = append(opts, service.WithClock(clock.NewAdjustable(clock.System{})))
+	default:
+		log.Fatalf("Invalid CLOCK_MODE: %s (must be system or adjustable)", mode)
+	}
+	
+	if days := os.Getenv("HISTORY_RETENTION_DAYS"); days != "" {
+		policy := domain.RetentionPolicy{}
+		var err error
+		if policy.HistoryDays, err = strconv.Atoi(days); err == nil {
+			err = policy.Validate()
+		}
+		if err != nil {
+			log.Fatalf("Invalid HISTORY_RETENTION_DAYS: %v", err)
+		}
+		opts = append(opts, service.WithRetention(policy))
+	}
+	
+	if name := os.Getenv("ROUNDING_MODE"); name != "" {
+		mode, err := domain.ParseRoundingMode(name)
+		if err != nil {
+			log.Fatalf("Invalid ROUNDING_MODE: %v", err)
+		}
+		opts = append(opts, service.WithRounding(mode))
+	}
+	
+	if value := os.Getenv("GAP_SAMPLE_RATE"); value != "" {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			log.Fatalf("Invalid GAP_SAMPLE_RATE: %s (must be between 0 and 1)", value)
+		}
+		opts = append(opts, service.WithGapSampling(rate))
+	}
+	
+	if name := os.Getenv("SHADOW_ALGORITHM"); name != "" {
+		if _, ok := domain.LookupAlgorithm(name); !ok {
+			log.Fatalf("Invalid SHADOW_ALGORITHM: %s", name)
+		}
+		rate := 1.0
+		if value := os.Getenv("SHADOW_SAMPLE_RATE"); value != "" {
+			var err error
+			rate, err = strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				log.Fatalf("Invalid SHADOW_SAMPLE_RATE: %s (must be between 0 and 1)", value)
+			}
+		}
+		log.Printf("Shadowing %.0f%% of solves with %s", rate*100, name)
+		opts = append(opts, service.WithShadowAlgorithm(name, rate))
+	}
+	
+	if path := os.Getenv("GEOCODE_TABLE_FILE"); path != "" {
+		table, err := geo.LoadStaticTable(path)
+		if err != nil {
+			log.Fatalf("Failed to load geocoding table: %v", err)
+		}
+		opts = append(opts, service.WithGeocoder(table))
+	} else if apiURL := os.Getenv("GEOCODE_API_URL"); apiURL != "" {
+		opts = append(opts, service.WithGeocoder(geo.NewHTTPProvider(apiURL)))
+	}
+	
+	if path := os.Getenv("DISTANCE_MATRIX_FILE"); path != "" {
+		matrix, err := distance.LoadStaticMatrix(path)
+		if err != nil {
+			log.Fatalf("Failed to load distance matrix: %v", err)
+		}
+		opts = append(opts, service.WithDistanceProvider(matrix))
+	} else if osrmURL := os.Getenv("OSRM_URL"); osrmURL != "" {
+		opts = append(opts, service.WithDistanceProvider(distance.NewOSRMProvider(osrmURL)))
+	}
+	
+	if path := os.Getenv("TOLL_TABLE_FILE"); path != "" {
+		table, err := tolls.LoadStaticTable(path)
+		if err != nil {
+			log.Fatalf("Failed to load toll table: %v", err)
+		}
+		opts = append(opts, service.WithTollProvider(table))
+	} else if apiURL := os.Getenv("TOLL_API_URL"); apiURL != "" {
+		opts = append(opts, service.WithTollProvider(tolls.NewHTTPProvider(apiURL)))
+	}
+	
+	if path := os.Getenv("PAYOUT_KEYS_FILE"); path != "" {
+		keyring, err := sealing.LoadKeyring(path)
+		if err != nil {
+			log.Fatalf("Failed to load payout keys: %v", err)
+		}
+		required := getEnvOrDefault("PAYOUT_ENCRYPTION", "optional") == "required"
+		opts = append(opts, service.WithPayoutKeyring(keyring, required))
+	}
+	
+	switch driver := getEnvOrDefault("JOB_STORE", "memory"); driver {
+	case "memory":
+	case "postgres":
+		url := os.Getenv("DATABASE_URL")
+		if url == "" {
+			log.Fatalf("JOB_STORE=postgres requires DATABASE_URL")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		store, err := jobs.OpenPostgres(ctx, url)
+		cancel()
+		if err != nil {
+			log.Fatalf("Failed to open job store: %v", err)
+		}
+		opts = append(opts, service.WithJobStore(store))
+		closers = append(closers, store)
+	default:
+		log.Fatalf("Invalid JOB_STORE: %s (must be memory or postgres)", driver)
+	}
+	
+	if url := os.Getenv("REDIS_URL"); url != "" {
+		redisOptions, err := redis.ParseURL(url)
+		if err != nil {
+			log.Fatalf("Invalid REDIS_URL: %v", err)
+		}
+		client := redis.NewClient(redisOptions)
+		opts = append(opts, service.WithResultCache(cache.NewRedisStore(client, resultCacheTTL())))
+		closers = append(closers, client)
+	} else if size := getEnvInt("RESULT_CACHE_SIZE", 0); size > 0 {
+		opts = append(opts, service.WithResultCache(cache.NewLRU(size, resultCacheTTL())))
+	}
+	
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" {
+		acks, err := publish.ParseAcks(os.Getenv("KAFKA_ACKS"))
+		if err != nil {
+			log.Fatalf("Invalid KAFKA_ACKS: %v", err)
+		}
+		sink := publish.NewKafkaSink(
+			strings.Split(brokers, ","),
+			getEnvOrDefault("KAFKA_RESULTS_TOPIC", "smartload.results"),
+			acks,
+		)
+		publisher := publish.NewAsyncPublisher(sink, publish.AsyncConfig{
+			BufferSize: getEnvInt("KAFKA_BUFFER_SIZE", 1000),
+			SchemaID:   uint32(getEnvInt("KAFKA_SCHEMA_ID", 0)),
+		})
+		opts = append(opts, service.WithResultPublisher(publisher))
+		closers = append(closers, publisher)
+	}
+	
+	if publisher, rate, enabled, key := openRecorder(); publisher != nil {
+		if enabled {
+			log.Printf("Recording %.0f%% of solves", rate*100)
+		}
+		opts = append(opts, service.WithRecorder(publisher, rate, enabled, key))
+		closers = append(closers, publisher)
+	}
+	
+	return opts, closers
+}
+
+// resultCacheTTL is how long the result cache serves a response
+func resultCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(getEnvOrDefault("RESULT_CACHE_TTL", "5m"))
+	if err != nil || ttl <= 0 {
+		log.Fatalf("Invalid RESULT_CACHE_TTL: %s (must be a positive duration)", os.Getenv("RESULT_CACHE_TTL"))
+	}
+	return ttl
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return parsed
+}
+
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
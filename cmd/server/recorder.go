@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+
+	"smart-load/internal/publish"
+	"smart-load/internal/recording"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// openRecorder connects the request recorder from the environment, returning
+// its publisher, the share of solves it records, whether it starts on, and the
+// key its pseudonyms are made under. The publisher is nil when neither
+// RECORDING_DIR nor RECORDING_S3_BUCKET is set.
+func openRecorder() (*publish.AsyncPublisher, float64, bool, []byte) {
+	var sink publish.Sink
+	if dir := os.Getenv("RECORDING_DIR"); dir != "" {
+		dirSink, err := recording.NewDirSink(dir)
+		if err != nil {
+			log.Fatalf("Failed to open RECORDING_DIR: %v", err)
+		}
+		sink = dirSink
+	} else if bucket := os.Getenv("RECORDING_S3_BUCKET"); bucket != "" {
+		awsConfig, err := config.LoadDefaultConfig(context.Background())
+		if err != nil {
+			log.Fatalf("Failed to load AWS configuration: %v", err)
+		}
+		sink = recording.NewS3Sink(s3.NewFromConfig(awsConfig), bucket, getEnvOrDefault("RECORDING_S3_PREFIX", "recordings"))
+	} else {
+		return nil, 0, false, nil
+	}
+	
+	path := os.Getenv("RECORDING_PSEUDONYM_KEY_FILE")
+	if path == "" {
+		log.Fatalf("RECORDING_PSEUDONYM_KEY_FILE is required to record solves")
+	}
+	key, err := recording.LoadPseudonymKey(path)
+	if err != nil {
+		log.Fatalf("Failed to load RECORDING_PSEUDONYM_KEY_FILE: %v", err)
+	}
+	
+	rate := 1.0
+	if value := os.Getenv("RECORDING_SAMPLE_RATE"); value != "" {
+		rate, err = strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			log.Fatalf("Invalid RECORDING_SAMPLE_RATE: %s (must be between 0 and 1)", value)
+		}
+	}
+	publisher := publish.NewAsyncPublisher(sink, publish.AsyncConfig{
+		BufferSize: getEnvInt("RECORDING_BUFFER_SIZE", 1000),
+	})
+	return publisher, rate, os.Getenv("RECORDING_ENABLED") == "true", key
+}
@@ -0,0 +1,259 @@
+// Package ordercsv maps order lists exported from spreadsheets onto the
+// optimizer's request types: orders from a CSV file with a header row, and
+// the truck from form fields.
+//
+// Columns are named like the JSON fields of an order, in any case and with
+// spaces or hyphens for underscores, so "Payout Cents" is payout_cents:
+//
+//	id,payout_cents,weight_lbs,volume_cuft,origin,destination,pickup_date,delivery_date
+//	ord-001,250000,18000,1200,"Los Angeles, CA","Dallas, TX",2025-12-05,2025-12-09
+//
+// Empty cells leave a field unset. Numbers may use thousands separators,
+// flags take true/false, yes/no or 1/0, and lists such as
+// equipment_requirements separate their items with semicolons.
+package ordercsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"smart-load/internal/domain"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// field sets one field of a T from the text of a cell
+type field[T any] func(target *T, value string) error
+
+func textField[T any](get func(*T) *string) field[T] {
+	return func(target *T, value string) error {
+		*get(target) = value
+		return nil
+	}
+}
+
+func intField[T any](get func(*T) *int) field[T] {
+	return func(target *T, value string) error {
+		n, err := strconv.Atoi(strings.ReplaceAll(value, ",", ""))
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*get(target) = n
+		return nil
+	}
+}
+
+func int64Field[T any](get func(*T) *int64) field[T] {
+	return func(target *T, value string) error {
+		n, err := strconv.ParseInt(strings.ReplaceAll(value, ",", ""), 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", value)
+		}
+		*get(target) = n
+		return nil
+	}
+}
+
+func floatField[T any](get func(*T) *float64) field[T] {
+	return func(target *T, value string) error {
+		f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*get(target) = f
+		return nil
+	}
+}
+
+// optionalFloatField is a float that stays nil when its cell is empty
+func optionalFloatField[T any](get func(*T) **float64) field[T] {
+	return func(target *T, value string) error {
+		f, err := strconv.ParseFloat(strings.ReplaceAll(value, ",", ""), 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		*get(target) = &f
+		return nil
+	}
+}
+
+func boolField[T any](get func(*T) *bool) field[T] {
+	return func(target *T, value string) error {
+		switch strings.ToLower(value) {
+		case "true", "yes", "y", "1":
+			*get(target) = true
+		case "false", "no", "n", "0":
+			*get(target) = false
+		default:
+			return fmt.Errorf("%q is not true or false", value)
+		}
+		return nil
+	}
+}
+
+func listField[T any](get func(*T) *[]string) field[T] {
+	return func(target *T, value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ";") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*get(target) = items
+		return nil
+	}
+}
+
+// orderColumns maps column names to the order fields they set. order_id and
+// hazmat are accepted for id and is_hazmat, as spreadsheets often name them.
+var orderColumns = map[string]field[domain.OrderInput]{
+	"id":                     textField(func(o *domain.OrderInput) *string { return &o.ID }),
+	"order_id":               textField(func(o *domain.OrderInput) *string { return &o.ID }),
+	"payout_cents":           int64Field(func(o *domain.OrderInput) *int64 { return &o.PayoutCents }),
+	"weight_lbs":             intField(func(o *domain.OrderInput) *int { return &o.WeightLbs }),
+	"volume_cuft":            intField(func(o *domain.OrderInput) *int { return &o.VolumeCuft }),
+	"linear_feet":            intField(func(o *domain.OrderInput) *int { return &o.LinearFeet }),
+	"origin":                 textField(func(o *domain.OrderInput) *string { return &o.Origin }),
+	"destination":            textField(func(o *domain.OrderInput) *string { return &o.Destination }),
+	"pickup_date":            textField(func(o *domain.OrderInput) *string { return &o.PickupDate }),
+	"delivery_date":          textField(func(o *domain.OrderInput) *string { return &o.DeliveryDate }),
+	"is_hazmat":              boolField(func(o *domain.OrderInput) *bool { return &o.IsHazmat }),
+	"hazmat":                 boolField(func(o *domain.OrderInput) *bool { return &o.IsHazmat }),
+	"hazmat_class":           textField(func(o *domain.OrderInput) *string { return &o.HazmatClass }),
+	"miles":                  intField(func(o *domain.OrderInput) *int { return &o.Miles }),
+	"shipper":                textField(func(o *domain.OrderInput) *string { return &o.Shipper }),
+	"pickup_window_start":    textField(func(o *domain.OrderInput) *string { return &o.PickupWindowStart }),
+	"pickup_window_end":      textField(func(o *domain.OrderInput) *string { return &o.PickupWindowEnd }),
+	"delivery_window_start":  textField(func(o *domain.OrderInput) *string { return &o.DeliveryWindowStart }),
+	"delivery_window_end":    textField(func(o *domain.OrderInput) *string { return &o.DeliveryWindowEnd }),
+	"commodity_type":         textField(func(o *domain.OrderInput) *string { return &o.CommodityType }),
+	"temperature_min_f":      optionalFloatField(func(o *domain.OrderInput) **float64 { return &o.TemperatureMinF }),
+	"temperature_max_f":      optionalFloatField(func(o *domain.OrderInput) **float64 { return &o.TemperatureMaxF }),
+	"equipment_requirements": listField(func(o *domain.OrderInput) *[]string { return &o.EquipmentRequirements }),
+	"length_in":              intField(func(o *domain.OrderInput) *int { return &o.LengthIn }),
+	"width_in":               intField(func(o *domain.OrderInput) *int { return &o.WidthIn }),
+	"height_in":              intField(func(o *domain.OrderInput) *int { return &o.HeightIn }),
+	"pallet_count":           intField(func(o *domain.OrderInput) *int { return &o.PalletCount }),
+	"stackable":              boolField(func(o *domain.OrderInput) *bool { return &o.Stackable }),
+	"exclusive_group":        textField(func(o *domain.OrderInput) *string { return &o.ExclusiveGroup }),
+	"splittable":             boolField(func(o *domain.OrderInput) *bool { return &o.Splittable }),
+	"priority":               intField(func(o *domain.OrderInput) *int { return &o.Priority }),
+	"max_exposure_hours":     floatField(func(o *domain.OrderInput) *float64 { return &o.MaxExposureHours }),
+}
+
+// truckFields maps form field names to the truck fields they set. The
+// truck's id is truck_id, to tell it from the orders'.
+var truckFields = map[string]field[domain.TruckInput]{
+	"truck_id":             textField(func(t *domain.TruckInput) *string { return &t.ID }),
+	"max_weight_lbs":       intField(func(t *domain.TruckInput) *int { return &t.MaxWeightLbs }),
+	"max_volume_cuft":      intField(func(t *domain.TruckInput) *int { return &t.MaxVolumeCuft }),
+	"fixed_cost_cents":     int64Field(func(t *domain.TruckInput) *int64 { return &t.FixedCostCents }),
+	"cost_per_mile_cents":  int64Field(func(t *domain.TruckInput) *int64 { return &t.CostPerMileCents }),
+	"stop_fee_cents":       int64Field(func(t *domain.TruckInput) *int64 { return &t.StopFeeCents }),
+	"max_orders":           intField(func(t *domain.TruckInput) *int { return &t.MaxOrders }),
+	"max_linear_feet":      intField(func(t *domain.TruckInput) *int { return &t.MaxLinearFeet }),
+	"max_pallet_positions": intField(func(t *domain.TruckInput) *int { return &t.MaxPalletPositions }),
+	"equipment_type":       textField(func(t *domain.TruckInput) *string { return &t.EquipmentType }),
+	"equipment":            listField(func(t *domain.TruckInput) *[]string { return &t.Equipment }),
+	"interior_length_in":   intField(func(t *domain.TruckInput) *int { return &t.InteriorLengthIn }),
+	"interior_width_in":    intField(func(t *domain.TruckInput) *int { return &t.InteriorWidthIn }),
+	"interior_height_in":   intField(func(t *domain.TruckInput) *int { return &t.InteriorHeightIn }),
+}
+
+// columnName normalizes a header cell: "Payout Cents" is payout_cents
+func columnName(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(header)
+}
+
+// Parse reads the orders of a CSV file, one per row after the header.
+// Under strict parsing, columns that map to no order field are rejected;
+// otherwise they are ignored. Reading stops once the rows pass maxOrders.
+func Parse(r io.Reader, strict bool, maxOrders int) ([]domain.OrderInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV has no header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	setters := make([]field[domain.OrderInput], len(header))
+	names := make([]string, len(header))
+	seen := make(map[string]bool, len(header))
+	for i, cell := range header {
+		if i == 0 {
+			// Spreadsheets often save UTF-8 with a byte order mark
+			cell = strings.TrimPrefix(cell, "\ufeff")
+		}
+		names[i] = columnName(cell)
+		setter, ok := orderColumns[names[i]]
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("column %q maps to no order field", cell)
+			}
+			continue
+		}
+		if seen[names[i]] {
+			return nil, fmt.Errorf("column %q is listed twice", cell)
+		}
+		seen[names[i]] = true
+		setters[i] = setter
+	}
+	if !seen["id"] && !seen["order_id"] {
+		return nil, fmt.Errorf("CSV has no id column")
+	}
+	
+	var orders []domain.OrderInput
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return orders, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		if len(orders) == maxOrders {
+			return nil, fmt.Errorf("orders list cannot exceed %d items", maxOrders)
+		}
+		
+		var order domain.OrderInput
+		for i, cell := range row {
+			cell = strings.TrimSpace(cell)
+			if setters[i] == nil || cell == "" {
+				continue
+			}
+			if err := setters[i](&order, cell); err != nil {
+				return nil, fmt.Errorf("line %d, column %s: %v", line, names[i], err)
+			}
+		}
+		orders = append(orders, order)
+	}
+}
+
+// ParseTruck builds a truck from form fields; value returns a field's
+// value, empty when it was not sent
+func ParseTruck(value func(name string) string) (domain.TruckInput, error) {
+	names := make([]string, 0, len(truckFields))
+	for name := range truckFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	
+	var truck domain.TruckInput
+	for _, name := range names {
+		text := strings.TrimSpace(value(name))
+		if text == "" {
+			continue
+		}
+		if err := truckFields[name](&truck, text); err != nil {
+			return domain.TruckInput{}, fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return truck, nil
+}
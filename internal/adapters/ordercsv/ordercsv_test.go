@@ -0,0 +1,78 @@
+package ordercsv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	csv := "\ufeffOrder ID,Payout Cents,weight-lbs,volume_cuft,Origin,Destination,pickup_date,delivery_date,hazmat,temperature_min_f,equipment_requirements,notes\n" +
+		`ord-001,"250,000",18000,1200,"Los Angeles, CA","Dallas, TX",2025-12-05,2025-12-09,no,34.5,reefer; liftgate,call ahead` + "\n" +
+		`ord-002,180000,12000,900,"Los Angeles, CA","Dallas, TX",2025-12-04,2025-12-10,yes,,,` + "\n"
+	orders, err := Parse(strings.NewReader(csv), false, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	low := 34.5
+	want := []domain.OrderInput{
+		{
+			ID: "ord-001", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: "2025-12-05", DeliveryDate: "2025-12-09",
+			TemperatureMinF: &low, EquipmentRequirements: []string{"reefer", "liftgate"},
+		},
+		{
+			ID: "ord-002", PayoutCents: 180000, WeightLbs: 12000, VolumeCuft: 900,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: "2025-12-04", DeliveryDate: "2025-12-10",
+			IsHazmat: true,
+		},
+	}
+	if !reflect.DeepEqual(orders, want) {
+		t.Fatalf("orders = %+v\nwant %+v", orders, want)
+	}
+	
+	// Strict parsing rejects the notes column no order field takes
+	if _, err := Parse(strings.NewReader(csv), true, 10); err == nil || !strings.Contains(err.Error(), "notes") {
+		t.Fatalf("strict parse err = %v, want the notes column rejected", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, test := range map[string]struct {
+		csv, want string
+	}{
+		"empty":       {"", "no header row"},
+		"no id":       {"payout_cents\n100\n", "no id column"},
+		"twice":       {"id,weight_lbs,Weight Lbs\na,1,2\n", "listed twice"},
+		"bad number":  {"id,weight_lbs\na,1\nb,heavy\n", "line 3, column weight_lbs"},
+		"bad flag":    {"id,is_hazmat\na,maybe\n", "not true or false"},
+		"ragged row":  {"id,weight_lbs\na\n", "wrong number of fields"},
+		"many orders": {"id\na\nb\nc\n", "cannot exceed 2"},
+	} {
+		if _, err := Parse(strings.NewReader(test.csv), false, 2); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: err = %v, want %q", name, err, test.want)
+		}
+	}
+}
+
+func TestParseTruck(t *testing.T) {
+	form := map[string]string{
+		"truck_id": "truck-1", "max_weight_lbs": "44,000", "max_volume_cuft": "3000",
+		"equipment": "reefer;liftgate", "id": "ignored",
+	}
+	truck, err := ParseTruck(func(name string) string { return form[name] })
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domain.TruckInput{ID: "truck-1", MaxWeightLbs: 44000, MaxVolumeCuft: 3000, Equipment: []string{"reefer", "liftgate"}}
+	if !reflect.DeepEqual(truck, want) {
+		t.Fatalf("truck = %+v, want %+v", truck, want)
+	}
+	
+	form["max_orders"] = "several"
+	if _, err := ParseTruck(func(name string) string { return form[name] }); err == nil || !strings.HasPrefix(err.Error(), "max_orders:") {
+		t.Fatalf("err = %v, want max_orders rejected", err)
+	}
+}
@@ -0,0 +1,230 @@
+// Package tmsxml maps the legacy TMS XML tender format onto the optimizer's
+// request and response types.
+//
+// A tender looks like:
+//
+//	<Tender>
+//	  <Truck id="truck-123" maxWeightLbs="44000" maxVolumeCuft="3000"/>
+//	  <Orders>
+//	    <Order id="ord-001" hazmat="false">
+//	      <PayoutCents>250000</PayoutCents>
+//	      <WeightLbs>18000</WeightLbs>
+//	      <VolumeCuft>1200</VolumeCuft>
+//	      <Origin>Los Angeles, CA</Origin>
+//	      <Destination>Dallas, TX</Destination>
+//	      <PickupDate>2025-12-05</PickupDate>
+//	      <DeliveryDate>2025-12-09</DeliveryDate>
+//	    </Order>
+//	  </Orders>
+//	</Tender>
+package tmsxml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"smart-load/internal/domain"
+)
+
+type Tender struct {
+	XMLName xml.Name `xml:"Tender"`
+	Truck   Truck    `xml:"Truck"`
+	Orders  []Order  `xml:"Orders>Order"`
+}
+
+type Truck struct {
+	ID             string `xml:"id,attr"`
+	MaxWeightLbs   int    `xml:"maxWeightLbs,attr"`
+	MaxVolumeCuft  int    `xml:"maxVolumeCuft,attr"`
+	FixedCostCents int64  `xml:"fixedCostCents,attr,omitempty"`
+	MaxOrders      int    `xml:"maxOrders,attr,omitempty"`
+	MaxLinearFeet  int    `xml:"maxLinearFeet,attr,omitempty"`
+	// MaxPalletPositions maps to max_pallet_positions
+	MaxPalletPositions int `xml:"maxPalletPositions,attr,omitempty"`
+	// EquipmentType maps to equipment_type
+	EquipmentType string `xml:"equipmentType,attr,omitempty"`
+	// Equipment maps to equipment, one element per item
+	Equipment []string `xml:"Equipment,omitempty"`
+	// InteriorLengthIn, InteriorWidthIn and InteriorHeightIn map to the
+	// interior_*_in fields
+	InteriorLengthIn int `xml:"interiorLengthIn,attr,omitempty"`
+	InteriorWidthIn  int `xml:"interiorWidthIn,attr,omitempty"`
+	InteriorHeightIn int `xml:"interiorHeightIn,attr,omitempty"`
+}
+
+type Order struct {
+	ID           string `xml:"id,attr"`
+	Hazmat       bool   `xml:"hazmat,attr"`
+	PayoutCents  int64  `xml:"PayoutCents"`
+	WeightLbs    int    `xml:"WeightLbs"`
+	VolumeCuft   int    `xml:"VolumeCuft"`
+	LinearFeet   int    `xml:"LinearFeet,omitempty"`
+	PalletCount  int    `xml:"PalletCount,omitempty"`
+	Stackable    bool   `xml:"stackable,attr,omitempty"`
+	Origin       string `xml:"Origin"`
+	Destination  string `xml:"Destination"`
+	PickupDate   string `xml:"PickupDate"`
+	DeliveryDate string `xml:"DeliveryDate"`
+	Shipper      string `xml:"Shipper,omitempty"`
+	// PickupWindow and DeliveryWindow map to the pickup_window_* and
+	// delivery_window_* fields
+	PickupWindow   *Window `xml:"PickupWindow,omitempty"`
+	DeliveryWindow *Window `xml:"DeliveryWindow,omitempty"`
+	// HazmatClass maps to hazmat_class
+	HazmatClass string `xml:"hazmatClass,attr,omitempty"`
+	// CommodityType maps to commodity_type
+	CommodityType string `xml:"commodityType,attr,omitempty"`
+	// TemperatureMinF and TemperatureMaxF map to temperature_min_f and
+	// temperature_max_f
+	TemperatureMinF *float64 `xml:"temperatureMinF,attr,omitempty"`
+	TemperatureMaxF *float64 `xml:"temperatureMaxF,attr,omitempty"`
+	// EquipmentRequirements maps to equipment_requirements, one element per item
+	EquipmentRequirements []string `xml:"EquipmentRequirement,omitempty"`
+	// LengthIn, WidthIn and HeightIn map to length_in, width_in and height_in
+	LengthIn int `xml:"lengthIn,attr,omitempty"`
+	WidthIn  int `xml:"widthIn,attr,omitempty"`
+	HeightIn int `xml:"heightIn,attr,omitempty"`
+	// ExclusiveGroup maps to exclusive_group
+	ExclusiveGroup string `xml:"exclusiveGroup,attr,omitempty"`
+	Splittable     bool   `xml:"splittable,attr,omitempty"`
+	Priority       int    `xml:"priority,attr,omitempty"`
+}
+
+// Window is a time window with ISO 8601 start and end attributes
+type Window struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+// bounds returns the window's start and end, both empty for a nil window
+func (w *Window) bounds() (string, string) {
+	if w == nil {
+		return "", ""
+	}
+	return w.Start, w.End
+}
+
+// Result is the XML form of an optimization response
+type Result struct {
+	XMLName                  xml.Name       `xml:"OptimizeResult"`
+	TruckID                  string         `xml:"truckId,attr"`
+	SolutionID               string         `xml:"solutionId,attr"`
+	SelectedOrderIDs         []string       `xml:"SelectedOrders>OrderId"`
+	TotalPayoutCents         int64          `xml:"TotalPayoutCents"`
+	TotalWeightLbs           int            `xml:"TotalWeightLbs"`
+	TotalVolumeCuft          int            `xml:"TotalVolumeCuft"`
+	UtilizationWeightPercent float64        `xml:"UtilizationWeightPercent"`
+	UtilizationVolumePercent float64        `xml:"UtilizationVolumePercent"`
+	NetProfitCents           int64          `xml:"NetProfitCents"`
+	Recommendation           string         `xml:"Recommendation"`
+	PartialOrders            []PartialOrder `xml:"PartialOrders>PartialOrder,omitempty"`
+}
+
+// PartialOrder is the XML form of domain.PartialOrder
+type PartialOrder struct {
+	OrderID     string  `xml:"orderId,attr"`
+	Fraction    float64 `xml:"fraction,attr"`
+	WeightLbs   int     `xml:"WeightLbs"`
+	VolumeCuft  int     `xml:"VolumeCuft"`
+	PayoutCents int64   `xml:"PayoutCents"`
+}
+
+// Error is the XML form of an error response
+type Error struct {
+	XMLName xml.Name `xml:"Error"`
+	Code    int      `xml:"code,attr"`
+	Message string   `xml:",chardata"`
+}
+
+// Parse decodes a tender document
+func Parse(data []byte) (*Tender, error) {
+	var tender Tender
+	if err := xml.Unmarshal(data, &tender); err != nil {
+		return nil, fmt.Errorf("invalid tender XML: %w", err)
+	}
+	return &tender, nil
+}
+
+// ToRequest converts a tender into an optimize request
+func (t *Tender) ToRequest() domain.OptimizeRequest {
+	orders := make([]domain.OrderInput, len(t.Orders))
+	for i, o := range t.Orders {
+		pickupStart, pickupEnd := o.PickupWindow.bounds()
+		deliveryStart, deliveryEnd := o.DeliveryWindow.bounds()
+		orders[i] = domain.OrderInput{
+			ID:                    o.ID,
+			PayoutCents:           o.PayoutCents,
+			WeightLbs:             o.WeightLbs,
+			VolumeCuft:            o.VolumeCuft,
+			LinearFeet:            o.LinearFeet,
+			PalletCount:           o.PalletCount,
+			Stackable:             o.Stackable,
+			Origin:                o.Origin,
+			Destination:           o.Destination,
+			PickupDate:            o.PickupDate,
+			DeliveryDate:          o.DeliveryDate,
+			PickupWindowStart:     pickupStart,
+			PickupWindowEnd:       pickupEnd,
+			DeliveryWindowStart:   deliveryStart,
+			DeliveryWindowEnd:     deliveryEnd,
+			IsHazmat:              o.Hazmat,
+			HazmatClass:           o.HazmatClass,
+			CommodityType:         o.CommodityType,
+			TemperatureMinF:       o.TemperatureMinF,
+			TemperatureMaxF:       o.TemperatureMaxF,
+			EquipmentRequirements: o.EquipmentRequirements,
+			LengthIn:              o.LengthIn,
+			WidthIn:               o.WidthIn,
+			HeightIn:              o.HeightIn,
+			Shipper:               o.Shipper,
+			ExclusiveGroup:        o.ExclusiveGroup,
+			Splittable:            o.Splittable,
+			Priority:              o.Priority,
+		}
+	}
+	
+	return domain.OptimizeRequest{
+		Truck: domain.TruckInput{
+			ID:                 t.Truck.ID,
+			MaxWeightLbs:       t.Truck.MaxWeightLbs,
+			MaxVolumeCuft:      t.Truck.MaxVolumeCuft,
+			FixedCostCents:     t.Truck.FixedCostCents,
+			MaxOrders:          t.Truck.MaxOrders,
+			MaxLinearFeet:      t.Truck.MaxLinearFeet,
+			MaxPalletPositions: t.Truck.MaxPalletPositions,
+			EquipmentType:      t.Truck.EquipmentType,
+			Equipment:          t.Truck.Equipment,
+			InteriorLengthIn:   t.Truck.InteriorLengthIn,
+			InteriorWidthIn:    t.Truck.InteriorWidthIn,
+			InteriorHeightIn:   t.Truck.InteriorHeightIn,
+		},
+		Orders: orders,
+	}
+}
+
+// FromResponse converts an optimize response into its XML form
+func FromResponse(response *domain.OptimizeResponse) Result {
+	return Result{
+		TruckID:                  response.TruckID,
+		SolutionID:               response.SolutionID,
+		SelectedOrderIDs:         response.SelectedOrderIDs,
+		TotalPayoutCents:         response.TotalPayoutCents,
+		TotalWeightLbs:           response.TotalWeightLbs,
+		TotalVolumeCuft:          response.TotalVolumeCuft,
+		UtilizationWeightPercent: response.UtilizationWeightPercent,
+		UtilizationVolumePercent: response.UtilizationVolumePercent,
+		NetProfitCents:           response.NetProfitCents,
+		Recommendation:           response.Recommendation,
+		PartialOrders:            partialOrders(response.PartialOrders),
+	}
+}
+
+func partialOrders(parts []domain.PartialOrder) []PartialOrder {
+	if len(parts) == 0 {
+		return nil
+	}
+	converted := make([]PartialOrder, len(parts))
+	for i, part := range parts {
+		converted[i] = PartialOrder(part)
+	}
+	return converted
+}
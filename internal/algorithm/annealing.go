@@ -0,0 +1,162 @@
+package algorithm
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// SimulatedAnnealingOptimizer improves a greedy start with simulated
+// annealing. Each iteration tries one random move (add an order, drop one, or
+// swap one in for one out) and always takes it when the score does not drop;
+// a worse move is taken with probability exp(delta/temperature), so early on
+// the search wanders out of local optima and as the temperature cools it
+// settles into the best region found. Like tabu search it works one
+// compatibility class at a time and returns the best plan seen.
+type SimulatedAnnealingOptimizer struct {
+	greedy  *GreedyOptimizer
+	checker domain.ConstraintChecker
+	// iterations is the number of moves tried per class
+	iterations int
+	// startTemperature and endTemperature are shares of the class's mean
+	// order score; the temperature cools geometrically between them
+	startTemperature float64
+	endTemperature   float64
+	seed             int64
+}
+
+func NewSimulatedAnnealingOptimizer() *SimulatedAnnealingOptimizer {
+	return &SimulatedAnnealingOptimizer{
+		greedy:           NewGreedyOptimizer(),
+		checker:          domain.NewConstraintChecker(),
+		iterations:       20000,
+		startTemperature: 0.5,
+		endTemperature:   0.001,
+		seed:             1,
+	}
+}
+
+func (a *SimulatedAnnealingOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *a
+	constrained.checker = checker
+	constrained.greedy = &GreedyOptimizer{checker: checker}
+	return &constrained
+}
+
+func (a *SimulatedAnnealingOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	result := summarize([]domain.Order{})
+	progress := newProgressReporter(ctx, "annealing")
+	// Every class gets at least its greedy start, so a plan is ready even
+	// when ctx is done
+	for _, class := range compatibilityClasses(a.checker, orders) {
+		start := a.greedy.Optimize(ctx, truck, class)
+		if annealed := summarize(a.anneal(ctx, truck, class, start.SelectedOrders, progress)); annealed.TotalScore > result.TotalScore {
+			result = annealed
+		}
+	}
+	progress.finish()
+	result.Algorithm = "annealing"
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+// anneal runs simulated annealing over one compatibility class from start
+// and returns the best selection seen
+func (a *SimulatedAnnealingOptimizer) anneal(
+	ctx context.Context,
+	truck domain.Truck,
+	orders []domain.Order,
+	start []domain.Order,
+	progress *progressReporter,
+) []domain.Order {
+	n := len(orders)
+	if n == 0 {
+		return start
+	}
+	
+	chosen := make([]bool, n)
+	index := make(map[string]int, n)
+	mean := 0.0
+	for i, order := range orders {
+		index[order.ID] = i
+		mean += float64(order.Score) / float64(n)
+	}
+	weight, volume, count := 0, 0, 0
+	score := domain.Score(0)
+	for _, order := range start {
+		count++
+		chosen[index[order.ID]] = true
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+		score += order.Score
+	}
+	best := append([]bool(nil), chosen...)
+	bestScore := score
+	selected := func() []domain.Order { return chosenOrders(orders, best) }
+	progress.improved(bestScore, selected)
+	
+	temperature := math.Max(a.startTemperature*mean, 1)
+	cooling := math.Pow(a.endTemperature/a.startTemperature, 1/float64(a.iterations))
+	r := rand.New(rand.NewSource(a.seed))
+	
+	for iteration := 0; iteration < a.iterations; iteration, temperature = iteration+1, temperature*cooling {
+		if iteration%cancelCheckInterval == 0 && ctx.Err() != nil {
+			break
+		}
+		progress.visit()
+		
+		in, out := -1, -1
+		if i := r.Intn(n); chosen[i] {
+			out = i
+		} else {
+			in = i
+			// Half the time pair the addition with a removal
+			if j := r.Intn(n); chosen[j] && r.Intn(2) == 0 {
+				out = j
+			}
+		}
+		
+		delta := domain.Score(0)
+		dw, dv, dc := 0, 0, 0
+		if in >= 0 {
+			delta += orders[in].Score
+			dw += orders[in].WeightLbs
+			dv += orders[in].VolumeCuft
+			dc++
+		}
+		if out >= 0 {
+			delta -= orders[out].Score
+			dw -= orders[out].WeightLbs
+			dv -= orders[out].VolumeCuft
+			dc--
+		}
+		if weight+dw > truck.MaxWeightLbs || volume+dv > truck.MaxVolumeCuft {
+			continue
+		}
+		if dc > 0 && !truck.FitsMoreOrders(count) {
+			continue
+		}
+		if delta < 0 && r.Float64() >= math.Exp(float64(delta)/temperature) {
+			continue
+		}
+		
+		for _, i := range []int{in, out} {
+			if i >= 0 {
+				chosen[i] = !chosen[i]
+			}
+		}
+		weight, volume, count = weight+dw, volume+dv, count+dc
+		score += delta
+		if score > bestScore {
+			bestScore = score
+			copy(best, chosen)
+			progress.improved(bestScore, selected)
+		}
+	}
+	return selected()
+}
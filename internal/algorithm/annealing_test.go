@@ -0,0 +1,32 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestSimulatedAnnealingMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewSimulatedAnnealingOptimizer(), 200)
+}
+
+func TestSimulatedAnnealingNeverWorsensGreedy(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(24))
+	
+	var greedyTotal, annealedTotal int64
+	for i := 0; i < 50; i++ {
+		orders := randomOrders(r, 10+r.Intn(80))
+		greedy := NewGreedyOptimizer().Optimize(ctx, testTruck, orders)
+		annealed := NewSimulatedAnnealingOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, annealed)
+		if annealed.TotalScore < greedy.TotalScore {
+			t.Fatalf("instance %d: annealing score %d is below greedy %d", i, annealed.TotalScore, greedy.TotalScore)
+		}
+		greedyTotal += int64(greedy.TotalScore)
+		annealedTotal += int64(annealed.TotalScore)
+	}
+	if annealedTotal <= greedyTotal {
+		t.Errorf("simulated annealing never improved on greedy (%d vs %d)", annealedTotal, greedyTotal)
+	}
+}
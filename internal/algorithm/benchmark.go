@@ -0,0 +1,64 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// BenchmarkTruck is the truck benchmark instances are generated for
+var BenchmarkTruck = domain.Truck{ID: "benchmark", MaxWeightLbs: 44000, MaxVolumeCuft: 3000}
+
+// BenchmarkOrders generates n typical orders for BenchmarkTruck: two lanes
+// out of one origin, one in five hazmat, each filling roughly 2-30% of the
+// truck. The same seed always gives the same orders.
+func BenchmarkOrders(seed int64, n int) []domain.Order {
+	r := rand.New(rand.NewSource(seed))
+	orders := make([]domain.Order, n)
+	for i := range orders {
+		payout := domain.Money(1000 + r.Intn(300000))
+		orders[i] = domain.Order{
+			ID:          fmt.Sprintf("bench-%d", i),
+			Payout:      payout,
+			Score:       domain.ScoreFromMoney(payout),
+			WeightLbs:   1000 + r.Intn(12000),
+			VolumeCuft:  50 + r.Intn(900),
+			Origin:      "Los Angeles, CA",
+			Destination: []string{"Dallas, TX", "Phoenix, AZ"}[r.Intn(2)],
+			IsHazmat:    r.Intn(5) == 0,
+		}
+	}
+	return orders
+}
+
+// Benchmark measures how an optimizer's compute time grows with the number
+// of orders. Each size is solved runs times on different BenchmarkOrders
+// instances and the median wall time kept. Sizes are measured in order until
+// one cannot finish before ctx is done, so the curve covers the sizes the
+// optimizer handles within the caller's budget.
+func Benchmark(ctx context.Context, optimizer Optimizer, sizes []int, runs int) []domain.RuntimePoint {
+	curve := make([]domain.RuntimePoint, 0, len(sizes))
+	for _, size := range sizes {
+		times := make([]time.Duration, 0, runs)
+		for run := 0; run < runs; run++ {
+			orders := BenchmarkOrders(int64(size*runs+run), size)
+			startTime := time.Now()
+			optimizer.Optimize(ctx, BenchmarkTruck, orders)
+			if ctx.Err() != nil {
+				// Cut short, so the time says nothing about this size
+				return curve
+			}
+			times = append(times, time.Since(startTime))
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+		
+		curve = append(curve, domain.RuntimePoint{
+			Orders:   size,
+			MedianMs: float64(times[len(times)/2].Microseconds()) / 1000,
+		})
+	}
+	return curve
+}
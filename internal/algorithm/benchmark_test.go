@@ -0,0 +1,34 @@
+package algorithm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestBenchmarkMeasuresEverySize(t *testing.T) {
+	sizes := []int{5, 10, 20}
+	curve := Benchmark(context.Background(), NewGreedyOptimizer(), sizes, 3)
+	if len(curve) != len(sizes) {
+		t.Fatalf("curve %v, want a point per size %v", curve, sizes)
+	}
+	for i, point := range curve {
+		if point.Orders != sizes[i] || point.MedianMs < 0 {
+			t.Fatalf("point %d is %+v", i, point)
+		}
+	}
+}
+
+func TestBenchmarkStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if curve := Benchmark(ctx, NewDPOptimizer(), []int{5, 10}, 3); len(curve) != 0 {
+		t.Fatalf("cancelled benchmark measured %v", curve)
+	}
+}
+
+func TestBenchmarkOrdersAreReproducible(t *testing.T) {
+	if !reflect.DeepEqual(BenchmarkOrders(7, 50), BenchmarkOrders(7, 50)) {
+		t.Fatal("same seed generated different orders")
+	}
+}
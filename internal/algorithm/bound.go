@@ -0,0 +1,52 @@
+package algorithm
+
+import (
+	"smart-load/internal/domain"
+	"sort"
+)
+
+// PayoutBound is an upper bound on the payout of any plan for the truck:
+// the tighter of the fractional knapsacks over weight and over volume, and
+// under a max_orders cap the largest payouts that fit in it. A heuristic
+// plan's payout is within PayoutBound minus its payout of the optimum.
+func PayoutBound(truck domain.Truck, orders []domain.Order) domain.Money {
+	bound := min(
+		fractionalPayout(orders, truck.MaxWeightLbs, func(o domain.Order) int { return o.WeightLbs }),
+		fractionalPayout(orders, truck.MaxVolumeCuft, func(o domain.Order) int { return o.VolumeCuft }),
+	)
+	if truck.MaxOrders > 0 && truck.MaxOrders < len(orders) {
+		payouts := make([]domain.Money, len(orders))
+		for i, order := range orders {
+			payouts[i] = order.Payout
+		}
+		sort.Slice(payouts, func(i, j int) bool { return payouts[i] > payouts[j] })
+		var best domain.Money
+		for _, payout := range payouts[:truck.MaxOrders] {
+			best += payout
+		}
+		bound = min(bound, best)
+	}
+	return bound
+}
+
+// fractionalPayout solves the fractional knapsack over one capacity
+func fractionalPayout(orders []domain.Order, capacity int, size func(domain.Order) int) domain.Money {
+	byDensity := append([]domain.Order(nil), orders...)
+	sort.Slice(byDensity, func(i, j int) bool {
+		return float64(byDensity[i].Payout)/float64(size(byDensity[i])) >
+			float64(byDensity[j].Payout)/float64(size(byDensity[j]))
+	})
+	
+	var bound float64
+	for _, order := range byDensity {
+		if size(order) <= capacity {
+			capacity -= size(order)
+			bound += float64(order.Payout)
+			continue
+		}
+		bound += float64(order.Payout) * float64(capacity) / float64(size(order))
+		break
+	}
+	// Round up so floating point error never puts the bound below the optimum
+	return domain.Money(bound) + 1
+}
@@ -0,0 +1,53 @@
+package algorithm
+
+import (
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestPayoutBoundIsAnUpperBound(t *testing.T) {
+	r := rand.New(rand.NewSource(53))
+	
+	for trial := 0; trial < 50; trial++ {
+		truck := testTruck
+		if trial%2 == 1 {
+			truck.MaxOrders = 1 + r.Intn(3)
+		}
+		orders := randomOrders(r, 2+r.Intn(10))
+		
+		bound := PayoutBound(truck, orders)
+		if best := bestPayout(truck, orders); bound < best {
+			t.Fatalf("trial %d: bound %d below the optimum %d", trial, bound, best)
+		}
+		var total domain.Money
+		for _, order := range orders {
+			total += order.Payout
+		}
+		if bound > total+1 {
+			t.Fatalf("trial %d: bound %d above the total payout %d", trial, bound, total)
+		}
+	}
+}
+
+// bestPayout is the largest payout of any plan within the truck's capacity
+// and order cap, by brute force
+func bestPayout(truck domain.Truck, orders []domain.Order) domain.Money {
+	var best domain.Money
+	for mask := 1; mask < 1<<len(orders); mask++ {
+		var payout domain.Money
+		weight, volume, count := 0, 0, 0
+		for i, order := range orders {
+			if mask&(1<<i) != 0 {
+				payout += order.Payout
+				weight += order.WeightLbs
+				volume += order.VolumeCuft
+				count++
+			}
+		}
+		if weight <= truck.MaxWeightLbs && volume <= truck.MaxVolumeCuft && (truck.MaxOrders == 0 || count <= truck.MaxOrders) {
+			best = max(best, payout)
+		}
+	}
+	return best
+}
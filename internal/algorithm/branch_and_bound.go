@@ -0,0 +1,238 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// BranchAndBoundOptimizer is an exact depth-first search that prunes with the
+// LP relaxation of the remaining problem. Relaxing either capacity alone gives a
+// fractional knapsack whose greedy solution bounds the true optimum, so the
+// tighter of the weight and volume bounds is used at every node. Under a
+// max_orders cap the best scores that fit in the remaining slots bound it too.
+// Orders of a component that conflict are never chosen together; the bounds
+// ignore conflicts, so they stay valid.
+type BranchAndBoundOptimizer struct {
+	checker domain.ConstraintChecker
+}
+
+func NewBranchAndBoundOptimizer() *BranchAndBoundOptimizer {
+	return &BranchAndBoundOptimizer{
+		checker: domain.NewConstraintChecker(),
+	}
+}
+
+func (o *BranchAndBoundOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+// bbSearch holds the state of one branch-and-bound run over a compatibility
+// component
+type bbSearch struct {
+	ctx    context.Context
+	truck  domain.Truck
+	orders []domain.Order
+	// conflicts[i] lists the orders that orders[i] cannot be combined with
+	conflicts [][]int
+	// byWeightDensity and byVolumeDensity index orders by payout per unit, best first
+	byWeightDensity []int
+	byVolumeDensity []int
+	// scorePrefix[i] sums the scores of orders[:i], which are sorted by score
+	scorePrefix []int64
+	
+	chosen     []bool
+	bestPayout int64
+	bestChosen []bool
+	nodes      int
+	cancelled  bool
+	progress   *progressReporter
+}
+
+func (bb *BranchAndBoundOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	
+	result := OptimizationResult{
+		SelectedOrders: []domain.Order{},
+		Algorithm:      "branch_and_bound",
+		Optimal:        true,
+	}
+	
+	progress := newProgressReporter(ctx, result.Algorithm)
+	for _, component := range compatibilityComponents(bb.checker, orders) {
+		search := newBBSearch(ctx, truck, bb.checker, component)
+		search.progress = progress
+		search.seedWithGreedy()
+		progress.improved(domain.Score(search.bestPayout), search.selected)
+		search.branch(0, 0, 0, 0, 0)
+		if search.cancelled {
+			result.Optimal = false
+		}
+		
+		if domain.Score(search.bestPayout) > result.TotalScore {
+			result.TotalScore = domain.Score(search.bestPayout)
+			result.SelectedOrders = search.selected()
+		}
+	}
+	progress.finish()
+	
+	result.TotalPayout = totalPayout(result.SelectedOrders)
+	for _, order := range result.SelectedOrders {
+		result.TotalWeight += order.WeightLbs
+		result.TotalVolume += order.VolumeCuft
+	}
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+func newBBSearch(ctx context.Context, truck domain.Truck, checker domain.ConstraintChecker, orders []domain.Order) *bbSearch {
+	// Branch on the most valuable orders first so good incumbents appear early
+	sorted := make([]domain.Order, len(orders))
+	copy(sorted, orders)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+	
+	search := &bbSearch{
+		ctx:             ctx,
+		truck:           truck,
+		orders:          sorted,
+		byWeightDensity: densityOrder(sorted, func(o domain.Order) int { return o.WeightLbs }),
+		byVolumeDensity: densityOrder(sorted, func(o domain.Order) int { return o.VolumeCuft }),
+		scorePrefix:     make([]int64, len(sorted)+1),
+		conflicts:       make([][]int, len(sorted)),
+		chosen:          make([]bool, len(sorted)),
+		bestChosen:      make([]bool, len(sorted)),
+	}
+	for i, order := range sorted {
+		search.scorePrefix[i+1] = search.scorePrefix[i] + int64(order.Score)
+		for j := range sorted[:i] {
+			if !checker.CanCombine(order, sorted[j]) {
+				search.conflicts[i] = append(search.conflicts[i], j)
+				search.conflicts[j] = append(search.conflicts[j], i)
+			}
+		}
+	}
+	return search
+}
+
+// conflictsWith reports whether orders[index] conflicts with an order in chosen
+func (s *bbSearch) conflictsWith(index int, chosen []bool) bool {
+	for _, j := range s.conflicts[index] {
+		if chosen[j] {
+			return true
+		}
+	}
+	return false
+}
+
+func densityOrder(orders []domain.Order, size func(domain.Order) int) []int {
+	indexes := make([]int, len(orders))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return float64(orders[indexes[a]].Score)/float64(size(orders[indexes[a]])) >
+			float64(orders[indexes[b]].Score)/float64(size(orders[indexes[b]]))
+	})
+	return indexes
+}
+
+// seedWithGreedy starts from the density-greedy plan so pruning bites immediately
+func (s *bbSearch) seedWithGreedy() {
+	weight, volume, count := 0, 0, 0
+	var payout int64
+	for _, i := range s.byWeightDensity {
+		order := s.orders[i]
+		if !s.truck.FitsMoreOrders(count) {
+			break
+		}
+		if weight+order.WeightLbs > s.truck.MaxWeightLbs || volume+order.VolumeCuft > s.truck.MaxVolumeCuft || s.conflictsWith(i, s.bestChosen) {
+			continue
+		}
+		count++
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+		payout += int64(order.Score)
+		s.bestChosen[i] = true
+	}
+	s.bestPayout = payout
+}
+
+func (s *bbSearch) branch(index int, payout int64, weight, volume, count int) {
+	s.nodes++
+	s.progress.visit()
+	if s.nodes%cancelCheckInterval == 0 && s.ctx.Err() != nil {
+		s.cancelled = true
+	}
+	if s.cancelled {
+		return
+	}
+	
+	if payout > s.bestPayout {
+		s.bestPayout = payout
+		copy(s.bestChosen, s.chosen)
+		s.progress.improved(domain.Score(payout), s.selected)
+	}
+	if index >= len(s.orders) {
+		return
+	}
+	
+	if !s.truck.FitsMoreOrders(count) || payout+s.upperBound(index, weight, volume, count) <= s.bestPayout {
+		return
+	}
+	
+	order := s.orders[index]
+	if weight+order.WeightLbs <= s.truck.MaxWeightLbs && volume+order.VolumeCuft <= s.truck.MaxVolumeCuft &&
+		!s.conflictsWith(index, s.chosen) {
+		s.chosen[index] = true
+		s.branch(index+1, payout+int64(order.Score), weight+order.WeightLbs, volume+order.VolumeCuft, count+1)
+		s.chosen[index] = false
+	}
+	
+	s.branch(index+1, payout, weight, volume, count)
+}
+
+// upperBound is the best payout any completion from index onward could add
+func (s *bbSearch) upperBound(index int, weight, volume, count int) int64 {
+	weightBound := s.fractionalBound(s.byWeightDensity, index, s.truck.MaxWeightLbs-weight,
+		func(o domain.Order) int { return o.WeightLbs })
+	volumeBound := s.fractionalBound(s.byVolumeDensity, index, s.truck.MaxVolumeCuft-volume,
+		func(o domain.Order) int { return o.VolumeCuft })
+	
+	bound := min(weightBound, volumeBound)
+	if s.truck.MaxOrders > 0 {
+		last := min(len(s.orders), index+s.truck.MaxOrders-count)
+		bound = min(bound, s.scorePrefix[last]-s.scorePrefix[index])
+	}
+	return bound
+}
+
+// fractionalBound solves the fractional knapsack over undecided orders for one capacity
+func (s *bbSearch) fractionalBound(byDensity []int, index int, capacity int, size func(domain.Order) int) int64 {
+	var bound float64
+	for _, i := range byDensity {
+		if i < index {
+			continue
+		}
+		order := s.orders[i]
+		if size(order) <= capacity {
+			capacity -= size(order)
+			bound += float64(order.Score)
+			continue
+		}
+		bound += float64(order.Score) * float64(capacity) / float64(size(order))
+		break
+	}
+	// Round up so floating point error can never prune the optimum
+	return int64(bound) + 1
+}
+
+func (s *bbSearch) selected() []domain.Order {
+	return chosenOrders(s.orders, s.bestChosen)
+}
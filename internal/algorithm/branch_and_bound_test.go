@@ -0,0 +1,36 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestBranchAndBoundMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewBranchAndBoundOptimizer(), 300)
+}
+
+func TestBranchAndBoundIsOptimal(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < 50; i++ {
+		result := NewBranchAndBoundOptimizer().Optimize(ctx, testTruck, randomOrders(r, r.Intn(19)))
+		if !result.Optimal {
+			t.Fatalf("instance %d: branch and bound did not finish", i)
+		}
+	}
+}
+
+func TestBranchAndBoundStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	
+	r := rand.New(rand.NewSource(5))
+	start := time.Now()
+	result := NewBranchAndBoundOptimizer().Optimize(ctx, testTruck, randomOrders(r, 1000))
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("search ran %v after a 50ms deadline", elapsed)
+	}
+	checkPlan(t, testTruck, result)
+}
@@ -0,0 +1,78 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// DeadheadOptimizer charges a plan for its empty miles: from the truck's
+// position to the first pickup and from the last delivery to its next
+// position, at a weight in cents per mile. The charge depends on where a plan
+// starts and ends, not on its orders one by one, so the inner optimizer is run
+// on all orders and on each route alone, and the candidate scoring best after
+// the charge wins. While the truck may stay empty, an empty plan is a
+// candidate too: no plan is worth more than staying put. TotalScore is the
+// objective after the charge.
+type DeadheadOptimizer struct {
+	inner  Optimizer
+	pinned []string
+	weight float64
+}
+
+func NewDeadheadOptimizer(inner Optimizer, pinnedIDs []string, weight float64) *DeadheadOptimizer {
+	return &DeadheadOptimizer{inner: inner, pinned: pinnedIDs, weight: weight}
+}
+
+func (d *DeadheadOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &DeadheadOptimizer{inner: WithChecker(d.inner, checker), pinned: d.pinned, weight: d.weight}
+}
+
+func (d *DeadheadOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	charged := func(result OptimizationResult) OptimizationResult {
+		result.TotalScore = result.TotalScore.Sub(domain.DeadheadCharge(truck, result.SelectedOrders, d.weight))
+		return result
+	}
+	best := charged(d.inner.Optimize(ctx, truck, orders))
+	
+	groups := domain.GroupOrdersByRoute(orders)
+	routes := make([]string, 0, len(groups))
+	for route := range groups {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		if len(groups) == 1 || !holdsPins(groups[route], d.pinned) || ctx.Err() != nil {
+			continue
+		}
+		if candidate := charged(d.inner.Optimize(ctx, truck, groups[route])); candidate.TotalScore > best.TotalScore {
+			candidate.Algorithm, candidate.Optimal, candidate.Portfolio = best.Algorithm, best.Optimal, best.Portfolio
+			best = candidate
+		}
+	}
+	if len(d.pinned) == 0 && best.TotalScore < 0 {
+		empty := OptimizationResult{SelectedOrders: []domain.Order{}, Algorithm: best.Algorithm, Optimal: best.Optimal, Portfolio: best.Portfolio}
+		best = empty
+	}
+	
+	best.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return best
+}
+
+// holdsPins reports whether the orders include every pinned order, so a
+// solve over them alone can honor the pins
+func holdsPins(orders []domain.Order, pinned []string) bool {
+	ids := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		ids[order.ID] = true
+	}
+	for _, id := range pinned {
+		if !ids[id] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// groupedOrders draws random orders and reposts some of them as copies on
+// the same lane, tied to the original by an exclusive group
+func groupedOrders(r *rand.Rand, n int) []domain.Order {
+	orders := randomOrders(r, n)
+	for i := range orders {
+		if r.Intn(3) != 0 {
+			continue
+		}
+		group := fmt.Sprintf("grp-%d", i)
+		orders[i].ExclusiveGroup = group
+		repost := orders[i]
+		repost.ID += "-repost"
+		repost.Score += domain.Score(r.Intn(2000))
+		orders = append(orders, repost)
+	}
+	return orders
+}
+
+func TestExclusiveGroupsAreEnforced(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(12))
+	optimizers := []Optimizer{
+		NewDPOptimizer(),
+		NewGreedyOptimizer(),
+		NewBacktrackingOptimizer(),
+		NewRegretGreedyOptimizer(),
+		NewKnapsackOptimizer(),
+		NewBranchAndBoundOptimizer(),
+		NewMeetInTheMiddleOptimizer(),
+		NewGreedyLocalSearchOptimizer(),
+	}
+	
+	for i := 0; i < 10; i++ {
+		orders := groupedOrders(r, 1+r.Intn(10))
+		for _, optimizer := range optimizers {
+			// checkPlan rejects plans that combine orders CanCombine refuses,
+			// which includes two orders of one group
+			checkPlan(t, testTruck, optimizer.Optimize(ctx, testTruck, orders))
+		}
+	}
+}
+
+// hazmatClassOrders draws random hazmat orders whose classes the
+// segregation table partly keeps apart, and orders without a class that
+// combine with any of them
+func hazmatClassOrders(r *rand.Rand, n int) []domain.Order {
+	orders := randomOrders(r, n)
+	for i := range orders {
+		orders[i].IsHazmat = true
+		orders[i].HazmatClass = []string{"", "3", "5.1", "8", "4.2"}[r.Intn(5)]
+	}
+	return orders
+}
+
+// Compatibility is not transitive: two orders that each combine with a third
+// may conflict with each other. Exact algorithms must still find the best plan.
+func TestExactAlgorithmsHonorExclusiveGroupsOptimally(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(13))
+	exact := []Optimizer{NewDPOptimizer(), NewBacktrackingOptimizer(), NewBranchAndBoundOptimizer(), NewMeetInTheMiddleOptimizer()}
+	
+	for i := 0; i < 200; i++ {
+		orders := groupedOrders(r, 1+r.Intn(9))
+		if i%2 == 1 {
+			orders = hazmatClassOrders(r, 1+r.Intn(12))
+		}
+		var best domain.Score
+		for _, plan := range feasibleSubsets(testTruck, orders) {
+			best = max(best, plan.score)
+		}
+		for _, optimizer := range exact {
+			got := optimizer.Optimize(ctx, testTruck, orders)
+			checkPlan(t, testTruck, got)
+			if got.TotalScore != best || !got.Optimal {
+				t.Fatalf("instance %d: %s scored %d (optimal %v), brute force %d", i, got.Algorithm, got.TotalScore, got.Optimal, best)
+			}
+		}
+		
+		// The knapsack cannot keep conflicting orders apart, so it may miss
+		// the best plan, but then must not claim it is optimal. Round sizes
+		// keep its table small and exact.
+		for j := range orders {
+			orders[j].WeightLbs = (orders[j].WeightLbs/500 + 1) * 500
+			orders[j].VolumeCuft = (orders[j].VolumeCuft/50 + 1) * 50
+		}
+		best = 0
+		for _, plan := range feasibleSubsets(testTruck, orders) {
+			best = max(best, plan.score)
+		}
+		got := NewKnapsackOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, got)
+		if got.TotalScore > best || got.Optimal && got.TotalScore != best {
+			t.Fatalf("instance %d: knapsack scored %d (optimal %v), brute force %d", i, got.TotalScore, got.Optimal, best)
+		}
+	}
+}
+
+func TestKnapsackIsNotOptimalAcrossConflicts(t *testing.T) {
+	order := func(id, group string, payout domain.Money) domain.Order {
+		return domain.Order{
+			ID: id, Payout: payout, Score: domain.ScoreFromMoney(payout), WeightLbs: 1000, VolumeCuft: 100,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX", ExclusiveGroup: group,
+		}
+	}
+	// X combines with both copies of A, which exclude each other
+	orders := []domain.Order{order("A1", "a", 5000), order("X", "", 10000), order("A2", "a", 50000)}
+	
+	knapsack := NewKnapsackOptimizer().Optimize(context.Background(), testTruck, orders)
+	if knapsack.Optimal {
+		t.Errorf("knapsack claims %v is optimal", planKey(knapsack.SelectedOrders))
+	}
+	for _, optimizer := range []Optimizer{NewBranchAndBoundOptimizer(), NewMeetInTheMiddleOptimizer()} {
+		if got := optimizer.Optimize(context.Background(), testTruck, orders); got.TotalPayout != 60000 {
+			t.Errorf("%s selected %v, want [X A2]", got.Algorithm, planKey(got.SelectedOrders))
+		}
+	}
+}
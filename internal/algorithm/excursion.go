@@ -0,0 +1,89 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// ExcursionOptimizer charges a plan for each hour it stays on the road beyond
+// the safe exposure of its most sensitive order, at a weight in cents per
+// hour. The charge depends on the plan as a whole: its route, its stops and
+// the least tolerant order on board. The inner optimizer is run on all orders,
+// on each route alone, and without the orders below each safe exposure in
+// turn, and the candidate scoring best after the charge wins. TotalScore is
+// the objective after the charge.
+type ExcursionOptimizer struct {
+	inner  Optimizer
+	pinned []string
+	weight float64
+}
+
+func NewExcursionOptimizer(inner Optimizer, pinnedIDs []string, weight float64) *ExcursionOptimizer {
+	return &ExcursionOptimizer{inner: inner, pinned: pinnedIDs, weight: weight}
+}
+
+func (e *ExcursionOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &ExcursionOptimizer{inner: WithChecker(e.inner, checker), pinned: e.pinned, weight: e.weight}
+}
+
+func (e *ExcursionOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	charged := func(result OptimizationResult) OptimizationResult {
+		result.TotalScore = result.TotalScore.Sub(domain.ExcursionCharge(result.SelectedOrders, e.weight))
+		return result
+	}
+	best := charged(e.inner.Optimize(ctx, truck, orders))
+	try := func(candidates []domain.Order) {
+		if len(candidates) == len(orders) || !holdsPins(candidates, e.pinned) || ctx.Err() != nil {
+			return
+		}
+		if candidate := charged(e.inner.Optimize(ctx, truck, candidates)); candidate.TotalScore > best.TotalScore {
+			candidate.Algorithm, candidate.Optimal, candidate.Portfolio = best.Algorithm, best.Optimal, best.Portfolio
+			best = candidate
+		}
+	}
+	
+	groups := domain.GroupOrdersByRoute(orders)
+	routes := make([]string, 0, len(groups))
+	for route := range groups {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	for _, route := range routes {
+		try(groups[route])
+	}
+	for _, limit := range exposureLimits(orders) {
+		tolerant := make([]domain.Order, 0, len(orders))
+		for _, order := range orders {
+			if order.MaxExposureHours == 0 || order.MaxExposureHours > limit {
+				tolerant = append(tolerant, order)
+			}
+		}
+		try(tolerant)
+	}
+	if len(e.pinned) == 0 && best.TotalScore < 0 {
+		empty := OptimizationResult{SelectedOrders: []domain.Order{}, Algorithm: best.Algorithm, Optimal: best.Optimal, Portfolio: best.Portfolio}
+		best = empty
+	}
+	
+	best.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return best
+}
+
+// exposureLimits lists the distinct safe exposures the orders set, shortest
+// first
+func exposureLimits(orders []domain.Order) []float64 {
+	seen := make(map[float64]bool)
+	limits := make([]float64, 0)
+	for _, order := range orders {
+		if order.MaxExposureHours > 0 && !seen[order.MaxExposureHours] {
+			seen[order.MaxExposureHours] = true
+			limits = append(limits, order.MaxExposureHours)
+		}
+	}
+	sort.Float64s(limits)
+	return limits
+}
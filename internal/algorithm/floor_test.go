@@ -0,0 +1,63 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// floorBoundOrders are random orders whose linear feet and pallets bind
+// before weight, about half of them stackable
+func floorBoundOrders(r *rand.Rand, n int) []domain.Order {
+	orders := randomOrders(r, n)
+	for i := range orders {
+		orders[i].LinearFeet = 4 + r.Intn(16)
+		orders[i].PalletCount = 1 + r.Intn(8)
+		orders[i].Stackable = r.Intn(2) == 0
+	}
+	return orders
+}
+
+// floorTruck limits linear feet and pallet positions well below its weight
+// and volume
+func floorTruck() (domain.Truck, domain.ConstraintChecker) {
+	truck := testTruck
+	truck.MaxLinearFeet = 28
+	truck.MaxPalletPositions = 10
+	limit := domain.FloorLimit{MaxLinearFeet: truck.MaxLinearFeet, MaxPalletPositions: truck.MaxPalletPositions}
+	return truck, domain.NewConstraintChecker().With(nil, []domain.SetRule{limit})
+}
+
+func TestDPAndGreedyHonorFloorSpace(t *testing.T) {
+	r := rand.New(rand.NewSource(48))
+	truck, limit := floorTruck()
+	
+	for trial := 0; trial < 40; trial++ {
+		orders := floorBoundOrders(r, 2+r.Intn(9))
+		
+		dp := NewDPOptimizer().Optimize(context.Background(), truck, orders)
+		checkPlan(t, truck, dp)
+		checkRules(t, limit, dp)
+		if want := bestUnderRules(truck, orders, limit); dp.TotalScore != want {
+			t.Fatalf("trial %d: dp scored %d, want %d", trial, dp.TotalScore, want)
+		}
+		
+		greedy := NewGreedyOptimizer().Optimize(context.Background(), truck, orders)
+		checkPlan(t, truck, greedy)
+		checkRules(t, limit, greedy)
+	}
+}
+
+func TestFloorSpaceRepairForOtherAlgorithms(t *testing.T) {
+	r := rand.New(rand.NewSource(49))
+	truck, checker := floorTruck()
+	
+	for trial := 0; trial < 20; trial++ {
+		orders := floorBoundOrders(r, 2+r.Intn(12))
+		for _, inner := range []Optimizer{NewBranchAndBoundOptimizer(), NewGreedyLocalSearchOptimizer(), NewRegretGreedyOptimizer()} {
+			result := NewSetRuleOptimizer(WithChecker(inner, checker), checker, nil, false).Optimize(context.Background(), truck, orders)
+			checkRules(t, checker, result)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"runtime"
+	"smart-load/internal/domain"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// GRASPOptimizer runs greedy randomized adaptive search: each restart builds
+// a plan greedily, but picks every next order at random among the densest
+// candidates that still fit, then refines it with local search. Restarts run
+// in parallel, one worker per core, until the restart count or the time
+// budget runs out; the best refined plan wins. The first restart in each
+// compatibility class is the plain greedy construction, so GRASP is never
+// worse than greedy followed by local search from the same starts.
+type GRASPOptimizer struct {
+	checker domain.ConstraintChecker
+	search  *LocalSearch
+	// restarts caps the number of constructions, though every class gets one
+	restarts int
+	// budget caps the wall time spent on restarts after the first
+	budget time.Duration
+	// alpha sets the candidate list: orders whose density is within alpha of
+	// the best candidate's, as a share of the spread between best and worst
+	alpha float64
+	// seed makes the sequence of restarts reproducible
+	seed    int64
+	workers int
+}
+
+func NewGRASPOptimizer() *GRASPOptimizer {
+	return &GRASPOptimizer{
+		checker:  domain.NewConstraintChecker(),
+		search:   NewLocalSearch(),
+		restarts: 256,
+		budget:   250 * time.Millisecond,
+		alpha:    0.3,
+		seed:     1,
+		workers:  runtime.GOMAXPROCS(0),
+	}
+}
+
+func (g *GRASPOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *g
+	constrained.checker = checker
+	constrained.search = g.search.withChecker(checker)
+	return &constrained
+}
+
+func (g *GRASPOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	density := func(order domain.Order) float64 {
+		size := float64(order.WeightLbs)/float64(truck.MaxWeightLbs) +
+			float64(order.VolumeCuft)/float64(truck.MaxVolumeCuft)
+		return float64(order.Score) / size
+	}
+	
+	// Local search never leaves the start's compatibility class, so restarts
+	// take the classes in turn
+	classes := compatibilityClasses(g.checker, orders)
+	if len(classes) == 0 {
+		classes = [][]domain.Order{{}}
+	}
+	for _, class := range classes {
+		sort.SliceStable(class, func(i, j int) bool {
+			return density(class[i]) > density(class[j])
+		})
+	}
+	
+	// The greedy start of every class always runs; later restarts stop at the budget
+	deadline := startTime.Add(g.budget)
+	var next atomic.Int64
+	var mu sync.Mutex
+	best, bestRestart := summarize([]domain.Order{}), -1
+	
+	var wg sync.WaitGroup
+	for w := 0; w < max(1, g.workers); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				restart := int(next.Add(1) - 1)
+				if restart >= max(g.restarts, len(classes)) || ctx.Err() != nil ||
+					(restart >= len(classes) && time.Now().After(deadline)) {
+					return
+				}
+				
+				class := classes[restart%len(classes)]
+				alpha := g.alpha
+				if restart < len(classes) {
+					alpha = 0
+				}
+				r := rand.New(rand.NewSource(g.seed + int64(restart)))
+				plan := g.construct(ctx, truck, class, density, alpha, r)
+				result := summarize(g.search.Improve(ctx, truck, class, plan))
+				
+				mu.Lock()
+				// Ties go to the earliest restart so a full run is reproducible
+				if bestRestart < 0 || result.TotalScore > best.TotalScore ||
+					(result.TotalScore == best.TotalScore && restart < bestRestart) {
+					best, bestRestart = result, restart
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	
+	best.Algorithm = "grasp"
+	best.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return best
+}
+
+// construct builds one plan, drawing each next order uniformly from the
+// restricted candidate list of open orders dense enough for alpha
+func (g *GRASPOptimizer) construct(
+	ctx context.Context,
+	truck domain.Truck,
+	byDensity []domain.Order,
+	density func(domain.Order) float64,
+	alpha float64,
+	r *rand.Rand,
+) []domain.Order {
+	selected := make([]domain.Order, 0)
+	open := append([]domain.Order(nil), byDensity...)
+	weight, volume := 0, 0
+	
+	for len(open) > 0 && ctx.Err() == nil && truck.FitsMoreOrders(len(selected)) {
+		// open stays in density order, so the candidate list is a prefix
+		top, bottom := density(open[0]), density(open[len(open)-1])
+		threshold := top - alpha*(top-bottom)
+		candidates := 1
+		for candidates < len(open) && density(open[candidates]) >= threshold {
+			candidates++
+		}
+		chosen := open[r.Intn(candidates)]
+		selected = append(selected, chosen)
+		weight += chosen.WeightLbs
+		volume += chosen.VolumeCuft
+		
+		kept := open[:0]
+		for _, order := range open {
+			if order.ID != chosen.ID && g.checker.CanCombine(order, chosen) &&
+				g.checker.CanFit(truck, weight, volume, order) {
+				kept = append(kept, order)
+			}
+		}
+		open = kept
+	}
+	return selected
+}
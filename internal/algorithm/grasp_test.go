@@ -0,0 +1,58 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestGRASPMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewGRASPOptimizer(), 100)
+}
+
+func TestGRASPNeverWorsensGreedyLocalSearch(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(16))
+	
+	var baseline, grasp int64
+	for i := 0; i < 20; i++ {
+		orders := randomOrders(r, 20+r.Intn(60))
+		start := NewGreedyLocalSearchOptimizer().Optimize(ctx, testTruck, orders)
+		result := NewGRASPOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, result)
+		baseline += int64(start.TotalScore)
+		grasp += int64(result.TotalScore)
+	}
+	if grasp < baseline {
+		t.Errorf("GRASP total %d is below greedy+ls %d", grasp, baseline)
+	}
+	t.Logf("greedy+ls %d, GRASP %d", baseline, grasp)
+}
+
+func TestGRASPIsReproducible(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	orders := randomOrders(r, 40)
+	
+	g := NewGRASPOptimizer()
+	g.restarts, g.budget = 32, time.Hour
+	first := g.Optimize(context.Background(), testTruck, orders)
+	second := g.Optimize(context.Background(), testTruck, orders)
+	if planKey(first.SelectedOrders) != planKey(second.SelectedOrders) {
+		t.Fatalf("two full runs chose %s and %s", planKey(first.SelectedOrders), planKey(second.SelectedOrders))
+	}
+}
+
+func TestGRASPStopsWhenCancelled(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+	orders := randomOrders(r, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	start := time.Now()
+	result := NewGRASPOptimizer().Optimize(ctx, testTruck, orders)
+	checkPlan(t, testTruck, result)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("cancelled solve took %v", elapsed)
+	}
+}
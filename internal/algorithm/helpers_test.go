@@ -0,0 +1,100 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+var testTruck = domain.Truck{ID: "truck-1", MaxWeightLbs: 44000, MaxVolumeCuft: 3000}
+
+// randomOrders draws orders on two lanes with some hazmat, so plans have to
+// respect compatibility. Scores differ from payouts the way tenant bonuses
+// make them, so tests notice an algorithm maximizing the wrong field.
+func randomOrders(r *rand.Rand, n int) []domain.Order {
+	orders := make([]domain.Order, n)
+	for i := range orders {
+		payout := domain.Money(1000 + r.Intn(300000))
+		orders[i] = domain.Order{
+			ID:          fmt.Sprintf("ord-%d", i),
+			Payout:      payout,
+			Score:       domain.ScoreFromMoney(payout),
+			WeightLbs:   1000 + r.Intn(12000),
+			VolumeCuft:  50 + r.Intn(900),
+			Origin:      "Los Angeles, CA",
+			Destination: []string{"Dallas, TX", "Phoenix, AZ"}[r.Intn(2)],
+			IsHazmat:    r.Intn(5) == 0,
+		}
+		if r.Intn(4) == 0 {
+			orders[i].Score += domain.ScoreFromMoney(domain.Money(r.Intn(50000)))
+		}
+	}
+	return orders
+}
+
+// checkPlan fails the test unless result fits the truck and its order cap,
+// combines only compatible orders, and reports totals that match its orders
+func checkPlan(t *testing.T, truck domain.Truck, result OptimizationResult) {
+	t.Helper()
+	checker := domain.NewConstraintChecker()
+	
+	var payout domain.Money
+	var score domain.Score
+	var weight, volume int
+	seen := make(map[string]bool)
+	for i, order := range result.SelectedOrders {
+		if seen[order.ID] {
+			t.Fatalf("%s selected %s twice", result.Algorithm, order.ID)
+		}
+		seen[order.ID] = true
+		for _, other := range result.SelectedOrders[:i] {
+			if !checker.CanCombine(order, other) {
+				t.Fatalf("%s combined incompatible orders %s and %s", result.Algorithm, order.ID, other.ID)
+			}
+		}
+		payout += order.Payout
+		score += order.Score
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	
+	if weight > truck.MaxWeightLbs || volume > truck.MaxVolumeCuft {
+		t.Fatalf("%s overloads the truck: %d lbs, %d cuft", result.Algorithm, weight, volume)
+	}
+	if truck.MaxOrders > 0 && len(result.SelectedOrders) > truck.MaxOrders {
+		t.Fatalf("%s loads %d orders, over max_orders %d", result.Algorithm, len(result.SelectedOrders), truck.MaxOrders)
+	}
+	if result.TotalPayout != payout || result.TotalScore != score {
+		t.Fatalf("%s reports payout %d score %d, orders sum to %d and %d",
+			result.Algorithm, result.TotalPayout, result.TotalScore, payout, score)
+	}
+	if result.TotalWeight != weight || result.TotalVolume != volume {
+		t.Fatalf("%s reports %d lbs %d cuft, orders sum to %d and %d",
+			result.Algorithm, result.TotalWeight, result.TotalVolume, weight, volume)
+	}
+}
+
+// checkMatchesDP solves random instances small enough for the bitmask DP and
+// requires optimizer to reach the same score whenever it claims optimality
+func checkMatchesDP(t *testing.T, optimizer Optimizer, instances int) {
+	t.Helper()
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(1))
+	dp := NewDPOptimizer()
+	
+	for i := 0; i < instances; i++ {
+		orders := randomOrders(r, r.Intn(19))
+		want := dp.Optimize(ctx, testTruck, orders)
+		got := optimizer.Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, got)
+		
+		if got.TotalScore > want.TotalScore {
+			t.Fatalf("instance %d: %s score %d beats the DP optimum %d", i, got.Algorithm, got.TotalScore, want.TotalScore)
+		}
+		if got.Optimal && got.TotalScore != want.TotalScore {
+			t.Fatalf("instance %d: %s claims optimal score %d, DP found %d", i, got.Algorithm, got.TotalScore, want.TotalScore)
+		}
+	}
+}
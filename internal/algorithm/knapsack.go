@@ -0,0 +1,272 @@
+package algorithm
+
+import (
+	"context"
+	"math"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// KnapsackOptimizer solves a 0/1 knapsack over weight/volume capacity instead
+// of over order subsets, so its cost grows with n * capacity rather than 2^n.
+// Capacities are divided by their greatest common divisor with the order sizes;
+// when that still leaves too many cells they are scaled down further, rounding
+// order sizes up so every plan stays feasible (near-exact instead of exact).
+type KnapsackOptimizer struct {
+	checker domain.ConstraintChecker
+	// maxWork bounds items * capacity cells per solve
+	maxWork int
+}
+
+func NewKnapsackOptimizer() *KnapsackOptimizer {
+	return &KnapsackOptimizer{
+		checker: domain.NewConstraintChecker(),
+		maxWork: 60_000_000,
+	}
+}
+
+func (o *KnapsackOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+func (k *KnapsackOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	
+	best := OptimizationResult{
+		SelectedOrders: []domain.Order{},
+		Algorithm:      "knapsack",
+		Optimal:        true,
+	}
+	
+	// Only compatible orders can share a truck, so each component is an
+	// independent knapsack and the best one wins. The knapsack cannot keep
+	// apart two orders of a component that conflict, so such a component is
+	// split into classes of mutually compatible orders, which can miss plans
+	// that mix them; the result is then not optimal.
+	for _, component := range compatibilityComponents(k.checker, orders) {
+		classes := compatibilityClasses(k.checker, component)
+		if len(classes) > 1 {
+			best.Optimal = false
+		}
+		for _, class := range classes {
+			selected, exact := k.solveClass(ctx, truck, class)
+			if !exact {
+				best.Optimal = false
+			}
+			
+			payout := domain.Score(0)
+			for _, order := range selected {
+				payout = payout.Add(order.Score)
+			}
+			if payout > best.TotalScore {
+				best.SelectedOrders = selected
+				best.TotalScore = payout
+			}
+		}
+	}
+	
+	best.TotalPayout = totalPayout(best.SelectedOrders)
+	for _, order := range best.SelectedOrders {
+		best.TotalWeight += order.WeightLbs
+		best.TotalVolume += order.VolumeCuft
+	}
+	best.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return best
+}
+
+// compatibilityClasses groups orders so that every pair within a group can be combined
+func compatibilityClasses(checker domain.ConstraintChecker, orders []domain.Order) [][]domain.Order {
+	classes := make([][]domain.Order, 0)
+	
+	for _, order := range orders {
+		placed := false
+		for i, class := range classes {
+			compatible := true
+			for _, member := range class {
+				if !checker.CanCombine(order, member) {
+					compatible = false
+					break
+				}
+			}
+			if compatible {
+				classes[i] = append(classes[i], order)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			classes = append(classes, []domain.Order{order})
+		}
+	}
+	
+	return classes
+}
+
+// compatibilityComponents groups orders into the connected components of the
+// pairs that can be combined, so that every feasible plan lies within one
+// component. Unlike compatibility classes, two orders of a component may
+// still conflict: two orders of one exclusive group can each combine with a
+// third.
+func compatibilityComponents(checker domain.ConstraintChecker, orders []domain.Order) [][]domain.Order {
+	parent := make([]int, len(orders))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range orders {
+		for j := i + 1; j < len(orders); j++ {
+			if find(i) != find(j) && checker.CanCombine(orders[i], orders[j]) {
+				parent[find(j)] = find(i)
+			}
+		}
+	}
+	
+	components := make([][]domain.Order, 0)
+	index := make(map[int]int)
+	for i, order := range orders {
+		root := find(i)
+		if _, ok := index[root]; !ok {
+			index[root] = len(components)
+			components = append(components, nil)
+		}
+		components[index[root]] = append(components[index[root]], order)
+	}
+	return components
+}
+
+func (k *KnapsackOptimizer) solveClass(ctx context.Context, truck domain.Truck, orders []domain.Order) ([]domain.Order, bool) {
+	n := len(orders)
+	
+	weightUnit := truck.MaxWeightLbs
+	volumeUnit := truck.MaxVolumeCuft
+	for _, order := range orders {
+		weightUnit = gcd(weightUnit, order.WeightLbs)
+		volumeUnit = gcd(volumeUnit, order.VolumeCuft)
+	}
+	
+	// A max_orders cap below the class size adds an order-count axis
+	slots := 1
+	if truck.MaxOrders > 0 && truck.MaxOrders < n {
+		slots = truck.MaxOrders + 1
+	}
+	
+	exact := true
+	maxCells := k.maxWork / n / slots
+	weightCells := truck.MaxWeightLbs / weightUnit
+	volumeCells := truck.MaxVolumeCuft / volumeUnit
+	if (weightCells+1)*(volumeCells+1) > maxCells {
+		exact = false
+		// Shrink both axes by the same factor so neither dimension loses all resolution
+		factor := math.Sqrt(float64((weightCells+1)*(volumeCells+1)) / float64(maxCells))
+		weightUnit = int(math.Ceil(float64(weightUnit) * factor))
+		volumeUnit = int(math.Ceil(float64(volumeUnit) * factor))
+		weightCells = truck.MaxWeightLbs / weightUnit
+		volumeCells = truck.MaxVolumeCuft / volumeUnit
+	}
+	
+	// cell(cw, cv, c) is the best score within cw, cv using at most c orders;
+	// without a cap c is always 0
+	stride := volumeCells + 1
+	cells := (weightCells + 1) * stride * slots
+	cell := func(cw, cv, c int) int {
+		return (cw*stride+cv)*slots + c
+	}
+	fewest := 0
+	if slots > 1 {
+		fewest = 1
+	}
+	
+	best := make([]int64, cells)
+	taken := make([][]uint64, n)
+	
+	for i, order := range orders {
+		if ctx.Err() != nil {
+			return []domain.Order{}, false
+		}
+		
+		w := ceilDiv(order.WeightLbs, weightUnit)
+		v := ceilDiv(order.VolumeCuft, volumeUnit)
+		taken[i] = make([]uint64, (cells+63)/64)
+		if w > weightCells || v > volumeCells {
+			continue
+		}
+		
+		payout := int64(order.Score)
+		shift := (w*stride+v)*slots + fewest
+		for cw := weightCells; cw >= w; cw-- {
+			for cv := volumeCells; cv >= v; cv-- {
+				base := cell(cw, cv, 0)
+				for target := base + slots - 1; target >= base+fewest; target-- {
+					candidate := best[target-shift] + payout
+					if candidate > best[target] {
+						best[target] = candidate
+						taken[i][target/64] |= 1 << (target % 64)
+					}
+				}
+			}
+		}
+	}
+	
+	selected := make([]domain.Order, 0)
+	cw, cv, c := weightCells, volumeCells, slots-1
+	for i := n - 1; i >= 0; i-- {
+		target := cell(cw, cv, c)
+		if taken[i][target/64]&(1<<(target%64)) == 0 {
+			continue
+		}
+		selected = append(selected, orders[i])
+		cw -= ceilDiv(orders[i].WeightLbs, weightUnit)
+		cv -= ceilDiv(orders[i].VolumeCuft, volumeUnit)
+		c -= fewest
+	}
+	
+	if !exact {
+		selected = k.fillSlack(truck, orders, selected)
+	}
+	return selected, exact
+}
+
+// fillSlack adds leftover orders into capacity lost to rounding when scaled
+func (k *KnapsackOptimizer) fillSlack(truck domain.Truck, orders []domain.Order, selected []domain.Order) []domain.Order {
+	chosen := make(map[string]bool, len(selected))
+	weight, volume := 0, 0
+	for _, order := range selected {
+		chosen[order.ID] = true
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	
+	for _, order := range orders {
+		if !truck.FitsMoreOrders(len(selected)) {
+			break
+		}
+		if chosen[order.ID] || !k.checker.CanFit(truck, weight, volume, order) {
+			continue
+		}
+		selected = append(selected, order)
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	return selected
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
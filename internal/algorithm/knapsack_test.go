@@ -0,0 +1,47 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestKnapsackMatchesDP(t *testing.T) {
+	// Odd sizes leave no common divisor, so each solve fills the whole work budget
+	checkMatchesDP(t, NewKnapsackOptimizer(), 20)
+}
+
+func TestKnapsackIsExactOnRoundNumbers(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(2))
+	
+	for i := 0; i < 100; i++ {
+		orders := randomOrders(r, 1+r.Intn(18))
+		for j := range orders {
+			orders[j].WeightLbs = orders[j].WeightLbs / 500 * 500
+			orders[j].VolumeCuft = orders[j].VolumeCuft / 50 * 50
+		}
+		
+		want := NewDPOptimizer().Optimize(ctx, testTruck, orders)
+		got := NewKnapsackOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, got)
+		if !got.Optimal || got.TotalScore != want.TotalScore {
+			t.Fatalf("instance %d: knapsack score %d (optimal %v), DP %d", i, got.TotalScore, got.Optimal, want.TotalScore)
+		}
+	}
+}
+
+func TestKnapsackScalesDownLargeCapacities(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(3))
+	truck := testTruck
+	truck.MaxWeightLbs = 44017
+	truck.MaxVolumeCuft = 3001
+	
+	orders := randomOrders(r, 200)
+	result := NewKnapsackOptimizer().Optimize(ctx, truck, orders)
+	checkPlan(t, truck, result)
+	if len(result.SelectedOrders) == 0 {
+		t.Fatal("no orders selected")
+	}
+}
@@ -0,0 +1,261 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// LocalSearch refines a feasible selection with first-improvement moves until
+// none applies: add an unselected order, swap one selected order for one
+// unselected order, or swap one selected order for two unselected ones (a
+// heavy order out, two lighter orders in). Every move strictly raises the total score.
+type LocalSearch struct {
+	checker domain.ConstraintChecker
+	// maxRounds bounds the number of applied moves per call
+	maxRounds int
+}
+
+func NewLocalSearch() *LocalSearch {
+	return &LocalSearch{
+		checker:   domain.NewConstraintChecker(),
+		maxRounds: 1000,
+	}
+}
+
+func (ls *LocalSearch) withChecker(checker domain.ConstraintChecker) *LocalSearch {
+	constrained := *ls
+	constrained.checker = checker
+	return &constrained
+}
+
+// Improve returns a selection at least as good as selected. It stops early,
+// keeping the best selection so far, when ctx is cancelled.
+func (ls *LocalSearch) Improve(ctx context.Context, truck domain.Truck, orders []domain.Order, selected []domain.Order) []domain.Order {
+	n := len(orders)
+	if n == 0 {
+		return selected
+	}
+	
+	classOf := make([]int, n)
+	index := make(map[string]int, n)
+	for i, order := range orders {
+		index[order.ID] = i
+	}
+	for class, members := range compatibilityClasses(ls.checker, orders) {
+		for _, order := range members {
+			classOf[index[order.ID]] = class
+		}
+	}
+	
+	byScore := make([]int, n)
+	for i := range byScore {
+		byScore[i] = i
+	}
+	sort.Slice(byScore, func(a, b int) bool {
+		return orders[byScore[a]].Score > orders[byScore[b]].Score
+	})
+	
+	s := &searchState{
+		truck:   truck,
+		orders:  orders,
+		classOf: classOf,
+		byScore: byScore,
+		chosen:  make([]bool, n),
+		class:   -1,
+	}
+	for _, order := range selected {
+		i, ok := index[order.ID]
+		if !ok {
+			// The selection came from a different order list; leave it alone
+			return selected
+		}
+		s.add(i)
+	}
+	
+	for round := 0; round < ls.maxRounds; round++ {
+		if ctx.Err() != nil {
+			break
+		}
+		if !s.tryAdd() && !s.trySwap(ctx) {
+			break
+		}
+	}
+	
+	improved := make([]domain.Order, 0, s.count)
+	for i, chosen := range s.chosen {
+		if chosen {
+			improved = append(improved, orders[i])
+		}
+	}
+	return improved
+}
+
+// searchState is a selection under local search. All selected orders share
+// one compatibility class; class is -1 while the selection is empty.
+type searchState struct {
+	truck   domain.Truck
+	orders  []domain.Order
+	classOf []int
+	byScore []int
+	chosen  []bool
+	class   int
+	count   int
+	weight  int
+	volume  int
+}
+
+func (s *searchState) add(i int) {
+	s.chosen[i] = true
+	s.count++
+	s.weight += s.orders[i].WeightLbs
+	s.volume += s.orders[i].VolumeCuft
+	s.class = s.classOf[i]
+}
+
+func (s *searchState) remove(i int) {
+	s.chosen[i] = false
+	s.count--
+	s.weight -= s.orders[i].WeightLbs
+	s.volume -= s.orders[i].VolumeCuft
+	if s.count == 0 {
+		s.class = -1
+	}
+}
+
+func (s *searchState) tryAdd() bool {
+	if !s.truck.FitsMoreOrders(s.count) {
+		return false
+	}
+	for _, j := range s.byScore {
+		order := s.orders[j]
+		if s.chosen[j] || (s.class >= 0 && s.classOf[j] != s.class) {
+			continue
+		}
+		if s.weight+order.WeightLbs <= s.truck.MaxWeightLbs && s.volume+order.VolumeCuft <= s.truck.MaxVolumeCuft {
+			s.add(j)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *searchState) trySwap(ctx context.Context) bool {
+	steps := 0
+	
+	for i, chosen := range s.chosen {
+		if !chosen {
+			continue
+		}
+		out := s.orders[i]
+		freeWeight := s.truck.MaxWeightLbs - s.weight + out.WeightLbs
+		freeVolume := s.truck.MaxVolumeCuft - s.volume + out.VolumeCuft
+		// With i gone the selection may be empty, and then any class is allowed
+		class := s.class
+		if s.count == 1 {
+			class = -1
+		}
+		
+		for a, j := range s.byScore {
+			first := s.orders[j]
+			if first.Score <= out.Score/2 {
+				break
+			}
+			if s.chosen[j] || (class >= 0 && s.classOf[j] != class) {
+				continue
+			}
+			if first.WeightLbs > freeWeight || first.VolumeCuft > freeVolume {
+				continue
+			}
+			
+			if first.Score > out.Score {
+				s.remove(i)
+				s.add(j)
+				return true
+			}
+			
+			// Two in for one out needs a free slot under max_orders
+			if !s.truck.FitsMoreOrders(s.count) {
+				continue
+			}
+			for _, k := range s.byScore[a+1:] {
+				steps++
+				if steps%cancelCheckInterval == 0 && ctx.Err() != nil {
+					return false
+				}
+				
+				second := s.orders[k]
+				if first.Score+second.Score <= out.Score {
+					break
+				}
+				if s.chosen[k] || s.classOf[k] != s.classOf[j] {
+					continue
+				}
+				if first.WeightLbs+second.WeightLbs <= freeWeight && first.VolumeCuft+second.VolumeCuft <= freeVolume {
+					s.remove(i)
+					s.add(j)
+					s.add(k)
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// GreedyLocalSearchOptimizer runs the greedy heuristic and then local search
+// to undo its most obvious mistakes
+type GreedyLocalSearchOptimizer struct {
+	greedy *GreedyOptimizer
+	search *LocalSearch
+}
+
+func NewGreedyLocalSearchOptimizer() *GreedyLocalSearchOptimizer {
+	return &GreedyLocalSearchOptimizer{
+		greedy: NewGreedyOptimizer(),
+		search: NewLocalSearch(),
+	}
+}
+
+func (g *GreedyLocalSearchOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &GreedyLocalSearchOptimizer{
+		greedy: &GreedyOptimizer{checker: checker},
+		search: g.search.withChecker(checker),
+	}
+}
+
+func (g *GreedyLocalSearchOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	
+	// Swaps never leave the greedy plan's compatibility class, so every class
+	// gets its own start and the best refined plan wins
+	result := summarize([]domain.Order{})
+	for _, class := range compatibilityClasses(g.search.checker, orders) {
+		if ctx.Err() != nil {
+			break
+		}
+		start := g.greedy.Optimize(ctx, truck, class)
+		refined := summarize(g.search.Improve(ctx, truck, class, start.SelectedOrders))
+		if refined.TotalScore > result.TotalScore {
+			result = refined
+		}
+	}
+	result.Algorithm = "greedy+ls"
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+// summarize totals a selection
+func summarize(selected []domain.Order) OptimizationResult {
+	result := OptimizationResult{SelectedOrders: selected}
+	for _, order := range selected {
+		result.TotalPayout = result.TotalPayout.Add(order.Payout)
+		result.TotalScore = result.TotalScore.Add(order.Score)
+		result.TotalWeight += order.WeightLbs
+		result.TotalVolume += order.VolumeCuft
+	}
+	return result
+}
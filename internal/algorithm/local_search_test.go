@@ -0,0 +1,49 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestGreedyLocalSearchMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewGreedyLocalSearchOptimizer(), 300)
+}
+
+func TestLocalSearchNeverWorsensGreedy(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(7))
+	
+	var greedyTotal, refinedTotal int64
+	for i := 0; i < 300; i++ {
+		orders := randomOrders(r, 1+r.Intn(60))
+		greedy := NewGreedyOptimizer().Optimize(ctx, testTruck, orders)
+		refined := NewGreedyLocalSearchOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, refined)
+		if refined.TotalScore < greedy.TotalScore {
+			t.Fatalf("instance %d: local search score %d is below greedy %d", i, refined.TotalScore, greedy.TotalScore)
+		}
+		greedyTotal += int64(greedy.TotalScore)
+		refinedTotal += int64(refined.TotalScore)
+	}
+	if refinedTotal <= greedyTotal {
+		t.Errorf("local search never improved on greedy (%d vs %d)", refinedTotal, greedyTotal)
+	}
+}
+
+func TestImproveFromEmptyPlan(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(8))
+	
+	for i := 0; i < 100; i++ {
+		orders := randomOrders(r, 1+r.Intn(40))
+		class := compatibilityClasses(NewLocalSearch().checker, orders)[0]
+		improved := summarize(NewLocalSearch().Improve(ctx, testTruck, class, []domain.Order{}))
+		improved.Algorithm = "local search"
+		checkPlan(t, testTruck, improved)
+		if len(improved.SelectedOrders) == 0 {
+			t.Fatalf("instance %d: nothing added to an empty plan", i)
+		}
+	}
+}
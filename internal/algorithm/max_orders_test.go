@@ -0,0 +1,98 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// cappedTruck returns testTruck with a max_orders cap low enough to bind
+func cappedTruck(r *rand.Rand) domain.Truck {
+	truck := testTruck
+	truck.MaxOrders = 1 + r.Intn(3)
+	return truck
+}
+
+func TestMaxOrdersIsEnforced(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(22))
+	checker := domain.NewConstraintChecker()
+	optimizers := []Optimizer{
+		NewDPOptimizer(),
+		NewGreedyOptimizer(),
+		NewBacktrackingOptimizer(),
+		NewRegretGreedyOptimizer(),
+		NewGreedyLocalSearchOptimizer(),
+		NewGRASPOptimizer(),
+		NewTabuSearchOptimizer(0, 0),
+		NewKnapsackOptimizer(),
+		NewBranchAndBoundOptimizer(),
+		NewMeetInTheMiddleOptimizer(),
+		NewHybridOptimizer(),
+	}
+	
+	for i := 0; i < 10; i++ {
+		truck := cappedTruck(r)
+		orders := randomOrders(r, 12+r.Intn(5))
+		for j := range orders {
+			// Small round orders: capacity alone would allow many more, and
+			// the knapsack stays quick
+			orders[j].WeightLbs = 500 + orders[j].WeightLbs/5000*500
+			orders[j].VolumeCuft = 50 + orders[j].VolumeCuft/500*50
+			orders[j].Priority = r.Intn(3)
+			orders[j].Splittable = r.Intn(3) == 0
+		}
+		for _, optimizer := range optimizers {
+			checkPlan(t, truck, optimizer.Optimize(ctx, truck, orders))
+		}
+		checkPlan(t, truck, NewPriorityOptimizer(NewDPOptimizer()).Optimize(ctx, truck, orders))
+		checkPlan(t, truck, NewSplittableOptimizer(NewDPOptimizer(), false).Optimize(ctx, truck, orders))
+		
+		pinnedIDs := []string{orders[0].ID}
+		candidates, err := domain.Pins{Include: pinnedIDs}.Apply(checker, truck, orders)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := NewPinnedOptimizer(NewDPOptimizer(), pinnedIDs).Optimize(ctx, truck, candidates)
+		checkPlan(t, truck, got)
+		if !containsOrder(got.SelectedOrders, pinnedIDs[0]) {
+			t.Fatalf("instance %d: pinned order %s dropped under max_orders %d", i, pinnedIDs[0], truck.MaxOrders)
+		}
+	}
+}
+
+func TestExactAlgorithmsAreOptimalUnderMaxOrders(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(23))
+	exact := []Optimizer{
+		NewDPOptimizer(),
+		NewBacktrackingOptimizer(),
+		NewKnapsackOptimizer(),
+		NewBranchAndBoundOptimizer(),
+		NewMeetInTheMiddleOptimizer(),
+	}
+	
+	for i := 0; i < 100; i++ {
+		truck := cappedTruck(r)
+		orders := randomOrders(r, 1+r.Intn(11))
+		for j := range orders {
+			// Round numbers keep the knapsack exact
+			orders[j].WeightLbs = orders[j].WeightLbs / 500 * 500
+			orders[j].VolumeCuft = orders[j].VolumeCuft / 50 * 50
+		}
+		
+		var best domain.Score
+		for _, plan := range feasibleSubsets(truck, orders) {
+			best = max(best, plan.score)
+		}
+		for _, optimizer := range exact {
+			got := optimizer.Optimize(ctx, truck, orders)
+			checkPlan(t, truck, got)
+			if !got.Optimal || got.TotalScore != best {
+				t.Fatalf("instance %d: %s scored %d (optimal %v) under max_orders %d, brute force %d",
+					i, got.Algorithm, got.TotalScore, got.Optimal, truck.MaxOrders, best)
+			}
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// MeetInTheMiddleOptimizer splits each compatibility component into two halves,
+// enumerates the feasible subsets of each half and joins them. The second half
+// is inserted into a Fenwick tree over volume in weight order, so every first-half
+// subset finds its best partner in O(log n); second-half subsets dominated by a
+// lighter, smaller, better-paying one are dropped on insertion. Exact for up to
+// ~44 orders, where bitmask DP runs out of memory and greedy is too lossy.
+// Under a max_orders cap there is one tree per order count c, holding the
+// subsets of at most c orders, and a first-half subset of k orders queries the
+// tree for the slots it leaves. Orders that conflict with others in their
+// component go in the first half, where enumeration keeps them apart; when
+// they do not all fit there, first-half subsets are joined separately for
+// each set of second-half orders they conflict with.
+type MeetInTheMiddleOptimizer struct {
+	checker domain.ConstraintChecker
+}
+
+func NewMeetInTheMiddleOptimizer() *MeetInTheMiddleOptimizer {
+	return &MeetInTheMiddleOptimizer{
+		checker: domain.NewConstraintChecker(),
+	}
+}
+
+func (o *MeetInTheMiddleOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+// subset is a feasible combination of orders within one half
+type subset struct {
+	weight int32
+	volume int32
+	payout int64
+	mask   uint32
+	count  int32
+}
+
+func (m *MeetInTheMiddleOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	
+	result := OptimizationResult{
+		SelectedOrders: []domain.Order{},
+		Algorithm:      "meet_in_the_middle",
+		Optimal:        true,
+	}
+	
+	for _, component := range compatibilityComponents(m.checker, orders) {
+		selected, payout, ok := m.solveClass(ctx, truck, component)
+		if !ok {
+			result.Optimal = false
+			break
+		}
+		if payout > result.TotalScore {
+			result.SelectedOrders = selected
+			result.TotalScore = payout
+		}
+	}
+	
+	result.TotalPayout = totalPayout(result.SelectedOrders)
+	for _, order := range result.SelectedOrders {
+		result.TotalWeight += order.WeightLbs
+		result.TotalVolume += order.VolumeCuft
+	}
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+func (m *MeetInTheMiddleOptimizer) solveClass(ctx context.Context, truck domain.Truck, orders []domain.Order) ([]domain.Order, domain.Score, bool) {
+	orders = conflictedFirst(m.checker, orders)
+	half := len(orders) / 2
+	left, right := orders[:half], orders[half:]
+	
+	leftSubsets, ok := enumerateSubsets(ctx, truck, left, conflictMasks(m.checker, left, left))
+	if !ok {
+		return nil, 0, false
+	}
+	rightSubsets, ok := enumerateSubsets(ctx, truck, right, conflictMasks(m.checker, right, right))
+	if !ok {
+		return nil, 0, false
+	}
+	
+	// Left subsets query with their remaining weight, smallest first, so every
+	// right subset light enough is already in the tree when the query runs.
+	sort.Slice(leftSubsets, func(i, j int) bool {
+		return leftSubsets[i].weight > leftSubsets[j].weight
+	})
+	sort.Slice(rightSubsets, func(i, j int) bool {
+		if rightSubsets[i].weight != rightSubsets[j].weight {
+			return rightSubsets[i].weight < rightSubsets[j].weight
+		}
+		return rightSubsets[i].volume < rightSubsets[j].volume
+	})
+	
+	// Left subsets are grouped by the right orders they conflict with, and
+	// each group joins only the right subsets free of those orders
+	cross := conflictMasks(m.checker, left, right)
+	groups := []conflictGroup{{subsets: leftSubsets}}
+	for _, mask := range cross {
+		if mask != 0 {
+			groups = groupByConflicts(leftSubsets, cross)
+			break
+		}
+	}
+	
+	// Without a binding cap a single tree holds every subset
+	slots := 0
+	if truck.MaxOrders > 0 && truck.MaxOrders < len(orders) {
+		slots = min(len(right), truck.MaxOrders)
+	}
+	
+	var bestPayout int64 = -1
+	var bestLeft, bestRight uint32
+	for _, group := range groups {
+		rights := rightSubsets
+		if group.forbidden != 0 {
+			rights = make([]subset, 0, len(rightSubsets))
+			for _, r := range rightSubsets {
+				if r.mask&group.forbidden == 0 {
+					rights = append(rights, r)
+				}
+			}
+		}
+		payout, leftMask, rightMask, ok := joinSubsets(ctx, truck, slots, group.subsets, rights)
+		if !ok {
+			return nil, 0, false
+		}
+		if payout > bestPayout {
+			bestPayout, bestLeft, bestRight = payout, leftMask, rightMask
+		}
+	}
+	
+	selected := make([]domain.Order, 0)
+	for i := range left {
+		if bestLeft&(1<<i) != 0 {
+			selected = append(selected, left[i])
+		}
+	}
+	for i := range right {
+		if bestRight&(1<<i) != 0 {
+			selected = append(selected, right[i])
+		}
+	}
+	return selected, domain.Score(bestPayout), true
+}
+
+// joinSubsets finds the best-paying pair of a left and a right subset that
+// fit the truck together, with slots as in solveClass. Both lists must be
+// sorted as solveClass sorts them.
+func joinSubsets(ctx context.Context, truck domain.Truck, slots int, leftSubsets, rightSubsets []subset) (int64, uint32, uint32, bool) {
+	volumes := make([]int32, 0, len(rightSubsets))
+	for _, s := range rightSubsets {
+		volumes = append(volumes, s.volume)
+	}
+	sort.Slice(volumes, func(i, j int) bool { return volumes[i] < volumes[j] })
+	volumes = uniqueInt32(volumes)
+	trees := make([]*maxFenwick, slots+1)
+	for c := range trees {
+		trees[c] = newMaxFenwick(len(volumes))
+	}
+	
+	var bestPayout int64 = -1
+	var bestLeft, bestRight uint32
+	next := 0
+	
+	for i, l := range leftSubsets {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return 0, 0, 0, false
+		}
+		
+		remainingWeight := int32(truck.MaxWeightLbs) - l.weight
+		remainingVolume := int32(truck.MaxVolumeCuft) - l.volume
+		
+		for next < len(rightSubsets) && rightSubsets[next].weight <= remainingWeight {
+			r := rightSubsets[next]
+			position := sort.Search(len(volumes), func(k int) bool { return volumes[k] >= r.volume })
+			fewest := int(r.count)
+			if slots == 0 {
+				fewest = 0
+			}
+			for c := fewest; c <= slots; c++ {
+				// Dominance pruning: skip if a lighter, smaller subset already pays as much
+				if payout, _ := trees[c].prefixMax(position); payout < r.payout {
+					trees[c].update(position, r.payout, r.mask)
+				}
+			}
+			next++
+		}
+		
+		position := sort.Search(len(volumes), func(k int) bool { return volumes[k] > remainingVolume }) - 1
+		if position < 0 {
+			continue
+		}
+		free := slots
+		if slots > 0 {
+			free = min(slots, truck.MaxOrders-int(l.count))
+		}
+		payout, mask := trees[free].prefixMax(position)
+		if payout < 0 {
+			continue
+		}
+		if l.payout+payout > bestPayout {
+			bestPayout = l.payout + payout
+			bestLeft, bestRight = l.mask, mask
+		}
+	}
+	return bestPayout, bestLeft, bestRight, true
+}
+
+// conflictedFirst moves the orders that conflict with another order to the
+// front, keeping the sequence otherwise
+func conflictedFirst(checker domain.ConstraintChecker, orders []domain.Order) []domain.Order {
+	conflicted := make([]bool, len(orders))
+	for i := range orders {
+		for j := i + 1; j < len(orders); j++ {
+			if !checker.CanCombine(orders[i], orders[j]) {
+				conflicted[i], conflicted[j] = true, true
+			}
+		}
+	}
+	sorted := make([]domain.Order, 0, len(orders))
+	for i, order := range orders {
+		if conflicted[i] {
+			sorted = append(sorted, order)
+		}
+	}
+	for i, order := range orders {
+		if !conflicted[i] {
+			sorted = append(sorted, order)
+		}
+	}
+	return sorted
+}
+
+// conflictMasks has, for each order, a bit set for every other order of
+// others it cannot be combined with
+func conflictMasks(checker domain.ConstraintChecker, orders, others []domain.Order) []uint32 {
+	masks := make([]uint32, len(orders))
+	for i := range orders {
+		for j := range others {
+			if &orders[i] != &others[j] && !checker.CanCombine(orders[i], others[j]) {
+				masks[i] |= 1 << j
+			}
+		}
+	}
+	return masks
+}
+
+// conflictGroup holds the subsets whose members conflict with the same
+// orders of the other half, marked in forbidden
+type conflictGroup struct {
+	forbidden uint32
+	subsets   []subset
+}
+
+// groupByConflicts groups subsets by the orders their members conflict with,
+// in order of first appearance and keeping their sequence within each group
+func groupByConflicts(subsets []subset, conflicts []uint32) []conflictGroup {
+	groups := make([]conflictGroup, 0)
+	index := make(map[uint32]int)
+	for _, s := range subsets {
+		var forbidden uint32
+		for i, mask := range conflicts {
+			if s.mask&(1<<i) != 0 {
+				forbidden |= mask
+			}
+		}
+		if _, ok := index[forbidden]; !ok {
+			index[forbidden] = len(groups)
+			groups = append(groups, conflictGroup{forbidden: forbidden})
+		}
+		groups[index[forbidden]].subsets = append(groups[index[forbidden]].subsets, s)
+	}
+	return groups
+}
+
+// enumerateSubsets lists every subset of orders that fits the truck on its
+// own and holds no two orders whose conflicts masks exclude each other
+func enumerateSubsets(ctx context.Context, truck domain.Truck, orders []domain.Order, conflicts []uint32) ([]subset, bool) {
+	subsets := []subset{{}}
+	for i, order := range orders {
+		if ctx.Err() != nil {
+			return nil, false
+		}
+		
+		count := len(subsets)
+		for _, s := range subsets[:count] {
+			weight := s.weight + int32(order.WeightLbs)
+			volume := s.volume + int32(order.VolumeCuft)
+			if int(weight) > truck.MaxWeightLbs || int(volume) > truck.MaxVolumeCuft ||
+				!truck.FitsMoreOrders(int(s.count)) || s.mask&conflicts[i] != 0 {
+				continue
+			}
+			subsets = append(subsets, subset{
+				weight: weight,
+				volume: volume,
+				payout: s.payout + int64(order.Score),
+				mask:   s.mask | 1<<i,
+				count:  s.count + 1,
+			})
+		}
+	}
+	return subsets, true
+}
+
+func uniqueInt32(sorted []int32) []int32 {
+	unique := sorted[:0]
+	for i, v := range sorted {
+		if i == 0 || v != sorted[i-1] {
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// maxFenwick answers prefix-maximum queries over payout, remembering the subset that achieved it
+type maxFenwick struct {
+	payout []int64
+	mask   []uint32
+}
+
+func newMaxFenwick(size int) *maxFenwick {
+	f := &maxFenwick{
+		payout: make([]int64, size+1),
+		mask:   make([]uint32, size+1),
+	}
+	for i := range f.payout {
+		f.payout[i] = -1
+	}
+	return f
+}
+
+func (f *maxFenwick) update(position int, payout int64, mask uint32) {
+	for i := position + 1; i < len(f.payout); i += i & -i {
+		if payout > f.payout[i] {
+			f.payout[i] = payout
+			f.mask[i] = mask
+		}
+	}
+}
+
+func (f *maxFenwick) prefixMax(position int) (int64, uint32) {
+	var best int64 = -1
+	var mask uint32
+	for i := position + 1; i > 0; i -= i & -i {
+		if f.payout[i] > best {
+			best = f.payout[i]
+			mask = f.mask[i]
+		}
+	}
+	return best, mask
+}
@@ -0,0 +1,28 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestMeetInTheMiddleMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewMeetInTheMiddleOptimizer(), 300)
+}
+
+func TestMeetInTheMiddleMatchesBranchAndBound(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(6))
+	
+	// Beyond the DP's reach, compare two exact algorithms with each other
+	for i := 0; i < 10; i++ {
+		orders := randomOrders(r, 23+r.Intn(8))
+		want := NewBranchAndBoundOptimizer().Optimize(ctx, testTruck, orders)
+		got := NewMeetInTheMiddleOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, got)
+		if !got.Optimal || !want.Optimal || got.TotalScore != want.TotalScore {
+			t.Fatalf("instance %d: meet in the middle %d (optimal %v), branch and bound %d (optimal %v)",
+				i, got.TotalScore, got.Optimal, want.TotalScore, want.Optimal)
+		}
+	}
+}
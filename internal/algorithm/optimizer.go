@@ -1,20 +1,88 @@
 package algorithm
 
 import (
+	"context"
+	"math/bits"
 	"smart-load/internal/domain"
+	"sort"
 	"time"
 )
 
+// Optimizer selects the best load for a truck. Implementations stop early when
+// ctx is cancelled; the result is then incomplete and callers should check ctx.Err().
 type Optimizer interface {
-	Optimize(truck domain.Truck, orders []domain.Order) OptimizationResult
+	Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult
 }
 
+// constrained is implemented by optimizers that decide which orders may share
+// a truck with a domain.ConstraintChecker
+type constrained interface {
+	withChecker(checker domain.ConstraintChecker) Optimizer
+}
+
+// WithChecker returns a copy of optimizer that decides which orders may share
+// a truck with checker instead of domain.NewConstraintChecker. Wrapping
+// optimizers pass checker on to the ones they wrap.
+func WithChecker(optimizer Optimizer, checker domain.ConstraintChecker) Optimizer {
+	if c, ok := optimizer.(constrained); ok {
+		return c.withChecker(checker)
+	}
+	return optimizer
+}
+
+// cancelCheckInterval is how many loop iterations run between context checks
+const cancelCheckInterval = 4096
+
+// OptimizationResult reports true payout alongside TotalScore, the sum of the
+// order scores the optimizer actually maximized
 type OptimizationResult struct {
 	SelectedOrders []domain.Order
 	TotalPayout    domain.Money
+	TotalScore     domain.Score
 	TotalWeight    int
 	TotalVolume    int
 	ComputeTimeMs  int64
+	Algorithm      string
+	// Optimal is true when the algorithm guarantees the best feasible plan
+	Optimal bool
+	// Fractions holds the share loaded of each order taken in part, keyed by
+	// order ID; those orders appear in SelectedOrders scaled to that share
+	Fractions map[string]float64
+	// Portfolio reports how each member of a portfolio run fared
+	Portfolio []PortfolioOutcome
+}
+
+// totalPayout sums what the shippers pay for a selection
+func totalPayout(orders []domain.Order) domain.Money {
+	total := domain.Money(0)
+	for _, order := range orders {
+		total = total.Add(order.Payout)
+	}
+	return total
+}
+
+// Rescore re-totals a plan solved over rewritten scores with the scores of
+// orders, matched by ID; orders taken in part keep their fraction
+func Rescore(result OptimizationResult, orders []domain.Order) OptimizationResult {
+	byID := make(map[string]domain.Order, len(orders))
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+	
+	selected := make([]domain.Order, len(result.SelectedOrders))
+	for i, order := range result.SelectedOrders {
+		selected[i] = byID[order.ID]
+		if fraction, ok := result.Fractions[order.ID]; ok {
+			selected[i], _ = portion(selected[i], fraction)
+		}
+	}
+	rescored := summarize(selected)
+	rescored.ComputeTimeMs = result.ComputeTimeMs
+	rescored.Algorithm = result.Algorithm
+	rescored.Optimal = result.Optimal
+	rescored.Fractions = result.Fractions
+	rescored.Portfolio = result.Portfolio
+	return rescored
 }
 
 // DPOptimizer uses dynamic programming with bitmask for n <= 22
@@ -30,7 +98,13 @@ func NewDPOptimizer() *DPOptimizer {
 	}
 }
 
-func (dp *DPOptimizer) Optimize(truck domain.Truck, orders []domain.Order) OptimizationResult {
+func (o *DPOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+func (dp *DPOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
 	startTime := time.Now()
 	
 	if len(orders) == 0 {
@@ -40,6 +114,8 @@ func (dp *DPOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Optim
 			TotalWeight:    0,
 			TotalVolume:    0,
 			ComputeTimeMs:  0,
+			Algorithm:      "dp",
+			Optimal:        true,
 		}
 	}
 	
@@ -51,9 +127,61 @@ func (dp *DPOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Optim
 			TotalWeight:    0,
 			TotalVolume:    0,
 			ComputeTimeMs:  time.Since(startTime).Milliseconds(),
+			Algorithm:      "dp",
+			Optimal:        true,
+		}
+	}
+	
+	table, ok := dp.buildTable(ctx, truck, orders)
+	if !ok {
+		return OptimizationResult{
+			SelectedOrders: []domain.Order{},
+			ComputeTimeMs:  time.Since(startTime).Milliseconds(),
+			Algorithm:      "dp",
+		}
+	}
+	dpPayout, dpWeight, dpVolume, dpValid := table.payout, table.weight, table.volume, table.valid
+	maxStates := len(dpValid)
+	
+	var bestPayout int64 = 0
+	bestMask := 0
+	
+	for mask := 0; mask < maxStates; mask++ {
+		if dpValid[mask] && dpPayout[mask] > bestPayout {
+			bestPayout = dpPayout[mask]
+			bestMask = mask
 		}
 	}
 	
+	selectedOrders := dp.extractOrders(bestMask, orders)
+	
+	computeTime := time.Since(startTime).Milliseconds()
+	
+	return OptimizationResult{
+		SelectedOrders: selectedOrders,
+		TotalPayout:    totalPayout(selectedOrders),
+		TotalScore:     domain.Score(bestPayout),
+		TotalWeight:    dpWeight[bestMask],
+		TotalVolume:    dpVolume[bestMask],
+		ComputeTimeMs:  computeTime,
+		Algorithm:      "dp",
+		Optimal:        true,
+	}
+}
+
+// dpTable holds every feasible, mutually compatible subset of up to 22 orders
+type dpTable struct {
+	payout []int64
+	weight []int
+	volume []int
+	floor  []domain.FloorSpace
+	valid  []bool
+	// incompatible[i] is the mask of orders that cannot share a truck with order i
+	incompatible []int
+}
+
+// buildTable fills the subset table, returning false when ctx is cancelled
+func (dp *DPOptimizer) buildTable(ctx context.Context, truck domain.Truck, orders []domain.Order) (*dpTable, bool) {
 	n := len(orders)
 	
 	// Precompute incompatibility bitmasks for O(1) compatibility checks
@@ -71,15 +199,24 @@ func (dp *DPOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Optim
 	dpPayout := make([]int64, maxStates)
 	dpWeight := make([]int, maxStates)
 	dpVolume := make([]int, maxStates)
+	dpFloor := make([]domain.FloorSpace, maxStates)
 	dpValid := make([]bool, maxStates)
 	
 	dpValid[0] = true
 	
 	for mask := 0; mask < maxStates; mask++ {
+		if mask%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, false
+		}
+		
 		if !dpValid[mask] {
 			continue
 		}
 		
+		if !truck.FitsMoreOrders(bits.OnesCount(uint(mask))) {
+			continue
+		}
+		
 		currentWeight := dpWeight[mask]
 		currentVolume := dpVolume[mask]
 		
@@ -96,43 +233,161 @@ func (dp *DPOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Optim
 			
 			newWeight := currentWeight + order.WeightLbs
 			newVolume := currentVolume + order.VolumeCuft
-			if newWeight > truck.MaxWeightLbs || newVolume > truck.MaxVolumeCuft {
+			if newWeight > truck.MaxWeightLbs || newVolume > truck.MaxVolumeCuft || !truck.FitsFloor(dpFloor[mask], order) {
 				continue
 			}
 			
 			newMask := mask | (1 << i)
-			newPayout := dpPayout[mask] + int64(order.Payout)
+			newPayout := dpPayout[mask] + int64(order.Score)
 			
 			if !dpValid[newMask] || newPayout > dpPayout[newMask] {
 				dpPayout[newMask] = newPayout
 				dpWeight[newMask] = newWeight
 				dpVolume[newMask] = newVolume
+				dpFloor[newMask] = dpFloor[mask].Add(order)
 				dpValid[newMask] = true
 			}
 		}
 	}
 	
-	var bestPayout int64 = 0
-	bestMask := 0
+	return &dpTable{
+		payout:       dpPayout,
+		weight:       dpWeight,
+		volume:       dpVolume,
+		floor:        dpFloor,
+		valid:        dpValid,
+		incompatible: incompatibleMask,
+	}, true
+}
+
+// TopK returns up to k distinct plans in descending payout order. Only maximal
+// plans are listed, ones no further order can join, so a backup plan is never
+// just the optimum with an order dropped.
+func (dp *DPOptimizer) TopK(ctx context.Context, truck domain.Truck, orders []domain.Order, k int) []OptimizationResult {
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	if len(orders) == 0 || k <= 0 {
+		return []OptimizationResult{}
+	}
 	
-	for mask := 0; mask < maxStates; mask++ {
-		if dpValid[mask] && dpPayout[mask] > bestPayout {
-			bestPayout = dpPayout[mask]
-			bestMask = mask
+	table, ok := dp.buildTable(ctx, truck, orders)
+	if !ok {
+		return []OptimizationResult{}
+	}
+	
+	best := make([]int, 0, k+1)
+	for mask := 1; mask < len(table.valid); mask++ {
+		if mask%cancelCheckInterval == 0 && ctx.Err() != nil {
+			break
+		}
+		if !table.valid[mask] || !table.isMaximal(mask, truck, orders) {
+			continue
+		}
+		if len(best) == k && table.payout[mask] <= table.payout[best[k-1]] {
+			continue
+		}
+		
+		// best stays sorted by payout; k is small so insertion is cheap
+		position := sort.Search(len(best), func(i int) bool {
+			return table.payout[best[i]] < table.payout[mask]
+		})
+		best = append(best, 0)
+		copy(best[position+1:], best[position:])
+		best[position] = mask
+		if len(best) > k {
+			best = best[:k]
 		}
 	}
 	
-	selectedOrders := dp.extractOrders(bestMask, orders)
+	plans := make([]OptimizationResult, 0, len(best))
+	for _, mask := range best {
+		selected := dp.extractOrders(mask, orders)
+		plans = append(plans, OptimizationResult{
+			SelectedOrders: selected,
+			TotalPayout:    totalPayout(selected),
+			TotalScore:     domain.Score(table.payout[mask]),
+			TotalWeight:    table.weight[mask],
+			TotalVolume:    table.volume[mask],
+			Algorithm:      "dp",
+			Optimal:        true,
+		})
+	}
+	return plans
+}
+
+// ParetoFrontier returns every plan that is Pareto-optimal between payout and
+// utilization (the mean of weight and volume fill), by epsilon-constraint over
+// the DP table: for each achievable utilization level it keeps the best payout
+// among plans at least that full, and a level joins the frontier only when it
+// pays more than every fuller level. Plans are returned fullest first.
+func (dp *DPOptimizer) ParetoFrontier(ctx context.Context, truck domain.Truck, orders []domain.Order) ([]OptimizationResult, bool) {
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	if len(orders) == 0 {
+		return []OptimizationResult{}, true
+	}
 	
-	computeTime := time.Since(startTime).Milliseconds()
+	table, ok := dp.buildTable(ctx, truck, orders)
+	if !ok {
+		return nil, false
+	}
 	
-	return OptimizationResult{
-		SelectedOrders: selectedOrders,
-		TotalPayout:    domain.Money(bestPayout),
-		TotalWeight:    dpWeight[bestMask],
-		TotalVolume:    dpVolume[bestMask],
-		ComputeTimeMs:  computeTime,
+	// w/W + v/V scaled by W*V, so utilization levels compare exactly
+	utilization := func(mask int) int64 {
+		return int64(table.weight[mask])*int64(truck.MaxVolumeCuft) + int64(table.volume[mask])*int64(truck.MaxWeightLbs)
+	}
+	
+	masks := make([]int, 0)
+	for mask := 1; mask < len(table.valid); mask++ {
+		if table.valid[mask] {
+			masks = append(masks, mask)
+		}
 	}
+	sort.Slice(masks, func(i, j int) bool {
+		ui, uj := utilization(masks[i]), utilization(masks[j])
+		if ui != uj {
+			return ui > uj
+		}
+		return table.payout[masks[i]] > table.payout[masks[j]]
+	})
+	
+	frontier := make([]OptimizationResult, 0)
+	var bestPayout int64 = -1
+	for i, mask := range masks {
+		if i%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, false
+		}
+		if table.payout[mask] <= bestPayout {
+			continue
+		}
+		bestPayout = table.payout[mask]
+		selected := dp.extractOrders(mask, orders)
+		frontier = append(frontier, OptimizationResult{
+			SelectedOrders: selected,
+			TotalPayout:    totalPayout(selected),
+			TotalScore:     domain.Score(table.payout[mask]),
+			TotalWeight:    table.weight[mask],
+			TotalVolume:    table.volume[mask],
+			Algorithm:      "dp",
+			Optimal:        true,
+		})
+	}
+	return frontier, true
+}
+
+// isMaximal reports whether no order outside mask can be added to it
+func (t *dpTable) isMaximal(mask int, truck domain.Truck, orders []domain.Order) bool {
+	if !truck.FitsMoreOrders(bits.OnesCount(uint(mask))) {
+		return true
+	}
+	for i, order := range orders {
+		if mask&(1<<i) != 0 || mask&t.incompatible[i] != 0 {
+			continue
+		}
+		if t.weight[mask]+order.WeightLbs <= truck.MaxWeightLbs && t.volume[mask]+order.VolumeCuft <= truck.MaxVolumeCuft &&
+			truck.FitsFloor(t.floor[mask], order) {
+			return false
+		}
+	}
+	return true
 }
 
 func (dp *DPOptimizer) isCompatibleWithState(mask int, orderIndex int, orders []domain.Order) bool {
@@ -176,7 +431,13 @@ func NewGreedyOptimizer() *GreedyOptimizer {
 	}
 }
 
-func (g *GreedyOptimizer) Optimize(truck domain.Truck, orders []domain.Order) OptimizationResult {
+func (o *GreedyOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+func (g *GreedyOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
 	startTime := time.Now()
 	
 	orders = domain.FilterFeasibleOrders(truck, orders)
@@ -186,10 +447,14 @@ func (g *GreedyOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Op
 	selected := make([]domain.Order, 0)
 	totalWeight := 0
 	totalVolume := 0
-	totalPayout := domain.Money(0)
+	var floor domain.FloorSpace
+	totalScore := domain.Score(0)
 	
 	for _, order := range sortedOrders {
-		if !g.checker.CanFit(truck, totalWeight, totalVolume, order) {
+		if !truck.FitsMoreOrders(len(selected)) {
+			break
+		}
+		if !g.checker.CanFit(truck, totalWeight, totalVolume, order) || !truck.FitsFloor(floor, order) {
 			continue
 		}
 		
@@ -205,16 +470,20 @@ func (g *GreedyOptimizer) Optimize(truck domain.Truck, orders []domain.Order) Op
 			selected = append(selected, order)
 			totalWeight += order.WeightLbs
 			totalVolume += order.VolumeCuft
-			totalPayout = totalPayout.Add(order.Payout)
+			floor = floor.Add(order)
+			totalScore = totalScore.Add(order.Score)
 		}
 	}
 	
 	return OptimizationResult{
 		SelectedOrders: selected,
-		TotalPayout:    totalPayout,
+		TotalPayout:    totalPayout(selected),
+		TotalScore:     totalScore,
 		TotalWeight:    totalWeight,
 		TotalVolume:    totalVolume,
 		ComputeTimeMs:  time.Since(startTime).Milliseconds(),
+		Algorithm:      "greedy",
+		Optimal:        false,
 	}
 }
 
@@ -224,8 +493,8 @@ func (g *GreedyOptimizer) sortByValueDensity(orders []domain.Order) []domain.Ord
 	
 	for i := 0; i < len(sorted)-1; i++ {
 		for j := i + 1; j < len(sorted); j++ {
-			density_i := float64(sorted[i].Payout) / float64(sorted[i].WeightLbs)
-			density_j := float64(sorted[j].Payout) / float64(sorted[j].WeightLbs)
+			density_i := float64(sorted[i].Score) / float64(sorted[i].WeightLbs)
+			density_j := float64(sorted[j].Score) / float64(sorted[j].WeightLbs)
 			
 			if density_j > density_i {
 				sorted[i], sorted[j] = sorted[j], sorted[i]
@@ -239,10 +508,14 @@ func (g *GreedyOptimizer) sortByValueDensity(orders []domain.Order) []domain.Ord
 // BacktrackingOptimizer uses recursive backtracking with pruning
 type BacktrackingOptimizer struct {
 	checker    domain.ConstraintChecker
-	bestPayout domain.Money
+	bestPayout domain.Score
 	bestOrders []domain.Order
 	bestWeight int
 	bestVolume int
+	ctx        context.Context
+	nodes      int
+	cancelled  bool
+	progress   *progressReporter
 }
 
 func NewBacktrackingOptimizer() *BacktrackingOptimizer {
@@ -251,25 +524,39 @@ func NewBacktrackingOptimizer() *BacktrackingOptimizer {
 	}
 }
 
-func (b *BacktrackingOptimizer) Optimize(truck domain.Truck, orders []domain.Order) OptimizationResult {
+func (o *BacktrackingOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+func (b *BacktrackingOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
 	startTime := time.Now()
 	
 	orders = domain.FilterFeasibleOrders(truck, orders)
 	
+	b.ctx = ctx
+	b.nodes = 0
+	b.cancelled = false
 	b.bestPayout = 0
 	b.bestOrders = []domain.Order{}
 	b.bestWeight = 0
 	b.bestVolume = 0
+	b.progress = newProgressReporter(ctx, "backtracking")
 	
 	currentOrders := []domain.Order{}
 	b.backtrack(truck, orders, currentOrders, 0, 0, 0, 0)
+	b.progress.finish()
 	
 	return OptimizationResult{
 		SelectedOrders: b.bestOrders,
-		TotalPayout:    b.bestPayout,
+		TotalPayout:    totalPayout(b.bestOrders),
+		TotalScore:     b.bestPayout,
 		TotalWeight:    b.bestWeight,
 		TotalVolume:    b.bestVolume,
 		ComputeTimeMs:  time.Since(startTime).Milliseconds(),
+		Algorithm:      "backtracking",
+		Optimal:        !b.cancelled,
 	}
 }
 
@@ -278,10 +565,19 @@ func (b *BacktrackingOptimizer) backtrack(
 	orders []domain.Order,
 	currentOrders []domain.Order,
 	index int,
-	currentPayout domain.Money,
+	currentPayout domain.Score,
 	currentWeight int,
 	currentVolume int,
 ) {
+	b.nodes++
+	b.progress.visit()
+	if b.nodes%cancelCheckInterval == 0 && b.ctx.Err() != nil {
+		b.cancelled = true
+	}
+	if b.cancelled {
+		return
+	}
+	
 	// Update best solution if current is better
 	if currentPayout > b.bestPayout {
 		b.bestPayout = currentPayout
@@ -289,6 +585,7 @@ func (b *BacktrackingOptimizer) backtrack(
 		copy(b.bestOrders, currentOrders)
 		b.bestWeight = currentWeight
 		b.bestVolume = currentVolume
+		b.progress.improved(b.bestPayout, func() []domain.Order { return b.bestOrders })
 	}
 	
 	// Base case: tried all orders
@@ -297,9 +594,9 @@ func (b *BacktrackingOptimizer) backtrack(
 	}
 	
 	// Pruning: calculate upper bound for remaining orders
-	remainingPayout := domain.Money(0)
+	remainingPayout := domain.Score(0)
 	for i := index; i < len(orders); i++ {
-		remainingPayout += orders[i].Payout
+		remainingPayout += orders[i].Score
 	}
 	if currentPayout+remainingPayout <= b.bestPayout {
 		return // Prune this branch
@@ -310,7 +607,8 @@ func (b *BacktrackingOptimizer) backtrack(
 	newWeight := currentWeight + order.WeightLbs
 	newVolume := currentVolume + order.VolumeCuft
 	
-	canFit := newWeight <= truck.MaxWeightLbs && newVolume <= truck.MaxVolumeCuft
+	canFit := newWeight <= truck.MaxWeightLbs && newVolume <= truck.MaxVolumeCuft &&
+		truck.FitsMoreOrders(len(currentOrders))
 	compatible := true
 	
 	if canFit {
@@ -328,7 +626,7 @@ func (b *BacktrackingOptimizer) backtrack(
 				orders,
 				append(currentOrders, order),
 				index+1,
-				currentPayout+order.Payout,
+				currentPayout+order.Score,
 				newWeight,
 				newVolume,
 			)
@@ -339,23 +637,47 @@ func (b *BacktrackingOptimizer) backtrack(
 	b.backtrack(truck, orders, currentOrders, index+1, currentPayout, currentWeight, currentVolume)
 }
 
+// HybridOptimizer uses bitmask DP while it is cheap and switches to the
+// capacity-indexed knapsack DP for larger order lists. When the knapsack had to
+// scale capacities, local search refines its near-exact plan.
 type HybridOptimizer struct {
-	dpOptimizer     *DPOptimizer
-	greedyOptimizer *GreedyOptimizer
-	maxDPSize       int
+	dpOptimizer       *DPOptimizer
+	knapsackOptimizer *KnapsackOptimizer
+	localSearch       *LocalSearch
+	maxDPSize         int
 }
 
 func NewHybridOptimizer() *HybridOptimizer {
 	return &HybridOptimizer{
-		dpOptimizer:     NewDPOptimizer(),
-		greedyOptimizer: NewGreedyOptimizer(),
-		maxDPSize:       22,
+		dpOptimizer:       NewDPOptimizer(),
+		knapsackOptimizer: NewKnapsackOptimizer(),
+		localSearch:       NewLocalSearch(),
+		maxDPSize:         22,
 	}
 }
 
-func (h *HybridOptimizer) Optimize(truck domain.Truck, orders []domain.Order) OptimizationResult {
+func (h *HybridOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *h
+	constrained.dpOptimizer = h.dpOptimizer.withChecker(checker).(*DPOptimizer)
+	constrained.knapsackOptimizer = h.knapsackOptimizer.withChecker(checker).(*KnapsackOptimizer)
+	constrained.localSearch = h.localSearch.withChecker(checker)
+	return &constrained
+}
+
+func (h *HybridOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
 	if len(orders) <= h.maxDPSize {
-		return h.dpOptimizer.Optimize(truck, orders)
+		return h.dpOptimizer.Optimize(ctx, truck, orders)
 	}
-	return h.greedyOptimizer.Optimize(truck, orders)
+	
+	result := h.knapsackOptimizer.Optimize(ctx, truck, orders)
+	if result.Optimal || ctx.Err() != nil {
+		return result
+	}
+	
+	startTime := time.Now()
+	feasible := domain.FilterFeasibleOrders(truck, orders)
+	refined := summarize(h.localSearch.Improve(ctx, truck, feasible, result.SelectedOrders))
+	refined.Algorithm = "knapsack+ls"
+	refined.ComputeTimeMs = result.ComputeTimeMs + time.Since(startTime).Milliseconds()
+	return refined
 }
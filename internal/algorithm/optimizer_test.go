@@ -0,0 +1,194 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// bruteForcePlan is one feasible plan found by brute force
+type bruteForcePlan struct {
+	orders  []domain.Order
+	score   domain.Score
+	weight  int
+	volume  int
+	maximal bool
+}
+
+// feasibleSubsets enumerates every non-empty plan that fits the truck and its
+// order cap and combines only compatible orders
+func feasibleSubsets(truck domain.Truck, orders []domain.Order) []bruteForcePlan {
+	checker := domain.NewConstraintChecker()
+	fits := func(mask int) (bruteForcePlan, bool) {
+		var s bruteForcePlan
+		for i, order := range orders {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			for _, other := range s.orders {
+				if !checker.CanCombine(order, other) {
+					return bruteForcePlan{}, false
+				}
+			}
+			s.orders = append(s.orders, order)
+			s.score += order.Score
+			s.weight += order.WeightLbs
+			s.volume += order.VolumeCuft
+		}
+		return s, s.weight <= truck.MaxWeightLbs && s.volume <= truck.MaxVolumeCuft &&
+			(truck.MaxOrders == 0 || len(s.orders) <= truck.MaxOrders)
+	}
+	
+	subsets := make([]bruteForcePlan, 0)
+	for mask := 1; mask < 1<<len(orders); mask++ {
+		s, ok := fits(mask)
+		if !ok {
+			continue
+		}
+		s.maximal = true
+		for i := range orders {
+			if mask&(1<<i) != 0 {
+				continue
+			}
+			if _, ok := fits(mask | 1<<i); ok {
+				s.maximal = false
+				break
+			}
+		}
+		subsets = append(subsets, s)
+	}
+	return subsets
+}
+
+func planKey(orders []domain.Order) string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func TestTopKMatchesBruteForce(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(9))
+	
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, r.Intn(12))
+		k := 1 + r.Intn(6)
+		
+		scores := make([]domain.Score, 0)
+		for _, s := range feasibleSubsets(testTruck, orders) {
+			if s.maximal {
+				scores = append(scores, s.score)
+			}
+		}
+		sort.Slice(scores, func(a, b int) bool { return scores[a] > scores[b] })
+		if len(scores) > k {
+			scores = scores[:k]
+		}
+		
+		plans := NewDPOptimizer().TopK(ctx, testTruck, orders, k)
+		if len(plans) != len(scores) {
+			t.Fatalf("instance %d: %d plans, want %d", i, len(plans), len(scores))
+		}
+		seen := make(map[string]bool)
+		for rank, plan := range plans {
+			checkPlan(t, testTruck, plan)
+			if plan.TotalScore != scores[rank] {
+				t.Fatalf("instance %d rank %d: score %d, want %d", i, rank+1, plan.TotalScore, scores[rank])
+			}
+			key := planKey(plan.SelectedOrders)
+			if seen[key] {
+				t.Fatalf("instance %d: plan %s listed twice", i, key)
+			}
+			seen[key] = true
+		}
+	}
+}
+
+func TestTopKFirstPlanIsOptimal(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(10))
+	
+	for i := 0; i < 100; i++ {
+		orders := randomOrders(r, 1+r.Intn(18))
+		want := NewDPOptimizer().Optimize(ctx, testTruck, orders)
+		plans := NewDPOptimizer().TopK(ctx, testTruck, orders, 3)
+		if len(plans) == 0 || plans[0].TotalScore != want.TotalScore {
+			t.Fatalf("instance %d: top plan does not match the optimum %d", i, want.TotalScore)
+		}
+	}
+}
+
+func TestParetoFrontierMatchesBruteForce(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(11))
+	utilization := func(weight, volume int) int64 {
+		return int64(weight)*int64(testTruck.MaxVolumeCuft) + int64(volume)*int64(testTruck.MaxWeightLbs)
+	}
+	
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, r.Intn(12))
+		plans := feasibleSubsets(testTruck, orders)
+		
+		// A plan is on the frontier when no other plan is at least as full and
+		// pays at least as much, with one of the two strictly better
+		type point struct {
+			utilization int64
+			score       domain.Score
+		}
+		want := make(map[point]bool)
+		for _, p := range plans {
+			dominated := false
+			for _, q := range plans {
+				up, uq := utilization(p.weight, p.volume), utilization(q.weight, q.volume)
+				if uq >= up && q.score >= p.score && (uq > up || q.score > p.score) {
+					dominated = true
+					break
+				}
+			}
+			if !dominated {
+				want[point{utilization(p.weight, p.volume), p.score}] = true
+			}
+		}
+		
+		frontier, ok := NewDPOptimizer().ParetoFrontier(ctx, testTruck, orders)
+		if !ok {
+			t.Fatalf("instance %d: frontier aborted", i)
+		}
+		if len(frontier) != len(want) {
+			t.Fatalf("instance %d: %d frontier plans, want %d", i, len(frontier), len(want))
+		}
+		for j, plan := range frontier {
+			checkPlan(t, testTruck, plan)
+			p := point{utilization(plan.TotalWeight, plan.TotalVolume), plan.TotalScore}
+			if !want[p] {
+				t.Fatalf("instance %d: plan %s is not Pareto-optimal", i, planKey(plan.SelectedOrders))
+			}
+			if j > 0 {
+				prev := frontier[j-1]
+				if utilization(prev.TotalWeight, prev.TotalVolume) <= p.utilization || prev.TotalScore >= p.score {
+					t.Fatalf("instance %d: frontier is not ordered fullest first", i)
+				}
+			}
+		}
+	}
+}
+
+func TestParetoFrontierIncludesOptimum(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(12))
+	
+	for i := 0; i < 100; i++ {
+		orders := randomOrders(r, 1+r.Intn(18))
+		want := NewDPOptimizer().Optimize(ctx, testTruck, orders)
+		frontier, _ := NewDPOptimizer().ParetoFrontier(ctx, testTruck, orders)
+		if len(frontier) == 0 || frontier[len(frontier)-1].TotalScore != want.TotalScore {
+			t.Fatalf("instance %d: best-paying frontier plan does not match the optimum %d", i, want.TotalScore)
+		}
+	}
+}
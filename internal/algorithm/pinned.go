@@ -0,0 +1,83 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// PinnedOptimizer solves with some orders fixed into every plan. The pinned
+// orders take their share of the truck first and the inner optimizer fills the
+// remaining capacity, so any algorithm can honor must_include lists. Callers
+// must drop orders that cannot be combined with the pinned ones beforehand
+// (see domain.Pins.Apply); pinned orders absent from a call's input are ignored.
+type PinnedOptimizer struct {
+	inner     Optimizer
+	pinnedIDs []string
+}
+
+func NewPinnedOptimizer(inner Optimizer, pinnedIDs []string) *PinnedOptimizer {
+	return &PinnedOptimizer{inner: inner, pinnedIDs: pinnedIDs}
+}
+
+func (p *PinnedOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &PinnedOptimizer{inner: WithChecker(p.inner, checker), pinnedIDs: p.pinnedIDs}
+}
+
+func (p *PinnedOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	fixed, residual, rest := SplitPinned(truck, orders, p.pinnedIDs)
+	result := AddPinned(fixed, p.inner.Optimize(ctx, residual, rest))
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+// SplitPinned separates the pinned orders from the rest and returns the truck
+// capacity, and max_orders slots, left once they are loaded
+func SplitPinned(truck domain.Truck, orders []domain.Order, pinnedIDs []string) ([]domain.Order, domain.Truck, []domain.Order) {
+	pinned := make(map[string]bool, len(pinnedIDs))
+	for _, id := range pinnedIDs {
+		pinned[id] = true
+	}
+	
+	fixed := make([]domain.Order, 0, len(pinned))
+	rest := make([]domain.Order, 0, len(orders))
+	residual := truck
+	for _, order := range orders {
+		if pinned[order.ID] {
+			fixed = append(fixed, order)
+			residual.MaxWeightLbs -= order.WeightLbs
+			residual.MaxVolumeCuft -= order.VolumeCuft
+			continue
+		}
+		rest = append(rest, order)
+	}
+	// Residual MaxOrders of 0 would mean uncapped, so with no slot left there
+	// is nothing for the inner optimizer to choose from
+	if truck.MaxOrders > 0 {
+		residual.MaxOrders -= len(fixed)
+		if residual.MaxOrders <= 0 {
+			rest = rest[:0]
+		}
+	}
+	return fixed, residual, rest
+}
+
+// AddPinned puts the pinned orders back into a plan solved for the residual truck
+func AddPinned(fixed []domain.Order, result OptimizationResult) OptimizationResult {
+	if len(fixed) == 0 {
+		return result
+	}
+	
+	selected := make([]domain.Order, 0, len(fixed)+len(result.SelectedOrders))
+	selected = append(append(selected, fixed...), result.SelectedOrders...)
+	result.SelectedOrders = selected
+	for _, order := range fixed {
+		result.TotalPayout = result.TotalPayout.Add(order.Payout)
+		result.TotalScore = result.TotalScore.Add(order.Score)
+		result.TotalWeight += order.WeightLbs
+		result.TotalVolume += order.VolumeCuft
+	}
+	return result
+}
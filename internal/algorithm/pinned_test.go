@@ -0,0 +1,53 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestPinnedOptimizerMatchesBruteForce(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(13))
+	checker := domain.NewConstraintChecker()
+	
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, 1+r.Intn(11))
+		pinnedIDs := []string{orders[r.Intn(len(orders))].ID}
+		
+		candidates, err := domain.Pins{Include: pinnedIDs}.Apply(checker, testTruck, orders)
+		if err != nil {
+			t.Fatal(err)
+		}
+		
+		var want domain.Score
+		for _, plan := range feasibleSubsets(testTruck, orders) {
+			if containsOrder(plan.orders, pinnedIDs[0]) {
+				if plan.score > want {
+					want = plan.score
+				}
+			}
+		}
+		
+		for _, inner := range []Optimizer{NewDPOptimizer(), NewBranchAndBoundOptimizer(), NewGreedyLocalSearchOptimizer()} {
+			got := NewPinnedOptimizer(inner, pinnedIDs).Optimize(ctx, testTruck, candidates)
+			checkPlan(t, testTruck, got)
+			if !containsOrder(got.SelectedOrders, pinnedIDs[0]) {
+				t.Fatalf("instance %d: %s dropped pinned order %s", i, got.Algorithm, pinnedIDs[0])
+			}
+			if got.Optimal && got.TotalScore != want {
+				t.Fatalf("instance %d: %s score %d, best plan with the pinned order scores %d", i, got.Algorithm, got.TotalScore, want)
+			}
+		}
+	}
+}
+
+func containsOrder(orders []domain.Order, id string) bool {
+	for _, order := range orders {
+		if order.ID == id {
+			return true
+		}
+	}
+	return false
+}
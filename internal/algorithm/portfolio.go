@@ -0,0 +1,127 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sync"
+	"time"
+)
+
+// Portfolio member statuses
+const (
+	// PortfolioCompleted members returned before the run was stopped, or
+	// proved their own plan optimal
+	PortfolioCompleted = "completed"
+	// PortfolioDeadline members were still running at the shared deadline,
+	// so their plan is the best they had found by then
+	PortfolioDeadline = "deadline"
+	// PortfolioSuperseded members were still running when another member
+	// proved its plan optimal and were told to stop
+	PortfolioSuperseded = "superseded"
+)
+
+// PortfolioOutcome is how one member of a portfolio run fared
+type PortfolioOutcome struct {
+	Algorithm string
+	Status    string
+	// Selected is true for the member whose plan was returned
+	Selected      bool
+	Optimal       bool
+	TotalScore    domain.Score
+	OrderCount    int
+	ComputeTimeMs int64
+}
+
+// PortfolioOptimizer runs several algorithms at once under one shared
+// deadline and returns the best plan any of them found. Exact members
+// (bitmask or knapsack DP, branch and bound) may prove optimality on easy
+// inputs; greedy and simulated annealing always have a plan ready when the
+// exact ones run out of time. Every member's plan is feasible, so the
+// deadline only costs quality, never correctness. As soon as a member
+// proves its plan optimal the others are stopped.
+type PortfolioOptimizer struct {
+	members []Optimizer
+	// budget is the shared deadline, counted from the start of the run
+	budget time.Duration
+}
+
+func NewPortfolioOptimizer() *PortfolioOptimizer {
+	return &PortfolioOptimizer{
+		members: []Optimizer{
+			NewHybridOptimizer(),
+			NewBranchAndBoundOptimizer(),
+			NewGreedyOptimizer(),
+			NewSimulatedAnnealingOptimizer(),
+		},
+		budget: 2 * time.Second,
+	}
+}
+
+func (p *PortfolioOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *p
+	constrained.members = make([]Optimizer, len(p.members))
+	for i, member := range p.members {
+		constrained.members[i] = WithChecker(member, checker)
+	}
+	return &constrained
+}
+
+func (p *PortfolioOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	deadline, cancelDeadline := context.WithTimeout(ctx, p.budget)
+	defer cancelDeadline()
+	// proven stops the other members once one finds a provably optimal plan
+	proven, stop := context.WithCancel(deadline)
+	defer stop()
+	
+	results := make([]OptimizationResult, len(p.members))
+	statuses := make([]string, len(p.members))
+	var wg sync.WaitGroup
+	for i, member := range p.members {
+		wg.Add(1)
+		go func(i int, member Optimizer) {
+			defer wg.Done()
+			results[i] = member.Optimize(proven, truck, orders)
+			switch {
+			case results[i].Optimal:
+				statuses[i] = PortfolioCompleted
+				stop()
+			case deadline.Err() != nil:
+				statuses[i] = PortfolioDeadline
+			case proven.Err() != nil:
+				statuses[i] = PortfolioSuperseded
+			default:
+				statuses[i] = PortfolioCompleted
+			}
+		}(i, member)
+	}
+	wg.Wait()
+	
+	// Highest score wins; ties go to a proven plan, then to the earlier member
+	best := 0
+	for i, result := range results {
+		if result.TotalScore > results[best].TotalScore ||
+			(result.TotalScore == results[best].TotalScore && result.Optimal && !results[best].Optimal) {
+			best = i
+		}
+	}
+	
+	outcomes := make([]PortfolioOutcome, len(results))
+	for i, result := range results {
+		outcomes[i] = PortfolioOutcome{
+			Algorithm:     result.Algorithm,
+			Status:        statuses[i],
+			Selected:      i == best,
+			Optimal:       result.Optimal,
+			TotalScore:    result.TotalScore,
+			OrderCount:    len(result.SelectedOrders),
+			ComputeTimeMs: result.ComputeTimeMs,
+		}
+	}
+	
+	result := results[best]
+	result.Portfolio = outcomes
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
@@ -0,0 +1,77 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestPortfolioMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewPortfolioOptimizer(), 100)
+}
+
+func TestPortfolioReportsEveryMember(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(25))
+	portfolio := NewPortfolioOptimizer()
+	
+	for i := 0; i < 20; i++ {
+		orders := randomOrders(r, 1+r.Intn(18))
+		got := portfolio.Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, got)
+		if !got.Optimal {
+			t.Fatalf("instance %d: DP member should prove %d orders optimal", i, len(orders))
+		}
+		if len(got.Portfolio) != len(portfolio.members) {
+			t.Fatalf("instance %d: %d outcomes for %d members", i, len(got.Portfolio), len(portfolio.members))
+		}
+		
+		selected := 0
+		for _, outcome := range got.Portfolio {
+			if outcome.TotalScore > got.TotalScore {
+				t.Fatalf("instance %d: %s scored %d, above the returned %d", i, outcome.Algorithm, outcome.TotalScore, got.TotalScore)
+			}
+			if outcome.Selected {
+				selected++
+				if outcome.Algorithm != got.Algorithm || outcome.TotalScore != got.TotalScore {
+					t.Fatalf("instance %d: selected outcome %+v does not match the result", i, outcome)
+				}
+			}
+			if outcome.Status != PortfolioCompleted && outcome.Status != PortfolioSuperseded {
+				t.Fatalf("instance %d: %s status %q without a deadline", i, outcome.Algorithm, outcome.Status)
+			}
+		}
+		if selected != 1 {
+			t.Fatalf("instance %d: %d outcomes selected", i, selected)
+		}
+	}
+}
+
+func TestPortfolioKeepsTheSharedDeadline(t *testing.T) {
+	r := rand.New(rand.NewSource(26))
+	orders := randomOrders(r, 1000)
+	portfolio := NewPortfolioOptimizer()
+	portfolio.budget = 50 * time.Millisecond
+	
+	startTime := time.Now()
+	got := portfolio.Optimize(context.Background(), testTruck, orders)
+	// Generous, since member setup such as splitting compatibility classes
+	// runs before the first deadline check
+	if elapsed := time.Since(startTime); elapsed > 5*time.Second {
+		t.Fatalf("portfolio ran %v on a %v deadline", elapsed, portfolio.budget)
+	}
+	checkPlan(t, testTruck, got)
+	
+	greedy := NewGreedyOptimizer().Optimize(context.Background(), testTruck, orders)
+	if got.TotalScore < greedy.TotalScore {
+		t.Fatalf("portfolio score %d is below its greedy member's %d", got.TotalScore, greedy.TotalScore)
+	}
+	cut := false
+	for _, outcome := range got.Portfolio {
+		cut = cut || outcome.Status == PortfolioDeadline
+	}
+	if !cut {
+		t.Errorf("no member was cut short on 1000 orders in %v: %+v", portfolio.budget, got.Portfolio)
+	}
+}
@@ -0,0 +1,117 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// PriorityOptimizer solves priority tiers lexicographically: the highest
+// tier is planned on its own, its plan is fixed, and each lower tier fills
+// the capacity left with orders compatible with everything already loaded.
+// No payout in a lower tier can displace an order of a higher one. Within a
+// tier the inner optimizer decides, and of several equally good plans for a
+// tier the first one found is kept even if another would leave more room
+// below, so plans spanning tiers are not reported optimal.
+type PriorityOptimizer struct {
+	inner   Optimizer
+	checker domain.ConstraintChecker
+}
+
+func NewPriorityOptimizer(inner Optimizer) *PriorityOptimizer {
+	return &PriorityOptimizer{inner: inner, checker: domain.NewConstraintChecker()}
+}
+
+func (p *PriorityOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &PriorityOptimizer{inner: WithChecker(p.inner, checker), checker: checker}
+}
+
+func (p *PriorityOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	tiers := make(map[int][]domain.Order)
+	for _, order := range orders {
+		tiers[order.Priority] = append(tiers[order.Priority], order)
+	}
+	if len(tiers) <= 1 {
+		return p.inner.Optimize(ctx, truck, orders)
+	}
+	levels := make([]int, 0, len(tiers))
+	for level := range tiers {
+		levels = append(levels, level)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	
+	fixed := make([]domain.Order, 0)
+	residual := truck
+	algorithm := ""
+	for _, level := range levels {
+		// A residual MaxOrders of 0 would mean uncapped, so stop at the cap
+		if ctx.Err() != nil || (truck.MaxOrders > 0 && len(fixed) >= truck.MaxOrders) {
+			break
+		}
+		candidates := make([]domain.Order, 0, len(tiers[level]))
+		for _, order := range tiers[level] {
+			if p.fitsWith(order, fixed) {
+				candidates = append(candidates, order)
+			}
+		}
+		
+		result := p.inner.Optimize(ctx, residual, candidates)
+		algorithm = result.Algorithm
+		fixed = append(fixed, result.SelectedOrders...)
+		residual.MaxWeightLbs -= result.TotalWeight
+		residual.MaxVolumeCuft -= result.TotalVolume
+		if truck.MaxOrders > 0 {
+			residual.MaxOrders = truck.MaxOrders - len(fixed)
+		}
+	}
+	
+	result := summarize(fixed)
+	result.Algorithm = algorithm
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+func (p *PriorityOptimizer) fitsWith(order domain.Order, selected []domain.Order) bool {
+	for _, other := range selected {
+		if !p.checker.CanCombine(order, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// ComparePriority orders plans lexicographically by the score they load in
+// each ranked priority tier, highest tier first. It returns a positive number
+// when a is better, negative when b is, and 0 when every ranked tier ties;
+// unranked orders are left for the caller's own tie-break.
+func ComparePriority(a, b OptimizationResult) int {
+	byTier := make(map[int]domain.Score)
+	for _, order := range a.SelectedOrders {
+		if order.Priority > 0 {
+			byTier[order.Priority] += order.Score
+		}
+	}
+	for _, order := range b.SelectedOrders {
+		if order.Priority > 0 {
+			byTier[order.Priority] -= order.Score
+		}
+	}
+	levels := make([]int, 0, len(byTier))
+	for level := range byTier {
+		levels = append(levels, level)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(levels)))
+	
+	for _, level := range levels {
+		if diff := byTier[level]; diff != 0 {
+			if diff > 0 {
+				return 1
+			}
+			return -1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,69 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestPriorityBeatsPayout(t *testing.T) {
+	truck := domain.Truck{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000}
+	orders := []domain.Order{
+		{ID: "contract", Payout: 10000, Score: 10000, WeightLbs: 6000, VolumeCuft: 100, Origin: "A", Destination: "B", Priority: 3},
+		{ID: "spot", Payout: 90000, Score: 90000, WeightLbs: 6000, VolumeCuft: 100, Origin: "A", Destination: "B"},
+		{ID: "filler", Payout: 5000, Score: 5000, WeightLbs: 3000, VolumeCuft: 100, Origin: "A", Destination: "B", Priority: 1},
+	}
+	
+	result := NewPriorityOptimizer(NewDPOptimizer()).Optimize(context.Background(), truck, orders)
+	checkPlan(t, truck, result)
+	if got := planKey(result.SelectedOrders); got != "contract,filler" {
+		t.Fatalf("selected %s, want contract,filler", got)
+	}
+}
+
+func TestPriorityServesTopTierOptimally(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(19))
+	
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, 1+r.Intn(14))
+		top := make([]domain.Order, 0)
+		for j := range orders {
+			orders[j].Priority = r.Intn(4)
+			if orders[j].Priority == 3 {
+				top = append(top, orders[j])
+			}
+		}
+		
+		result := NewPriorityOptimizer(NewDPOptimizer()).Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, result)
+		want := NewDPOptimizer().Optimize(ctx, testTruck, top)
+		var got domain.Score
+		for _, order := range result.SelectedOrders {
+			if order.Priority == 3 {
+				got += order.Score
+			}
+		}
+		if got != want.TotalScore {
+			t.Fatalf("instance %d: top tier loads %d, its optimum is %d", i, got, want.TotalScore)
+		}
+	}
+}
+
+func TestComparePriority(t *testing.T) {
+	plan := func(orders ...domain.Order) OptimizationResult { return summarize(orders) }
+	high := domain.Order{ID: "high", Score: 100, Priority: 5}
+	low := domain.Order{ID: "low", Score: 100000, Priority: 1}
+	unranked := domain.Order{ID: "unranked", Score: 100000}
+	
+	if ComparePriority(plan(high), plan(low)) <= 0 {
+		t.Error("a higher tier should beat any payout in a lower tier")
+	}
+	if ComparePriority(plan(low), plan(unranked)) <= 0 {
+		t.Error("a ranked order should beat an unranked one")
+	}
+	if ComparePriority(plan(high, unranked), plan(high)) != 0 {
+		t.Error("unranked orders should be left to the caller")
+	}
+}
@@ -0,0 +1,119 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// Progress is a snapshot of a running search: the best plan it has found so
+// far and how much of the search space it has covered
+type Progress struct {
+	Algorithm      string
+	SelectedOrders []domain.Order
+	Score          domain.Score
+	// Nodes counts the search tree nodes visited, or the moves tried by
+	// metaheuristics
+	Nodes   int64
+	Elapsed time.Duration
+}
+
+const (
+	// progressInterval is the least time between two reports of one search
+	progressInterval = 100 * time.Millisecond
+	// progressCheckInterval is how many nodes are visited between clock reads
+	progressCheckInterval = 256
+)
+
+type progressKey struct{}
+
+// WithProgress returns a context under which backtracking, branch and bound,
+// annealing and tabu search hand their progress to report: at once when they
+// find their first plan, then at most every 100ms. Each Optimize call reports
+// on its own, and members of a portfolio report concurrently, so report must
+// be safe for concurrent use.
+func WithProgress(ctx context.Context, report func(Progress)) context.Context {
+	return context.WithValue(ctx, progressKey{}, report)
+}
+
+// progressReporter throttles the reports of one search. A nil reporter, for
+// searches nobody watches, ignores every call.
+type progressReporter struct {
+	report    func(Progress)
+	algorithm string
+	start     time.Time
+	last      time.Time
+	nodes     int64
+	found     bool
+	best      domain.Score
+	// selected builds the best plan; it is only called when a report goes out
+	selected func() []domain.Order
+	pending  bool
+}
+
+func newProgressReporter(ctx context.Context, algorithm string) *progressReporter {
+	report, _ := ctx.Value(progressKey{}).(func(Progress))
+	if report == nil {
+		return nil
+	}
+	return &progressReporter{report: report, algorithm: algorithm, start: time.Now()}
+}
+
+// visit counts one node
+func (p *progressReporter) visit() {
+	if p == nil {
+		return
+	}
+	p.nodes++
+	if p.nodes%progressCheckInterval == 0 {
+		p.flush(false)
+	}
+}
+
+// improved records a plan better than any seen before; selected returns it
+func (p *progressReporter) improved(score domain.Score, selected func() []domain.Order) {
+	if p == nil || (p.found && score <= p.best) {
+		return
+	}
+	p.found, p.best, p.selected, p.pending = true, score, selected, true
+	p.flush(false)
+}
+
+// flush reports when a plan has been found and the interval has passed since
+// the last report, or at once when forced or for the first plan
+func (p *progressReporter) flush(force bool) {
+	if !p.found {
+		return
+	}
+	now := time.Now()
+	if !force && !p.last.IsZero() && now.Sub(p.last) < progressInterval {
+		return
+	}
+	p.last, p.pending = now, false
+	p.report(Progress{
+		Algorithm:      p.algorithm,
+		SelectedOrders: p.selected(),
+		Score:          p.best,
+		Nodes:          p.nodes,
+		Elapsed:        now.Sub(p.start),
+	})
+}
+
+// finish reports an improvement the interval held back, so the last report
+// of a search always shows its best plan
+func (p *progressReporter) finish() {
+	if p != nil && p.pending {
+		p.flush(true)
+	}
+}
+
+// chosenOrders lists the orders whose chosen flag is set
+func chosenOrders(orders []domain.Order, chosen []bool) []domain.Order {
+	selected := make([]domain.Order, 0)
+	for i, in := range chosen {
+		if in {
+			selected = append(selected, orders[i])
+		}
+	}
+	return selected
+}
@@ -0,0 +1,50 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestSearchesReportProgress(t *testing.T) {
+	orders := randomOrders(rand.New(rand.NewSource(7)), 30)
+	optimizers := map[string]Optimizer{
+		"backtracking":     NewBacktrackingOptimizer(),
+		"branch_and_bound": NewBranchAndBoundOptimizer(),
+		"annealing":        NewSimulatedAnnealingOptimizer(),
+		"tabu":             NewTabuSearchOptimizer(0, 0),
+	}
+	for name, optimizer := range optimizers {
+		t.Run(name, func(t *testing.T) {
+			var reports []Progress
+			ctx := WithProgress(context.Background(), func(progress Progress) {
+				reports = append(reports, progress)
+			})
+			result := optimizer.Optimize(ctx, testTruck, orders)
+			if len(reports) == 0 {
+				t.Fatal("no progress reported")
+			}
+			
+			for i, report := range reports {
+				if report.Algorithm != name {
+					t.Errorf("report %d algorithm = %q, want %q", i, report.Algorithm, name)
+				}
+				if i > 0 && (report.Score < reports[i-1].Score || report.Nodes < reports[i-1].Nodes) {
+					t.Errorf("report %d went back: %+v after %+v", i, report, reports[i-1])
+				}
+				if got := summarize(report.SelectedOrders).TotalScore; got != report.Score {
+					t.Errorf("report %d plan scores %d, reported %d", i, got, report.Score)
+				}
+			}
+			if last := reports[len(reports)-1]; last.Score != result.TotalScore {
+				t.Errorf("last report score = %d, want the result's %d", last.Score, result.TotalScore)
+			}
+		})
+	}
+}
+
+func TestProgressNeedsWatcher(t *testing.T) {
+	if p := newProgressReporter(context.Background(), "tabu"); p != nil {
+		t.Fatalf("reporter = %+v without WithProgress, want nil", p)
+	}
+}
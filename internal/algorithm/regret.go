@@ -0,0 +1,141 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// RegretGreedyOptimizer builds a plan one order at a time like greedy, but
+// weighs what each choice rules out. An order's value is its own score plus an
+// estimate of what can still be loaded next to it: a fractional fill of the
+// remaining capacity with the orders it is compatible with. A dense order that
+// is incompatible with most of the pool therefore loses to a slightly less
+// dense one that keeps the pool open, which is where plain density sorting
+// falls furthest behind DP.
+type RegretGreedyOptimizer struct {
+	checker domain.ConstraintChecker
+	// lookahead is how many of the densest open orders are weighed per step
+	lookahead int
+}
+
+func NewRegretGreedyOptimizer() *RegretGreedyOptimizer {
+	return &RegretGreedyOptimizer{
+		checker:   domain.NewConstraintChecker(),
+		lookahead: 64,
+	}
+}
+
+func (o *RegretGreedyOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *o
+	constrained.checker = checker
+	return &constrained
+}
+
+func (g *RegretGreedyOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	n := len(orders)
+	
+	compatible := make([][]bool, n)
+	for i := range compatible {
+		compatible[i] = make([]bool, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			ok := g.checker.CanCombine(orders[i], orders[j])
+			compatible[i][j], compatible[j][i] = ok, ok
+		}
+	}
+	
+	// Density against both dimensions, so volume-heavy orders are not favored
+	byDensity := make([]int, n)
+	for i := range byDensity {
+		byDensity[i] = i
+	}
+	size := func(i int) float64 {
+		return float64(orders[i].WeightLbs)/float64(truck.MaxWeightLbs) +
+			float64(orders[i].VolumeCuft)/float64(truck.MaxVolumeCuft)
+	}
+	sort.SliceStable(byDensity, func(a, b int) bool {
+		return float64(orders[byDensity[a]].Score)*size(byDensity[b]) >
+			float64(orders[byDensity[b]].Score)*size(byDensity[a])
+	})
+	
+	open := make([]bool, n)
+	for i := range open {
+		open[i] = true
+	}
+	remainingWeight, remainingVolume := truck.MaxWeightLbs, truck.MaxVolumeCuft
+	
+	result := OptimizationResult{SelectedOrders: []domain.Order{}, Algorithm: "regret", Optimal: false}
+	
+	// estimate fractionally fills the capacity left after loading c with the
+	// open orders compatible with c, densest first
+	estimate := func(c int) float64 {
+		weight, volume := remainingWeight-orders[c].WeightLbs, remainingVolume-orders[c].VolumeCuft
+		value := 0.0
+		added := len(result.SelectedOrders) + 1
+		for _, i := range byDensity {
+			if !truck.FitsMoreOrders(added) {
+				break
+			}
+			if !open[i] || i == c || !compatible[c][i] {
+				continue
+			}
+			order := orders[i]
+			if order.WeightLbs <= weight && order.VolumeCuft <= volume {
+				value += float64(order.Score)
+				weight -= order.WeightLbs
+				volume -= order.VolumeCuft
+				added++
+				continue
+			}
+			fraction := 1.0
+			if order.WeightLbs > weight {
+				fraction = float64(weight) / float64(order.WeightLbs)
+			}
+			if order.VolumeCuft > volume {
+				fraction = min(fraction, float64(volume)/float64(order.VolumeCuft))
+			}
+			return value + fraction*float64(order.Score)
+		}
+		return value
+	}
+	
+	for ctx.Err() == nil && truck.FitsMoreOrders(len(result.SelectedOrders)) {
+		best, bestValue, weighed := -1, 0.0, 0
+		for _, c := range byDensity {
+			if !open[c] {
+				continue
+			}
+			if value := float64(orders[c].Score) + estimate(c); best < 0 || value > bestValue {
+				best, bestValue = c, value
+			}
+			if weighed++; weighed == g.lookahead {
+				break
+			}
+		}
+		if best < 0 {
+			break
+		}
+		
+		chosen := orders[best]
+		result.SelectedOrders = append(result.SelectedOrders, chosen)
+		remainingWeight -= chosen.WeightLbs
+		remainingVolume -= chosen.VolumeCuft
+		open[best] = false
+		for i := range open {
+			if open[i] && (!compatible[best][i] || orders[i].WeightLbs > remainingWeight || orders[i].VolumeCuft > remainingVolume) {
+				open[i] = false
+			}
+		}
+	}
+	
+	summary := summarize(result.SelectedOrders)
+	summary.Algorithm = result.Algorithm
+	summary.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return summary
+}
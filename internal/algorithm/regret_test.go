@@ -0,0 +1,38 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestRegretGreedyProducesValidPlans(t *testing.T) {
+	checkMatchesDP(t, NewRegretGreedyOptimizer(), 300)
+}
+
+// With two lanes and hazmat in the pool most pairs are incompatible, which is
+// where weighing what an order rules out should beat plain density sorting
+func TestRegretGreedyClosesGapToDP(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(11))
+	
+	var greedyTotal, regretTotal, dpTotal int64
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, 8+r.Intn(12))
+		greedy := NewGreedyOptimizer().Optimize(ctx, testTruck, orders)
+		regret := NewRegretGreedyOptimizer().Optimize(ctx, testTruck, orders)
+		dp := NewDPOptimizer().Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, regret)
+		if regret.TotalScore > dp.TotalScore {
+			t.Fatalf("instance %d: regret score %d beats the optimum %d", i, regret.TotalScore, dp.TotalScore)
+		}
+		greedyTotal += int64(greedy.TotalScore)
+		regretTotal += int64(regret.TotalScore)
+		dpTotal += int64(dp.TotalScore)
+	}
+	if dpTotal-regretTotal >= dpTotal-greedyTotal {
+		t.Errorf("regret gap %d is no smaller than greedy gap %d", dpTotal-regretTotal, dpTotal-greedyTotal)
+	}
+	t.Logf("gap to DP: greedy %.2f%%, regret %.2f%%",
+		100*float64(dpTotal-greedyTotal)/float64(dpTotal), 100*float64(dpTotal-regretTotal)/float64(dpTotal))
+}
@@ -0,0 +1,147 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// bestUnderRules brute-forces the best plan score when checker decides
+// which orders may share the truck
+func bestUnderRules(truck domain.Truck, orders []domain.Order, checker domain.ConstraintChecker) domain.Score {
+	var best domain.Score
+	for mask := 1; mask < 1<<len(orders); mask++ {
+		var selected []domain.Order
+		weight, volume := 0, 0
+		var score domain.Score
+		for i, order := range orders {
+			if mask&(1<<i) != 0 {
+				selected = append(selected, order)
+				weight += order.WeightLbs
+				volume += order.VolumeCuft
+				score += order.Score
+			}
+		}
+		if weight <= truck.MaxWeightLbs && volume <= truck.MaxVolumeCuft && checker.ValidateOrderSet(selected) {
+			best = max(best, score)
+		}
+	}
+	return best
+}
+
+// checkRules fails the test unless result honors every rule of checker
+func checkRules(t *testing.T, checker domain.ConstraintChecker, result OptimizationResult) {
+	t.Helper()
+	if !checker.ValidateOrderSet(result.SelectedOrders) {
+		t.Fatalf("%s broke a rule with %v", result.Algorithm, planKey(result.SelectedOrders))
+	}
+}
+
+func TestEveryOptimizerTakesAChecker(t *testing.T) {
+	inner := NewDPOptimizer()
+	optimizers := []Optimizer{
+		NewDPOptimizer(),
+		NewGreedyOptimizer(),
+		NewBacktrackingOptimizer(),
+		NewHybridOptimizer(),
+		NewKnapsackOptimizer(),
+		NewBranchAndBoundOptimizer(),
+		NewMeetInTheMiddleOptimizer(),
+		NewGreedyLocalSearchOptimizer(),
+		NewRegretGreedyOptimizer(),
+		NewGRASPOptimizer(),
+		NewTabuSearchOptimizer(0, 0),
+		NewSimulatedAnnealingOptimizer(),
+		NewPortfolioOptimizer(),
+		NewPinnedOptimizer(inner, nil),
+		NewPriorityOptimizer(inner),
+		NewSplittableOptimizer(inner, false),
+	}
+	for _, optimizer := range optimizers {
+		if _, ok := optimizer.(constrained); !ok {
+			t.Errorf("%T ignores WithChecker", optimizer)
+		}
+	}
+}
+
+func TestPairRulesReachEveryOptimizer(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(27))
+	
+	for i := 0; i < 20; i++ {
+		orders := randomOrders(r, 2+r.Intn(9))
+		apart := map[string]bool{}
+		for _, order := range orders {
+			if r.Intn(2) == 0 {
+				apart[order.ID] = true
+			}
+		}
+		checker := domain.NewConstraintChecker().With([]domain.PairRule{domain.KeepApart{OrderIDs: apart}}, nil)
+		want := bestUnderRules(testTruck, orders, checker)
+		
+		for _, optimizer := range []Optimizer{NewDPOptimizer(), NewBacktrackingOptimizer()} {
+			if got := WithChecker(optimizer, checker).Optimize(ctx, testTruck, orders); got.TotalScore != want {
+				t.Fatalf("instance %d: %s scored %d under keep_apart, brute force %d", i, got.Algorithm, got.TotalScore, want)
+			}
+		}
+		for _, optimizer := range []Optimizer{
+			NewBranchAndBoundOptimizer(),
+			NewMeetInTheMiddleOptimizer(),
+			NewGreedyLocalSearchOptimizer(),
+			NewTabuSearchOptimizer(0, 0),
+			NewPortfolioOptimizer(),
+		} {
+			got := WithChecker(optimizer, checker).Optimize(ctx, testTruck, orders)
+			checkPlan(t, testTruck, got)
+			checkRules(t, checker, got)
+		}
+	}
+}
+
+func TestWithCheckerLeavesTheOriginalAlone(t *testing.T) {
+	original := NewGreedyOptimizer()
+	blockAll := domain.PairPredicate("never", func(a, b domain.Order) bool { return false })
+	WithChecker(original, domain.NewConstraintChecker().With([]domain.PairRule{blockAll}, nil))
+	
+	orders := randomOrders(rand.New(rand.NewSource(28)), 10)
+	for i := range orders {
+		orders[i].Destination = "Dallas, TX"
+		orders[i].IsHazmat = false
+		orders[i].WeightLbs = 1000
+		orders[i].VolumeCuft = 50
+	}
+	if got := original.Optimize(context.Background(), testTruck, orders); len(got.SelectedOrders) < 2 {
+		t.Fatalf("original optimizer picked up the copy's rule: %d orders", len(got.SelectedOrders))
+	}
+}
+
+func TestSetRuleOptimizerRepairsPlans(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(29))
+	
+	for i := 0; i < 50; i++ {
+		orders := randomOrders(r, 4+r.Intn(8))
+		for j := range orders {
+			orders[j].Shipper = fmt.Sprintf("shipper-%d", r.Intn(2))
+		}
+		checker := domain.NewConstraintChecker().With(nil, []domain.SetRule{domain.MaxOrdersPerShipper{Limit: 1}})
+		pinnedIDs := []string{orders[0].ID}
+		candidates, err := domain.Pins{Include: pinnedIDs}.Apply(checker, testTruck, orders)
+		if err != nil {
+			t.Fatal(err)
+		}
+		
+		inner := NewPinnedOptimizer(NewDPOptimizer(), pinnedIDs)
+		got := NewSetRuleOptimizer(inner, checker, pinnedIDs, false).Optimize(ctx, testTruck, candidates)
+		checkPlan(t, testTruck, got)
+		checkRules(t, checker, got)
+		if !containsOrder(got.SelectedOrders, pinnedIDs[0]) {
+			t.Fatalf("instance %d: repair dropped pinned order %s", i, pinnedIDs[0])
+		}
+		if got.Optimal && got.TotalScore != bestUnderRules(testTruck, candidates, checker) {
+			t.Fatalf("instance %d: repaired plan reported optimal", i)
+		}
+	}
+}
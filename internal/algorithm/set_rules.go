@@ -0,0 +1,109 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// SetRuleOptimizer enforces a checker's set rules, which the other optimizers
+// never see since they only ask whether two orders combine. When the inner
+// plan breaks a set rule, orders are dropped, lowest priority and density
+// first and pinned orders never, until it passes; the capacity freed is then
+// refilled greedily with orders that keep it passing. Set rules are monotone,
+// so the repair always ends in a valid plan. Repaired plans are not reported
+// optimal.
+type SetRuleOptimizer struct {
+	inner      Optimizer
+	checker    domain.ConstraintChecker
+	pinnedIDs  []string
+	byPriority bool
+}
+
+func NewSetRuleOptimizer(inner Optimizer, checker domain.ConstraintChecker, pinnedIDs []string, byPriority bool) *SetRuleOptimizer {
+	return &SetRuleOptimizer{inner: inner, checker: checker, pinnedIDs: pinnedIDs, byPriority: byPriority}
+}
+
+func (s *SetRuleOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	result := s.inner.Optimize(ctx, truck, orders)
+	if s.checker.ValidateOrderSet(result.SelectedOrders) {
+		return result
+	}
+	
+	density := func(order domain.Order) float64 {
+		size := float64(order.WeightLbs)/float64(truck.MaxWeightLbs) +
+			float64(order.VolumeCuft)/float64(truck.MaxVolumeCuft)
+		return float64(order.Score) / size
+	}
+	worse := func(a, b domain.Order) bool {
+		if s.byPriority && a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return density(a) < density(b)
+	}
+	pinned := make(map[string]bool, len(s.pinnedIDs))
+	for _, id := range s.pinnedIDs {
+		pinned[id] = true
+	}
+	
+	// Drop the worst unpinned orders until the plan passes
+	selected := append([]domain.Order(nil), result.SelectedOrders...)
+	sort.SliceStable(selected, func(i, j int) bool {
+		if pinned[selected[i].ID] != pinned[selected[j].ID] {
+			return pinned[selected[i].ID]
+		}
+		return worse(selected[j], selected[i])
+	})
+	for len(selected) > 0 && !pinned[selected[len(selected)-1].ID] && !s.checker.ValidateOrderSet(selected) {
+		selected = selected[:len(selected)-1]
+	}
+	
+	// Refill with whole orders, best first
+	inPlan := make(map[string]bool, len(selected))
+	weight, volume := 0, 0
+	for _, order := range selected {
+		inPlan[order.ID] = true
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	candidates := make([]domain.Order, 0, len(orders))
+	for _, order := range orders {
+		if !inPlan[order.ID] {
+			candidates = append(candidates, order)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return worse(candidates[j], candidates[i])
+	})
+	for _, order := range candidates {
+		if !truck.FitsMoreOrders(len(selected)) {
+			break
+		}
+		if !s.checker.CanFit(truck, weight, volume, order) {
+			continue
+		}
+		if !s.checker.ValidateOrderSet(append(selected, order)) {
+			continue
+		}
+		selected = append(selected, order)
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	
+	repaired := summarize(selected)
+	repaired.Algorithm = result.Algorithm
+	repaired.Portfolio = result.Portfolio
+	for _, order := range selected {
+		if fraction, ok := result.Fractions[order.ID]; ok {
+			if repaired.Fractions == nil {
+				repaired.Fractions = make(map[string]float64)
+			}
+			repaired.Fractions[order.ID] = fraction
+		}
+	}
+	repaired.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return repaired
+}
@@ -0,0 +1,163 @@
+package algorithm
+
+import (
+	"context"
+	"math"
+	"smart-load/internal/domain"
+	"sort"
+	"time"
+)
+
+// SplittableOptimizer turns the 0/1 problem into a mixed one: orders marked
+// splittable may also be loaded in part, for the same share of their payout.
+// The inner optimizer picks whole orders, then leftover capacity is topped up
+// with the densest splittable orders that fit alongside them, the last one
+// taken in part. The inner optimizer runs twice, over all orders and over the
+// whole-only ones, because leaving room for fractions sometimes pays more
+// than the best whole-order plan. Mixed plans are never reported optimal.
+//
+// With byPriority, top-up candidates are taken highest priority first and
+// the two plans are compared tier by tier (see ComparePriority).
+type SplittableOptimizer struct {
+	inner      Optimizer
+	checker    domain.ConstraintChecker
+	byPriority bool
+}
+
+func NewSplittableOptimizer(inner Optimizer, byPriority bool) *SplittableOptimizer {
+	return &SplittableOptimizer{inner: inner, checker: domain.NewConstraintChecker(), byPriority: byPriority}
+}
+
+func (s *SplittableOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &SplittableOptimizer{inner: WithChecker(s.inner, checker), checker: checker, byPriority: s.byPriority}
+}
+
+func (s *SplittableOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	whole := make([]domain.Order, 0, len(orders))
+	splittable := make([]domain.Order, 0)
+	for _, order := range orders {
+		if order.Splittable {
+			splittable = append(splittable, order)
+			continue
+		}
+		whole = append(whole, order)
+	}
+	if len(splittable) == 0 {
+		return s.inner.Optimize(ctx, truck, orders)
+	}
+	
+	best := s.topUp(truck, s.inner.Optimize(ctx, truck, orders), splittable)
+	if len(whole) < len(orders) && ctx.Err() == nil {
+		if candidate := s.topUp(truck, s.inner.Optimize(ctx, truck, whole), splittable); s.better(candidate, best) {
+			best = candidate
+		}
+	}
+	best.Optimal = false
+	best.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return best
+}
+
+// topUp fills the capacity a plan leaves with splittable orders it does not
+// carry, densest first, taking the first one that does not fit in part
+func (s *SplittableOptimizer) topUp(truck domain.Truck, plan OptimizationResult, splittable []domain.Order) OptimizationResult {
+	chosen := make(map[string]bool, len(plan.SelectedOrders))
+	for _, order := range plan.SelectedOrders {
+		chosen[order.ID] = true
+	}
+	
+	density := func(order domain.Order) float64 {
+		size := float64(order.WeightLbs)/float64(truck.MaxWeightLbs) +
+			float64(order.VolumeCuft)/float64(truck.MaxVolumeCuft)
+		return float64(order.Score) / size
+	}
+	candidates := make([]domain.Order, 0, len(splittable))
+	for _, order := range splittable {
+		if !chosen[order.ID] {
+			candidates = append(candidates, order)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if s.byPriority && candidates[i].Priority != candidates[j].Priority {
+			return candidates[i].Priority > candidates[j].Priority
+		}
+		return density(candidates[i]) > density(candidates[j])
+	})
+	
+	selected := append([]domain.Order(nil), plan.SelectedOrders...)
+	fractions := make(map[string]float64, len(plan.Fractions))
+	for id, fraction := range plan.Fractions {
+		fractions[id] = fraction
+	}
+	weight, volume := plan.TotalWeight, plan.TotalVolume
+	for _, order := range candidates {
+		if !truck.FitsMoreOrders(len(selected)) {
+			break
+		}
+		if !s.fitsWith(order, selected) {
+			continue
+		}
+		if s.checker.CanFit(truck, weight, volume, order) {
+			selected = append(selected, order)
+			weight += order.WeightLbs
+			volume += order.VolumeCuft
+			continue
+		}
+		
+		fraction := math.Min(
+			float64(truck.MaxWeightLbs-weight)/float64(order.WeightLbs),
+			float64(truck.MaxVolumeCuft-volume)/float64(order.VolumeCuft),
+		)
+		part, ok := portion(order, fraction)
+		if !ok {
+			continue
+		}
+		fractions[order.ID] = fraction
+		selected = append(selected, part)
+		break
+	}
+	
+	result := summarize(selected)
+	result.Algorithm = plan.Algorithm
+	result.Portfolio = plan.Portfolio
+	if len(fractions) > 0 {
+		result.Fractions = fractions
+	}
+	return result
+}
+
+func (s *SplittableOptimizer) better(a, b OptimizationResult) bool {
+	if s.byPriority {
+		if order := ComparePriority(a, b); order != 0 {
+			return order > 0
+		}
+	}
+	return a.TotalScore > b.TotalScore
+}
+
+func (s *SplittableOptimizer) fitsWith(order domain.Order, selected []domain.Order) bool {
+	for _, other := range selected {
+		if !s.checker.CanCombine(order, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// portion scales an order's weight, volume, payout and score down to the
+// given fraction, rounding down so the part never outgrows its share. Linear
+// feet and pallets round up, as a part still takes a whole foot of floor or
+// pallet it starts. It reports false when nothing of the order would be
+// loaded.
+func portion(order domain.Order, fraction float64) (domain.Order, bool) {
+	part := order
+	part.WeightLbs = int(fraction * float64(order.WeightLbs))
+	part.ScaleWeightLbs = int(fraction * float64(order.ScaleWeightLbs))
+	part.VolumeCuft = int(fraction * float64(order.VolumeCuft))
+	part.LinearFeet = int(math.Ceil(fraction * float64(order.LinearFeet)))
+	part.PalletCount = int(math.Ceil(fraction * float64(order.PalletCount)))
+	part.Payout = domain.Money(fraction * float64(order.Payout))
+	part.Score = domain.Score(fraction * float64(order.Score))
+	return part, part.WeightLbs > 0 && part.VolumeCuft > 0
+}
@@ -0,0 +1,62 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestSplittableTopsUpWithPartOfAnOrder(t *testing.T) {
+	truck := domain.Truck{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000}
+	orders := []domain.Order{
+		{ID: "whole", Payout: 60000, Score: 60000, WeightLbs: 6000, VolumeCuft: 300, Origin: "A", Destination: "B"},
+		{ID: "bulk", Payout: 80000, Score: 80000, WeightLbs: 8000, VolumeCuft: 400, Origin: "A", Destination: "B", Splittable: true},
+	}
+	
+	result := NewSplittableOptimizer(NewDPOptimizer(), false).Optimize(context.Background(), truck, orders)
+	checkPlan(t, truck, result)
+	if result.TotalWeight != 10000 || result.TotalPayout != 100000 {
+		t.Fatalf("got %d lbs for %d cents, want the truck full at 100000", result.TotalWeight, result.TotalPayout)
+	}
+	if result.Fractions["bulk"] != 0.5 || len(result.Fractions) != 1 {
+		t.Fatalf("fractions = %v, want half of bulk", result.Fractions)
+	}
+	if result.Optimal {
+		t.Fatal("a mixed plan was reported optimal")
+	}
+}
+
+func TestSplittableNeverLosesToWholeOrders(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(14))
+	
+	for i := 0; i < 200; i++ {
+		orders := randomOrders(r, r.Intn(16))
+		for j := range orders {
+			orders[j].Splittable = r.Intn(3) == 0
+		}
+		whole := NewDPOptimizer().Optimize(ctx, testTruck, orders)
+		mixed := NewSplittableOptimizer(NewDPOptimizer(), false).Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, mixed)
+		if mixed.TotalScore < whole.TotalScore {
+			t.Fatalf("instance %d: mixed score %d is below the whole-order optimum %d", i, mixed.TotalScore, whole.TotalScore)
+		}
+		for id, fraction := range mixed.Fractions {
+			if fraction <= 0 || fraction >= 1 {
+				t.Fatalf("instance %d: %s loaded at fraction %v", i, id, fraction)
+			}
+		}
+	}
+}
+
+func TestSplittableWithoutSplittableOrdersIsInner(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	orders := randomOrders(r, 12)
+	
+	want := NewDPOptimizer().Optimize(context.Background(), testTruck, orders)
+	got := NewSplittableOptimizer(NewDPOptimizer(), false).Optimize(context.Background(), testTruck, orders)
+	if got.TotalScore != want.TotalScore || !got.Optimal || got.Fractions != nil {
+		t.Fatalf("got score %d optimal %v fractions %v, want the DP plan %d", got.TotalScore, got.Optimal, got.Fractions, want.TotalScore)
+	}
+}
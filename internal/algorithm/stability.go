@@ -0,0 +1,55 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// StabilityOptimizer favors the previously committed plan when a load is
+// re-optimized, so small input changes do not reshuffle it. Every order
+// moved in or out of the plan costs weight times its score: orders of the
+// previous plan earn that much on top of their score and other orders lose
+// it. A new order therefore replaces a previous one only when it scores more
+// by a margin the weight sets. TotalScore is the objective after the
+// adjustment.
+type StabilityOptimizer struct {
+	inner    Optimizer
+	previous map[string]bool
+	weight   domain.Weight
+}
+
+func NewStabilityOptimizer(inner Optimizer, previousIDs []string, weight domain.Weight) *StabilityOptimizer {
+	previous := make(map[string]bool, len(previousIDs))
+	for _, id := range previousIDs {
+		previous[id] = true
+	}
+	return &StabilityOptimizer{inner: inner, previous: previous, weight: weight}
+}
+
+func (s *StabilityOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &StabilityOptimizer{inner: WithChecker(s.inner, checker), previous: s.previous, weight: s.weight}
+}
+
+func (s *StabilityOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	adjusted := make([]domain.Order, len(orders))
+	for i, order := range orders {
+		adjusted[i] = order
+		change := order.Score
+		if change < 0 {
+			change = change.MulRatio(-1, 1)
+		}
+		change = change.Mul(s.weight)
+		if s.previous[order.ID] {
+			adjusted[i].Score = order.Score.Add(change)
+		} else {
+			adjusted[i].Score = order.Score.Sub(change)
+		}
+	}
+	
+	result := s.inner.Optimize(ctx, truck, adjusted)
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
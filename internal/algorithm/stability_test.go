@@ -0,0 +1,36 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestStabilityKeepsThePreviousPlanAgainstSmallGains(t *testing.T) {
+	order := func(id string, payout domain.Money) domain.Order {
+		return domain.Order{
+			ID: id, Payout: payout, Score: domain.ScoreFromMoney(payout), WeightLbs: 30000, VolumeCuft: 100,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+		}
+	}
+	// Only one of the two fits; the new order pays 5% more
+	orders := []domain.Order{order("previous", 100000), order("new", 105000)}
+	previous := []string{"previous"}
+	
+	tests := []struct {
+		weight float64
+		want   string
+	}{
+		{0, "new"},
+		{0.02, "new"},
+		{0.03, "previous"},
+		{1, "previous"},
+	}
+	for _, tt := range tests {
+		optimizer := NewStabilityOptimizer(NewDPOptimizer(), previous, domain.WeightFromFloat(tt.weight))
+		result := optimizer.Optimize(context.Background(), testTruck, orders)
+		if len(result.SelectedOrders) != 1 || result.SelectedOrders[0].ID != tt.want {
+			t.Errorf("stability_weight %v selected %v, want %s", tt.weight, planKey(result.SelectedOrders), tt.want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package algorithm
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// StopFeeOptimizer charges a fee for every stop a plan makes beyond its first
+// pickup and delivery, so many small orders only win when they pay for their
+// stops. Each order adds a pickup and a delivery (see
+// domain.ConsolidationStops), which makes the fee a charge of two stops per
+// order refunded once per plan: the inner optimizer maximizes scores lowered
+// by two stops each, and when that leaves the truck empty the best single
+// order, which pays no fee, is weighed against the empty plan. TotalScore is
+// the objective after fees.
+type StopFeeOptimizer struct {
+	inner   Optimizer
+	checker domain.ConstraintChecker
+	fee     domain.Money
+}
+
+func NewStopFeeOptimizer(inner Optimizer, checker domain.ConstraintChecker, fee domain.Money) *StopFeeOptimizer {
+	return &StopFeeOptimizer{inner: inner, checker: checker, fee: fee}
+}
+
+func (s *StopFeeOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	return &StopFeeOptimizer{inner: WithChecker(s.inner, checker), checker: checker, fee: s.fee}
+}
+
+func (s *StopFeeOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	perOrder := domain.ScoreFromMoney(2 * s.fee)
+	charged := make([]domain.Order, len(orders))
+	for i, order := range orders {
+		charged[i] = order
+		charged[i].Score = order.Score.Sub(perOrder)
+	}
+	
+	result := s.inner.Optimize(ctx, truck, charged)
+	if len(result.SelectedOrders) > 0 {
+		result.TotalScore = result.TotalScore.Add(perOrder)
+		result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+		return result
+	}
+	
+	// Every order costs more in fees than it earns, but the first is free
+	best := -1
+	for i, order := range orders {
+		if order.Score <= 0 || !truck.FitsMoreOrders(0) || !s.checker.CanFit(truck, 0, 0, order) ||
+			!s.checker.ValidateOrderSet([]domain.Order{order}) {
+			continue
+		}
+		if best < 0 || order.Score > orders[best].Score {
+			best = i
+		}
+	}
+	if best >= 0 {
+		single := summarize([]domain.Order{orders[best]})
+		single.Algorithm = result.Algorithm
+		single.Optimal = result.Optimal
+		single.Portfolio = result.Portfolio
+		result = single
+	}
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
@@ -0,0 +1,64 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// bestWithStopFee is the best objective over every feasible plan, scores less
+// fee for each consolidation stop, by brute force
+func bestWithStopFee(truck domain.Truck, orders []domain.Order, fee domain.Money) domain.Score {
+	checker := domain.NewConstraintChecker()
+	var best domain.Score
+	for mask := 1; mask < 1<<len(orders); mask++ {
+		var selected []domain.Order
+		weight, volume := 0, 0
+		var score domain.Score
+		for i, order := range orders {
+			if mask&(1<<i) != 0 {
+				selected = append(selected, order)
+				weight += order.WeightLbs
+				volume += order.VolumeCuft
+				score += order.Score
+			}
+		}
+		if weight <= truck.MaxWeightLbs && volume <= truck.MaxVolumeCuft && checker.ValidateOrderSet(selected) {
+			stops := domain.Money(domain.ConsolidationStops(selected))
+			best = max(best, score-domain.ScoreFromMoney(fee*stops))
+		}
+	}
+	return best
+}
+
+func TestStopFeeOptimizerMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	for trial := 0; trial < 40; trial++ {
+		orders := randomOrders(r, 2+r.Intn(9))
+		fee := domain.Money(r.Intn(150000))
+		
+		result := NewStopFeeOptimizer(NewDPOptimizer(), domain.NewConstraintChecker(), fee).Optimize(context.Background(), testTruck, orders)
+		if want := bestWithStopFee(testTruck, orders, fee); result.TotalScore != want {
+			t.Fatalf("trial %d: fee %d scored %d, want %d", trial, fee, result.TotalScore, want)
+		}
+	}
+}
+
+func TestStopFeeKeepsOneOrderThatCannotPayForMore(t *testing.T) {
+	order := func(id string, payout domain.Money) domain.Order {
+		return domain.Order{
+			ID: id, Payout: payout, Score: domain.ScoreFromMoney(payout), WeightLbs: 1000, VolumeCuft: 10,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+		}
+	}
+	orders := []domain.Order{order("small", 10000), order("big", 20000)}
+	
+	result := NewStopFeeOptimizer(NewDPOptimizer(), domain.NewConstraintChecker(), 50000).Optimize(context.Background(), testTruck, orders)
+	if len(result.SelectedOrders) != 1 || result.SelectedOrders[0].ID != "big" {
+		t.Fatalf("selected %v, want only big", planKey(result.SelectedOrders))
+	}
+	if result.TotalScore != domain.ScoreFromMoney(20000) || result.TotalPayout != 20000 {
+		t.Fatalf("score %d payout %d, want the big order's untouched", result.TotalScore, result.TotalPayout)
+	}
+}
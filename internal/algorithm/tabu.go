@@ -0,0 +1,199 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// Defaults for TabuSearchOptimizer
+const (
+	DefaultTabuTenure       = 7
+	DefaultTabuNeighborhood = 50
+)
+
+// TabuSearchOptimizer improves a greedy start with tabu search. Each
+// iteration samples a neighborhood of moves (add an order, drop one, or swap
+// one in for one out) and applies the best of them even when it lowers the
+// score, which lets the search walk out of local optima where LocalSearch
+// stops. Orders moved in the last tenure iterations are tabu, so the search
+// does not immediately undo a move and cycle; a tabu move is still taken when
+// it beats the best plan seen (aspiration). Moves never leave the start's
+// compatibility class, so every class is searched on its own.
+type TabuSearchOptimizer struct {
+	greedy  *GreedyOptimizer
+	checker domain.ConstraintChecker
+	// tenure is how many iterations a moved order stays tabu
+	tenure int
+	// neighborhood is how many random moves are weighed per iteration
+	neighborhood int
+	iterations   int
+	seed         int64
+}
+
+// NewTabuSearchOptimizer returns a tabu search with the given tenure and
+// neighborhood size; zero picks the default
+func NewTabuSearchOptimizer(tenure, neighborhood int) *TabuSearchOptimizer {
+	if tenure <= 0 {
+		tenure = DefaultTabuTenure
+	}
+	if neighborhood <= 0 {
+		neighborhood = DefaultTabuNeighborhood
+	}
+	return &TabuSearchOptimizer{
+		greedy:       NewGreedyOptimizer(),
+		checker:      domain.NewConstraintChecker(),
+		tenure:       tenure,
+		neighborhood: neighborhood,
+		iterations:   2000,
+		seed:         1,
+	}
+}
+
+func (t *TabuSearchOptimizer) withChecker(checker domain.ConstraintChecker) Optimizer {
+	constrained := *t
+	constrained.checker = checker
+	constrained.greedy = &GreedyOptimizer{checker: checker}
+	return &constrained
+}
+
+func (t *TabuSearchOptimizer) Optimize(ctx context.Context, truck domain.Truck, orders []domain.Order) OptimizationResult {
+	startTime := time.Now()
+	
+	orders = domain.FilterFeasibleOrders(truck, orders)
+	result := summarize([]domain.Order{})
+	progress := newProgressReporter(ctx, "tabu")
+	for _, class := range compatibilityClasses(t.checker, orders) {
+		if ctx.Err() != nil {
+			break
+		}
+		start := t.greedy.Optimize(ctx, truck, class)
+		if searched := summarize(t.search(ctx, truck, class, start.SelectedOrders, progress)); searched.TotalScore > result.TotalScore {
+			result = searched
+		}
+	}
+	progress.finish()
+	result.Algorithm = "tabu"
+	result.ComputeTimeMs = time.Since(startTime).Milliseconds()
+	return result
+}
+
+// tabuMove takes out and puts in one order each; -1 means none
+type tabuMove struct {
+	out, in int
+}
+
+// search runs tabu search over one compatibility class from start and
+// returns the best selection seen
+func (t *TabuSearchOptimizer) search(
+	ctx context.Context,
+	truck domain.Truck,
+	orders []domain.Order,
+	start []domain.Order,
+	progress *progressReporter,
+) []domain.Order {
+	n := len(orders)
+	if n == 0 {
+		return start
+	}
+	
+	chosen := make([]bool, n)
+	index := make(map[string]int, n)
+	for i, order := range orders {
+		index[order.ID] = i
+	}
+	weight, volume, count := 0, 0, 0
+	score := domain.Score(0)
+	for _, order := range start {
+		count++
+		chosen[index[order.ID]] = true
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+		score += order.Score
+	}
+	best := append([]bool(nil), chosen...)
+	bestScore := score
+	selected := func() []domain.Order { return chosenOrders(orders, best) }
+	progress.improved(bestScore, selected)
+	
+	tabuUntil := make([]int, n)
+	r := rand.New(rand.NewSource(t.seed))
+	
+	for iteration := 1; iteration <= t.iterations && ctx.Err() == nil; iteration++ {
+		progress.visit()
+		var picked tabuMove
+		var pickedDelta domain.Score
+		found := false
+		
+		for tries := 0; tries < t.neighborhood; tries++ {
+			move := tabuMove{out: -1, in: -1}
+			a := r.Intn(n)
+			switch {
+			case !chosen[a]:
+				move.in = a
+				// Half the time pair the addition with a removal
+				if b := r.Intn(n); chosen[b] && r.Intn(2) == 0 {
+					move.out = b
+				}
+			default:
+				move.out = a
+			}
+			
+			delta := domain.Score(0)
+			dw, dv := 0, 0
+			if move.in >= 0 {
+				delta += orders[move.in].Score
+				dw += orders[move.in].WeightLbs
+				dv += orders[move.in].VolumeCuft
+			}
+			if move.out >= 0 {
+				delta -= orders[move.out].Score
+				dw -= orders[move.out].WeightLbs
+				dv -= orders[move.out].VolumeCuft
+			}
+			if weight+dw > truck.MaxWeightLbs || volume+dv > truck.MaxVolumeCuft {
+				continue
+			}
+			if move.in >= 0 && move.out < 0 && !truck.FitsMoreOrders(count) {
+				continue
+			}
+			
+			tabu := (move.in >= 0 && tabuUntil[move.in] >= iteration) ||
+				(move.out >= 0 && tabuUntil[move.out] >= iteration)
+			if tabu && score+delta <= bestScore {
+				continue
+			}
+			if !found || delta > pickedDelta {
+				picked, pickedDelta, found = move, delta, true
+			}
+		}
+		if !found {
+			continue
+		}
+		
+		for _, i := range []int{picked.in, picked.out} {
+			if i >= 0 {
+				chosen[i] = !chosen[i]
+				tabuUntil[i] = iteration + t.tenure
+			}
+		}
+		if picked.in >= 0 {
+			weight += orders[picked.in].WeightLbs
+			volume += orders[picked.in].VolumeCuft
+			count++
+		}
+		if picked.out >= 0 {
+			weight -= orders[picked.out].WeightLbs
+			volume -= orders[picked.out].VolumeCuft
+			count--
+		}
+		score += pickedDelta
+		if score > bestScore {
+			bestScore = score
+			copy(best, chosen)
+			progress.improved(bestScore, selected)
+		}
+	}
+	return selected()
+}
@@ -0,0 +1,45 @@
+package algorithm
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+)
+
+func TestTabuSearchMatchesDP(t *testing.T) {
+	checkMatchesDP(t, NewTabuSearchOptimizer(0, 0), 200)
+}
+
+func TestTabuSearchNeverWorsensGreedy(t *testing.T) {
+	ctx := context.Background()
+	r := rand.New(rand.NewSource(20))
+	
+	var greedyTotal, tabuTotal int64
+	for i := 0; i < 50; i++ {
+		orders := randomOrders(r, 10+r.Intn(80))
+		greedy := NewGreedyOptimizer().Optimize(ctx, testTruck, orders)
+		tabu := NewTabuSearchOptimizer(0, 0).Optimize(ctx, testTruck, orders)
+		checkPlan(t, testTruck, tabu)
+		if tabu.TotalScore < greedy.TotalScore {
+			t.Fatalf("instance %d: tabu score %d is below greedy %d", i, tabu.TotalScore, greedy.TotalScore)
+		}
+		greedyTotal += int64(greedy.TotalScore)
+		tabuTotal += int64(tabu.TotalScore)
+	}
+	if tabuTotal <= greedyTotal {
+		t.Errorf("tabu search never improved on greedy (%d vs %d)", tabuTotal, greedyTotal)
+	}
+}
+
+func TestTabuSearchAcceptsTuning(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	orders := randomOrders(r, 60)
+	
+	for _, tuning := range [][2]int{{1, 1}, {50, 5}, {3, 500}} {
+		tabu := NewTabuSearchOptimizer(tuning[0], tuning[1])
+		if tabu.tenure != tuning[0] || tabu.neighborhood != tuning[1] {
+			t.Fatalf("tuning %v gave tenure %d neighborhood %d", tuning, tabu.tenure, tabu.neighborhood)
+		}
+		checkPlan(t, testTruck, tabu.Optimize(context.Background(), testTruck, orders))
+	}
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/url"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// AlgorithmHandler describes one algorithm: the constraints it honors, its
+// size limit, its optimality guarantee and its measured runtime curve
+func AlgorithmHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Accept greedy+ls whether or not the client escaped the plus
+		name, err := url.PathUnescape(c.Params("name"))
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "invalid algorithm name")
+		}
+		capabilities, ok := optimizerService.AlgorithmCapabilities(name)
+		if !ok {
+			return respondError(c, fiber.StatusNotFound, "algorithm not found: "+name)
+		}
+		return c.Status(fiber.StatusOK).JSON(capabilities)
+	}
+}
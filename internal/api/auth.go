@@ -0,0 +1,66 @@
+package api
+
+import (
+	"smart-load/internal/auth"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const principalKey = "principal"
+
+// APIKeyAuth rejects requests without a known API key, taken from the
+// X-API-Key header or an "Authorization: Bearer" header
+func APIKeyAuth(keys *auth.KeyStore) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("X-API-Key")
+		if key == "" {
+			if bearer := c.Get(fiber.HeaderAuthorization); strings.HasPrefix(bearer, "Bearer ") {
+				key = strings.TrimPrefix(bearer, "Bearer ")
+			}
+		}
+		if key == "" {
+			return respondError(c, fiber.StatusUnauthorized, "API key required")
+		}
+		
+		principal, ok := keys.Lookup(key)
+		if !ok {
+			return respondError(c, fiber.StatusUnauthorized, "invalid API key")
+		}
+		if tenantID := c.Get("X-Tenant-ID"); !principal.CanAccessTenant(tenantID) {
+			return respondError(c, fiber.StatusForbidden, "API key is not allowed to act for tenant "+tenantID)
+		}
+		c.Locals(principalKey, principal)
+		return c.Next()
+	}
+}
+
+// principalName returns the name of the caller's API key, or "" without APIKeyAuth
+func principalName(c *fiber.Ctx) string {
+	principal, _ := c.Locals(principalKey).(auth.Principal)
+	return principal.Name
+}
+
+// requireTenantParam rejects callers whose API key is not bound to the tenant
+// in the :tenantId route parameter
+func requireTenantParam() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal, ok := c.Locals(principalKey).(auth.Principal)
+		if ok && !principal.CanAccessTenant(c.Params("tenantId")) {
+			return respondError(c, fiber.StatusForbidden, "API key is not allowed to act for tenant "+c.Params("tenantId"))
+		}
+		return c.Next()
+	}
+}
+
+// requireScope rejects callers whose API key lacks scope. Without APIKeyAuth
+// in front there is no principal and every request is allowed.
+func requireScope(scope auth.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal, ok := c.Locals(principalKey).(auth.Principal)
+		if ok && !principal.HasScope(scope) {
+			return respondError(c, fiber.StatusForbidden, "API key lacks the "+string(scope)+" scope")
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestOptimizeBatchHandler(t *testing.T) {
+	invalid := strings.Replace(streamRequest, `"max_weight_lbs": 10000`, `"max_weight_lbs": -1`, 1)
+	if invalid == streamRequest {
+		t.Fatal("streamRequest has no max_weight_lbs to break")
+	}
+	body := `{"requests": [` + streamRequest + `,` + invalid + `]}`
+	resp := post(t, "/api/v1/load-optimizer/optimize-batch", fiber.MIMEApplicationJSON, "", []byte(body))
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	var response domain.BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Succeeded != 1 || response.Failed != 1 || len(response.Results) != 2 {
+		t.Fatalf("response %+v, want one success and one failure", response)
+	}
+	first, second := response.Results[0], response.Results[1]
+	if first.Index != 0 || first.Status != fiber.StatusOK || first.Response == nil || first.Error != nil {
+		t.Errorf("first result %+v, want a response", first)
+	}
+	if second.Index != 1 || second.Status != fiber.StatusBadRequest || second.Error == nil || second.Response != nil {
+		t.Errorf("second result %+v, want a 400 error", second)
+	}
+	
+	resp = post(t, "/api/v1/load-optimizer/optimize-batch", fiber.MIMEApplicationJSON, "", []byte(`{"requests": []}`))
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("empty batch: status %d, want 400", resp.StatusCode)
+	}
+}
+
+// streamingApp serves the routes as the server does, with bodies over limit
+// streamed to the handlers
+func streamingApp(limit int) *fiber.App {
+	app := fiber.New(fiber.Config{BodyLimit: limit, StreamRequestBody: true, DisablePreParseMultipartForm: true})
+	app.Use(RequestSizeLimiter(limit))
+	app.Use(JSONParsing(false))
+	SetupRoutes(app, service.NewOptimizerService())
+	return app
+}
+
+// Batches are decoded a request at a time, so they may pass the body limit
+// and are bounded by their request count instead
+func TestOptimizeBatchStreamsBody(t *testing.T) {
+	const limit = 4096
+	app := streamingApp(limit)
+	send := func(body string, headers map[string]string) *http.Response {
+		req := httptest.NewRequest("POST", "/api/v1/load-optimizer/optimize-batch", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	batch := func(n int, extra string) string {
+		requests := make([]string, n)
+		for i := range requests {
+			requests[i] = streamRequest
+		}
+		return `{` + extra + `"requests": [` + strings.Join(requests, ",") + `]}`
+	}
+	
+	body := batch(10, `"note": {"nested": [1, {"a": "b"}]}, `)
+	if len(body) <= limit {
+		t.Fatalf("body of %d bytes does not pass the limit", len(body))
+	}
+	resp := send(body, nil)
+	if resp.StatusCode != fiber.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		t.Fatalf("status %d: %s", resp.StatusCode, data)
+	}
+	var response domain.BatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Succeeded != 10 {
+		t.Fatalf("response %+v, want 10 successes", response)
+	}
+	
+	for name, test := range map[string]struct {
+		body    string
+		headers map[string]string
+		status  int
+	}{
+		"over the request cap": {batch(domain.MaxBatchRequests+1, ""), nil, fiber.StatusBadRequest},
+		"strict unknown field": {batch(1, `"note": 1, `), map[string]string{"X-JSON-Parsing": "strict"}, fiber.StatusBadRequest},
+		"trailing data":        {batch(1, "") + "]", nil, fiber.StatusBadRequest},
+		"truncated":            {batch(1, "")[:100], nil, fiber.StatusBadRequest},
+		// A keyed body is compared whole, so it is held to the limit
+		"idempotency key": {body, map[string]string{IdempotencyKeyHeader: "k1"}, fiber.StatusRequestEntityTooLarge},
+	} {
+		if resp := send(test.body, test.headers); resp.StatusCode != test.status {
+			t.Errorf("%s: status %d, want %d", name, resp.StatusCode, test.status)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"fmt"
+	"smart-load/internal/auth"
+	"smart-load/internal/clock"
+	"smart-load/internal/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupClockRoutes lets admins move the service's clock when it runs with an
+// adjustable one; otherwise the routes do not exist
+func setupClockRoutes(v1 fiber.Router, optimizerService *service.OptimizerService) {
+	adjustable := optimizerService.AdjustableClock()
+	if adjustable == nil {
+		return
+	}
+	admin := v1.Group("/admin/clock", requireScope(auth.ScopeAdminConfig))
+	admin.Get("", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(adjustable.State())
+	})
+	admin.Put("", PutClockHandler(adjustable))
+	admin.Delete("", func(c *fiber.Ctx) error {
+		adjustable.Reset()
+		return c.Status(fiber.StatusOK).JSON(adjustable.State())
+	})
+}
+
+// PutClockHandler freezes the clock at frozen_at or runs it offset from real
+// time, such as "72h" or "-30m"
+func PutClockHandler(adjustable *clock.Adjustable) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			FrozenAt *time.Time `json:"frozen_at"`
+			Offset   string     `json:"offset"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		switch {
+		case body.FrozenAt != nil && body.Offset != "":
+			return respondError(c, fiber.StatusBadRequest, "set frozen_at or offset, not both")
+		case body.FrozenAt != nil:
+			adjustable.Freeze(*body.FrozenAt)
+		case body.Offset != "":
+			offset, err := time.ParseDuration(body.Offset)
+			if err != nil {
+				return respondError(c, fiber.StatusBadRequest, fmt.Sprintf("invalid offset: %s", body.Offset))
+			}
+			adjustable.Offset(offset)
+		default:
+			return respondError(c, fiber.StatusBadRequest, "frozen_at or offset is required")
+		}
+		return c.Status(fiber.StatusOK).JSON(adjustable.State())
+	}
+}
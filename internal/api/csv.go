@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"smart-load/internal/adapters/ordercsv"
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+const (
+	// maxCSVFormParts bounds the parts of a CSV upload, file included
+	maxCSVFormParts = 100
+	// maxCSVFieldBytes bounds each truck field of a CSV upload
+	maxCSVFieldBytes = 4 * 1024
+)
+
+// OptimizeCSVHandler accepts a multipart form of the truck's fields and a
+// CSV file of orders in the orders field; see package ordercsv for the
+// column mapping. The response is the JSON optimize response.
+func OptimizeCSVHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		request, err := parseCSVUpload(c)
+		if err != nil {
+			return respondParseError(c, err)
+		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		response, err := optimizerService.OptimizeLoad(c.UserContext(), request)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+}
+
+// parseCSVUpload reads the form a part at a time as the body arrives, and
+// the orders file a row at a time, so an upload is never held whole. Truck
+// fields may come before or after the file; the first value of each counts.
+func parseCSVUpload(c *fiber.Ctx) (domain.OptimizeRequest, error) {
+	var request domain.OptimizeRequest
+	mediaType, params, err := mime.ParseMediaType(c.Get(fiber.HeaderContentType))
+	if err != nil || mediaType != fiber.MIMEMultipartForm || params["boundary"] == "" {
+		return request, fmt.Errorf("request Content-Type must be %s", fiber.MIMEMultipartForm)
+	}
+	strict, _ := c.Locals(strictParsingKey).(bool)
+	
+	form := multipart.NewReader(requestBody(c), params["boundary"])
+	fields := make(map[string]string)
+	files := 0
+	for parts := 0; ; parts++ {
+		part, err := form.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return request, err
+		}
+		if parts == maxCSVFormParts {
+			return request, fmt.Errorf("form cannot have more than %d parts", maxCSVFormParts)
+		}
+		
+		name := part.FormName()
+		if name == "orders" && part.FileName() != "" {
+			if files++; files > 1 {
+				return request, fmt.Errorf("orders must be a single CSV file")
+			}
+			request.Orders, err = ordercsv.Parse(part, strict, domain.DefaultValidationProfile().MaxOrders)
+			if err != nil {
+				return request, err
+			}
+			continue
+		}
+		value, err := io.ReadAll(io.LimitReader(part, maxCSVFieldBytes+1))
+		if err != nil {
+			return request, err
+		}
+		if len(value) > maxCSVFieldBytes {
+			return request, fmt.Errorf("form field %s is longer than %d bytes", name, maxCSVFieldBytes)
+		}
+		if _, seen := fields[name]; !seen {
+			fields[name] = string(value)
+		}
+	}
+	if files == 0 {
+		return request, fmt.Errorf("orders must be a single CSV file")
+	}
+	
+	request.Truck, err = ordercsv.ParseTruck(func(name string) string {
+		return fields[name]
+	})
+	return request, err
+}
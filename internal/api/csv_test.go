@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const ordersCSV = `id,payout_cents,weight_lbs,volume_cuft,origin,destination,pickup_date,delivery_date
+a,100000,5000,100,"Los Angeles, CA","Dallas, TX",2030-01-01,2030-01-03
+b,90000,6000,800,"Los Angeles, CA","Dallas, TX",2030-01-01,2030-01-03
+c,40000,4000,100,"Los Angeles, CA","Dallas, TX",2030-01-01,2030-01-03
+`
+
+// postCSV uploads orders as a CSV file with the given form fields
+func postCSV(t *testing.T, fields map[string]string, orders string) (int, []byte) {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	for name, value := range fields {
+		form.WriteField(name, value)
+	}
+	if orders != "" {
+		file, _ := form.CreateFormFile("orders", "orders.csv")
+		file.Write([]byte(orders))
+	}
+	form.Close()
+	
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	req := httptest.NewRequest("POST", "/api/v1/load-optimizer/optimize-csv", &body)
+	req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, data
+}
+
+func TestOptimizeCSV(t *testing.T) {
+	truck := map[string]string{"truck_id": "truck-1", "max_weight_lbs": "10000", "max_volume_cuft": "1000"}
+	status, body := postCSV(t, truck, ordersCSV)
+	if status != fiber.StatusOK {
+		t.Fatalf("status %d: %s", status, body)
+	}
+	var response struct {
+		TruckID          string   `json:"truck_id"`
+		SelectedOrderIDs []string `json:"selected_order_ids"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.TruckID != "truck-1" || !reflect.DeepEqual(response.SelectedOrderIDs, []string{"a", "c"}) {
+		t.Fatalf("truck %s selected %v, want truck-1 with a and c", response.TruckID, response.SelectedOrderIDs)
+	}
+}
+
+func TestOptimizeCSVErrors(t *testing.T) {
+	truck := map[string]string{"truck_id": "truck-1", "max_weight_lbs": "10000", "max_volume_cuft": "1000"}
+	for name, test := range map[string]struct {
+		fields map[string]string
+		orders string
+	}{
+		"no file":     {truck, ""},
+		"bad cell":    {truck, "id,weight_lbs\na,heavy\n"},
+		"bad truck":   {map[string]string{"truck_id": "truck-1", "max_weight_lbs": "lots"}, ordersCSV},
+		"no capacity": {map[string]string{"truck_id": "truck-1"}, ordersCSV},
+	} {
+		if status, body := postCSV(t, test.fields, test.orders); status != fiber.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400: %s", name, status, body)
+		}
+	}
+}
+
+// Uploads are read a part and a row at a time, so they may pass the body
+// limit and are bounded by their order count instead
+func TestOptimizeCSVStreamsBody(t *testing.T) {
+	const limit = 4096
+	app := streamingApp(limit)
+	send := func(write func(form *multipart.Writer)) int {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		write(form)
+		form.Close()
+		req := httptest.NewRequest("POST", "/api/v1/load-optimizer/optimize-csv", &body)
+		req.Header.Set(fiber.HeaderContentType, form.FormDataContentType())
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode
+	}
+	orders := func(n int) string {
+		var b strings.Builder
+		b.WriteString("id,payout_cents,weight_lbs,volume_cuft,origin,destination,pickup_date,delivery_date\n")
+		for i := 0; i < n; i++ {
+			fmt.Fprintf(&b, "o%d,%d,100,10,\"Los Angeles, CA\",\"Dallas, TX\",2030-01-01,2030-01-03\n", i, 10000+i)
+		}
+		return b.String()
+	}
+	upload := func(files ...string) func(form *multipart.Writer) {
+		return func(form *multipart.Writer) {
+			for _, orders := range files {
+				file, _ := form.CreateFormFile("orders", "orders.csv")
+				file.Write([]byte(orders))
+			}
+			// Fields may follow the file
+			form.WriteField("truck_id", "truck-1")
+			form.WriteField("max_weight_lbs", "10000")
+			form.WriteField("max_volume_cuft", "1000")
+		}
+	}
+	
+	if large := orders(100); len(large) <= limit {
+		t.Fatalf("orders of %d bytes do not pass the limit", len(large))
+	}
+	if status := send(upload(orders(100))); status != fiber.StatusOK {
+		t.Fatalf("status %d, want 200", status)
+	}
+	if status := send(upload(orders(domain.DefaultValidationProfile().MaxOrders + 1))); status != fiber.StatusBadRequest {
+		t.Errorf("too many orders: status %d, want 400", status)
+	}
+	if status := send(upload(ordersCSV, ordersCSV)); status != fiber.StatusBadRequest {
+		t.Errorf("two files: status %d, want 400", status)
+	}
+	status := send(func(form *multipart.Writer) {
+		upload(ordersCSV)(form)
+		form.WriteField("equipment", strings.Repeat("x", maxCSVFieldBytes+1))
+	})
+	if status != fiber.StatusBadRequest {
+		t.Errorf("long field: status %d, want 400", status)
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"smart-load/internal/auth"
+	"smart-load/internal/demo"
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// demoRequests is the /demo/requests response
+type demoRequests struct {
+	TenantID string                   `json:"tenant_id"`
+	Requests []domain.OptimizeRequest `json:"requests"`
+}
+
+// SetupDemoRoutes serves the demo mode: the demo trucks' order pools, ready to
+// post to the optimizer, and a reset of the demo tenant's data. The routes are
+// under /api, so with API keys they need the admin-config scope and a key
+// bound to the demo tenant.
+func SetupDemoRoutes(app *fiber.App, optimizerService *service.OptimizerService) {
+	demoRoutes := app.Group("/api/v1/demo", requireScope(auth.ScopeAdminConfig), requireDemoTenant)
+	demoRoutes.Get("/requests", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(demoRequests{
+			TenantID: demo.TenantID,
+			Requests: optimizerService.DemoRequests(),
+		})
+	})
+	demoRoutes.Post("/reset", func(c *fiber.Ctx) error {
+		seed, err := optimizerService.ResetDemo(c.UserContext())
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(seed)
+	})
+}
+
+// requireDemoTenant rejects callers whose API key is not bound to the demo tenant
+func requireDemoTenant(c *fiber.Ctx) error {
+	principal, ok := c.Locals(principalKey).(auth.Principal)
+	if ok && !principal.CanAccessTenant(demo.TenantID) {
+		return respondError(c, fiber.StatusForbidden, "API key is not allowed to act for tenant "+demo.TenantID)
+	}
+	return c.Next()
+}
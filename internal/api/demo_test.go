@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"smart-load/internal/auth"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestDemoRoutesNeedAdminConfig(t *testing.T) {
+	keys, err := auth.NewKeyStore([]auth.KeyConfig{
+		{Name: "solver", Key: "solver-5d1c0e7a9b3f", Scopes: []auth.Scope{auth.ScopeSolve}, Tenants: []string{"*"}},
+		{Name: "acme-ops", Key: "acme-ops-2f8e6d4c1a", Scopes: []auth.Scope{auth.ScopeAdminConfig}, Tenants: []string{"acme"}},
+		{Name: "ops", Key: "ops-71ad5c2e9f3b4406", Scopes: []auth.Scope{auth.ScopeAdminConfig}, Tenants: []string{"*"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := fiber.New()
+	app.Use("/api", APIKeyAuth(keys))
+	SetupDemoRoutes(app, service.NewOptimizerService())
+	
+	for key, want := range map[string]int{
+		"":                     fiber.StatusUnauthorized,
+		"solver-5d1c0e7a9b3f":  fiber.StatusForbidden,
+		"acme-ops-2f8e6d4c1a":  fiber.StatusForbidden,
+		"ops-71ad5c2e9f3b4406": fiber.StatusOK,
+	} {
+		req := httptest.NewRequest("GET", "/api/v1/demo/requests", nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("key %q: status %d, want %d", key, resp.StatusCode, want)
+		}
+	}
+}
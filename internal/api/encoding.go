@@ -0,0 +1,105 @@
+package api
+
+import (
+	"bytes"
+	"smart-load/internal/domain"
+	"smart-load/internal/grpcapi"
+	"smart-load/internal/grpcapi/smartloadpb"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Media types the optimize endpoints take besides JSON. They answer in
+// protobuf and msgpack too; NDJSON is for requests only.
+const (
+	MIMEApplicationProtobuf = "application/x-protobuf"
+	MIMEApplicationMsgpack  = "application/msgpack"
+	MIMEApplicationNDJSON   = "application/x-ndjson"
+)
+
+// bodyFormat is the media type of the request body: protobuf, msgpack,
+// NDJSON, or JSON for anything else
+func bodyFormat(c *fiber.Ctx) string {
+	contentType := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.HasPrefix(contentType, MIMEApplicationProtobuf):
+		return MIMEApplicationProtobuf
+	case strings.HasPrefix(contentType, MIMEApplicationMsgpack):
+		return MIMEApplicationMsgpack
+	case strings.HasPrefix(contentType, MIMEApplicationNDJSON):
+		return MIMEApplicationNDJSON
+	}
+	return fiber.MIMEApplicationJSON
+}
+
+// responseFormat is the media type to answer in: the Accept header's pick of
+// JSON, protobuf and msgpack, or the body's format when Accept states no
+// preference, with NDJSON bodies answered in JSON. It is empty when Accept
+// allows none of them.
+func responseFormat(c *fiber.Ctx) string {
+	if accept := c.Get(fiber.HeaderAccept); accept == "" || accept == "*/*" {
+		if format := bodyFormat(c); format != MIMEApplicationNDJSON {
+			return format
+		}
+		return fiber.MIMEApplicationJSON
+	}
+	return c.Accepts(fiber.MIMEApplicationJSON, MIMEApplicationProtobuf, MIMEApplicationMsgpack)
+}
+
+// respondNotAcceptable answers a request whose Accept header allows none of
+// the optimize endpoints' formats
+func respondNotAcceptable(c *fiber.Ctx) error {
+	return respondError(c, fiber.StatusNotAcceptable, "Accept must allow application/json, application/x-protobuf or application/msgpack")
+}
+
+// parseOptimizeRequest decodes an optimize request in the body's format.
+// Protobuf bodies are the gRPC service's OptimizeRequest message and carry
+// what it does; msgpack bodies use the JSON field names, and under strict
+// parsing reject unknown fields as JSON does. NDJSON bodies are decoded as
+// they arrive, by parseNDJSONRequest.
+func parseOptimizeRequest(c *fiber.Ctx, request *domain.OptimizeRequest) error {
+	switch bodyFormat(c) {
+	case MIMEApplicationProtobuf:
+		var message smartloadpb.OptimizeRequest
+		if err := proto.Unmarshal(c.Body(), &message); err != nil {
+			return err
+		}
+		*request = grpcapi.RequestFromProto(&message)
+		return nil
+	case MIMEApplicationMsgpack:
+		strict, _ := c.Locals(strictParsingKey).(bool)
+		decoder := msgpack.NewDecoder(bytes.NewReader(c.Body()))
+		decoder.SetCustomStructTag("json")
+		decoder.DisallowUnknownFields(strict)
+		return decoder.Decode(request)
+	case MIMEApplicationNDJSON:
+		return parseNDJSONRequest(c, request)
+	}
+	return parseBody(c, request)
+}
+
+// sendProtobuf answers with a protobuf message
+func sendProtobuf(c *fiber.Ctx, message proto.Message) error {
+	body, err := proto.Marshal(message)
+	if err != nil {
+		return respondError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	c.Set(fiber.HeaderContentType, MIMEApplicationProtobuf)
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// sendMsgpack answers with a value encoded as msgpack under its JSON field
+// names
+func sendMsgpack(c *fiber.Ctx, value interface{}) error {
+	var body bytes.Buffer
+	encoder := msgpack.NewEncoder(&body)
+	encoder.SetCustomStructTag("json")
+	if err := encoder.Encode(value); err != nil {
+		return respondError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	c.Set(fiber.HeaderContentType, MIMEApplicationMsgpack)
+	return c.Status(fiber.StatusOK).Send(body.Bytes())
+}
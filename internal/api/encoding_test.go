@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/grpcapi/smartloadpb"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// post sends body to path with the given Content-Type and Accept headers
+func post(t *testing.T, path, contentType, accept string, body []byte) *http.Response {
+	t.Helper()
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	req := httptest.NewRequest("POST", path, bytes.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, contentType)
+	if accept != "" {
+		req.Header.Set(fiber.HeaderAccept, accept)
+	}
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func protobufRequest() []byte {
+	order := func(id string, payout int64, weight, volume int32) *smartloadpb.Order {
+		return &smartloadpb.Order{
+			Id: id, PayoutCents: payout, WeightLbs: weight, VolumeCuft: volume,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: "2030-01-01", DeliveryDate: "2030-01-03",
+		}
+	}
+	body, _ := proto.Marshal(&smartloadpb.OptimizeRequest{
+		Truck:  &smartloadpb.Truck{Id: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+		Orders: []*smartloadpb.Order{order("a", 100000, 5000, 100), order("b", 90000, 6000, 800), order("c", 40000, 4000, 100)},
+	})
+	return body
+}
+
+func TestOptimizeProtobuf(t *testing.T) {
+	resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationProtobuf, "", protobufRequest())
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != MIMEApplicationProtobuf {
+		t.Fatalf("status %d, content type %q: %s", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), body)
+	}
+	var response smartloadpb.OptimizeResponse
+	if err := proto.Unmarshal(body, &response); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(response.SelectedOrderIds, []string{"a", "c"}) || response.TotalPayoutCents != 140000 {
+		t.Fatalf("selected %v for %d, want a and c for 140000", response.SelectedOrderIds, response.TotalPayoutCents)
+	}
+	
+	// Accept picks the response format whatever the body's
+	resp = post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationProtobuf, fiber.MIMEApplicationJSON, protobufRequest())
+	var decoded struct {
+		SelectedOrderIDs []string `json:"selected_order_ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil || len(decoded.SelectedOrderIDs) != 2 {
+		t.Fatalf("JSON response %+v (%v)", decoded, err)
+	}
+}
+
+func TestOptimizeMsgpack(t *testing.T) {
+	var request domain.OptimizeRequest
+	if err := json.Unmarshal([]byte(streamRequest), &request); err != nil {
+		t.Fatal(err)
+	}
+	var body bytes.Buffer
+	encoder := msgpack.NewEncoder(&body)
+	encoder.SetCustomStructTag("json")
+	if err := encoder.Encode(request); err != nil {
+		t.Fatal(err)
+	}
+	
+	resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationMsgpack, "", body.Bytes())
+	if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != MIMEApplicationMsgpack {
+		t.Fatalf("status %d, content type %q", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType))
+	}
+	var response map[string]interface{}
+	if err := msgpack.NewDecoder(resp.Body).Decode(&response); err != nil {
+		t.Fatal(err)
+	}
+	if ids, _ := response["selected_order_ids"].([]interface{}); len(ids) != 2 || ids[0] != "a" {
+		t.Fatalf("response %v, want the JSON field names and a and c selected", response)
+	}
+	
+	resp = post(t, "/api/v1/load-optimizer/pareto-solutions", fiber.MIMEApplicationJSON, MIMEApplicationMsgpack, []byte(streamRequest))
+	var page map[string]interface{}
+	if err := msgpack.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	if page["truck_id"] != "truck-1" || page["solutions"] == nil {
+		t.Fatalf("pareto page %v", page)
+	}
+}
+
+func TestParetoProtobuf(t *testing.T) {
+	resp := post(t, "/api/v1/load-optimizer/pareto-solutions?page_size=1", MIMEApplicationProtobuf, MIMEApplicationProtobuf, protobufRequest())
+	body, _ := io.ReadAll(resp.Body)
+	var page smartloadpb.ParetoSolutionsResponse
+	if err := proto.Unmarshal(body, &page); err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Solutions) != 1 || page.Total < 2 || page.NextCursor == "" {
+		t.Fatalf("page of %d solutions of %d, cursor %q; want the first of several", len(page.Solutions), page.Total, page.NextCursor)
+	}
+}
+
+func TestBinaryBodyErrors(t *testing.T) {
+	resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationProtobuf, "", []byte{0xff, 0xff})
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusBadRequest || !strings.Contains(string(body), "Invalid request body") {
+		t.Errorf("garbled protobuf: status %d, %s", resp.StatusCode, body)
+	}
+	
+	resp = post(t, "/api/v1/load-optimizer/optimize", fiber.MIMEApplicationJSON, "text/csv", []byte(streamRequest))
+	if resp.StatusCode != fiber.StatusNotAcceptable {
+		t.Errorf("Accept text/csv: status %d, want 406", resp.StatusCode)
+	}
+}
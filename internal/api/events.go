@@ -0,0 +1,80 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"smart-load/internal/i18n"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// wantsEventStream reports whether the client asked for server-sent events
+func wantsEventStream(c *fiber.Ctx) bool {
+	return strings.Contains(c.Get(fiber.HeaderAccept), "text/event-stream")
+}
+
+// eventStream writes server-sent events, flushing each as it is sent. Events
+// may be sent from several goroutines at once.
+type eventStream struct {
+	mu       sync.Mutex
+	w        *bufio.Writer
+	language string
+	cancel   context.CancelFunc
+	failed   bool
+}
+
+// streamEvents answers with a server-sent event stream written by write. The
+// status goes out before write runs, so failures are sent as "error" events.
+// ctx is cancelled once the client stops reading.
+func streamEvents(c *fiber.Ctx, write func(ctx context.Context, events *eventStream)) error {
+	language := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderContentLanguage, language)
+	c.Vary(fiber.HeaderAcceptLanguage)
+	c.Status(fiber.StatusOK)
+	
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		write(ctx, &eventStream{w: w, language: language, cancel: cancel})
+	})
+	return nil
+}
+
+// send writes one event with data as JSON. Once a write fails the client is
+// gone: the stream's context is cancelled and later events are dropped.
+func (e *eventStream) send(event string, data interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.failed {
+		return
+	}
+	payload, err := json.Marshal(data)
+	if err == nil {
+		_, err = fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", event, payload)
+	}
+	if err == nil {
+		err = e.w.Flush()
+	}
+	if err != nil {
+		log.Printf("Event stream closed at %s event: %v", event, err)
+		e.failed = true
+		e.cancel()
+	}
+}
+
+// fail sends an "error" event shaped like an error response body
+func (e *eventStream) fail(code int, message string) {
+	e.send("error", fiber.Map{
+		"error": fiber.Map{
+			"code":    code,
+			"message": i18n.Translate(e.language, message),
+		},
+	})
+}
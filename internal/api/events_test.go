@@ -0,0 +1,191 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const streamRequest = `{
+	"truck": {"id": "truck-1", "max_weight_lbs": 10000, "max_volume_cuft": 1000},
+	"k": 3,
+	"orders": [
+		{"id": "a", "payout_cents": 100000, "weight_lbs": 5000, "volume_cuft": 100, "origin": "Los Angeles, CA", "destination": "Dallas, TX", "pickup_date": "2030-01-01", "delivery_date": "2030-01-03"},
+		{"id": "b", "payout_cents": 90000, "weight_lbs": 6000, "volume_cuft": 100, "origin": "Los Angeles, CA", "destination": "Dallas, TX", "pickup_date": "2030-01-01", "delivery_date": "2030-01-03"},
+		{"id": "c", "payout_cents": 40000, "weight_lbs": 4000, "volume_cuft": 100, "origin": "Los Angeles, CA", "destination": "Dallas, TX", "pickup_date": "2030-01-01", "delivery_date": "2030-01-03"}
+	]
+}`
+
+type event struct {
+	name string
+	data json.RawMessage
+}
+
+// readEvents posts body to path asking for server-sent events and returns
+// the events in the order they arrived
+func readEvents(t *testing.T, path, body string) []event {
+	t.Helper()
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	req := httptest.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderAccept, "text/event-stream")
+	
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentType); got != "text/event-stream" {
+		t.Fatalf("content type = %q, want text/event-stream", got)
+	}
+	
+	var events []event
+	var current event
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			current.name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			current.data = json.RawMessage(strings.TrimPrefix(line, "data: "))
+		case line == "":
+			events = append(events, current)
+			current = event{}
+		}
+	}
+	return events
+}
+
+func TestOptimizeStreamsAlternatives(t *testing.T) {
+	events := readEvents(t, "/api/v1/load-optimizer/optimize", streamRequest)
+	if len(events) == 0 || events[len(events)-1].name != "result" {
+		t.Fatalf("got %d events, want alternatives then a result", len(events))
+	}
+	if events[0].name != "started" {
+		t.Fatalf("first event %q, want started", events[0].name)
+	}
+	var streamed []event
+	for _, e := range events[1 : len(events)-1] {
+		switch e.name {
+		case "alternative":
+			streamed = append(streamed, e)
+		case "progress":
+		default:
+			t.Fatalf("event %q before the result, want alternative or progress", e.name)
+		}
+	}
+	
+	var result struct {
+		Alternatives []json.RawMessage `json:"alternatives"`
+	}
+	if err := json.Unmarshal(events[len(events)-1].data, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Alternatives) < 2 || len(streamed) != len(result.Alternatives) {
+		t.Fatalf("streamed %d alternatives, returned %d", len(streamed), len(result.Alternatives))
+	}
+	for i, alternative := range result.Alternatives {
+		if string(alternative) != string(streamed[i].data) {
+			t.Errorf("alternative %d streamed as %s, returned as %s", i, streamed[i].data, alternative)
+		}
+	}
+}
+
+func TestOptimizeStreamReportsErrors(t *testing.T) {
+	body := strings.Replace(streamRequest, `"max_weight_lbs": 10000`, `"max_weight_lbs": -1`, 1)
+	events := readEvents(t, "/api/v1/load-optimizer/optimize", body)
+	if len(events) != 1 || events[0].name != "error" {
+		t.Fatalf("got %v, want a single error event", events)
+	}
+	var failure struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(events[0].data, &failure); err != nil {
+		t.Fatal(err)
+	}
+	if failure.Error.Code != fiber.StatusBadRequest {
+		t.Errorf("code = %d, want 400", failure.Error.Code)
+	}
+}
+
+func TestParetoStreamsSolutions(t *testing.T) {
+	events := readEvents(t, "/api/v1/load-optimizer/pareto-solutions", streamRequest)
+	if len(events) < 2 || events[len(events)-1].name != "done" {
+		t.Fatalf("got %v, want solutions then done", events)
+	}
+	for _, e := range events[:len(events)-1] {
+		if e.name != "solution" {
+			t.Fatalf("event %q before done, want solution", e.name)
+		}
+	}
+	
+	var done struct {
+		Total     int   `json:"total"`
+		Exact     bool  `json:"exact"`
+		Dominated []int `json:"dominated"`
+	}
+	if err := json.Unmarshal(events[len(events)-1].data, &done); err != nil {
+		t.Fatal(err)
+	}
+	if !done.Exact || len(done.Dominated) != 0 {
+		t.Errorf("done = %+v, want an exact frontier with nothing dominated", done)
+	}
+	if done.Total != len(events)-1 {
+		t.Errorf("total = %d, want the %d streamed solutions", done.Total, len(events)-1)
+	}
+}
+
+func TestOptimizeStreamsProgress(t *testing.T) {
+	body := strings.Replace(streamRequest, `"k": 3,`, `"optimization_config": {"algorithm": "branch_and_bound"},`, 1)
+	events := readEvents(t, "/api/v1/load-optimizer/optimize", body)
+	if len(events) < 3 || events[len(events)-1].name != "result" {
+		t.Fatalf("got %v, want started, progress, then a result", events)
+	}
+	
+	var started struct {
+		RunID string `json:"run_id"`
+	}
+	if err := json.Unmarshal(events[0].data, &started); err != nil || started.RunID == "" {
+		t.Fatalf("started event %s, want a run id", events[0].data)
+	}
+	var progress struct {
+		Algorithm       string   `json:"algorithm"`
+		SelectedOrders  []string `json:"selected_order_ids"`
+		BestPayoutCents int64    `json:"best_payout_cents"`
+	}
+	last := events[len(events)-2]
+	if last.name != "progress" {
+		t.Fatalf("event before the result %q, want progress", last.name)
+	}
+	if err := json.Unmarshal(last.data, &progress); err != nil {
+		t.Fatal(err)
+	}
+	if progress.Algorithm != "branch_and_bound" || progress.BestPayoutCents != 140000 {
+		t.Errorf("progress = %+v, want branch_and_bound at 140000 cents", progress)
+	}
+}
+
+func TestStopRunUnknown(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	req := httptest.NewRequest("POST", "/api/v1/load-optimizer/runs/nope/stop", nil)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status = %d, want 404", resp.StatusCode)
+	}
+}
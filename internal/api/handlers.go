@@ -1,21 +1,53 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"smart-load/internal/auth"
 	"smart-load/internal/domain"
+	"smart-load/internal/grpcapi"
+	"smart-load/internal/grpcapi/smartloadpb"
+	"smart-load/internal/i18n"
 	"smart-load/internal/service"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
 )
 
 func SetupRoutes(app *fiber.App, optimizerService *service.OptimizerService) {
 	app.Get("/healthz", HealthCheckHandler)
 	app.Get("/actuator/health", HealthCheckHandler)
+	app.Get("/health/details", HealthDetailsHandler(optimizerService))
+	setupDocsRoutes(app)
 	
 	v1 := app.Group("/api/v1")
-	loadOptimizer := v1.Group("/load-optimizer")
+	loadOptimizer := v1.Group("/load-optimizer", requireScope(auth.ScopeSolve))
 	loadOptimizer.Post("/optimize", OptimizeHandler(optimizerService))
 	loadOptimizer.Post("/pareto-solutions", ParetoHandler(optimizerService))
+	loadOptimizer.Post("/bid-scenarios", BidScenariosHandler(optimizerService))
+	loadOptimizer.Post("/backhaul", BackhaulHandler(optimizerService))
+	loadOptimizer.Post("/optimize-xml", OptimizeXMLHandler(optimizerService))
+	loadOptimizer.Post("/optimize-csv", OptimizeCSVHandler(optimizerService))
+	loadOptimizer.Post("/optimize-batch", OptimizeBatchHandler(optimizerService))
+	loadOptimizer.Post("/runs/:runId/stop", StopRunHandler(optimizerService))
+	loadOptimizer.Post("/jobs", SubmitJobHandler(optimizerService))
+	loadOptimizer.Get("/jobs/:jobId", JobHandler(optimizerService))
+	setupPlanRoutes(loadOptimizer, optimizerService)
+	v1.Get("/algorithms/:name", requireScope(auth.ScopeSolve), AlgorithmHandler(optimizerService))
+	
+	setupTenantRoutes(v1, optimizerService)
+	setupClockRoutes(v1, optimizerService)
+	setupRecordingRoutes(v1, optimizerService)
+	
+	v1.Get("/history/export", requireScope(auth.ScopeReadHistory), HistoryExportHandler(optimizerService))
+	v1.Get("/history/solutions/:solutionId", requireScope(auth.ScopeReadHistory), SolveHandler(optimizerService))
+	v1.Get("/usage", requireScope(auth.ScopeReadHistory), UsageHandler(optimizerService))
+	v1.Get("/analytics/duplicates", requireScope(auth.ScopeReadHistory), DuplicatesHandler(optimizerService))
+	v1.Get("/analytics/accruals", requireScope(auth.ScopeReadHistory), AccrualsHandler(optimizerService))
+	v1.Get("/analytics/utilization", requireScope(auth.ScopeReadHistory), UtilizationHandler(optimizerService))
 }
 
 func HealthCheckHandler(c *fiber.Ctx) error {
@@ -26,90 +58,368 @@ func HealthCheckHandler(c *fiber.Ctx) error {
 	})
 }
 
+// HealthDetailsHandler reports recent solver health, for dispatch systems
+// that fall back to manual planning when solver quality drops. It answers
+// 200 even when degraded; the status field carries the verdict.
+func HealthDetailsHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(optimizerService.HealthDetails())
+	}
+}
+
 func OptimizeHandler(optimizerService *service.OptimizerService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var request domain.OptimizeRequest
-		if err := c.BodyParser(&request); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    fiber.StatusBadRequest,
-					"message": "Invalid JSON format",
-					"details": err.Error(),
-				},
-			})
+		if err := parseOptimizeRequest(c, &request); err != nil {
+			return respondParseError(c, err)
 		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
 		
-		response, err := optimizerService.OptimizeLoad(request)
+		if wantsEventStream(c) {
+			return streamOptimize(c, optimizerService, request)
+		}
+		format := responseFormat(c)
+		if format == "" {
+			return respondNotAcceptable(c)
+		}
+		
+		response, err := optimizerService.OptimizeLoad(c.UserContext(), request)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		
+		switch format {
+		case MIMEApplicationProtobuf:
+			return sendProtobuf(c, grpcapi.ResponseToProto(response))
+		case MIMEApplicationMsgpack:
+			return sendMsgpack(c, response)
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+}
+
+// streamOptimize answers /optimize as server-sent events: a "started" event
+// with the run id StopRunHandler takes, "progress" events while the search
+// runs, an "alternative" event per ranked alternative as soon as it is
+// found, then a "result" event with the full response, or an "error" event
+// if the solve fails.
+func streamOptimize(c *fiber.Ctx, optimizerService *service.OptimizerService, request domain.OptimizeRequest) error {
+	return streamEvents(c, func(ctx context.Context, events *eventStream) {
+		response, err := optimizerService.OptimizeLoadStream(ctx, request, service.Stream{
+			Started: func(runID string) {
+				events.send("started", fiber.Map{"run_id": runID})
+			},
+			Progress: func(progress domain.SolveProgress) {
+				events.send("progress", progress)
+			},
+			Alternative: func(summary domain.PlanSummary) {
+				events.send("alternative", summary)
+			},
+		})
+		if err != nil {
+			events.fail(solveErrorStatus(err), err.Error())
+			return
+		}
+		events.send("result", response)
+	})
+}
+
+// StopRunHandler stops the search of a solve streamed from /optimize, which
+// then answers with the best plan found so far. Only the tenant and API key
+// that started the run may stop it.
+func StopRunHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !optimizerService.StopRun(c.Get("X-Tenant-ID"), principalName(c), c.Params("runId")) {
+			return respondError(c, fiber.StatusNotFound, "no run of this id is searching")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// solveErrorStatus maps a solve error to its response status
+func solveErrorStatus(err error) int {
+	if strings.Contains(err.Error(), "validation") {
+		return fiber.StatusBadRequest
+	}
+	if isAborted(err) {
+		return fiber.StatusServiceUnavailable
+	}
+	return fiber.StatusInternalServerError
+}
+
+// BidScenariosHandler picks the bids and load with the highest expected
+// profit; see OptimizerService.OptimizeBids
+func BidScenariosHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request domain.BidRequest
+		if err := parseBody(c, &request); err != nil {
+			return respondParseError(c, err)
+		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		response, err := optimizerService.OptimizeBids(c.UserContext(), request)
 		if err != nil {
 			statusCode := fiber.StatusInternalServerError
-			
 			if strings.Contains(err.Error(), "validation") {
 				statusCode = fiber.StatusBadRequest
+			} else if isAborted(err) {
+				statusCode = fiber.StatusServiceUnavailable
 			}
-			
-			return c.Status(statusCode).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    statusCode,
-					"message": err.Error(),
-				},
-			})
+			return respondError(c, statusCode, err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+}
+
+// BackhaulHandler pairs an outbound plan with return loads; see
+// OptimizerService.MatchBackhauls
+func BackhaulHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request domain.BackhaulRequest
+		if err := parseBody(c, &request); err != nil {
+			return respondParseError(c, err)
+		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		response, err := optimizerService.MatchBackhauls(c.UserContext(), request)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
+	}
+}
+
+// OptimizeBatchHandler solves independent requests in one call; see
+// OptimizerService.OptimizeBatch. The call succeeds when the batch is well
+// formed, and each result carries the status its request would have had
+// alone.
+func OptimizeBatchHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request domain.BatchRequest
+		if err := parseBatchRequest(c, &request); err != nil {
+			return respondParseError(c, err)
+		}
+		tenantID, apiKey := utils.CopyString(c.Get("X-Tenant-ID")), principalName(c)
+		for i := range request.Requests {
+			request.Requests[i].TenantID = tenantID
+			request.Requests[i].APIKey = apiKey
 		}
 		
+		results, err := optimizerService.OptimizeBatch(c.UserContext(), request)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		response := domain.BatchResponse{Results: make([]domain.BatchItem, len(results))}
+		for i, result := range results {
+			item := domain.BatchItem{Index: i, Status: fiber.StatusOK, Response: result.Response}
+			if result.Err != nil {
+				item.Status = solveErrorStatus(result.Err)
+				item.Error = &domain.ErrorDetail{Code: item.Status, Message: localize(c, result.Err.Error())}
+				response.Failed++
+			} else {
+				response.Succeeded++
+			}
+			response.Results[i] = item
+		}
 		return c.Status(fiber.StatusOK).JSON(response)
 	}
 }
 
+// RequestSizeLimiter rejects bodies over maxBytes. Bodies the handlers
+// stream are exempt (see streamsBody): NDJSON is read a line at a time, JSON
+// batches a request at a time and CSV uploads a row at a time, each bounded
+// by its item count. When the server streams request bodies, a chunked body
+// of unknown size is read up to the limit here, so the other handlers only
+// ever see a bounded buffer.
 func RequestSizeLimiter(maxBytes int) fiber.Handler {
+	// A streamed body may be left partly unread, and the connection then
+	// cannot carry another request
+	tooLarge := func(c *fiber.Ctx) error {
+		c.Context().SetConnectionClose()
+		return respondError(c, fiber.StatusRequestEntityTooLarge, "Request body too large")
+	}
 	return func(c *fiber.Ctx) error {
-		if c.Request().Header.ContentLength() > maxBytes {
-			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    fiber.StatusRequestEntityTooLarge,
-					"message": "Request body too large",
-				},
-			})
+		length := c.Request().Header.ContentLength()
+		if streamsBody(c) {
+			if length > maxBytes || length < 0 {
+				c.Context().SetConnectionClose()
+			}
+			return c.Next()
+		}
+		if length > maxBytes {
+			return tooLarge(c)
+		}
+		if stream := c.Context().RequestBodyStream(); stream != nil && length < 0 {
+			body, err := io.ReadAll(io.LimitReader(stream, int64(maxBytes)+1))
+			if err != nil {
+				return respondError(c, fiber.StatusBadRequest, err.Error())
+			}
+			if len(body) > maxBytes {
+				return tooLarge(c)
+			}
+			c.Request().SetBodyRaw(body)
 		}
 		return c.Next()
 	}
 }
 
+// isAborted reports whether an optimization was stopped by cancellation or its deadline
+func isAborted(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func respondError(c *fiber.Ctx, code int, message string) error {
+	return c.Status(code).JSON(fiber.Map{
+		"error": fiber.Map{
+			"code":    code,
+			"message": localize(c, message),
+		},
+	})
+}
+
+// localize translates an error message into the language the client's
+// Accept-Language header prefers and labels the response with it
+func localize(c *fiber.Ctx, message string) string {
+	language := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Set(fiber.HeaderContentLanguage, language)
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return i18n.Translate(language, message)
+}
+
+// maxParetoSolutions caps the limit query parameter of /pareto-solutions;
+// the solutions are returned in pages of at most maxPageSize
+const maxParetoSolutions = 1000
+
 func ParetoHandler(optimizerService *service.OptimizerService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var request domain.OptimizeRequest
-		if err := c.BodyParser(&request); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    fiber.StatusBadRequest,
-					"message": "Invalid JSON format",
-				},
-			})
+		if err := parseOptimizeRequest(c, &request); err != nil {
+			return respondParseError(c, err)
 		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
 		
-		if err := request.Validate(); err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    fiber.StatusBadRequest,
-					"message": err.Error(),
-				},
-			})
+		if err := optimizerService.ValidateRequest(&request); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
 		}
 		
 		truck, orders, err := request.ToDomain()
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": fiber.Map{
-					"code":    fiber.StatusBadRequest,
-					"message": err.Error(),
-				},
-			})
+			return respondError(c, fiber.StatusBadRequest, err.Error())
 		}
 		
-		solutions := optimizerService.GetParetoOptimalSolutions(*truck, orders, 5)
+		limit := c.QueryInt("limit", 20)
+		if limit < 1 || limit > maxParetoSolutions {
+			return respondError(c, fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxParetoSolutions))
+		}
+		paging, err := parsePage(c, fmt.Sprintf("pareto:%s:%d", request.Fingerprint(), limit))
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
 		
-		return c.Status(fiber.StatusOK).JSON(fiber.Map{
-			"truck_id":  truck.ID,
-			"solutions": solutions,
-			"count":     len(solutions),
-		})
+		sealed := request.PayoutsSealed()
+		if wantsEventStream(c) {
+			return streamPareto(c, optimizerService, request.TenantID, *truck, orders, request.Pins(), limit, sealed)
+		}
+		format := responseFormat(c)
+		if format == "" {
+			return respondNotAcceptable(c)
+		}
+		
+		solutions, exact, err := optimizerService.GetParetoOptimalSolutions(c.UserContext(), request.TenantID, *truck, orders, request.Pins(), limit)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		
+		start, end, next := paging.bounds(len(solutions))
+		page := solutions[start:end]
+		
+		if sealed {
+			for i := range page {
+				page[i].RedactPayout()
+			}
+		}
+		
+		if format == MIMEApplicationProtobuf {
+			message := &smartloadpb.ParetoSolutionsResponse{
+				TruckId:        truck.ID,
+				Solutions:      make([]*smartloadpb.ParetoSolution, len(page)),
+				Exact:          exact,
+				Total:          int32(len(solutions)),
+				NextCursor:     next,
+				PayoutRedacted: sealed,
+			}
+			for i, solution := range page {
+				message.Solutions[i] = grpcapi.ParetoSolutionToProto(solution)
+			}
+			return sendProtobuf(c, message)
+		}
+		response := fiber.Map{
+			"truck_id":        truck.ID,
+			"solutions":       page,
+			"count":           len(page),
+			"total":           len(solutions),
+			"exact":           exact,
+			"payout_redacted": sealed,
+		}
+		if next != "" {
+			response["next_cursor"] = next
+		}
+		if format == MIMEApplicationMsgpack {
+			return sendMsgpack(c, response)
+		}
+		return c.Status(fiber.StatusOK).JSON(response)
 	}
 }
+
+// streamPareto answers /pareto-solutions as server-sent events: a "solution"
+// event per plan as soon as it is found, then a "done" event. Sampled
+// frontiers can stream a plan a later one dominates; "done" lists the
+// positions of those plans, counted from 0 in stream order, under
+// "dominated". Streams are not paged.
+func streamPareto(
+	c *fiber.Ctx,
+	optimizerService *service.OptimizerService,
+	tenantID string,
+	truck domain.Truck,
+	orders []domain.Order,
+	pins domain.Pins,
+	limit int,
+	sealed bool,
+) error {
+	return streamEvents(c, func(ctx context.Context, events *eventStream) {
+		var streamed []string
+		solutions, exact, err := optimizerService.StreamParetoSolutions(ctx, tenantID, truck, orders, pins, limit, func(solution service.ParetoSolution) {
+			streamed = append(streamed, strings.Join(solution.OrderIDs, ","))
+			if sealed {
+				solution.RedactPayout()
+			}
+			events.send("solution", solution)
+		})
+		if err != nil {
+			events.fail(solveErrorStatus(err), err.Error())
+			return
+		}
+		
+		kept := make(map[string]bool, len(solutions))
+		for _, solution := range solutions {
+			kept[strings.Join(solution.OrderIDs, ",")] = true
+		}
+		dominated := make([]int, 0)
+		for i, ids := range streamed {
+			if !kept[ids] {
+				dominated = append(dominated, i)
+			}
+		}
+		events.send("done", fiber.Map{
+			"truck_id":        truck.ID,
+			"total":           len(solutions),
+			"exact":           exact,
+			"dominated":       dominated,
+			"payout_redacted": sealed,
+		})
+	})
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"smart-load/internal/auth"
+	"smart-load/internal/history"
+	"smart-load/internal/service"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func HistoryExportHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		from, err := parseTimeParam(c.Query("from"), time.Time{})
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "invalid from: "+err.Error())
+		}
+		to, err := parseTimeParam(c.Query("to"), optimizerService.Now().UTC().Add(time.Second))
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, "invalid to: "+err.Error())
+		}
+		if !from.Before(to) {
+			return respondError(c, fiber.StatusBadRequest, "from must be before to")
+		}
+		
+		tenantID, status, message := historyTenant(c)
+		if status != 0 {
+			return respondError(c, status, message)
+		}
+		records := optimizerService.History(tenantID, from, to)
+		
+		format := c.Query("format", "csv")
+		exporter, ok := exportFormats[format]
+		if !ok {
+			return respondError(c, fiber.StatusBadRequest, fmt.Sprintf("invalid format: %s (must be csv, parquet, or arrow)", format))
+		}
+		
+		c.Set(fiber.HeaderContentType, exporter.contentType)
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="history.`+exporter.extension+`"`)
+		c.Status(fiber.StatusOK)
+		
+		// Stream the file instead of building it in memory. The status is sent
+		// before the first row, so a failure part way through can only be
+		// logged; the client sees a truncated file.
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			if err := exporter.write(w, records); err != nil {
+				log.Printf("History export (%s, %d records) failed: %v", format, len(records), err)
+				return
+			}
+			if err := w.Flush(); err != nil {
+				log.Printf("History export (%s) flush failed: %v", format, err)
+			}
+		})
+		return nil
+	}
+}
+
+// historyTenant picks the tenant whose history a request reads. One tenant's
+// view must never include another's solves, so the all-tenant view ("") has
+// to be asked for and needs the admin-config scope. A non-zero status rejects
+// the request with message.
+func historyTenant(c *fiber.Ctx) (string, int, string) {
+	tenantID := c.Get("X-Tenant-ID")
+	if tenantID != "" {
+		return tenantID, 0, ""
+	}
+	if c.Query("all_tenants") != "true" {
+		return "", fiber.StatusBadRequest, "X-Tenant-ID header is required (or all_tenants=true)"
+	}
+	if principal, ok := c.Locals(principalKey).(auth.Principal); ok &&
+		(!principal.HasScope(auth.ScopeAdminConfig) || !principal.HasAllTenants()) {
+		return "", fiber.StatusForbidden, "reading all tenants requires the admin-config scope and access to every tenant"
+	}
+	return "", 0, ""
+}
+
+// SolveHandler returns the history record of one solve. Solves of other
+// tenants are reported as not found.
+func SolveHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		record, ok := optimizerService.Solve(c.Params("solutionId"))
+		if !ok || record.TenantID != c.Get("X-Tenant-ID") {
+			return respondError(c, fiber.StatusNotFound, "solution not found")
+		}
+		return c.Status(fiber.StatusOK).JSON(record)
+	}
+}
+
+type exportFormat struct {
+	contentType string
+	extension   string
+	write       func(w io.Writer, records []history.Record) error
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv":     {contentType: "text/csv", extension: "csv", write: history.WriteCSV},
+	"parquet": {contentType: "application/vnd.apache.parquet", extension: "parquet", write: history.WriteParquet},
+	"arrow":   {contentType: "application/vnd.apache.arrow.stream", extension: "arrows", write: history.WriteArrow},
+}
+
+// parseTimeParam accepts RFC 3339 timestamps or YYYY-MM-DD dates (midnight UTC)
+func parseTimeParam(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC 3339 timestamp or YYYY-MM-DD")
+	}
+	return t, nil
+}
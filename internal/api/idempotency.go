@@ -0,0 +1,174 @@
+package api
+
+import (
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyKeyHeader names a POST request so that retries of it are
+// answered with the response to the first
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength bounds the keys clients may choose
+const maxIdempotencyKeyLength = 255
+
+// idempotentEntry is the first request sent under a key: its fingerprint,
+// and once it has been answered, the response
+type idempotentEntry struct {
+	fingerprint [sha256.Size]byte
+	expires     time.Time
+	done        bool
+	status      int
+	headers     [][2]string
+	body        []byte
+}
+
+// idempotencyStore keeps the entries of one window, in the order they were
+// made, which is also the order they expire in. Past maxEntries keys, the
+// oldest is dropped before its window ends.
+type idempotencyStore struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+	
+	mu      sync.Mutex
+	entries map[string]*idempotentEntry
+	order   []idempotentKey
+}
+
+// idempotentKey is a key and when the entry made under it expires; a key
+// forgotten and made again is listed once for each entry
+type idempotentKey struct {
+	key     string
+	expires time.Time
+}
+
+// begin returns the entry already made under key, or makes one for a request
+// of the given fingerprint and returns nil
+func (s *idempotencyStore) begin(key string, fingerprint [sha256.Size]byte) *idempotentEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	now := s.now()
+	expired := 0
+	for expired < len(s.order) && !now.Before(s.order[expired].expires) {
+		listed := s.order[expired]
+		if entry, ok := s.entries[listed.key]; ok && entry.expires.Equal(listed.expires) {
+			delete(s.entries, listed.key)
+		}
+		expired++
+	}
+	s.order = s.order[expired:]
+	
+	if entry, ok := s.entries[key]; ok {
+		copied := *entry
+		return &copied
+	}
+	// Forgotten keys stay listed until they expire, so bounding the list
+	// also bounds the entries
+	for len(s.order) >= s.maxEntries {
+		oldest := s.order[0]
+		if entry, ok := s.entries[oldest.key]; ok && entry.expires.Equal(oldest.expires) {
+			delete(s.entries, oldest.key)
+		}
+		s.order = s.order[1:]
+	}
+	expires := now.Add(s.window)
+	s.entries[key] = &idempotentEntry{fingerprint: fingerprint, expires: expires}
+	s.order = append(s.order, idempotentKey{key: key, expires: expires})
+	return nil
+}
+
+// finish stores the response to the request made under key, or forgets the
+// request when response is nil so that a retry is handled afresh
+func (s *idempotencyStore) finish(key string, response *idempotentEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	if response == nil {
+		delete(s.entries, key)
+		return
+	}
+	entry.done = true
+	entry.status, entry.headers, entry.body = response.status, response.headers, response.body
+}
+
+// Idempotency honors an Idempotency-Key header on POST requests. The first
+// request sent under a key is handled as usual and its response kept for
+// window; a retry with the same key, method, path and body within the window
+// is answered with that response, marked Idempotent-Replayed, and is not
+// handled again. Keys are scoped to the tenant and API key. Reusing a key for
+// another request is rejected with 422, and a retry sent while the first
+// request is still being handled with 409.
+//
+// At most maxEntries keys are kept; past that, the oldest is forgotten early.
+// Server errors and streamed responses are not kept, so a retry after one is
+// handled afresh. NDJSON bodies are read as they arrive and cannot be
+// compared, so they cannot be sent with a key.
+func Idempotency(window time.Duration, maxEntries int) fiber.Handler {
+	store := &idempotencyStore{window: window, maxEntries: maxEntries, now: time.Now, entries: make(map[string]*idempotentEntry)}
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return respondError(c, fiber.StatusBadRequest, "Idempotency-Key cannot exceed 255 characters")
+		}
+		if bodyFormat(c) == MIMEApplicationNDJSON {
+			return respondError(c, fiber.StatusBadRequest, "Idempotency-Key cannot be used with NDJSON bodies")
+		}
+		
+		scoped := c.Get("X-Tenant-ID") + "\x00" + principalName(c) + "\x00" + key
+		hash := sha256.New()
+		for _, part := range []string{c.Method(), c.OriginalURL(), c.Get(fiber.HeaderContentType)} {
+			hash.Write([]byte(part))
+			hash.Write([]byte{0})
+		}
+		hash.Write(c.Body())
+		var fingerprint [sha256.Size]byte
+		copy(fingerprint[:], hash.Sum(nil))
+		
+		if entry := store.begin(scoped, fingerprint); entry != nil {
+			switch {
+			case entry.fingerprint != fingerprint:
+				return respondError(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case !entry.done:
+				return respondError(c, fiber.StatusConflict, "a request with this Idempotency-Key is still being handled")
+			}
+			for _, header := range entry.headers {
+				c.Set(header[0], header[1])
+			}
+			c.Set("Idempotent-Replayed", "true")
+			return c.Status(entry.status).Send(entry.body)
+		}
+		
+		if err := c.Next(); err != nil {
+			store.finish(scoped, nil)
+			return err
+		}
+		response := c.Response()
+		if response.StatusCode() >= fiber.StatusInternalServerError || response.IsBodyStream() {
+			store.finish(scoped, nil)
+			return nil
+		}
+		kept := &idempotentEntry{status: response.StatusCode(), body: append([]byte(nil), response.Body()...)}
+		response.Header.VisitAll(func(name, value []byte) {
+			switch strings.ToLower(string(name)) {
+			case "content-length", "date", "server", "connection", "transfer-encoding":
+				return
+			}
+			kept.headers = append(kept.headers, [2]string{string(name), string(value)})
+		})
+		store.finish(scoped, kept)
+		return nil
+	}
+}
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func idempotentApp(calls *int) *fiber.App {
+	app := fiber.New()
+	app.Use(Idempotency(time.Hour, 100))
+	app.Post("/api/solve", func(c *fiber.Ctx) error {
+		*calls++
+		if c.Query("fail") != "" {
+			return respondError(c, fiber.StatusServiceUnavailable, "busy")
+		}
+		c.Set("X-Solve", fmt.Sprint(*calls))
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"call": *calls})
+	})
+	return app
+}
+
+func sendIdempotent(t *testing.T, app *fiber.App, path, key, tenant, body string) (int, string, map[string]string) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(IdempotencyKeyHeader, key)
+	req.Header.Set("X-Tenant-ID", tenant)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	headers := map[string]string{
+		"X-Solve":             resp.Header.Get("X-Solve"),
+		"Idempotent-Replayed": resp.Header.Get("Idempotent-Replayed"),
+	}
+	return resp.StatusCode, string(data), headers
+}
+
+func TestIdempotencyReplays(t *testing.T) {
+	calls := 0
+	app := idempotentApp(&calls)
+	
+	status, first, headers := sendIdempotent(t, app, "/api/solve", "k1", "acme", `{"a":1}`)
+	if status != fiber.StatusCreated || headers["Idempotent-Replayed"] != "" {
+		t.Fatalf("first: status %d, headers %v", status, headers)
+	}
+	status, again, headers := sendIdempotent(t, app, "/api/solve", "k1", "acme", `{"a":1}`)
+	if status != fiber.StatusCreated || again != first || headers["X-Solve"] != "1" || headers["Idempotent-Replayed"] != "true" {
+		t.Errorf("replay: status %d, body %s, headers %v; want the first response replayed", status, again, headers)
+	}
+	if calls != 1 {
+		t.Errorf("handler ran %d times, want once", calls)
+	}
+	
+	if status, _, _ := sendIdempotent(t, app, "/api/solve", "k1", "acme", `{"a":2}`); status != fiber.StatusUnprocessableEntity {
+		t.Errorf("key reused for another body: status %d, want 422", status)
+	}
+	if status, _, _ := sendIdempotent(t, app, "/api/solve", "k1", "other", `{"a":1}`); status != fiber.StatusCreated || calls != 2 {
+		t.Errorf("another tenant's key: status %d after %d calls, want it handled", status, calls)
+	}
+	if status, _, _ := sendIdempotent(t, app, "/api/solve", "", "acme", `{"a":1}`); status != fiber.StatusCreated || calls != 3 {
+		t.Errorf("no key: status %d after %d calls, want it handled", status, calls)
+	}
+}
+
+func TestIdempotencyForgetsServerErrors(t *testing.T) {
+	calls := 0
+	app := idempotentApp(&calls)
+	for i := 0; i < 2; i++ {
+		if status, _, _ := sendIdempotent(t, app, "/api/solve?fail=1", "k1", "acme", `{}`); status != fiber.StatusServiceUnavailable {
+			t.Fatalf("status %d, want 503", status)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want a retry after a server error to run again", calls)
+	}
+}
+
+func TestIdempotencyRejectsLongKeys(t *testing.T) {
+	calls := 0
+	app := idempotentApp(&calls)
+	if status, _, _ := sendIdempotent(t, app, "/api/solve", strings.Repeat("k", 256), "acme", `{}`); status != fiber.StatusBadRequest || calls != 0 {
+		t.Errorf("status %d after %d calls, want 400 unhandled", status, calls)
+	}
+}
+
+func TestIdempotencyStore(t *testing.T) {
+	now := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	store := &idempotencyStore{window: time.Hour, maxEntries: 100, now: func() time.Time { return now }, entries: make(map[string]*idempotentEntry)}
+	fingerprint := sha256.Sum256([]byte("request"))
+	
+	if entry := store.begin("k", fingerprint); entry != nil {
+		t.Fatalf("new key returned %+v", entry)
+	}
+	if entry := store.begin("k", fingerprint); entry == nil || entry.done {
+		t.Fatalf("in-flight key returned %+v, want an unfinished entry", entry)
+	}
+	store.finish("k", &idempotentEntry{status: fiber.StatusOK, body: []byte("ok")})
+	if entry := store.begin("k", fingerprint); entry == nil || !entry.done || string(entry.body) != "ok" {
+		t.Fatalf("finished key returned %+v", entry)
+	}
+	
+	now = now.Add(time.Hour)
+	if entry := store.begin("k", fingerprint); entry != nil {
+		t.Errorf("expired key returned %+v", entry)
+	}
+	if len(store.order) != 1 || len(store.entries) != 1 {
+		t.Errorf("store holds %d keys in order and %d entries, want the expired one dropped", len(store.order), len(store.entries))
+	}
+}
+
+func TestIdempotencyStoreCap(t *testing.T) {
+	now := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	store := &idempotencyStore{window: time.Hour, maxEntries: 3, now: func() time.Time { return now }, entries: make(map[string]*idempotentEntry)}
+	fingerprint := sha256.Sum256([]byte("request"))
+	
+	for _, key := range []string{"a", "b", "c", "d"} {
+		now = now.Add(time.Second)
+		store.begin(key, fingerprint)
+	}
+	if len(store.order) != 3 || len(store.entries) != 3 {
+		t.Fatalf("store holds %d keys in order and %d entries, want 3", len(store.order), len(store.entries))
+	}
+	if _, ok := store.entries["a"]; ok {
+		t.Error("the oldest key was kept past the cap")
+	}
+	if entry := store.begin("d", fingerprint); entry == nil {
+		t.Error("the newest key was forgotten")
+	}
+	
+	// Keys forgotten after a server error count until they expire, so
+	// retries cannot grow the list either
+	for i := 0; i < 10; i++ {
+		store.begin("retry", fingerprint)
+		store.finish("retry", nil)
+	}
+	if len(store.order) != 3 || len(store.entries) > 3 {
+		t.Errorf("after retries the store holds %d keys in order and %d entries, want at most 3", len(store.order), len(store.entries))
+	}
+}
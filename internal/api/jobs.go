@@ -0,0 +1,45 @@
+package api
+
+import (
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// SubmitJobHandler queues an optimize request to be solved in the
+// background and answers 202 with the queued job, whose Location is polled
+// for the outcome; see OptimizerService.SubmitJob
+func SubmitJobHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request domain.OptimizeRequest
+		if err := parseBody(c, &request); err != nil {
+			return respondParseError(c, err)
+		}
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		job, err := optimizerService.SubmitJob(c.UserContext(), request)
+		if err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		c.Location("/api/v1/load-optimizer/jobs/" + job.ID)
+		return c.Status(fiber.StatusAccepted).JSON(job)
+	}
+}
+
+// JobHandler reports a job's state, and once it has run, its response or
+// error. Only the tenant and API key that submitted a job can see it.
+func JobHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		job, ok, err := optimizerService.Job(c.UserContext(), c.Get("X-Tenant-ID"), principalName(c), c.Params("jobId"))
+		if err != nil {
+			return respondError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		if !ok {
+			return respondError(c, fiber.StatusNotFound, "job not found")
+		}
+		return c.Status(fiber.StatusOK).JSON(job)
+	}
+}
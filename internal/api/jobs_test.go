@@ -0,0 +1,57 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"smart-load/internal/jobs"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestJobHandlers(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/load-optimizer/jobs", strings.NewReader(streamRequest))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var job jobs.Job
+	if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+		t.Fatal(err)
+	}
+	location := resp.Header.Get(fiber.HeaderLocation)
+	if resp.StatusCode != fiber.StatusAccepted || job.Status != jobs.StatusQueued || location != "/api/v1/load-optimizer/jobs/"+job.ID {
+		t.Fatalf("submit: status %d, job %+v, location %q", resp.StatusCode, job, location)
+	}
+	
+	deadline := time.Now().Add(5 * time.Second)
+	for !job.Finished() && time.Now().Before(deadline) {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, location, nil), -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("poll: status %d", resp.StatusCode)
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if job.Status != jobs.StatusSucceeded || job.Response == nil || len(job.Response.SelectedOrderIDs) == 0 {
+		t.Errorf("job = %+v, want it succeeded with a plan", job)
+	}
+	
+	req = httptest.NewRequest(fiber.MethodGet, location, nil)
+	req.Header.Set("X-Tenant-ID", "other")
+	if resp, _ := app.Test(req, -1); resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("another tenant's poll: status %d, want 404", resp.StatusCode)
+	}
+}
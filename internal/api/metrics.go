@@ -0,0 +1,42 @@
+package api
+
+import (
+	"errors"
+	"smart-load/internal/metrics"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+)
+
+// unmatchedRoute labels requests that matched no route, so scans of unknown
+// paths add one series rather than one per path
+const unmatchedRoute = "unmatched"
+
+// Metrics counts and times every request by its route pattern and status.
+// Errors are answered here, with the app's error handler, so their status
+// is the one counted.
+func Metrics(m *metrics.Metrics) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		started := time.Now()
+		err := c.Next()
+		route := c.Route().Path
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) && (fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusMethodNotAllowed) {
+			route = unmatchedRoute
+		}
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				c.Status(fiber.StatusInternalServerError)
+			}
+		}
+		
+		m.ObserveRequest(c.Method(), route, c.Response().StatusCode(), time.Since(started))
+		return nil
+	}
+}
+
+// MetricsHandler serves the metrics for Prometheus to scrape
+func MetricsHandler(m *metrics.Metrics) fiber.Handler {
+	return adaptor.HTTPHandler(m.Handler())
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/metrics"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMetricsLabelsRequestsByRoute(t *testing.T) {
+	registry := metrics.New()
+	app := fiber.New()
+	app.Use(Metrics(registry))
+	SetupRoutes(app, service.NewOptimizerService())
+	app.Get("/metrics", MetricsHandler(registry))
+	
+	for _, path := range []string{"/healthz", "/api/v1/history/solutions/one", "/api/v1/history/solutions/two", "/no-such-path"} {
+		if _, err := app.Test(httptest.NewRequest(fiber.MethodGet, path, nil), -1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/metrics", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	for _, want := range []string{
+		`smartload_http_requests_total{method="GET",route="/healthz",status="200"} 1`,
+		`smartload_http_requests_total{method="GET",route="/api/v1/history/solutions/:solutionId",status="404"} 2`,
+		`smartload_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`smartload_http_request_duration_seconds_count{method="GET",route="/healthz"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
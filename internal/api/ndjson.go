@@ -0,0 +1,95 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"smart-load/internal/domain"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxNDJSONLineBytes bounds a single NDJSON line to the size a whole JSON
+// body may have
+const maxNDJSONLineBytes = 1 * 1024 * 1024
+
+// parseNDJSONRequest decodes an NDJSON optimize request line by line as the
+// body arrives, so large order lists are never held as one buffer. The first
+// line is the truck, or the request without its orders when it has a "truck"
+// field, for requests that also carry a config; every later line is an
+// order. Blank lines are skipped. Reading stops once the orders pass the
+// most any request may have, so an endless body cannot exhaust memory.
+func parseNDJSONRequest(c *fiber.Ctx, request *domain.OptimizeRequest) error {
+	if len(c.Request().Header.Peek(fiber.HeaderContentEncoding)) > 0 {
+		return fmt.Errorf("NDJSON bodies cannot have a Content-Encoding")
+	}
+	strict, _ := c.Locals(strictParsingKey).(bool)
+	
+	maxOrders := domain.DefaultValidationProfile().MaxOrders
+	scanner := bufio.NewScanner(requestBody(c))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineBytes)
+	line, header := 0, false
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		
+		if !header {
+			header = true
+			var envelope struct {
+				Truck json.RawMessage `json:"truck"`
+			}
+			var err error
+			if json.Unmarshal(text, &envelope) == nil && envelope.Truck != nil {
+				err = decodeNDJSONLine(text, request, strict)
+			} else {
+				err = decodeNDJSONLine(text, &request.Truck, strict)
+			}
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		
+		var order domain.OrderInput
+		if err := decodeNDJSONLine(text, &order, strict); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if len(request.Orders) == maxOrders {
+			return fmt.Errorf("orders list cannot exceed %d items", maxOrders)
+		}
+		request.Orders = append(request.Orders, order)
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return fmt.Errorf("line %d is longer than %d bytes", line+1, maxNDJSONLineBytes)
+		}
+		return err
+	}
+	if !header {
+		return fmt.Errorf("NDJSON body is empty")
+	}
+	return nil
+}
+
+// decodeNDJSONLine decodes one line; strict parsing rejects unknown fields
+// and anything after the value, as it does for whole JSON bodies
+func decodeNDJSONLine(line []byte, out interface{}, strict bool) error {
+	if !strict {
+		return json.Unmarshal(line, out)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ndjsonOrders is an NDJSON line per order, n orders of 100 lbs each
+func ndjsonOrders(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `{"id":"o%d","payout_cents":%d,"weight_lbs":100,"volume_cuft":10,"origin":"Los Angeles, CA","destination":"Dallas, TX","pickup_date":"2030-01-01","delivery_date":"2030-01-03"}`+"\n", i, 10000+i)
+	}
+	return b.String()
+}
+
+func selectedCount(t *testing.T, resp *http.Response) int {
+	t.Helper()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	var decoded struct {
+		SelectedOrderIDs []string `json:"selected_order_ids"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	return len(decoded.SelectedOrderIDs)
+}
+
+func TestOptimizeNDJSON(t *testing.T) {
+	truck := `{"id":"truck-1","max_weight_lbs":250,"max_volume_cuft":1000}` + "\n"
+	resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationNDJSON, "", []byte(truck+"\n"+ndjsonOrders(3)))
+	if resp.Header.Get(fiber.HeaderContentType) != fiber.MIMEApplicationJSON {
+		t.Fatalf("content type %q, want JSON", resp.Header.Get(fiber.HeaderContentType))
+	}
+	if n := selectedCount(t, resp); n != 2 {
+		t.Fatalf("selected %d orders, want the 2 that fit", n)
+	}
+	
+	// A first line with a truck field is the request without its orders
+	envelope := `{"truck":{"id":"truck-1","max_weight_lbs":250,"max_volume_cuft":1000},"min_total_payout_cents":1}` + "\n"
+	if n := selectedCount(t, post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationNDJSON, "", []byte(envelope+ndjsonOrders(3)))); n != 2 {
+		t.Fatalf("selected %d orders, want 2", n)
+	}
+}
+
+func TestOptimizeNDJSONErrors(t *testing.T) {
+	truck := `{"id":"truck-1","max_weight_lbs":250,"max_volume_cuft":1000}` + "\n"
+	for name, body := range map[string]string{
+		"empty":      "\n\n",
+		"bad order":  truck + ndjsonOrders(1) + "{not json}\n",
+		"too many":   truck + ndjsonOrders(1001),
+		"long line":  truck + `{"id":"` + strings.Repeat("x", maxNDJSONLineBytes) + `"}` + "\n",
+		"bad header": "[1,2]\n" + ndjsonOrders(1),
+	} {
+		resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationNDJSON, "", []byte(body))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, resp.StatusCode)
+		}
+	}
+	
+	resp := post(t, "/api/v1/load-optimizer/optimize", MIMEApplicationNDJSON, "", []byte(truck+ndjsonOrders(1)+"{not json}\n"))
+	var decoded struct {
+		Error struct {
+			Details string `json:"details"`
+		} `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	if !strings.HasPrefix(decoded.Error.Details, "line 3:") {
+		t.Fatalf("details %q, want the line number", decoded.Error.Details)
+	}
+}
+
+// With streamed bodies, NDJSON may pass the body limit while other bodies,
+// chunked ones included, are still held to it
+func TestNDJSONPassesBodyLimit(t *testing.T) {
+	const limit = 4096
+	app := fiber.New(fiber.Config{BodyLimit: limit, StreamRequestBody: true})
+	app.Use(RequestSizeLimiter(limit))
+	SetupRoutes(app, service.NewOptimizerService())
+	send := func(contentType string, body string, chunked bool) *http.Response {
+		req := httptest.NewRequest("POST", "/api/v1/load-optimizer/optimize", strings.NewReader(body))
+		req.Header.Set(fiber.HeaderContentType, contentType)
+		if chunked {
+			req.ContentLength = -1
+			req.TransferEncoding = []string{"chunked"}
+		}
+		resp, err := app.Test(req, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+	
+	orders := ndjsonOrders(100)
+	if len(orders) <= limit {
+		t.Fatalf("body of %d bytes does not pass the limit", len(orders))
+	}
+	truck := `{"id":"truck-1","max_weight_lbs":250,"max_volume_cuft":1000}` + "\n"
+	if n := selectedCount(t, send(MIMEApplicationNDJSON, truck+orders, false)); n != 2 {
+		t.Fatalf("selected %d orders, want 2", n)
+	}
+	if n := selectedCount(t, send(MIMEApplicationNDJSON, truck+orders, true)); n != 2 {
+		t.Fatalf("chunked: selected %d orders, want 2", n)
+	}
+	
+	large := `{"truck":{"id":"truck-1"},"orders":[],"pad":"` + strings.Repeat("x", limit) + `"}`
+	for _, chunked := range []bool{false, true} {
+		if resp := send(fiber.MIMEApplicationJSON, large, chunked); resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+			t.Errorf("chunked %v: JSON over the limit got status %d, want 413", chunked, resp.StatusCode)
+		}
+	}
+	// Chunked bodies under the limit are still read in full
+	small := strings.Replace(streamRequest, "\n", " ", -1)
+	if len(small) > limit {
+		t.Fatalf("streamRequest is %d bytes", len(small))
+	}
+	if resp := send(fiber.MIMEApplicationJSON, small, true); resp.StatusCode != fiber.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("chunked JSON under the limit: status %d: %s", resp.StatusCode, body)
+	}
+	
+	resp := send(MIMEApplicationNDJSON, truck+orders, false)
+	resp.Body.Close()
+	req := httptest.NewRequest("POST", "/api/v1/load-optimizer/optimize-xml", strings.NewReader(orders))
+	req.Header.Set(fiber.HeaderContentType, MIMEApplicationNDJSON)
+	if resp, _ = app.Test(req, -1); resp.StatusCode != fiber.StatusUnsupportedMediaType {
+		t.Fatalf("NDJSON XML tender: status %d, want 415", resp.StatusCode)
+	}
+}
@@ -0,0 +1,403 @@
+package api
+
+import (
+	"path"
+	"reflect"
+	"regexp"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/jobs"
+	"smart-load/internal/service"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// operationDoc describes the bodies of one route. Request and Response are
+// zero values of the types the handler parses and answers with; nil means
+// no body, or one the schema does not describe.
+type operationDoc struct {
+	// ID names the operation in the generated clients
+	ID       string
+	Summary  string
+	Request  interface{}
+	Response interface{}
+	// RequestType is the request's media type when it is not JSON. The body
+	// is text unless Request describes it, as it does form fields.
+	RequestType string
+	// Status is the success status when it is not 200
+	Status int
+	// Binary marks bodies that may also be protobuf or msgpack, and requests
+	// that may be NDJSON
+	Binary bool
+}
+
+// paretoPage is the /pareto-solutions response
+type paretoPage struct {
+	TruckID        string                   `json:"truck_id"`
+	Solutions      []service.ParetoSolution `json:"solutions"`
+	Count          int                      `json:"count"`
+	Total          int                      `json:"total"`
+	Exact          bool                     `json:"exact"`
+	PayoutRedacted bool                     `json:"payout_redacted"`
+	NextCursor     string                   `json:"next_cursor,omitempty"`
+}
+
+// disabledRules is the body and response of /tenants/:tenantId/disabled-rules
+type disabledRules struct {
+	TenantID      string   `json:"tenant_id,omitempty"`
+	DisabledRules []string `json:"disabled_rules"`
+	Toggleable    []string `json:"toggleable,omitempty"`
+}
+
+// csvUpload is the /optimize-csv form: the truck's fields, and the orders
+// as a CSV file
+type csvUpload struct {
+	Orders             string `json:"orders"`
+	TruckID            string `json:"truck_id"`
+	MaxWeightLbs       int    `json:"max_weight_lbs"`
+	MaxVolumeCuft      int    `json:"max_volume_cuft"`
+	FixedCostCents     int64  `json:"fixed_cost_cents,omitempty"`
+	CostPerMileCents   int64  `json:"cost_per_mile_cents,omitempty"`
+	StopFeeCents       int64  `json:"stop_fee_cents,omitempty"`
+	MaxOrders          int    `json:"max_orders,omitempty"`
+	MaxLinearFeet      int    `json:"max_linear_feet,omitempty"`
+	MaxPalletPositions int    `json:"max_pallet_positions,omitempty"`
+	EquipmentType      string `json:"equipment_type,omitempty"`
+	// Equipment separates its items with semicolons
+	Equipment        string `json:"equipment,omitempty"`
+	InteriorLengthIn int    `json:"interior_length_in,omitempty"`
+	InteriorWidthIn  int    `json:"interior_width_in,omitempty"`
+	InteriorHeightIn int    `json:"interior_height_in,omitempty"`
+}
+
+// solutionRecord is the /history/solutions/:solutionId response, named so
+// its schema does not read as a generic record
+type solutionRecord struct {
+	history.Record
+}
+
+// operationDocs documents routes by method and path as registered. Routes
+// missing here are still listed in the document, with untyped bodies.
+var operationDocs = map[string]operationDoc{
+	"GET /healthz":        {ID: "healthCheck", Summary: "Liveness check"},
+	"GET /health/details": {ID: "healthDetails", Summary: "Recent solver health", Response: domain.HealthDetails{}},
+	"POST /api/v1/load-optimizer/optimize": {
+		ID: "optimize", Summary: "Pick the best load for one truck", Request: domain.OptimizeRequest{}, Response: domain.OptimizeResponse{},
+		Binary: true,
+	},
+	"POST /api/v1/load-optimizer/pareto-solutions": {
+		ID: "paretoSolutions", Summary: "List plans trading payout against utilization", Request: domain.OptimizeRequest{}, Response: paretoPage{},
+		Binary: true,
+	},
+	"POST /api/v1/load-optimizer/bid-scenarios": {
+		ID: "bidScenarios", Summary: "Pick bids and a load by expected profit", Request: domain.BidRequest{}, Response: domain.BidResponse{},
+	},
+	"POST /api/v1/load-optimizer/backhaul": {
+		ID: "backhaul", Summary: "Pair an outbound plan with return loads", Request: domain.BackhaulRequest{}, Response: domain.BackhaulResponse{},
+	},
+	"POST /api/v1/load-optimizer/optimize-xml": {
+		ID: "optimizeXML", Summary: "Pick the best load from a TMS XML export", RequestType: fiber.MIMEApplicationXML, Response: domain.OptimizeResponse{},
+	},
+	"POST /api/v1/load-optimizer/optimize-batch": {
+		ID: "optimizeBatch", Summary: "Pick the best loads for several trucks at once", Request: domain.BatchRequest{}, Response: domain.BatchResponse{},
+	},
+	"POST /api/v1/load-optimizer/optimize-csv": {
+		ID: "optimizeCSV", Summary: "Pick the best load from a spreadsheet of orders", Request: csvUpload{}, RequestType: fiber.MIMEMultipartForm, Response: domain.OptimizeResponse{},
+	},
+	"POST /api/v1/load-optimizer/jobs": {
+		ID: "submitJob", Summary: "Queue a load to be picked in the background", Request: domain.OptimizeRequest{}, Response: jobs.Job{},
+		Status: fiber.StatusAccepted,
+	},
+	"GET /api/v1/load-optimizer/jobs/:jobId": {ID: "getJob", Summary: "Get a background job and its outcome", Response: jobs.Job{}},
+	"POST /api/v1/load-optimizer/runs/:runId/stop": {
+		ID: "stopRun", Summary: "Stop a streamed solve early with its best plan so far", Status: fiber.StatusNoContent,
+	},
+	"GET /api/v1/load-optimizer/trucks/:truckId/plan": {ID: "getPlan", Summary: "Get a truck's committed plan", Response: domain.PlanState{}},
+	"PUT /api/v1/load-optimizer/trucks/:truckId/plan": {
+		ID: "commitPlan", Summary: "Commit a truck's plan", Request: domain.OptimizeRequest{}, Response: domain.PlanState{},
+	},
+	"DELETE /api/v1/load-optimizer/trucks/:truckId/plan": {
+		ID: "releasePlan", Summary: "Release a truck's committed plan", Status: fiber.StatusNoContent,
+	},
+	"POST /api/v1/load-optimizer/trucks/:truckId/plan/check": {
+		ID: "checkPlanOrder", Summary: "Check whether an order can join a committed plan", Request: domain.OrderInput{}, Response: domain.AdditionCheck{},
+	},
+	"POST /api/v1/load-optimizer/trucks/:truckId/plan/orders": {
+		ID: "addPlanOrder", Summary: "Add an order to a committed plan", Request: domain.OrderInput{}, Response: domain.AdditionCheck{},
+	},
+	"GET /api/v1/algorithms/:name": {ID: "getAlgorithm", Summary: "Describe an algorithm", Response: domain.AlgorithmCapabilities{}},
+	"GET /api/v1/tenants/:tenantId/disabled-rules": {
+		ID: "getDisabledRules", Summary: "List the built-in rules a tenant switches off", Response: disabledRules{},
+	},
+	"PUT /api/v1/tenants/:tenantId/disabled-rules": {
+		ID: "setDisabledRules", Summary: "Replace the built-in rules a tenant switches off", Request: disabledRules{}, Response: disabledRules{},
+	},
+	"POST /api/v1/tenants/:tenantId/purge": {
+		ID: "purgeTenant", Summary: "Purge a tenant's expired data now", Response: service.TenantPurge{},
+	},
+	"GET /api/v1/demo/requests": {
+		ID: "demoRequests", Summary: "List the demo trucks' order pools", Response: demoRequests{},
+	},
+	"POST /api/v1/demo/reset": {
+		ID: "resetDemo", Summary: "Restore the demo tenant's data", Response: service.DemoSeed{},
+	},
+	"GET /api/v1/history/solutions/:solutionId": {ID: "getSolution", Summary: "Get the history record of a solve", Response: solutionRecord{}},
+}
+
+// setupDocsRoutes serves the OpenAPI document of every route registered on
+// the app, and a Swagger UI to explore it
+func setupDocsRoutes(app *fiber.App) {
+	var once sync.Once
+	var document fiber.Map
+	app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		// Built on first use, once every route is registered
+		once.Do(func() {
+			document = OpenAPIDocument(app.GetRoutes(true))
+		})
+		return c.Status(fiber.StatusOK).JSON(document)
+	})
+	app.Get("/docs", func(c *fiber.Ctx) error {
+		c.Type("html")
+		return c.SendString(swaggerUIPage)
+	})
+}
+
+// fiberParam matches a route parameter such as :tenantId
+var fiberParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// OpenAPIDocument describes routes as an OpenAPI 3.0 document. Bodies come
+// from operationDocs, by reflection over their types' JSON tags.
+func OpenAPIDocument(routes []fiber.Route) fiber.Map {
+	schemas := newSchemaSet()
+	paths := fiber.Map{}
+	for _, route := range routes {
+		if route.Method == fiber.MethodHead || route.Method == fiber.MethodOptions {
+			continue
+		}
+		pattern := fiberParam.ReplaceAllString(route.Path, "{$1}")
+		item, ok := paths[pattern].(fiber.Map)
+		if !ok {
+			item = fiber.Map{}
+			paths[pattern] = item
+		}
+		item[strings.ToLower(route.Method)] = openAPIOperation(route, operationDocs[route.Method+" "+route.Path], schemas)
+	}
+	
+	return fiber.Map{
+		"openapi": "3.0.3",
+		"info": fiber.Map{
+			"title":   "SmartLoad Optimizer API",
+			"version": "1.0.0",
+		},
+		"paths": paths,
+		"components": fiber.Map{
+			"schemas": schemas.components,
+			"securitySchemes": fiber.Map{
+				"apiKey": fiber.Map{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+				"bearer": fiber.Map{"type": "http", "scheme": "bearer"},
+			},
+		},
+	}
+}
+
+func openAPIOperation(route fiber.Route, doc operationDoc, schemas *schemaSet) fiber.Map {
+	operation := fiber.Map{}
+	if doc.ID != "" {
+		operation["operationId"] = doc.ID
+	}
+	if doc.Summary != "" {
+		operation["summary"] = doc.Summary
+	}
+	
+	parameters := make([]fiber.Map, 0, len(route.Params)+2)
+	for _, name := range route.Params {
+		parameters = append(parameters, fiber.Map{
+			"name": name, "in": "path", "required": true, "schema": fiber.Map{"type": "string"},
+		})
+	}
+	if strings.HasPrefix(route.Path, "/api/") {
+		parameters = append(parameters, fiber.Map{
+			"name": "X-Tenant-ID", "in": "header", "schema": fiber.Map{"type": "string"},
+		})
+		operation["security"] = []fiber.Map{{"apiKey": []string{}}, {"bearer": []string{}}}
+		if route.Method == fiber.MethodPost {
+			parameters = append(parameters, fiber.Map{
+				"name": IdempotencyKeyHeader, "in": "header", "schema": fiber.Map{"type": "string", "maxLength": maxIdempotencyKeyLength},
+			})
+		}
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+	
+	switch {
+	case doc.RequestType != "":
+		schema := fiber.Map{"type": "string"}
+		if doc.Request != nil {
+			schema = schemas.schema(reflect.TypeOf(doc.Request))
+		}
+		operation["requestBody"] = fiber.Map{
+			"required": true,
+			"content":  fiber.Map{doc.RequestType: fiber.Map{"schema": schema}},
+		}
+	case doc.Request != nil:
+		content := doc.content(schemas.schema(reflect.TypeOf(doc.Request)))
+		if doc.Binary {
+			// A truck line, or the request without its orders, then an order per line
+			content[MIMEApplicationNDJSON] = fiber.Map{"schema": fiber.Map{"type": "string"}}
+		}
+		operation["requestBody"] = fiber.Map{"required": true, "content": content}
+	}
+	
+	success := fiber.Map{"description": "Success"}
+	if doc.Response != nil {
+		success["content"] = doc.content(schemas.schema(reflect.TypeOf(doc.Response)))
+	}
+	errorResponse := fiber.Map{
+		"description": "Error",
+		"content":     fiber.Map{fiber.MIMEApplicationJSON: fiber.Map{"schema": schemas.schema(reflect.TypeOf(domain.ErrorResponse{}))}},
+	}
+	status := fiber.StatusOK
+	if doc.Status != 0 {
+		status = doc.Status
+	}
+	operation["responses"] = fiber.Map{strconv.Itoa(status): success, "default": errorResponse}
+	return operation
+}
+
+// content lists the media types of a body with the JSON schema. Msgpack
+// bodies share it; protobuf bodies are the gRPC service's messages.
+func (doc operationDoc) content(schema fiber.Map) fiber.Map {
+	content := fiber.Map{fiber.MIMEApplicationJSON: fiber.Map{"schema": schema}}
+	if doc.Binary {
+		content[MIMEApplicationMsgpack] = fiber.Map{"schema": schema}
+		content[MIMEApplicationProtobuf] = fiber.Map{"schema": fiber.Map{"type": "string", "format": "binary"}}
+	}
+	return content
+}
+
+// schemaSet turns Go types into JSON schemas, collecting named structs as
+// components referenced by name
+type schemaSet struct {
+	components fiber.Map
+	names      map[reflect.Type]string
+}
+
+func newSchemaSet() *schemaSet {
+	return &schemaSet{components: fiber.Map{}, names: map[reflect.Type]string{}}
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schema describes how encoding/json encodes a value of type t
+func (s *schemaSet) schema(t reflect.Type) fiber.Map {
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := s.schema(t.Elem())
+		if _, ref := schema["$ref"]; !ref {
+			schema["nullable"] = true
+		}
+		return schema
+	case reflect.Bool:
+		return fiber.Map{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return fiber.Map{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return fiber.Map{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return fiber.Map{"type": "number"}
+	case reflect.String:
+		return fiber.Map{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return fiber.Map{"type": "string", "format": "byte"}
+		}
+		return fiber.Map{"type": "array", "items": s.schema(t.Elem())}
+	case reflect.Map:
+		return fiber.Map{"type": "object", "additionalProperties": s.schema(t.Elem())}
+	case reflect.Struct:
+		if t == timeType {
+			return fiber.Map{"type": "string", "format": "date-time"}
+		}
+		if t.Name() == "" {
+			return s.object(t)
+		}
+		return fiber.Map{"$ref": "#/components/schemas/" + s.component(t)}
+	}
+	return fiber.Map{}
+}
+
+// component registers a named struct and returns its component name: the
+// type's name, capitalized, and qualified by its package when two packages
+// share it
+func (s *schemaSet) component(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+	name := strings.ToUpper(t.Name()[:1]) + t.Name()[1:]
+	if _, taken := s.components[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	s.names[t] = name
+	// Reserve the name first, for types that refer to themselves
+	s.components[name] = fiber.Map{}
+	s.components[name] = s.object(t)
+	return name
+}
+
+// object describes a struct's exported fields by their JSON names, with the
+// fields of untagged embedded structs promoted as encoding/json does
+func (s *schemaSet) object(t reflect.Type) fiber.Map {
+	properties := fiber.Map{}
+	s.addFields(t, properties)
+	object := fiber.Map{"type": "object", "properties": properties}
+	if len(properties) == 0 {
+		delete(object, "properties")
+	}
+	return object
+}
+
+func (s *schemaSet) addFields(t reflect.Type, properties fiber.Map) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			s.addFields(field.Type, properties)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = s.schema(field.Type)
+	}
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>SmartLoad Optimizer API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.onload = () => {
+      window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+    };
+  </script>
+</body>
+</html>
+`
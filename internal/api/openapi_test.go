@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	resp, err := app.Test(httptest.NewRequest("GET", "/openapi.json", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var document struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		t.Fatal(err)
+	}
+	if document.OpenAPI != "3.0.3" {
+		t.Fatalf("openapi %q", document.OpenAPI)
+	}
+	
+	optimize, ok := document.Paths["/api/v1/load-optimizer/optimize"]["post"]
+	if !ok || !strings.Contains(string(optimize), `"$ref":"#/components/schemas/OptimizeRequest"`) {
+		t.Fatalf("optimize operation %s, want a body referencing OptimizeRequest", optimize)
+	}
+	if _, ok := document.Paths["/api/v1/tenants/{tenantId}/disabled-rules"]["put"]; !ok {
+		t.Error("tenant route missing or its parameter not in OpenAPI form")
+	}
+	
+	request := document.Components.Schemas["OptimizeRequest"].Properties
+	if _, ok := request["truck"]; !ok {
+		t.Errorf("OptimizeRequest properties %v lack truck", request)
+	}
+	if _, ok := request["TenantID"]; ok {
+		t.Error("field hidden from JSON is documented")
+	}
+	backhaul := document.Components.Schemas["BackhaulRequest"].Properties
+	if _, ok := backhaul["orders"]; !ok || backhaul["outbound_order_ids"] == nil {
+		t.Errorf("BackhaulRequest properties %v, want the embedded request's fields promoted", backhaul)
+	}
+}
+
+func TestOperationDocsMatchRoutes(t *testing.T) {
+	app := fiber.New()
+	optimizerService := service.NewOptimizerService()
+	SetupRoutes(app, optimizerService)
+	SetupDemoRoutes(app, optimizerService)
+	registered := make(map[string]bool)
+	for _, route := range app.GetRoutes(true) {
+		registered[route.Method+" "+route.Path] = true
+	}
+	for key := range operationDocs {
+		if !registered[key] {
+			t.Errorf("%s is documented but not registered", key)
+		}
+	}
+}
+
+func TestSwaggerUI(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	resp, err := app.Test(httptest.NewRequest("GET", "/docs", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.HasPrefix(resp.Header.Get(fiber.HeaderContentType), "text/html") || !strings.Contains(string(body), "/openapi.json") {
+		t.Fatalf("content type %q, body %s", resp.Header.Get(fiber.HeaderContentType), body)
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Page sizes of paginated result sets
+const (
+	defaultPageSize = 100
+	maxPageSize     = 100
+)
+
+// pageCursor is where the next page of a result set starts. Scope ties the
+// cursor to the request that produced it, so it cannot be replayed against
+// a different problem and land on unrelated results.
+type pageCursor struct {
+	Offset int    `json:"offset"`
+	Scope  string `json:"scope"`
+}
+
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.Offset < 0 {
+		return pageCursor{}, fmt.Errorf("invalid cursor")
+	}
+	return cursor, nil
+}
+
+// pageRequest is the page of a result set a request asks for
+type pageRequest struct {
+	size   int
+	offset int
+	scope  string
+}
+
+// parsePage reads the page_size and cursor query parameters for a result set
+// produced for scope. It runs before the result set is computed, so a bad
+// cursor costs no solve.
+func parsePage(c *fiber.Ctx, scope string) (pageRequest, error) {
+	page := pageRequest{size: c.QueryInt("page_size", defaultPageSize), scope: scope}
+	if page.size < 1 || page.size > maxPageSize {
+		return pageRequest{}, fmt.Errorf("page_size must be between 1 and %d", maxPageSize)
+	}
+	if value := c.Query("cursor"); value != "" {
+		cursor, err := decodeCursor(value)
+		if err != nil {
+			return pageRequest{}, err
+		}
+		if cursor.Scope != scope {
+			return pageRequest{}, fmt.Errorf("cursor belongs to a different request")
+		}
+		page.offset = cursor.Offset
+	}
+	return page, nil
+}
+
+// bounds picks the page [start, end) of a result set of total items. next is
+// the cursor of the following page, or "" on the last one.
+func (p pageRequest) bounds(total int) (start, end int, next string) {
+	start = min(p.offset, total)
+	end = min(start+p.size, total)
+	if end < total {
+		next = encodeCursor(pageCursor{Offset: end, Scope: p.scope})
+	}
+	return start, end, next
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// newPagingApp pages through 0..249, scoped by the scope query parameter
+func newPagingApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		paging, err := parsePage(c, c.Query("scope"))
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		items := make([]int, 250)
+		for i := range items {
+			items[i] = i
+		}
+		start, end, next := paging.bounds(len(items))
+		return c.JSON(fiber.Map{"items": items[start:end], "next_cursor": next})
+	})
+	return app
+}
+
+type pageBody struct {
+	Items      []int  `json:"items"`
+	NextCursor string `json:"next_cursor"`
+}
+
+func getPage(t *testing.T, app *fiber.App, query url.Values) (int, pageBody) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest("GET", "/?"+query.Encode(), nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body pageBody
+	json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body
+}
+
+func TestCursorPagination(t *testing.T) {
+	app := newPagingApp()
+	
+	var seen []int
+	query := url.Values{"scope": {"a"}, "page_size": {"60"}}
+	for pages := 0; ; pages++ {
+		status, body := getPage(t, app, query)
+		if status != fiber.StatusOK || pages > 5 {
+			t.Fatalf("page %d: status %d", pages, status)
+		}
+		seen = append(seen, body.Items...)
+		if body.NextCursor == "" {
+			break
+		}
+		query.Set("cursor", body.NextCursor)
+	}
+	if len(seen) != 250 || seen[0] != 0 || seen[249] != 249 {
+		t.Fatalf("paged through %d items", len(seen))
+	}
+	
+	if _, body := getPage(t, app, url.Values{"scope": {"a"}}); len(body.Items) != maxPageSize {
+		t.Errorf("default page has %d items, want %d", len(body.Items), maxPageSize)
+	}
+}
+
+func TestCursorRejections(t *testing.T) {
+	app := newPagingApp()
+	_, first := getPage(t, app, url.Values{"scope": {"a"}})
+	
+	for name, query := range map[string]url.Values{
+		"other request": {"scope": {"b"}, "cursor": {first.NextCursor}},
+		"garbage":       {"scope": {"a"}, "cursor": {"not a cursor"}},
+		"page too big":  {"scope": {"a"}, "page_size": {"101"}},
+		"empty page":    {"scope": {"a"}, "page_size": {"0"}},
+	} {
+		if status, _ := getPage(t, app, query); status != fiber.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, status)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const strictParsingKey = "strictParsing"
+
+// JSONParsing chooses how request bodies are decoded. Lenient parsing is fiber's
+// BodyParser: unknown fields are ignored and form bodies are accepted. Strict
+// parsing only takes application/json, rejects unknown fields and trailing data,
+// so a misspelled field fails instead of silently defaulting to zero. Clients can
+// ask for strict parsing with an X-JSON-Parsing: strict header; the header can
+// only tighten the server default, never loosen it.
+func JSONParsing(strictByDefault bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		strict := strictByDefault
+		switch strings.ToLower(c.Get("X-JSON-Parsing")) {
+		case "strict":
+			strict = true
+		case "lenient":
+			if strictByDefault {
+				return respondError(c, fiber.StatusBadRequest, "X-JSON-Parsing cannot loosen the server's strict parsing")
+			}
+		case "":
+		default:
+			return respondError(c, fiber.StatusBadRequest, "X-JSON-Parsing must be strict or lenient")
+		}
+		c.Locals(strictParsingKey, strict)
+		return c.Next()
+	}
+}
+
+// parseBody decodes the request body in the parsing mode chosen for the request
+func parseBody(c *fiber.Ctx, out interface{}) error {
+	strict, _ := c.Locals(strictParsingKey).(bool)
+	if !strict {
+		return c.BodyParser(out)
+	}
+	
+	if !strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEApplicationJSON) {
+		return fmt.Errorf("strict parsing requires Content-Type %s", fiber.MIMEApplicationJSON)
+	}
+	
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(out); err != nil {
+		return err
+	}
+	// More() treats a stray closing bracket as the end of input, so read
+	// the next token and insist on a clean EOF
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON body")
+	}
+	return nil
+}
+
+func respondParseError(c *fiber.Ctx, err error) error {
+	message := "Invalid JSON format"
+	multipart := strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEMultipartForm)
+	if bodyFormat(c) != fiber.MIMEApplicationJSON || multipart {
+		message = "Invalid request body"
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error": fiber.Map{
+			"code":    fiber.StatusBadRequest,
+			"message": localize(c, message),
+			"details": err.Error(),
+		},
+	})
+}
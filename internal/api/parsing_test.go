@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newParsingApp(strictByDefault bool) *fiber.App {
+	app := fiber.New()
+	app.Use(JSONParsing(strictByDefault))
+	app.Post("/", func(c *fiber.Ctx) error {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		return c.SendString(body.Name)
+	})
+	return app
+}
+
+func TestParseBody(t *testing.T) {
+	tests := []struct {
+		name            string
+		strictByDefault bool
+		header          string
+		body            string
+		want            int
+	}{
+		{"lenient ignores unknown fields", false, "", `{"name":"a","extra":1}`, fiber.StatusOK},
+		{"header tightens", false, "strict", `{"name":"a","extra":1}`, fiber.StatusBadRequest},
+		{"strict accepts clean body", true, "", `{"name":"a"}`, fiber.StatusOK},
+		{"strict accepts trailing whitespace", true, "", "{\"name\":\"a\"}\n", fiber.StatusOK},
+		{"strict rejects unknown fields", true, "", `{"name":"a","extra":1}`, fiber.StatusBadRequest},
+		{"strict rejects stray bracket", true, "", `{"name":"a"}}`, fiber.StatusBadRequest},
+		{"strict rejects second value", true, "", `{"name":"a"} {"name":"b"}`, fiber.StatusBadRequest},
+		{"header cannot loosen", true, "lenient", `{"name":"a"}`, fiber.StatusBadRequest},
+		{"unknown mode", false, "sloppy", `{"name":"a"}`, fiber.StatusBadRequest},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newParsingApp(tt.strictByDefault)
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			if tt.header != "" {
+				req.Header.Set("X-JSON-Parsing", tt.header)
+			}
+			
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorsFollowAcceptLanguage(t *testing.T) {
+	app := newParsingApp(false)
+	req := httptest.NewRequest("POST", "/", strings.NewReader(`{"name":"a"}`))
+	req.Header.Set("X-JSON-Parsing", "sloppy")
+	req.Header.Set(fiber.HeaderAcceptLanguage, "es-MX,en;q=0.5")
+	
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if want := "X-JSON-Parsing debe ser strict o lenient"; body.Error.Message != want {
+		t.Errorf("message = %q, want %q", body.Error.Message, want)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentLanguage); got != "es" {
+		t.Errorf("Content-Language = %q, want es", got)
+	}
+}
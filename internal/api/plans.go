@@ -0,0 +1,84 @@
+package api
+
+import (
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// setupPlanRoutes serves trucks' committed plans and the hot-path check of
+// whether an order can still join one
+func setupPlanRoutes(loadOptimizer fiber.Router, optimizerService *service.OptimizerService) {
+	plan := loadOptimizer.Group("/trucks/:truckId/plan")
+	plan.Put("", CommitPlanHandler(optimizerService))
+	plan.Get("", func(c *fiber.Ctx) error {
+		state, ok := optimizerService.CommittedPlan(c.Get("X-Tenant-ID"), c.Params("truckId"))
+		if !ok {
+			return respondError(c, fiber.StatusNotFound, "no plan is committed for this truck")
+		}
+		return c.Status(fiber.StatusOK).JSON(state)
+	})
+	plan.Delete("", func(c *fiber.Ctx) error {
+		if !optimizerService.ReleasePlan(c.Get("X-Tenant-ID"), c.Params("truckId")) {
+			return respondError(c, fiber.StatusNotFound, "no plan is committed for this truck")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+	plan.Post("/check", AdditionHandler(optimizerService, false))
+	plan.Post("/orders", AdditionHandler(optimizerService, true))
+}
+
+// CommitPlanHandler commits an optimize request's orders as the truck's plan.
+// The truck's id comes from the path; a body naming another truck is rejected.
+func CommitPlanHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var request domain.OptimizeRequest
+		if err := parseBody(c, &request); err != nil {
+			return respondParseError(c, err)
+		}
+		truckID := utils.CopyString(c.Params("truckId"))
+		if request.Truck.ID != "" && request.Truck.ID != truckID {
+			return respondError(c, fiber.StatusBadRequest, "truck id must match the path")
+		}
+		request.Truck.ID = truckID
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		state, err := optimizerService.CommitPlan(c.UserContext(), request)
+		if err != nil {
+			statusCode := fiber.StatusInternalServerError
+			if strings.Contains(err.Error(), "validation") {
+				statusCode = fiber.StatusBadRequest
+			}
+			return respondError(c, statusCode, err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(state)
+	}
+}
+
+// AdditionHandler answers whether the order in the body can join the truck's
+// committed plan and, when add is set, adds it. An order that cannot be added
+// is answered 409 with the reasons.
+func AdditionHandler(optimizerService *service.OptimizerService, add bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var order domain.OrderInput
+		if err := parseBody(c, &order); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		check, ok, err := optimizerService.CheckAddition(c.Get("X-Tenant-ID"), c.Params("truckId"), order, add)
+		if !ok {
+			return respondError(c, fiber.StatusNotFound, "no plan is committed for this truck")
+		}
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		if add && !check.Added {
+			return c.Status(fiber.StatusConflict).JSON(check)
+		}
+		return c.Status(fiber.StatusOK).JSON(check)
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"smart-load/internal/auth"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// setupRecordingRoutes lets admins turn the request recorder on and off when
+// one is configured; otherwise the routes do not exist
+func setupRecordingRoutes(v1 fiber.Router, optimizerService *service.OptimizerService) {
+	if _, ok := optimizerService.Recording(); !ok {
+		return
+	}
+	admin := v1.Group("/admin/recording", requireScope(auth.ScopeAdminConfig))
+	admin.Get("", func(c *fiber.Ctx) error {
+		state, _ := optimizerService.Recording()
+		return c.Status(fiber.StatusOK).JSON(state)
+	})
+	admin.Put("", PutRecordingHandler(optimizerService))
+}
+
+// PutRecordingHandler turns recording on or off; sample_rate, when given,
+// changes the share of solves recorded
+func PutRecordingHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			Enabled    *bool    `json:"enabled"`
+			SampleRate *float64 `json:"sample_rate"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		if body.Enabled == nil {
+			return respondError(c, fiber.StatusBadRequest, "enabled is required")
+		}
+		
+		state, _ := optimizerService.Recording()
+		state.Enabled = *body.Enabled
+		if body.SampleRate != nil {
+			state.SampleRate = *body.SampleRate
+		}
+		if err := optimizerService.SetRecording(state); err != nil {
+			return respondError(c, solveErrorStatus(err), err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(state)
+	}
+}
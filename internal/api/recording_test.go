@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// discardPublisher drops every event
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(key string, event interface{}) {}
+
+func (discardPublisher) Close() error { return nil }
+
+func putRecording(t *testing.T, app *fiber.App, body string) (int, service.RecordingState) {
+	t.Helper()
+	req := httptest.NewRequest(fiber.MethodPut, "/api/v1/admin/recording", strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state service.RecordingState
+	json.NewDecoder(resp.Body).Decode(&state)
+	return resp.StatusCode, state
+}
+
+func TestRecordingRoutes(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService(service.WithRecorder(discardPublisher{}, 0.5, false, []byte("recording-test-key-0123456789abcdef"))))
+	
+	status, state := putRecording(t, app, `{"enabled": true}`)
+	if status != fiber.StatusOK || !state.Enabled || state.SampleRate != 0.5 {
+		t.Errorf("enable: status %d, state %+v; want enabled at the configured rate", status, state)
+	}
+	status, state = putRecording(t, app, `{"enabled": false, "sample_rate": 0.1}`)
+	if status != fiber.StatusOK || state.Enabled || state.SampleRate != 0.1 {
+		t.Errorf("disable: status %d, state %+v", status, state)
+	}
+	if status, _ := putRecording(t, app, `{"sample_rate": 0.1}`); status != fiber.StatusBadRequest {
+		t.Errorf("without enabled: status %d, want 400", status)
+	}
+	if status, _ := putRecording(t, app, `{"enabled": true, "sample_rate": 2}`); status != fiber.StatusBadRequest {
+		t.Errorf("sample rate 2: status %d, want 400", status)
+	}
+	
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/admin/recording", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&state); err != nil || state.Enabled || state.SampleRate != 0.1 {
+		t.Errorf("GET state %+v, %v; want the last one set", state, err)
+	}
+}
+
+func TestRecordingRoutesNeedRecorder(t *testing.T) {
+	app := fiber.New()
+	SetupRoutes(app, service.NewOptimizerService())
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/v1/admin/recording", nil), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Errorf("status %d without a recorder, want 404", resp.StatusCode)
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"log"
+	"smart-load/internal/signing"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// SignResponses rewrites JSON response bodies into canonical form and adds a
+// detached JWS over them in the X-JWS-Signature header, so clients behind
+// intermediaries can verify the exact bytes they received. The client's
+// X-Request-ID is bound into the signature and echoed back.
+func SignResponses(signer *signing.Signer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+		
+		contentType := string(c.Response().Header.ContentType())
+		if !strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) {
+			return nil
+		}
+		
+		body, err := signing.Canonicalize(c.Response().Body())
+		if err != nil {
+			log.Printf("  Response not signed: %v", err)
+			return nil
+		}
+		requestID := c.Get("X-Request-ID")
+		signature, err := signer.SignDetached(body, time.Now(), requestID)
+		if err != nil {
+			log.Printf("  Response not signed: %v", err)
+			return nil
+		}
+		
+		c.Response().SetBodyRaw(body)
+		c.Set("X-JWS-Signature", signature)
+		if requestID != "" {
+			c.Set("X-Request-ID", requestID)
+		}
+		return nil
+	}
+}
+
+// JWKSHandler publishes the response signing key
+func JWKSHandler(signer *signing.Signer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(signer.JWKS())
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"smart-load/internal/domain"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// streamedRoutes are the routes whose handlers decode the body as it
+// arrives, with the media type they stream
+var streamedRoutes = map[string]string{
+	"/api/v1/load-optimizer/optimize-batch": fiber.MIMEApplicationJSON,
+	"/api/v1/load-optimizer/optimize-csv":   fiber.MIMEMultipartForm,
+}
+
+// streamsBody reports whether the request's handler decodes its body as it
+// arrives, bounded by its item counts rather than its size: NDJSON bodies,
+// and uncompressed JSON batches and CSV uploads. Bodies sent with an
+// Idempotency-Key are read in full to be compared, so those are not streamed.
+func streamsBody(c *fiber.Ctx) bool {
+	if bodyFormat(c) == MIMEApplicationNDJSON {
+		return true
+	}
+	if c.Get(IdempotencyKeyHeader) != "" || len(c.Request().Header.Peek(fiber.HeaderContentEncoding)) > 0 {
+		return false
+	}
+	mediaType, ok := streamedRoutes[c.Path()]
+	return ok && strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), mediaType)
+}
+
+// requestBody reads the body as it arrives when the server streams it, or
+// from the buffer it was read into otherwise
+func requestBody(c *fiber.Ctx) io.Reader {
+	if body := c.Context().RequestBodyStream(); body != nil {
+		return body
+	}
+	return bytes.NewReader(c.Body())
+}
+
+// parseBatchRequest decodes a JSON batch one request at a time as the body
+// arrives, so a nightly batch is never held as one buffer besides the
+// requests themselves. Reading stops at the first request past
+// MaxBatchRequests. Strict parsing rejects unknown fields and trailing data
+// as parseBody does; lenient parsing skips unknown fields.
+func parseBatchRequest(c *fiber.Ctx, batch *domain.BatchRequest) error {
+	strict, _ := c.Locals(strictParsingKey).(bool)
+	if !strings.HasPrefix(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEApplicationJSON) || len(c.Request().Header.Peek(fiber.HeaderContentEncoding)) > 0 {
+		// Form and compressed bodies are buffered and held to the body limit
+		return parseBody(c, batch)
+	}
+	
+	decoder := json.NewDecoder(requestBody(c))
+	if strict {
+		decoder.DisallowUnknownFields()
+	}
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		// Keys are matched as encoding/json matches them
+		if key, _ := token.(string); !strings.EqualFold(key, "requests") {
+			if strict {
+				return fmt.Errorf("json: unknown field %q", key)
+			}
+			if err := skipValue(decoder); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := decodeBatchRequests(decoder, batch); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(decoder, '}'); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON body")
+	}
+	return nil
+}
+
+// decodeBatchRequests decodes the requests array, or a null
+func decodeBatchRequests(decoder *json.Decoder, batch *domain.BatchRequest) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		batch.Requests = nil
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("requests must be an array")
+	}
+	batch.Requests = batch.Requests[:0]
+	for decoder.More() {
+		if len(batch.Requests) == domain.MaxBatchRequests {
+			return fmt.Errorf("requests list cannot exceed %d items", domain.MaxBatchRequests)
+		}
+		var request domain.OptimizeRequest
+		if err := decoder.Decode(&request); err != nil {
+			return fmt.Errorf("requests[%d]: %w", len(batch.Requests), err)
+		}
+		batch.Requests = append(batch.Requests, request)
+	}
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token and insists it is delim
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %s, found %v", delim, token)
+	}
+	return nil
+}
+
+// skipValue reads past the next value a token at a time, so an unknown
+// field is never held whole
+func skipValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
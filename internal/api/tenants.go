@@ -0,0 +1,268 @@
+package api
+
+import (
+	"net/url"
+	"smart-load/internal/auth"
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+func setupTenantRoutes(v1 fiber.Router, optimizerService *service.OptimizerService) {
+	tenants := v1.Group("/tenants/:tenantId", requireScope(auth.ScopeAdminConfig), requireTenantParam())
+	tenants.Get("/preferred-lanes", GetPreferredLanesHandler(optimizerService))
+	tenants.Put("/preferred-lanes", PutPreferredLanesHandler(optimizerService))
+	tenants.Get("/blocked-shippers", GetBlockedShippersHandler(optimizerService))
+	tenants.Post("/blocked-shippers", AddBlockedShipperHandler(optimizerService))
+	tenants.Delete("/blocked-shippers/:shipper", DeleteBlockedShipperHandler(optimizerService))
+	tenants.Get("/preferred-shippers", GetPreferredShippersHandler(optimizerService))
+	tenants.Post("/preferred-shippers", AddPreferredShipperHandler(optimizerService))
+	tenants.Delete("/preferred-shippers/:shipper", DeletePreferredShipperHandler(optimizerService))
+	tenants.Get("/validation-profile", GetValidationProfileHandler(optimizerService))
+	tenants.Put("/validation-profile", PutValidationProfileHandler(optimizerService))
+	tenants.Delete("/validation-profile", DeleteValidationProfileHandler(optimizerService))
+	tenants.Get("/retention", GetRetentionHandler(optimizerService))
+	tenants.Put("/retention", PutRetentionHandler(optimizerService))
+	tenants.Delete("/retention", DeleteRetentionHandler(optimizerService))
+	tenants.Get("/disabled-rules", GetDisabledRulesHandler(optimizerService))
+	tenants.Put("/disabled-rules", PutDisabledRulesHandler(optimizerService))
+	tenants.Post("/purge", PurgeHandler(optimizerService))
+}
+
+// tenantParam copies the tenant id out of the request buffer so it can be stored
+func tenantParam(c *fiber.Ctx) string {
+	return utils.CopyString(c.Params("tenantId"))
+}
+
+func GetPreferredLanesHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":       c.Params("tenantId"),
+			"preferred_lanes": optimizerService.PreferredLanes(c.Params("tenantId")),
+		})
+	}
+}
+
+func PutPreferredLanesHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			PreferredLanes []domain.PreferredLane `json:"preferred_lanes"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.SetPreferredLanes(tenantID, body.PreferredLanes); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":       tenantID,
+			"preferred_lanes": optimizerService.PreferredLanes(tenantID),
+		})
+	}
+}
+
+func GetBlockedShippersHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":        c.Params("tenantId"),
+			"blocked_shippers": optimizerService.BlockedShippers(c.Params("tenantId")),
+		})
+	}
+}
+
+func AddBlockedShipperHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			Shipper string `json:"shipper"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.BlockShipper(tenantID, body.Shipper); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"tenant_id":        tenantID,
+			"blocked_shippers": optimizerService.BlockedShippers(tenantID),
+		})
+	}
+}
+
+func DeleteBlockedShipperHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !optimizerService.UnblockShipper(tenantParam(c), shipperParam(c)) {
+			return respondError(c, fiber.StatusNotFound, "shipper is not blocked")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func GetPreferredShippersHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":          c.Params("tenantId"),
+			"preferred_shippers": optimizerService.PreferredShippers(c.Params("tenantId")),
+		})
+	}
+}
+
+func AddPreferredShipperHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var preferred domain.PreferredShipper
+		if err := parseBody(c, &preferred); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.SetPreferredShipper(tenantID, preferred); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"tenant_id":          tenantID,
+			"preferred_shippers": optimizerService.PreferredShippers(tenantID),
+		})
+	}
+}
+
+func DeletePreferredShipperHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !optimizerService.RemovePreferredShipper(tenantParam(c), shipperParam(c)) {
+			return respondError(c, fiber.StatusNotFound, "shipper is not preferred")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func GetValidationProfileHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":          c.Params("tenantId"),
+			"validation_profile": optimizerService.ValidationProfile(c.Params("tenantId")),
+		})
+	}
+}
+
+func PutValidationProfileHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var profile domain.ValidationProfile
+		if err := parseBody(c, &profile); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.SetValidationProfile(tenantID, profile); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":          tenantID,
+			"validation_profile": optimizerService.ValidationProfile(tenantID),
+		})
+	}
+}
+
+func DeleteValidationProfileHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !optimizerService.ResetValidationProfile(tenantParam(c)) {
+			return respondError(c, fiber.StatusNotFound, "no validation profile is set")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func GetRetentionHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id": c.Params("tenantId"),
+			"retention": optimizerService.RetentionPolicy(c.Params("tenantId")),
+		})
+	}
+}
+
+func PutRetentionHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var policy domain.RetentionPolicy
+		if err := parseBody(c, &policy); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.SetRetentionPolicy(tenantID, policy); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id": tenantID,
+			"retention": optimizerService.RetentionPolicy(tenantID),
+		})
+	}
+}
+
+func DeleteRetentionHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !optimizerService.ResetRetentionPolicy(tenantParam(c)) {
+			return respondError(c, fiber.StatusNotFound, "no retention policy is set")
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+func GetDisabledRulesHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":      c.Params("tenantId"),
+			"disabled_rules": optimizerService.DisabledRules(c.Params("tenantId")),
+			"toggleable":     domain.TenantToggleableRules,
+		})
+	}
+}
+
+// PutDisabledRulesHandler replaces the built-in rules the tenant switches off;
+// send an empty list to turn them all back on
+func PutDisabledRulesHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var body struct {
+			DisabledRules []string `json:"disabled_rules"`
+		}
+		if err := parseBody(c, &body); err != nil {
+			return respondParseError(c, err)
+		}
+		
+		tenantID := tenantParam(c)
+		if err := optimizerService.SetDisabledRules(tenantID, body.DisabledRules); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"tenant_id":      tenantID,
+			"disabled_rules": optimizerService.DisabledRules(tenantID),
+			"toggleable":     domain.TenantToggleableRules,
+		})
+	}
+}
+
+// PurgeHandler deletes the tenant's expired data now, rather than waiting for
+// the purge job, and reports what was deleted
+func PurgeHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(optimizerService.PurgeTenant(tenantParam(c), optimizerService.Now()))
+	}
+}
+
+// shipperParam decodes the shipper path segment, which is usually URL-escaped
+func shipperParam(c *fiber.Ctx) string {
+	shipper, err := url.PathUnescape(c.Params("shipper"))
+	if err != nil {
+		return utils.CopyString(c.Params("shipper"))
+	}
+	return utils.CopyString(shipper)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"fmt"
+	"smart-load/internal/auth"
+	"smart-load/internal/history"
+	"smart-load/internal/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UsageHandler reports solves, orders processed and compute time per tenant
+// and API key. Callers without the admin-config scope only see their own key.
+func UsageHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		now := optimizerService.Now().UTC()
+		from, to, err := usageWindow(c, now)
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		
+		apiKey := c.Query("api_key")
+		if principal, ok := c.Locals(principalKey).(auth.Principal); ok && !principal.HasScope(auth.ScopeAdminConfig) {
+			if apiKey != "" && apiKey != principal.Name {
+				return respondError(c, fiber.StatusForbidden, "usage of other API keys requires the admin-config scope")
+			}
+			apiKey = principal.Name
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"from":  from,
+			"to":    to,
+			"usage": optimizerService.Usage(c.Get("X-Tenant-ID"), apiKey, from, to),
+		})
+	}
+}
+
+// DuplicatesHandler reports how often each tenant re-solves a problem it has
+// already solved, to judge whether clients would benefit from caching
+func DuplicatesHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		from, to, err := usageWindow(c, optimizerService.Now().UTC())
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		tenantID, status, message := historyTenant(c)
+		if status != 0 {
+			return respondError(c, status, message)
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"from":    from,
+			"to":      to,
+			"tenants": optimizerService.Duplicates(tenantID, from, to),
+		})
+	}
+}
+
+// AccrualsHandler reports planned revenue by the week or month it is
+// recognized in, for finance's accrual reporting. period is week or month
+// (default month) and basis delivery or pickup (default delivery); the
+// window selects solves by when they were made.
+func AccrualsHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		from, to, err := usageWindow(c, optimizerService.Now().UTC())
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		period, basis := c.Query("period", history.PeriodMonth), c.Query("basis", history.BasisDelivery)
+		if err := history.ValidateAccrualOptions(period, basis); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		tenantID, status, message := historyTenant(c)
+		if status != 0 {
+			return respondError(c, status, message)
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"from":     from,
+			"to":       to,
+			"period":   period,
+			"basis":    basis,
+			"accruals": optimizerService.Accruals(tenantID, from, to, period, basis),
+		})
+	}
+}
+
+// UtilizationHandler reports, for fleet managers, payout, average weight and
+// volume utilization and algorithm usage per day, week or month (default
+// day); the window selects solves by when they were made
+func UtilizationHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		from, to, err := usageWindow(c, optimizerService.Now().UTC())
+		if err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		period := c.Query("period", history.PeriodDay)
+		if err := history.ValidateUtilizationPeriod(period); err != nil {
+			return respondError(c, fiber.StatusBadRequest, err.Error())
+		}
+		tenantID, status, message := historyTenant(c)
+		if status != 0 {
+			return respondError(c, status, message)
+		}
+		
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"from":        from,
+			"to":          to,
+			"period":      period,
+			"utilization": optimizerService.Utilization(tenantID, from, to, period),
+		})
+	}
+}
+
+// usageWindow reads either window (a duration such as 1h, 24h or 7d, ending
+// now) or from/to, defaulting to the last 24 hours
+func usageWindow(c *fiber.Ctx, now time.Time) (time.Time, time.Time, error) {
+	if window := c.Query("window"); window != "" {
+		if c.Query("from") != "" || c.Query("to") != "" {
+			return time.Time{}, time.Time{}, fmt.Errorf("window cannot be combined with from or to")
+		}
+		length, err := parseWindow(window)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return now.Add(-length), now, nil
+	}
+	
+	from, err := parseTimeParam(c.Query("from"), now.Add(-24*time.Hour))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid from: %w", err)
+	}
+	to, err := parseTimeParam(c.Query("to"), now.Add(time.Second))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid to: %w", err)
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("from must be before to")
+	}
+	return from, to, nil
+}
+
+// parseWindow accepts Go durations plus a whole number of days ("7d")
+func parseWindow(window string) (time.Duration, error) {
+	var length time.Duration
+	if days, ok := strings.CutSuffix(window, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window: %s", window)
+		}
+		length = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(window)
+		if err != nil {
+			return 0, fmt.Errorf("invalid window: %s", window)
+		}
+		length = parsed
+	}
+	if length <= 0 {
+		return 0, fmt.Errorf("window must be positive")
+	}
+	return length, nil
+}
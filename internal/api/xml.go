@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/xml"
+	"smart-load/internal/adapters/tmsxml"
+	"smart-load/internal/service"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// OptimizeXMLHandler accepts legacy TMS XML tenders. The result is XML unless
+// the client asks for JSON through the Accept header.
+func OptimizeXMLHandler(optimizerService *service.OptimizerService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		wantsJSON := c.Accepts(fiber.MIMEApplicationXML, fiber.MIMEApplicationJSON) == fiber.MIMEApplicationJSON
+		// NDJSON bodies pass the size limit unread; only the optimize
+		// endpoints stream them
+		if bodyFormat(c) == MIMEApplicationNDJSON {
+			return respondXMLError(c, wantsJSON, fiber.StatusUnsupportedMediaType, "XML tenders cannot be sent as NDJSON")
+		}
+		
+		tender, err := tmsxml.Parse(c.Body())
+		if err != nil {
+			return respondXMLError(c, wantsJSON, fiber.StatusBadRequest, err.Error())
+		}
+		
+		request := tender.ToRequest()
+		request.TenantID = utils.CopyString(c.Get("X-Tenant-ID"))
+		request.APIKey = principalName(c)
+		
+		response, err := optimizerService.OptimizeLoad(c.UserContext(), request)
+		if err != nil {
+			statusCode := fiber.StatusInternalServerError
+			if strings.Contains(err.Error(), "validation") {
+				statusCode = fiber.StatusBadRequest
+			} else if isAborted(err) {
+				statusCode = fiber.StatusServiceUnavailable
+			}
+			return respondXMLError(c, wantsJSON, statusCode, err.Error())
+		}
+		
+		if wantsJSON {
+			return c.Status(fiber.StatusOK).JSON(response)
+		}
+		return respondXML(c, fiber.StatusOK, tmsxml.FromResponse(response))
+	}
+}
+
+func respondXML(c *fiber.Ctx, code int, body interface{}) error {
+	data, err := xml.Marshal(body)
+	if err != nil {
+		return err
+	}
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	return c.Status(code).Send(append([]byte(xml.Header), data...))
+}
+
+func respondXMLError(c *fiber.Ctx, wantsJSON bool, code int, message string) error {
+	if wantsJSON {
+		return respondError(c, code, message)
+	}
+	return respondXML(c, code, tmsxml.Error{Code: code, Message: localize(c, message)})
+}
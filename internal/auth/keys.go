@@ -0,0 +1,136 @@
+// Package auth authenticates API keys and the scopes granted to them.
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Scope is a permission granted to an API key
+type Scope string
+
+const (
+	// ScopeSolve allows running optimizations
+	ScopeSolve Scope = "solve"
+	// ScopeReadHistory allows reading and exporting solve history
+	ScopeReadHistory Scope = "read-history"
+	// ScopeAdminConfig allows reading and changing tenant configuration
+	ScopeAdminConfig Scope = "admin-config"
+	// ScopeCommit is reserved for committing a solution for dispatch; no
+	// route checks it yet
+	ScopeCommit Scope = "commit"
+)
+
+// AllTenants in a key's tenant list grants access to every tenant
+const AllTenants = "*"
+
+var knownScopes = map[Scope]bool{
+	ScopeSolve:       true,
+	ScopeReadHistory: true,
+	ScopeAdminConfig: true,
+	ScopeCommit:      true,
+}
+
+// Principal is the caller an API key identifies, with the tenants it may act for
+type Principal struct {
+	Name    string
+	Scopes  []Scope
+	Tenants []string
+}
+
+func (p Principal) HasScope(scope Scope) bool {
+	for _, granted := range p.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessTenant reports whether the principal may act for tenantID. Requests
+// without a tenant use no tenant settings and are open to every key.
+func (p Principal) CanAccessTenant(tenantID string) bool {
+	if tenantID == "" {
+		return true
+	}
+	for _, tenant := range p.Tenants {
+		if tenant == AllTenants || tenant == tenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllTenants reports whether the principal may act for every tenant at once
+func (p Principal) HasAllTenants() bool {
+	for _, tenant := range p.Tenants {
+		if tenant == AllTenants {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyConfig is one entry of the API key file
+type KeyConfig struct {
+	Name    string   `json:"name"`
+	Key     string   `json:"key"`
+	Scopes  []Scope  `json:"scopes"`
+	Tenants []string `json:"tenants"`
+}
+
+// KeyStore looks up API keys. Only SHA-256 digests of the keys are kept in memory.
+type KeyStore struct {
+	principals map[[sha256.Size]byte]Principal
+}
+
+func NewKeyStore(keys []KeyConfig) (*KeyStore, error) {
+	store := &KeyStore{principals: make(map[[sha256.Size]byte]Principal, len(keys))}
+	for i, key := range keys {
+		if key.Name == "" {
+			return nil, fmt.Errorf("api key %d: name is required", i)
+		}
+		if len(key.Key) < 16 {
+			return nil, fmt.Errorf("api key %s: key must be at least 16 characters", key.Name)
+		}
+		for _, scope := range key.Scopes {
+			if !knownScopes[scope] {
+				return nil, fmt.Errorf("api key %s: unknown scope %q", key.Name, scope)
+			}
+		}
+		
+		digest := sha256.Sum256([]byte(key.Key))
+		if _, exists := store.principals[digest]; exists {
+			return nil, fmt.Errorf("api key %s: key is already assigned", key.Name)
+		}
+		for _, tenant := range key.Tenants {
+			if tenant == "" {
+				return nil, fmt.Errorf("api key %s: tenant cannot be empty", key.Name)
+			}
+		}
+		store.principals[digest] = Principal{Name: key.Name, Scopes: key.Scopes, Tenants: key.Tenants}
+	}
+	return store, nil
+}
+
+// LoadKeyStore reads a JSON array of KeyConfig entries
+func LoadKeyStore(path string) (*KeyStore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read api keys: %w", err)
+	}
+	
+	var keys []KeyConfig
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parse api keys: %w", err)
+	}
+	return NewKeyStore(keys)
+}
+
+// Lookup returns the principal for a presented key
+func (s *KeyStore) Lookup(key string) (Principal, bool) {
+	principal, ok := s.principals[sha256.Sum256([]byte(key))]
+	return principal, ok
+}
@@ -0,0 +1,56 @@
+package auth
+
+import "testing"
+
+func TestKeyStoreLookup(t *testing.T) {
+	store, err := NewKeyStore([]KeyConfig{
+		{Name: "dispatch", Key: "dispatch-6f1c0e4b9a2d", Scopes: []Scope{ScopeSolve}, Tenants: []string{"acme"}},
+		{Name: "ops", Key: "ops-71ad5c2e9f3b4406", Scopes: []Scope{ScopeAdminConfig}, Tenants: []string{AllTenants}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	dispatch, ok := store.Lookup("dispatch-6f1c0e4b9a2d")
+	if !ok || dispatch.Name != "dispatch" {
+		t.Fatalf("lookup dispatch = %+v, %v", dispatch, ok)
+	}
+	if !dispatch.HasScope(ScopeSolve) || dispatch.HasScope(ScopeAdminConfig) {
+		t.Errorf("dispatch scopes = %v", dispatch.Scopes)
+	}
+	if !dispatch.CanAccessTenant("acme") || dispatch.CanAccessTenant("globex") || dispatch.HasAllTenants() {
+		t.Errorf("dispatch tenants = %v", dispatch.Tenants)
+	}
+	if !dispatch.CanAccessTenant("") {
+		t.Errorf("requests without a tenant should be open to every key")
+	}
+	
+	ops, _ := store.Lookup("ops-71ad5c2e9f3b4406")
+	if !ops.CanAccessTenant("globex") || !ops.HasAllTenants() {
+		t.Errorf("ops tenants = %v", ops.Tenants)
+	}
+	
+	if _, ok := store.Lookup("unknown-key-0000000"); ok {
+		t.Errorf("unknown key was accepted")
+	}
+}
+
+func TestNewKeyStoreRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []KeyConfig
+	}{
+		{"missing name", []KeyConfig{{Key: "0123456789abcdef"}}},
+		{"short key", []KeyConfig{{Name: "a", Key: "short"}}},
+		{"unknown scope", []KeyConfig{{Name: "a", Key: "0123456789abcdef", Scopes: []Scope{"root"}}}},
+		{"empty tenant", []KeyConfig{{Name: "a", Key: "0123456789abcdef", Tenants: []string{""}}}},
+		{"duplicate key", []KeyConfig{{Name: "a", Key: "0123456789abcdef"}, {Name: "b", Key: "0123456789abcdef"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewKeyStore(tt.keys); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+// Package cache keeps the responses of optimize requests, so a request sent
+// again while its response is cached is answered without solving it again
+package cache
+
+import (
+	"context"
+	"smart-load/internal/domain"
+)
+
+// Store keeps responses by request key for a bounded time. Stores are caches:
+// they may drop responses at any time, and failures read as misses.
+type Store interface {
+	// Get returns the response cached under key
+	Get(ctx context.Context, key string) (*domain.OptimizeResponse, bool)
+	// Set caches a response under key
+	Set(ctx context.Context, key string, response *domain.OptimizeResponse)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"log"
+	"smart-load/internal/domain"
+	"sync"
+	"time"
+)
+
+// LRU caches responses in process memory, for deployments without Redis. It
+// holds at most size responses, dropping the least recently used first, and
+// serves each for the TTL. Responses are kept encoded, so callers can change
+// the ones they are handed.
+type LRU struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+	
+	mu      sync.Mutex
+	order   *list.List // of *lruEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	data    []byte
+	expires time.Time
+}
+
+func NewLRU(size int, ttl time.Duration) *LRU {
+	return &LRU{size: size, ttl: ttl, now: time.Now, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+func (l *LRU) Get(_ context.Context, key string) (*domain.OptimizeResponse, bool) {
+	l.mu.Lock()
+	element, ok := l.entries[key]
+	if ok && !l.now().Before(element.Value.(*lruEntry).expires) {
+		l.remove(element)
+		ok = false
+	}
+	var data []byte
+	if ok {
+		l.order.MoveToFront(element)
+		data = element.Value.(*lruEntry).data
+	}
+	l.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+	
+	var response domain.OptimizeResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		log.Printf("  Result cache entry unreadable: %v", err)
+		return nil, false
+	}
+	return &response, true
+}
+
+func (l *LRU) Set(_ context.Context, key string, response *domain.OptimizeResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("  Failed to encode result for the cache: %v", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	entry := &lruEntry{key: key, data: data, expires: l.now().Add(l.ttl)}
+	if element, ok := l.entries[key]; ok {
+		element.Value = entry
+		l.order.MoveToFront(element)
+		return
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	for l.order.Len() > l.size {
+		l.remove(l.order.Back())
+	}
+}
+
+// Len is the number of responses held, including expired ones not yet dropped
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,66 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(2, time.Minute)
+	lru.Set(ctx, "a", &domain.OptimizeResponse{SolutionID: "a"})
+	lru.Set(ctx, "b", &domain.OptimizeResponse{SolutionID: "b"})
+	if _, ok := lru.Get(ctx, "a"); !ok {
+		t.Fatal("a missing")
+	}
+	lru.Set(ctx, "c", &domain.OptimizeResponse{SolutionID: "c"})
+	
+	if _, ok := lru.Get(ctx, "b"); ok {
+		t.Error("b, the least recently used, was kept")
+	}
+	for _, key := range []string{"a", "c"} {
+		if response, ok := lru.Get(ctx, key); !ok || response.SolutionID != key {
+			t.Errorf("Get(%s) = %+v, %v", key, response, ok)
+		}
+	}
+	if lru.Len() != 2 {
+		t.Errorf("Len = %d, want 2", lru.Len())
+	}
+}
+
+func TestLRUExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	lru := NewLRU(10, time.Minute)
+	lru.now = func() time.Time { return now }
+	lru.Set(ctx, "a", &domain.OptimizeResponse{SolutionID: "a"})
+	
+	now = now.Add(59 * time.Second)
+	if _, ok := lru.Get(ctx, "a"); !ok {
+		t.Fatal("response expired before its TTL")
+	}
+	now = now.Add(time.Second)
+	if _, ok := lru.Get(ctx, "a"); ok {
+		t.Error("response served after its TTL")
+	}
+	if lru.Len() != 0 {
+		t.Errorf("expired response kept, Len = %d", lru.Len())
+	}
+}
+
+func TestLRUCopiesResponses(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(10, time.Minute)
+	stored := &domain.OptimizeResponse{SelectedOrderIDs: []string{"ord-1"}}
+	lru.Set(ctx, "a", stored)
+	stored.SelectedOrderIDs[0] = "changed"
+	
+	response, _ := lru.Get(ctx, "a")
+	response.SelectedOrderIDs[0] = "changed again"
+	if again, _ := lru.Get(ctx, "a"); again.SelectedOrderIDs[0] != "ord-1" {
+		t.Errorf("cached response changed to %v", again.SelectedOrderIDs)
+	}
+}
@@ -0,0 +1,63 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"smart-load/internal/domain"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces the cached responses in a shared Redis
+const redisKeyPrefix = "smartload:result:"
+
+// RedisStore caches responses in Redis as JSON, so every instance sharing the
+// Redis answers a request any of them has solved. Responses expire after the
+// store's TTL.
+type RedisStore struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+	// timeout bounds each Redis call, so a slow Redis costs a solve rather
+	// than stalling the request
+	timeout time.Duration
+}
+
+func NewRedisStore(client redis.UniversalClient, ttl time.Duration) *RedisStore {
+	return &RedisStore{client: client, ttl: ttl, timeout: 250 * time.Millisecond}
+}
+
+func (r *RedisStore) Get(ctx context.Context, key string) (*domain.OptimizeResponse, bool) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	
+	data, err := r.client.Get(ctx, redisKeyPrefix+key).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("  Result cache read failed: %v", err)
+		}
+		return nil, false
+	}
+	var response domain.OptimizeResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		log.Printf("  Result cache entry unreadable: %v", err)
+		return nil, false
+	}
+	return &response, true
+}
+
+func (r *RedisStore) Set(ctx context.Context, key string, response *domain.OptimizeResponse) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("  Failed to encode result for the cache: %v", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	
+	if err := r.client.Set(ctx, redisKeyPrefix+key, data, r.ttl).Err(); err != nil {
+		log.Printf("  Result cache write failed: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), time.Minute)
+	ctx := context.Background()
+	
+	if _, ok := store.Get(ctx, "k"); ok {
+		t.Fatal("empty store returned a response")
+	}
+	store.Set(ctx, "k", &domain.OptimizeResponse{SolutionID: "01J", SelectedOrderIDs: []string{"ord-1"}})
+	response, ok := store.Get(ctx, "k")
+	if !ok || response.SolutionID != "01J" || len(response.SelectedOrderIDs) != 1 {
+		t.Fatalf("Get = %+v, %v; want the stored response", response, ok)
+	}
+	if ttl := server.TTL(redisKeyPrefix + "k"); ttl != time.Minute {
+		t.Errorf("entry TTL = %v, want 1m", ttl)
+	}
+	
+	server.FastForward(time.Minute)
+	if _, ok := store.Get(ctx, "k"); ok {
+		t.Error("expired response returned")
+	}
+}
+
+func TestRedisStoreUnavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := NewRedisStore(redis.NewClient(&redis.Options{Addr: server.Addr()}), time.Minute)
+	server.Close()
+	
+	store.Set(context.Background(), "k", &domain.OptimizeResponse{SolutionID: "01J"})
+	if _, ok := store.Get(context.Background(), "k"); ok {
+		t.Error("unreachable Redis returned a response")
+	}
+}
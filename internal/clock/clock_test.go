@@ -0,0 +1,34 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+type fixed time.Time
+
+func (f fixed) Now() time.Time { return time.Time(f) }
+
+func TestAdjustable(t *testing.T) {
+	base := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := NewAdjustable(fixed(base))
+	if !clock.Now().Equal(base) {
+		t.Fatalf("Now = %s, want the base clock's %s", clock.Now(), base)
+	}
+	
+	clock.Offset(48 * time.Hour)
+	if want := base.Add(48 * time.Hour); !clock.Now().Equal(want) {
+		t.Errorf("offset Now = %s, want %s", clock.Now(), want)
+	}
+	
+	frozen := time.Date(2031, 6, 1, 0, 0, 0, 0, time.UTC)
+	clock.Freeze(frozen)
+	if state := clock.State(); !state.Now.Equal(frozen) || state.FrozenAt == nil || state.Offset != "0s" {
+		t.Errorf("frozen state %+v", state)
+	}
+	
+	clock.Reset()
+	if state := clock.State(); !state.Now.Equal(base) || state.FrozenAt != nil {
+		t.Errorf("reset state %+v", state)
+	}
+}
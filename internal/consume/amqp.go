@@ -0,0 +1,115 @@
+package consume
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQPQueue consumes requests from an AMQP queue, such as a RabbitMQ one,
+// and publishes each result to the queue the request names in reply_to, or
+// else to the results queue, with the request's correlation id, or message
+// id when it has none. Results are published with confirms, and
+// acknowledging a request acks its delivery.
+type AMQPQueue struct {
+	conn       *amqp.Connection
+	channel    *amqp.Channel
+	deliveries <-chan amqp.Delivery
+	results    string
+}
+
+// DialAMQPQueue connects to url and consumes the requests queue. Both queues
+// are declared durable, so either side may start first.
+func DialAMQPQueue(url, requests, results string) (*AMQPQueue, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+	q := &AMQPQueue{conn: conn, results: results}
+	if err := q.open(requests); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return q, nil
+}
+
+func (q *AMQPQueue) open(requests string) error {
+	var err error
+	if q.channel, err = q.conn.Channel(); err != nil {
+		return err
+	}
+	for _, name := range []string{requests, q.results} {
+		if _, err := q.channel.QueueDeclare(name, true, false, false, false, nil); err != nil {
+			return err
+		}
+	}
+	// One request at a time, as the consumer solves them
+	if err := q.channel.Qos(1, 0, false); err != nil {
+		return err
+	}
+	if err := q.channel.Confirm(false); err != nil {
+		return err
+	}
+	q.deliveries, err = q.channel.Consume(requests, "", false, false, false, false, nil)
+	return err
+}
+
+func (q *AMQPQueue) Receive(ctx context.Context) (Delivery, error) {
+	select {
+	case <-ctx.Done():
+		return Delivery{}, ctx.Err()
+	case delivery, ok := <-q.deliveries:
+		if !ok {
+			return Delivery{}, errors.New("AMQP channel closed")
+		}
+		return amqpDelivery(delivery), nil
+	}
+}
+
+// amqpDelivery maps an AMQP delivery to the request it carries
+func amqpDelivery(delivery amqp.Delivery) Delivery {
+	key := delivery.CorrelationId
+	if key == "" {
+		key = delivery.MessageId
+	}
+	request := Delivery{Key: []byte(key), Body: delivery.Body, source: delivery}
+	if tenant, ok := delivery.Headers[TenantHeader].(string); ok {
+		request.TenantID = tenant
+	}
+	return request
+}
+
+func (q *AMQPQueue) Reply(ctx context.Context, request Delivery, result []byte) error {
+	queue := request.source.(amqp.Delivery).ReplyTo
+	if queue == "" {
+		queue = q.results
+	}
+	confirmation, err := q.channel.PublishWithDeferredConfirmWithContext(ctx, "", queue, false, false, amqp.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  amqp.Persistent,
+		CorrelationId: string(request.Key),
+		Body:          result,
+	})
+	if err != nil {
+		return err
+	}
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return fmt.Errorf("broker refused the result for %s", queue)
+	}
+	return nil
+}
+
+func (q *AMQPQueue) Ack(ctx context.Context, request Delivery) error {
+	return request.source.(amqp.Delivery).Ack(false)
+}
+
+// Close closes the channel and the connection
+func (q *AMQPQueue) Close() error {
+	return errors.Join(q.channel.Close(), q.conn.Close())
+}
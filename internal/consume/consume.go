@@ -0,0 +1,163 @@
+// Package consume solves optimize requests read from message queues and
+// publishes a result for each, so event-driven pipelines can use the
+// optimizer without an HTTP hop. A Consumer drives any Queue; Kafka, SQS
+// and AMQP queues are provided.
+package consume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"smart-load/internal/domain"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// TenantHeader is the message header, or attribute, naming the tenant a
+// request is solved for, as X-Tenant-ID does over HTTP
+const TenantHeader = "X-Tenant-ID"
+
+// Solver solves one optimize request; *service.OptimizerService is one
+type Solver interface {
+	OptimizeLoad(ctx context.Context, request domain.OptimizeRequest) (*domain.OptimizeResponse, error)
+}
+
+// Result is published for every request consumed: the response, or the
+// error the API would have answered with
+type Result struct {
+	Response *domain.OptimizeResponse `json:"response,omitempty"`
+	Error    *domain.ErrorDetail      `json:"error,omitempty"`
+}
+
+// Solve decodes a request message, a JSON optimize request, and solves it
+// for tenantID. Errors use the API's status codes: 400 for bodies that do not
+// decode or validate, 503 for solves that ran out of time, 500 otherwise.
+func Solve(ctx context.Context, solver Solver, body []byte, tenantID string) Result {
+	var request domain.OptimizeRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return failed(fiber.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+	}
+	request.TenantID = tenantID
+	
+	response, err := solver.OptimizeLoad(ctx, request)
+	switch {
+	case err == nil:
+		return Result{Response: response}
+	case strings.Contains(err.Error(), "validation"):
+		return failed(fiber.StatusBadRequest, err)
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return failed(fiber.StatusServiceUnavailable, err)
+	}
+	return failed(fiber.StatusInternalServerError, err)
+}
+
+func failed(code int, err error) Result {
+	return Result{Error: &domain.ErrorDetail{Code: code, Message: err.Error()}}
+}
+
+// Delivery is a request message taken from a Queue
+type Delivery struct {
+	// Key identifies the request to whoever sent it; its result carries
+	// the key back
+	Key      []byte
+	Body     []byte
+	TenantID string
+	// source is the queue's own form of the message, for Reply and Ack
+	source interface{}
+}
+
+// Queue is where a Consumer takes requests from and sends their results
+type Queue interface {
+	// Receive waits for the next request
+	Receive(ctx context.Context) (Delivery, error)
+	// Reply sends the result of a request
+	Reply(ctx context.Context, request Delivery, result []byte) error
+	// Ack marks a request handled, so it is not delivered again
+	Ack(ctx context.Context, request Delivery) error
+	Close() error
+}
+
+// Consumer solves the requests of a Queue one at a time
+type Consumer struct {
+	queue  Queue
+	solver Solver
+	// retryDelay is the first wait before sending a result again; it doubles
+	// up to maxRetryDelay
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+}
+
+func NewConsumer(queue Queue, solver Solver) *Consumer {
+	return &Consumer{
+		queue:         queue,
+		solver:        solver,
+		retryDelay:    time.Second,
+		maxRetryDelay: 30 * time.Second,
+	}
+}
+
+// Run consumes requests until ctx is done, then returns nil; other errors
+// come from the queue. A request is acknowledged only once its result is
+// sent, so every request is solved at least once: one in flight when the
+// consumer stops, or whose result could not be sent, is delivered again.
+func (c *Consumer) Run(ctx context.Context) error {
+	for {
+		request, err := c.queue.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		
+		result := Solve(ctx, c.solver, request.Body, request.TenantID)
+		if ctx.Err() != nil {
+			// Stopping cut the solve short; leave the request to be delivered again
+			return nil
+		}
+		value, err := json.Marshal(result)
+		if err != nil {
+			return err
+		}
+		if !c.reply(ctx, request, value) {
+			return nil
+		}
+		if err := c.queue.Ack(ctx, request); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// reply sends a result until the queue takes it, waiting longer after each
+// failure; false means ctx ended first
+func (c *Consumer) reply(ctx context.Context, request Delivery, result []byte) bool {
+	delay := c.retryDelay
+	for {
+		err := c.queue.Reply(ctx, request, result)
+		if err == nil {
+			return true
+		}
+		if ctx.Err() != nil {
+			return false
+		}
+		log.Printf("  Failed to send result, retrying in %s: %v", delay, err)
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, c.maxRetryDelay)
+	}
+}
+
+// Close closes the queue
+func (c *Consumer) Close() error {
+	return c.queue.Close()
+}
@@ -0,0 +1,150 @@
+package consume
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/service"
+)
+
+const request = `{
+	"truck": {"id": "truck-1", "max_weight_lbs": 10000, "max_volume_cuft": 1000},
+	"orders": [
+		{"id": "a", "payout_cents": 100000, "weight_lbs": 5000, "volume_cuft": 100, "origin": "Los Angeles, CA", "destination": "Dallas, TX", "pickup_date": "2030-01-01", "delivery_date": "2030-01-03"}
+	]
+}`
+
+// fakeQueue hands out its requests, then blocks until ctx is done. It fails
+// as many replies as failures.
+type fakeQueue struct {
+	mu       sync.Mutex
+	requests []Delivery
+	failures int
+	replies  map[string][]byte
+	acked    []string
+	// onAck runs after each ack
+	onAck func()
+}
+
+func (q *fakeQueue) Receive(ctx context.Context) (Delivery, error) {
+	q.mu.Lock()
+	if len(q.requests) == 0 {
+		q.mu.Unlock()
+		<-ctx.Done()
+		return Delivery{}, ctx.Err()
+	}
+	defer q.mu.Unlock()
+	request := q.requests[0]
+	q.requests = q.requests[1:]
+	return request, nil
+}
+
+func (q *fakeQueue) Reply(ctx context.Context, request Delivery, result []byte) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.failures > 0 {
+		q.failures--
+		return errors.New("broker unavailable")
+	}
+	if q.replies == nil {
+		q.replies = make(map[string][]byte)
+	}
+	q.replies[string(request.Key)] = result
+	return nil
+}
+
+func (q *fakeQueue) Ack(ctx context.Context, request Delivery) error {
+	q.mu.Lock()
+	q.acked = append(q.acked, string(request.Key))
+	q.mu.Unlock()
+	if q.onAck != nil {
+		q.onAck()
+	}
+	return nil
+}
+
+func (q *fakeQueue) Close() error { return nil }
+
+func TestConsumerSolves(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	queue := &fakeQueue{
+		requests: []Delivery{
+			{Key: []byte("req-1"), Body: []byte(request), TenantID: "acme"},
+			{Key: []byte("req-2"), Body: []byte(`{"truck": `)},
+		},
+		failures: 2,
+	}
+	queue.onAck = func() {
+		if len(queue.acked) == 2 {
+			cancel()
+		}
+	}
+	consumer := NewConsumer(queue, service.NewOptimizerService())
+	consumer.retryDelay = time.Millisecond
+	
+	if err := consumer.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(queue.acked) != 2 {
+		t.Fatalf("acked %v, want both requests", queue.acked)
+	}
+	
+	var solved, rejected Result
+	if err := json.Unmarshal(queue.replies["req-1"], &solved); err != nil {
+		t.Fatal(err)
+	}
+	if solved.Response == nil || len(solved.Response.SelectedOrderIDs) != 1 {
+		t.Errorf("req-1 result = %s, want it solved", queue.replies["req-1"])
+	}
+	if err := json.Unmarshal(queue.replies["req-2"], &rejected); err != nil {
+		t.Fatal(err)
+	}
+	if rejected.Error == nil || rejected.Error.Code != 400 {
+		t.Errorf("req-2 result = %s, want a 400 error", queue.replies["req-2"])
+	}
+}
+
+func TestConsumerLeavesUnsentResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	queue := &fakeQueue{
+		requests: []Delivery{{Key: []byte("req-1"), Body: []byte(request)}},
+		failures: 1000,
+	}
+	consumer := NewConsumer(queue, service.NewOptimizerService())
+	consumer.retryDelay = time.Millisecond
+	time.AfterFunc(50*time.Millisecond, cancel)
+	
+	if err := consumer.Run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(queue.acked) != 0 {
+		t.Errorf("acked %v, whose results were never sent", queue.acked)
+	}
+}
+
+func TestSolveTenant(t *testing.T) {
+	var got domain.OptimizeRequest
+	solver := solverFunc(func(ctx context.Context, request domain.OptimizeRequest) (*domain.OptimizeResponse, error) {
+		got = request
+		return nil, errors.New("validation failed: no orders")
+	})
+	result := Solve(context.Background(), solver, []byte(request), "acme")
+	if got.TenantID != "acme" {
+		t.Errorf("tenant = %q, want acme", got.TenantID)
+	}
+	if result.Error == nil || result.Error.Code != 400 {
+		t.Errorf("result = %+v, want a 400 error", result)
+	}
+}
+
+type solverFunc func(ctx context.Context, request domain.OptimizeRequest) (*domain.OptimizeResponse, error)
+
+func (f solverFunc) OptimizeLoad(ctx context.Context, request domain.OptimizeRequest) (*domain.OptimizeResponse, error) {
+	return f(ctx, request)
+}
@@ -0,0 +1,82 @@
+package consume
+
+import (
+	"context"
+	"errors"
+	"smart-load/internal/publish"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaConfig says where a KafkaQueue reads requests and writes results
+type KafkaConfig struct {
+	Brokers       []string
+	RequestsTopic string
+	ResultsTopic  string
+	// GroupID is the consumer group; instances sharing it split the
+	// requests topic's partitions between them
+	GroupID string
+	Acks    kafka.RequiredAcks
+}
+
+// messageReader is the part of a kafka.Reader a KafkaQueue uses
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+	Close() error
+}
+
+// KafkaQueue reads requests from a Kafka topic and writes each result to
+// another, keyed by the request's key. Acknowledging a request commits its
+// offset.
+type KafkaQueue struct {
+	reader messageReader
+	sink   publish.Sink
+}
+
+func NewKafkaQueue(config KafkaConfig) *KafkaQueue {
+	return &KafkaQueue{
+		reader: kafka.NewReader(kafka.ReaderConfig{
+			Brokers: config.Brokers,
+			GroupID: config.GroupID,
+			Topic:   config.RequestsTopic,
+		}),
+		sink: publish.NewKafkaSink(config.Brokers, config.ResultsTopic, config.Acks),
+	}
+}
+
+func (k *KafkaQueue) Receive(ctx context.Context) (Delivery, error) {
+	message, err := k.reader.FetchMessage(ctx)
+	if err != nil {
+		return Delivery{}, err
+	}
+	return Delivery{
+		Key:      message.Key,
+		Body:     message.Value,
+		TenantID: header(message, TenantHeader),
+		source:   message,
+	}, nil
+}
+
+func (k *KafkaQueue) Reply(ctx context.Context, request Delivery, result []byte) error {
+	return k.sink.Send(ctx, []publish.Message{{Key: request.Key, Value: result}})
+}
+
+func (k *KafkaQueue) Ack(ctx context.Context, request Delivery) error {
+	return k.reader.CommitMessages(ctx, request.source.(kafka.Message))
+}
+
+// Close closes the reader and the result writer
+func (k *KafkaQueue) Close() error {
+	return errors.Join(k.reader.Close(), k.sink.Close())
+}
+
+// header is the value of a message's header, empty when it has none
+func header(message kafka.Message, key string) string {
+	for _, h := range message.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
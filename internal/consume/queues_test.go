@@ -0,0 +1,148 @@
+package consume
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/publish"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeReader struct {
+	message   kafka.Message
+	committed []kafka.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	return r.message, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	r.committed = append(r.committed, messages...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+type fakeSink struct {
+	sent []publish.Message
+}
+
+func (s *fakeSink) Send(ctx context.Context, messages []publish.Message) error {
+	s.sent = append(s.sent, messages...)
+	return nil
+}
+
+func (s *fakeSink) Close() error { return nil }
+
+func TestKafkaQueue(t *testing.T) {
+	message := kafka.Message{
+		Key:     []byte("req-1"),
+		Value:   []byte(request),
+		Offset:  7,
+		Headers: []kafka.Header{{Key: TenantHeader, Value: []byte("acme")}},
+	}
+	reader, sink := &fakeReader{message: message}, &fakeSink{}
+	queue := &KafkaQueue{reader: reader, sink: sink}
+	ctx := context.Background()
+	
+	delivery, err := queue.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(delivery.Key) != "req-1" || delivery.TenantID != "acme" || string(delivery.Body) != request {
+		t.Errorf("delivery = %+v, want req-1 for acme", delivery)
+	}
+	if err := queue.Reply(ctx, delivery, []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.sent) != 1 || string(sink.sent[0].Key) != "req-1" {
+		t.Errorf("sent %+v, want the result keyed req-1", sink.sent)
+	}
+	if err := queue.Ack(ctx, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if len(reader.committed) != 1 || reader.committed[0].Offset != 7 {
+		t.Errorf("committed %+v, want offset 7", reader.committed)
+	}
+}
+
+// fakeSQS holds one message and records what is sent and deleted
+type fakeSQS struct {
+	polls   int
+	message types.Message
+	sent    []*sqs.SendMessageInput
+	deleted []string
+}
+
+func (f *fakeSQS) ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	f.polls++
+	if f.polls == 1 {
+		// The first long poll times out empty
+		return &sqs.ReceiveMessageOutput{}, nil
+	}
+	return &sqs.ReceiveMessageOutput{Messages: []types.Message{f.message}}, nil
+}
+
+func (f *fakeSQS) SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error) {
+	f.sent = append(f.sent, params)
+	return &sqs.SendMessageOutput{}, nil
+}
+
+func (f *fakeSQS) DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error) {
+	f.deleted = append(f.deleted, aws.ToString(params.ReceiptHandle))
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func TestSQSQueue(t *testing.T) {
+	client := &fakeSQS{message: types.Message{
+		MessageId:     aws.String("msg-1"),
+		ReceiptHandle: aws.String("receipt-1"),
+		Body:          aws.String(request),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			TenantHeader: {DataType: aws.String("String"), StringValue: aws.String("acme")},
+		},
+	}}
+	queue := &SQSQueue{client: client, requestsURL: "requests", resultsURL: "results"}
+	ctx := context.Background()
+	
+	delivery, err := queue.Receive(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(delivery.Key) != "msg-1" || delivery.TenantID != "acme" || client.polls != 2 {
+		t.Errorf("delivery = %+v after %d polls, want msg-1 for acme after 2", delivery, client.polls)
+	}
+	if err := queue.Reply(ctx, delivery, []byte("{}")); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.sent) != 1 || aws.ToString(client.sent[0].QueueUrl) != "results" ||
+		aws.ToString(client.sent[0].MessageAttributes[RequestIDAttribute].StringValue) != "msg-1" {
+		t.Errorf("sent %+v, want the result on results for msg-1", client.sent)
+	}
+	if err := queue.Ack(ctx, delivery); err != nil {
+		t.Fatal(err)
+	}
+	if len(client.deleted) != 1 || client.deleted[0] != "receipt-1" {
+		t.Errorf("deleted %v, want receipt-1", client.deleted)
+	}
+}
+
+func TestAMQPDelivery(t *testing.T) {
+	delivery := amqpDelivery(amqp.Delivery{
+		MessageId: "msg-1",
+		Headers:   amqp.Table{TenantHeader: "acme"},
+		Body:      []byte(request),
+	})
+	if string(delivery.Key) != "msg-1" || delivery.TenantID != "acme" {
+		t.Errorf("delivery = %+v, want msg-1 for acme", delivery)
+	}
+	if delivery := amqpDelivery(amqp.Delivery{MessageId: "msg-1", CorrelationId: "corr-1"}); string(delivery.Key) != "corr-1" {
+		t.Errorf("key = %s, want the correlation id", delivery.Key)
+	}
+}
@@ -0,0 +1,85 @@
+package consume
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/aws/aws-sdk-go-v2/service/sqs/types"
+)
+
+// RequestIDAttribute is the attribute an SQS result carries its request's
+// message id in
+const RequestIDAttribute = "Request-Id"
+
+// sqsClient is the part of an *sqs.Client an SQSQueue uses
+type sqsClient interface {
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	SendMessage(ctx context.Context, params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
+	DeleteMessage(ctx context.Context, params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+}
+
+// SQSQueue reads requests from one SQS queue and sends each result to
+// another, with the request's message id in its Request-Id attribute.
+// Acknowledging a request deletes it. A request whose result is not sent
+// within the requests queue's visibility timeout is delivered again.
+type SQSQueue struct {
+	client      sqsClient
+	requestsURL string
+	resultsURL  string
+}
+
+func NewSQSQueue(client *sqs.Client, requestsURL, resultsURL string) *SQSQueue {
+	return &SQSQueue{client: client, requestsURL: requestsURL, resultsURL: resultsURL}
+}
+
+// Receive long-polls the requests queue until a request arrives
+func (q *SQSQueue) Receive(ctx context.Context) (Delivery, error) {
+	for {
+		output, err := q.client.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:              aws.String(q.requestsURL),
+			MaxNumberOfMessages:   1,
+			WaitTimeSeconds:       20,
+			MessageAttributeNames: []string{TenantHeader},
+		})
+		if err != nil {
+			return Delivery{}, err
+		}
+		if len(output.Messages) == 0 {
+			continue
+		}
+		message := output.Messages[0]
+		request := Delivery{
+			Key:    []byte(aws.ToString(message.MessageId)),
+			Body:   []byte(aws.ToString(message.Body)),
+			source: message,
+		}
+		if tenant, ok := message.MessageAttributes[TenantHeader]; ok {
+			request.TenantID = aws.ToString(tenant.StringValue)
+		}
+		return request, nil
+	}
+}
+
+func (q *SQSQueue) Reply(ctx context.Context, request Delivery, result []byte) error {
+	_, err := q.client.SendMessage(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.resultsURL),
+		MessageBody: aws.String(string(result)),
+		MessageAttributes: map[string]types.MessageAttributeValue{
+			RequestIDAttribute: {DataType: aws.String("String"), StringValue: aws.String(string(request.Key))},
+		},
+	})
+	return err
+}
+
+func (q *SQSQueue) Ack(ctx context.Context, request Delivery) error {
+	_, err := q.client.DeleteMessage(ctx, &sqs.DeleteMessageInput{
+		QueueUrl:      aws.String(q.requestsURL),
+		ReceiptHandle: request.source.(types.Message).ReceiptHandle,
+	})
+	return err
+}
+
+func (q *SQSQueue) Close() error {
+	return nil
+}
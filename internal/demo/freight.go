@@ -0,0 +1,136 @@
+// Package demo generates the synthetic freight of the demo mode: a small
+// fleet, each truck with a pool of orders on its lane and a few that are not
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"smart-load/internal/domain"
+	"time"
+)
+
+// TenantID is the tenant the demo data belongs to
+const TenantID = "demo"
+
+// OrdersPerTruck is the size of each truck's order pool
+const OrdersPerTruck = 10
+
+type city struct {
+	name  string
+	point domain.LatLng
+}
+
+var (
+	losAngeles = city{"Los Angeles, CA", domain.LatLng{Lat: 34.0522, Lng: -118.2437}}
+	dallas     = city{"Dallas, TX", domain.LatLng{Lat: 32.7767, Lng: -96.7970}}
+	phoenix    = city{"Phoenix, AZ", domain.LatLng{Lat: 33.4484, Lng: -112.0740}}
+	denver     = city{"Denver, CO", domain.LatLng{Lat: 39.7392, Lng: -104.9903}}
+	chicago    = city{"Chicago, IL", domain.LatLng{Lat: 41.8781, Lng: -87.6298}}
+	atlanta    = city{"Atlanta, GA", domain.LatLng{Lat: 33.7490, Lng: -84.3880}}
+	houston    = city{"Houston, TX", domain.LatLng{Lat: 29.7604, Lng: -95.3698}}
+	memphis    = city{"Memphis, TN", domain.LatLng{Lat: 35.1495, Lng: -90.0490}}
+)
+
+// lane is a trip between two cities, by road miles
+type lane struct {
+	origin, destination city
+	miles               int
+}
+
+// fleetTruck is a demo truck, the lane its pool is built around and the
+// lane its off-route orders run
+type fleetTruck struct {
+	truck  domain.TruckInput
+	lane   lane
+	other  lane
+	reefer bool
+}
+
+var fleet = []fleetTruck{
+	{
+		truck: domain.TruckInput{ID: "demo-truck-1", MaxWeightLbs: 44000, MaxVolumeCuft: 3000, CostPerMileCents: 95},
+		lane:  lane{losAngeles, dallas, 1435},
+		other: lane{losAngeles, phoenix, 373},
+	},
+	{
+		truck:  domain.TruckInput{ID: "demo-truck-2", MaxWeightLbs: 42000, MaxVolumeCuft: 2800, CostPerMileCents: 110, EquipmentType: "reefer"},
+		lane:   lane{phoenix, denver, 821},
+		other:  lane{phoenix, losAngeles, 373},
+		reefer: true,
+	},
+	{
+		truck: domain.TruckInput{ID: "demo-truck-3", MaxWeightLbs: 44000, MaxVolumeCuft: 3000, CostPerMileCents: 95},
+		lane:  lane{chicago, atlanta, 716},
+		other: lane{chicago, memphis, 531},
+	},
+	{
+		truck: domain.TruckInput{ID: "demo-truck-4", MaxWeightLbs: 40000, MaxVolumeCuft: 2600, CostPerMileCents: 90},
+		lane:  lane{houston, memphis, 565},
+		other: lane{houston, dallas, 239},
+	},
+}
+
+var shippers = []string{
+	"Acme Foods", "Globex Manufacturing", "Initech Supply", "Stark Retail",
+	"Wayne Distribution", "Cyberdyne Parts", "Soylent Grocers", "Tyrell Home Goods",
+}
+
+// Requests returns the optimize request of every demo truck, with its order
+// pool. The freight is the same on every call, so a reset restores it; only
+// the dates move, to start the day after now.
+func Requests(now time.Time) []domain.OptimizeRequest {
+	requests := make([]domain.OptimizeRequest, len(fleet))
+	for i, t := range fleet {
+		// Seeded by truck, so each pool is fixed
+		random := rand.New(rand.NewSource(int64(i + 1)))
+		orders := make([]domain.OrderInput, OrdersPerTruck)
+		for j := range orders {
+			l := t.lane
+			// Every fifth order runs another lane, for the route filter to drop
+			if j%5 == 4 {
+				l = t.other
+			}
+			orders[j] = order(random, fmt.Sprintf("%s-ord-%02d", t.truck.ID, j+1), l, t.reefer, now)
+		}
+		requests[i] = domain.OptimizeRequest{Truck: t.truck, Orders: orders, TenantID: TenantID}
+	}
+	return requests
+}
+
+// order is a partial load on a lane, paid $2.50 to $3.50 a mile for the
+// share of a full 44,000 lb load it weighs, and never less than $350
+func order(random *rand.Rand, id string, l lane, reefer bool, now time.Time) domain.OrderInput {
+	weight := 2000 + random.Intn(19)*1000
+	volume := weight/15 + random.Intn(300)
+	centsPerMile := 250 + random.Intn(101)
+	payout := int64(l.miles*centsPerMile) * int64(weight) / 44000
+	payout = max(payout/100*100, 35000)
+	
+	pickup := now.AddDate(0, 0, 1+random.Intn(3))
+	transitDays := 1 + l.miles/500
+	input := domain.OrderInput{
+		ID:               id,
+		PayoutCents:      payout,
+		WeightLbs:        weight,
+		VolumeCuft:       volume,
+		Origin:           l.origin.name,
+		Destination:      l.destination.name,
+		OriginPoint:      &domain.LatLng{Lat: l.origin.point.Lat, Lng: l.origin.point.Lng},
+		DestinationPoint: &domain.LatLng{Lat: l.destination.point.Lat, Lng: l.destination.point.Lng},
+		PickupDate:       pickup.Format("2006-01-02"),
+		DeliveryDate:     pickup.AddDate(0, 0, transitDays+random.Intn(2)).Format("2006-01-02"),
+		Miles:            l.miles,
+		Shipper:          shippers[random.Intn(len(shippers))],
+	}
+	switch {
+	case reefer:
+		low, high := 34.0, 38.0
+		input.TemperatureMinF, input.TemperatureMaxF = &low, &high
+		input.CommodityType = "food"
+	case random.Intn(6) == 0:
+		input.IsHazmat = true
+		input.HazmatClass = "3"
+		input.Shipper = "Umbrella Chemicals"
+	}
+	return input
+}
@@ -0,0 +1,27 @@
+package demo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRequestsAreValidAndFixed(t *testing.T) {
+	now := time.Now()
+	requests := Requests(now)
+	if len(requests) != len(fleet) {
+		t.Fatalf("%d requests, want one per truck", len(requests))
+	}
+	for _, request := range requests {
+		request := request
+		if err := request.Validate(); err != nil {
+			t.Errorf("%s: %v", request.Truck.ID, err)
+		}
+		if len(request.Orders) != OrdersPerTruck || request.TenantID != TenantID {
+			t.Errorf("%s: %d orders for tenant %q", request.Truck.ID, len(request.Orders), request.TenantID)
+		}
+	}
+	if !reflect.DeepEqual(Requests(now), requests) {
+		t.Error("the demo freight changed between calls")
+	}
+}
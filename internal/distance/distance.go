@@ -0,0 +1,215 @@
+package distance
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"smart-load/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LaneMiles is a single entry of a static distance matrix
+type LaneMiles struct {
+	Origin      string  `json:"origin"`
+	Destination string  `json:"destination"`
+	Miles       float64 `json:"miles"`
+}
+
+// StaticMatrix measures lanes from a fixed table. Locations are looked up in
+// domain.NormalizeAddress form, and a lane listed one way is used for the
+// other way too when that is not listed. Unknown lanes are not measured.
+type StaticMatrix struct {
+	miles map[string]float64
+}
+
+func NewStaticMatrix(lanes []LaneMiles) *StaticMatrix {
+	matrix := &StaticMatrix{miles: make(map[string]float64)}
+	for _, lane := range lanes {
+		matrix.miles[laneKey(lane.Origin, lane.Destination)] = lane.Miles
+	}
+	return matrix
+}
+
+// LoadStaticMatrix reads a JSON array of LaneMiles entries from disk
+func LoadStaticMatrix(path string) (*StaticMatrix, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read distance matrix: %w", err)
+	}
+	
+	var lanes []LaneMiles
+	if err := json.Unmarshal(data, &lanes); err != nil {
+		return nil, fmt.Errorf("parse distance matrix: %w", err)
+	}
+	for i, lane := range lanes {
+		if lane.Miles < 0 {
+			return nil, fmt.Errorf("distance matrix entry %d: miles cannot be negative", i)
+		}
+	}
+	return NewStaticMatrix(lanes), nil
+}
+
+func (m *StaticMatrix) DrivingMiles(ctx context.Context, from, to domain.Waypoint) (float64, bool, error) {
+	if miles, ok := m.miles[laneKey(from.Location, to.Location)]; ok {
+		return miles, true, nil
+	}
+	miles, ok := m.miles[laneKey(to.Location, from.Location)]
+	return miles, ok, nil
+}
+
+func laneKey(origin, destination string) string {
+	return domain.NormalizeAddress(origin) + "->" + domain.NormalizeAddress(destination)
+}
+
+const metersPerMile = 1609.344
+
+// OSRMProvider asks an OSRM routing server for driving distances and
+// remembers the answers. It routes between coordinates, so waypoints the
+// geocoder did not place are not measured. Answers are kept in a bounded LRU
+// cache; failures are cached too, briefly, so an unavailable server is not
+// retried for every request.
+type OSRMProvider struct {
+	baseURL string
+	profile string
+	client  *http.Client
+	cache   *milesCache
+	
+	// ttl is how long an answer is trusted; failureTTL how long a failure is
+	ttl        time.Duration
+	failureTTL time.Duration
+}
+
+// NewOSRMProvider routes with the server at baseURL, such as
+// http://localhost:5000, using the driving profile
+func NewOSRMProvider(baseURL string) *OSRMProvider {
+	return &OSRMProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		profile:    "driving",
+		client:     &http.Client{Timeout: 2 * time.Second},
+		cache:      newMilesCache(10000),
+		ttl:        24 * time.Hour,
+		failureTTL: 30 * time.Second,
+	}
+}
+
+func (o *OSRMProvider) DrivingMiles(ctx context.Context, from, to domain.Waypoint) (float64, bool, error) {
+	if from.Point == nil || to.Point == nil {
+		return 0, false, nil
+	}
+	// OSRM takes longitude first
+	coordinates := fmt.Sprintf("%.5f,%.5f;%.5f,%.5f", from.Point.Lng, from.Point.Lat, to.Point.Lng, to.Point.Lat)
+	if entry, ok := o.cache.get(coordinates, time.Now()); ok {
+		return entry.miles, entry.found, entry.err
+	}
+	
+	miles, found, err := o.fetch(ctx, coordinates)
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up; that says nothing about the server
+		return 0, false, err
+	}
+	
+	ttl := o.ttl
+	if err != nil {
+		ttl = o.failureTTL
+	}
+	o.cache.put(coordinates, milesEntry{miles: miles, found: found, err: err, expires: time.Now().Add(ttl)})
+	return miles, found, err
+}
+
+func (o *OSRMProvider) fetch(ctx context.Context, coordinates string) (float64, bool, error) {
+	endpoint := fmt.Sprintf("%s/route/v1/%s/%s?overview=false", o.baseURL, o.profile, coordinates)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, false, fmt.Errorf("osrm request invalid: %w", err)
+	}
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("osrm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	var body struct {
+		Code   string `json:"code"`
+		Routes []struct {
+			Distance float64 `json:"distance"`
+		} `json:"routes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, false, fmt.Errorf("osrm response invalid (status %d): %w", resp.StatusCode, err)
+	}
+	// NoRoute and NoSegment mean the points cannot be driven between, which
+	// is an answer; any other failure is the server's
+	switch {
+	case body.Code == "NoRoute" || body.Code == "NoSegment":
+		return 0, false, nil
+	case body.Code != "Ok" || len(body.Routes) == 0:
+		return 0, false, fmt.Errorf("osrm returned status %d, code %q", resp.StatusCode, body.Code)
+	}
+	return body.Routes[0].Distance / metersPerMile, true, nil
+}
+
+type milesEntry struct {
+	key     string
+	miles   float64
+	found   bool
+	err     error
+	expires time.Time
+}
+
+// milesCache is a fixed-size LRU of routed distances with per-entry expiry
+type milesCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newMilesCache(capacity int) *milesCache {
+	return &milesCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *milesCache) get(key string, now time.Time) (milesEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	element, ok := c.entries[key]
+	if !ok {
+		return milesEntry{}, false
+	}
+	entry := element.Value.(milesEntry)
+	if now.After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return milesEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *milesCache) put(key string, entry milesEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	entry.key = key
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(milesEntry).key)
+	}
+}
@@ -0,0 +1,67 @@
+package distance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestStaticMatrixLooksUpBothWays(t *testing.T) {
+	matrix := NewStaticMatrix([]LaneMiles{{Origin: "Los Angeles, CA", Destination: "Dallas, TX", Miles: 1435}})
+	for _, lane := range [][2]string{{"los angeles ca", "Dallas TX"}, {"Dallas, TX", "Los Angeles, CA"}} {
+		miles, ok, err := matrix.DrivingMiles(context.Background(), domain.Waypoint{Location: lane[0]}, domain.Waypoint{Location: lane[1]})
+		if err != nil || !ok || miles != 1435 {
+			t.Errorf("%s->%s = %v, %v, %v; want 1435 miles", lane[0], lane[1], miles, ok, err)
+		}
+	}
+	if _, ok, _ := matrix.DrivingMiles(context.Background(), domain.Waypoint{Location: "Dallas, TX"}, domain.Waypoint{Location: "Houston, TX"}); ok {
+		t.Error("unlisted lane measured")
+	}
+}
+
+func TestOSRMProvider(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if !strings.HasPrefix(r.URL.Path, "/route/v1/driving/-118.24000,34.05000;-96.80000,32.78000") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"code": "Ok", "routes": [{"distance": 2309410.6}]}`))
+	}))
+	defer server.Close()
+	
+	provider := NewOSRMProvider(server.URL + "/")
+	from := domain.Waypoint{Location: "Los Angeles, CA", Point: &domain.LatLng{Lat: 34.05, Lng: -118.24}}
+	to := domain.Waypoint{Location: "Dallas, TX", Point: &domain.LatLng{Lat: 32.78, Lng: -96.80}}
+	for i := 0; i < 2; i++ {
+		miles, ok, err := provider.DrivingMiles(context.Background(), from, to)
+		if err != nil || !ok || miles < 1434 || miles > 1436 {
+			t.Fatalf("DrivingMiles = %v, %v, %v; want about 1435 miles", miles, ok, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server called %d times, want the answer cached", calls)
+	}
+	
+	if _, ok, err := provider.DrivingMiles(context.Background(), domain.Waypoint{Location: "Nowhere"}, to); ok || err != nil {
+		t.Errorf("waypoint without a point measured: %v, %v", ok, err)
+	}
+}
+
+func TestOSRMProviderFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": "InvalidQuery"}`))
+	}))
+	defer server.Close()
+	
+	point := &domain.LatLng{Lat: 34.05, Lng: -118.24}
+	_, _, err := NewOSRMProvider(server.URL).DrivingMiles(context.Background(), domain.Waypoint{Point: point}, domain.Waypoint{Point: point})
+	if err == nil {
+		t.Error("InvalidQuery accepted")
+	}
+}
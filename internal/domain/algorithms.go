@@ -0,0 +1,174 @@
+package domain
+
+import "time"
+
+// Optimality guarantees an algorithm can give
+const (
+	// OptimalityExact algorithms always return a best feasible plan
+	OptimalityExact = "exact"
+	// OptimalityConditional algorithms are exact on some inputs only; the
+	// note says which, and solves report whether they were
+	OptimalityConditional = "conditional"
+	// OptimalityHeuristic algorithms return a good feasible plan with no
+	// guarantee of being the best
+	OptimalityHeuristic = "heuristic"
+)
+
+// AlgorithmConstraints are the constraints every algorithm honors: the
+// optimizers enforce capacity, compatibility and max_orders themselves, and
+// the rest is layered around whichever algorithm runs
+var AlgorithmConstraints = []string{
+	"max_weight_lbs",
+	"max_volume_cuft",
+	"max_orders",
+	"max_linear_feet",
+	"max_pallet_positions",
+	"route",
+	"multi_stop",
+	"hazmat",
+	"hazmat_class",
+	"commodity_type",
+	"temperature",
+	"time_windows",
+	"equipment_type",
+	"equipment_requirements",
+	"dimensions",
+	"axles",
+	"compartments",
+	"exclusive_group",
+	"rules",
+	"facilities",
+	"must_include_order_ids",
+	"priority",
+	"splittable",
+	"min_total_payout_cents",
+	"min_utilization_percent",
+}
+
+// AlgorithmInfo describes what an algorithm accepts and guarantees, so
+// clients can pick one for their problem
+type AlgorithmInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// MaxOrders is the largest order list the algorithm accepts
+	MaxOrders      int    `json:"max_orders"`
+	Optimality     string `json:"optimality"`
+	OptimalityNote string `json:"optimality_note,omitempty"`
+	// Deterministic is true when the same request always gets the same plan
+	Deterministic bool `json:"deterministic"`
+	// TimeLimitMs is the algorithm's own time budget; 0 means it runs until
+	// done or the service's solve timeout
+	TimeLimitMs int64    `json:"time_limit_ms,omitempty"`
+	Constraints []string `json:"constraints"`
+	// Parameters lists the optimization_config fields that tune it
+	Parameters []string `json:"parameters,omitempty"`
+}
+
+// AlgorithmCapabilities is an algorithm's description plus its measured
+// runtime curve
+type AlgorithmCapabilities struct {
+	AlgorithmInfo
+	RuntimeCurve []RuntimePoint `json:"runtime_curve"`
+	// MeasuredAt is when the runtime curve was benchmarked
+	MeasuredAt time.Time `json:"measured_at"`
+}
+
+// RuntimePoint is the typical compute time of an algorithm at one input size
+type RuntimePoint struct {
+	Orders   int     `json:"orders"`
+	MedianMs float64 `json:"median_ms"`
+}
+
+// algorithms lists every value optimization_config.algorithm accepts
+var algorithms = []AlgorithmInfo{
+	{
+		Name:          "dp",
+		Description:   "Dynamic programming over order subsets as bitmasks",
+		Optimality:    OptimalityExact,
+		Deterministic: true,
+	},
+	{
+		Name:          "backtracking",
+		Description:   "Depth-first search over order subsets, pruned by an upper bound",
+		Optimality:    OptimalityExact,
+		Deterministic: true,
+	},
+	{
+		Name:          "greedy",
+		Description:   "Takes orders by payout density while they fit",
+		Optimality:    OptimalityHeuristic,
+		Deterministic: true,
+	},
+	{
+		Name:          "greedy+ls",
+		Description:   "Greedy followed by add, 1-swap and 2-swap local search",
+		Optimality:    OptimalityHeuristic,
+		Deterministic: true,
+	},
+	{
+		Name:          "regret",
+		Description:   "Greedy that weighs each order by what it leaves room for among compatible orders",
+		Optimality:    OptimalityHeuristic,
+		Deterministic: true,
+	},
+	{
+		Name:           "grasp",
+		Description:    "Randomized greedy construction plus local search, restarted in parallel",
+		Optimality:     OptimalityHeuristic,
+		OptimalityNote: "restarts after the first per compatibility class stop at the time limit, so the plan can vary with load",
+		TimeLimitMs:    250,
+	},
+	{
+		Name:          "tabu",
+		Description:   "Tabu search from a greedy start over sampled add, drop and swap moves",
+		Optimality:    OptimalityHeuristic,
+		Deterministic: true,
+		Parameters:    []string{"tabu_tenure", "tabu_neighborhood"},
+	},
+	{
+		Name:           "portfolio",
+		Description:    "Runs DP, branch and bound, greedy and simulated annealing in parallel and keeps the best plan",
+		Optimality:     OptimalityConditional,
+		OptimalityNote: "optimal when an exact algorithm finishes within the time limit",
+		TimeLimitMs:    2000,
+	},
+	{
+		Name:           "knapsack",
+		Description:    "Knapsack DP over weight and volume capacity",
+		Optimality:     OptimalityConditional,
+		OptimalityNote: "exact when capacities and order sizes share a large common divisor and no two orders that each combine with a third conflict with each other; otherwise the plan is near-exact",
+		Deterministic:  true,
+	},
+	{
+		Name:          "branch_and_bound",
+		Description:   "Exact search pruned by the LP relaxation bound",
+		Optimality:    OptimalityExact,
+		Deterministic: true,
+	},
+	{
+		Name:          "meet_in_the_middle",
+		Description:   "Exact split-and-merge enumeration with dominance pruning",
+		Optimality:    OptimalityExact,
+		Deterministic: true,
+	},
+	{
+		Name:           "auto",
+		Description:    "Bitmask DP up to 22 orders, knapsack DP beyond, refined by local search when the knapsack is not exact",
+		Optimality:     OptimalityConditional,
+		OptimalityNote: "exact up to 22 orders and whenever the knapsack DP is",
+		Deterministic:  true,
+	},
+}
+
+// LookupAlgorithm describes the named algorithm, reporting false when no
+// algorithm has that name
+func LookupAlgorithm(name string) (AlgorithmInfo, bool) {
+	for _, info := range algorithms {
+		if info.Name == name {
+			info.MaxOrders = MaxOrdersForAlgorithm(name)
+			info.Constraints = AlgorithmConstraints
+			return info, true
+		}
+	}
+	return AlgorithmInfo{}, false
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "testing"
+
+func TestEveryAlgorithmIsDescribed(t *testing.T) {
+	for _, info := range algorithms {
+		config := OptimizationConfig{Algorithm: info.Name, RevenueWeight: 1}
+		if err := config.Validate(); err != nil {
+			t.Fatalf("described algorithm %s is rejected: %v", info.Name, err)
+		}
+		
+		described, ok := LookupAlgorithm(info.Name)
+		if !ok {
+			t.Fatalf("algorithm %s not found", info.Name)
+		}
+		if described.MaxOrders != MaxOrdersForAlgorithm(info.Name) || len(described.Constraints) == 0 {
+			t.Fatalf("algorithm %s described with max_orders %d and constraints %v", info.Name, described.MaxOrders, described.Constraints)
+		}
+		switch described.Optimality {
+		case OptimalityExact, OptimalityConditional, OptimalityHeuristic:
+		default:
+			t.Fatalf("algorithm %s has unknown optimality %q", info.Name, described.Optimality)
+		}
+	}
+}
+
+func TestUnknownAlgorithmIsRejected(t *testing.T) {
+	if _, ok := LookupAlgorithm("simplex"); ok {
+		t.Fatal("unknown algorithm found")
+	}
+	config := OptimizationConfig{Algorithm: "simplex", RevenueWeight: 1}
+	if err := config.Validate(); err == nil {
+		t.Fatal("unknown algorithm accepted")
+	}
+}
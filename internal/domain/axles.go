@@ -0,0 +1,240 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Axle groups
+const (
+	AxleSteer  = "steer"
+	AxleDrive  = "drive"
+	AxleTandem = "tandem"
+)
+
+// What to do when a plan's estimated axle loads are over the limits
+const (
+	AxleViolationWarn       = "warn"
+	AxleViolationReoptimize = "reoptimize"
+)
+
+// MaxAxleLbs bounds every axle limit and empty axle weight
+const MaxAxleLbs = 100000
+
+// defaultDeckLengthIn is the deck of a 53 ft trailer, used when the truck
+// gives no interior length
+const defaultDeckLengthIn = 53 * 12
+
+// AxleInput describes how a tractor-trailer carries its load: the legal
+// limit and empty weight of the steer axle, the tractor's drive axles and
+// the trailer's tandem, and where the trailer rests on them. Positions are
+// in inches: KingpinIn and TandemIn from the trailer's nose to the kingpin
+// and to the middle of the tandem, and FifthWheelOffsetIn how far the fifth
+// wheel sits ahead of the middle of the drive axles on a tractor whose steer
+// and drive axles are WheelbaseIn apart. Without a wheelbase the kingpin's
+// whole load goes to the drive axles.
+type AxleInput struct {
+	SteerMaxLbs        int    `json:"steer_max_lbs"`
+	DriveMaxLbs        int    `json:"drive_max_lbs"`
+	TandemMaxLbs       int    `json:"tandem_max_lbs"`
+	SteerEmptyLbs      int    `json:"steer_empty_lbs,omitempty"`
+	DriveEmptyLbs      int    `json:"drive_empty_lbs,omitempty"`
+	TandemEmptyLbs     int    `json:"tandem_empty_lbs,omitempty"`
+	KingpinIn          int    `json:"kingpin_in"`
+	TandemIn           int    `json:"tandem_in"`
+	WheelbaseIn        int    `json:"wheelbase_in,omitempty"`
+	FifthWheelOffsetIn int    `json:"fifth_wheel_offset_in,omitempty"`
+	OnViolation        string `json:"on_violation,omitempty"`
+}
+
+func (a *AxleInput) Validate() error {
+	for _, limit := range []struct {
+		name       string
+		max, empty int
+	}{
+		{AxleSteer, a.SteerMaxLbs, a.SteerEmptyLbs},
+		{AxleDrive, a.DriveMaxLbs, a.DriveEmptyLbs},
+		{AxleTandem, a.TandemMaxLbs, a.TandemEmptyLbs},
+	} {
+		if limit.max <= 0 || limit.max > MaxAxleLbs {
+			return fmt.Errorf("%s_max_lbs must be between 1 and %d", limit.name, MaxAxleLbs)
+		}
+		if limit.empty < 0 || limit.empty > limit.max {
+			return fmt.Errorf("%s_empty_lbs must be between 0 and %s_max_lbs", limit.name, limit.name)
+		}
+	}
+	if a.KingpinIn < 0 || a.TandemIn <= a.KingpinIn || a.TandemIn > MaxDimensionIn {
+		return fmt.Errorf("kingpin_in and tandem_in must satisfy 0 <= kingpin_in < tandem_in <= %d", MaxDimensionIn)
+	}
+	if a.WheelbaseIn < 0 || a.WheelbaseIn > MaxDimensionIn {
+		return fmt.Errorf("wheelbase_in must be between 0 and %d", MaxDimensionIn)
+	}
+	if a.FifthWheelOffsetIn < 0 || a.FifthWheelOffsetIn > a.WheelbaseIn {
+		return fmt.Errorf("fifth_wheel_offset_in must be between 0 and wheelbase_in")
+	}
+	switch a.OnViolation {
+	case "", AxleViolationWarn, AxleViolationReoptimize:
+	default:
+		return fmt.Errorf("invalid on_violation: %s (must be warn or reoptimize)", a.OnViolation)
+	}
+	return nil
+}
+
+// ToDomain returns nil for a truck without an axle model
+func (a *AxleInput) ToDomain() *Axles {
+	if a == nil {
+		return nil
+	}
+	return &Axles{
+		Max:                AxleLoads{SteerLbs: a.SteerMaxLbs, DriveLbs: a.DriveMaxLbs, TandemLbs: a.TandemMaxLbs},
+		Empty:              AxleLoads{SteerLbs: a.SteerEmptyLbs, DriveLbs: a.DriveEmptyLbs, TandemLbs: a.TandemEmptyLbs},
+		KingpinIn:          a.KingpinIn,
+		TandemIn:           a.TandemIn,
+		WheelbaseIn:        a.WheelbaseIn,
+		FifthWheelOffsetIn: a.FifthWheelOffsetIn,
+		Reoptimize:         a.OnViolation == AxleViolationReoptimize,
+	}
+}
+
+// Axles is a truck's axle model; see AxleInput
+type Axles struct {
+	Max                AxleLoads
+	Empty              AxleLoads
+	KingpinIn          int
+	TandemIn           int
+	WheelbaseIn        int
+	FifthWheelOffsetIn int
+	// Reoptimize makes the limits a rule plans must keep instead of a warning
+	Reoptimize bool
+}
+
+// AxleLoads is the weight on each axle group, in pounds
+type AxleLoads struct {
+	SteerLbs  int `json:"steer_lbs"`
+	DriveLbs  int `json:"drive_lbs"`
+	TandemLbs int `json:"tandem_lbs"`
+}
+
+// LoadingSequence is the proposed loading plan, nose first, that the axle
+// loads are estimated for: orders go on in pickup order, so the first picked
+// up rides at the front. Orders picked up together go on latest delivery
+// first, so they come off last in, first out; remaining ties go by ID.
+func LoadingSequence(orders []Order) []Order {
+	sequence := append([]Order(nil), orders...)
+	sort.SliceStable(sequence, func(i, j int) bool {
+		a, b := sequence[i], sequence[j]
+		if !a.pickupStart().Equal(b.pickupStart()) {
+			return a.pickupStart().Before(b.pickupStart())
+		}
+		if !a.deliveryStart().Equal(b.deliveryStart()) {
+			return a.deliveryStart().After(b.deliveryStart())
+		}
+		return a.ID < b.ID
+	})
+	return sequence
+}
+
+// AxleLoads estimates the axle loads of the truck carrying orders in their
+// LoadingSequence, or returns nil for a truck without an axle model. Each
+// order takes its linear feet of deck or, without them, its share of the
+// truck's cube as a share of the deck, and weighs on the middle of that
+// stretch. The trailer is a beam resting on the kingpin and the tandem; the
+// kingpin's load is split between steer and drive axles by the fifth wheel's
+// place in the wheelbase.
+func (t Truck) AxleLoads(orders []Order) *AxleLoads {
+	if t.Axles == nil {
+		return nil
+	}
+	a := t.Axles
+	deck := defaultDeckLengthIn
+	if t.Interior != nil {
+		deck = t.Interior.LengthIn
+	}
+	
+	kingpin, tandem := 0.0, 0.0
+	front := 0.0
+	for _, order := range LoadingSequence(orders) {
+		length := float64(order.LinearFeet * 12)
+		if order.LinearFeet == 0 {
+			length = float64(deck) * float64(order.VolumeCuft) / float64(t.MaxVolumeCuft)
+		}
+		center := front + length/2
+		if center > float64(deck) {
+			center = float64(deck)
+		}
+		front += length
+		
+		weight := float64(order.ScaleWeight())
+		onTandem := weight * (center - float64(a.KingpinIn)) / float64(a.TandemIn-a.KingpinIn)
+		tandem += onTandem
+		kingpin += weight - onTandem
+	}
+	
+	steer := 0.0
+	if a.WheelbaseIn > 0 {
+		steer = kingpin * float64(a.FifthWheelOffsetIn) / float64(a.WheelbaseIn)
+	}
+	return &AxleLoads{
+		SteerLbs:  a.Empty.SteerLbs + roundLbs(steer),
+		DriveLbs:  a.Empty.DriveLbs + roundLbs(kingpin-steer),
+		TandemLbs: a.Empty.TandemLbs + roundLbs(tandem),
+	}
+}
+
+func roundLbs(lbs float64) int {
+	if lbs < 0 {
+		return -int(-lbs + 0.5)
+	}
+	return int(lbs + 0.5)
+}
+
+// Overloaded describes each axle group loaded past its limit, or returns
+// none when the loads are legal
+func (l AxleLoads) Overloaded(max AxleLoads) []string {
+	var over []string
+	for _, axle := range []struct {
+		name        string
+		load, limit int
+	}{
+		{AxleSteer, l.SteerLbs, max.SteerLbs},
+		{AxleDrive, l.DriveLbs, max.DriveLbs},
+		{AxleTandem, l.TandemLbs, max.TandemLbs},
+	} {
+		if axle.load > axle.limit {
+			over = append(over, fmt.Sprintf("%s axle load %d lbs exceeds its %d lb limit", axle.name, axle.load, axle.limit))
+		}
+	}
+	return over
+}
+
+// AxleLimit keeps plans within the truck's axle limits when its axle model
+// asks to reoptimize. Unlike the other set rules it is not monotone: taking
+// an order off moves the ones loaded behind it forward. The empty plan is
+// always legal, since empty weights are validated against the limits, so
+// the set rule repair still ends in a legal plan.
+type AxleLimit struct {
+	Truck Truck
+}
+
+func (AxleLimit) Name() string { return "axle_weights" }
+
+func (l AxleLimit) AllowsSet(orders []Order) bool {
+	loads := l.Truck.AxleLoads(orders)
+	return loads == nil || len(loads.Overloaded(l.Truck.Axles.Max)) == 0
+}
+
+// AxleWarnings lists a warning for each axle group the plan overloads
+func (t Truck) AxleWarnings(loads *AxleLoads) []ValidationWarning {
+	if loads == nil {
+		return nil
+	}
+	var warnings []ValidationWarning
+	for _, message := range loads.Overloaded(t.Axles.Max) {
+		warnings = append(warnings, ValidationWarning{
+			Code:    "axle_overweight",
+			Field:   "truck.axles",
+			Message: "estimated " + message,
+		})
+	}
+	return warnings
+}
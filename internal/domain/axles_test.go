@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func axleTruck() Truck {
+	return Truck{
+		MaxWeightLbs:  45000,
+		MaxVolumeCuft: 3000,
+		Axles: &Axles{
+			Max:         AxleLoads{SteerLbs: 12000, DriveLbs: 34000, TandemLbs: 34000},
+			Empty:       AxleLoads{SteerLbs: 10000, DriveLbs: 9000, TandemLbs: 6000},
+			KingpinIn:   36,
+			TandemIn:    516,
+			WheelbaseIn: 200,
+			// A tenth of the kingpin's load goes to the steer axle
+			FifthWheelOffsetIn: 20,
+		},
+	}
+}
+
+func TestAxleLoads(t *testing.T) {
+	truck := axleTruck()
+	if loads := (Truck{}).AxleLoads(nil); loads != nil {
+		t.Errorf("truck without axles estimated %+v", loads)
+	}
+	if got := *truck.AxleLoads(nil); got != truck.Axles.Empty {
+		t.Errorf("empty truck loads = %+v, want the empty weights", got)
+	}
+	
+	// Centered 120 in from the nose, 84 in behind the kingpin: 84/480 of the
+	// weight on the tandem and the rest on the kingpin
+	order := Order{ID: "a", WeightLbs: 10000, VolumeCuft: 100, LinearFeet: 20}
+	want := AxleLoads{SteerLbs: 10825, DriveLbs: 16425, TandemLbs: 7750}
+	if got := *truck.AxleLoads([]Order{order}); got != want {
+		t.Errorf("loads = %+v, want %+v", got, want)
+	}
+	
+	// Without linear feet the order takes its share of cube as deck: a full
+	// trailer of cube spreads over the 53 ft deck, centered at 318 in
+	cube := Order{ID: "b", WeightLbs: 4800, VolumeCuft: 3000}
+	if got := truck.AxleLoads([]Order{cube}).TandemLbs; got != 6000+2820 {
+		t.Errorf("tandem load = %d, want %d", got, 6000+2820)
+	}
+}
+
+func TestLoadingSequenceFollowsPickups(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	orders := []Order{{ID: "c", PickupDate: day(2)}, {ID: "b", PickupDate: day(1)}, {ID: "a", PickupDate: day(2)}}
+	
+	var ids []string
+	for _, order := range LoadingSequence(orders) {
+		ids = append(ids, order.ID)
+	}
+	if want := []string{"b", "a", "c"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("sequence = %v, want %v", ids, want)
+	}
+}
+
+func TestAxleWarnings(t *testing.T) {
+	truck := axleTruck()
+	loads := &AxleLoads{SteerLbs: 12000, DriveLbs: 34001, TandemLbs: 40000}
+	
+	warnings := truck.AxleWarnings(loads)
+	if len(warnings) != 2 || warnings[0].Code != "axle_overweight" ||
+		warnings[0].Message != "estimated drive axle load 34001 lbs exceeds its 34000 lb limit" {
+		t.Fatalf("warnings = %+v", warnings)
+	}
+	if warnings := truck.AxleWarnings(truck.AxleLoads(nil)); len(warnings) != 0 {
+		t.Errorf("empty truck warned %+v", warnings)
+	}
+}
+
+func TestAxleInputValidate(t *testing.T) {
+	valid := AxleInput{SteerMaxLbs: 12000, DriveMaxLbs: 34000, TandemMaxLbs: 34000, KingpinIn: 36, TandemIn: 516}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid axles: %v", err)
+	}
+	
+	tests := []func(a *AxleInput){
+		func(a *AxleInput) { a.SteerMaxLbs = 0 },
+		func(a *AxleInput) { a.DriveEmptyLbs = 35000 },
+		func(a *AxleInput) { a.TandemIn = a.KingpinIn },
+		func(a *AxleInput) { a.FifthWheelOffsetIn = 10 },
+		func(a *AxleInput) { a.OnViolation = "ignore" },
+	}
+	for i, breakIt := range tests {
+		a := valid
+		breakIt(&a)
+		if err := a.Validate(); err == nil {
+			t.Errorf("case %d: accepted %+v", i, a)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxBackhaulRadiusMiles caps radius_miles of a backhaul request
+const MaxBackhaulRadiusMiles = 250
+
+// BackhaulRequest looks for return loads for an outbound plan. Orders is the
+// pool: the outbound orders named in OutboundOrderIDs and the candidates for
+// the way back. The rest of the request configures the backhaul solve.
+type BackhaulRequest struct {
+	OptimizeRequest
+	OutboundOrderIDs []string `json:"outbound_order_ids"`
+	// RadiusMiles widens the trip's ends from the same place, as routes
+	// match, to anywhere within this many road miles, when both have points
+	RadiusMiles int `json:"radius_miles,omitempty"`
+}
+
+// Validate checks the outbound plan and radius; the rest of the request is
+// validated as an optimize request
+func (r *BackhaulRequest) Validate() error {
+	if len(r.OutboundOrderIDs) == 0 {
+		return fmt.Errorf("outbound_order_ids is required")
+	}
+	known := make(map[string]bool, len(r.Orders))
+	for _, order := range r.Orders {
+		known[order.ID] = true
+	}
+	seen := make(map[string]bool, len(r.OutboundOrderIDs))
+	for _, id := range r.OutboundOrderIDs {
+		if !known[id] {
+			return fmt.Errorf("outbound_order_ids: unknown order %s", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("outbound_order_ids: duplicate order %s", id)
+		}
+		seen[id] = true
+	}
+	if r.RadiusMiles < 0 || r.RadiusMiles > MaxBackhaulRadiusMiles {
+		return fmt.Errorf("radius_miles must be between 0 and %d", MaxBackhaulRadiusMiles)
+	}
+	return nil
+}
+
+// Outbound reports whether an order belongs to the outbound plan
+func (r *BackhaulRequest) Outbound() map[string]bool {
+	outbound := make(map[string]bool, len(r.OutboundOrderIDs))
+	for _, id := range r.OutboundOrderIDs {
+		outbound[id] = true
+	}
+	return outbound
+}
+
+// RoundTrip is an outbound plan's route seen from the way back: where the
+// truck starts (Home), where it ends up (Destination) and when it is free
+// to reload there
+type RoundTrip struct {
+	Home        Stop
+	Destination Stop
+	FreeAt      time.Time
+}
+
+// NewRoundTrip sequences the outbound plan from the truck's position. The
+// truck is free once the last delivery window opens.
+func NewRoundTrip(position *LatLng, outbound []Order) RoundTrip {
+	stops := SequenceStops(position, outbound)
+	trip := RoundTrip{Home: stops[0], Destination: stops[len(stops)-1]}
+	for _, order := range outbound {
+		if order.DeliveryWindow.Start.After(trip.FreeAt) {
+			trip.FreeAt = order.DeliveryWindow.Start
+		}
+	}
+	return trip
+}
+
+// Accepts reports whether an order can ride back: picked up at the trip's
+// destination no earlier than the truck is free, and delivered home
+func (t RoundTrip) Accepts(order Order, radiusMiles int) bool {
+	if !order.PickupWindow.End.IsZero() && !order.PickupWindow.End.After(t.FreeAt) {
+		return false
+	}
+	return near(t.Destination, order.Origin, order.OriginPoint, radiusMiles) &&
+		near(t.Home, order.Destination, order.DestinationPoint, radiusMiles)
+}
+
+// near reports whether a location is the same place as a stop, or within
+// radiusMiles of it
+func near(stop Stop, location string, point *LatLng, radiusMiles int) bool {
+	if samePlace(stop.Location, stop.Point, location, point) {
+		return true
+	}
+	return radiusMiles > 0 && stop.Point != nil && point != nil &&
+		legMiles(*stop.Point, *point) <= float64(radiusMiles)
+}
+
+// BackhaulPair is an outbound plan with one of its return loads
+type BackhaulPair struct {
+	Rank                 int      `json:"rank"`
+	BackhaulOrderIDs     []string `json:"backhaul_order_ids"`
+	BackhaulPayoutCents  int64    `json:"backhaul_payout_cents"`
+	RoundTripPayoutCents int64    `json:"round_trip_payout_cents"`
+	// RoundTripNetProfitCents is the payout of both legs less the cost of
+	// each, every leg being costed as its own plan
+	RoundTripNetProfitCents int64 `json:"round_trip_net_profit_cents"`
+}
+
+// RankBackhauls pairs the outbound plan with each backhaul plan, highest
+// round-trip payout first; ties keep the order the plans came in
+func RankBackhauls(outbound *OptimizeResponse, backhauls []PlanSummary) []BackhaulPair {
+	pairs := make([]BackhaulPair, 0, len(backhauls))
+	for _, backhaul := range backhauls {
+		if len(backhaul.SelectedOrderIDs) == 0 {
+			continue
+		}
+		pairs = append(pairs, BackhaulPair{
+			BackhaulOrderIDs:        backhaul.SelectedOrderIDs,
+			BackhaulPayoutCents:     backhaul.TotalPayoutCents,
+			RoundTripPayoutCents:    outbound.TotalPayoutCents + backhaul.TotalPayoutCents,
+			RoundTripNetProfitCents: outbound.NetProfitCents + backhaul.NetProfitCents,
+		})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return pairs[i].RoundTripPayoutCents > pairs[j].RoundTripPayoutCents
+	})
+	for i := range pairs {
+		pairs[i].Rank = i + 1
+	}
+	return pairs
+}
+
+// BackhaulResponse is the outbound plan and its return loads. Backhaul is
+// the best return plan in full, nil when no order in the pool can ride back.
+type BackhaulResponse struct {
+	Outbound          *OptimizeResponse `json:"outbound"`
+	Home              string            `json:"home"`
+	Destination       string            `json:"destination"`
+	CandidateOrderIDs []string          `json:"candidate_order_ids"`
+	Pairs             []BackhaulPair    `json:"pairs"`
+	Backhaul          *OptimizeResponse `json:"backhaul,omitempty"`
+	PayoutRedacted    bool              `json:"payout_redacted,omitempty"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRoundTripAccepts(t *testing.T) {
+	day := func(d int) TimeWindow {
+		return dayWindow(time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC))
+	}
+	outbound := Order{
+		ID: "out", Origin: "Dallas, TX", Destination: "Phoenix, AZ", OriginPoint: dallas, DestinationPoint: phoenix,
+		PickupWindow: day(1), DeliveryWindow: day(3),
+	}
+	trip := NewRoundTrip(nil, []Order{outbound})
+	if trip.Home.Location != "Dallas, TX" || trip.Destination.Location != "Phoenix, AZ" {
+		t.Fatalf("trip from %s to %s", trip.Home.Location, trip.Destination.Location)
+	}
+	
+	fortWorth := &LatLng{Lat: 32.75, Lng: -97.33}
+	back := Order{
+		Origin: "phoenix az", Destination: "Irving, TX", OriginPoint: phoenix, DestinationPoint: irving,
+		PickupWindow: day(3),
+	}
+	tests := []struct {
+		name   string
+		order  func(Order) Order
+		radius int
+		want   bool
+	}{
+		{"same places", func(o Order) Order { return o }, 0, true},
+		{"picked up before delivering", func(o Order) Order { o.PickupWindow = day(2); return o }, 0, false},
+		{"goes elsewhere", func(o Order) Order { o.Destination, o.DestinationPoint = "Houston, TX", houston; return o }, 0, false},
+		{"beyond the same place", func(o Order) Order { o.Destination, o.DestinationPoint = "Fort Worth, TX", fortWorth; return o }, 0, false},
+		{"within the radius", func(o Order) Order { o.Destination, o.DestinationPoint = "Fort Worth, TX", fortWorth; return o }, 50, true},
+		{"picked up elsewhere", func(o Order) Order { o.Origin, o.OriginPoint = "El Paso, TX", elPaso; return o }, 250, false},
+	}
+	for _, tt := range tests {
+		if got := trip.Accepts(tt.order(back), tt.radius); got != tt.want {
+			t.Errorf("%s: accepted = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRankBackhauls(t *testing.T) {
+	outbound := &OptimizeResponse{TotalPayoutCents: 1000, NetProfitCents: 800}
+	pairs := RankBackhauls(outbound, []PlanSummary{
+		{SelectedOrderIDs: []string{"a"}, TotalPayoutCents: 300, NetProfitCents: 300},
+		{SelectedOrderIDs: nil},
+		{SelectedOrderIDs: []string{"b"}, TotalPayoutCents: 500, NetProfitCents: 200},
+	})
+	if len(pairs) != 2 || pairs[0].BackhaulOrderIDs[0] != "b" || pairs[0].Rank != 1 {
+		t.Fatalf("pairs %+v, want b first and the empty plan dropped", pairs)
+	}
+	if pairs[0].RoundTripPayoutCents != 1500 || pairs[0].RoundTripNetProfitCents != 1000 {
+		t.Errorf("round trip %+v", pairs[0])
+	}
+}
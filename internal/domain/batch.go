@@ -0,0 +1,37 @@
+package domain
+
+import "fmt"
+
+// MaxBatchRequests caps the requests of one batch
+const MaxBatchRequests = 100
+
+// BatchRequest is a set of independent optimize requests solved in one call
+type BatchRequest struct {
+	Requests []OptimizeRequest `json:"requests"`
+}
+
+func (r *BatchRequest) Validate() error {
+	if len(r.Requests) == 0 {
+		return fmt.Errorf("requests list cannot be empty")
+	}
+	if len(r.Requests) > MaxBatchRequests {
+		return fmt.Errorf("requests list cannot exceed %d items (got %d)", MaxBatchRequests, len(r.Requests))
+	}
+	return nil
+}
+
+// BatchItem is the outcome of one request of a batch: its response, or the
+// error and status it would have failed with on its own
+type BatchItem struct {
+	Index    int               `json:"index"`
+	Status   int               `json:"status"`
+	Response *OptimizeResponse `json:"response,omitempty"`
+	Error    *ErrorDetail      `json:"error,omitempty"`
+}
+
+// BatchResponse lists the outcomes in the order of the requests
+type BatchResponse struct {
+	Results   []BatchItem `json:"results"`
+	Succeeded int         `json:"succeeded"`
+	Failed    int         `json:"failed"`
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxBidLevels caps the payout levels one order may be bid at
+const MaxBidLevels = 10
+
+// BidRequest is an optimize request for spot bidding: orders listed in Bids
+// are not yet won, and may be bid at any of their levels. Orders without bids
+// are already won and pay their payout_cents for certain.
+type BidRequest struct {
+	OptimizeRequest
+	Bids []OrderBids `json:"bids"`
+}
+
+// OrderBids are the payout levels an order could be bid at
+type OrderBids struct {
+	OrderID string     `json:"order_id"`
+	Levels  []BidLevel `json:"levels"`
+}
+
+// BidLevel is one payout we could bid and the chance it wins the order
+type BidLevel struct {
+	PayoutCents    int64   `json:"payout_cents"`
+	WinProbability float64 `json:"win_probability"`
+}
+
+// ExpectedPayout is what bidding at the level earns on average
+func (l BidLevel) ExpectedPayout() float64 {
+	return float64(l.PayoutCents) * l.WinProbability
+}
+
+// Validate checks the bids against the request's orders; the rest of the
+// request is validated as an optimize request
+func (r *BidRequest) Validate(profile ValidationProfile) error {
+	orders := make(map[string]OrderInput, len(r.Orders))
+	for _, order := range r.Orders {
+		orders[order.ID] = order
+	}
+	seen := make(map[string]bool, len(r.Bids))
+	for i, bids := range r.Bids {
+		order, ok := orders[bids.OrderID]
+		if !ok {
+			return fmt.Errorf("bids[%d]: unknown order %s", i, bids.OrderID)
+		}
+		if seen[bids.OrderID] {
+			return fmt.Errorf("bids[%d]: duplicate order %s", i, bids.OrderID)
+		}
+		seen[bids.OrderID] = true
+		if order.PayoutCents != 0 || order.PayoutEncrypted != "" {
+			return fmt.Errorf("bids[%d]: order %s is bid on, so it takes no payout", i, bids.OrderID)
+		}
+		if len(bids.Levels) == 0 || len(bids.Levels) > MaxBidLevels {
+			return fmt.Errorf("bids[%d]: levels must be between 1 and %d", i, MaxBidLevels)
+		}
+		for _, level := range bids.Levels {
+			if level.PayoutCents <= 0 || level.PayoutCents > profile.MaxPayoutCents {
+				return fmt.Errorf("bids[%d]: payout_cents must be between 1 and %d", i, profile.MaxPayoutCents)
+			}
+			if !(level.WinProbability > 0 && level.WinProbability <= 1) {
+				return fmt.Errorf("bids[%d]: win_probability must be above 0 and at most 1", i)
+			}
+		}
+	}
+	return nil
+}
+
+// BestBids picks each bid-on order's level with the highest expected payout,
+// highest payout on a tie. Costs do not depend on the level, so whichever
+// orders end up loaded, this level maximizes their expected profit.
+func (r *BidRequest) BestBids() map[string]BidLevel {
+	best := make(map[string]BidLevel, len(r.Bids))
+	for _, bids := range r.Bids {
+		choice := bids.Levels[0]
+		for _, level := range bids.Levels[1:] {
+			if level.ExpectedPayout() > choice.ExpectedPayout() ||
+				level.ExpectedPayout() == choice.ExpectedPayout() && level.PayoutCents > choice.PayoutCents {
+				choice = level
+			}
+		}
+		best[bids.OrderID] = choice
+	}
+	return best
+}
+
+// ExpectedRequest is the optimize request that prices every bid-on order at
+// the expected payout of its chosen level, so the optimizer selects the load
+// with the highest expected payout
+func (r *BidRequest) ExpectedRequest(chosen map[string]BidLevel, rounding RoundingMode) OptimizeRequest {
+	request := r.OptimizeRequest
+	request.Orders = append([]OrderInput(nil), r.Orders...)
+	for i, order := range request.Orders {
+		if level, ok := chosen[order.ID]; ok {
+			// Every bid earns at least a cent on average, keeping payouts positive
+			request.Orders[i].PayoutCents = int64(math.Max(1, float64(rounding.RoundCents(level.ExpectedPayout()))))
+		}
+	}
+	return request
+}
+
+// ChosenBid is the level to bid on an order in the load
+type ChosenBid struct {
+	OrderID             string  `json:"order_id"`
+	PayoutCents         int64   `json:"payout_cents"`
+	WinProbability      float64 `json:"win_probability"`
+	ExpectedPayoutCents int64   `json:"expected_payout_cents"`
+}
+
+// BidResponse is the bid vector and load that maximize expected profit. Plan
+// is solved with bid-on orders at their expected payouts, so its payout and
+// profit are expectations too. ExpectedCostCents charges the plan's full cost
+// whenever any of its orders is won, an upper bound when only some are.
+type BidResponse struct {
+	Bids                []ChosenBid       `json:"bids"`
+	WinAnyProbability   float64           `json:"win_any_probability"`
+	ExpectedPayoutCents int64             `json:"expected_payout_cents"`
+	ExpectedCostCents   int64             `json:"expected_cost_cents"`
+	ExpectedProfitCents int64             `json:"expected_profit_cents"`
+	Plan                *OptimizeResponse `json:"plan"`
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// CommittedPlan is the orders a truck is committed to and what they leave
+// free, kept so whether one more order fits is answered without solving. A
+// plan is never changed; With returns the plan with an order added.
+type CommittedPlan struct {
+	Truck  Truck
+	Orders []Order
+	
+	rules  *RuleEngine
+	ids    map[string]bool
+	weight int
+	volume int
+	floor  FloorSpace
+}
+
+// NewCommittedPlan starts an empty plan for the truck under rules
+func NewCommittedPlan(truck Truck, rules *RuleEngine) *CommittedPlan {
+	return &CommittedPlan{Truck: truck, rules: rules, ids: make(map[string]bool)}
+}
+
+// Blockers lists why order cannot join the plan; it is empty when the order
+// fits the remaining capacity and every rule allows it with the committed
+// orders
+func (p *CommittedPlan) Blockers(order Order) []string {
+	var reasons []string
+	if p.ids[order.ID] {
+		return []string{fmt.Sprintf("order %s is already on the plan", order.ID)}
+	}
+	if reason := p.Truck.CannotCarry(order); reason != "" {
+		reasons = append(reasons, reason)
+	}
+	if !p.Truck.FitsMoreOrders(len(p.Orders)) {
+		reasons = append(reasons, fmt.Sprintf("the truck takes at most %d orders", p.Truck.MaxOrders))
+	}
+	if remaining := p.Truck.MaxWeightLbs - p.weight; order.WeightLbs > remaining {
+		reasons = append(reasons, fmt.Sprintf("needs %d lbs, %d lbs remain", order.WeightLbs, remaining))
+	}
+	if remaining := p.Truck.MaxVolumeCuft - p.volume; order.VolumeCuft > remaining {
+		reasons = append(reasons, fmt.Sprintf("needs %d cuft, %d cuft remain", order.VolumeCuft, remaining))
+	}
+	if !p.Truck.FitsFloor(p.floor, order) {
+		reasons = append(reasons, "does not fit the remaining floor space")
+	}
+	for _, committed := range p.Orders {
+		if rule := p.rules.PairConflict(committed, order); rule != "" {
+			reasons = append(reasons, fmt.Sprintf("rule %s keeps it apart from order %s", rule, committed.ID))
+		}
+	}
+	if len(reasons) > 0 {
+		return reasons
+	}
+	
+	// Set rules see the whole plan, so they run last, once the cheap checks pass
+	if rule := p.rules.SetConflict(append(p.Orders[:len(p.Orders):len(p.Orders)], order)); rule != "" {
+		reasons = append(reasons, fmt.Sprintf("rule %s rejects the plan with it", rule))
+	}
+	return reasons
+}
+
+// With returns the plan with order added, without checking it; see Blockers
+func (p *CommittedPlan) With(order Order) *CommittedPlan {
+	ids := make(map[string]bool, len(p.ids)+1)
+	for id := range p.ids {
+		ids[id] = true
+	}
+	ids[order.ID] = true
+	return &CommittedPlan{
+		Truck:  p.Truck,
+		Orders: append(p.Orders[:len(p.Orders):len(p.Orders)], order),
+		rules:  p.rules,
+		ids:    ids,
+		weight: p.weight + order.WeightLbs,
+		volume: p.volume + order.VolumeCuft,
+		floor:  p.floor.Add(order),
+	}
+}
+
+// RemainingCapacity is what a committed plan leaves free on its truck. Limits
+// the truck does not set are left out.
+type RemainingCapacity struct {
+	WeightLbs       int  `json:"weight_lbs"`
+	VolumeCuft      int  `json:"volume_cuft"`
+	Orders          *int `json:"orders,omitempty"`
+	LinearFeet      *int `json:"linear_feet,omitempty"`
+	PalletPositions *int `json:"pallet_positions,omitempty"`
+}
+
+// Remaining reports the capacity the plan leaves free
+func (p *CommittedPlan) Remaining() RemainingCapacity {
+	remaining := RemainingCapacity{
+		WeightLbs:  p.Truck.MaxWeightLbs - p.weight,
+		VolumeCuft: p.Truck.MaxVolumeCuft - p.volume,
+	}
+	limit := func(max, used int) *int {
+		if max == 0 {
+			return nil
+		}
+		free := max - used
+		return &free
+	}
+	remaining.Orders = limit(p.Truck.MaxOrders, len(p.Orders))
+	remaining.LinearFeet = limit(p.Truck.MaxLinearFeet, p.floor.LinearFeet)
+	remaining.PalletPositions = limit(p.Truck.MaxPalletPositions, p.floor.PalletPositions())
+	return remaining
+}
+
+// PlanState reports a truck's committed plan
+type PlanState struct {
+	TruckID     string            `json:"truck_id"`
+	OrderIDs    []string          `json:"order_ids"`
+	Remaining   RemainingCapacity `json:"remaining"`
+	CommittedAt time.Time         `json:"committed_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// AdditionCheck answers whether an order can join a truck's committed plan.
+// Remaining is the capacity left after the order when it was added, and
+// before it otherwise.
+type AdditionCheck struct {
+	TruckID   string            `json:"truck_id"`
+	OrderID   string            `json:"order_id"`
+	CanAdd    bool              `json:"can_add"`
+	Added     bool              `json:"added"`
+	Reasons   []string          `json:"reasons,omitempty"`
+	Remaining RemainingCapacity `json:"remaining"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommittedPlanBlockers(t *testing.T) {
+	truck := Truck{ID: "t", MaxWeightLbs: 1000, MaxVolumeCuft: 100, MaxOrders: 2, MaxLinearFeet: 20}
+	order := func(id string, weight, feet int) Order {
+		return Order{ID: id, WeightLbs: weight, VolumeCuft: 10, LinearFeet: feet, Origin: "A", Destination: "B"}
+	}
+	plan := NewCommittedPlan(truck, NewConstraintChecker()).With(order("a", 600, 12))
+	
+	remaining := plan.Remaining()
+	if remaining.WeightLbs != 400 || remaining.VolumeCuft != 90 || *remaining.Orders != 1 || *remaining.LinearFeet != 8 || remaining.PalletPositions != nil {
+		t.Fatalf("remaining %+v", remaining)
+	}
+	if reasons := plan.Blockers(order("b", 300, 8)); len(reasons) != 0 {
+		t.Errorf("fitting order blocked: %v", reasons)
+	}
+	reasons := plan.Blockers(order("b", 500, 10))
+	if len(reasons) != 2 || !strings.Contains(reasons[0], "500 lbs") || !strings.Contains(reasons[1], "floor") {
+		t.Errorf("reasons %v, want weight and floor", reasons)
+	}
+	if reasons := plan.Blockers(order("a", 1, 0)); len(reasons) != 1 || !strings.Contains(reasons[0], "already") {
+		t.Errorf("reasons %v, want already on the plan", reasons)
+	}
+	
+	full := plan.With(order("b", 100, 0))
+	if reasons := full.Blockers(order("c", 1, 0)); len(reasons) != 1 || !strings.Contains(reasons[0], "at most 2 orders") {
+		t.Errorf("reasons %v, want the order cap", reasons)
+	}
+	if len(plan.Orders) != 1 {
+		t.Error("With changed the plan it was called on")
+	}
+	
+	limited := NewCommittedPlan(truck, NewRuleEngine(nil, []SetRule{MaxOrdersPerShipper{Limit: 1}}))
+	limited = limited.With(Order{ID: "x", Shipper: "s"})
+	if reasons := limited.Blockers(Order{ID: "y", Shipper: "s"}); len(reasons) != 1 || !strings.Contains(reasons[0], "max_orders_per_shipper") {
+		t.Errorf("reasons %v, want the set rule", reasons)
+	}
+}
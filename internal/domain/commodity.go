@@ -0,0 +1,89 @@
+package domain
+
+import "fmt"
+
+// Commodity types an order's commodity_type may name
+const (
+	CommodityGeneral = "general"
+	CommodityFood    = "food"
+	CommodityPharma  = "pharma"
+	CommodityHazmat  = "hazmat"
+)
+
+var commodityTypes = map[string]bool{
+	CommodityGeneral: true,
+	CommodityFood:    true,
+	CommodityPharma:  true,
+	CommodityHazmat:  true,
+}
+
+// validateCommodity checks commodity_type against is_hazmat: hazmat orders
+// are the hazmat commodity and no other order is
+func (o *OrderInput) validateCommodity() error {
+	if o.CommodityType == "" {
+		return nil
+	}
+	if !commodityTypes[o.CommodityType] {
+		return fmt.Errorf("invalid commodity_type: %s (must be general, food, pharma or hazmat)", o.CommodityType)
+	}
+	if o.CommodityType == CommodityHazmat && !o.IsHazmat {
+		return fmt.Errorf("commodity_type hazmat requires is_hazmat")
+	}
+	if o.IsHazmat && o.CommodityType != CommodityHazmat {
+		return fmt.Errorf("is_hazmat orders must have commodity_type hazmat")
+	}
+	return nil
+}
+
+// commodity is the order's commodity type, general or hazmat by is_hazmat
+// when it names none
+func (o *OrderInput) commodity() string {
+	switch {
+	case o.CommodityType != "":
+		return o.CommodityType
+	case o.IsHazmat:
+		return CommodityHazmat
+	}
+	return CommodityGeneral
+}
+
+// CommodityExclusion keeps hazmat orders off trucks carrying Commodity, for
+// freight such as food and pharma that must never ride with hazardous goods
+type CommodityExclusion struct {
+	Commodity string
+}
+
+func (c CommodityExclusion) Name() string { return "hazmat_" + c.Commodity }
+
+func (c CommodityExclusion) Allows(a, b Order) bool {
+	return !(a.IsHazmat && b.Commodity == c.Commodity || b.IsHazmat && a.Commodity == c.Commodity)
+}
+
+// TenantToggleableRules are the built-in rules a tenant may switch off: the
+// isolation of hazmat from all other freight, and its exclusion from food
+// and from pharma. Switching off isolation alone lets hazmat ride with
+// general freight while food and pharma stay apart from it.
+var TenantToggleableRules = []string{
+	HazmatMatch{}.Name(),
+	CommodityExclusion{Commodity: CommodityFood}.Name(),
+	CommodityExclusion{Commodity: CommodityPharma}.Name(),
+}
+
+// ValidateDisabledRules checks the rules a tenant switches off
+func ValidateDisabledRules(names []string) error {
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		toggleable := false
+		for _, rule := range TenantToggleableRules {
+			toggleable = toggleable || rule == name
+		}
+		if !toggleable {
+			return fmt.Errorf("rule %s cannot be disabled", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("rule %s is listed twice", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCommodityExclusion(t *testing.T) {
+	hazmat := Order{IsHazmat: true, Commodity: CommodityHazmat}
+	food := Order{Commodity: CommodityFood}
+	pharma := Order{Commodity: CommodityPharma}
+	general := Order{Commodity: CommodityGeneral}
+	
+	rule := CommodityExclusion{Commodity: CommodityFood}
+	if rule.Allows(hazmat, food) || rule.Allows(food, hazmat) {
+		t.Error("hazmat allowed with food")
+	}
+	if !rule.Allows(hazmat, pharma) || !rule.Allows(hazmat, general) || !rule.Allows(food, general) {
+		t.Error("food exclusion blocked an unrelated pair")
+	}
+	if (CommodityExclusion{Commodity: CommodityPharma}).Allows(pharma, hazmat) {
+		t.Error("hazmat allowed with pharma")
+	}
+}
+
+func TestCommodityValidation(t *testing.T) {
+	day := time.Now().Format("2006-01-02")
+	order := OrderInput{
+		ID: "a", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200, Origin: "LA", Destination: "Dallas",
+		PickupDate: day, DeliveryDate: day, CommodityType: CommodityFood,
+	}
+	if err := order.Validate(); err != nil {
+		t.Fatalf("food rejected: %v", err)
+	}
+	if got, _ := order.ToDomain(); got.Commodity != CommodityFood {
+		t.Errorf("commodity = %q, want food", got.Commodity)
+	}
+	
+	order.CommodityType = "produce"
+	if err := order.Validate(); err == nil {
+		t.Fatal("unknown commodity_type accepted")
+	}
+	
+	order.CommodityType = CommodityHazmat
+	if err := order.Validate(); err == nil {
+		t.Fatal("commodity_type hazmat accepted on a non-hazmat order")
+	}
+	
+	order.IsHazmat = true
+	order.CommodityType = CommodityPharma
+	if err := order.Validate(); err == nil {
+		t.Fatal("hazmat order accepted as pharma")
+	}
+	
+	order.CommodityType = ""
+	if got, _ := order.ToDomain(); got.Commodity != CommodityHazmat {
+		t.Errorf("hazmat order commodity = %q, want hazmat", got.Commodity)
+	}
+}
+
+func TestValidateDisabledRules(t *testing.T) {
+	if err := ValidateDisabledRules([]string{"hazmat", "hazmat_pharma"}); err != nil {
+		t.Fatalf("toggleable rules rejected: %v", err)
+	}
+	if err := ValidateDisabledRules([]string{"route"}); err == nil {
+		t.Error("route rule accepted")
+	}
+	if err := ValidateDisabledRules([]string{"hazmat_food", "hazmat_food"}); err == nil {
+		t.Error("duplicate rule accepted")
+	}
+}
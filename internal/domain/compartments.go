@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MaxCompartments is the most compartments a truck may have
+const MaxCompartments = 4
+
+// maxAssignmentNodes bounds the search for a compartment assignment; a plan
+// whose assignment is not found within it is turned down
+const maxAssignmentNodes = 100000
+
+// CompartmentInput is one compartment of a multi-compartment truck, such as
+// the reefer and dry sections of a split trailer or two temperature zones.
+// A reefer compartment holds one temperature zone: the orders in it must
+// share a temperature.
+type CompartmentInput struct {
+	ID            string `json:"id"`
+	MaxWeightLbs  int    `json:"max_weight_lbs"`
+	MaxVolumeCuft int    `json:"max_volume_cuft"`
+	// EquipmentType is "dry" or "reefer"; empty means dry
+	EquipmentType string `json:"equipment_type,omitempty"`
+}
+
+// Compartment is a section of the trailer with limits of its own
+type Compartment struct {
+	ID            string
+	MaxWeightLbs  int
+	MaxVolumeCuft int
+	// Reefer compartments hold temperature-controlled and dry freight, dry
+	// ones dry freight only
+	Reefer bool
+}
+
+// CompartmentLoad lists the orders a plan puts in one compartment
+type CompartmentLoad struct {
+	CompartmentID string   `json:"compartment_id"`
+	OrderIDs      []string `json:"order_ids"`
+	WeightLbs     int      `json:"weight_lbs"`
+	VolumeCuft    int      `json:"volume_cuft"`
+}
+
+func (t *TruckInput) validateCompartments() error {
+	if len(t.Compartments) == 0 {
+		return nil
+	}
+	if len(t.Compartments) > MaxCompartments {
+		return fmt.Errorf("at most %d compartments allowed", MaxCompartments)
+	}
+	if t.EquipmentType != "" {
+		return fmt.Errorf("equipment_type cannot be combined with compartments; set it on each compartment")
+	}
+	seen := make(map[string]bool, len(t.Compartments))
+	for i, compartment := range t.Compartments {
+		if compartment.ID == "" || len(compartment.ID) > 100 {
+			return fmt.Errorf("compartments[%d]: id is required and must be less than 100 characters", i)
+		}
+		if seen[compartment.ID] {
+			return fmt.Errorf("duplicate compartment id: %s", compartment.ID)
+		}
+		seen[compartment.ID] = true
+		if compartment.MaxWeightLbs <= 0 || compartment.MaxWeightLbs > t.MaxWeightLbs {
+			return fmt.Errorf("compartments[%d]: max_weight_lbs must be between 1 and the truck's max_weight_lbs", i)
+		}
+		if compartment.MaxVolumeCuft <= 0 || compartment.MaxVolumeCuft > t.MaxVolumeCuft {
+			return fmt.Errorf("compartments[%d]: max_volume_cuft must be between 1 and the truck's max_volume_cuft", i)
+		}
+		if err := validateEquipmentType(compartment.EquipmentType); err != nil {
+			return fmt.Errorf("compartments[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// compartments converts the truck's compartments, nil when it has none
+func (t *TruckInput) compartments() []Compartment {
+	if len(t.Compartments) == 0 {
+		return nil
+	}
+	compartments := make([]Compartment, len(t.Compartments))
+	for i, input := range t.Compartments {
+		compartments[i] = Compartment{
+			ID:            input.ID,
+			MaxWeightLbs:  input.MaxWeightLbs,
+			MaxVolumeCuft: input.MaxVolumeCuft,
+			Reefer:        input.EquipmentType == EquipmentReefer,
+		}
+	}
+	return compartments
+}
+
+// holds reports whether the compartment can take the order on its own
+func (c Compartment) holds(order Order) bool {
+	return order.WeightLbs <= c.MaxWeightLbs && order.VolumeCuft <= c.MaxVolumeCuft &&
+		(c.Reefer || !order.TemperatureControlled())
+}
+
+// unplaceable says why no compartment of the truck can take the order, or
+// returns "" when one can or the truck has no compartments
+func (t Truck) unplaceable(order Order) string {
+	if len(t.Compartments) == 0 {
+		return ""
+	}
+	for _, compartment := range t.Compartments {
+		if compartment.holds(order) {
+			return ""
+		}
+	}
+	return "fits no compartment of the truck"
+}
+
+// AssignCompartments puts each order in a compartment within its limits,
+// keeping temperature-controlled orders in reefer compartments whose orders
+// share a temperature. It reports false when no assignment was found. The
+// search is exact up to maxAssignmentNodes, largest orders first.
+func AssignCompartments(compartments []Compartment, orders []Order) ([]CompartmentLoad, bool) {
+	sorted := append([]Order(nil), orders...)
+	size := func(order Order) float64 {
+		return float64(order.WeightLbs) + float64(order.VolumeCuft)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return size(sorted[i]) > size(sorted[j])
+	})
+	
+	type zone struct {
+		weight, volume int
+		temperature    TemperatureRange
+	}
+	zones := make([]zone, len(compartments))
+	for i := range zones {
+		zones[i].temperature = TemperatureRange{MinF: math.Inf(-1), MaxF: math.Inf(1)}
+	}
+	placed := make([]int, len(sorted))
+	nodes := 0
+	
+	var place func(index int) bool
+	place = func(index int) bool {
+		if index == len(sorted) {
+			return true
+		}
+		nodes++
+		if nodes > maxAssignmentNodes {
+			return false
+		}
+		order := sorted[index]
+		for i, compartment := range compartments {
+			z := zones[i]
+			if !compartment.holds(order) || z.weight+order.WeightLbs > compartment.MaxWeightLbs ||
+				z.volume+order.VolumeCuft > compartment.MaxVolumeCuft {
+				continue
+			}
+			if order.TemperatureControlled() && !z.temperature.Overlaps(*order.Temperature) {
+				continue
+			}
+			zones[i].weight += order.WeightLbs
+			zones[i].volume += order.VolumeCuft
+			if order.TemperatureControlled() {
+				zones[i].temperature = TemperatureRange{
+					MinF: math.Max(z.temperature.MinF, order.Temperature.MinF),
+					MaxF: math.Min(z.temperature.MaxF, order.Temperature.MaxF),
+				}
+			}
+			placed[index] = i
+			if place(index + 1) {
+				return true
+			}
+			zones[i] = z
+		}
+		return false
+	}
+	if !place(0) {
+		return nil, false
+	}
+	
+	loads := make([]CompartmentLoad, len(compartments))
+	for i, compartment := range compartments {
+		loads[i] = CompartmentLoad{CompartmentID: compartment.ID, OrderIDs: []string{}}
+	}
+	for index, order := range sorted {
+		load := &loads[placed[index]]
+		load.OrderIDs = append(load.OrderIDs, order.ID)
+		load.WeightLbs += order.WeightLbs
+		load.VolumeCuft += order.VolumeCuft
+	}
+	for i := range loads {
+		sort.Strings(loads[i].OrderIDs)
+	}
+	return loads, true
+}
+
+// CompartmentFit keeps a plan assignable to the truck's compartments. The
+// assignment search gives up on very large plans, so the rule can turn
+// down a plan whose subset it accepted; the empty plan always passes, which
+// is all the set rule repair needs.
+type CompartmentFit struct {
+	Compartments []Compartment
+}
+
+func (CompartmentFit) Name() string { return "compartments" }
+
+func (f CompartmentFit) AllowsSet(orders []Order) bool {
+	_, ok := AssignCompartments(f.Compartments, orders)
+	return ok
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func tempRange(min, max float64) *TemperatureRange {
+	return &TemperatureRange{MinF: min, MaxF: max}
+}
+
+func TestAssignCompartments(t *testing.T) {
+	compartments := []Compartment{
+		{ID: "front", MaxWeightLbs: 10000, MaxVolumeCuft: 1000, Reefer: true},
+		{ID: "rear", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+	}
+	frozen := Order{ID: "frozen", WeightLbs: 4000, VolumeCuft: 300, Temperature: tempRange(-10, 0)}
+	chilled := Order{ID: "chilled", WeightLbs: 4000, VolumeCuft: 300, Temperature: tempRange(33, 38)}
+	// The two dry orders only fit if the larger goes with frozen freight
+	big := Order{ID: "big", WeightLbs: 6000, VolumeCuft: 600}
+	small := Order{ID: "small", WeightLbs: 9000, VolumeCuft: 300}
+	
+	loads, ok := AssignCompartments(compartments, []Order{frozen, big, small})
+	want := []CompartmentLoad{
+		{CompartmentID: "front", OrderIDs: []string{"big", "frozen"}, WeightLbs: 10000, VolumeCuft: 900},
+		{CompartmentID: "rear", OrderIDs: []string{"small"}, WeightLbs: 9000, VolumeCuft: 300},
+	}
+	if !ok || !reflect.DeepEqual(loads, want) {
+		t.Errorf("loads = %+v (%v), want %+v", loads, ok, want)
+	}
+	
+	if _, ok := AssignCompartments(compartments, []Order{frozen, chilled}); ok {
+		t.Error("frozen and chilled freight shared the only reefer compartment")
+	}
+	compartments[1].Reefer = true
+	if _, ok := AssignCompartments(compartments, []Order{frozen, chilled}); !ok {
+		t.Error("frozen and chilled freight did not get a zone each")
+	}
+	if loads, ok := AssignCompartments(compartments, nil); !ok || len(loads) != 2 || len(loads[0].OrderIDs) != 0 {
+		t.Errorf("empty plan = %+v (%v)", loads, ok)
+	}
+}
+
+func TestOrderFitsNoCompartment(t *testing.T) {
+	truck := Truck{
+		MaxWeightLbs: 20000, MaxVolumeCuft: 2000, EquipmentType: EquipmentReefer,
+		Compartments: []Compartment{
+			{ID: "reefer", MaxWeightLbs: 5000, MaxVolumeCuft: 500, Reefer: true},
+			{ID: "dry", MaxWeightLbs: 15000, MaxVolumeCuft: 1500},
+		},
+	}
+	if reason := truck.CannotCarry(Order{WeightLbs: 12000, VolumeCuft: 100}); reason != "" {
+		t.Errorf("dry freight turned down: %s", reason)
+	}
+	cold := Order{WeightLbs: 6000, VolumeCuft: 100, Temperature: tempRange(33, 38)}
+	if reason := truck.CannotCarry(cold); reason != "fits no compartment of the truck" {
+		t.Errorf("reason = %q", reason)
+	}
+}
+
+func TestValidateCompartments(t *testing.T) {
+	truck := TruckInput{MaxWeightLbs: 20000, MaxVolumeCuft: 2000, Compartments: []CompartmentInput{
+		{ID: "a", MaxWeightLbs: 10000, MaxVolumeCuft: 1000, EquipmentType: EquipmentReefer},
+		{ID: "b", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+	}}
+	if err := truck.validateCompartments(); err != nil {
+		t.Fatal(err)
+	}
+	
+	tests := []func(t *TruckInput){
+		func(t *TruckInput) { t.Compartments[1].ID = "a" },
+		func(t *TruckInput) { t.Compartments[0].MaxWeightLbs = 25000 },
+		func(t *TruckInput) { t.Compartments[0].EquipmentType = "flatbed" },
+		func(t *TruckInput) { t.EquipmentType = EquipmentReefer },
+		func(t *TruckInput) {
+			t.Compartments = append(t.Compartments, t.Compartments...)
+			t.Compartments = append(t.Compartments, t.Compartments[0])
+		},
+	}
+	for i, breakIt := range tests {
+		broken := truck
+		broken.Compartments = append([]CompartmentInput(nil), truck.Compartments...)
+		breakIt(&broken)
+		if err := broken.validateCompartments(); err == nil {
+			t.Errorf("case %d: accepted %+v", i, broken)
+		}
+	}
+}
@@ -6,66 +6,36 @@ type ConstraintChecker interface {
 	ValidateOrderSet(orders []Order) bool
 }
 
-type DefaultConstraintChecker struct{}
-
-func NewConstraintChecker() ConstraintChecker {
-	return &DefaultConstraintChecker{}
+// NewConstraintChecker returns a rule engine enforcing DefaultPairRules plus
+// every rule registered with RegisterPairRule or RegisterSetRule
+func NewConstraintChecker() *RuleEngine {
+	registry.RLock()
+	defer registry.RUnlock()
+	return NewRuleEngine(DefaultPairRules(), nil).With(registry.pairs, registry.sets)
 }
 
-func (d *DefaultConstraintChecker) CanCombine(order1, order2 Order) bool {
-	if order1.Route() != order2.Route() {
-		return false
-	}
-	
-	if order1.IsHazmat != order2.IsHazmat {
-		return false
-	}
-	
-	if !d.timeWindowsCompatible(order1, order2) {
-		return false
-	}
-	
-	return true
-}
-
-func (d *DefaultConstraintChecker) CanFit(truck Truck, currentWeight, currentVolume int, order Order) bool {
-	newWeight := currentWeight + order.WeightLbs
-	newVolume := currentVolume + order.VolumeCuft
-	
-	return newWeight <= truck.MaxWeightLbs && newVolume <= truck.MaxVolumeCuft
-}
-
-func (d *DefaultConstraintChecker) ValidateOrderSet(orders []Order) bool {
-	if len(orders) == 0 {
-		return true
-	}
-	
-	for i := 0; i < len(orders); i++ {
-		for j := i + 1; j < len(orders); j++ {
-			if !d.CanCombine(orders[i], orders[j]) {
-				return false
-			}
-		}
-	}
-	
-	return true
-}
-
-func (d *DefaultConstraintChecker) timeWindowsCompatible(order1, order2 Order) bool {
-	return true
+// FilterFeasibleOrders drops the orders the truck cannot carry at all
+func FilterFeasibleOrders(truck Truck, orders []Order) []Order {
+	feasible, _ := SplitFeasibleOrders(truck, orders)
+	return feasible
 }
 
-func FilterFeasibleOrders(truck Truck, orders []Order) []Order {
+// SplitFeasibleOrders separates the orders the truck can carry from those it
+// cannot, which are returned with the reason: too heavy or large, reefer
+// freight on a dry van, or missing equipment
+func SplitFeasibleOrders(truck Truck, orders []Order) ([]Order, []ExcludedOrder) {
 	feasible := make([]Order, 0, len(orders))
+	var infeasible []ExcludedOrder
 	
 	for _, order := range orders {
-		if order.WeightLbs > truck.MaxWeightLbs || order.VolumeCuft > truck.MaxVolumeCuft {
+		if reason := truck.CannotCarry(order); reason != "" {
+			infeasible = append(infeasible, ExcludedOrder{OrderID: order.ID, Reason: reason})
 			continue
 		}
 		feasible = append(feasible, order)
 	}
 	
-	return feasible
+	return feasible, infeasible
 }
 
 func GroupOrdersByRoute(orders []Order) map[string][]Order {
@@ -94,19 +64,8 @@ func SeparateHazmatOrders(orders []Order) (hazmat []Order, nonHazmat []Order) {
 	return
 }
 
-type StrictConstraintChecker struct {
-	DefaultConstraintChecker
-}
-
-func (s *StrictConstraintChecker) CanCombine(order1, order2 Order) bool {
-	if !s.DefaultConstraintChecker.CanCombine(order1, order2) {
-		return false
-	}
-	
-	daysDiff := order1.PickupDate.Sub(order2.PickupDate).Hours() / 24
-	if daysDiff < 0 {
-		daysDiff = -daysDiff
-	}
-	
-	return daysDiff <= 1
+// NewStrictConstraintChecker is NewConstraintChecker that also keeps
+// pickups on a truck within a day of each other
+func NewStrictConstraintChecker() *RuleEngine {
+	return NewConstraintChecker().With([]PairRule{PickupWithin{Days: 1}}, nil)
 }
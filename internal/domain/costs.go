@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// AverageSpeedMph converts lane miles into driving hours for hourly pay
+	AverageSpeedMph = 50
+	// StopDwellHours is the time billed per pickup or delivery stop
+	StopDwellHours = 1.0
+	// MaxFuelPriceCents caps the fuel price per gallon a request may give
+	MaxFuelPriceCents = 10000
+	// MaxMilesPerGallon caps the fuel economy a request may give
+	MaxMilesPerGallon = 50
+)
+
+// TollProvider estimates the toll cost of driving a lane. Implementations that
+// call out to other services must give up when ctx is done.
+type TollProvider interface {
+	EstimateToll(ctx context.Context, origin, destination string) (Money, error)
+}
+
+// NoTolls is the default provider when no toll source is configured
+type NoTolls struct{}
+
+func (NoTolls) EstimateToll(ctx context.Context, origin, destination string) (Money, error) {
+	return 0, nil
+}
+
+type DriverPayInput struct {
+	PerMileCents int64 `json:"per_mile_cents"`
+	PerStopCents int64 `json:"per_stop_cents"`
+	HourlyCents  int64 `json:"hourly_cents"`
+}
+
+type DriverPay struct {
+	PerMile Money
+	PerStop Money
+	Hourly  Money
+}
+
+func (d *DriverPayInput) Validate() error {
+	if d.PerMileCents < 0 || d.PerStopCents < 0 || d.HourlyCents < 0 {
+		return fmt.Errorf("driver pay rates cannot be negative")
+	}
+	if d.PerMileCents > 100000 || d.PerStopCents > 1000000 || d.HourlyCents > 1000000 {
+		return fmt.Errorf("driver pay rates exceed maximum allowed value")
+	}
+	return nil
+}
+
+func (d *DriverPayInput) ToDomain() DriverPay {
+	if d == nil {
+		return DriverPay{}
+	}
+	return DriverPay{
+		PerMile: Money(d.PerMileCents),
+		PerStop: Money(d.PerStopCents),
+		Hourly:  Money(d.HourlyCents),
+	}
+}
+
+// FuelInput prices the fuel a truck burns: price_cents_per_gallon at
+// miles_per_gallon
+type FuelInput struct {
+	PriceCentsPerGallon int64   `json:"price_cents_per_gallon"`
+	MilesPerGallon      float64 `json:"miles_per_gallon"`
+}
+
+type Fuel struct {
+	PricePerGallon Money
+	MilesPerGallon float64
+}
+
+func (f *FuelInput) Validate() error {
+	if f.PriceCentsPerGallon < 0 || f.PriceCentsPerGallon > MaxFuelPriceCents {
+		return fmt.Errorf("fuel price_cents_per_gallon must be between 0 and %d", MaxFuelPriceCents)
+	}
+	if !(f.MilesPerGallon >= 1 && f.MilesPerGallon <= MaxMilesPerGallon) {
+		return fmt.Errorf("fuel miles_per_gallon must be between 1 and %d", MaxMilesPerGallon)
+	}
+	return nil
+}
+
+func (f *FuelInput) ToDomain() Fuel {
+	if f == nil {
+		return Fuel{}
+	}
+	return Fuel{
+		PricePerGallon: Money(f.PriceCentsPerGallon),
+		MilesPerGallon: f.MilesPerGallon,
+	}
+}
+
+// Cost prices the fuel for driving miles, rounded to the cent under rounding
+func (f Fuel) Cost(miles int, rounding RoundingMode) Money {
+	if f.MilesPerGallon == 0 {
+		return 0
+	}
+	return rounding.RoundCents(float64(f.PricePerGallon) * float64(miles) / f.MilesPerGallon)
+}
+
+// CostBreakdown itemizes the estimated operating cost of running a plan
+type CostBreakdown struct {
+	FixedCents  int64 `json:"fixed_cents"`
+	DriverCents int64 `json:"driver_cents"`
+	TollCents   int64 `json:"toll_cents"`
+	// MileageCents is the truck's cost per mile times the plan's miles, for
+	// wear, maintenance and the like
+	MileageCents int64 `json:"mileage_cents,omitempty"`
+	FuelCents    int64 `json:"fuel_cents,omitempty"`
+	// StopFeeCents is the truck's stop fee times ConsolidationStops
+	StopFeeCents int64 `json:"stop_fee_cents,omitempty"`
+	TotalCents   int64 `json:"total_cents"`
+	// TollsUnavailable lists lanes whose toll could not be estimated; their
+	// tolls are missing from TollCents, so the total is understated
+	TollsUnavailable []string `json:"tolls_unavailable,omitempty"`
+}
+
+func (c CostBreakdown) Total() Money {
+	return Money(c.TotalCents)
+}
+
+func (c CostBreakdown) NetProfit(payout Money) Money {
+	return payout - c.Total()
+}
+
+// IsWorthDispatching reports whether a load's payout exceeds the cost of
+// running the truck. A fleet-level optimizer would leave trucks failing this
+// check parked; for a single truck it drives the "hold" recommendation.
+func (c CostBreakdown) IsWorthDispatching(payout Money) bool {
+	return payout > c.Total()
+}
+
+// PlanMiles is the lane distance driven for a set of orders
+func PlanMiles(orders []Order) int {
+	miles := 0
+	for _, order := range orders {
+		if order.Miles > miles {
+			miles = order.Miles
+		}
+	}
+	return miles
+}
+
+// PlanStops counts the distinct pickup and delivery locations of a plan
+func PlanStops(orders []Order) int {
+	origins := make(map[string]bool)
+	destinations := make(map[string]bool)
+	for _, order := range orders {
+		origins[order.Origin] = true
+		destinations[order.Destination] = true
+	}
+	return len(origins) + len(destinations)
+}
+
+// ConsolidationStops counts the stops a plan makes beyond one pickup and one
+// delivery. Requests carry no shipper or consignee addresses, so every order
+// is picked up and delivered at its own stop.
+func ConsolidationStops(orders []Order) int {
+	if len(orders) == 0 {
+		return 0
+	}
+	return 2 * (len(orders) - 1)
+}
+
+// EstimatePlanCost prices a plan for the given truck, including the tolls for
+// the lanes it drives and, per mile, the truck's running cost and fuel. An empty plan is never dispatched and therefore costs nothing.
+// Hourly pay for part of an hour is rounded to the cent under rounding.
+func EstimatePlanCost(truck Truck, orders []Order, tolls Money, rounding RoundingMode) CostBreakdown {
+	if len(orders) == 0 {
+		return CostBreakdown{}
+	}
+	
+	miles := PlanMiles(orders)
+	stops := PlanStops(orders)
+	hours := float64(miles)/AverageSpeedMph + float64(stops)*StopDwellHours
+	
+	driver := truck.DriverPay.PerMile*Money(miles) +
+		truck.DriverPay.PerStop*Money(stops) +
+		rounding.RoundCents(float64(truck.DriverPay.Hourly)*hours)
+	
+	breakdown := CostBreakdown{
+		FixedCents:   int64(truck.FixedCost),
+		DriverCents:  int64(driver),
+		TollCents:    int64(tolls),
+		MileageCents: int64(truck.CostPerMile) * int64(miles),
+		FuelCents:    int64(truck.Fuel.Cost(miles, rounding)),
+		StopFeeCents: int64(truck.StopFee) * int64(ConsolidationStops(orders)),
+	}
+	breakdown.TotalCents = breakdown.FixedCents + breakdown.DriverCents + breakdown.TollCents +
+		breakdown.MileageCents + breakdown.FuelCents + breakdown.StopFeeCents
+	return breakdown
+}
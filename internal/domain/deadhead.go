@@ -0,0 +1,67 @@
+package domain
+
+import "math"
+
+// MaxDeadheadWeight caps deadhead_weight, in cents per empty mile
+const MaxDeadheadWeight = 1000
+
+// Deadhead is the empty driving around a plan: from the truck's position to
+// the first pickup, and from the last delivery to where the truck must be
+// next. Legs without a point at both ends count 0 miles.
+type Deadhead struct {
+	ToFirstPickupMiles    int `json:"to_first_pickup_miles"`
+	FromLastDeliveryMiles int `json:"from_last_delivery_miles"`
+	TotalMiles            int `json:"total_miles"`
+}
+
+// deadheadLegs estimates both deadhead legs of a plan driven in the order
+// SequenceStops gives
+func deadheadLegs(truck Truck, orders []Order) (float64, float64) {
+	stops := SequenceStops(truck.Position, orders)
+	if len(stops) == 0 {
+		return 0, 0
+	}
+	to, from := 0.0, 0.0
+	if first := stops[0].Point; truck.Position != nil && first != nil {
+		to = legMiles(*truck.Position, *first)
+	}
+	if last := stops[len(stops)-1].Point; truck.NextPosition != nil && last != nil {
+		from = legMiles(*last, *truck.NextPosition)
+	}
+	return to, from
+}
+
+// DeadheadMiles estimates a plan's deadhead miles; an empty plan drives none
+func DeadheadMiles(truck Truck, orders []Order) float64 {
+	to, from := deadheadLegs(truck, orders)
+	return to + from
+}
+
+// PlanDeadhead reports a plan's deadhead, or nil when the truck gives neither
+// its position nor its next position
+func PlanDeadhead(truck Truck, orders []Order) *Deadhead {
+	if truck.Position == nil && truck.NextPosition == nil {
+		return nil
+	}
+	to, from := deadheadLegs(truck, orders)
+	deadhead := &Deadhead{
+		ToFirstPickupMiles:    int(math.Round(to)),
+		FromLastDeliveryMiles: int(math.Round(from)),
+	}
+	deadhead.TotalMiles = deadhead.ToFirstPickupMiles + deadhead.FromLastDeliveryMiles
+	return deadhead
+}
+
+// DeadheadCharge is what a plan's deadhead costs in score at weight cents per
+// mile
+func DeadheadCharge(truck Truck, orders []Order, weight float64) Score {
+	return Score(math.Round(DeadheadMiles(truck, orders) * weight * ScoreScale))
+}
+
+// Deadhead returns the deadhead weight, 0 when no config is given
+func (c *OptimizationConfig) Deadhead() float64 {
+	if c == nil {
+		return 0
+	}
+	return c.DeadheadWeight
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestPlanDeadhead(t *testing.T) {
+	order := Order{ID: "a", Origin: "Phoenix", Destination: "El Paso", OriginPoint: phoenix, DestinationPoint: elPaso}
+	if PlanDeadhead(Truck{}, []Order{order}) != nil {
+		t.Error("deadhead reported without positions")
+	}
+	
+	deadhead := PlanDeadhead(Truck{Position: losAngeles, NextPosition: dallas}, []Order{order})
+	if deadhead.ToFirstPickupMiles < 400 || deadhead.ToFirstPickupMiles > 500 {
+		t.Errorf("Los Angeles to Phoenix %d miles", deadhead.ToFirstPickupMiles)
+	}
+	if deadhead.FromLastDeliveryMiles < 650 || deadhead.FromLastDeliveryMiles > 750 {
+		t.Errorf("El Paso to Dallas %d miles", deadhead.FromLastDeliveryMiles)
+	}
+	if deadhead.TotalMiles != deadhead.ToFirstPickupMiles+deadhead.FromLastDeliveryMiles {
+		t.Errorf("total %d miles", deadhead.TotalMiles)
+	}
+	
+	if empty := PlanDeadhead(Truck{Position: losAngeles}, nil); *empty != (Deadhead{}) {
+		t.Errorf("empty plan deadheads %+v", empty)
+	}
+	unplaced := order
+	unplaced.OriginPoint = nil
+	if miles := DeadheadMiles(Truck{Position: losAngeles}, []Order{unplaced}); miles != 0 {
+		t.Errorf("pickup without a point deadheads %.0f miles", miles)
+	}
+}
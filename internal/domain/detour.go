@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MaxDetourPercent bounds multi_stop.max_detour_percent
+const MaxDetourPercent = 1000
+
+func (m *MultiStopInput) validateDetour() error {
+	if math.IsNaN(m.MaxDetourMiles) || m.MaxDetourMiles < 0 || m.MaxDetourMiles > 10000 {
+		return fmt.Errorf("multi_stop: max_detour_miles must be between 0 and 10000")
+	}
+	if math.IsNaN(m.MaxDetourPercent) || m.MaxDetourPercent < 0 || m.MaxDetourPercent > MaxDetourPercent {
+		return fmt.Errorf("multi_stop: max_detour_percent must be between 0 and %d", MaxDetourPercent)
+	}
+	return nil
+}
+
+// routeStop is a pickup or delivery on a multi-stop route
+type routeStop struct {
+	at      time.Time
+	pickup  bool
+	orderID string
+	point   LatLng
+}
+
+// routeStops lays out the geocoded orders' pickups and deliveries in the
+// order their windows open, pickups first on a tie, then by order ID.
+// Orders that are not geocoded cannot be placed and are left off.
+func routeStops(orders []Order) []routeStop {
+	var stops []routeStop
+	for _, order := range orders {
+		if !order.geocoded() {
+			continue
+		}
+		stops = append(stops,
+			routeStop{at: order.pickupStart(), pickup: true, orderID: order.ID, point: *order.OriginPoint},
+			routeStop{at: order.deliveryStart(), orderID: order.ID, point: *order.DestinationPoint})
+	}
+	sort.SliceStable(stops, func(i, j int) bool {
+		a, b := stops[i], stops[j]
+		if !a.at.Equal(b.at) {
+			return a.at.Before(b.at)
+		}
+		if a.pickup != b.pickup {
+			return a.pickup
+		}
+		return a.orderID < b.orderID
+	})
+	return stops
+}
+
+// DetourLimit caps how far out of its way sharing the truck takes each
+// order: the estimated road miles the truck covers between the order's
+// pickup and its delivery, beyond the order's own lane. The route visits the
+// stops in the order their windows open. MaxMiles and MaxPercent, of the
+// order's lane, apply when set. Dropping an order only takes stops off the
+// route, which never lengthens it, so the rule is monotone. Orders that are
+// not geocoded cannot be measured and are not held to it.
+type DetourLimit struct {
+	MaxMiles   float64
+	MaxPercent float64
+}
+
+func (DetourLimit) Name() string { return "max_detour" }
+
+func (l DetourLimit) AllowsSet(orders []Order) bool {
+	stops := routeStops(orders)
+	if len(stops) <= 2 {
+		return true
+	}
+	pickedUp := make(map[string]int)
+	riding := make(map[string]float64)
+	for i, stop := range stops {
+		if i > 0 {
+			leg := greatCircleMiles(stops[i-1].point, stop.point) * RoadCircuity
+			for id := range pickedUp {
+				riding[id] += leg
+			}
+		}
+		if stop.pickup {
+			pickedUp[stop.orderID] = i
+			riding[stop.orderID] = 0
+			continue
+		}
+		
+		start := stops[pickedUp[stop.orderID]].point
+		direct := greatCircleMiles(start, stop.point) * RoadCircuity
+		detour := riding[stop.orderID] - direct
+		if l.MaxMiles > 0 && detour > l.MaxMiles {
+			return false
+		}
+		if l.MaxPercent > 0 && direct > 0 && detour/direct*100 > l.MaxPercent {
+			return false
+		}
+		delete(pickedUp, stop.orderID)
+	}
+	return true
+}
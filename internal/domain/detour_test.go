@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDetourLimit(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	long := Order{ID: "long", OriginPoint: losAngeles, DestinationPoint: dallas, PickupDate: day(1), DeliveryDate: day(4)}
+	onRoute := Order{ID: "on-route", OriginPoint: phoenix, DestinationPoint: elPaso, PickupDate: day(2), DeliveryDate: day(3)}
+	offRoute := Order{ID: "off-route", OriginPoint: phoenix, DestinationPoint: denver, PickupDate: day(2), DeliveryDate: day(3)}
+	notGeocoded := Order{ID: "not-geocoded", PickupDate: day(2), DeliveryDate: day(3)}
+	
+	limit := DetourLimit{MaxMiles: 100}
+	if !limit.AllowsSet([]Order{long, onRoute}) {
+		t.Error("order along the route rejected")
+	}
+	if limit.AllowsSet([]Order{long, offRoute}) {
+		t.Error("order through Denver allowed")
+	}
+	if !limit.AllowsSet([]Order{long, notGeocoded}) {
+		t.Error("order without points held to the limit")
+	}
+	
+	percent := DetourLimit{MaxPercent: 10}
+	if !percent.AllowsSet([]Order{long, onRoute}) || percent.AllowsSet([]Order{long, offRoute}) {
+		t.Error("percent limit disagrees with the miles limit")
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "fmt"
+
+// MaxDimFactor bounds dim_factor, in cubic inches per pound
+const MaxDimFactor = 1000
+
+func validateDimFactor(dimFactor int) error {
+	if dimFactor < 0 || dimFactor > MaxDimFactor {
+		return fmt.Errorf("dim_factor must be between 0 and %d", MaxDimFactor)
+	}
+	return nil
+}
+
+// chargeableWeightLbs is the weight an order takes of the truck's capacity:
+// the larger of what it weighs and its dimensional weight, its cube divided
+// by dimFactor and rounded up as carriers bill it. The cube comes from the
+// order's dimensions, or its volume_cuft when it gave none. A dimFactor of 0
+// charges the actual weight.
+func chargeableWeightLbs(weightLbs, volumeCuft int, dims *Dimensions, dimFactor int) int {
+	if dimFactor <= 0 {
+		return weightLbs
+	}
+	cubicInches := int64(volumeCuft) * 1728
+	if dims != nil {
+		cubicInches = int64(dims.LengthIn) * int64(dims.WidthIn) * int64(dims.HeightIn)
+	}
+	dimWeight := int((cubicInches + int64(dimFactor) - 1) / int64(dimFactor))
+	return max(weightLbs, dimWeight)
+}
+
+// chargeDimWeight makes the order's WeightLbs its chargeable weight, keeping
+// what it weighs in ScaleWeightLbs when the two differ
+func (o *Order) chargeDimWeight(dimFactor int) {
+	chargeable := chargeableWeightLbs(o.WeightLbs, o.VolumeCuft, o.Dimensions, dimFactor)
+	if chargeable > o.WeightLbs {
+		o.ScaleWeightLbs = o.WeightLbs
+		o.WeightLbs = chargeable
+	}
+}
+
+// ScaleWeight is what the order actually weighs, for physics such as axle
+// loads, where its dimensional weight does not count
+func (o Order) ScaleWeight() int {
+	if o.ScaleWeightLbs > 0 {
+		return o.ScaleWeightLbs
+	}
+	return o.WeightLbs
+}
+
+// TotalScaleWeight sums what the orders actually weigh
+func TotalScaleWeight(orders []Order) int {
+	total := 0
+	for _, order := range orders {
+		total += order.ScaleWeight()
+	}
+	return total
+}
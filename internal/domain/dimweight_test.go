@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestChargeableWeight(t *testing.T) {
+	tests := []struct {
+		name       string
+		weight     int
+		volume     int
+		dims       *Dimensions
+		dimFactor  int
+		wantWeight int
+	}{
+		{"no dim factor", 100, 50, nil, 0, 100},
+		{"heavy freight", 5000, 50, nil, 139, 5000},
+		{"light freight by volume", 100, 50, nil, 139, 622},
+		{"light freight by dimensions", 100, 50, &Dimensions{LengthIn: 48, WidthIn: 40, HeightIn: 50}, 139, 691},
+	}
+	for _, tt := range tests {
+		if got := chargeableWeightLbs(tt.weight, tt.volume, tt.dims, tt.dimFactor); got != tt.wantWeight {
+			t.Errorf("%s: chargeable weight = %d, want %d", tt.name, got, tt.wantWeight)
+		}
+	}
+}
+
+func TestDimWeightKeepsScaleWeight(t *testing.T) {
+	order := Order{WeightLbs: 100, VolumeCuft: 50}
+	order.chargeDimWeight(139)
+	if order.WeightLbs != 622 || order.ScaleWeight() != 100 {
+		t.Errorf("charged %d lbs with scale weight %d, want 622 and 100", order.WeightLbs, order.ScaleWeight())
+	}
+	
+	dense := Order{WeightLbs: 5000, VolumeCuft: 50}
+	dense.chargeDimWeight(139)
+	if dense.WeightLbs != 5000 || dense.ScaleWeightLbs != 0 {
+		t.Errorf("dense order charged %d lbs with scale weight %d", dense.WeightLbs, dense.ScaleWeightLbs)
+	}
+}
+
+func TestDimFactorValidation(t *testing.T) {
+	for _, dimFactor := range []int{-1, MaxDimFactor + 1} {
+		if err := validateDimFactor(dimFactor); err == nil {
+			t.Errorf("dim_factor %d accepted", dimFactor)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "context"
+
+// Waypoint is a location a truck drives to: its name and, when the geocoder
+// found it, its point
+type Waypoint struct {
+	Location string
+	Point    *LatLng
+}
+
+// DistanceProvider measures the driving distance between two waypoints. ok
+// is false when it cannot tell, such as a lane a static matrix does not list
+// or a waypoint a routing engine needs coordinates for but has none.
+// Implementations that call external services must honor ctx.
+type DistanceProvider interface {
+	DrivingMiles(ctx context.Context, from, to Waypoint) (miles float64, ok bool, err error)
+}
+
+// NoDistances measures nothing; orders keep the miles they were given
+type NoDistances struct{}
+
+func (NoDistances) DrivingMiles(ctx context.Context, from, to Waypoint) (float64, bool, error) {
+	return 0, false, nil
+}
+
+// RoadCircuity is how much longer road miles run than the straight line
+// between two points, on average across US freight lanes
+const RoadCircuity = 1.2
+
+// HaversineDistances estimates driving distance from the great-circle
+// distance between two points, stretched by RoadCircuity. It is the default
+// when no routing source is configured, and measures only waypoints that have
+// a point, given with the order or found by the geocoder.
+type HaversineDistances struct{}
+
+func (HaversineDistances) DrivingMiles(ctx context.Context, from, to Waypoint) (float64, bool, error) {
+	if from.Point == nil || to.Point == nil {
+		return 0, false, nil
+	}
+	return greatCircleMiles(*from.Point, *to.Point) * RoadCircuity, true, nil
+}
+
+// OriginWaypoint and DestinationWaypoint are the ends of the order's lane
+func (o Order) OriginWaypoint() Waypoint {
+	return Waypoint{Location: o.Origin, Point: o.OriginPoint}
+}
+
+func (o Order) DestinationWaypoint() Waypoint {
+	return Waypoint{Location: o.Destination, Point: o.DestinationPoint}
+}
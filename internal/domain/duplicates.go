@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Duplicate policies for orders that look like the same load entered twice
+const (
+	// DuplicateFlagOnly keeps every order and warns about each duplicate;
+	// it is the default
+	DuplicateFlagOnly = "flag_only"
+	// DuplicateKeepHighestPayout keeps the best paid order of each group and
+	// excludes the others
+	DuplicateKeepHighestPayout = "keep_highest_payout"
+	// DuplicateKeepAll treats every order as distinct without a warning
+	DuplicateKeepAll = "keep_all"
+)
+
+func validateDuplicatePolicy(policy string) error {
+	switch policy {
+	case "", DuplicateFlagOnly, DuplicateKeepHighestPayout, DuplicateKeepAll:
+		return nil
+	}
+	return fmt.Errorf("invalid duplicate_policy: %s (must be flag_only, keep_highest_payout, or keep_all)", policy)
+}
+
+// duplicateKey is what two orders share when they are likely the same load
+// under different IDs: the route, the weight and the dates
+type duplicateKey struct {
+	origin, destination string
+	weightLbs           int
+	pickup, delivery    time.Time
+}
+
+func (o Order) duplicateKey() duplicateKey {
+	return duplicateKey{
+		origin:      NormalizeAddress(o.Origin),
+		destination: NormalizeAddress(o.Destination),
+		weightLbs:   o.WeightLbs,
+		pickup:      o.PickupDate,
+		delivery:    o.DeliveryDate,
+	}
+}
+
+// DuplicateGroups groups the orders that look like duplicates of each other,
+// each group in order of appearance; orders without a duplicate are left out
+func DuplicateGroups(orders []Order) [][]Order {
+	index := make(map[duplicateKey]int)
+	var groups [][]Order
+	for _, order := range orders {
+		key := order.duplicateKey()
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], order)
+	}
+	return slices.DeleteFunc(groups, func(group []Order) bool {
+		return len(group) < 2
+	})
+}
+
+// SplitDuplicates applies the request's duplicate_policy to the orders. It
+// returns the orders to plan with, those excluded as duplicates with the
+// reason, and a warning for each group of duplicates saying what was done.
+// keep_highest_payout keeps the best paid order of a group, the first listed
+// on a tie; a must_include order is always kept, and takes the place of the
+// best paid one.
+func (r *OptimizeRequest) SplitDuplicates(orders []Order) ([]Order, []ExcludedOrder, []ValidationWarning) {
+	if r.DuplicatePolicy == DuplicateKeepAll {
+		return orders, nil, nil
+	}
+	groups := DuplicateGroups(orders)
+	if len(groups) == 0 {
+		return orders, nil, nil
+	}
+	
+	fields := make(map[string]string, len(r.Orders))
+	for i, order := range r.Orders {
+		fields[order.ID] = fmt.Sprintf("orders[%d]", i)
+	}
+	dropped := make(map[string]bool)
+	var excluded []ExcludedOrder
+	warnings := make([]ValidationWarning, 0, len(groups))
+	for _, group := range groups {
+		ids := make([]string, len(group))
+		for i, order := range group {
+			ids[i] = order.ID
+		}
+		description := fmt.Sprintf("orders %s look like duplicates: same route %s, weight and dates", strings.Join(ids, ", "), group[0].Route())
+		
+		if r.DuplicatePolicy != DuplicateKeepHighestPayout {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "possible_duplicate",
+				Field:   fields[group[0].ID],
+				Message: description + "; all are kept",
+			})
+			continue
+		}
+		
+		kept := r.keptDuplicates(group)
+		for _, order := range group {
+			if slices.Contains(kept, order.ID) {
+				continue
+			}
+			dropped[order.ID] = true
+			excluded = append(excluded, ExcludedOrder{
+				OrderID: order.ID,
+				Reason:  "likely duplicate of " + kept[0],
+			})
+		}
+		warnings = append(warnings, ValidationWarning{
+			Code:    "duplicate_excluded",
+			Field:   fields[group[0].ID],
+			Message: fmt.Sprintf("%s; kept %s", description, strings.Join(kept, ", ")),
+		})
+	}
+	if len(dropped) == 0 {
+		return orders, excluded, warnings
+	}
+	
+	remaining := make([]Order, 0, len(orders)-len(dropped))
+	for _, order := range orders {
+		if !dropped[order.ID] {
+			remaining = append(remaining, order)
+		}
+	}
+	return remaining, excluded, warnings
+}
+
+// keptDuplicates are the IDs of the group's orders keep_highest_payout keeps
+func (r *OptimizeRequest) keptDuplicates(group []Order) []string {
+	var kept []string
+	for _, order := range group {
+		if slices.Contains(r.MustIncludeOrderIDs, order.ID) {
+			kept = append(kept, order.ID)
+		}
+	}
+	if len(kept) > 0 {
+		return kept
+	}
+	
+	best := group[0]
+	for _, order := range group[1:] {
+		if order.Payout > best.Payout {
+			best = order
+		}
+	}
+	return []string{best.ID}
+}
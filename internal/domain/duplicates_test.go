@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func duplicateOrders() []Order {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	order := func(id string, payout Money, origin string, weight int) Order {
+		return Order{ID: id, Payout: payout, Origin: origin, Destination: "Dallas, TX", WeightLbs: weight, PickupDate: day(1), DeliveryDate: day(3)}
+	}
+	return []Order{
+		order("a", 1000, "Los Angeles, CA", 500),
+		order("b", 3000, "los angeles, ca", 500),
+		order("c", 3000, "Los Angeles, CA", 500),
+		order("d", 9000, "Los Angeles, CA", 600),
+	}
+}
+
+func orderIDs(orders []Order) []string {
+	ids := make([]string, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	return ids
+}
+
+func TestDuplicateGroups(t *testing.T) {
+	groups := DuplicateGroups(duplicateOrders())
+	if len(groups) != 1 || !slices.Equal(orderIDs(groups[0]), []string{"a", "b", "c"}) {
+		t.Errorf("groups = %v, want [[a b c]]", groups)
+	}
+}
+
+func TestSplitDuplicates(t *testing.T) {
+	tests := []struct {
+		policy       string
+		include      []string
+		wantKept     []string
+		wantExcluded []string
+		wantCode     string
+	}{
+		{"", nil, []string{"a", "b", "c", "d"}, nil, "possible_duplicate"},
+		{DuplicateFlagOnly, nil, []string{"a", "b", "c", "d"}, nil, "possible_duplicate"},
+		{DuplicateKeepAll, nil, []string{"a", "b", "c", "d"}, nil, ""},
+		// b and c tie on payout; the first listed wins
+		{DuplicateKeepHighestPayout, nil, []string{"b", "d"}, []string{"a", "c"}, "duplicate_excluded"},
+		{DuplicateKeepHighestPayout, []string{"a"}, []string{"a", "d"}, []string{"b", "c"}, "duplicate_excluded"},
+	}
+	for _, tt := range tests {
+		request := OptimizeRequest{DuplicatePolicy: tt.policy, MustIncludeOrderIDs: tt.include}
+		kept, excluded, warnings := request.SplitDuplicates(duplicateOrders())
+		if !slices.Equal(orderIDs(kept), tt.wantKept) {
+			t.Errorf("%q: kept %v, want %v", tt.policy, orderIDs(kept), tt.wantKept)
+		}
+		var excludedIDs []string
+		for _, e := range excluded {
+			excludedIDs = append(excludedIDs, e.OrderID)
+		}
+		if !slices.Equal(excludedIDs, tt.wantExcluded) {
+			t.Errorf("%q: excluded %v, want %v", tt.policy, excluded, tt.wantExcluded)
+		}
+		if tt.wantCode == "" {
+			if len(warnings) != 0 {
+				t.Errorf("%q: warnings = %+v, want none", tt.policy, warnings)
+			}
+		} else if len(warnings) != 1 || warnings[0].Code != tt.wantCode {
+			t.Errorf("%q: warnings = %+v, want one %s", tt.policy, warnings, tt.wantCode)
+		}
+	}
+}
+
+func TestDuplicatePolicyValidation(t *testing.T) {
+	if err := validateDuplicatePolicy("keep_newest"); err == nil {
+		t.Error("unknown duplicate_policy accepted")
+	}
+	if err := validateDuplicatePolicy(DuplicateKeepHighestPayout); err != nil {
+		t.Errorf("keep_highest_payout rejected: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import "math"
+
+const (
+	// DieselKgCO2PerGallon is the CO2 released by burning a gallon of diesel
+	DieselKgCO2PerGallon = 10.21
+	// EmptyMilesPerGallon is the fuel economy of a tractor-trailer running
+	// empty
+	EmptyMilesPerGallon = 7.5
+	// GallonsPerTonMile is the fuel each ton of freight adds per mile
+	GallonsPerTonMile = 0.0035
+	// MaxEmissionsWeight caps emissions_weight, in cents per kilogram of CO2
+	MaxEmissionsWeight = 100
+)
+
+// EmissionsKg estimates the CO2 the order's freight is responsible for: the
+// extra fuel its actual weight burns over its lane miles. Orders without miles
+// emit nothing.
+func (o Order) EmissionsKg() float64 {
+	tons := float64(o.ScaleWeight()) / 2000
+	return float64(o.Miles) * tons * GallonsPerTonMile * DieselKgCO2PerGallon
+}
+
+// PlanEmissionsKg estimates the CO2 of running a plan: the truck itself over
+// PlanMiles plus each order's freight. An empty plan is not driven.
+func PlanEmissionsKg(orders []Order) float64 {
+	if len(orders) == 0 {
+		return 0
+	}
+	kg := float64(PlanMiles(orders)) / EmptyMilesPerGallon * DieselKgCO2PerGallon
+	for _, order := range orders {
+		kg += order.EmissionsKg()
+	}
+	return kg
+}
+
+// ChargeEmissions lowers each order's score by what its emissions cost at
+// weight cents per kilogram, so plans trade payout for lower CO2. The truck's
+// own emissions are the same for every plan that drives and do not change
+// which orders win.
+func ChargeEmissions(orders []Order, weight float64) []Order {
+	if weight == 0 {
+		return orders
+	}
+	charged := make([]Order, len(orders))
+	for i, order := range orders {
+		charged[i] = order
+		charged[i].Score = order.Score.Sub(Score(math.Round(order.EmissionsKg() * weight * ScoreScale)))
+	}
+	return charged
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlanEmissionsKg(t *testing.T) {
+	order := Order{ID: "a", WeightLbs: 40000, Miles: 1000, Score: ScoreFromMoney(100000)}
+	// 20 tons over 1000 miles burn 70 extra gallons
+	if got := order.EmissionsKg(); math.Abs(got-714.7) > 0.01 {
+		t.Errorf("order emissions %.2f kg, want 714.70", got)
+	}
+	// The truck burns 133.3 gallons over the same miles
+	if got := PlanEmissionsKg([]Order{order}); math.Abs(got-2076.03) > 0.01 {
+		t.Errorf("plan emissions %.2f kg, want 2076.03", got)
+	}
+	if PlanEmissionsKg(nil) != 0 {
+		t.Error("empty plan emits")
+	}
+	
+	dimensional := order
+	dimensional.WeightLbs, dimensional.ScaleWeightLbs = 50000, 40000
+	if dimensional.EmissionsKg() != order.EmissionsKg() {
+		t.Error("emissions counted on dimensional weight")
+	}
+	
+	charged := ChargeEmissions([]Order{order}, 10)
+	if charged[0].Score.Money() != 100000-7147 || order.Score.Money() != 100000 {
+		t.Errorf("charged score %d, want %d without changing the input", charged[0].Score.Money(), 100000-7147)
+	}
+}
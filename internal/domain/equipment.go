@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Equipment a truck can carry and an order can require
+const (
+	EquipmentLiftgate = "liftgate"
+	EquipmentStraps   = "straps"
+	EquipmentETrack   = "e_track"
+	// EquipmentHazmatEndorsement is a driver with a hazmat endorsement
+	EquipmentHazmatEndorsement = "hazmat_endorsement"
+)
+
+// EquipmentItems lists every value of equipment and equipment_requirements
+var EquipmentItems = []string{EquipmentLiftgate, EquipmentStraps, EquipmentETrack, EquipmentHazmatEndorsement}
+
+// Carries reports whether the truck's equipment can hold the order: reefer
+// freight needs a reefer, every equipment requirement needs that equipment
+// freight with dimensions must fit inside the trailer and, on a truck with
+// compartments, some compartment must hold it
+func (t Truck) Carries(order Order) bool {
+	return t.lacks(order) == "" && t.tooLarge(order) == "" && t.unplaceable(order) == ""
+}
+
+// CannotCarry says why the truck cannot carry the order at all, or returns
+// "" when it can
+func (t Truck) CannotCarry(order Order) string {
+	if order.WeightLbs > t.MaxWeightLbs && order.ScaleWeightLbs > 0 {
+		return fmt.Sprintf("has a dimensional weight of %d lbs, more than the truck's %d", order.WeightLbs, t.MaxWeightLbs)
+	}
+	if order.WeightLbs > t.MaxWeightLbs {
+		return fmt.Sprintf("weighs %d lbs, more than the truck's %d", order.WeightLbs, t.MaxWeightLbs)
+	}
+	if order.VolumeCuft > t.MaxVolumeCuft {
+		return fmt.Sprintf("takes %d cuft, more than the truck's %d", order.VolumeCuft, t.MaxVolumeCuft)
+	}
+	if t.MaxLinearFeet > 0 && order.LinearFeet > t.MaxLinearFeet {
+		return fmt.Sprintf("takes %d linear feet, more than the truck's %d", order.LinearFeet, t.MaxLinearFeet)
+	}
+	if positions := order.FloorSpace().PalletPositions(); t.MaxPalletPositions > 0 && positions > t.MaxPalletPositions {
+		return fmt.Sprintf("takes %d pallet positions, more than the truck's %d", positions, t.MaxPalletPositions)
+	}
+	if reason := t.tooLarge(order); reason != "" {
+		return reason
+	}
+	if reason := t.lacks(order); reason != "" {
+		return reason
+	}
+	return t.unplaceable(order)
+}
+
+// lacks names the equipment the truck is missing for the order, or returns ""
+func (t Truck) lacks(order Order) string {
+	if order.TemperatureControlled() && t.EquipmentType != EquipmentReefer {
+		return "reefer freight on a dry van"
+	}
+	for _, item := range order.EquipmentRequirements {
+		if !slices.Contains(t.Equipment, item) {
+			return "truck has no " + item
+		}
+	}
+	return ""
+}
+
+// validateEquipment checks that field lists only known equipment, once each
+func validateEquipment(field string, items []string) error {
+	for i, item := range items {
+		if !slices.Contains(EquipmentItems, item) {
+			return fmt.Errorf("invalid %s: %s (must be liftgate, straps, e_track, or hazmat_endorsement)", field, item)
+		}
+		if slices.Contains(items[:i], item) {
+			return fmt.Errorf("%s lists %s twice", field, item)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "testing"
+
+func TestSplitFeasibleOrders(t *testing.T) {
+	truck := Truck{MaxWeightLbs: 10000, MaxVolumeCuft: 1000, EquipmentType: EquipmentDry, Equipment: []string{EquipmentStraps}}
+	orders := []Order{
+		{ID: "fits", WeightLbs: 1000, VolumeCuft: 100, EquipmentRequirements: []string{EquipmentStraps}},
+		{ID: "heavy", WeightLbs: 12000, VolumeCuft: 100},
+		{ID: "bulky", WeightLbs: 1000, VolumeCuft: 1200},
+		{ID: "cold", WeightLbs: 1000, VolumeCuft: 100, Temperature: &TemperatureRange{MinF: 33, MaxF: 38}},
+		{ID: "liftgate", WeightLbs: 1000, VolumeCuft: 100, EquipmentRequirements: []string{EquipmentStraps, EquipmentLiftgate}},
+	}
+	
+	feasible, infeasible := SplitFeasibleOrders(truck, orders)
+	if len(feasible) != 1 || feasible[0].ID != "fits" {
+		t.Fatalf("feasible = %v, want only fits", feasible)
+	}
+	want := []ExcludedOrder{
+		{OrderID: "heavy", Reason: "weighs 12000 lbs, more than the truck's 10000"},
+		{OrderID: "bulky", Reason: "takes 1200 cuft, more than the truck's 1000"},
+		{OrderID: "cold", Reason: "reefer freight on a dry van"},
+		{OrderID: "liftgate", Reason: "truck has no liftgate"},
+	}
+	if len(infeasible) != len(want) {
+		t.Fatalf("infeasible = %v, want %v", infeasible, want)
+	}
+	for i := range want {
+		if infeasible[i] != want[i] {
+			t.Errorf("infeasible[%d] = %+v, want %+v", i, infeasible[i], want[i])
+		}
+	}
+	if NewConstraintChecker().CanFit(truck, 0, 0, orders[4]) {
+		t.Error("CanFit accepted an order needing a liftgate")
+	}
+}
+
+func TestEquipmentValidation(t *testing.T) {
+	if err := validateEquipment("equipment", []string{EquipmentLiftgate, EquipmentETrack}); err != nil {
+		t.Fatalf("valid equipment rejected: %v", err)
+	}
+	if validateEquipment("equipment", []string{"forklift"}) == nil {
+		t.Fatal("unknown equipment accepted")
+	}
+	if validateEquipment("equipment", []string{EquipmentStraps, EquipmentStraps}) == nil {
+		t.Fatal("duplicate equipment accepted")
+	}
+}
+
+func TestOrdersLongerThanTheFloor(t *testing.T) {
+	truck := Truck{MaxWeightLbs: 44000, MaxVolumeCuft: 3000, MaxLinearFeet: 28}
+	order := Order{ID: "long", WeightLbs: 1000, VolumeCuft: 100, LinearFeet: 30}
+	if reason := truck.CannotCarry(order); reason != "takes 30 linear feet, more than the truck's 28" {
+		t.Errorf("reason = %q", reason)
+	}
+	
+	truck.MaxLinearFeet = 0
+	if reason := truck.CannotCarry(order); reason != "" {
+		t.Errorf("without a floor limit reason = %q, want none", reason)
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Excursion risk limits
+const (
+	// MaxExposureHours caps max_exposure_hours
+	MaxExposureHours = 240
+	// MaxLaneRisks caps lane_risks per request
+	MaxLaneRisks = 100
+	// MaxLaneRiskFactor caps risk_factor
+	MaxLaneRiskFactor = 5
+	// MaxExcursionWeight caps excursion_weight, in cents per hour
+	MaxExcursionWeight = 1000000
+)
+
+// LaneRiskInput is how much a lane adds to the risk of a temperature
+// excursion, from an ambient forecast or seasonal data: each driving hour on
+// it counts RiskFactor hours against the safe exposure of the orders on
+// board. Lanes without an entry count 1.
+type LaneRiskInput struct {
+	Origin      string  `json:"origin"`
+	Destination string  `json:"destination"`
+	RiskFactor  float64 `json:"risk_factor"`
+}
+
+func (r *OptimizeRequest) validateLaneRisks() error {
+	if len(r.LaneRisks) > MaxLaneRisks {
+		return fmt.Errorf("at most %d lane_risks allowed", MaxLaneRisks)
+	}
+	seen := make(map[string]bool, len(r.LaneRisks))
+	for i, lane := range r.LaneRisks {
+		if lane.Origin == "" || lane.Destination == "" {
+			return fmt.Errorf("lane_risks[%d]: origin and destination are required", i)
+		}
+		if !(lane.RiskFactor >= 1 && lane.RiskFactor <= MaxLaneRiskFactor) {
+			return fmt.Errorf("lane_risks[%d]: risk_factor must be between 1 and %d", i, MaxLaneRiskFactor)
+		}
+		key := laneKey(lane.Origin, lane.Destination)
+		if seen[key] {
+			return fmt.Errorf("lane_risks[%d]: lane %s to %s is listed twice", i, lane.Origin, lane.Destination)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// applyLaneRisks sets each order's ExposureRisk from the lane it drives
+func (r *OptimizeRequest) applyLaneRisks(orders []Order) {
+	if len(r.LaneRisks) == 0 {
+		return
+	}
+	risks := make(map[string]float64, len(r.LaneRisks))
+	for _, lane := range r.LaneRisks {
+		risks[laneKey(lane.Origin, lane.Destination)] = lane.RiskFactor
+	}
+	for i := range orders {
+		if risk, ok := risks[laneKey(orders[i].Origin, orders[i].Destination)]; ok {
+			orders[i].ExposureRisk = risk
+		}
+	}
+}
+
+func laneKey(origin, destination string) string {
+	return normalizeLocation(origin) + "->" + normalizeLocation(destination)
+}
+
+// validateExposure checks max_exposure_hours, which only temperature
+// controlled orders may set
+func (o *OrderInput) validateExposure() error {
+	if o.MaxExposureHours == 0 {
+		return nil
+	}
+	if !(o.MaxExposureHours > 0 && o.MaxExposureHours <= MaxExposureHours) {
+		return fmt.Errorf("max_exposure_hours must be between 0 and %d", MaxExposureHours)
+	}
+	if o.TemperatureMinF == nil && o.TemperatureMaxF == nil {
+		return fmt.Errorf("max_exposure_hours requires temperature_min_f or temperature_max_f")
+	}
+	return nil
+}
+
+// Excursion compares how long a plan keeps its freight on the road with the
+// safe exposure of its most sensitive order
+type Excursion struct {
+	ExposureHours     float64 `json:"exposure_hours"`
+	SafeExposureHours float64 `json:"safe_exposure_hours"`
+	ExcessHours       float64 `json:"excess_hours"`
+}
+
+// PlanExposureHours estimates a plan's time on the road as for hourly pay,
+// its driving hours counted at the highest risk factor of its lanes
+func PlanExposureHours(orders []Order) float64 {
+	if len(orders) == 0 {
+		return 0
+	}
+	risk := 1.0
+	for _, order := range orders {
+		risk = math.Max(risk, order.ExposureRisk)
+	}
+	return float64(PlanMiles(orders))/AverageSpeedMph*risk + float64(PlanStops(orders))*StopDwellHours
+}
+
+// PlanExcursion reports a plan's exposure against the shortest
+// max_exposure_hours on board, or nil when no order on board sets one
+func PlanExcursion(orders []Order) *Excursion {
+	safe := math.Inf(1)
+	for _, order := range orders {
+		if order.MaxExposureHours > 0 {
+			safe = math.Min(safe, order.MaxExposureHours)
+		}
+	}
+	if math.IsInf(safe, 1) {
+		return nil
+	}
+	exposure := PlanExposureHours(orders)
+	return &Excursion{
+		ExposureHours:     exposure,
+		SafeExposureHours: safe,
+		ExcessHours:       math.Max(0, exposure-safe),
+	}
+}
+
+// ExcursionCharge is what a plan's exposure beyond the safe exposure of its
+// most sensitive order costs in score at weight cents per hour
+func ExcursionCharge(orders []Order, weight float64) Score {
+	excursion := PlanExcursion(orders)
+	if excursion == nil {
+		return 0
+	}
+	return Score(math.Round(excursion.ExcessHours * weight * ScoreScale))
+}
+
+// Excursion returns the excursion weight, 0 when no config is given
+func (c *OptimizationConfig) Excursion() float64 {
+	if c == nil {
+		return 0
+	}
+	return c.ExcursionWeight
+}
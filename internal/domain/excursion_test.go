@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPlanExcursion(t *testing.T) {
+	produce := Order{ID: "produce", Origin: "Phoenix", Destination: "Dallas", Miles: 1000, MaxExposureHours: 24}
+	dry := Order{ID: "dry", Origin: "Phoenix", Destination: "Dallas", Miles: 1000}
+	if PlanExcursion([]Order{dry}) != nil {
+		t.Error("excursion reported without a safe exposure")
+	}
+	
+	// 20 hours driving and a pickup and a delivery stop
+	excursion := PlanExcursion([]Order{produce, dry})
+	if excursion.ExposureHours != 22 || excursion.SafeExposureHours != 24 || excursion.ExcessHours != 0 {
+		t.Errorf("excursion %+v, want 22 of 24 hours", excursion)
+	}
+	
+	request := OptimizeRequest{LaneRisks: []LaneRiskInput{{Origin: "phoenix ", Destination: "DALLAS", RiskFactor: 1.5}}}
+	orders := []Order{produce, dry}
+	request.applyLaneRisks(orders)
+	excursion = PlanExcursion(orders)
+	if excursion.ExposureHours != 32 || excursion.ExcessHours != 8 {
+		t.Errorf("excursion %+v on a 1.5 risk lane, want 32 hours, 8 over", excursion)
+	}
+	if charge := ExcursionCharge(orders, 100); charge != ScoreFromMoney(800) {
+		t.Errorf("charge %d, want 8 hours at 100 cents", charge)
+	}
+	if hours := PlanExposureHours(nil); hours != 0 || math.IsNaN(hours) {
+		t.Errorf("empty plan exposed %v hours", hours)
+	}
+}
+
+func TestExposureValidation(t *testing.T) {
+	day := time.Now().Format("2006-01-02")
+	cold := 38.0
+	order := OrderInput{
+		ID: "a", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200, Origin: "LA", Destination: "Dallas",
+		PickupDate: day, DeliveryDate: day, TemperatureMaxF: &cold, MaxExposureHours: 36,
+	}
+	if err := order.Validate(); err != nil {
+		t.Fatalf("36 hours rejected: %v", err)
+	}
+	
+	order.MaxExposureHours = MaxExposureHours + 1
+	if err := order.Validate(); err == nil {
+		t.Fatal("exposure over the cap accepted")
+	}
+	
+	order.MaxExposureHours = 36
+	order.TemperatureMaxF = nil
+	if err := order.Validate(); err == nil {
+		t.Fatal("max_exposure_hours accepted on dry freight")
+	}
+}
+
+func TestLaneRiskValidation(t *testing.T) {
+	lane := LaneRiskInput{Origin: "Phoenix", Destination: "Dallas", RiskFactor: 2}
+	request := OptimizeRequest{LaneRisks: []LaneRiskInput{lane}}
+	if err := request.validateLaneRisks(); err != nil {
+		t.Fatalf("lane rejected: %v", err)
+	}
+	
+	request.LaneRisks = []LaneRiskInput{lane, {Origin: "PHOENIX", Destination: "dallas", RiskFactor: 3}}
+	if err := request.validateLaneRisks(); err == nil {
+		t.Error("lane listed twice accepted")
+	}
+	request.LaneRisks = []LaneRiskInput{{Origin: "Phoenix", Destination: "Dallas", RiskFactor: 0.5}}
+	if err := request.validateLaneRisks(); err == nil {
+		t.Error("risk_factor below 1 accepted")
+	}
+}
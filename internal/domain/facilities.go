@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Dock door types a facility can offer
+const (
+	DoorDockHigh = "dock_high"
+	// DoorGroundLevel doors sit at ground height; only a truck with a
+	// liftgate can load at one
+	DoorGroundLevel = "ground_level"
+	DoorDriveIn     = "drive_in"
+)
+
+// DoorTypes lists every value of dock_door_types
+var DoorTypes = []string{DoorDockHigh, DoorGroundLevel, DoorDriveIn}
+
+// Facility limits, per request and per facility
+const (
+	MaxFacilities       = 50
+	MaxFacilityWindows  = 50
+	MaxTrucksPerWindow  = 1000
+	maxFacilityLocation = 200
+)
+
+// FacilityInput describes an origin facility the request's orders are
+// picked up at, matched to orders by their origin. DockDoorTypes are the
+// doors it has; none listed puts no restriction on trucks. Windows cap how
+// many truck visits it can take between two dates.
+type FacilityInput struct {
+	Location      string                `json:"location"`
+	DockDoorTypes []string              `json:"dock_door_types,omitempty"`
+	Windows       []FacilityWindowInput `json:"windows,omitempty"`
+}
+
+// FacilityWindowInput lets the facility load at most MaxTrucks trucks from
+// From through To, both inclusive dates
+type FacilityWindowInput struct {
+	From      string `json:"from"`
+	To        string `json:"to"`
+	MaxTrucks int    `json:"max_trucks"`
+}
+
+type facilityWindow struct {
+	from, to  time.Time
+	maxTrucks int
+}
+
+func (f FacilityInput) validate() error {
+	if f.Location == "" {
+		return fmt.Errorf("location is required")
+	}
+	if len(f.Location) > maxFacilityLocation {
+		return fmt.Errorf("location exceeds %d characters", maxFacilityLocation)
+	}
+	for i, door := range f.DockDoorTypes {
+		if !slices.Contains(DoorTypes, door) {
+			return fmt.Errorf("invalid dock_door_types: %s (must be dock_high, ground_level, or drive_in)", door)
+		}
+		if slices.Contains(f.DockDoorTypes[:i], door) {
+			return fmt.Errorf("dock_door_types lists %s twice", door)
+		}
+	}
+	if len(f.Windows) > MaxFacilityWindows {
+		return fmt.Errorf("at most %d windows allowed", MaxFacilityWindows)
+	}
+	for i, window := range f.Windows {
+		if _, err := window.parse(); err != nil {
+			return fmt.Errorf("windows[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (w FacilityWindowInput) parse() (facilityWindow, error) {
+	from, err := time.Parse("2006-01-02", w.From)
+	if err != nil {
+		return facilityWindow{}, fmt.Errorf("invalid from date format (expected YYYY-MM-DD)")
+	}
+	to, err := time.Parse("2006-01-02", w.To)
+	if err != nil {
+		return facilityWindow{}, fmt.Errorf("invalid to date format (expected YYYY-MM-DD)")
+	}
+	if to.Before(from) {
+		return facilityWindow{}, fmt.Errorf("to cannot be before from")
+	}
+	if w.MaxTrucks < 0 || w.MaxTrucks > MaxTrucksPerWindow {
+		return facilityWindow{}, fmt.Errorf("max_trucks must be between 0 and %d", MaxTrucksPerWindow)
+	}
+	return facilityWindow{from: from, to: to, maxTrucks: w.MaxTrucks}, nil
+}
+
+// docks reports whether the truck can load at one of the facility's doors
+func (f FacilityInput) docks(truck Truck) bool {
+	if len(f.DockDoorTypes) == 0 || slices.Contains(f.DockDoorTypes, DoorDockHigh) || slices.Contains(f.DockDoorTypes, DoorDriveIn) {
+		return true
+	}
+	return slices.Contains(truck.Equipment, EquipmentLiftgate)
+}
+
+func (r *OptimizeRequest) validateFacilities() error {
+	if len(r.Facilities) > MaxFacilities {
+		return fmt.Errorf("at most %d facilities allowed", MaxFacilities)
+	}
+	seen := make(map[string]bool, len(r.Facilities))
+	for i, facility := range r.Facilities {
+		if err := facility.validate(); err != nil {
+			return fmt.Errorf("facilities[%d]: %w", i, err)
+		}
+		location := normalizeLocation(facility.Location)
+		if seen[location] {
+			return fmt.Errorf("duplicate facility: %s", facility.Location)
+		}
+		seen[location] = true
+	}
+	return nil
+}
+
+// SplitDockableOrders separates the orders picked up at a facility the truck
+// can load at from those it cannot, which are returned with the reason
+func (r *OptimizeRequest) SplitDockableOrders(truck Truck, orders []Order) ([]Order, []ExcludedOrder) {
+	undockable := make(map[string]bool)
+	for _, facility := range r.Facilities {
+		if !facility.docks(truck) {
+			undockable[normalizeLocation(facility.Location)] = true
+		}
+	}
+	if len(undockable) == 0 {
+		return orders, nil
+	}
+	
+	dockable := make([]Order, 0, len(orders))
+	var excluded []ExcludedOrder
+	for _, order := range orders {
+		if undockable[normalizeLocation(order.Origin)] {
+			excluded = append(excluded, ExcludedOrder{
+				OrderID: order.ID,
+				Reason:  "origin has only ground-level doors and the truck has no liftgate",
+			})
+			continue
+		}
+		dockable = append(dockable, order)
+	}
+	return dockable, excluded
+}
+
+// FacilitySchedule is the schedule feasibility check for origin facilities:
+// a truck visits a facility once for each distinct pickup date it loads
+// there, so within each of the facility's windows the selection may pick up
+// on at most max_trucks distinct dates. Orders picked up the same day share
+// one visit.
+type FacilitySchedule struct {
+	windows map[string][]facilityWindow
+}
+
+// FacilitySchedule builds the schedule check for the request's facilities,
+// or returns nil when none of them has a window
+func (r *OptimizeRequest) FacilitySchedule() *FacilitySchedule {
+	windows := make(map[string][]facilityWindow)
+	for _, facility := range r.Facilities {
+		for _, input := range facility.Windows {
+			window, err := input.parse()
+			if err != nil {
+				continue
+			}
+			location := normalizeLocation(facility.Location)
+			windows[location] = append(windows[location], window)
+		}
+	}
+	if len(windows) == 0 {
+		return nil
+	}
+	return &FacilitySchedule{windows: windows}
+}
+
+func (*FacilitySchedule) Name() string { return "facility_schedule" }
+
+func (f *FacilitySchedule) AllowsSet(orders []Order) bool {
+	visits := make(map[string]map[time.Time]bool)
+	for _, order := range orders {
+		location := normalizeLocation(order.Origin)
+		if _, ok := f.windows[location]; !ok {
+			continue
+		}
+		if visits[location] == nil {
+			visits[location] = make(map[time.Time]bool)
+		}
+		visits[location][order.PickupDate] = true
+	}
+	
+	for location, dates := range visits {
+		for _, window := range f.windows[location] {
+			trucks := 0
+			for date := range dates {
+				if !date.Before(window.from) && !date.After(window.to) {
+					trucks++
+				}
+			}
+			if trucks > window.maxTrucks {
+				return false
+			}
+		}
+	}
+	return true
+}
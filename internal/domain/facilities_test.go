@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFacilityScheduleCountsVisitsPerWindow(t *testing.T) {
+	request := OptimizeRequest{Facilities: []FacilityInput{{
+		Location: "Los Angeles, CA",
+		Windows:  []FacilityWindowInput{{From: "2030-01-01", To: "2030-01-02", MaxTrucks: 1}},
+	}}}
+	schedule := request.FacilitySchedule()
+	if schedule == nil {
+		t.Fatal("no schedule for a facility with a window")
+	}
+	
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	order := func(id, origin string, pickup time.Time) Order {
+		return Order{ID: id, Origin: origin, PickupDate: pickup}
+	}
+	tests := []struct {
+		name   string
+		orders []Order
+		want   bool
+	}{
+		{"one visit", []Order{order("a", "Los Angeles, CA", day(1)), order("b", "los angeles, ca ", day(1))}, true},
+		{"two visits in the window", []Order{order("a", "Los Angeles, CA", day(1)), order("b", "Los Angeles, CA", day(2))}, false},
+		{"second visit after the window", []Order{order("a", "Los Angeles, CA", day(1)), order("b", "Los Angeles, CA", day(3))}, true},
+		{"other facility", []Order{order("a", "Los Angeles, CA", day(1)), order("b", "Phoenix, AZ", day(2))}, true},
+	}
+	for _, tt := range tests {
+		if got := schedule.AllowsSet(tt.orders); got != tt.want {
+			t.Errorf("%s: AllowsSet = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	
+	request.Facilities[0].Windows[0].MaxTrucks = 0
+	if request.FacilitySchedule().AllowsSet([]Order{order("a", "Los Angeles, CA", day(2))}) {
+		t.Error("a closed window allowed a pickup")
+	}
+	
+	request.Facilities[0].Windows = nil
+	if request.FacilitySchedule() != nil {
+		t.Error("schedule built for a facility without windows")
+	}
+}
+
+func TestSplitDockableOrders(t *testing.T) {
+	request := OptimizeRequest{Facilities: []FacilityInput{
+		{Location: "Los Angeles, CA", DockDoorTypes: []string{DoorGroundLevel}},
+		{Location: "Phoenix, AZ", DockDoorTypes: []string{DoorGroundLevel, DoorDockHigh}},
+	}}
+	orders := []Order{{ID: "la", Origin: "Los Angeles, CA"}, {ID: "phx", Origin: "Phoenix, AZ"}, {ID: "sf", Origin: "San Francisco, CA"}}
+	
+	dockable, excluded := request.SplitDockableOrders(Truck{}, orders)
+	if len(dockable) != 2 || dockable[0].ID != "phx" || dockable[1].ID != "sf" {
+		t.Fatalf("dockable = %v, want phx and sf", dockable)
+	}
+	if len(excluded) != 1 || excluded[0].OrderID != "la" {
+		t.Fatalf("excluded = %v, want la", excluded)
+	}
+	
+	dockable, excluded = request.SplitDockableOrders(Truck{Equipment: []string{EquipmentLiftgate}}, orders)
+	if len(dockable) != 3 || excluded != nil {
+		t.Fatalf("with a liftgate dockable = %v excluded = %v, want every order", dockable, excluded)
+	}
+}
+
+func TestFacilityValidation(t *testing.T) {
+	valid := FacilityInput{
+		Location:      "Los Angeles, CA",
+		DockDoorTypes: []string{DoorDockHigh},
+		Windows:       []FacilityWindowInput{{From: "2030-01-01", To: "2030-01-01", MaxTrucks: 2}},
+	}
+	if err := valid.validate(); err != nil {
+		t.Fatalf("valid facility rejected: %v", err)
+	}
+	
+	tests := []struct {
+		name   string
+		modify func(f *FacilityInput)
+		want   string
+	}{
+		{"no location", func(f *FacilityInput) { f.Location = "" }, "location is required"},
+		{"unknown door", func(f *FacilityInput) { f.DockDoorTypes = []string{"loading_bay"} }, "invalid dock_door_types"},
+		{"repeated door", func(f *FacilityInput) { f.DockDoorTypes = []string{DoorDriveIn, DoorDriveIn} }, "twice"},
+		{"bad date", func(f *FacilityInput) { f.Windows[0].From = "Jan 1" }, "invalid from date"},
+		{"reversed window", func(f *FacilityInput) { f.Windows[0].From = "2030-01-02" }, "to cannot be before from"},
+		{"negative trucks", func(f *FacilityInput) { f.Windows[0].MaxTrucks = -1 }, "max_trucks"},
+	}
+	for _, tt := range tests {
+		facility := valid
+		facility.Windows = append([]FacilityWindowInput(nil), valid.Windows...)
+		tt.modify(&facility)
+		err := facility.validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+	
+	request := OptimizeRequest{Facilities: []FacilityInput{valid, {Location: " los angeles, ca"}}}
+	if err := request.validateFacilities(); err == nil || !strings.Contains(err.Error(), "duplicate facility") {
+		t.Errorf("err = %v, want a duplicate facility", err)
+	}
+}
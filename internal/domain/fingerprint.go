@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// Fingerprint is a canonical SHA-256 hash of everything in a validated request
+// that decides its plan: truck capacity and costs, orders, objective,
+// thresholds, k, pins, rules and currency. Orders and order ID lists are
+// sorted first, so the same problem sent with orders in another sequence gets
+// the same fingerprint. The truck ID, tenant and API key are left out.
+//
+// Requests with sealed payouts get no fingerprint: a plain hash over their
+// payouts could be brute-forced to recover them.
+func (r *OptimizeRequest) Fingerprint() string {
+	if r.PayoutsSealed() {
+		return ""
+	}
+	
+	canonical := *r
+	canonical.Truck.ID = ""
+	canonical.Orders = append([]OrderInput(nil), r.Orders...)
+	sort.Slice(canonical.Orders, func(i, j int) bool {
+		return canonical.Orders[i].ID < canonical.Orders[j].ID
+	})
+	canonical.MustIncludeOrderIDs = sortedCopy(r.MustIncludeOrderIDs)
+	canonical.MustExcludeOrderIDs = sortedCopy(r.MustExcludeOrderIDs)
+	canonical.PreviousOrderIDs = sortedCopy(r.PreviousOrderIDs)
+	canonical.Rules = append([]RuleInput(nil), r.Rules...)
+	for i := range canonical.Rules {
+		canonical.Rules[i].OrderIDs = sortedCopy(r.Rules[i].OrderIDs)
+	}
+	
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		// Every field is a plain value; this cannot fail
+		panic("fingerprint: " + err.Error())
+	}
+	digest := sha256.Sum256(data)
+	return hex.EncodeToString(digest[:])
+}
+
+func sortedCopy(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,60 @@
+package domain
+
+import "testing"
+
+func fingerprintRequest() OptimizeRequest {
+	return OptimizeRequest{
+		Truck: TruckInput{ID: "truck-1", MaxWeightLbs: 44000, MaxVolumeCuft: 3000},
+		Orders: []OrderInput{
+			{ID: "a", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200, Origin: "LA", Destination: "Dallas"},
+			{ID: "b", PayoutCents: 180000, WeightLbs: 12000, VolumeCuft: 900, Origin: "LA", Destination: "Dallas"},
+		},
+		MustExcludeOrderIDs: []string{"b", "a"},
+		Currency:            "USD",
+	}
+}
+
+func TestFingerprintIgnoresPresentation(t *testing.T) {
+	base := fingerprintRequest()
+	want := base.Fingerprint()
+	if len(want) != 64 {
+		t.Fatalf("fingerprint %q is not a hex SHA-256", want)
+	}
+	
+	reordered := fingerprintRequest()
+	reordered.Orders[0], reordered.Orders[1] = reordered.Orders[1], reordered.Orders[0]
+	reordered.MustExcludeOrderIDs = []string{"a", "b"}
+	reordered.Truck.ID = "truck-2"
+	reordered.TenantID = "acme"
+	if got := reordered.Fingerprint(); got != want {
+		t.Errorf("reordered request fingerprint %s, want %s", got, want)
+	}
+	if base.Orders[0].ID != "a" {
+		t.Error("Fingerprint reordered the request's orders")
+	}
+}
+
+func TestFingerprintChangesWithProblem(t *testing.T) {
+	base := fingerprintRequest()
+	want := base.Fingerprint()
+	
+	changed := fingerprintRequest()
+	changed.Orders[1].PayoutCents++
+	if changed.Fingerprint() == want {
+		t.Error("payout change kept the fingerprint")
+	}
+	
+	changed = fingerprintRequest()
+	changed.Truck.MaxWeightLbs--
+	if changed.Fingerprint() == want {
+		t.Error("capacity change kept the fingerprint")
+	}
+}
+
+func TestFingerprintSkipsSealedPayouts(t *testing.T) {
+	sealed := fingerprintRequest()
+	sealed.Orders[0].PayoutEncrypted = "v1.x.y"
+	if got := sealed.Fingerprint(); got != "" {
+		t.Errorf("sealed request fingerprint = %q, want none", got)
+	}
+}
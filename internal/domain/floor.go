@@ -0,0 +1,88 @@
+package domain
+
+// MaxLinearFeet bounds linear_feet and max_linear_feet; the longest trailers
+// in common use are 53 feet
+const MaxLinearFeet = 100
+
+// Pallet limits
+const (
+	MaxPalletCount     = 100
+	MaxPalletPositions = 100
+	// PalletStackHeight is how many stackable pallets share one position
+	PalletStackHeight = 2
+)
+
+// FloorSpace is the trailer floor an order or plan takes, measured beyond
+// weight and volume. StackSlots counts pallets in stack slots, PalletStackHeight
+// to a position: a stackable pallet takes one slot and any other pallet a
+// whole position. Stackable pallets of different orders share positions, so
+// a plan needs its non-stackable pallets plus its stackable ones divided by
+// PalletStackHeight, rounded up, in positions.
+type FloorSpace struct {
+	LinearFeet int
+	StackSlots int
+}
+
+// FloorSpace is the floor the order takes
+func (o Order) FloorSpace() FloorSpace {
+	slots := o.PalletCount * PalletStackHeight
+	if o.Stackable {
+		slots = o.PalletCount
+	}
+	return FloorSpace{LinearFeet: o.LinearFeet, StackSlots: slots}
+}
+
+// Add returns the floor taken with the order added
+func (f FloorSpace) Add(order Order) FloorSpace {
+	space := order.FloorSpace()
+	return FloorSpace{LinearFeet: f.LinearFeet + space.LinearFeet, StackSlots: f.StackSlots + space.StackSlots}
+}
+
+// PalletPositions is the number of pallet positions the floor space fills
+func (f FloorSpace) PalletPositions() int {
+	return (f.StackSlots + PalletStackHeight - 1) / PalletStackHeight
+}
+
+// TotalFloorSpace is the floor the orders take together
+func TotalFloorSpace(orders []Order) FloorSpace {
+	var space FloorSpace
+	for _, order := range orders {
+		space = space.Add(order)
+	}
+	return space
+}
+
+// FitsFloor reports whether the order fits on the trailer floor next to used,
+// within max_linear_feet and max_pallet_positions; limits the truck does not
+// set always fit
+func (t Truck) FitsFloor(used FloorSpace, order Order) bool {
+	return fitsFloor(used.Add(order), t.MaxLinearFeet, t.MaxPalletPositions)
+}
+
+// FloorLimit keeps a plan within the trailer's linear feet and pallet
+// positions. DP and greedy track floor space as a capacity of their own; for
+// the other algorithms this set rule repairs plans that run over.
+type FloorLimit struct {
+	MaxLinearFeet      int
+	MaxPalletPositions int
+}
+
+func (FloorLimit) Name() string { return "floor_space" }
+
+func (l FloorLimit) AllowsSet(orders []Order) bool {
+	return fitsFloor(TotalFloorSpace(orders), l.MaxLinearFeet, l.MaxPalletPositions)
+}
+
+func fitsFloor(space FloorSpace, maxLinearFeet, maxPalletPositions int) bool {
+	return (maxLinearFeet == 0 || space.LinearFeet <= maxLinearFeet) &&
+		(maxPalletPositions == 0 || space.StackSlots <= maxPalletPositions*PalletStackHeight)
+}
+
+// TotalPallets counts the orders' pallets, stacked or not
+func TotalPallets(orders []Order) int {
+	pallets := 0
+	for _, order := range orders {
+		pallets += order.PalletCount
+	}
+	return pallets
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestStackablePalletsSharePositions(t *testing.T) {
+	tests := []struct {
+		name   string
+		orders []Order
+		want   int
+	}{
+		{"nothing", nil, 0},
+		{"floor pallets", []Order{{PalletCount: 3}}, 3},
+		{"stacked pallets", []Order{{PalletCount: 3, Stackable: true}}, 2},
+		{"stacked across orders", []Order{{PalletCount: 3, Stackable: true}, {PalletCount: 1, Stackable: true}}, 2},
+		{"mixed", []Order{{PalletCount: 3, Stackable: true}, {PalletCount: 2}}, 4},
+	}
+	for _, tt := range tests {
+		if got := TotalFloorSpace(tt.orders).PalletPositions(); got != tt.want {
+			t.Errorf("%s: %d positions, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFitsFloor(t *testing.T) {
+	truck := Truck{MaxLinearFeet: 20, MaxPalletPositions: 4}
+	used := TotalFloorSpace([]Order{{LinearFeet: 10, PalletCount: 3, Stackable: true}})
+	
+	if !truck.FitsFloor(used, Order{LinearFeet: 10, PalletCount: 5, Stackable: true}) {
+		t.Error("8 stackable pallets on 4 positions did not fit")
+	}
+	if truck.FitsFloor(used, Order{LinearFeet: 10, PalletCount: 3}) {
+		t.Error("2 stacked positions plus 3 floor pallets fit on 4 positions")
+	}
+	if truck.FitsFloor(used, Order{LinearFeet: 11}) {
+		t.Error("21 linear feet fit in 20")
+	}
+	if !(Truck{}).FitsFloor(used, Order{LinearFeet: 500, PalletCount: 100}) {
+		t.Error("a truck without floor limits turned an order down")
+	}
+	
+	long := Order{ID: "long", WeightLbs: 1, VolumeCuft: 1, PalletCount: 5}
+	if reason := (Truck{MaxWeightLbs: 100, MaxVolumeCuft: 100, MaxPalletPositions: 4}).CannotCarry(long); reason != "takes 5 pallet positions, more than the truck's 4" {
+		t.Errorf("reason = %q", reason)
+	}
+}
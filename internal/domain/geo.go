@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// LatLng is a point on the earth in decimal degrees
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// Geocoder finds where a free-text location such as "Dallas, TX" is. ok is
+// false when the location is unknown; the order then keeps being compared
+// by name. Implementations that call external services must honor ctx.
+type Geocoder interface {
+	Geocode(ctx context.Context, location string) (point LatLng, ok bool, err error)
+}
+
+// NoGeocoder is the default when no geocoding source is configured
+type NoGeocoder struct{}
+
+func (NoGeocoder) Geocode(ctx context.Context, location string) (LatLng, bool, error) {
+	return LatLng{}, false, nil
+}
+
+func validatePoint(field string, point *LatLng) error {
+	if point == nil {
+		return nil
+	}
+	if !(point.Lat >= -90 && point.Lat <= 90 && point.Lng >= -180 && point.Lng <= 180) {
+		return fmt.Errorf("%s: lat must be between -90 and 90 and lng between -180 and 180", field)
+	}
+	return nil
+}
+
+// SameLocationMiles is how close two geocoded points must be to count as the
+// same pickup or delivery location, so a suburb matches its city
+const SameLocationMiles = 25
+
+const earthRadiusMiles = 3958.8
+
+// NormalizeAddress is the form locations are compared and geocoded in:
+// lower case, punctuation dropped and spaces collapsed, so "Dallas, TX" and
+// "dallas  TX." are the same place
+func NormalizeAddress(location string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, location)
+	return strings.Join(strings.Fields(cleaned), " ")
+}
+
+// samePlace reports whether two locations are the same place: the same
+// name once normalized, or, when both are geocoded, within SameLocationMiles
+func samePlace(nameA string, pointA *LatLng, nameB string, pointB *LatLng) bool {
+	if nameA == nameB || NormalizeAddress(nameA) == NormalizeAddress(nameB) {
+		return true
+	}
+	return pointA != nil && pointB != nil && greatCircleMiles(*pointA, *pointB) <= SameLocationMiles
+}
+
+// greatCircleMiles is the haversine distance between two points
+func greatCircleMiles(a, b LatLng) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMiles * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// segmentDistance measures p against the straight route from a to b: how
+// many miles p lies off it, and how far along it p's nearest point is, from
+// 0 at a to 1 at b. Points are flattened around the route's mean latitude,
+// which is close enough at the scale of a truck lane.
+func segmentDistance(p, a, b LatLng) (miles, along float64) {
+	milesPerDegree := earthRadiusMiles * math.Pi / 180
+	scale := math.Cos((a.Lat + b.Lat) / 2 * math.Pi / 180)
+	flat := func(q LatLng) (x, y float64) {
+		return (q.Lng - a.Lng) * scale * milesPerDegree, (q.Lat - a.Lat) * milesPerDegree
+	}
+	px, py := flat(p)
+	bx, by := flat(b)
+	length := bx*bx + by*by
+	if length > 0 {
+		along = math.Max(0, math.Min(1, (px*bx+py*by)/length))
+	}
+	return math.Hypot(px-along*bx, py-along*by), along
+}
+
+// geocoded reports whether both ends of the order have a point
+func (o Order) geocoded() bool {
+	return o.OriginPoint != nil && o.DestinationPoint != nil
+}
+
+// routeMiles is the straight-line length of a geocoded order's route
+func (o Order) routeMiles() float64 {
+	return greatCircleMiles(*o.OriginPoint, *o.DestinationPoint)
+}
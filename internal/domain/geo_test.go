@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+var (
+	dallas     = &LatLng{Lat: 32.78, Lng: -96.80}
+	irving     = &LatLng{Lat: 32.81, Lng: -96.95}
+	houston    = &LatLng{Lat: 29.76, Lng: -95.37}
+	losAngeles = &LatLng{Lat: 34.05, Lng: -118.24}
+	phoenix    = &LatLng{Lat: 33.45, Lng: -112.07}
+	elPaso     = &LatLng{Lat: 31.76, Lng: -106.49}
+	denver     = &LatLng{Lat: 39.74, Lng: -104.99}
+)
+
+func TestNormalizeAddress(t *testing.T) {
+	for _, location := range []string{"Dallas, TX", "dallas  TX.", " DALLAS,TX "} {
+		if got := NormalizeAddress(location); got != "dallas tx" {
+			t.Errorf("NormalizeAddress(%q) = %q, want %q", location, got, "dallas tx")
+		}
+	}
+}
+
+func TestRouteMatchGeocoded(t *testing.T) {
+	base := Order{Origin: "Los Angeles, CA", Destination: "Dallas, TX", OriginPoint: losAngeles, DestinationPoint: dallas}
+	tests := []struct {
+		name string
+		b    Order
+		want bool
+	}{
+		{"spelling", Order{Origin: "los angeles ca", Destination: "Dallas TX"}, true},
+		{"suburb", Order{Origin: "Los Angeles, CA", Destination: "Irving, TX", OriginPoint: losAngeles, DestinationPoint: irving}, true},
+		{"other city", Order{Origin: "Los Angeles, CA", Destination: "Houston, TX", OriginPoint: losAngeles, DestinationPoint: houston}, false},
+		{"not geocoded", Order{Origin: "Los Angeles, CA", Destination: "Irving, TX"}, false},
+	}
+	for _, tt := range tests {
+		if got := (RouteMatch{}).Allows(base, tt.b); got != tt.want {
+			t.Errorf("%s: allowed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCorridorMatchGeocoded(t *testing.T) {
+	rule := CorridorMatch{CorridorMiles: 150}
+	long := Order{Origin: "Los Angeles, CA", Destination: "Dallas, TX", OriginPoint: losAngeles, DestinationPoint: dallas}
+	tests := []struct {
+		name string
+		b    Order
+		want bool
+	}{
+		{"along the route", Order{Origin: "Phoenix, AZ", Destination: "El Paso, TX", OriginPoint: phoenix, DestinationPoint: elPaso}, true},
+		{"wrong direction", Order{Origin: "El Paso, TX", Destination: "Phoenix, AZ", OriginPoint: elPaso, DestinationPoint: phoenix}, false},
+		{"off the route", Order{Origin: "Phoenix, AZ", Destination: "Denver, CO", OriginPoint: phoenix, DestinationPoint: denver}, false},
+	}
+	for _, tt := range tests {
+		if got := rule.Allows(long, tt.b); got != tt.want {
+			t.Errorf("%s: allowed = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := rule.Allows(tt.b, long); got != tt.want {
+			t.Errorf("%s reversed: allowed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHaversineDistances(t *testing.T) {
+	miles, ok, err := HaversineDistances{}.DrivingMiles(context.Background(),
+		Waypoint{Location: "Los Angeles, CA", Point: losAngeles}, Waypoint{Location: "Dallas, TX", Point: dallas})
+	if err != nil || !ok {
+		t.Fatalf("not measured: %v, %v", ok, err)
+	}
+	// About 1240 straight-line miles
+	if miles < 1230*RoadCircuity || miles > 1250*RoadCircuity {
+		t.Errorf("measured %.0f miles", miles)
+	}
+	if _, ok, _ := (HaversineDistances{}).DrivingMiles(context.Background(), Waypoint{Location: "Los Angeles, CA"}, Waypoint{Point: dallas}); ok {
+		t.Error("waypoint without a point measured")
+	}
+}
+
+func TestValidatePoint(t *testing.T) {
+	for _, point := range []*LatLng{{Lat: 91}, {Lng: -181}, {Lat: math.NaN()}} {
+		if err := validatePoint("origin_point", point); err == nil {
+			t.Errorf("%+v accepted", *point)
+		}
+	}
+	if err := validatePoint("origin_point", dallas); err != nil {
+		t.Errorf("Dallas rejected: %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+// Segregation table entries, after 49 CFR 177.848(d)
+const (
+	// segregationNone puts no restriction on loading two classes together
+	segregationNone = iota
+	// segregationSeparated classes may share a truck only when separated so
+	// they cannot commingle if their packages leak
+	segregationSeparated
+	// segregationForbidden classes may never share a truck
+	segregationForbidden
+	// segregationExplosives is the table's asterisk: explosives of different
+	// divisions, which only the class 1 compatibility groups can decide
+	segregationExplosives
+)
+
+// hazmatClasses are the hazmat_class values orders may carry, mapped to their
+// row in segregationTable; -1 marks classes the table puts no restriction on.
+// 2.3 and 6.1 rows are the table's zone A ones and 8 is its liquids row, the
+// strictest readings, since requests carry no hazard zone or physical state;
+// 2.3B names a zone B gas and 6.1B any other poison.
+var hazmatClasses = map[string]int{
+	"1.1": 0, "1.2": 0, "1.3": 1, "1.4": 2, "1.5": 3, "1.6": 4,
+	"2.1": 5, "2.2": 6, "2.3": 7, "2.3B": 8,
+	"3":   9,
+	"4.1": 10, "4.2": 11, "4.3": 12,
+	"5.1": 13, "5.2": 14,
+	"6.1": 15, "6.1B": -1, "6.2": -1,
+	"7": 16,
+	"8": 17,
+	"9": -1,
+}
+
+// segregationTable is the lower triangle of the DOT segregation table; rows
+// and columns are 1.1/1.2, 1.3, 1.4, 1.5, 1.6, 2.1, 2.2, 2.3 zone A, 2.3 zone
+// B, 3, 4.1, 4.2, 4.3, 5.1, 5.2, 6.1 zone A, 7 and 8 liquids
+var segregationTable = func() [18][18]int {
+	const (
+		n = segregationNone
+		o = segregationSeparated
+		x = segregationForbidden
+		e = segregationExplosives
+	)
+	rows := [][]int{
+		{e},
+		{e, e},
+		{e, e, e},
+		{e, e, e, e},
+		{e, e, e, e, e},
+		{x, x, o, x, n, n},
+		{x, n, n, x, n, n, n},
+		{x, x, o, x, n, x, n, n},
+		{x, x, o, x, n, o, n, n, n},
+		{x, x, o, x, n, n, n, x, o, n},
+		{x, n, n, x, n, n, n, x, o, n, n},
+		{x, x, o, x, n, n, n, x, o, n, n, n},
+		{x, x, n, x, n, n, n, x, o, n, n, n, n},
+		{x, x, n, x, n, n, n, x, o, o, n, n, n, n},
+		{x, x, n, x, n, n, n, x, o, n, n, n, n, n, n},
+		{x, x, o, x, n, o, n, n, n, x, x, x, x, x, x, n},
+		{x, n, n, x, n, n, n, n, n, n, n, n, n, n, n, n, n},
+		{x, x, o, x, n, n, n, x, o, n, n, o, o, o, o, x, n, n},
+	}
+	var table [18][18]int
+	for i, row := range rows {
+		for j, entry := range row {
+			table[i][j] = entry
+			table[j][i] = entry
+		}
+	}
+	return table
+}()
+
+// ValidHazmatClass reports whether class is a hazmat_class orders may carry
+func ValidHazmatClass(class string) bool {
+	_, ok := hazmatClasses[class]
+	return ok
+}
+
+// HazmatSegregation applies the DOT segregation table to hazmat orders that
+// name their class. Classes the table forbids together never share a truck,
+// and neither do ones it allows only when separated, since a plan cannot
+// promise the separation. Explosives of different divisions ride apart, as
+// compatibility groups are not modeled. Orders without a class combine with
+// any hazmat order, as they did before classes existed.
+type HazmatSegregation struct{}
+
+func (HazmatSegregation) Name() string { return "hazmat_segregation" }
+
+func (HazmatSegregation) Allows(a, b Order) bool {
+	rowA, okA := hazmatClasses[a.HazmatClass]
+	rowB, okB := hazmatClasses[b.HazmatClass]
+	if !okA || !okB || rowA < 0 || rowB < 0 {
+		return true
+	}
+	
+	switch segregationTable[rowA][rowB] {
+	case segregationForbidden, segregationSeparated:
+		return false
+	case segregationExplosives:
+		return a.HazmatClass == b.HazmatClass
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHazmatSegregation(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		allow bool
+	}{
+		{"3", "3", true},
+		{"3", "8", true},
+		{"3", "6.1", false},
+		{"6.1B", "3", true},
+		{"2.1", "2.3", false},
+		{"2.3B", "3", false},
+		{"5.1", "3", false},
+		{"8", "4.2", false},
+		{"7", "1.1", false},
+		{"7", "3", true},
+		{"1.4", "1.4", true},
+		{"1.1", "1.3", false},
+		{"9", "1.1", true},
+		{"", "1.1", true},
+	}
+	for _, tt := range tests {
+		a := Order{IsHazmat: true, HazmatClass: tt.a}
+		b := Order{IsHazmat: true, HazmatClass: tt.b}
+		if got := (HazmatSegregation{}).Allows(a, b); got != tt.allow {
+			t.Errorf("%q with %q allowed = %v, want %v", tt.a, tt.b, got, tt.allow)
+		}
+		if got := (HazmatSegregation{}).Allows(b, a); got != tt.allow {
+			t.Errorf("%q with %q allowed = %v, want %v", tt.b, tt.a, got, tt.allow)
+		}
+	}
+}
+
+func TestHazmatClassValidation(t *testing.T) {
+	day := time.Now().Format("2006-01-02")
+	order := OrderInput{
+		ID: "a", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200, Origin: "LA", Destination: "Dallas",
+		PickupDate: day, DeliveryDate: day, IsHazmat: true, HazmatClass: "5.1",
+	}
+	if err := order.Validate(); err != nil {
+		t.Fatalf("class 5.1 rejected: %v", err)
+	}
+	
+	order.HazmatClass = "10"
+	if err := order.Validate(); err == nil {
+		t.Fatal("class 10 accepted")
+	}
+	
+	order.HazmatClass = "3"
+	order.IsHazmat = false
+	if err := order.Validate(); err == nil {
+		t.Fatal("hazmat_class accepted on a non-hazmat order")
+	}
+}
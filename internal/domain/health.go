@@ -0,0 +1,72 @@
+package domain
+
+// Solver health statuses
+const (
+	HealthUp = "UP"
+	// HealthDegraded means recent solves failed, timed out or fell short of
+	// the optimum often enough that plans should be checked by hand
+	HealthDegraded = "DEGRADED"
+)
+
+// HealthDetails reports solver health over rolling windows, shortest first
+type HealthDetails struct {
+	Status string `json:"status"`
+	// Reasons explains a DEGRADED status
+	Reasons []string       `json:"reasons,omitempty"`
+	Windows []HealthWindow `json:"windows"`
+	// ResultCache counts the result cache's lookups since the server started;
+	// it is left out when no cache is set
+	ResultCache *ResultCacheStats `json:"result_cache,omitempty"`
+	// Shadow compares the shadow algorithm's plans with the ones answered
+	// since the server started; it is left out when no shadow algorithm is set
+	Shadow *ShadowStats `json:"shadow,omitempty"`
+}
+
+// ShadowStats totals the background solves of a shadow algorithm. Runs are
+// the shadow solves that finished, each counted Better, Worse or Same by
+// whether its payout beat the answered plan's; Skipped are those dropped
+// because every slot was busy or the solve timed out. Deltas are the shadow
+// plan's payout minus the answered plan's.
+type ShadowStats struct {
+	Algorithm               string  `json:"algorithm"`
+	Runs                    int64   `json:"runs"`
+	Skipped                 int64   `json:"skipped"`
+	Better                  int64   `json:"better"`
+	Worse                   int64   `json:"worse"`
+	Same                    int64   `json:"same"`
+	PayoutDeltaCents        int64   `json:"payout_delta_cents"`
+	AveragePayoutDeltaCents float64 `json:"average_payout_delta_cents"`
+	AveragePrimaryComputeMs float64 `json:"average_primary_compute_ms"`
+	AverageShadowComputeMs  float64 `json:"average_shadow_compute_ms"`
+}
+
+// ResultCacheStats counts optimize requests answered from the result cache
+// (hits) and those solved because it held no response for them (misses).
+// Requests the cache never serves, such as streamed solves, count as
+// neither. Entries is the number of responses held, reported by in-process
+// caches only.
+type ResultCacheStats struct {
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+	Entries *int    `json:"entries,omitempty"`
+}
+
+// HealthWindow summarizes the solves that finished in the last Window.
+// Errors count failed solves other than rejected requests, and timeouts the
+// solves stopped by the solve timeout. The gap is measured on revenue solves
+// as how far the plan's payout could be below the optimum, a bound on the
+// true gap; optimal plans have none. The measured gap is the true gap of
+// the sampled heuristic solves re-solved exactly in the window.
+type HealthWindow struct {
+	Window                    string  `json:"window"`
+	Solves                    int     `json:"solves"`
+	Errors                    int     `json:"errors"`
+	Timeouts                  int     `json:"timeouts"`
+	ErrorRate                 float64 `json:"error_rate"`
+	TimeoutRate               float64 `json:"timeout_rate"`
+	GapSamples                int     `json:"gap_samples"`
+	AverageGapPercent         float64 `json:"average_gap_percent"`
+	MeasuredGapSamples        int     `json:"measured_gap_samples"`
+	AverageMeasuredGapPercent float64 `json:"average_measured_gap_percent"`
+}
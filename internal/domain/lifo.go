@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LIFO modes for multi_stop.lifo
+const (
+	// LIFOSoft plans as usual and warns about loads that need restacking
+	LIFOSoft = "soft"
+	// LIFOHard only combines orders that can be unloaded last in, first out
+	LIFOHard = "hard"
+)
+
+func validateLIFO(mode string) error {
+	switch mode {
+	case "", LIFOSoft, LIFOHard:
+		return nil
+	}
+	return fmt.Errorf("invalid lifo: %s (must be soft or hard)", mode)
+}
+
+// pickupStart and deliveryStart are when the order's pickup and delivery
+// windows open, falling back to the dates for orders built without windows
+func (o Order) pickupStart() time.Time {
+	if o.PickupWindow.Start.IsZero() {
+		return o.PickupDate
+	}
+	return o.PickupWindow.Start
+}
+
+func (o Order) deliveryStart() time.Time {
+	if o.DeliveryWindow.Start.IsZero() {
+		return o.DeliveryDate
+	}
+	return o.DeliveryWindow.Start
+}
+
+// restacks reports whether a blocks b's way out: a is picked up first, so it
+// rides deeper in the trailer, yet it is delivered first. Orders whose
+// pickups or deliveries open together can be loaded or unloaded in either
+// order, so they never block each other.
+func restacks(a, b Order) bool {
+	return a.pickupStart().Before(b.pickupStart()) && a.deliveryStart().Before(b.deliveryStart())
+}
+
+// LIFOUnload combines only orders that can be unloaded last in, first out
+// without moving other freight: of two orders, the one picked up first must
+// be delivered last. Pickups and deliveries are taken in the order their
+// windows open.
+type LIFOUnload struct{}
+
+func (LIFOUnload) Name() string { return "lifo" }
+
+func (LIFOUnload) Allows(a, b Order) bool {
+	return !restacks(a, b) && !restacks(b, a)
+}
+
+// LIFOWarnings flags each pair of plan orders that cannot be unloaded last
+// in, first out, for multi-stop requests that ask about it
+func (r *OptimizeRequest) LIFOWarnings(orders []Order) []ValidationWarning {
+	if r.MultiStop == nil || r.MultiStop.LIFO == "" {
+		return nil
+	}
+	var warnings []ValidationWarning
+	for _, a := range orders {
+		for _, b := range orders {
+			if restacks(a, b) {
+				warnings = append(warnings, ValidationWarning{
+					Code:    "lifo_restack",
+					Field:   "multi_stop.lifo",
+					Message: fmt.Sprintf("order %s is loaded before %s but delivered first; %s must be moved to unload it", a.ID, b.ID, b.ID),
+				})
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLIFOUnload(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	order := func(id string, pickup, delivery int) Order {
+		return Order{ID: id, PickupDate: day(pickup), DeliveryDate: day(delivery)}
+	}
+	tests := []struct {
+		name string
+		a, b Order
+		want bool
+	}{
+		{"first on, last off", order("a", 1, 4), order("b", 2, 3), true},
+		{"first on, first off", order("a", 1, 3), order("b", 2, 4), false},
+		{"picked up together", order("a", 1, 3), order("b", 1, 4), true},
+		{"delivered together", order("a", 1, 4), order("b", 2, 4), true},
+	}
+	for _, tt := range tests {
+		if got := (LIFOUnload{}).Allows(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: allowed = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := (LIFOUnload{}).Allows(tt.b, tt.a); got != tt.want {
+			t.Errorf("%s reversed: allowed = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadingSequenceLoadsLastDeliveryFirst(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	orders := []Order{
+		{ID: "a", PickupDate: day(1), DeliveryDate: day(3)},
+		{ID: "b", PickupDate: day(1), DeliveryDate: day(5)},
+		{ID: "c", PickupDate: day(2), DeliveryDate: day(2)},
+	}
+	var ids []string
+	for _, order := range LoadingSequence(orders) {
+		ids = append(ids, order.ID)
+	}
+	if !slices.Equal(ids, []string{"b", "a", "c"}) {
+		t.Errorf("loading sequence %v, want [b a c]", ids)
+	}
+}
+
+func TestLIFOValidation(t *testing.T) {
+	if err := (&MultiStopInput{MaxStops: 4, LIFO: "strict"}).validate(); err == nil {
+		t.Error("lifo strict accepted")
+	}
+	if err := (&MultiStopInput{MaxStops: 4, LIFO: LIFOHard}).validate(); err != nil {
+		t.Errorf("lifo hard rejected: %v", err)
+	}
+}
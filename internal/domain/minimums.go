@@ -0,0 +1,58 @@
+package domain
+
+import "fmt"
+
+// Minimums are hard floors on a plan. DispatchThresholds only turn the
+// recommendation into a hold; a plan below its Minimums is never returned.
+type Minimums struct {
+	TotalPayoutCents int64
+	// UtilizationPercent applies to the binding dimension, the fuller of
+	// weight and volume
+	UtilizationPercent float64
+}
+
+// Minimums returns the request's min_total_payout_cents and min_utilization_percent
+func (r *OptimizeRequest) Minimums() Minimums {
+	return Minimums{TotalPayoutCents: r.MinTotalPayoutCents, UtilizationPercent: r.MinUtilizationPercent}
+}
+
+func (m Minimums) Empty() bool {
+	return m.TotalPayoutCents == 0 && m.UtilizationPercent == 0
+}
+
+func (m Minimums) validate() error {
+	if m.TotalPayoutCents < 0 {
+		return fmt.Errorf("min_total_payout_cents cannot be negative")
+	}
+	if m.UtilizationPercent < 0 || m.UtilizationPercent > 100 {
+		return fmt.Errorf("min_utilization_percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// Unmet lists the minimums a plan misses, empty when it meets them all.
+// Redacted reasons leave out the payout, as for sealed requests.
+func (m Minimums) Unmet(payout Money, utilizationWeight, utilizationVolume float64, redacted bool) []string {
+	unmet := make([]string, 0)
+	if int64(payout) < m.TotalPayoutCents {
+		if redacted {
+			unmet = append(unmet, "total payout is below min_total_payout_cents")
+		} else {
+			unmet = append(unmet, fmt.Sprintf("total payout %s is below min_total_payout_cents %s",
+				payout.ToDollars(), Money(m.TotalPayoutCents).ToDollars()))
+		}
+	}
+	
+	utilization := max(utilizationWeight, utilizationVolume)
+	if utilization < m.UtilizationPercent {
+		unmet = append(unmet, fmt.Sprintf("utilization %.2f%% is below min_utilization_percent %.2f%%",
+			utilization, m.UtilizationPercent))
+	}
+	return unmet
+}
+
+// NoAcceptablePlan explains an empty result: no plan found met the request's
+// minimums. Reasons describe the best plan that was found.
+type NoAcceptablePlan struct {
+	Reasons []string `json:"reasons"`
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMinimumsUnmet(t *testing.T) {
+	minimums := Minimums{TotalPayoutCents: 500000, UtilizationPercent: 80}
+	
+	if unmet := minimums.Unmet(500000, 60, 80, false); len(unmet) != 0 {
+		t.Fatalf("a plan at both minimums failed: %v", unmet)
+	}
+	unmet := minimums.Unmet(400000, 70, 75, false)
+	if len(unmet) != 2 || !strings.Contains(unmet[0], "$4000.00") || !strings.Contains(unmet[1], "75.00%") {
+		t.Fatalf("unmet = %v", unmet)
+	}
+	for _, reason := range minimums.Unmet(400000, 90, 0, true) {
+		if strings.Contains(reason, "$") {
+			t.Fatalf("redacted reason %q shows an amount", reason)
+		}
+	}
+}
+
+func TestMinimumsValidation(t *testing.T) {
+	for _, minimums := range []Minimums{{TotalPayoutCents: -1}, {UtilizationPercent: 101}, {UtilizationPercent: -5}} {
+		if minimums.validate() == nil {
+			t.Errorf("%+v passed validation", minimums)
+		}
+	}
+	if !(Minimums{}).Empty() {
+		t.Error("zero minimums should be empty")
+	}
+}
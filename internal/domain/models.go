@@ -7,9 +7,16 @@ import (
 
 type Money int64
 
+// ToDollars formats the amount as dollars and cents, such as -$1234.50. The
+// cents are split off in integers, so large amounts keep every digit.
 func (m Money) ToDollars() string {
-	dollars := float64(m) / 100.0
-	return fmt.Sprintf("$%.2f", dollars)
+	sign := ""
+	cents := uint64(m)
+	if m < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s$%d.%02d", sign, cents/100, cents%100)
 }
 
 func (m Money) Add(other Money) Money {
@@ -24,6 +31,46 @@ type OptimizeRequest struct {
 	Truck              TruckInput          `json:"truck"`
 	Orders             []OrderInput        `json:"orders"`
 	OptimizationConfig *OptimizationConfig `json:"optimization_config,omitempty"`
+	DispatchThresholds *DispatchThresholds `json:"dispatch_thresholds,omitempty"`
+	// Currency is the ISO 4217 code of every *_cents amount, echoed back unchanged
+	Currency string `json:"currency,omitempty"`
+	// K asks for up to K distinct plans, best first, in the response's alternatives
+	K int `json:"k,omitempty"`
+	// MustIncludeOrderIDs are committed orders every plan carries;
+	// MustExcludeOrderIDs are orders no plan may carry
+	MustIncludeOrderIDs []string `json:"must_include_order_ids,omitempty"`
+	MustExcludeOrderIDs []string `json:"must_exclude_order_ids,omitempty"`
+	// MinTotalPayoutCents and MinUtilizationPercent are hard floors on the
+	// plan; see Minimums
+	MinTotalPayoutCents   int64   `json:"min_total_payout_cents,omitempty"`
+	MinUtilizationPercent float64 `json:"min_utilization_percent,omitempty"`
+	// Rules are compatibility rules for this request only, enforced on top
+	// of the ones every truck follows; see RuleInput
+	Rules []RuleInput `json:"rules,omitempty"`
+	// Facilities are the origin facilities orders are picked up at, with
+	// their dock doors and truck windows; see FacilityInput
+	Facilities []FacilityInput `json:"facilities,omitempty"`
+	// PreviousOrderIDs is the plan committed before this re-optimization;
+	// the response lists the changes from it, and stability_weight makes
+	// changing it cost. An empty list is a previous plan with no orders.
+	PreviousOrderIDs []string `json:"previous_order_ids,omitempty"`
+	// DuplicatePolicy decides what happens to orders that look like the same
+	// load under different IDs; see SplitDuplicates
+	DuplicatePolicy string `json:"duplicate_policy,omitempty"`
+	// MultiStop lets orders on different routes of one corridor share the
+	// truck; see MultiStopInput
+	MultiStop *MultiStopInput `json:"multi_stop,omitempty"`
+	// DimFactor, in cubic inches per pound, charges every order the larger
+	// of its weight and its dimensional weight; 0 charges actual weight
+	DimFactor int `json:"dim_factor,omitempty"`
+	// LaneRisks weights each lane's driving hours against the safe exposure
+	// of temperature-controlled orders; see LaneRiskInput
+	LaneRisks []LaneRiskInput `json:"lane_risks,omitempty"`
+	
+	// TenantID is taken from the X-Tenant-ID header, never from the body
+	TenantID string `json:"-"`
+	// APIKey names the API key the request was made with, for usage reporting
+	APIKey string `json:"-"`
 }
 
 type OptimizationConfig struct {
@@ -31,42 +78,227 @@ type OptimizationConfig struct {
 	RevenueWeight     float64 `json:"revenue_weight"`
 	UtilizationWeight float64 `json:"utilization_weight"`
 	Algorithm         string  `json:"algorithm"`
+	// PriorityMode "lexicographic" serves higher order priorities first,
+	// whatever lower tiers would pay; empty ignores priorities
+	PriorityMode string `json:"priority_mode,omitempty"`
+	// TabuTenure and TabuNeighborhood tune algorithm "tabu": how many
+	// iterations a moved order stays tabu, and how many moves are weighed per
+	// iteration. Zero picks the default.
+	TabuTenure       int `json:"tabu_tenure,omitempty"`
+	TabuNeighborhood int `json:"tabu_neighborhood,omitempty"`
+	// StabilityWeight, from 0 to 1, is what moving an order in or out of
+	// previous_order_ids costs, as a share of its score
+	StabilityWeight float64 `json:"stability_weight,omitempty"`
+	// EmissionsWeight, in cents per kilogram of CO2, is what each order's
+	// estimated emissions cost it in score; see ChargeEmissions
+	EmissionsWeight float64 `json:"emissions_weight,omitempty"`
+	// DeadheadWeight, in cents per mile, is what each empty mile to the first
+	// pickup and from the last delivery costs a plan in score
+	DeadheadWeight float64 `json:"deadhead_weight,omitempty"`
+	// ExcursionWeight, in cents per hour, is what each hour a plan stays on
+	// the road beyond the safe exposure of its most sensitive order costs it
+	// in score
+	ExcursionWeight float64 `json:"excursion_weight,omitempty"`
 }
 
 type TruckInput struct {
-	ID            string `json:"id"`
-	MaxWeightLbs  int    `json:"max_weight_lbs"`
-	MaxVolumeCuft int    `json:"max_volume_cuft"`
+	ID             string          `json:"id"`
+	MaxWeightLbs   int             `json:"max_weight_lbs"`
+	MaxVolumeCuft  int             `json:"max_volume_cuft"`
+	FixedCostCents int64           `json:"fixed_cost_cents"`
+	DriverPay      *DriverPayInput `json:"driver_pay,omitempty"`
+	// CostPerMileCents is what the truck costs to run per mile beyond fuel
+	// and driver pay, such as maintenance and tires
+	CostPerMileCents int64      `json:"cost_per_mile_cents,omitempty"`
+	Fuel             *FuelInput `json:"fuel,omitempty"`
+	// StopFeeCents is charged for every stop after the first pickup and
+	// delivery; see ConsolidationStops
+	StopFeeCents int64 `json:"stop_fee_cents,omitempty"`
+	// MaxOrders caps the orders on the truck, for dock door or stop-count
+	// limits; 0 means no cap
+	MaxOrders int `json:"max_orders,omitempty"`
+	// MaxLinearFeet is the trailer floor length orders can take, for LTL
+	// loads that run out of floor before weight or cube; 0 means no limit
+	MaxLinearFeet int `json:"max_linear_feet,omitempty"`
+	// MaxPalletPositions is the pallet positions on the trailer floor; 0
+	// means pallets are not counted
+	MaxPalletPositions int `json:"max_pallet_positions,omitempty"`
+	// EquipmentType is "dry" or "reefer"; empty means a dry van
+	EquipmentType string `json:"equipment_type,omitempty"`
+	// Equipment lists what the truck carries for orders that need it, from
+	// EquipmentItems
+	Equipment []string `json:"equipment,omitempty"`
+	// InteriorLengthIn, InteriorWidthIn and InteriorHeightIn are the
+	// trailer's inside dimensions in inches. Set together, they turn on the
+	// packing check for orders with dimensions.
+	InteriorLengthIn int `json:"interior_length_in,omitempty"`
+	InteriorWidthIn  int `json:"interior_width_in,omitempty"`
+	InteriorHeightIn int `json:"interior_height_in,omitempty"`
+	// Axles turns on the axle weight check; see AxleInput
+	Axles *AxleInput `json:"axles,omitempty"`
+	// Compartments split the trailer into sections with limits of their
+	// own; the truck's limits still cap the whole load
+	Compartments []CompartmentInput `json:"compartments,omitempty"`
+	// Position is where the truck is now, when the dispatcher knows
+	Position *LatLng `json:"position,omitempty"`
+	// NextPosition is where the truck must be after the load, such as its
+	// next committed pickup or its home terminal
+	NextPosition *LatLng `json:"next_position,omitempty"`
 }
 
 type OrderInput struct {
-	ID           string `json:"id"`
-	PayoutCents  int64  `json:"payout_cents"`
-	WeightLbs    int    `json:"weight_lbs"`
-	VolumeCuft   int    `json:"volume_cuft"`
+	ID          string `json:"id"`
+	PayoutCents int64  `json:"payout_cents"`
+	WeightLbs   int    `json:"weight_lbs"`
+	VolumeCuft  int    `json:"volume_cuft"`
+	// LinearFeet is the trailer floor length the order takes, counted
+	// against the truck's max_linear_feet
+	LinearFeet   int    `json:"linear_feet,omitempty"`
 	Origin       string `json:"origin"`
 	Destination  string `json:"destination"`
 	PickupDate   string `json:"pickup_date"`
 	DeliveryDate string `json:"delivery_date"`
 	IsHazmat     bool   `json:"is_hazmat"`
+	Miles        int    `json:"miles"`
+	Shipper      string `json:"shipper"`
+	
+	// OriginPoint and DestinationPoint place the ends of the lane directly;
+	// where given, the geocoder is not asked
+	OriginPoint      *LatLng `json:"origin_point,omitempty"`
+	DestinationPoint *LatLng `json:"destination_point,omitempty"`
+	
+	// PickupWindowStart and PickupWindowEnd, and the delivery pair, narrow
+	// pickup and delivery to hours, as ISO 8601 timestamps with a timezone.
+	// pickup_date and delivery_date may then be left out.
+	PickupWindowStart   string `json:"pickup_window_start,omitempty"`
+	PickupWindowEnd     string `json:"pickup_window_end,omitempty"`
+	DeliveryWindowStart string `json:"delivery_window_start,omitempty"`
+	DeliveryWindowEnd   string `json:"delivery_window_end,omitempty"`
+	// HazmatClass is the DOT hazard class or division of a hazmat order,
+	// such as "3" or "2.1", and decides which hazmat orders may ride together
+	HazmatClass string `json:"hazmat_class,omitempty"`
+	// CommodityType is general, food, pharma or hazmat; left out it is
+	// hazmat for is_hazmat orders and general otherwise
+	CommodityType string `json:"commodity_type,omitempty"`
+	// TemperatureMinF and TemperatureMaxF bound the temperature, in degrees
+	// Fahrenheit, the order must be kept at. Setting either makes it reefer
+	// freight; an unset bound is open.
+	TemperatureMinF *float64 `json:"temperature_min_f,omitempty"`
+	TemperatureMaxF *float64 `json:"temperature_max_f,omitempty"`
+	// EquipmentRequirements lists equipment from EquipmentItems the truck
+	// must have to take the order
+	EquipmentRequirements []string `json:"equipment_requirements,omitempty"`
+	// LengthIn, WidthIn and HeightIn are the freight's dimensions in inches,
+	// set together or not at all. Orders without them take only volume.
+	LengthIn int `json:"length_in,omitempty"`
+	WidthIn  int `json:"width_in,omitempty"`
+	HeightIn int `json:"height_in,omitempty"`
+	// PalletCount is the order's pallets. Stackable pallets stack
+	// PalletStackHeight high, sharing positions with stackable pallets of
+	// other orders.
+	PalletCount int  `json:"pallet_count,omitempty"`
+	Stackable   bool `json:"stackable,omitempty"`
+	// ExclusiveGroup ties orders of which at most one may be loaded, such as
+	// the same freight posted on two lanes
+	ExclusiveGroup string `json:"exclusive_group,omitempty"`
+	// Splittable lets the optimizer load part of the order for the same
+	// share of its payout, as with bulk freight
+	Splittable bool `json:"splittable,omitempty"`
+	// Priority ranks contracted freight from 1 (lowest) to 5; 0 is unranked
+	// and counts below 1. It only matters under priority_mode "lexicographic".
+	Priority int `json:"priority,omitempty"`
+	// MaxExposureHours is how long a temperature-controlled order can safely
+	// stay on the road; 0 sets no limit
+	MaxExposureHours float64 `json:"max_exposure_hours,omitempty"`
+	
+	// PayoutEncrypted replaces payout_cents with a payout sealed under the tenant's key
+	PayoutEncrypted string `json:"payout_encrypted,omitempty"`
 }
 
 type Truck struct {
 	ID            string
 	MaxWeightLbs  int
 	MaxVolumeCuft int
+	FixedCost     Money
+	DriverPay     DriverPay
+	CostPerMile   Money
+	Fuel          Fuel
+	StopFee       Money
+	// MaxOrders is 0 when the number of orders is not capped
+	MaxOrders int
+	// MaxLinearFeet and MaxPalletPositions are 0 when not limited
+	MaxLinearFeet      int
+	MaxPalletPositions int
+	// EquipmentType is EquipmentDry or EquipmentReefer
+	EquipmentType string
+	Equipment     []string
+	// Interior is nil when the trailer's dimensions are unknown
+	Interior *Dimensions
+	// Axles is nil for trucks without an axle model
+	Axles *Axles
+	// Compartments is nil for single-compartment trucks
+	Compartments []Compartment
+	// Position is nil when the truck's whereabouts are unknown
+	Position *LatLng
+	// NextPosition is nil when the truck need not be anywhere after the load
+	NextPosition *LatLng
+}
+
+// FitsMoreOrders reports whether a truck already carrying count orders can
+// take another under its MaxOrders cap
+func (t Truck) FitsMoreOrders(count int) bool {
+	return t.MaxOrders == 0 || count < t.MaxOrders
 }
 
+// Order is a shipment offer. Payout is what the shipper pays and is never
+// changed; Score is what optimizers maximize. ToDomain sets Score to Payout,
+// and weighted objectives and tenant bonuses adjust Score only.
 type Order struct {
-	ID           string
-	Payout       Money
-	WeightLbs    int
-	VolumeCuft   int
-	Origin       string
-	Destination  string
-	PickupDate   time.Time
-	DeliveryDate time.Time
-	IsHazmat     bool
+	ID     string
+	Payout Money
+	Score  Score
+	// WeightLbs is the chargeable weight, raised to the dimensional weight
+	// under a dim_factor; ScaleWeightLbs keeps the actual weight when it was
+	// raised and is 0 otherwise
+	WeightLbs      int
+	ScaleWeightLbs int
+	VolumeCuft     int
+	LinearFeet     int
+	PalletCount    int
+	Stackable      bool
+	Origin         string
+	Destination    string
+	PickupDate     time.Time
+	DeliveryDate   time.Time
+	// OriginPoint and DestinationPoint are where the geocoder placed the
+	// locations, nil when it could not
+	OriginPoint      *LatLng
+	DestinationPoint *LatLng
+	// PickupWindow and DeliveryWindow span the whole day of the dates when
+	// the order gives no windows
+	PickupWindow   TimeWindow
+	DeliveryWindow TimeWindow
+	IsHazmat       bool
+	Miles          int
+	Shipper        string
+	// HazmatClass is empty for orders that name no hazard class
+	HazmatClass string
+	// Commodity is one of the Commodity types, never empty
+	Commodity string
+	// Temperature is nil for dry freight
+	Temperature           *TemperatureRange
+	EquipmentRequirements []string
+	// Dimensions is nil for orders that gave none
+	Dimensions *Dimensions
+	// ExclusiveGroup is empty for orders that belong to no group
+	ExclusiveGroup string
+	Splittable     bool
+	Priority       int
+	// MaxExposureHours is 0 for orders without a safe exposure
+	MaxExposureHours float64
+	// ExposureRisk is the risk factor of the order's lane, 0 when lane_risks
+	// gives none
+	ExposureRisk float64
 }
 
 func (o Order) FitsIn(availableWeight, availableVolume int) bool {
@@ -78,13 +310,166 @@ func (o Order) Route() string {
 }
 
 type OptimizeResponse struct {
-	TruckID                  string   `json:"truck_id"`
+	// SolutionID identifies this solve in history, exports and published events
+	SolutionID string `json:"solution_id"`
+	// ProblemFingerprint hashes the request's problem; equal fingerprints mean
+	// the same problem was solved again (see OptimizeRequest.Fingerprint)
+	ProblemFingerprint string   `json:"problem_fingerprint,omitempty"`
+	TruckID            string   `json:"truck_id"`
+	SelectedOrderIDs   []string `json:"selected_order_ids"`
+	TotalPayoutCents   int64    `json:"total_payout_cents"`
+	TotalWeightLbs     int      `json:"total_weight_lbs"`
+	TotalVolumeCuft    int      `json:"total_volume_cuft"`
+	// TotalScaleWeightLbs is what the selected orders actually weigh, set
+	// when dim_factor charged some of them more than that
+	TotalScaleWeightLbs int `json:"total_scale_weight_lbs,omitempty"`
+	// TotalLinearFeet sums the selected orders' linear_feet, TotalPallets
+	// their pallets and TotalPalletPositions the positions those fill once
+	// stackable ones are stacked
+	TotalLinearFeet          int           `json:"total_linear_feet,omitempty"`
+	TotalPallets             int           `json:"total_pallets,omitempty"`
+	TotalPalletPositions     int           `json:"total_pallet_positions,omitempty"`
+	UtilizationWeightPercent float64       `json:"utilization_weight_percent"`
+	UtilizationVolumePercent float64       `json:"utilization_volume_percent"`
+	FixedCostCents           int64         `json:"fixed_cost_cents"`
+	CostBreakdown            CostBreakdown `json:"cost_breakdown"`
+	NetProfitCents           int64         `json:"net_profit_cents"`
+	Recommendation           string        `json:"recommendation"`
+	RecommendationReasons    []string      `json:"recommendation_reasons,omitempty"`
+	Explanation              *Explanation  `json:"explanation,omitempty"`
+	Currency                 string        `json:"currency"`
+	// Score is the objective value of the plan: payout plus tenant bonuses
+	// less any emissions and deadhead charges, or the composite revenue/utilization score
+	// under weighted objectives
+	Score int64 `json:"score"`
+	// EmissionsKgCO2 estimates the plan's CO2; see PlanEmissionsKg
+	EmissionsKgCO2 float64 `json:"emissions_kg_co2"`
+	// Deadhead estimates the plan's empty miles when the truck gives its
+	// position or next position
+	Deadhead *Deadhead `json:"deadhead,omitempty"`
+	// Excursion compares the plan's time on the road with the safe exposure
+	// of its most sensitive order, when an order on board sets one
+	Excursion *Excursion `json:"excursion,omitempty"`
+	// Warnings lists accepted but suspicious input; see ValidationWarning
+	Warnings []ValidationWarning `json:"warnings,omitempty"`
+	// Alternatives lists the K best distinct plans when the request sets k
+	Alternatives []PlanSummary `json:"alternatives,omitempty"`
+	// NoAcceptablePlan is set, and the plan left empty, when no plan met
+	// min_total_payout_cents and min_utilization_percent
+	NoAcceptablePlan *NoAcceptablePlan `json:"no_acceptable_plan,omitempty"`
+	// PlanChanges compares the plan with previous_order_ids when the
+	// request sends them
+	PlanChanges *PlanChanges `json:"plan_changes,omitempty"`
+	// AxleLoads estimates the plan's axle loads when the truck has an axle
+	// model; overloads are reported in Warnings
+	AxleLoads *AxleLoads `json:"axle_loads,omitempty"`
+	// LoadingSequence lists the selected orders nose first, as LoadingSequence
+	// orders them, for multi-stop requests that set lifo
+	LoadingSequence []string `json:"loading_sequence,omitempty"`
+	// Stops is the route that picks up and delivers the selected orders, as
+	// SequenceStops orders it
+	Stops []Stop `json:"stops,omitempty"`
+	// Compartments says which compartment each selected order goes in, for
+	// trucks with compartments
+	Compartments []CompartmentLoad `json:"compartments,omitempty"`
+	// PartialOrders details the splittable orders loaded only in part. They
+	// are also listed in SelectedOrderIDs, and the totals count only the part.
+	PartialOrders []PartialOrder `json:"partial_orders,omitempty"`
+	// Portfolio reports every algorithm of an algorithm "portfolio" solve
+	Portfolio []AlgorithmOutcome `json:"portfolio,omitempty"`
+	// StoppedEarly is set when the caller stopped the search before it
+	// finished; the plan is the best it had found by then
+	StoppedEarly bool `json:"stopped_early,omitempty"`
+	// Cached is set when the response was solved for an identical earlier
+	// request and served from the result cache
+	Cached bool `json:"cached,omitempty"`
+	// PayoutRedacted is set when payouts arrived sealed and every amount
+	// derived from them has been zeroed
+	PayoutRedacted bool `json:"payout_redacted,omitempty"`
+}
+
+// RedactPayouts zeroes every amount a caller could use to recover sealed
+// payouts: totals, net profit, score, the same fields of each alternative,
+// the payout of each partial order and the score of each portfolio algorithm.
+// Cost and recommendation are left; see RecommendRedacted for the reasons.
+func (r *OptimizeResponse) RedactPayouts() {
+	r.TotalPayoutCents = 0
+	r.NetProfitCents = 0
+	r.Score = 0
+	for i := range r.Alternatives {
+		r.Alternatives[i].RedactPayout()
+	}
+	for i := range r.PartialOrders {
+		r.PartialOrders[i].PayoutCents = 0
+	}
+	for i := range r.Portfolio {
+		r.Portfolio[i].Score = 0
+	}
+	r.PayoutRedacted = true
+}
+
+// RedactPayout zeroes the amounts derived from sealed payouts
+func (p *PlanSummary) RedactPayout() {
+	p.TotalPayoutCents = 0
+	p.NetProfitCents = 0
+}
+
+// PartialOrder is the share of a splittable order a plan loads
+type PartialOrder struct {
+	OrderID string `json:"order_id"`
+	// Fraction is the loaded share, between 0 and 1
+	Fraction    float64 `json:"fraction"`
+	WeightLbs   int     `json:"weight_lbs"`
+	VolumeCuft  int     `json:"volume_cuft"`
+	PayoutCents int64   `json:"payout_cents"`
+}
+
+// AlgorithmOutcome is how one algorithm of a portfolio solve fared
+type AlgorithmOutcome struct {
+	Algorithm string `json:"algorithm"`
+	// Status is completed, deadline (still running at the shared deadline)
+	// or superseded (still running when another algorithm proved its plan
+	// optimal)
+	Status string `json:"status"`
+	// Selected marks the algorithm whose plan was returned
+	Selected       bool  `json:"selected"`
+	Optimal        bool  `json:"optimal"`
+	OrdersSelected int   `json:"orders_selected"`
+	Score          int64 `json:"score"`
+	ComputeTimeMs  int64 `json:"compute_time_ms"`
+}
+
+// PlanSummary is one ranked load plan
+type PlanSummary struct {
+	Rank                     int      `json:"rank"`
 	SelectedOrderIDs         []string `json:"selected_order_ids"`
 	TotalPayoutCents         int64    `json:"total_payout_cents"`
 	TotalWeightLbs           int      `json:"total_weight_lbs"`
 	TotalVolumeCuft          int      `json:"total_volume_cuft"`
 	UtilizationWeightPercent float64  `json:"utilization_weight_percent"`
 	UtilizationVolumePercent float64  `json:"utilization_volume_percent"`
+	NetProfitCents           int64    `json:"net_profit_cents"`
+	EmissionsKgCO2           float64  `json:"emissions_kg_co2"`
+}
+
+// SolveProgress reports how far a running search has got: the best plan it
+// has found so far, and how many search nodes, or metaheuristic moves, it
+// has tried
+type SolveProgress struct {
+	Algorithm        string   `json:"algorithm"`
+	SelectedOrderIDs []string `json:"selected_order_ids"`
+	BestPayoutCents  int64    `json:"best_payout_cents"`
+	BestScore        int64    `json:"best_score"`
+	NodesExplored    int64    `json:"nodes_explored"`
+	ElapsedMs        int64    `json:"elapsed_ms"`
+	PayoutRedacted   bool     `json:"payout_redacted,omitempty"`
+}
+
+// RedactPayout zeroes the amounts derived from sealed payouts
+func (p *SolveProgress) RedactPayout() {
+	p.BestPayoutCents = 0
+	p.BestScore = 0
+	p.PayoutRedacted = true
 }
 
 type ErrorResponse struct {
@@ -133,13 +518,19 @@ func (s State) HasOrder(orderIndex int) bool {
 }
 
 func (r *OptimizeRequest) Validate() error {
+	return r.ValidateWith(DefaultValidationProfile(), time.Now())
+}
+
+// ValidateWith validates the request against a tenant's validation profile,
+// judging dates against now
+func (r *OptimizeRequest) ValidateWith(profile ValidationProfile, now time.Time) error {
 	if r.Truck.ID == "" {
 		return fmt.Errorf("truck id is required")
 	}
 	if r.Truck.MaxWeightLbs <= 0 {
 		return fmt.Errorf("truck max_weight_lbs must be positive")
 	}
-	if r.Truck.MaxWeightLbs > 1000000 {
+	if r.Truck.MaxWeightLbs > profile.MaxWeightLbs {
 		return fmt.Errorf("truck max_weight_lbs exceeds maximum allowed value")
 	}
 	if r.Truck.MaxVolumeCuft <= 0 {
@@ -148,8 +539,69 @@ func (r *OptimizeRequest) Validate() error {
 	if r.Truck.MaxVolumeCuft > 100000 {
 		return fmt.Errorf("truck max_volume_cuft exceeds maximum allowed value")
 	}
-	if len(r.Orders) > 22 {
-		return fmt.Errorf("orders list cannot exceed 22 items for optimal solution (got %d)", len(r.Orders))
+	if r.Truck.FixedCostCents < 0 {
+		return fmt.Errorf("truck fixed_cost_cents cannot be negative")
+	}
+	if r.Truck.FixedCostCents > 100000000000 {
+		return fmt.Errorf("truck fixed_cost_cents exceeds maximum allowed value")
+	}
+	if r.Truck.MaxLinearFeet < 0 || r.Truck.MaxLinearFeet > MaxLinearFeet {
+		return fmt.Errorf("truck max_linear_feet must be between 0 and %d", MaxLinearFeet)
+	}
+	if r.Truck.MaxPalletPositions < 0 || r.Truck.MaxPalletPositions > MaxPalletPositions {
+		return fmt.Errorf("truck max_pallet_positions must be between 0 and %d", MaxPalletPositions)
+	}
+	if r.Truck.StopFeeCents < 0 || r.Truck.StopFeeCents > 1000000 {
+		return fmt.Errorf("truck stop_fee_cents must be between 0 and 1000000")
+	}
+	if r.Truck.MaxOrders < 0 || r.Truck.MaxOrders > profile.MaxOrders {
+		return fmt.Errorf("truck max_orders must be between 0 and %d", profile.MaxOrders)
+	}
+	if err := validateEquipmentType(r.Truck.EquipmentType); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if err := validateEquipment("equipment", r.Truck.Equipment); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if err := validateDimensions("interior_", r.Truck.InteriorLengthIn, r.Truck.InteriorWidthIn, r.Truck.InteriorHeightIn); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if r.Truck.DriverPay != nil {
+		if err := r.Truck.DriverPay.Validate(); err != nil {
+			return fmt.Errorf("truck driver_pay: %w", err)
+		}
+	}
+	if r.Truck.CostPerMileCents < 0 || r.Truck.CostPerMileCents > 100000 {
+		return fmt.Errorf("truck cost_per_mile_cents must be between 0 and 100000")
+	}
+	if r.Truck.Fuel != nil {
+		if err := r.Truck.Fuel.Validate(); err != nil {
+			return fmt.Errorf("truck %w", err)
+		}
+	}
+	if err := r.Truck.validateCompartments(); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if err := validatePoint("position", r.Truck.Position); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if err := validatePoint("next_position", r.Truck.NextPosition); err != nil {
+		return fmt.Errorf("truck %w", err)
+	}
+	if r.Truck.Axles != nil {
+		if err := r.Truck.Axles.Validate(); err != nil {
+			return fmt.Errorf("truck axles: %w", err)
+		}
+	}
+	if r.Currency == "" {
+		r.Currency = "USD"
+	}
+	if !isCurrencyCode(r.Currency) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code")
+	}
+	
+	if len(r.Orders) > profile.MaxOrders {
+		return fmt.Errorf("orders list cannot exceed %d items (got %d)", profile.MaxOrders, len(r.Orders))
 	}
 	
 	seenIDs := make(map[string]bool)
@@ -159,10 +611,42 @@ func (r *OptimizeRequest) Validate() error {
 		}
 		seenIDs[order.ID] = true
 		
-		if err := order.Validate(); err != nil {
+		if err := order.validateWith(profile, now); err != nil {
 			return fmt.Errorf("order[%d]: %w", i, err)
 		}
 	}
+	if err := r.Pins().validate(seenIDs); err != nil {
+		return err
+	}
+	if len(r.Rules) > MaxRequestRules {
+		return fmt.Errorf("at most %d rules allowed", MaxRequestRules)
+	}
+	for i, rule := range r.Rules {
+		if err := rule.validate(seenIDs); err != nil {
+			return fmt.Errorf("rules[%d]: %w", i, err)
+		}
+	}
+	if err := r.validateFacilities(); err != nil {
+		return err
+	}
+	if err := r.validateStability(); err != nil {
+		return err
+	}
+	if err := validateDuplicatePolicy(r.DuplicatePolicy); err != nil {
+		return err
+	}
+	if err := r.MultiStop.validate(); err != nil {
+		return err
+	}
+	if err := validateDimFactor(r.DimFactor); err != nil {
+		return err
+	}
+	if err := r.validateLaneRisks(); err != nil {
+		return err
+	}
+	if err := r.Minimums().validate(); err != nil {
+		return err
+	}
 	
 	if r.OptimizationConfig != nil {
 		if err := r.OptimizationConfig.Validate(); err != nil {
@@ -170,9 +654,63 @@ func (r *OptimizeRequest) Validate() error {
 		}
 	}
 	
+	algorithm := "auto"
+	if r.OptimizationConfig != nil {
+		algorithm = r.OptimizationConfig.Algorithm
+	}
+	if limit := MaxOrdersForAlgorithm(algorithm); len(r.Orders) > limit {
+		return fmt.Errorf("orders list cannot exceed %d items for algorithm %s (got %d)", limit, algorithm, len(r.Orders))
+	}
+	
+	if r.K < 0 || r.K > MaxAlternatives {
+		return fmt.Errorf("k must be between 0 and %d", MaxAlternatives)
+	}
+	if limit := MaxOrdersForAlgorithm("dp"); r.K > 1 && len(r.Orders) > limit {
+		return fmt.Errorf("k > 1 supports at most %d orders (got %d)", limit, len(r.Orders))
+	}
+	
+	if r.DispatchThresholds != nil {
+		if err := r.DispatchThresholds.Validate(); err != nil {
+			return fmt.Errorf("dispatch_thresholds: %w", err)
+		}
+	}
+	
 	return nil
 }
 
+// MaxPriority is the highest order priority tier
+const MaxPriority = 5
+
+// MaxAlternatives is the largest k a request may ask for
+const MaxAlternatives = 20
+
+// MaxOrdersForAlgorithm is the largest order list an algorithm accepts. The
+// subset-enumerating algorithms are exponential in the number of orders.
+func MaxOrdersForAlgorithm(algorithm string) int {
+	switch algorithm {
+	case "dp", "backtracking":
+		return 22
+	case "meet_in_the_middle":
+		return 44
+	case "branch_and_bound":
+		return 50
+	default:
+		return 1000
+	}
+}
+
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, ch := range code {
+		if ch < 'A' || ch > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *OptimizationConfig) Validate() error {
 	if c.Objective == "" {
 		c.Objective = "revenue"
@@ -182,9 +720,10 @@ func (c *OptimizationConfig) Validate() error {
 		"revenue":     true,
 		"utilization": true,
 		"balanced":    true,
+		"profit":      true,
 	}
 	if !validObjectives[c.Objective] {
-		return fmt.Errorf("invalid objective: %s (must be revenue, utilization, or balanced)", c.Objective)
+		return fmt.Errorf("invalid objective: %s (must be revenue, utilization, balanced, or profit)", c.Objective)
 	}
 	
 	if c.RevenueWeight < 0 || c.RevenueWeight > 1 {
@@ -196,7 +735,7 @@ func (c *OptimizationConfig) Validate() error {
 	
 	if c.RevenueWeight == 0 && c.UtilizationWeight == 0 {
 		switch c.Objective {
-		case "revenue":
+		case "revenue", "profit":
 			c.RevenueWeight = 1.0
 			c.UtilizationWeight = 0.0
 		case "utilization":
@@ -211,33 +750,59 @@ func (c *OptimizationConfig) Validate() error {
 	if c.Algorithm == "" {
 		c.Algorithm = "auto"
 	}
-	validAlgorithms := map[string]bool{
-		"dp":           true,
-		"backtracking": true,
-		"greedy":       true,
-		"auto":         true,
+	if _, ok := LookupAlgorithm(c.Algorithm); !ok {
+		return fmt.Errorf("invalid algorithm: %s (must be dp, backtracking, greedy, greedy+ls, regret, grasp, tabu, portfolio, knapsack, branch_and_bound, meet_in_the_middle, or auto)", c.Algorithm)
+	}
+	
+	if c.TabuTenure < 0 || c.TabuTenure > 1000 {
+		return fmt.Errorf("tabu_tenure must be between 0 and 1000")
+	}
+	if c.TabuNeighborhood < 0 || c.TabuNeighborhood > 10000 {
+		return fmt.Errorf("tabu_neighborhood must be between 0 and 10000")
+	}
+	
+	if !(c.EmissionsWeight >= 0 && c.EmissionsWeight <= MaxEmissionsWeight) {
+		return fmt.Errorf("emissions_weight must be between 0 and %d", MaxEmissionsWeight)
+	}
+	
+	if !(c.DeadheadWeight >= 0 && c.DeadheadWeight <= MaxDeadheadWeight) {
+		return fmt.Errorf("deadhead_weight must be between 0 and %d", MaxDeadheadWeight)
 	}
-	if !validAlgorithms[c.Algorithm] {
-		return fmt.Errorf("invalid algorithm: %s (must be dp, backtracking, greedy, or auto)", c.Algorithm)
+	
+	if !(c.ExcursionWeight >= 0 && c.ExcursionWeight <= MaxExcursionWeight) {
+		return fmt.Errorf("excursion_weight must be between 0 and %d", MaxExcursionWeight)
+	}
+	
+	if c.PriorityMode != "" && c.PriorityMode != "lexicographic" {
+		return fmt.Errorf("invalid priority_mode: %s (must be lexicographic or omitted)", c.PriorityMode)
 	}
 	
 	return nil
 }
 
+// ByPriority reports whether order priorities override payout
+func (c *OptimizationConfig) ByPriority() bool {
+	return c != nil && c.PriorityMode == "lexicographic"
+}
+
 func (o *OrderInput) Validate() error {
+	return o.validateWith(DefaultValidationProfile(), time.Now())
+}
+
+func (o *OrderInput) validateWith(profile ValidationProfile, now time.Time) error {
 	if o.ID == "" {
 		return fmt.Errorf("order id is required")
 	}
 	if o.PayoutCents <= 0 {
 		return fmt.Errorf("payout_cents must be positive")
 	}
-	if o.PayoutCents > 100000000000 {
+	if o.PayoutCents > profile.MaxPayoutCents {
 		return fmt.Errorf("payout_cents exceeds maximum allowed value")
 	}
 	if o.WeightLbs <= 0 {
 		return fmt.Errorf("weight_lbs must be positive")
 	}
-	if o.WeightLbs > 1000000 {
+	if o.WeightLbs > profile.MaxWeightLbs {
 		return fmt.Errorf("weight_lbs exceeds maximum allowed value")
 	}
 	if o.VolumeCuft <= 0 {
@@ -246,6 +811,12 @@ func (o *OrderInput) Validate() error {
 	if o.VolumeCuft > 100000 {
 		return fmt.Errorf("volume_cuft exceeds maximum allowed value")
 	}
+	if o.LinearFeet < 0 || o.LinearFeet > MaxLinearFeet {
+		return fmt.Errorf("linear_feet must be between 0 and %d", MaxLinearFeet)
+	}
+	if o.PalletCount < 0 || o.PalletCount > MaxPalletCount {
+		return fmt.Errorf("pallet_count must be between 0 and %d", MaxPalletCount)
+	}
 	if o.Origin == "" || o.Destination == "" {
 		return fmt.Errorf("origin and destination are required")
 	}
@@ -255,27 +826,105 @@ func (o *OrderInput) Validate() error {
 	if len(o.ID) > 100 {
 		return fmt.Errorf("order id must be less than 100 characters")
 	}
+	if len(o.Shipper) > 200 {
+		return fmt.Errorf("shipper must be less than 200 characters")
+	}
+	if o.HazmatClass != "" && !ValidHazmatClass(o.HazmatClass) {
+		return fmt.Errorf("invalid hazmat_class: %s", o.HazmatClass)
+	}
+	if o.HazmatClass != "" && !o.IsHazmat {
+		return fmt.Errorf("hazmat_class requires is_hazmat")
+	}
+	if err := o.validateCommodity(); err != nil {
+		return err
+	}
+	if err := o.validateTemperature(); err != nil {
+		return err
+	}
+	if err := o.validateExposure(); err != nil {
+		return err
+	}
+	if err := validateEquipment("equipment_requirements", o.EquipmentRequirements); err != nil {
+		return err
+	}
+	if err := validateDimensions("", o.LengthIn, o.WidthIn, o.HeightIn); err != nil {
+		return err
+	}
+	if len(o.ExclusiveGroup) > 100 {
+		return fmt.Errorf("exclusive_group must be less than 100 characters")
+	}
+	if o.Priority < 0 || o.Priority > MaxPriority {
+		return fmt.Errorf("priority must be between 1 and %d", MaxPriority)
+	}
+	if o.Miles < 0 || o.Miles > 10000 {
+		return fmt.Errorf("miles must be between 0 and 10000")
+	}
+	if err := validatePoint("origin_point", o.OriginPoint); err != nil {
+		return err
+	}
+	if err := validatePoint("destination_point", o.DestinationPoint); err != nil {
+		return err
+	}
 	
-	pickup, err := time.Parse("2006-01-02", o.PickupDate)
+	if err := o.validateTimeWindows(); err != nil {
+		return err
+	}
+	pickup, err := time.Parse("2006-01-02", o.pickupDate())
 	if err != nil {
 		return fmt.Errorf("invalid pickup_date format (expected YYYY-MM-DD)")
 	}
-	delivery, err := time.Parse("2006-01-02", o.DeliveryDate)
+	delivery, err := time.Parse("2006-01-02", o.deliveryDate())
 	if err != nil {
 		return fmt.Errorf("invalid delivery_date format (expected YYYY-MM-DD)")
 	}
 	if delivery.Before(pickup) {
 		return fmt.Errorf("delivery_date cannot be before pickup_date")
 	}
+	if !profile.withinHorizon(pickup, now) || !profile.withinHorizon(delivery, now) {
+		return fmt.Errorf("pickup_date and delivery_date must be within %d days of today", profile.DateHorizonDays)
+	}
 	
 	return nil
 }
 
+// PayoutsSealed reports whether any order arrived with an encrypted payout
+func (r *OptimizeRequest) PayoutsSealed() bool {
+	for _, order := range r.Orders {
+		if order.PayoutEncrypted != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *OptimizeRequest) ToDomain() (*Truck, []Order, error) {
 	truck := &Truck{
-		ID:            r.Truck.ID,
-		MaxWeightLbs:  r.Truck.MaxWeightLbs,
-		MaxVolumeCuft: r.Truck.MaxVolumeCuft,
+		ID:                 r.Truck.ID,
+		MaxWeightLbs:       r.Truck.MaxWeightLbs,
+		MaxVolumeCuft:      r.Truck.MaxVolumeCuft,
+		FixedCost:          Money(r.Truck.FixedCostCents),
+		DriverPay:          r.Truck.DriverPay.ToDomain(),
+		CostPerMile:        Money(r.Truck.CostPerMileCents),
+		Fuel:               r.Truck.Fuel.ToDomain(),
+		StopFee:            Money(r.Truck.StopFeeCents),
+		MaxOrders:          r.Truck.MaxOrders,
+		MaxLinearFeet:      r.Truck.MaxLinearFeet,
+		MaxPalletPositions: r.Truck.MaxPalletPositions,
+		EquipmentType:      r.Truck.EquipmentType,
+		Equipment:          r.Truck.Equipment,
+		Interior:           dimensions(r.Truck.InteriorLengthIn, r.Truck.InteriorWidthIn, r.Truck.InteriorHeightIn),
+		Axles:              r.Truck.Axles.ToDomain(),
+		Compartments:       r.Truck.compartments(),
+		Position:           r.Truck.Position,
+		NextPosition:       r.Truck.NextPosition,
+	}
+	if truck.EquipmentType == "" {
+		truck.EquipmentType = EquipmentDry
+	}
+	for _, compartment := range truck.Compartments {
+		if compartment.Reefer {
+			truck.EquipmentType = EquipmentReefer
+		}
 	}
 	
 	orders := make([]Order, 0, len(r.Orders))
@@ -284,25 +933,61 @@ func (r *OptimizeRequest) ToDomain() (*Truck, []Order, error) {
 		if err != nil {
 			return nil, nil, err
 		}
+		order.chargeDimWeight(r.DimFactor)
 		orders = append(orders, order)
 	}
+	r.applyLaneRisks(orders)
 	
 	return truck, orders, nil
 }
 
+// OrderToDomain validates and converts one order joining the request's orders
+// later, the way ToDomain converts them
+func (r *OptimizeRequest) OrderToDomain(input OrderInput, profile ValidationProfile, now time.Time) (Order, error) {
+	if err := input.validateWith(profile, now); err != nil {
+		return Order{}, err
+	}
+	order, err := input.ToDomain()
+	if err != nil {
+		return Order{}, err
+	}
+	order.chargeDimWeight(r.DimFactor)
+	return order, nil
+}
+
 func (o *OrderInput) ToDomain() (Order, error) {
-	pickup, _ := time.Parse("2006-01-02", o.PickupDate)
-	delivery, _ := time.Parse("2006-01-02", o.DeliveryDate)
+	pickup, _ := time.Parse("2006-01-02", o.pickupDate())
+	delivery, _ := time.Parse("2006-01-02", o.deliveryDate())
+	pickupWindow, deliveryWindow := o.timeWindows(pickup, delivery)
 	
 	return Order{
-		ID:           o.ID,
-		Payout:       Money(o.PayoutCents),
-		WeightLbs:    o.WeightLbs,
-		VolumeCuft:   o.VolumeCuft,
-		Origin:       o.Origin,
-		Destination:  o.Destination,
-		PickupDate:   pickup,
-		DeliveryDate: delivery,
-		IsHazmat:     o.IsHazmat,
+		ID:                    o.ID,
+		Payout:                Money(o.PayoutCents),
+		Score:                 ScoreFromMoney(Money(o.PayoutCents)),
+		WeightLbs:             o.WeightLbs,
+		VolumeCuft:            o.VolumeCuft,
+		LinearFeet:            o.LinearFeet,
+		PalletCount:           o.PalletCount,
+		Stackable:             o.Stackable,
+		Origin:                o.Origin,
+		Destination:           o.Destination,
+		OriginPoint:           o.OriginPoint,
+		DestinationPoint:      o.DestinationPoint,
+		PickupDate:            pickup,
+		DeliveryDate:          delivery,
+		PickupWindow:          pickupWindow,
+		DeliveryWindow:        deliveryWindow,
+		IsHazmat:              o.IsHazmat,
+		HazmatClass:           o.HazmatClass,
+		Commodity:             o.commodity(),
+		Temperature:           o.temperatureRange(),
+		EquipmentRequirements: o.EquipmentRequirements,
+		Dimensions:            dimensions(o.LengthIn, o.WidthIn, o.HeightIn),
+		Miles:                 o.Miles,
+		Shipper:               o.Shipper,
+		ExclusiveGroup:        o.ExclusiveGroup,
+		Splittable:            o.Splittable,
+		Priority:              o.Priority,
+		MaxExposureHours:      o.MaxExposureHours,
 	}, nil
 }
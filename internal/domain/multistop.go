@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxMultiStops bounds multi_stop.max_stops
+const MaxMultiStops = 20
+
+// MultiStopInput lets orders on different routes share a truck, making up to
+// MaxStops distinct pickup and delivery stops. Orders combine when they run
+// along the same corridor: their origins lie in one region and their
+// destinations in one region, so Los Angeles, CA->Dallas, TX rides with San
+// Diego, CA->Houston, TX. With CorridorMiles, geocoded orders are held to
+// the road instead: see CorridorMatch. LIFO checks that the load can come
+// off last in, first out: see LIFOUnload. MaxDetourMiles and
+// MaxDetourPercent bound how far out of its way each order is taken: see
+// DetourLimit.
+type MultiStopInput struct {
+	MaxStops         int     `json:"max_stops"`
+	CorridorMiles    float64 `json:"corridor_miles,omitempty"`
+	LIFO             string  `json:"lifo,omitempty"`
+	MaxDetourMiles   float64 `json:"max_detour_miles,omitempty"`
+	MaxDetourPercent float64 `json:"max_detour_percent,omitempty"`
+}
+
+// MaxCorridorMiles bounds multi_stop.corridor_miles
+const MaxCorridorMiles = 500
+
+func (m *MultiStopInput) validate() error {
+	if m == nil {
+		return nil
+	}
+	if m.MaxStops < 2 || m.MaxStops > MaxMultiStops {
+		return fmt.Errorf("multi_stop: max_stops must be between 2 and %d", MaxMultiStops)
+	}
+	if math.IsNaN(m.CorridorMiles) || m.CorridorMiles < 0 || m.CorridorMiles > MaxCorridorMiles {
+		return fmt.Errorf("multi_stop: corridor_miles must be between 0 and %d", MaxCorridorMiles)
+	}
+	if err := validateLIFO(m.LIFO); err != nil {
+		return fmt.Errorf("multi_stop: %w", err)
+	}
+	if err := m.validateDetour(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// region is the last word of a location, the state in "Dallas, TX" or
+// "Dallas TX"
+func region(location string) string {
+	words := strings.Fields(NormalizeAddress(location))
+	if len(words) == 0 {
+		return ""
+	}
+	return words[len(words)-1]
+}
+
+// CorridorMatch replaces RouteMatch in multi-stop mode: orders combine when
+// their origins share a region and their destinations share a region. With
+// CorridorMiles set and both orders geocoded, the shorter order must instead
+// lie along the longer one's route: both its ends within CorridorMiles of
+// the straight line between the longer order's ends, its pickup no further
+// along than its delivery.
+type CorridorMatch struct {
+	CorridorMiles float64
+}
+
+func (CorridorMatch) Name() string { return "corridor" }
+
+func (c CorridorMatch) Allows(a, b Order) bool {
+	if c.CorridorMiles > 0 && a.geocoded() && b.geocoded() {
+		if a.routeMiles() < b.routeMiles() {
+			a, b = b, a
+		}
+		pickupOff, pickupAlong := segmentDistance(*b.OriginPoint, *a.OriginPoint, *a.DestinationPoint)
+		deliveryOff, deliveryAlong := segmentDistance(*b.DestinationPoint, *a.OriginPoint, *a.DestinationPoint)
+		return pickupOff <= c.CorridorMiles && deliveryOff <= c.CorridorMiles && pickupAlong <= deliveryAlong
+	}
+	return region(a.Origin) == region(b.Origin) && region(a.Destination) == region(b.Destination)
+}
+
+// StopLimit caps the distinct pickup and delivery stops of a plan, counted
+// as PlanStops counts them. Dropping an order never adds a stop, so the rule
+// is monotone.
+type StopLimit struct {
+	MaxStops int
+}
+
+func (StopLimit) Name() string { return "max_stops" }
+
+func (l StopLimit) AllowsSet(orders []Order) bool {
+	return PlanStops(orders) <= l.MaxStops
+}
@@ -0,0 +1,51 @@
+package domain
+
+import "testing"
+
+func TestCorridorMatch(t *testing.T) {
+	order := func(origin, destination string) Order {
+		return Order{Origin: origin, Destination: destination}
+	}
+	tests := []struct {
+		a, b Order
+		want bool
+	}{
+		{order("Los Angeles, CA", "Dallas, TX"), order("San Diego, CA", "Houston, TX"), true},
+		{order("Los Angeles, CA", "Dallas, TX"), order("los angeles, ca", "Austin,TX "), true},
+		{order("Los Angeles, CA", "Dallas, TX"), order("Phoenix, AZ", "Dallas, TX"), false},
+		{order("Los Angeles, CA", "Dallas, TX"), order("Los Angeles, CA", "Tulsa, OK"), false},
+		{order("Depot 7", "Dallas, TX"), order("Depot 7", "Houston, TX"), true},
+	}
+	for _, tt := range tests {
+		if got := (CorridorMatch{}).Allows(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s with %s allowed = %v, want %v", tt.a.Route(), tt.b.Route(), got, tt.want)
+		}
+	}
+}
+
+func TestStopLimit(t *testing.T) {
+	orders := []Order{
+		{Origin: "Los Angeles, CA", Destination: "Dallas, TX"},
+		{Origin: "Los Angeles, CA", Destination: "Houston, TX"},
+		{Origin: "San Diego, CA", Destination: "Houston, TX"},
+	}
+	limit := StopLimit{MaxStops: 3}
+	if !limit.AllowsSet(orders[:2]) {
+		t.Error("3 stops rejected under a limit of 3")
+	}
+	if limit.AllowsSet(orders) {
+		t.Error("4 stops allowed under a limit of 3")
+	}
+}
+
+func TestMultiStopValidation(t *testing.T) {
+	for _, maxStops := range []int{0, 1, MaxMultiStops + 1} {
+		if err := (&MultiStopInput{MaxStops: maxStops}).validate(); err == nil {
+			t.Errorf("max_stops %d accepted", maxStops)
+		}
+	}
+	var none *MultiStopInput
+	if err := none.validate(); err != nil {
+		t.Errorf("no multi_stop rejected: %v", err)
+	}
+}
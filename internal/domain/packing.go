@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MaxDimensionIn bounds every length, width and height, in inches
+const MaxDimensionIn = 1200
+
+// Dimensions are a length, width and height in inches. For a truck they
+// are the trailer's interior: length runs front to back and width across.
+type Dimensions struct {
+	LengthIn int
+	WidthIn  int
+	HeightIn int
+}
+
+func (d Dimensions) String() string {
+	return fmt.Sprintf("%dx%dx%d in", d.LengthIn, d.WidthIn, d.HeightIn)
+}
+
+// dimensions is nil when none of the three is set; validation has made sure
+// they are set together
+func dimensions(length, width, height int) *Dimensions {
+	if length == 0 && width == 0 && height == 0 {
+		return nil
+	}
+	return &Dimensions{LengthIn: length, WidthIn: width, HeightIn: height}
+}
+
+// validateDimensions checks that fields, named by prefix, are all unset or
+// all between 1 and MaxDimensionIn
+func validateDimensions(prefix string, length, width, height int) error {
+	if length == 0 && width == 0 && height == 0 {
+		return nil
+	}
+	for _, value := range []int{length, width, height} {
+		if value < 1 || value > MaxDimensionIn {
+			return fmt.Errorf("%slength_in, %swidth_in and %sheight_in must be set together, each between 1 and %d",
+				prefix, prefix, prefix, MaxDimensionIn)
+		}
+	}
+	return nil
+}
+
+// footprint places the order on the trailer floor, turned so it takes the
+// least trailer length: across is the room it takes side to side and along
+// front to back. ok is false when it cannot stand in the trailer at all.
+func footprint(order Dimensions, interior Dimensions) (across, along int, ok bool) {
+	if order.HeightIn > interior.HeightIn {
+		return 0, 0, false
+	}
+	long, short := order.LengthIn, order.WidthIn
+	if short > long {
+		long, short = short, long
+	}
+	if long <= interior.WidthIn && short <= interior.LengthIn {
+		return long, short, true
+	}
+	if short <= interior.WidthIn && long <= interior.LengthIn {
+		return short, long, true
+	}
+	return 0, 0, false
+}
+
+// tooLarge says why the order cannot stand in the truck's trailer on its
+// own, or returns "" when it can or either has no dimensions
+func (t Truck) tooLarge(order Order) string {
+	if t.Interior == nil || order.Dimensions == nil {
+		return ""
+	}
+	if _, _, ok := footprint(*order.Dimensions, *t.Interior); !ok {
+		return fmt.Sprintf("measures %s, too large for the truck's %s interior", order.Dimensions, t.Interior)
+	}
+	return ""
+}
+
+// ShelfPacking checks that the orders with dimensions can be laid out on
+// the trailer floor, so a plan that fits by cube can also be loaded. Orders
+// are placed without stacking in rows across the trailer, deepest first,
+// each row as deep as its first order (next-fit decreasing height shelves).
+// The heuristic can turn down a load a crew would fit, never the reverse.
+// Dropping an order never lengthens the rows, so the rule is monotone.
+// Orders without dimensions take only volume.
+type ShelfPacking struct {
+	Interior Dimensions
+}
+
+func (ShelfPacking) Name() string { return "packing" }
+
+func (p ShelfPacking) AllowsSet(orders []Order) bool {
+	type item struct{ across, along int }
+	items := make([]item, 0, len(orders))
+	for _, order := range orders {
+		if order.Dimensions == nil {
+			continue
+		}
+		across, along, ok := footprint(*order.Dimensions, p.Interior)
+		if !ok {
+			return false
+		}
+		items = append(items, item{across: across, along: along})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].along > items[j].along
+	})
+	
+	used, rowRoom := 0, 0
+	for i, it := range items {
+		if i > 0 && it.across <= rowRoom {
+			rowRoom -= it.across
+			continue
+		}
+		used += it.along
+		if used > p.Interior.LengthIn {
+			return false
+		}
+		rowRoom = p.Interior.WidthIn - it.across
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShelfPacking(t *testing.T) {
+	// A 53' trailer: 636 x 100 x 110 inches
+	packing := ShelfPacking{Interior: Dimensions{LengthIn: 636, WidthIn: 100, HeightIn: 110}}
+	crate := func(id string, length, width, height int) Order {
+		return Order{ID: id, Dimensions: &Dimensions{LengthIn: length, WidthIn: width, HeightIn: height}}
+	}
+	pallets := func(n int) []Order {
+		orders := make([]Order, n)
+		for i := range orders {
+			orders[i] = crate("pallet", 48, 40, 60)
+		}
+		return orders
+	}
+	
+	tests := []struct {
+		name   string
+		orders []Order
+		want   bool
+	}{
+		{"empty trailer", nil, true},
+		{"no dimensions", []Order{{ID: "loose"}, {ID: "bulk"}}, true},
+		// Pallets go 48 inches across, two abreast in rows 40 inches deep
+		{"30 pallets two abreast", pallets(30), true},
+		{"31 pallets", pallets(31), false},
+		{"too tall", []Order{crate("tall", 40, 40, 120)}, false},
+		{"long beam along the trailer", []Order{crate("beam", 600, 20, 20)}, true},
+		{"two beams side by side", []Order{crate("beam", 600, 20, 20), crate("beam", 600, 50, 20)}, true},
+		{"beams end to end", []Order{crate("beam", 600, 60, 20), crate("beam", 600, 60, 20)}, false},
+	}
+	for _, tt := range tests {
+		if got := packing.AllowsSet(tt.orders); got != tt.want {
+			t.Errorf("%s: AllowsSet = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOrdersTooLargeForTheTrailer(t *testing.T) {
+	truck := Truck{MaxWeightLbs: 44000, MaxVolumeCuft: 3000, Interior: &Dimensions{LengthIn: 636, WidthIn: 100, HeightIn: 110}}
+	orders := []Order{
+		{ID: "fits", WeightLbs: 100, VolumeCuft: 10, Dimensions: &Dimensions{LengthIn: 48, WidthIn: 40, HeightIn: 60}},
+		{ID: "tall", WeightLbs: 100, VolumeCuft: 10, Dimensions: &Dimensions{LengthIn: 48, WidthIn: 40, HeightIn: 111}},
+		{ID: "wide", WeightLbs: 100, VolumeCuft: 10, Dimensions: &Dimensions{LengthIn: 700, WidthIn: 101, HeightIn: 10}},
+		{ID: "loose", WeightLbs: 100, VolumeCuft: 10},
+	}
+	
+	feasible, infeasible := SplitFeasibleOrders(truck, orders)
+	if len(feasible) != 2 || feasible[0].ID != "fits" || feasible[1].ID != "loose" {
+		t.Fatalf("feasible = %v, want fits and loose", feasible)
+	}
+	if len(infeasible) != 2 || infeasible[0].Reason != "measures 48x40x111 in, too large for the truck's 636x100x110 in interior" {
+		t.Fatalf("infeasible = %v", infeasible)
+	}
+	if NewConstraintChecker().CanFit(truck, 0, 0, orders[1]) {
+		t.Error("CanFit accepted an order taller than the trailer")
+	}
+	
+	truck.Interior = nil
+	if feasible, _ := SplitFeasibleOrders(truck, orders); len(feasible) != 4 {
+		t.Errorf("without an interior %d orders fit, want all 4", len(feasible))
+	}
+}
+
+func TestDimensionValidation(t *testing.T) {
+	if err := validateDimensions("", 0, 0, 0); err != nil {
+		t.Errorf("unset dimensions rejected: %v", err)
+	}
+	if err := validateDimensions("", 48, 40, 60); err != nil {
+		t.Errorf("valid dimensions rejected: %v", err)
+	}
+	for _, dims := range [][3]int{{48, 40, 0}, {-1, 40, 60}, {48, MaxDimensionIn + 1, 60}} {
+		err := validateDimensions("interior_", dims[0], dims[1], dims[2])
+		if err == nil || !strings.Contains(err.Error(), "interior_length_in, interior_width_in and interior_height_in") {
+			t.Errorf("dimensions %v: err = %v", dims, err)
+		}
+	}
+}
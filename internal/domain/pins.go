@@ -0,0 +1,111 @@
+package domain
+
+import "fmt"
+
+// Pins are the orders a dispatcher has already decided on: Include orders are
+// committed and must be in the plan, Exclude orders must stay out of it
+type Pins struct {
+	Include []string
+	Exclude []string
+}
+
+// Pins returns the request's must_include/must_exclude lists
+func (r *OptimizeRequest) Pins() Pins {
+	return Pins{Include: r.MustIncludeOrderIDs, Exclude: r.MustExcludeOrderIDs}
+}
+
+func (p Pins) Empty() bool {
+	return len(p.Include) == 0 && len(p.Exclude) == 0
+}
+
+// validate checks that every pinned ID names an order and none is both
+// included and excluded
+func (p Pins) validate(orderIDs map[string]bool) error {
+	included := make(map[string]bool, len(p.Include))
+	for _, id := range p.Include {
+		if !orderIDs[id] {
+			return fmt.Errorf("must_include_order_ids: unknown order id %s", id)
+		}
+		if included[id] {
+			return fmt.Errorf("must_include_order_ids: duplicate order id %s", id)
+		}
+		included[id] = true
+	}
+	
+	excluded := make(map[string]bool, len(p.Exclude))
+	for _, id := range p.Exclude {
+		if !orderIDs[id] {
+			return fmt.Errorf("must_exclude_order_ids: unknown order id %s", id)
+		}
+		if excluded[id] {
+			return fmt.Errorf("must_exclude_order_ids: duplicate order id %s", id)
+		}
+		if included[id] {
+			return fmt.Errorf("order %s is in both must_include_order_ids and must_exclude_order_ids", id)
+		}
+		excluded[id] = true
+	}
+	return nil
+}
+
+// Apply removes excluded orders and checks that the included ones, all of
+// which must still be among orders, can share the truck. Orders that cannot be
+// combined with every included order are dropped, since no plan could hold them.
+func (p Pins) Apply(checker ConstraintChecker, truck Truck, orders []Order) ([]Order, error) {
+	if p.Empty() {
+		return orders, nil
+	}
+	
+	excluded := make(map[string]bool, len(p.Exclude))
+	for _, id := range p.Exclude {
+		excluded[id] = true
+	}
+	byID := make(map[string]Order, len(orders))
+	for _, order := range orders {
+		byID[order.ID] = order
+	}
+	
+	pinned := make([]Order, 0, len(p.Include))
+	weight, volume := 0, 0
+	for _, id := range p.Include {
+		order, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("must_include order %s cannot be loaded (the truck cannot carry it or tenant settings exclude it)", id)
+		}
+		for _, other := range pinned {
+			if !checker.CanCombine(order, other) {
+				return nil, fmt.Errorf("must_include orders %s and %s cannot share a truck", other.ID, order.ID)
+			}
+		}
+		pinned = append(pinned, order)
+		weight += order.WeightLbs
+		volume += order.VolumeCuft
+	}
+	if !checker.ValidateOrderSet(pinned) {
+		return nil, fmt.Errorf("must_include orders break a rule on orders sharing a truck")
+	}
+	if weight > truck.MaxWeightLbs || volume > truck.MaxVolumeCuft {
+		return nil, fmt.Errorf("must_include orders need %d lbs and %d cuft, more than the truck holds", weight, volume)
+	}
+	if truck.MaxOrders > 0 && len(pinned) > truck.MaxOrders {
+		return nil, fmt.Errorf("must_include lists %d orders, more than the truck's max_orders %d", len(pinned), truck.MaxOrders)
+	}
+	
+	kept := make([]Order, 0, len(orders))
+	for _, order := range orders {
+		if excluded[order.ID] {
+			continue
+		}
+		compatible := true
+		for _, fixed := range pinned {
+			if order.ID != fixed.ID && !checker.CanCombine(order, fixed) {
+				compatible = false
+				break
+			}
+		}
+		if compatible {
+			kept = append(kept, order)
+		}
+	}
+	return kept, nil
+}
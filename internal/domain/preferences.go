@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreferredLane rewards orders that keep a truck inside a tenant's core network.
+// Empty fields match anything; Region matches the tail of the destination
+// (e.g. "TX" matches "Dallas, TX").
+type PreferredLane struct {
+	Origin      string `json:"origin,omitempty"`
+	Destination string `json:"destination,omitempty"`
+	Region      string `json:"region,omitempty"`
+	BonusCents  int64  `json:"bonus_cents"`
+}
+
+func (p PreferredLane) Validate() error {
+	if p.Origin == "" && p.Destination == "" && p.Region == "" {
+		return fmt.Errorf("preferred lane needs an origin, destination, or region")
+	}
+	if p.BonusCents <= 0 {
+		return fmt.Errorf("bonus_cents must be positive")
+	}
+	if p.BonusCents > 100000000 {
+		return fmt.Errorf("bonus_cents exceeds maximum allowed value")
+	}
+	return nil
+}
+
+func (p PreferredLane) Matches(order Order) bool {
+	if p.Origin != "" && !sameLocation(p.Origin, order.Origin) {
+		return false
+	}
+	if p.Destination != "" && !sameLocation(p.Destination, order.Destination) {
+		return false
+	}
+	if p.Region != "" && !strings.HasSuffix(normalizeLocation(order.Destination), normalizeLocation(p.Region)) {
+		return false
+	}
+	return true
+}
+
+func (p PreferredLane) Describe() string {
+	parts := make([]string, 0, 3)
+	if p.Origin != "" {
+		parts = append(parts, "origin "+p.Origin)
+	}
+	if p.Destination != "" {
+		parts = append(parts, "destination "+p.Destination)
+	}
+	if p.Region != "" {
+		parts = append(parts, "region "+p.Region)
+	}
+	return "preferred lane (" + strings.Join(parts, ", ") + ")"
+}
+
+// PreferredShipper rewards every order tendered by a shipper the tenant wants to serve
+type PreferredShipper struct {
+	Shipper    string `json:"shipper"`
+	BonusCents int64  `json:"bonus_cents"`
+}
+
+func (p PreferredShipper) Validate() error {
+	if strings.TrimSpace(p.Shipper) == "" {
+		return fmt.Errorf("shipper is required")
+	}
+	if p.BonusCents <= 0 {
+		return fmt.Errorf("bonus_cents must be positive")
+	}
+	if p.BonusCents > 100000000 {
+		return fmt.Errorf("bonus_cents exceeds maximum allowed value")
+	}
+	return nil
+}
+
+// AppliedBonus records a score bonus granted to an order
+type AppliedBonus struct {
+	OrderID    string `json:"order_id"`
+	Reason     string `json:"reason"`
+	BonusCents int64  `json:"bonus_cents"`
+}
+
+// ExcludedOrder records an order removed from consideration before optimizing
+type ExcludedOrder struct {
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+}
+
+// Explanation describes the adjustments that influenced a solution
+type Explanation struct {
+	AppliedBonuses []AppliedBonus  `json:"applied_bonuses,omitempty"`
+	ExcludedOrders []ExcludedOrder `json:"excluded_orders,omitempty"`
+}
+
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.TrimSpace(location))
+}
+
+func sameLocation(a, b string) bool {
+	return normalizeLocation(a) == normalizeLocation(b)
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "fmt"
+
+const (
+	RecommendationDispatch = "dispatch"
+	RecommendationHold     = "hold"
+)
+
+// DispatchThresholds are the minimums a plan must meet before the truck is sent out
+type DispatchThresholds struct {
+	MinPayoutCents        int64   `json:"min_payout_cents"`
+	MinUtilizationPercent float64 `json:"min_utilization_percent"`
+	MinMarginPercent      float64 `json:"min_margin_percent"`
+}
+
+func (t *DispatchThresholds) Validate() error {
+	if t.MinPayoutCents < 0 {
+		return fmt.Errorf("min_payout_cents cannot be negative")
+	}
+	if t.MinUtilizationPercent < 0 || t.MinUtilizationPercent > 100 {
+		return fmt.Errorf("min_utilization_percent must be between 0 and 100")
+	}
+	if t.MinMarginPercent < -100 || t.MinMarginPercent > 100 {
+		return fmt.Errorf("min_margin_percent must be between -100 and 100")
+	}
+	return nil
+}
+
+// Recommend decides whether a plan should be dispatched. Utilization is judged
+// on the binding dimension (the fuller of weight and volume), and margin is net
+// profit as a percentage of payout. Reasons are only returned for a hold.
+func Recommend(
+	thresholds *DispatchThresholds,
+	payout Money,
+	cost CostBreakdown,
+	utilizationWeight float64,
+	utilizationVolume float64,
+) (string, []string) {
+	return recommend(thresholds, payout, cost, utilizationWeight, utilizationVolume, false)
+}
+
+// RecommendRedacted makes the same decision as Recommend for sealed payouts.
+// Its reasons name the failed check without the payout, margin or any other
+// amount the payout could be worked back from.
+func RecommendRedacted(
+	thresholds *DispatchThresholds,
+	payout Money,
+	cost CostBreakdown,
+	utilizationWeight float64,
+	utilizationVolume float64,
+) (string, []string) {
+	return recommend(thresholds, payout, cost, utilizationWeight, utilizationVolume, true)
+}
+
+func recommend(
+	thresholds *DispatchThresholds,
+	payout Money,
+	cost CostBreakdown,
+	utilizationWeight float64,
+	utilizationVolume float64,
+	redacted bool,
+) (string, []string) {
+	if payout == 0 {
+		return RecommendationHold, []string{"no orders selected"}
+	}
+	
+	reasons := make([]string, 0)
+	for _, lane := range cost.TollsUnavailable {
+		reasons = append(reasons, fmt.Sprintf("toll estimate unavailable for %s, so operating cost is understated", lane))
+	}
+	if !cost.IsWorthDispatching(payout) {
+		if redacted {
+			reasons = append(reasons, "payout does not cover operating cost")
+		} else {
+			reasons = append(reasons, fmt.Sprintf("payout %s does not cover operating cost %s",
+				payout.ToDollars(), cost.Total().ToDollars()))
+		}
+	}
+	
+	if thresholds != nil {
+		if int64(payout) < thresholds.MinPayoutCents {
+			if redacted {
+				reasons = append(reasons, "payout is below minimum")
+			} else {
+				reasons = append(reasons, fmt.Sprintf("payout %s is below minimum %s",
+					payout.ToDollars(), Money(thresholds.MinPayoutCents).ToDollars()))
+			}
+		}
+		
+		utilization := utilizationWeight
+		if utilizationVolume > utilization {
+			utilization = utilizationVolume
+		}
+		if utilization < thresholds.MinUtilizationPercent {
+			reasons = append(reasons, fmt.Sprintf("utilization %.2f%% is below minimum %.2f%%",
+				utilization, thresholds.MinUtilizationPercent))
+		}
+		
+		margin := float64(cost.NetProfit(payout)) / float64(payout) * 100
+		if margin < thresholds.MinMarginPercent {
+			if redacted {
+				reasons = append(reasons, "margin is below minimum")
+			} else {
+				reasons = append(reasons, fmt.Sprintf("margin %.2f%% is below minimum %.2f%%",
+					margin, thresholds.MinMarginPercent))
+			}
+		}
+	}
+	
+	if len(reasons) > 0 {
+		return RecommendationHold, reasons
+	}
+	return RecommendationDispatch, nil
+}
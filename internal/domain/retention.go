@@ -0,0 +1,21 @@
+package domain
+
+import "fmt"
+
+// MaxRetentionDays bounds every retention period, about ten years
+const MaxRetentionDays = 3650
+
+// RetentionPolicy says how long a tenant's data is kept. HistoryDays covers
+// solve history, which also holds the solutions served by
+// /history/solutions; 0 keeps records until the history store's own
+// capacity pushes them out.
+type RetentionPolicy struct {
+	HistoryDays int `json:"history_days"`
+}
+
+func (p RetentionPolicy) Validate() error {
+	if p.HistoryDays < 0 || p.HistoryDays > MaxRetentionDays {
+		return fmt.Errorf("history_days must be between 0 and %d", MaxRetentionDays)
+	}
+	return nil
+}
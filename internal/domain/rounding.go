@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RoundingMode decides how response figures are rounded when a value falls
+// exactly halfway between two results
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds halves away from zero: 2.345 becomes 2.35 and
+	// -2.345 becomes -2.35
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundHalfEven rounds halves to the even neighbor, banker's rounding:
+	// 2.345 becomes 2.34 and 2.355 becomes 2.36
+	RoundHalfEven RoundingMode = "half_even"
+)
+
+// ParseRoundingMode reads a rounding mode by name; empty is RoundHalfUp
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	switch mode := RoundingMode(name); mode {
+	case "":
+		return RoundHalfUp, nil
+	case RoundHalfUp, RoundHalfEven:
+		return mode, nil
+	}
+	return "", fmt.Errorf("invalid rounding mode: %s (must be half_up or half_even)", name)
+}
+
+// Round rounds value to places decimal places. It works on the shortest
+// decimal that reads back as value, so 2.675 rounds as the 2.675 a client
+// sent rather than the 2.67499... a float64 holds, and values too large to
+// have a fractional part come back unchanged.
+func (m RoundingMode) Round(value float64, places int) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	
+	whole, fraction, _ := strings.Cut(strconv.FormatFloat(math.Abs(value), 'f', -1, 64), ".")
+	if len(fraction) <= places {
+		return value
+	}
+	digits := []byte(whole + fraction[:places])
+	rest := fraction[places:]
+	
+	var up bool
+	switch {
+	case rest[0] != '5':
+		up = rest[0] > '5'
+	case strings.TrimRight(rest[1:], "0") != "":
+		up = true
+	case m == RoundHalfEven:
+		up = (digits[len(digits)-1]-'0')%2 == 1
+	default:
+		up = true
+	}
+	if up {
+		i := len(digits) - 1
+		for ; i >= 0 && digits[i] == '9'; i-- {
+			digits[i] = '0'
+		}
+		if i < 0 {
+			digits = append([]byte{'1'}, digits...)
+		} else {
+			digits[i]++
+		}
+	}
+	
+	split := len(digits) - places
+	rounded, _ := strconv.ParseFloat(string(digits[:split])+"."+string(digits[split:]), 64)
+	// Negative values that round to zero come back as 0, not -0
+	if value < 0 && rounded != 0 {
+		return -rounded
+	}
+	return rounded
+}
+
+// RoundCents rounds an amount of cents to whole cents
+func (m RoundingMode) RoundCents(cents float64) Money {
+	return Money(m.Round(cents, 0))
+}
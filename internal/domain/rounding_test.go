@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		value  float64
+		places int
+		halfUp float64
+		even   float64
+	}{
+		{2.345, 2, 2.35, 2.34},
+		{2.355, 2, 2.36, 2.36},
+		{2.675, 2, 2.68, 2.68},
+		{1.005, 2, 1.01, 1},
+		{-2.345, 2, -2.35, -2.34},
+		{-0.001, 2, 0, 0},
+		{99.995, 2, 100, 100},
+		{0.5, 0, 1, 0},
+		{1.5, 0, 2, 2},
+		{12.3456, 2, 12.35, 12.35},
+		{12.3449, 2, 12.34, 12.34},
+		{0.33333333, 4, 0.3333, 0.3333},
+		{1e20, 2, 1e20, 1e20},
+		{-9.2e18, 2, -9.2e18, -9.2e18},
+	}
+	for _, tt := range tests {
+		if got := RoundHalfUp.Round(tt.value, tt.places); got != tt.halfUp || math.Signbit(got) != math.Signbit(tt.halfUp) {
+			t.Errorf("half_up Round(%v, %d) = %v, want %v", tt.value, tt.places, got, tt.halfUp)
+		}
+		if got := RoundHalfEven.Round(tt.value, tt.places); got != tt.even || math.Signbit(got) != math.Signbit(tt.even) {
+			t.Errorf("half_even Round(%v, %d) = %v, want %v", tt.value, tt.places, got, tt.even)
+		}
+	}
+	
+	if got := RoundHalfUp.RoundCents(250.5); got != 251 {
+		t.Errorf("RoundCents(250.5) = %d, want 251", got)
+	}
+	if got := RoundHalfEven.RoundCents(250.5); got != 250 {
+		t.Errorf("half_even RoundCents(250.5) = %d, want 250", got)
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for name, want := range map[string]RoundingMode{"": RoundHalfUp, "half_up": RoundHalfUp, "half_even": RoundHalfEven} {
+		if got, err := ParseRoundingMode(name); err != nil || got != want {
+			t.Errorf("ParseRoundingMode(%q) = %q, %v", name, got, err)
+		}
+	}
+	if _, err := ParseRoundingMode("bankers"); err == nil {
+		t.Error("unknown mode accepted")
+	}
+}
+
+func TestToDollars(t *testing.T) {
+	tests := map[Money]string{
+		0:                "$0.00",
+		5:                "$0.05",
+		123456:           "$1234.56",
+		-150:             "-$1.50",
+		9007199254740993: "$90071992547409.93",
+		math.MinInt64:    "-$92233720368547758.08",
+	}
+	for cents, want := range tests {
+		if got := cents.ToDollars(); got != want {
+			t.Errorf("Money(%d).ToDollars() = %s, want %s", cents, got, want)
+		}
+	}
+}
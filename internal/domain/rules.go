@@ -0,0 +1,342 @@
+package domain
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PairRule decides whether two orders may share a truck
+type PairRule interface {
+	Name() string
+	Allows(a, b Order) bool
+}
+
+// SetRule decides whether a whole selection may share a truck, for limits no
+// pair of orders breaks on its own. Set rules must be monotone: dropping
+// orders from an allowed selection never makes it disallowed.
+type SetRule interface {
+	Name() string
+	AllowsSet(orders []Order) bool
+}
+
+// RouteMatch keeps every order on a truck on the same origin and destination.
+// Locations match by name regardless of case and punctuation, and geocoded
+// ones also when they lie within SameLocationMiles of each other.
+type RouteMatch struct{}
+
+func (RouteMatch) Name() string { return "route" }
+
+func (RouteMatch) Allows(a, b Order) bool {
+	return samePlace(a.Origin, a.OriginPoint, b.Origin, b.OriginPoint) &&
+		samePlace(a.Destination, a.DestinationPoint, b.Destination, b.DestinationPoint)
+}
+
+// HazmatMatch keeps hazmat and non-hazmat orders on separate trucks
+type HazmatMatch struct{}
+
+func (HazmatMatch) Name() string { return "hazmat" }
+
+func (HazmatMatch) Allows(a, b Order) bool {
+	return a.IsHazmat == b.IsHazmat
+}
+
+// ExclusiveGroups allows at most one order of each exclusive group
+type ExclusiveGroups struct{}
+
+func (ExclusiveGroups) Name() string { return "exclusive_group" }
+
+func (ExclusiveGroups) Allows(a, b Order) bool {
+	return a.ExclusiveGroup == "" || a.ExclusiveGroup != b.ExclusiveGroup
+}
+
+// PickupWithin only combines orders picked up at most Days days apart
+type PickupWithin struct {
+	Days int
+}
+
+func (PickupWithin) Name() string { return "pickup_within_days" }
+
+func (p PickupWithin) Allows(a, b Order) bool {
+	daysDiff := a.PickupDate.Sub(b.PickupDate).Hours() / 24
+	if daysDiff < 0 {
+		daysDiff = -daysDiff
+	}
+	return daysDiff <= float64(p.Days)
+}
+
+// KeepApart puts no two of the listed orders on the same truck
+type KeepApart struct {
+	OrderIDs map[string]bool
+}
+
+func (KeepApart) Name() string { return "keep_apart" }
+
+func (k KeepApart) Allows(a, b Order) bool {
+	return !k.OrderIDs[a.ID] || !k.OrderIDs[b.ID]
+}
+
+// MaxOrdersPerShipper caps how many orders of one shipper a truck carries
+type MaxOrdersPerShipper struct {
+	Limit int
+}
+
+func (MaxOrdersPerShipper) Name() string { return "max_orders_per_shipper" }
+
+func (m MaxOrdersPerShipper) AllowsSet(orders []Order) bool {
+	counts := make(map[string]int)
+	for _, order := range orders {
+		if order.Shipper == "" {
+			continue
+		}
+		counts[order.Shipper]++
+		if counts[order.Shipper] > m.Limit {
+			return false
+		}
+	}
+	return true
+}
+
+// PairPredicate makes a pair rule from a function
+func PairPredicate(name string, allows func(a, b Order) bool) PairRule {
+	return pairPredicate{name: name, allows: allows}
+}
+
+type pairPredicate struct {
+	name   string
+	allows func(a, b Order) bool
+}
+
+func (p pairPredicate) Name() string { return p.name }
+
+func (p pairPredicate) Allows(a, b Order) bool { return p.allows(a, b) }
+
+// SetPredicate makes a set rule from a function, which must be monotone
+func SetPredicate(name string, allows func(orders []Order) bool) SetRule {
+	return setPredicate{name: name, allows: allows}
+}
+
+type setPredicate struct {
+	name   string
+	allows func(orders []Order) bool
+}
+
+func (s setPredicate) Name() string { return s.name }
+
+func (s setPredicate) AllowsSet(orders []Order) bool { return s.allows(orders) }
+
+// DefaultPairRules are the compatibility rules every truck follows
+func DefaultPairRules() []PairRule {
+	return []PairRule{
+		RouteMatch{}, HazmatMatch{}, HazmatSegregation{},
+		CommodityExclusion{Commodity: CommodityFood}, CommodityExclusion{Commodity: CommodityPharma},
+		TemperatureMatch{}, TimeWindowsMatch{}, ExclusiveGroups{},
+	}
+}
+
+var registry struct {
+	sync.RWMutex
+	pairs []PairRule
+	sets  []SetRule
+}
+
+// RegisterPairRule adds a pair rule to every checker NewConstraintChecker
+// builds from then on; call it at startup, before serving
+func RegisterPairRule(rule PairRule) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.pairs = append(registry.pairs, rule)
+}
+
+// RegisterSetRule adds a set rule to every checker NewConstraintChecker
+// builds from then on; call it at startup, before serving
+func RegisterSetRule(rule SetRule) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.sets = append(registry.sets, rule)
+}
+
+// RuleEngine is a ConstraintChecker assembled from rule objects: two orders
+// combine when every pair rule allows them, and a selection is valid when
+// every pair in it combines and every set rule allows it
+type RuleEngine struct {
+	pairs []PairRule
+	sets  []SetRule
+}
+
+func NewRuleEngine(pairs []PairRule, sets []SetRule) *RuleEngine {
+	return &RuleEngine{pairs: pairs, sets: sets}
+}
+
+// With returns an engine enforcing e's rules plus the given ones; e is unchanged
+func (e *RuleEngine) With(pairs []PairRule, sets []SetRule) *RuleEngine {
+	return &RuleEngine{
+		pairs: append(append([]PairRule(nil), e.pairs...), pairs...),
+		sets:  append(append([]SetRule(nil), e.sets...), sets...),
+	}
+}
+
+// Without returns an engine enforcing e's rules except the pair rule named
+// name; e is unchanged
+func (e *RuleEngine) Without(name string) *RuleEngine {
+	pairs := make([]PairRule, 0, len(e.pairs))
+	for _, rule := range e.pairs {
+		if rule.Name() != name {
+			pairs = append(pairs, rule)
+		}
+	}
+	return &RuleEngine{pairs: pairs, sets: e.sets}
+}
+
+// HasSetRules reports whether any set rule applies beyond the pair rules
+func (e *RuleEngine) HasSetRules() bool {
+	return len(e.sets) > 0
+}
+
+func (e *RuleEngine) CanCombine(order1, order2 Order) bool {
+	for _, rule := range e.pairs {
+		if !rule.Allows(order1, order2) {
+			return false
+		}
+	}
+	return true
+}
+
+// PairConflict names the first pair rule that keeps two orders apart, or
+// returns "" when they combine
+func (e *RuleEngine) PairConflict(order1, order2 Order) string {
+	for _, rule := range e.pairs {
+		if !rule.Allows(order1, order2) {
+			return rule.Name()
+		}
+	}
+	return ""
+}
+
+// SetConflict names the first set rule that rejects a selection, or returns
+// "" when every set rule allows it
+func (e *RuleEngine) SetConflict(orders []Order) string {
+	for _, rule := range e.sets {
+		if !rule.AllowsSet(orders) {
+			return rule.Name()
+		}
+	}
+	return ""
+}
+
+func (e *RuleEngine) CanFit(truck Truck, currentWeight, currentVolume int, order Order) bool {
+	newWeight := currentWeight + order.WeightLbs
+	newVolume := currentVolume + order.VolumeCuft
+	
+	return newWeight <= truck.MaxWeightLbs && newVolume <= truck.MaxVolumeCuft && truck.Carries(order)
+}
+
+func (e *RuleEngine) ValidateOrderSet(orders []Order) bool {
+	for i := 0; i < len(orders); i++ {
+		for j := i + 1; j < len(orders); j++ {
+			if !e.CanCombine(orders[i], orders[j]) {
+				return false
+			}
+		}
+	}
+	for _, rule := range e.sets {
+		if !rule.AllowsSet(orders) {
+			return false
+		}
+	}
+	return true
+}
+
+// MaxRequestRules caps the rules one request may supply
+const MaxRequestRules = 20
+
+// RuleInput is a rule supplied with a request. Type picks the rule and the
+// fields it reads:
+//
+//	pickup_within_days      days     pickups on a truck at most days apart
+//	keep_apart              order_ids no two of the listed orders together
+//	max_orders_per_shipper  limit    at most limit orders of any one shipper
+type RuleInput struct {
+	Type     string   `json:"type"`
+	Days     int      `json:"days,omitempty"`
+	OrderIDs []string `json:"order_ids,omitempty"`
+	Limit    int      `json:"limit,omitempty"`
+}
+
+func (r RuleInput) validate(orderIDs map[string]bool) error {
+	switch r.Type {
+	case "pickup_within_days":
+		if r.Days < 0 || r.Days > 365 {
+			return fmt.Errorf("pickup_within_days days must be between 0 and 365")
+		}
+	case "keep_apart":
+		if len(r.OrderIDs) < 2 {
+			return fmt.Errorf("keep_apart needs at least 2 order_ids")
+		}
+		for _, id := range r.OrderIDs {
+			if !orderIDs[id] {
+				return fmt.Errorf("keep_apart: unknown order id %s", id)
+			}
+		}
+	case "max_orders_per_shipper":
+		if r.Limit < 1 || r.Limit > 1000 {
+			return fmt.Errorf("max_orders_per_shipper limit must be between 1 and 1000")
+		}
+	default:
+		return fmt.Errorf("invalid rule type: %s (must be pickup_within_days, keep_apart, or max_orders_per_shipper)", r.Type)
+	}
+	return nil
+}
+
+// RuleSet builds the request's rules, plus the facility schedule when its
+// facilities have windows, the packing check when the truck gives its
+// interior, its floor space limits, its axle limits when the axle model
+// asks to reoptimize, its compartments, and in multi-stop mode the corridor
+// match with the stop limit; both lists are empty without any
+func (r *OptimizeRequest) RuleSet() ([]PairRule, []SetRule) {
+	var pairs []PairRule
+	var sets []SetRule
+	for _, rule := range r.Rules {
+		switch rule.Type {
+		case "pickup_within_days":
+			pairs = append(pairs, PickupWithin{Days: rule.Days})
+		case "keep_apart":
+			ids := make(map[string]bool, len(rule.OrderIDs))
+			for _, id := range rule.OrderIDs {
+				ids[id] = true
+			}
+			pairs = append(pairs, KeepApart{OrderIDs: ids})
+		case "max_orders_per_shipper":
+			sets = append(sets, MaxOrdersPerShipper{Limit: rule.Limit})
+		}
+	}
+	if schedule := r.FacilitySchedule(); schedule != nil {
+		sets = append(sets, schedule)
+	}
+	if interior := dimensions(r.Truck.InteriorLengthIn, r.Truck.InteriorWidthIn, r.Truck.InteriorHeightIn); interior != nil {
+		sets = append(sets, ShelfPacking{Interior: *interior})
+	}
+	if r.Truck.MaxLinearFeet > 0 || r.Truck.MaxPalletPositions > 0 {
+		sets = append(sets, FloorLimit{MaxLinearFeet: r.Truck.MaxLinearFeet, MaxPalletPositions: r.Truck.MaxPalletPositions})
+	}
+	if axles := r.Truck.Axles.ToDomain(); axles != nil && axles.Reoptimize {
+		sets = append(sets, AxleLimit{Truck: Truck{
+			MaxVolumeCuft: r.Truck.MaxVolumeCuft,
+			Interior:      dimensions(r.Truck.InteriorLengthIn, r.Truck.InteriorWidthIn, r.Truck.InteriorHeightIn),
+			Axles:         axles,
+		}})
+	}
+	if compartments := r.Truck.compartments(); compartments != nil {
+		sets = append(sets, CompartmentFit{Compartments: compartments})
+	}
+	if r.MultiStop != nil {
+		pairs = append(pairs, CorridorMatch{CorridorMiles: r.MultiStop.CorridorMiles})
+		sets = append(sets, StopLimit{MaxStops: r.MultiStop.MaxStops})
+		if r.MultiStop.LIFO == LIFOHard {
+			pairs = append(pairs, LIFOUnload{})
+		}
+		if r.MultiStop.MaxDetourMiles > 0 || r.MultiStop.MaxDetourPercent > 0 {
+			sets = append(sets, DetourLimit{MaxMiles: r.MultiStop.MaxDetourMiles, MaxPercent: r.MultiStop.MaxDetourPercent})
+		}
+	}
+	return pairs, sets
+}
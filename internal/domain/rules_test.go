@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRulesDecideCompatibility(t *testing.T) {
+	day := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := Order{ID: "a", Origin: "Los Angeles, CA", Destination: "Dallas, TX", PickupDate: day, Shipper: "acme"}
+	other := base
+	other.ID = "b"
+	
+	tests := []struct {
+		name  string
+		rule  PairRule
+		b     func(Order) Order
+		allow bool
+	}{
+		{"same route", RouteMatch{}, func(o Order) Order { return o }, true},
+		{"other route", RouteMatch{}, func(o Order) Order { o.Destination = "Phoenix, AZ"; return o }, false},
+		{"hazmat mix", HazmatMatch{}, func(o Order) Order { o.IsHazmat = true; return o }, false},
+		{"same exclusive group", ExclusiveGroups{}, func(o Order) Order { o.ExclusiveGroup = "x"; return o }, true},
+		{"pickup in window", PickupWithin{Days: 2}, func(o Order) Order { o.PickupDate = day.AddDate(0, 0, 2); return o }, true},
+		{"pickup outside window", PickupWithin{Days: 2}, func(o Order) Order { o.PickupDate = day.AddDate(0, 0, -3); return o }, false},
+		{"kept apart", KeepApart{OrderIDs: map[string]bool{"a": true, "b": true}}, func(o Order) Order { return o }, false},
+		{"one kept apart", KeepApart{OrderIDs: map[string]bool{"a": true}}, func(o Order) Order { return o }, true},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Allows(base, tt.b(other)); got != tt.allow {
+			t.Errorf("%s: %s allows = %v, want %v", tt.name, tt.rule.Name(), got, tt.allow)
+		}
+	}
+	
+	grouped := base
+	grouped.ExclusiveGroup = "x"
+	if (ExclusiveGroups{}).Allows(grouped, grouped) {
+		t.Error("two orders of one exclusive group allowed together")
+	}
+}
+
+func TestRuleEngineChecksSetRules(t *testing.T) {
+	orders := []Order{
+		{ID: "a", Origin: "A", Destination: "B", Shipper: "acme"},
+		{ID: "b", Origin: "A", Destination: "B", Shipper: "acme"},
+		{ID: "c", Origin: "A", Destination: "B", Shipper: "globex"},
+	}
+	engine := NewConstraintChecker()
+	limited := engine.With(nil, []SetRule{MaxOrdersPerShipper{Limit: 1}})
+	
+	if !engine.ValidateOrderSet(orders) || engine.HasSetRules() {
+		t.Fatal("default engine rejected orders on one route")
+	}
+	if limited.ValidateOrderSet(orders) || !limited.ValidateOrderSet(orders[1:]) {
+		t.Fatal("max_orders_per_shipper not applied to the whole selection")
+	}
+	if !limited.CanCombine(orders[0], orders[1]) {
+		t.Fatal("a set rule blocked a pair")
+	}
+}
+
+func TestRegisteredRulesReachNewCheckers(t *testing.T) {
+	before := NewConstraintChecker()
+	RegisterPairRule(PairPredicate("registered-test", func(a, b Order) bool {
+		return a.ID != "registered-test" && b.ID != "registered-test"
+	}))
+	
+	a := Order{ID: "registered-test", Origin: "A", Destination: "B"}
+	b := Order{ID: "other", Origin: "A", Destination: "B"}
+	if !before.CanCombine(a, b) {
+		t.Fatal("registration changed an existing checker")
+	}
+	if NewConstraintChecker().CanCombine(a, b) {
+		t.Fatal("registered rule missing from a new checker")
+	}
+}
+
+func TestRequestRules(t *testing.T) {
+	orderIDs := map[string]bool{"a": true, "b": true}
+	valid := []RuleInput{
+		{Type: "pickup_within_days", Days: 1},
+		{Type: "keep_apart", OrderIDs: []string{"a", "b"}},
+		{Type: "max_orders_per_shipper", Limit: 2},
+	}
+	for _, rule := range valid {
+		if err := rule.validate(orderIDs); err != nil {
+			t.Errorf("%+v rejected: %v", rule, err)
+		}
+	}
+	invalid := []RuleInput{
+		{Type: "same_driver"},
+		{Type: "pickup_within_days", Days: -1},
+		{Type: "keep_apart", OrderIDs: []string{"a"}},
+		{Type: "keep_apart", OrderIDs: []string{"a", "z"}},
+		{Type: "max_orders_per_shipper"},
+	}
+	for _, rule := range invalid {
+		if err := rule.validate(orderIDs); err == nil {
+			t.Errorf("%+v accepted", rule)
+		}
+	}
+	
+	request := OptimizeRequest{Rules: valid}
+	pairs, sets := request.RuleSet()
+	if len(pairs) != 2 || len(sets) != 1 {
+		t.Fatalf("built %d pair and %d set rules", len(pairs), len(sets))
+	}
+}
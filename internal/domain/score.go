@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"math"
+	"math/bits"
+)
+
+// ScoreScale is the number of Score units in one cent
+const ScoreScale = 100
+
+// Score is the fixed-point objective value optimizers maximize, in
+// hundredths of a cent. Composite objectives blend payouts, utilization and
+// bonuses with fractional weights; keeping the fraction of a cent stops small
+// weights from rounding orders to the same score, and doing the arithmetic in
+// integers keeps large payouts exact where a float64 would drop their low
+// digits. Arithmetic saturates at the int64 bounds instead of wrapping, so an
+// extreme request can lose precision but never flip the sign of a score.
+type Score int64
+
+// ScoreFromMoney is the score of an amount of money
+func ScoreFromMoney(m Money) Score {
+	return Score(m).MulRatio(ScoreScale, 1)
+}
+
+// Money rounds the score to the nearest cent, halves away from zero
+func (s Score) Money() Money {
+	half := Score(ScoreScale / 2)
+	if s < 0 {
+		half = -half
+	}
+	return Money(s.Add(half) / ScoreScale)
+}
+
+// Add returns s+other, saturating at the int64 bounds
+func (s Score) Add(other Score) Score {
+	sum := s + other
+	switch {
+	case other > 0 && sum < s:
+		return math.MaxInt64
+	case other < 0 && sum > s:
+		return math.MinInt64
+	}
+	return sum
+}
+
+// Sub returns s-other, saturating at the int64 bounds
+func (s Score) Sub(other Score) Score {
+	if other == math.MinInt64 {
+		if s >= 0 {
+			return math.MaxInt64
+		}
+		return s - other
+	}
+	return s.Add(-other)
+}
+
+// MulRatio returns s*num/den rounded toward zero, saturating at the int64
+// bounds. The product is taken in 128 bits, so it never overflows before
+// the division. den must be positive.
+func (s Score) MulRatio(num, den int64) Score {
+	negative := (s < 0) != (num < 0)
+	hi, lo := bits.Mul64(absUint64(int64(s)), absUint64(num))
+	if hi >= uint64(den) {
+		return saturated(negative)
+	}
+	quotient, _ := bits.Div64(hi, lo, uint64(den))
+	if negative {
+		if quotient >= 1<<63 {
+			return math.MinInt64
+		}
+		return Score(-int64(quotient))
+	}
+	if quotient > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return Score(quotient)
+}
+
+// Mul returns s weighted by w, saturating at the int64 bounds
+func (s Score) Mul(w Weight) Score {
+	return s.MulRatio(int64(w), WeightScale)
+}
+
+func absUint64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+func saturated(negative bool) Score {
+	if negative {
+		return math.MinInt64
+	}
+	return math.MaxInt64
+}
+
+// WeightScale is the number of Weight units in a weight of 1
+const WeightScale = 1000000
+
+// Weight is a fixed-point objective weight in millionths, such as the
+// revenue_weight and utilization_weight of a request
+type Weight int64
+
+// WeightFromFloat rounds f to the nearest millionth
+func WeightFromFloat(f float64) Weight {
+	return Weight(math.Round(f * WeightScale))
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func TestScoreSaturates(t *testing.T) {
+	const max, min = Score(math.MaxInt64), Score(math.MinInt64)
+	tests := []struct {
+		name      string
+		got, want Score
+	}{
+		{"add", Score(5).Add(7), 12},
+		{"add past max", (max - 1).Add(2), max},
+		{"add past min", (min + 1).Add(-2), min},
+		{"sub", Score(5).Sub(7), -2},
+		{"sub past max", max.Sub(-1), max},
+		{"sub min", Score(0).Sub(min), max},
+		{"sub min from negative", Score(-1).Sub(min), max},
+		{"sub past min", min.Sub(1), min},
+		{"ratio", Score(10).MulRatio(2, 3), 6},
+		{"negative ratio", Score(-10).MulRatio(2, 3), -6},
+		{"ratio with a wide product", max.MulRatio(3, 4), 3<<61 - 1},
+		{"ratio past max", max.MulRatio(3, 2), max},
+		{"ratio past min", max.MulRatio(-3, 2), min},
+		{"ratio to min", min.MulRatio(1, 1), min},
+		{"negated min", min.MulRatio(-1, 1), max},
+		{"weight", Score(1000).Mul(WeightFromFloat(0.25)), 250},
+		{"money past max", ScoreFromMoney(Money(math.MaxInt64 / 10)), max},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestScoreRoundsToCents(t *testing.T) {
+	tests := []struct {
+		score Score
+		want  Money
+	}{
+		{ScoreFromMoney(123), 123},
+		{149, 1},
+		{150, 2},
+		{-150, -2},
+		{-149, -1},
+		{math.MaxInt64, math.MaxInt64 / ScoreScale},
+		{math.MinInt64, math.MinInt64 / ScoreScale},
+	}
+	for _, tt := range tests {
+		if got := tt.score.Money(); got != tt.want {
+			t.Errorf("%d.Money() = %d, want %d", tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestWeightedScoresKeepLargePayoutsExact(t *testing.T) {
+	// 2^53+1 cents is the first amount a float64 cannot hold
+	payout := ScoreFromMoney(Money(1<<53 + 1))
+	half := WeightFromFloat(0.5)
+	if got, want := payout.Mul(half), Score((1<<53+1)*ScoreScale/2); got != want {
+		t.Fatalf("half of %d = %d, want %d", payout, got, want)
+	}
+}
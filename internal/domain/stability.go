@@ -0,0 +1,72 @@
+package domain
+
+import "fmt"
+
+// MaxStabilityWeight bounds stability_weight; at 1 a new order is worth
+// nothing against the previous plan
+const MaxStabilityWeight = 1
+
+// PlanChanges compares a plan with the previously committed one
+type PlanChanges struct {
+	// Added are orders the previous plan did not carry
+	Added []string `json:"added"`
+	// Removed are orders of the previous plan left out
+	Removed []string `json:"removed"`
+}
+
+// validateStability checks previous_order_ids and that stability_weight
+// only comes with them. Previous orders missing from the request are
+// allowed: they were cancelled or taken elsewhere and simply leave the plan.
+func (r *OptimizeRequest) validateStability() error {
+	seen := make(map[string]bool, len(r.PreviousOrderIDs))
+	for _, id := range r.PreviousOrderIDs {
+		if seen[id] {
+			return fmt.Errorf("previous_order_ids: duplicate order id %s", id)
+		}
+		seen[id] = true
+	}
+	if r.OptimizationConfig == nil || r.OptimizationConfig.StabilityWeight == 0 {
+		return nil
+	}
+	if r.OptimizationConfig.StabilityWeight < 0 || r.OptimizationConfig.StabilityWeight > MaxStabilityWeight {
+		return fmt.Errorf("optimization_config: stability_weight must be between 0 and %d", MaxStabilityWeight)
+	}
+	if r.PreviousOrderIDs == nil {
+		return fmt.Errorf("stability_weight requires previous_order_ids")
+	}
+	return nil
+}
+
+// PlanChanges lists how selected differs from the request's previous plan,
+// or returns nil when the request names none
+func (r *OptimizeRequest) PlanChanges(selected []string) *PlanChanges {
+	if r.PreviousOrderIDs == nil {
+		return nil
+	}
+	previous := make(map[string]bool, len(r.PreviousOrderIDs))
+	for _, id := range r.PreviousOrderIDs {
+		previous[id] = true
+	}
+	kept := make(map[string]bool, len(selected))
+	changes := &PlanChanges{Added: []string{}, Removed: []string{}}
+	for _, id := range selected {
+		kept[id] = true
+		if !previous[id] {
+			changes.Added = append(changes.Added, id)
+		}
+	}
+	for _, id := range r.PreviousOrderIDs {
+		if !kept[id] {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	return changes
+}
+
+// Stability is the config's stability_weight, 0 when none is set
+func (c *OptimizationConfig) Stability() Weight {
+	if c == nil {
+		return 0
+	}
+	return WeightFromFloat(c.StabilityWeight)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStabilityValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		request OptimizeRequest
+		want    string
+	}{
+		{"no previous plan", OptimizeRequest{}, ""},
+		{"previous plan only", OptimizeRequest{PreviousOrderIDs: []string{"a", "b"}}, ""},
+		{"weighted", OptimizeRequest{PreviousOrderIDs: []string{}, OptimizationConfig: &OptimizationConfig{StabilityWeight: 0.2}}, ""},
+		{"duplicate", OptimizeRequest{PreviousOrderIDs: []string{"a", "a"}}, "duplicate order id a"},
+		{"weight alone", OptimizeRequest{OptimizationConfig: &OptimizationConfig{StabilityWeight: 0.2}}, "requires previous_order_ids"},
+		{"weight too high", OptimizeRequest{PreviousOrderIDs: []string{"a"}, OptimizationConfig: &OptimizationConfig{StabilityWeight: 1.5}}, "between 0 and 1"},
+	}
+	for _, tt := range tests {
+		err := tt.request.validateStability()
+		if tt.want == "" && err != nil {
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		}
+		if tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestPlanChanges(t *testing.T) {
+	if changes := (&OptimizeRequest{}).PlanChanges([]string{"a"}); changes != nil {
+		t.Errorf("changes = %+v without a previous plan, want nil", changes)
+	}
+	
+	request := OptimizeRequest{PreviousOrderIDs: []string{"a", "b", "c"}}
+	want := &PlanChanges{Added: []string{"d"}, Removed: []string{"b"}}
+	if changes := request.PlanChanges([]string{"c", "a", "d"}); !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %+v, want %+v", changes, want)
+	}
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Stop types
+const (
+	StopPickup   = "pickup"
+	StopDelivery = "delivery"
+)
+
+// Stop is one visit of a plan's route: every order picked up or delivered at
+// one location
+type Stop struct {
+	Sequence int    `json:"sequence"`
+	Type     string `json:"type"`
+	Location string `json:"location"`
+	// Point is where the stop is, when it was given or geocoded
+	Point    *LatLng  `json:"point,omitempty"`
+	OrderIDs []string `json:"order_ids"`
+	// MilesFromPrevious estimates the drive from the previous stop, or from
+	// the truck's position to the first; nil when either end has no point
+	MilesFromPrevious *int `json:"miles_from_previous,omitempty"`
+}
+
+// maxExactStops is the most stops sequenced exactly; longer routes are built
+// nearest stop first
+const maxExactStops = 12
+
+// stopNode is a stop before it is sequenced
+type stopNode struct {
+	Stop
+	opens time.Time
+	// needs are the pickup nodes that must come first, for a delivery
+	needs uint64
+}
+
+// SequenceStops turns a plan into the route that picks up and delivers it:
+// every order's pickup before its delivery, orders sharing a location and
+// type merged into one stop. When every stop has a point the route takes the
+// fewest estimated miles, starting from the truck's position when known;
+// otherwise stops are visited in the order their windows open. Miles are
+// great-circle distances stretched by RoadCircuity.
+func SequenceStops(position *LatLng, orders []Order) []Stop {
+	nodes := stopNodes(orders)
+	if len(nodes) == 0 {
+		return nil
+	}
+	
+	located := true
+	for _, node := range nodes {
+		located = located && node.Point != nil
+	}
+	route := make([]int, len(nodes))
+	for i := range route {
+		route[i] = i
+	}
+	switch {
+	case !located || len(nodes) > 64:
+		// nodes are already in window order, which keeps pickups first
+	case len(nodes) <= maxExactStops:
+		route = exactRoute(position, nodes)
+	default:
+		route = nearestRoute(position, nodes)
+	}
+	
+	stops := make([]Stop, len(route))
+	previous := position
+	for i, index := range route {
+		stops[i] = nodes[index].Stop
+		stops[i].Sequence = i + 1
+		if previous != nil && stops[i].Point != nil {
+			miles := int(math.Round(legMiles(*previous, *stops[i].Point)))
+			stops[i].MilesFromPrevious = &miles
+		}
+		previous = stops[i].Point
+	}
+	return stops
+}
+
+// stopNodes merges the orders' pickups and deliveries by location, in the
+// order their windows open, pickups first on a tie
+func stopNodes(orders []Order) []stopNode {
+	var nodes []stopNode
+	index := make(map[string]int)
+	add := func(kind, location string, point *LatLng, opens time.Time, orderID string) int {
+		key := kind + "|" + NormalizeAddress(location)
+		i, ok := index[key]
+		if !ok {
+			i = len(nodes)
+			index[key] = i
+			nodes = append(nodes, stopNode{Stop: Stop{Type: kind, Location: location, Point: point}, opens: opens})
+		}
+		node := &nodes[i]
+		node.OrderIDs = append(node.OrderIDs, orderID)
+		if node.Point == nil {
+			node.Point = point
+		}
+		if opens.Before(node.opens) {
+			node.opens = opens
+		}
+		return i
+	}
+	pickups := make(map[string]int, len(orders))
+	for _, order := range orders {
+		pickups[order.ID] = add(StopPickup, order.Origin, order.OriginPoint, order.pickupStart(), order.ID)
+	}
+	deliveries := make(map[string]int, len(orders))
+	for _, order := range orders {
+		deliveries[order.ID] = add(StopDelivery, order.Destination, order.DestinationPoint, order.deliveryStart(), order.ID)
+	}
+	
+	// Sort into window order, then wire each delivery to its pickups
+	order := make([]int, len(nodes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := nodes[order[i]], nodes[order[j]]
+		if !a.opens.Equal(b.opens) {
+			return a.opens.Before(b.opens)
+		}
+		return a.Type == StopPickup && b.Type == StopDelivery
+	})
+	position := make([]int, len(nodes))
+	sorted := make([]stopNode, len(nodes))
+	for to, from := range order {
+		position[from] = to
+		sorted[to] = nodes[from]
+	}
+	if len(sorted) <= 64 {
+		for _, o := range orders {
+			sorted[position[deliveries[o.ID]]].needs |= 1 << uint(position[pickups[o.ID]])
+		}
+	}
+	for i := range sorted {
+		sort.Strings(sorted[i].OrderIDs)
+	}
+	return sorted
+}
+
+func legMiles(from, to LatLng) float64 {
+	return greatCircleMiles(from, to) * RoadCircuity
+}
+
+// exactRoute finds the shortest route through every node that visits each
+// delivery after its pickups, by dynamic programming over the visited sets
+func exactRoute(position *LatLng, nodes []stopNode) []int {
+	n := len(nodes)
+	full := 1<<uint(n) - 1
+	cost := make([][]float64, full+1)
+	from := make([][]int8, full+1)
+	for mask := range cost {
+		cost[mask] = make([]float64, n)
+		from[mask] = make([]int8, n)
+		for last := range cost[mask] {
+			cost[mask][last] = math.Inf(1)
+		}
+	}
+	for i, node := range nodes {
+		if node.needs != 0 {
+			continue
+		}
+		cost[1<<uint(i)][i] = 0
+		if position != nil {
+			cost[1<<uint(i)][i] = legMiles(*position, *node.Point)
+		}
+		from[1<<uint(i)][i] = -1
+	}
+	
+	for mask := 1; mask <= full; mask++ {
+		for last := 0; last < n; last++ {
+			current := cost[mask][last]
+			if math.IsInf(current, 1) {
+				continue
+			}
+			for next := 0; next < n; next++ {
+				bit := 1 << uint(next)
+				if mask&bit != 0 || uint64(mask)&nodes[next].needs != nodes[next].needs {
+					continue
+				}
+				total := current + legMiles(*nodes[last].Point, *nodes[next].Point)
+				if total < cost[mask|bit][next] {
+					cost[mask|bit][next] = total
+					from[mask|bit][next] = int8(last)
+				}
+			}
+		}
+	}
+	
+	last := 0
+	for i := 1; i < n; i++ {
+		if cost[full][i] < cost[full][last] {
+			last = i
+		}
+	}
+	route := make([]int, n)
+	for mask, i := full, n-1; i >= 0; i-- {
+		route[i] = last
+		previous := int(from[mask][last])
+		mask &^= 1 << uint(last)
+		last = previous
+	}
+	return route
+}
+
+// nearestRoute drives to the nearest stop it may visit next, for routes too
+// long to sequence exactly
+func nearestRoute(position *LatLng, nodes []stopNode) []int {
+	var visited uint64
+	route := make([]int, 0, len(nodes))
+	current := position
+	for len(route) < len(nodes) {
+		best, bestMiles := -1, math.Inf(1)
+		for i, node := range nodes {
+			if visited&(1<<uint(i)) != 0 || visited&node.needs != node.needs {
+				continue
+			}
+			miles := 0.0
+			if current != nil {
+				miles = legMiles(*current, *node.Point)
+			}
+			if miles < bestMiles {
+				best, bestMiles = i, miles
+			}
+		}
+		visited |= 1 << uint(best)
+		route = append(route, best)
+		current = nodes[best].Point
+	}
+	return route
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSequenceStops(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	long := Order{ID: "long", Origin: "Los Angeles", Destination: "Dallas", OriginPoint: losAngeles, DestinationPoint: dallas, PickupDate: day(1), DeliveryDate: day(4)}
+	// Opens first in El Paso, but picking it up there means doubling back
+	short := Order{ID: "short", Origin: "Phoenix", Destination: "El Paso", OriginPoint: phoenix, DestinationPoint: elPaso, PickupDate: day(1), DeliveryDate: day(1)}
+	sameLane := Order{ID: "same-lane", Origin: "los angeles", Destination: "Dallas", OriginPoint: losAngeles, DestinationPoint: dallas, PickupDate: day(1), DeliveryDate: day(4)}
+	
+	stops := SequenceStops(nil, []Order{sameLane, short, long})
+	var got []string
+	for i, stop := range stops {
+		if stop.Sequence != i+1 {
+			t.Errorf("stop %d numbered %d", i, stop.Sequence)
+		}
+		got = append(got, stop.Type+" "+stop.Location)
+	}
+	want := []string{"pickup los angeles", "pickup Phoenix", "delivery El Paso", "delivery Dallas"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("route %v, want %v", got, want)
+	}
+	if ids := stops[0].OrderIDs; !reflect.DeepEqual(ids, []string{"long", "same-lane"}) {
+		t.Errorf("Los Angeles pickups %v, want both lane orders", ids)
+	}
+	if stops[0].MilesFromPrevious != nil {
+		t.Error("first stop measured without a truck position")
+	}
+	if stops[1].MilesFromPrevious == nil || *stops[1].MilesFromPrevious < 400 {
+		t.Errorf("Los Angeles to Phoenix estimated %v miles", stops[1].MilesFromPrevious)
+	}
+	
+	fromDallas := SequenceStops(dallas, []Order{long})
+	if fromDallas[0].MilesFromPrevious == nil || *fromDallas[0].MilesFromPrevious < 1400 {
+		t.Error("deadhead to the first pickup not measured from the truck")
+	}
+}
+
+func TestSequenceStopsWithoutPoints(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	first := Order{ID: "first", Origin: "A", Destination: "C", PickupDate: day(1), DeliveryDate: day(3)}
+	second := Order{ID: "second", Origin: "B", Destination: "C", PickupDate: day(2), DeliveryDate: day(3)}
+	
+	var got []string
+	for _, stop := range SequenceStops(nil, []Order{second, first}) {
+		got = append(got, stop.Type+" "+stop.Location)
+		if stop.MilesFromPrevious != nil {
+			t.Errorf("%s measured without points", stop.Location)
+		}
+	}
+	want := []string{"pickup A", "pickup B", "delivery C"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("route %v, want window order %v", got, want)
+	}
+}
+
+func TestNearestRouteKeepsPrecedence(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2030, 1, d, 0, 0, 0, 0, time.UTC) }
+	points := []*LatLng{losAngeles, phoenix, elPaso, dallas, houston, denver, irving}
+	var orders []Order
+	for i, origin := range points {
+		id := string(rune('a' + i))
+		destination := points[(i+3)%len(points)]
+		orders = append(orders, Order{ID: id, Origin: "from " + id, Destination: "to " + id, OriginPoint: origin, DestinationPoint: destination, PickupDate: day(1), DeliveryDate: day(2)})
+	}
+	stops := SequenceStops(nil, orders)
+	if len(stops) <= maxExactStops {
+		t.Fatalf("%d stops, want more than %d to exercise the heuristic", len(stops), maxExactStops)
+	}
+	picked := make(map[string]bool)
+	for _, stop := range stops {
+		for _, id := range stop.OrderIDs {
+			if stop.Type == StopPickup {
+				picked[id] = true
+			} else if !picked[id] {
+				t.Errorf("order %s delivered before pickup", id)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Truck equipment types
+const (
+	// EquipmentDry is a dry van, which cannot hold freight at a temperature.
+	// Trucks that name no equipment type are dry vans.
+	EquipmentDry = "dry"
+	// EquipmentReefer is a refrigerated trailer; it carries dry freight too
+	EquipmentReefer = "reefer"
+)
+
+// Temperature bounds orders may set, in degrees Fahrenheit
+const (
+	MinTemperatureF = -100
+	MaxTemperatureF = 150
+)
+
+// TemperatureRange is the band, in degrees Fahrenheit, an order must be kept
+// in. A bound the order did not set is infinite.
+type TemperatureRange struct {
+	MinF float64
+	MaxF float64
+}
+
+// Overlaps reports whether some temperature satisfies both ranges, so one
+// trailer setting can carry both orders
+func (r TemperatureRange) Overlaps(other TemperatureRange) bool {
+	return math.Max(r.MinF, other.MinF) <= math.Min(r.MaxF, other.MaxF)
+}
+
+// TemperatureControlled reports whether the order must ride in a reefer
+func (o Order) TemperatureControlled() bool {
+	return o.Temperature != nil
+}
+
+// TemperatureMatch keeps orders whose temperature ranges do not overlap on
+// separate trucks. Dry freight combines with any order.
+type TemperatureMatch struct{}
+
+func (TemperatureMatch) Name() string { return "temperature" }
+
+func (TemperatureMatch) Allows(a, b Order) bool {
+	if !a.TemperatureControlled() || !b.TemperatureControlled() {
+		return true
+	}
+	return a.Temperature.Overlaps(*b.Temperature)
+}
+
+func validateEquipmentType(equipmentType string) error {
+	switch equipmentType {
+	case "", EquipmentDry, EquipmentReefer:
+		return nil
+	}
+	return fmt.Errorf("equipment_type must be dry or reefer")
+}
+
+func (o *OrderInput) validateTemperature() error {
+	for _, bound := range []*float64{o.TemperatureMinF, o.TemperatureMaxF} {
+		if bound != nil && (math.IsNaN(*bound) || *bound < MinTemperatureF || *bound > MaxTemperatureF) {
+			return fmt.Errorf("temperature_min_f and temperature_max_f must be between %d and %d", MinTemperatureF, MaxTemperatureF)
+		}
+	}
+	if o.TemperatureMinF != nil && o.TemperatureMaxF != nil && *o.TemperatureMinF > *o.TemperatureMaxF {
+		return fmt.Errorf("temperature_min_f cannot be above temperature_max_f")
+	}
+	return nil
+}
+
+// temperatureRange is the order's range, or nil for dry freight
+func (o *OrderInput) temperatureRange() *TemperatureRange {
+	if o.TemperatureMinF == nil && o.TemperatureMaxF == nil {
+		return nil
+	}
+	r := TemperatureRange{MinF: math.Inf(-1), MaxF: math.Inf(1)}
+	if o.TemperatureMinF != nil {
+		r.MinF = *o.TemperatureMinF
+	}
+	if o.TemperatureMaxF != nil {
+		r.MaxF = *o.TemperatureMaxF
+	}
+	return &r
+}
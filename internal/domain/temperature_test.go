@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func reeferOrder(id string, minF, maxF float64) Order {
+	return Order{ID: id, WeightLbs: 1000, VolumeCuft: 100, Origin: "A", Destination: "B",
+		Temperature: &TemperatureRange{MinF: minF, MaxF: maxF}}
+}
+
+func TestTemperatureMatch(t *testing.T) {
+	frozen := reeferOrder("frozen", math.Inf(-1), 0)
+	chilled := reeferOrder("chilled", 33, 38)
+	produce := reeferOrder("produce", 36, 45)
+	dry := Order{ID: "dry", Origin: "A", Destination: "B"}
+	
+	tests := []struct {
+		a, b  Order
+		allow bool
+	}{
+		{chilled, produce, true},
+		{frozen, chilled, false},
+		{frozen, reeferOrder("deep", -20, -10), true},
+		{chilled, reeferOrder("edge", 38, 40), true},
+		{dry, frozen, true},
+		{dry, dry, true},
+	}
+	for _, tt := range tests {
+		if got := (TemperatureMatch{}).Allows(tt.a, tt.b); got != tt.allow {
+			t.Errorf("%s with %s allowed = %v, want %v", tt.a.ID, tt.b.ID, got, tt.allow)
+		}
+	}
+}
+
+func TestDryVansLeaveReeferFreight(t *testing.T) {
+	orders := []Order{reeferOrder("chilled", 33, 38), {ID: "dry", WeightLbs: 1000, VolumeCuft: 100}}
+	dryVan := Truck{MaxWeightLbs: 44000, MaxVolumeCuft: 3000, EquipmentType: EquipmentDry}
+	reefer := dryVan
+	reefer.EquipmentType = EquipmentReefer
+	
+	if got := FilterFeasibleOrders(dryVan, orders); len(got) != 1 || got[0].ID != "dry" {
+		t.Fatalf("dry van kept %v", got)
+	}
+	if got := FilterFeasibleOrders(reefer, orders); len(got) != 2 {
+		t.Fatalf("reefer kept %d of 2 orders", len(got))
+	}
+	if NewConstraintChecker().CanFit(dryVan, 0, 0, orders[0]) {
+		t.Fatal("CanFit put reefer freight on a dry van")
+	}
+}
+
+func TestTemperatureValidation(t *testing.T) {
+	day := time.Now().Format("2006-01-02")
+	temperature := func(minF, maxF *float64) error {
+		order := OrderInput{
+			ID: "a", PayoutCents: 250000, WeightLbs: 18000, VolumeCuft: 1200, Origin: "LA", Destination: "Dallas",
+			PickupDate: day, DeliveryDate: day, TemperatureMinF: minF, TemperatureMaxF: maxF,
+		}
+		return order.Validate()
+	}
+	f := func(v float64) *float64 { return &v }
+	
+	if err := temperature(f(33), f(38)); err != nil {
+		t.Fatalf("33-38F rejected: %v", err)
+	}
+	if err := temperature(nil, f(0)); err != nil {
+		t.Fatalf("max only rejected: %v", err)
+	}
+	if temperature(f(40), f(30)) == nil {
+		t.Fatal("min above max accepted")
+	}
+	if temperature(f(-200), nil) == nil {
+		t.Fatal("-200F accepted")
+	}
+	
+	if r := (&OrderInput{TemperatureMaxF: f(0)}).temperatureRange(); !math.IsInf(r.MinF, -1) || r.MaxF != 0 {
+		t.Fatalf("max only became %+v", *r)
+	}
+	if validateEquipmentType("flatbed") == nil {
+		t.Fatal("flatbed accepted")
+	}
+}
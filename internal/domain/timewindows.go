@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeWindow is the span an order may be picked up or delivered in. Orders
+// that give only dates get the whole day, midnight to midnight UTC.
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// dayWindow is the window of a date-only pickup or delivery
+func dayWindow(date time.Time) TimeWindow {
+	return TimeWindow{Start: date, End: date.Add(24 * time.Hour)}
+}
+
+// timeWindow validates one pair of window fields, named by prefix. ok is
+// false when neither is set.
+func timeWindow(prefix, start, end string) (window TimeWindow, ok bool, err error) {
+	if start == "" && end == "" {
+		return TimeWindow{}, false, nil
+	}
+	if start == "" || end == "" {
+		return TimeWindow{}, false, fmt.Errorf("%s_window_start and %s_window_end must be set together", prefix, prefix)
+	}
+	window.Start, err = time.Parse(time.RFC3339, start)
+	if err != nil {
+		return TimeWindow{}, false, fmt.Errorf("invalid %s_window_start (expected ISO 8601 with a timezone, such as 2030-01-01T08:00:00-06:00)", prefix)
+	}
+	window.End, err = time.Parse(time.RFC3339, end)
+	if err != nil {
+		return TimeWindow{}, false, fmt.Errorf("invalid %s_window_end (expected ISO 8601 with a timezone, such as 2030-01-01T17:00:00-06:00)", prefix)
+	}
+	if !window.End.After(window.Start) {
+		return TimeWindow{}, false, fmt.Errorf("%s_window_end must be after %s_window_start", prefix, prefix)
+	}
+	return window, true, nil
+}
+
+// windowDate is the date of a pickup or delivery: the date field when set,
+// else the local date the window starts on
+func windowDate(date, start string) string {
+	if date != "" {
+		return date
+	}
+	if t, err := time.Parse(time.RFC3339, start); err == nil {
+		return t.Format("2006-01-02")
+	}
+	return ""
+}
+
+// pickupDate and deliveryDate are the order's dates, taken from its windows
+// when it gives no dates
+func (o *OrderInput) pickupDate() string {
+	return windowDate(o.PickupDate, o.PickupWindowStart)
+}
+
+func (o *OrderInput) deliveryDate() string {
+	return windowDate(o.DeliveryDate, o.DeliveryWindowStart)
+}
+
+func (o *OrderInput) validateTimeWindows() error {
+	pickup, hasPickup, err := timeWindow("pickup", o.PickupWindowStart, o.PickupWindowEnd)
+	if err != nil {
+		return err
+	}
+	delivery, hasDelivery, err := timeWindow("delivery", o.DeliveryWindowStart, o.DeliveryWindowEnd)
+	if err != nil {
+		return err
+	}
+	if hasPickup && o.PickupDate != "" && o.PickupDate != pickup.Start.Format("2006-01-02") {
+		return fmt.Errorf("pickup_date must be the date pickup_window_start falls on")
+	}
+	if hasDelivery && o.DeliveryDate != "" && o.DeliveryDate != delivery.Start.Format("2006-01-02") {
+		return fmt.Errorf("delivery_date must be the date delivery_window_start falls on")
+	}
+	if hasPickup && hasDelivery && !delivery.End.After(pickup.Start) {
+		return fmt.Errorf("delivery_window_end must be after pickup_window_start")
+	}
+	return nil
+}
+
+// timeWindows returns the order's pickup and delivery windows, the whole
+// day for a date without a window
+func (o *OrderInput) timeWindows(pickupDate, deliveryDate time.Time) (TimeWindow, TimeWindow) {
+	pickup, ok, _ := timeWindow("pickup", o.PickupWindowStart, o.PickupWindowEnd)
+	if !ok {
+		pickup = dayWindow(pickupDate)
+	}
+	delivery, ok, _ := timeWindow("delivery", o.DeliveryWindowStart, o.DeliveryWindowEnd)
+	if !ok {
+		delivery = dayWindow(deliveryDate)
+	}
+	return pickup, delivery
+}
+
+// TimeWindowsMatch keeps orders on one truck only when their windows can be
+// sequenced: the truck must be able to load both before it has to deliver
+// either, so the later pickup window must open before the earlier delivery
+// window closes. Travel time is not known and not counted. Orders without
+// windows, built outside a request, always combine.
+type TimeWindowsMatch struct{}
+
+func (TimeWindowsMatch) Name() string { return "time_windows" }
+
+func (TimeWindowsMatch) Allows(a, b Order) bool {
+	if a.PickupWindow.Start.IsZero() || b.PickupWindow.Start.IsZero() {
+		return true
+	}
+	lastPickup := a.PickupWindow.Start
+	if b.PickupWindow.Start.After(lastPickup) {
+		lastPickup = b.PickupWindow.Start
+	}
+	firstDelivery := a.DeliveryWindow.End
+	if b.DeliveryWindow.End.Before(firstDelivery) {
+		firstDelivery = b.DeliveryWindow.End
+	}
+	return lastPickup.Before(firstDelivery)
+}
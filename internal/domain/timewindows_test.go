@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func windowOrder(pickupStart, pickupEnd, deliveryStart, deliveryEnd string) OrderInput {
+	return OrderInput{
+		ID: "o", PayoutCents: 1000, WeightLbs: 100, VolumeCuft: 10,
+		Origin: "Dallas, TX", Destination: "Houston, TX",
+		PickupWindowStart: pickupStart, PickupWindowEnd: pickupEnd,
+		DeliveryWindowStart: deliveryStart, DeliveryWindowEnd: deliveryEnd,
+	}
+}
+
+func TestTimeWindowsReplaceDates(t *testing.T) {
+	// 11pm in Dallas is already the next day in UTC; the date is local
+	input := windowOrder("2030-01-01T20:00:00-06:00", "2030-01-01T23:00:00-06:00", "2030-01-02T08:00:00-06:00", "2030-01-02T12:00:00-06:00")
+	if err := input.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	order, _ := input.ToDomain()
+	if got := order.PickupDate.Format("2006-01-02"); got != "2030-01-01" {
+		t.Errorf("pickup date = %s, want 2030-01-01", got)
+	}
+	if !order.PickupWindow.End.Equal(time.Date(2030, 1, 2, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("pickup window = %+v", order.PickupWindow)
+	}
+	
+	dated := OrderInput{PickupDate: "2030-01-01", DeliveryDate: "2030-01-03"}
+	order, _ = dated.ToDomain()
+	if want := (TimeWindow{Start: time.Date(2030, 1, 3, 0, 0, 0, 0, time.UTC), End: time.Date(2030, 1, 4, 0, 0, 0, 0, time.UTC)}); order.DeliveryWindow != want {
+		t.Errorf("delivery window = %+v, want the whole day", order.DeliveryWindow)
+	}
+}
+
+func TestTimeWindowValidation(t *testing.T) {
+	tests := map[string]OrderInput{
+		"start only":        windowOrder("2030-01-01T08:00:00Z", "", "2030-01-02T08:00:00Z", "2030-01-02T12:00:00Z"),
+		"no timezone":       windowOrder("2030-01-01T08:00:00", "2030-01-01T10:00:00", "2030-01-02T08:00:00Z", "2030-01-02T12:00:00Z"),
+		"ends before start": windowOrder("2030-01-01T10:00:00Z", "2030-01-01T08:00:00Z", "2030-01-02T08:00:00Z", "2030-01-02T12:00:00Z"),
+		"delivered first":   windowOrder("2030-01-03T08:00:00Z", "2030-01-03T10:00:00Z", "2030-01-02T08:00:00Z", "2030-01-02T12:00:00Z"),
+	}
+	mismatch := windowOrder("2030-01-01T08:00:00Z", "2030-01-01T10:00:00Z", "2030-01-02T08:00:00Z", "2030-01-02T12:00:00Z")
+	mismatch.PickupDate = "2030-01-02"
+	tests["date off the window"] = mismatch
+	
+	for name, input := range tests {
+		if err := input.Validate(); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
+
+func TestTimeWindowsMatch(t *testing.T) {
+	at := func(day, hour int) time.Time { return time.Date(2030, 1, day, hour, 0, 0, 0, time.UTC) }
+	order := func(pickup, delivery TimeWindow) Order {
+		return Order{PickupWindow: pickup, DeliveryWindow: delivery}
+	}
+	morning := order(TimeWindow{at(1, 8), at(1, 10)}, TimeWindow{at(1, 14), at(1, 16)})
+	
+	tests := []struct {
+		name  string
+		other Order
+		want  bool
+	}{
+		{"overlapping", order(TimeWindow{at(1, 9), at(1, 11)}, TimeWindow{at(1, 15), at(1, 18)}), true},
+		{"picked up later, before the first delivery closes", order(TimeWindow{at(1, 12), at(1, 13)}, TimeWindow{at(2, 8), at(2, 9)}), true},
+		{"picked up after the first delivery closes", order(TimeWindow{at(1, 16), at(1, 17)}, TimeWindow{at(2, 8), at(2, 9)}), false},
+		{"whole days", order(dayWindow(at(1, 0)), dayWindow(at(3, 0))), true},
+		{"next day", order(dayWindow(at(2, 0)), dayWindow(at(3, 0))), false},
+		{"no windows", Order{}, true},
+	}
+	for _, tt := range tests {
+		if got := (TimeWindowsMatch{}).Allows(morning, tt.other); got != tt.want {
+			t.Errorf("%s: Allows = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := (TimeWindowsMatch{}).Allows(tt.other, morning); got != tt.want {
+			t.Errorf("%s reversed: Allows = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
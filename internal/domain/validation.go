@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ValidationProfile holds the request limits a tenant may tighten. Zero fields
+// fall back to the built-in limits, which are also the ceiling for every field.
+type ValidationProfile struct {
+	MaxPayoutCents int64 `json:"max_payout_cents,omitempty"`
+	MaxWeightLbs   int   `json:"max_weight_lbs,omitempty"`
+	MaxOrders      int   `json:"max_orders,omitempty"`
+	// DateHorizonDays is how far pickup and delivery dates may lie from today; 0 allows any date
+	DateHorizonDays int `json:"date_horizon_days,omitempty"`
+}
+
+const (
+	maxPayoutCents     = 100000000000
+	maxWeightLbs       = 1000000
+	maxOrders          = 1000
+	maxDateHorizonDays = 3650
+)
+
+// DefaultValidationProfile returns the built-in limits
+func DefaultValidationProfile() ValidationProfile {
+	return ValidationProfile{
+		MaxPayoutCents: maxPayoutCents,
+		MaxWeightLbs:   maxWeightLbs,
+		MaxOrders:      maxOrders,
+	}
+}
+
+func (p ValidationProfile) Validate() error {
+	if p.MaxPayoutCents < 0 || p.MaxPayoutCents > maxPayoutCents {
+		return fmt.Errorf("max_payout_cents must be between 0 and %d", int64(maxPayoutCents))
+	}
+	if p.MaxWeightLbs < 0 || p.MaxWeightLbs > maxWeightLbs {
+		return fmt.Errorf("max_weight_lbs must be between 0 and %d", maxWeightLbs)
+	}
+	if p.MaxOrders < 0 || p.MaxOrders > maxOrders {
+		return fmt.Errorf("max_orders must be between 0 and %d", maxOrders)
+	}
+	if p.DateHorizonDays < 0 || p.DateHorizonDays > maxDateHorizonDays {
+		return fmt.Errorf("date_horizon_days must be between 0 and %d", maxDateHorizonDays)
+	}
+	return nil
+}
+
+// Resolve fills unset fields with the built-in limits
+func (p *ValidationProfile) Resolve() ValidationProfile {
+	resolved := DefaultValidationProfile()
+	if p == nil {
+		return resolved
+	}
+	if p.MaxPayoutCents > 0 {
+		resolved.MaxPayoutCents = p.MaxPayoutCents
+	}
+	if p.MaxWeightLbs > 0 {
+		resolved.MaxWeightLbs = p.MaxWeightLbs
+	}
+	if p.MaxOrders > 0 {
+		resolved.MaxOrders = p.MaxOrders
+	}
+	resolved.DateHorizonDays = p.DateHorizonDays
+	return resolved
+}
+
+// withinHorizon reports whether date lies within the profile's horizon of now
+func (p ValidationProfile) withinHorizon(date, now time.Time) bool {
+	if p.DateHorizonDays == 0 {
+		return true
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	horizon := time.Duration(p.DateHorizonDays) * 24 * time.Hour
+	return !date.Before(today.Add(-horizon)) && !date.After(today.Add(horizon))
+}
+
+// ValidationWarning flags input that is accepted but probably wrong. Unlike
+// validation errors, warnings never stop a solve; they are echoed in the response.
+type ValidationWarning struct {
+	Code    string `json:"code"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Plausible freight density in lb/ft3; outside this range a weight or volume
+// was most likely entered in the wrong unit.
+const (
+	minPlausibleDensity = 1.0
+	maxPlausibleDensity = 150.0
+)
+
+// Warnings lists the soft checks a valid request fails
+func (r *OptimizeRequest) Warnings(now time.Time) []ValidationWarning {
+	warnings := make([]ValidationWarning, 0)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	
+	for i, order := range r.Orders {
+		field := fmt.Sprintf("orders[%d]", i)
+		
+		if pickup, err := time.Parse("2006-01-02", order.pickupDate()); err == nil && pickup.Before(today) {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "pickup_in_past",
+				Field:   field + ".pickup_date",
+				Message: fmt.Sprintf("order %s pickup_date %s is in the past", order.ID, order.pickupDate()),
+			})
+		}
+		
+		density := float64(order.WeightLbs) / float64(order.VolumeCuft)
+		if density < minPlausibleDensity || density > maxPlausibleDensity {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "suspicious_density",
+				Field:   field,
+				Message: fmt.Sprintf("order %s density %.1f lb/ft3 is outside the plausible range %.0f-%.0f", order.ID, density, minPlausibleDensity, maxPlausibleDensity),
+			})
+		}
+		
+		weight := chargeableWeightLbs(order.WeightLbs, order.VolumeCuft, dimensions(order.LengthIn, order.WidthIn, order.HeightIn), r.DimFactor)
+		if weight > r.Truck.MaxWeightLbs || order.VolumeCuft > r.Truck.MaxVolumeCuft {
+			warnings = append(warnings, ValidationWarning{
+				Code:    "exceeds_truck_capacity",
+				Field:   field,
+				Message: fmt.Sprintf("order %s can never fit truck %s and will not be loaded", order.ID, r.Truck.ID),
+			})
+		}
+	}
+	
+	return warnings
+}
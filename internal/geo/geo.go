@@ -0,0 +1,197 @@
+package geo
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"smart-load/internal/domain"
+	"sync"
+	"time"
+)
+
+// Place is a single entry of a static geocoding table
+type Place struct {
+	Location string  `json:"location"`
+	Lat      float64 `json:"lat"`
+	Lng      float64 `json:"lng"`
+}
+
+// StaticTable geocodes from a fixed table of places. Locations are looked up
+// in domain.NormalizeAddress form, so "Dallas, TX" finds an entry written
+// "Dallas TX". Unknown locations are not geocoded.
+type StaticTable struct {
+	points map[string]domain.LatLng
+}
+
+func NewStaticTable(places []Place) *StaticTable {
+	table := &StaticTable{points: make(map[string]domain.LatLng)}
+	for _, place := range places {
+		table.points[domain.NormalizeAddress(place.Location)] = domain.LatLng{Lat: place.Lat, Lng: place.Lng}
+	}
+	return table
+}
+
+// LoadStaticTable reads a JSON array of Place entries from disk
+func LoadStaticTable(path string) (*StaticTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read geocoding table: %w", err)
+	}
+	
+	var places []Place
+	if err := json.Unmarshal(data, &places); err != nil {
+		return nil, fmt.Errorf("parse geocoding table: %w", err)
+	}
+	for i, place := range places {
+		if place.Lat < -90 || place.Lat > 90 || place.Lng < -180 || place.Lng > 180 {
+			return nil, fmt.Errorf("geocoding table entry %d: lat or lng out of range", i)
+		}
+	}
+	return NewStaticTable(places), nil
+}
+
+func (t *StaticTable) Geocode(ctx context.Context, location string) (domain.LatLng, bool, error) {
+	point, ok := t.points[domain.NormalizeAddress(location)]
+	return point, ok, nil
+}
+
+// HTTPProvider asks an external geocoding API where locations are and
+// remembers the answers. The API is called as GET <baseURL>?location=.. and
+// must return {"lat": <float>, "lng": <float>}, or 404 for a location it does
+// not know. Answers are kept in a bounded LRU cache; failures are cached too,
+// briefly, so an unavailable API is not retried for every request.
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+	cache   *placeCache
+	
+	// ttl is how long an answer is trusted; failureTTL how long a failure is
+	ttl        time.Duration
+	failureTTL time.Duration
+}
+
+func NewHTTPProvider(baseURL string) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL:    baseURL,
+		client:     &http.Client{Timeout: 2 * time.Second},
+		cache:      newPlaceCache(10000),
+		ttl:        7 * 24 * time.Hour,
+		failureTTL: 30 * time.Second,
+	}
+}
+
+func (h *HTTPProvider) Geocode(ctx context.Context, location string) (domain.LatLng, bool, error) {
+	key := domain.NormalizeAddress(location)
+	if entry, ok := h.cache.get(key, time.Now()); ok {
+		return entry.point, entry.found, entry.err
+	}
+	
+	point, found, err := h.fetch(ctx, location)
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up; that says nothing about the API
+		return domain.LatLng{}, false, err
+	}
+	
+	ttl := h.ttl
+	if err != nil {
+		ttl = h.failureTTL
+	}
+	h.cache.put(key, placeEntry{point: point, found: found, err: err, expires: time.Now().Add(ttl)})
+	return point, found, err
+}
+
+func (h *HTTPProvider) fetch(ctx context.Context, location string) (domain.LatLng, bool, error) {
+	query := url.Values{}
+	query.Set("location", location)
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return domain.LatLng{}, false, fmt.Errorf("geocoding api request invalid: %w", err)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return domain.LatLng{}, false, fmt.Errorf("geocoding api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode == http.StatusNotFound {
+		return domain.LatLng{}, false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return domain.LatLng{}, false, fmt.Errorf("geocoding api returned status %d", resp.StatusCode)
+	}
+	
+	var point domain.LatLng
+	if err := json.NewDecoder(resp.Body).Decode(&point); err != nil {
+		return domain.LatLng{}, false, fmt.Errorf("geocoding api response invalid: %w", err)
+	}
+	if point.Lat < -90 || point.Lat > 90 || point.Lng < -180 || point.Lng > 180 {
+		return domain.LatLng{}, false, fmt.Errorf("geocoding api returned lat/lng out of range")
+	}
+	return point, true, nil
+}
+
+type placeEntry struct {
+	key     string
+	point   domain.LatLng
+	found   bool
+	err     error
+	expires time.Time
+}
+
+// placeCache is a fixed-size LRU of geocoding answers with per-entry expiry
+type placeCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newPlaceCache(capacity int) *placeCache {
+	return &placeCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *placeCache) get(key string, now time.Time) (placeEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	element, ok := c.entries[key]
+	if !ok {
+		return placeEntry{}, false
+	}
+	entry := element.Value.(placeEntry)
+	if now.After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return placeEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *placeCache) put(key string, entry placeEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	entry.key = key
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(placeEntry).key)
+	}
+}
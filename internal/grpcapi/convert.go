@@ -0,0 +1,186 @@
+package grpcapi
+
+import (
+	"smart-load/internal/domain"
+	"smart-load/internal/grpcapi/smartloadpb"
+	"smart-load/internal/service"
+)
+
+// RequestFromProto converts a protobuf optimize request to the JSON API's
+// request; the service validates it as it would a JSON body. HTTP handlers
+// use it for application/x-protobuf bodies.
+func RequestFromProto(in *smartloadpb.OptimizeRequest) domain.OptimizeRequest {
+	request := domain.OptimizeRequest{
+		Truck:                 toTruck(in.GetTruck()),
+		Orders:                make([]domain.OrderInput, len(in.GetOrders())),
+		Currency:              in.GetCurrency(),
+		K:                     int(in.GetK()),
+		MustIncludeOrderIDs:   in.GetMustIncludeOrderIds(),
+		MustExcludeOrderIDs:   in.GetMustExcludeOrderIds(),
+		MinTotalPayoutCents:   in.GetMinTotalPayoutCents(),
+		MinUtilizationPercent: in.GetMinUtilizationPercent(),
+		PreviousOrderIDs:      in.GetPreviousOrderIds(),
+		DuplicatePolicy:       in.GetDuplicatePolicy(),
+		DimFactor:             int(in.GetDimFactor()),
+	}
+	for i, order := range in.GetOrders() {
+		request.Orders[i] = toOrder(order)
+	}
+	for _, lane := range in.GetLaneRisks() {
+		request.LaneRisks = append(request.LaneRisks, domain.LaneRiskInput{
+			Origin:      lane.GetOrigin(),
+			Destination: lane.GetDestination(),
+			RiskFactor:  lane.GetRiskFactor(),
+		})
+	}
+	if config := in.GetOptimizationConfig(); config != nil {
+		request.OptimizationConfig = &domain.OptimizationConfig{
+			Objective:         config.GetObjective(),
+			RevenueWeight:     config.GetRevenueWeight(),
+			UtilizationWeight: config.GetUtilizationWeight(),
+			Algorithm:         config.GetAlgorithm(),
+			PriorityMode:      config.GetPriorityMode(),
+			TabuTenure:        int(config.GetTabuTenure()),
+			TabuNeighborhood:  int(config.GetTabuNeighborhood()),
+			StabilityWeight:   config.GetStabilityWeight(),
+			EmissionsWeight:   config.GetEmissionsWeight(),
+			DeadheadWeight:    config.GetDeadheadWeight(),
+			ExcursionWeight:   config.GetExcursionWeight(),
+		}
+	}
+	return request
+}
+
+func toTruck(in *smartloadpb.Truck) domain.TruckInput {
+	return domain.TruckInput{
+		ID:                 in.GetId(),
+		MaxWeightLbs:       int(in.GetMaxWeightLbs()),
+		MaxVolumeCuft:      int(in.GetMaxVolumeCuft()),
+		FixedCostCents:     in.GetFixedCostCents(),
+		CostPerMileCents:   in.GetCostPerMileCents(),
+		StopFeeCents:       in.GetStopFeeCents(),
+		MaxOrders:          int(in.GetMaxOrders()),
+		MaxLinearFeet:      int(in.GetMaxLinearFeet()),
+		MaxPalletPositions: int(in.GetMaxPalletPositions()),
+		EquipmentType:      in.GetEquipmentType(),
+		Equipment:          in.GetEquipment(),
+		InteriorLengthIn:   int(in.GetInteriorLengthIn()),
+		InteriorWidthIn:    int(in.GetInteriorWidthIn()),
+		InteriorHeightIn:   int(in.GetInteriorHeightIn()),
+		Position:           toLatLng(in.GetPosition()),
+		NextPosition:       toLatLng(in.GetNextPosition()),
+	}
+}
+
+func toOrder(in *smartloadpb.Order) domain.OrderInput {
+	return domain.OrderInput{
+		ID:                    in.GetId(),
+		PayoutCents:           in.GetPayoutCents(),
+		WeightLbs:             int(in.GetWeightLbs()),
+		VolumeCuft:            int(in.GetVolumeCuft()),
+		LinearFeet:            int(in.GetLinearFeet()),
+		Origin:                in.GetOrigin(),
+		Destination:           in.GetDestination(),
+		PickupDate:            in.GetPickupDate(),
+		DeliveryDate:          in.GetDeliveryDate(),
+		IsHazmat:              in.GetIsHazmat(),
+		Miles:                 int(in.GetMiles()),
+		Shipper:               in.GetShipper(),
+		OriginPoint:           toLatLng(in.GetOriginPoint()),
+		DestinationPoint:      toLatLng(in.GetDestinationPoint()),
+		PickupWindowStart:     in.GetPickupWindowStart(),
+		PickupWindowEnd:       in.GetPickupWindowEnd(),
+		DeliveryWindowStart:   in.GetDeliveryWindowStart(),
+		DeliveryWindowEnd:     in.GetDeliveryWindowEnd(),
+		HazmatClass:           in.GetHazmatClass(),
+		CommodityType:         in.GetCommodityType(),
+		TemperatureMinF:       in.TemperatureMinF,
+		TemperatureMaxF:       in.TemperatureMaxF,
+		EquipmentRequirements: in.GetEquipmentRequirements(),
+		LengthIn:              int(in.GetLengthIn()),
+		WidthIn:               int(in.GetWidthIn()),
+		HeightIn:              int(in.GetHeightIn()),
+		PalletCount:           int(in.GetPalletCount()),
+		Stackable:             in.GetStackable(),
+		ExclusiveGroup:        in.GetExclusiveGroup(),
+		Splittable:            in.GetSplittable(),
+		Priority:              int(in.GetPriority()),
+		MaxExposureHours:      in.GetMaxExposureHours(),
+	}
+}
+
+func toLatLng(in *smartloadpb.LatLng) *domain.LatLng {
+	if in == nil {
+		return nil
+	}
+	return &domain.LatLng{Lat: in.GetLat(), Lng: in.GetLng()}
+}
+
+// ResponseToProto converts an optimize response to its protobuf message,
+// leaving out what the message does not carry
+func ResponseToProto(in *domain.OptimizeResponse) *smartloadpb.OptimizeResponse {
+	out := &smartloadpb.OptimizeResponse{
+		SolutionId:               in.SolutionID,
+		ProblemFingerprint:       in.ProblemFingerprint,
+		TruckId:                  in.TruckID,
+		SelectedOrderIds:         in.SelectedOrderIDs,
+		TotalPayoutCents:         in.TotalPayoutCents,
+		TotalWeightLbs:           int32(in.TotalWeightLbs),
+		TotalVolumeCuft:          int32(in.TotalVolumeCuft),
+		UtilizationWeightPercent: in.UtilizationWeightPercent,
+		UtilizationVolumePercent: in.UtilizationVolumePercent,
+		CostBreakdown: &smartloadpb.CostBreakdown{
+			FixedCents:   in.CostBreakdown.FixedCents,
+			DriverCents:  in.CostBreakdown.DriverCents,
+			TollCents:    in.CostBreakdown.TollCents,
+			MileageCents: in.CostBreakdown.MileageCents,
+			FuelCents:    in.CostBreakdown.FuelCents,
+			StopFeeCents: in.CostBreakdown.StopFeeCents,
+			TotalCents:   in.CostBreakdown.TotalCents,
+		},
+		NetProfitCents:        in.NetProfitCents,
+		Recommendation:        in.Recommendation,
+		RecommendationReasons: in.RecommendationReasons,
+		Currency:              in.Currency,
+		Score:                 in.Score,
+		EmissionsKgCo2:        in.EmissionsKgCO2,
+		Alternatives:          make([]*smartloadpb.PlanSummary, len(in.Alternatives)),
+		LoadingSequence:       in.LoadingSequence,
+	}
+	if in.Excursion != nil {
+		out.Excursion = &smartloadpb.Excursion{
+			ExposureHours:     in.Excursion.ExposureHours,
+			SafeExposureHours: in.Excursion.SafeExposureHours,
+			ExcessHours:       in.Excursion.ExcessHours,
+		}
+	}
+	for i, plan := range in.Alternatives {
+		out.Alternatives[i] = &smartloadpb.PlanSummary{
+			Rank:                     int32(plan.Rank),
+			SelectedOrderIds:         plan.SelectedOrderIDs,
+			TotalPayoutCents:         plan.TotalPayoutCents,
+			TotalWeightLbs:           int32(plan.TotalWeightLbs),
+			TotalVolumeCuft:          int32(plan.TotalVolumeCuft),
+			UtilizationWeightPercent: plan.UtilizationWeightPercent,
+			UtilizationVolumePercent: plan.UtilizationVolumePercent,
+			NetProfitCents:           plan.NetProfitCents,
+			EmissionsKgCo2:           plan.EmissionsKgCO2,
+		}
+	}
+	return out
+}
+
+// ParetoSolutionToProto converts one Pareto-optimal plan to its protobuf
+// message
+func ParetoSolutionToProto(in service.ParetoSolution) *smartloadpb.ParetoSolution {
+	return &smartloadpb.ParetoSolution{
+		OrderIds:                 in.OrderIDs,
+		TotalPayoutCents:         in.TotalPayoutCents,
+		TotalWeightLbs:           int32(in.TotalWeightLbs),
+		TotalVolumeCuft:          int32(in.TotalVolumeCuft),
+		UtilizationWeightPercent: in.UtilizationWeightPercent,
+		UtilizationVolumePercent: in.UtilizationVolumePercent,
+		Score:                    in.Score,
+		EmissionsKgCo2:           in.EmissionsKgCO2,
+	}
+}
@@ -0,0 +1,154 @@
+// Package grpcapi serves the optimizer over gRPC, next to the HTTP API and
+// on the same service layer. Messages are defined in smartloadpb.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"smart-load/internal/auth"
+	"smart-load/internal/grpcapi/smartloadpb"
+	"smart-load/internal/service"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// maxParetoSolutions caps the limit of a ParetoSolutions call, as it caps
+// the limit query parameter of /pareto-solutions
+const maxParetoSolutions = 1000
+
+// Server implements the Optimizer gRPC service
+type Server struct {
+	smartloadpb.UnimplementedOptimizerServer
+	optimizerService *service.OptimizerService
+}
+
+// NewServer returns a gRPC server with the Optimizer service registered.
+// With keys, every call needs an API key with the solve scope, as the HTTP
+// API does; without, calls are open.
+func NewServer(optimizerService *service.OptimizerService, keys *auth.KeyStore) *grpc.Server {
+	server := grpc.NewServer(grpc.UnaryInterceptor(authenticate(keys)))
+	smartloadpb.RegisterOptimizerServer(server, &Server{optimizerService: optimizerService})
+	return server
+}
+
+// Optimize picks the best load for one truck
+func (s *Server) Optimize(ctx context.Context, in *smartloadpb.OptimizeRequest) (*smartloadpb.OptimizeResponse, error) {
+	request := RequestFromProto(in)
+	request.TenantID = tenantID(ctx)
+	request.APIKey = principalName(ctx)
+	
+	response, err := s.optimizerService.OptimizeLoad(ctx, request)
+	if err != nil {
+		return nil, solveError(err)
+	}
+	return ResponseToProto(response), nil
+}
+
+// ParetoSolutions lists the plans trading payout against utilization,
+// fullest first
+func (s *Server) ParetoSolutions(ctx context.Context, in *smartloadpb.ParetoSolutionsRequest) (*smartloadpb.ParetoSolutionsResponse, error) {
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = 20
+	}
+	if limit < 1 || limit > maxParetoSolutions {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxParetoSolutions)
+	}
+	
+	request := RequestFromProto(in.GetRequest())
+	request.TenantID = tenantID(ctx)
+	request.APIKey = principalName(ctx)
+	if err := s.optimizerService.ValidateRequest(&request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	truck, orders, err := request.ToDomain()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	
+	solutions, exact, err := s.optimizerService.GetParetoOptimalSolutions(ctx, request.TenantID, *truck, orders, request.Pins(), limit)
+	if err != nil {
+		return nil, solveError(err)
+	}
+	response := &smartloadpb.ParetoSolutionsResponse{
+		TruckId:   truck.ID,
+		Solutions: make([]*smartloadpb.ParetoSolution, len(solutions)),
+		Exact:     exact,
+		Total:     int32(len(solutions)),
+	}
+	for i, solution := range solutions {
+		response.Solutions[i] = ParetoSolutionToProto(solution)
+	}
+	return response, nil
+}
+
+// solveError maps a solve error to its status, as solveErrorStatus does for
+// HTTP: InvalidArgument for validation, Unavailable for a solve cut short
+func solveError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), "validation"):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+type principalKey struct{}
+
+// authenticate checks the API key in the x-api-key or "authorization: Bearer"
+// metadata and the tenant in x-tenant-id, as APIKeyAuth does for HTTP
+func authenticate(keys *auth.KeyStore) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if keys == nil {
+			return handler(ctx, req)
+		}
+		key := firstMetadata(ctx, "x-api-key")
+		if key == "" {
+			if bearer := firstMetadata(ctx, "authorization"); strings.HasPrefix(bearer, "Bearer ") {
+				key = strings.TrimPrefix(bearer, "Bearer ")
+			}
+		}
+		if key == "" {
+			return nil, status.Error(codes.Unauthenticated, "API key required")
+		}
+		
+		principal, ok := keys.Lookup(key)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		}
+		if tenant := tenantID(ctx); !principal.CanAccessTenant(tenant) {
+			return nil, status.Error(codes.PermissionDenied, "API key is not allowed to act for tenant "+tenant)
+		}
+		if !principal.HasScope(auth.ScopeSolve) {
+			return nil, status.Error(codes.PermissionDenied, "API key lacks the "+string(auth.ScopeSolve)+" scope")
+		}
+		return handler(context.WithValue(ctx, principalKey{}, principal), req)
+	}
+}
+
+// tenantID is the tenant named in the x-tenant-id metadata, the gRPC
+// counterpart of the X-Tenant-ID header
+func tenantID(ctx context.Context) string {
+	return firstMetadata(ctx, "x-tenant-id")
+}
+
+// principalName returns the name of the caller's API key, or "" when calls
+// are not authenticated
+func principalName(ctx context.Context) string {
+	principal, _ := ctx.Value(principalKey{}).(auth.Principal)
+	return principal.Name
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,5 @@
+// Package smartloadpb holds the protobuf messages and gRPC service of the
+// optimizer, generated from optimizer.proto
+package smartloadpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative optimizer.proto
@@ -0,0 +1,2186 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: optimizer.proto
+
+package smartloadpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LatLng struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng float64 `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+}
+
+func (x *LatLng) Reset() {
+	*x = LatLng{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LatLng) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LatLng) ProtoMessage() {}
+
+func (x *LatLng) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LatLng.ProtoReflect.Descriptor instead.
+func (*LatLng) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LatLng) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *LatLng) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+type Truck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                 string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxWeightLbs       int32    `protobuf:"varint,2,opt,name=max_weight_lbs,json=maxWeightLbs,proto3" json:"max_weight_lbs,omitempty"`
+	MaxVolumeCuft      int32    `protobuf:"varint,3,opt,name=max_volume_cuft,json=maxVolumeCuft,proto3" json:"max_volume_cuft,omitempty"`
+	FixedCostCents     int64    `protobuf:"varint,4,opt,name=fixed_cost_cents,json=fixedCostCents,proto3" json:"fixed_cost_cents,omitempty"`
+	CostPerMileCents   int64    `protobuf:"varint,5,opt,name=cost_per_mile_cents,json=costPerMileCents,proto3" json:"cost_per_mile_cents,omitempty"`
+	StopFeeCents       int64    `protobuf:"varint,6,opt,name=stop_fee_cents,json=stopFeeCents,proto3" json:"stop_fee_cents,omitempty"`
+	MaxOrders          int32    `protobuf:"varint,7,opt,name=max_orders,json=maxOrders,proto3" json:"max_orders,omitempty"`
+	MaxLinearFeet      int32    `protobuf:"varint,8,opt,name=max_linear_feet,json=maxLinearFeet,proto3" json:"max_linear_feet,omitempty"`
+	MaxPalletPositions int32    `protobuf:"varint,9,opt,name=max_pallet_positions,json=maxPalletPositions,proto3" json:"max_pallet_positions,omitempty"`
+	EquipmentType      string   `protobuf:"bytes,10,opt,name=equipment_type,json=equipmentType,proto3" json:"equipment_type,omitempty"`
+	Equipment          []string `protobuf:"bytes,11,rep,name=equipment,proto3" json:"equipment,omitempty"`
+	InteriorLengthIn   int32    `protobuf:"varint,12,opt,name=interior_length_in,json=interiorLengthIn,proto3" json:"interior_length_in,omitempty"`
+	InteriorWidthIn    int32    `protobuf:"varint,13,opt,name=interior_width_in,json=interiorWidthIn,proto3" json:"interior_width_in,omitempty"`
+	InteriorHeightIn   int32    `protobuf:"varint,14,opt,name=interior_height_in,json=interiorHeightIn,proto3" json:"interior_height_in,omitempty"`
+	Position           *LatLng  `protobuf:"bytes,15,opt,name=position,proto3" json:"position,omitempty"`
+	NextPosition       *LatLng  `protobuf:"bytes,16,opt,name=next_position,json=nextPosition,proto3" json:"next_position,omitempty"`
+}
+
+func (x *Truck) Reset() {
+	*x = Truck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Truck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Truck) ProtoMessage() {}
+
+func (x *Truck) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Truck.ProtoReflect.Descriptor instead.
+func (*Truck) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Truck) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Truck) GetMaxWeightLbs() int32 {
+	if x != nil {
+		return x.MaxWeightLbs
+	}
+	return 0
+}
+
+func (x *Truck) GetMaxVolumeCuft() int32 {
+	if x != nil {
+		return x.MaxVolumeCuft
+	}
+	return 0
+}
+
+func (x *Truck) GetFixedCostCents() int64 {
+	if x != nil {
+		return x.FixedCostCents
+	}
+	return 0
+}
+
+func (x *Truck) GetCostPerMileCents() int64 {
+	if x != nil {
+		return x.CostPerMileCents
+	}
+	return 0
+}
+
+func (x *Truck) GetStopFeeCents() int64 {
+	if x != nil {
+		return x.StopFeeCents
+	}
+	return 0
+}
+
+func (x *Truck) GetMaxOrders() int32 {
+	if x != nil {
+		return x.MaxOrders
+	}
+	return 0
+}
+
+func (x *Truck) GetMaxLinearFeet() int32 {
+	if x != nil {
+		return x.MaxLinearFeet
+	}
+	return 0
+}
+
+func (x *Truck) GetMaxPalletPositions() int32 {
+	if x != nil {
+		return x.MaxPalletPositions
+	}
+	return 0
+}
+
+func (x *Truck) GetEquipmentType() string {
+	if x != nil {
+		return x.EquipmentType
+	}
+	return ""
+}
+
+func (x *Truck) GetEquipment() []string {
+	if x != nil {
+		return x.Equipment
+	}
+	return nil
+}
+
+func (x *Truck) GetInteriorLengthIn() int32 {
+	if x != nil {
+		return x.InteriorLengthIn
+	}
+	return 0
+}
+
+func (x *Truck) GetInteriorWidthIn() int32 {
+	if x != nil {
+		return x.InteriorWidthIn
+	}
+	return 0
+}
+
+func (x *Truck) GetInteriorHeightIn() int32 {
+	if x != nil {
+		return x.InteriorHeightIn
+	}
+	return 0
+}
+
+func (x *Truck) GetPosition() *LatLng {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Truck) GetNextPosition() *LatLng {
+	if x != nil {
+		return x.NextPosition
+	}
+	return nil
+}
+
+type Order struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                    string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PayoutCents           int64    `protobuf:"varint,2,opt,name=payout_cents,json=payoutCents,proto3" json:"payout_cents,omitempty"`
+	WeightLbs             int32    `protobuf:"varint,3,opt,name=weight_lbs,json=weightLbs,proto3" json:"weight_lbs,omitempty"`
+	VolumeCuft            int32    `protobuf:"varint,4,opt,name=volume_cuft,json=volumeCuft,proto3" json:"volume_cuft,omitempty"`
+	LinearFeet            int32    `protobuf:"varint,5,opt,name=linear_feet,json=linearFeet,proto3" json:"linear_feet,omitempty"`
+	Origin                string   `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination           string   `protobuf:"bytes,7,opt,name=destination,proto3" json:"destination,omitempty"`
+	PickupDate            string   `protobuf:"bytes,8,opt,name=pickup_date,json=pickupDate,proto3" json:"pickup_date,omitempty"`
+	DeliveryDate          string   `protobuf:"bytes,9,opt,name=delivery_date,json=deliveryDate,proto3" json:"delivery_date,omitempty"`
+	IsHazmat              bool     `protobuf:"varint,10,opt,name=is_hazmat,json=isHazmat,proto3" json:"is_hazmat,omitempty"`
+	Miles                 int32    `protobuf:"varint,11,opt,name=miles,proto3" json:"miles,omitempty"`
+	Shipper               string   `protobuf:"bytes,12,opt,name=shipper,proto3" json:"shipper,omitempty"`
+	OriginPoint           *LatLng  `protobuf:"bytes,13,opt,name=origin_point,json=originPoint,proto3" json:"origin_point,omitempty"`
+	DestinationPoint      *LatLng  `protobuf:"bytes,14,opt,name=destination_point,json=destinationPoint,proto3" json:"destination_point,omitempty"`
+	PickupWindowStart     string   `protobuf:"bytes,15,opt,name=pickup_window_start,json=pickupWindowStart,proto3" json:"pickup_window_start,omitempty"`
+	PickupWindowEnd       string   `protobuf:"bytes,16,opt,name=pickup_window_end,json=pickupWindowEnd,proto3" json:"pickup_window_end,omitempty"`
+	DeliveryWindowStart   string   `protobuf:"bytes,17,opt,name=delivery_window_start,json=deliveryWindowStart,proto3" json:"delivery_window_start,omitempty"`
+	DeliveryWindowEnd     string   `protobuf:"bytes,18,opt,name=delivery_window_end,json=deliveryWindowEnd,proto3" json:"delivery_window_end,omitempty"`
+	HazmatClass           string   `protobuf:"bytes,19,opt,name=hazmat_class,json=hazmatClass,proto3" json:"hazmat_class,omitempty"`
+	CommodityType         string   `protobuf:"bytes,20,opt,name=commodity_type,json=commodityType,proto3" json:"commodity_type,omitempty"`
+	TemperatureMinF       *float64 `protobuf:"fixed64,21,opt,name=temperature_min_f,json=temperatureMinF,proto3,oneof" json:"temperature_min_f,omitempty"`
+	TemperatureMaxF       *float64 `protobuf:"fixed64,22,opt,name=temperature_max_f,json=temperatureMaxF,proto3,oneof" json:"temperature_max_f,omitempty"`
+	EquipmentRequirements []string `protobuf:"bytes,23,rep,name=equipment_requirements,json=equipmentRequirements,proto3" json:"equipment_requirements,omitempty"`
+	LengthIn              int32    `protobuf:"varint,24,opt,name=length_in,json=lengthIn,proto3" json:"length_in,omitempty"`
+	WidthIn               int32    `protobuf:"varint,25,opt,name=width_in,json=widthIn,proto3" json:"width_in,omitempty"`
+	HeightIn              int32    `protobuf:"varint,26,opt,name=height_in,json=heightIn,proto3" json:"height_in,omitempty"`
+	PalletCount           int32    `protobuf:"varint,27,opt,name=pallet_count,json=palletCount,proto3" json:"pallet_count,omitempty"`
+	Stackable             bool     `protobuf:"varint,28,opt,name=stackable,proto3" json:"stackable,omitempty"`
+	ExclusiveGroup        string   `protobuf:"bytes,29,opt,name=exclusive_group,json=exclusiveGroup,proto3" json:"exclusive_group,omitempty"`
+	Splittable            bool     `protobuf:"varint,30,opt,name=splittable,proto3" json:"splittable,omitempty"`
+	Priority              int32    `protobuf:"varint,31,opt,name=priority,proto3" json:"priority,omitempty"`
+	MaxExposureHours      float64  `protobuf:"fixed64,32,opt,name=max_exposure_hours,json=maxExposureHours,proto3" json:"max_exposure_hours,omitempty"`
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetPayoutCents() int64 {
+	if x != nil {
+		return x.PayoutCents
+	}
+	return 0
+}
+
+func (x *Order) GetWeightLbs() int32 {
+	if x != nil {
+		return x.WeightLbs
+	}
+	return 0
+}
+
+func (x *Order) GetVolumeCuft() int32 {
+	if x != nil {
+		return x.VolumeCuft
+	}
+	return 0
+}
+
+func (x *Order) GetLinearFeet() int32 {
+	if x != nil {
+		return x.LinearFeet
+	}
+	return 0
+}
+
+func (x *Order) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *Order) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *Order) GetPickupDate() string {
+	if x != nil {
+		return x.PickupDate
+	}
+	return ""
+}
+
+func (x *Order) GetDeliveryDate() string {
+	if x != nil {
+		return x.DeliveryDate
+	}
+	return ""
+}
+
+func (x *Order) GetIsHazmat() bool {
+	if x != nil {
+		return x.IsHazmat
+	}
+	return false
+}
+
+func (x *Order) GetMiles() int32 {
+	if x != nil {
+		return x.Miles
+	}
+	return 0
+}
+
+func (x *Order) GetShipper() string {
+	if x != nil {
+		return x.Shipper
+	}
+	return ""
+}
+
+func (x *Order) GetOriginPoint() *LatLng {
+	if x != nil {
+		return x.OriginPoint
+	}
+	return nil
+}
+
+func (x *Order) GetDestinationPoint() *LatLng {
+	if x != nil {
+		return x.DestinationPoint
+	}
+	return nil
+}
+
+func (x *Order) GetPickupWindowStart() string {
+	if x != nil {
+		return x.PickupWindowStart
+	}
+	return ""
+}
+
+func (x *Order) GetPickupWindowEnd() string {
+	if x != nil {
+		return x.PickupWindowEnd
+	}
+	return ""
+}
+
+func (x *Order) GetDeliveryWindowStart() string {
+	if x != nil {
+		return x.DeliveryWindowStart
+	}
+	return ""
+}
+
+func (x *Order) GetDeliveryWindowEnd() string {
+	if x != nil {
+		return x.DeliveryWindowEnd
+	}
+	return ""
+}
+
+func (x *Order) GetHazmatClass() string {
+	if x != nil {
+		return x.HazmatClass
+	}
+	return ""
+}
+
+func (x *Order) GetCommodityType() string {
+	if x != nil {
+		return x.CommodityType
+	}
+	return ""
+}
+
+func (x *Order) GetTemperatureMinF() float64 {
+	if x != nil && x.TemperatureMinF != nil {
+		return *x.TemperatureMinF
+	}
+	return 0
+}
+
+func (x *Order) GetTemperatureMaxF() float64 {
+	if x != nil && x.TemperatureMaxF != nil {
+		return *x.TemperatureMaxF
+	}
+	return 0
+}
+
+func (x *Order) GetEquipmentRequirements() []string {
+	if x != nil {
+		return x.EquipmentRequirements
+	}
+	return nil
+}
+
+func (x *Order) GetLengthIn() int32 {
+	if x != nil {
+		return x.LengthIn
+	}
+	return 0
+}
+
+func (x *Order) GetWidthIn() int32 {
+	if x != nil {
+		return x.WidthIn
+	}
+	return 0
+}
+
+func (x *Order) GetHeightIn() int32 {
+	if x != nil {
+		return x.HeightIn
+	}
+	return 0
+}
+
+func (x *Order) GetPalletCount() int32 {
+	if x != nil {
+		return x.PalletCount
+	}
+	return 0
+}
+
+func (x *Order) GetStackable() bool {
+	if x != nil {
+		return x.Stackable
+	}
+	return false
+}
+
+func (x *Order) GetExclusiveGroup() string {
+	if x != nil {
+		return x.ExclusiveGroup
+	}
+	return ""
+}
+
+func (x *Order) GetSplittable() bool {
+	if x != nil {
+		return x.Splittable
+	}
+	return false
+}
+
+func (x *Order) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *Order) GetMaxExposureHours() float64 {
+	if x != nil {
+		return x.MaxExposureHours
+	}
+	return 0
+}
+
+type OptimizationConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Objective         string  `protobuf:"bytes,1,opt,name=objective,proto3" json:"objective,omitempty"`
+	RevenueWeight     float64 `protobuf:"fixed64,2,opt,name=revenue_weight,json=revenueWeight,proto3" json:"revenue_weight,omitempty"`
+	UtilizationWeight float64 `protobuf:"fixed64,3,opt,name=utilization_weight,json=utilizationWeight,proto3" json:"utilization_weight,omitempty"`
+	Algorithm         string  `protobuf:"bytes,4,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	PriorityMode      string  `protobuf:"bytes,5,opt,name=priority_mode,json=priorityMode,proto3" json:"priority_mode,omitempty"`
+	TabuTenure        int32   `protobuf:"varint,6,opt,name=tabu_tenure,json=tabuTenure,proto3" json:"tabu_tenure,omitempty"`
+	TabuNeighborhood  int32   `protobuf:"varint,7,opt,name=tabu_neighborhood,json=tabuNeighborhood,proto3" json:"tabu_neighborhood,omitempty"`
+	StabilityWeight   float64 `protobuf:"fixed64,8,opt,name=stability_weight,json=stabilityWeight,proto3" json:"stability_weight,omitempty"`
+	EmissionsWeight   float64 `protobuf:"fixed64,9,opt,name=emissions_weight,json=emissionsWeight,proto3" json:"emissions_weight,omitempty"`
+	DeadheadWeight    float64 `protobuf:"fixed64,10,opt,name=deadhead_weight,json=deadheadWeight,proto3" json:"deadhead_weight,omitempty"`
+	ExcursionWeight   float64 `protobuf:"fixed64,11,opt,name=excursion_weight,json=excursionWeight,proto3" json:"excursion_weight,omitempty"`
+}
+
+func (x *OptimizationConfig) Reset() {
+	*x = OptimizationConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptimizationConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizationConfig) ProtoMessage() {}
+
+func (x *OptimizationConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizationConfig.ProtoReflect.Descriptor instead.
+func (*OptimizationConfig) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OptimizationConfig) GetObjective() string {
+	if x != nil {
+		return x.Objective
+	}
+	return ""
+}
+
+func (x *OptimizationConfig) GetRevenueWeight() float64 {
+	if x != nil {
+		return x.RevenueWeight
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetUtilizationWeight() float64 {
+	if x != nil {
+		return x.UtilizationWeight
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *OptimizationConfig) GetPriorityMode() string {
+	if x != nil {
+		return x.PriorityMode
+	}
+	return ""
+}
+
+func (x *OptimizationConfig) GetTabuTenure() int32 {
+	if x != nil {
+		return x.TabuTenure
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetTabuNeighborhood() int32 {
+	if x != nil {
+		return x.TabuNeighborhood
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetStabilityWeight() float64 {
+	if x != nil {
+		return x.StabilityWeight
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetEmissionsWeight() float64 {
+	if x != nil {
+		return x.EmissionsWeight
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetDeadheadWeight() float64 {
+	if x != nil {
+		return x.DeadheadWeight
+	}
+	return 0
+}
+
+func (x *OptimizationConfig) GetExcursionWeight() float64 {
+	if x != nil {
+		return x.ExcursionWeight
+	}
+	return 0
+}
+
+type OptimizeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Truck                 *Truck              `protobuf:"bytes,1,opt,name=truck,proto3" json:"truck,omitempty"`
+	Orders                []*Order            `protobuf:"bytes,2,rep,name=orders,proto3" json:"orders,omitempty"`
+	OptimizationConfig    *OptimizationConfig `protobuf:"bytes,3,opt,name=optimization_config,json=optimizationConfig,proto3" json:"optimization_config,omitempty"`
+	Currency              string              `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	K                     int32               `protobuf:"varint,5,opt,name=k,proto3" json:"k,omitempty"`
+	MustIncludeOrderIds   []string            `protobuf:"bytes,6,rep,name=must_include_order_ids,json=mustIncludeOrderIds,proto3" json:"must_include_order_ids,omitempty"`
+	MustExcludeOrderIds   []string            `protobuf:"bytes,7,rep,name=must_exclude_order_ids,json=mustExcludeOrderIds,proto3" json:"must_exclude_order_ids,omitempty"`
+	MinTotalPayoutCents   int64               `protobuf:"varint,8,opt,name=min_total_payout_cents,json=minTotalPayoutCents,proto3" json:"min_total_payout_cents,omitempty"`
+	MinUtilizationPercent float64             `protobuf:"fixed64,9,opt,name=min_utilization_percent,json=minUtilizationPercent,proto3" json:"min_utilization_percent,omitempty"`
+	PreviousOrderIds      []string            `protobuf:"bytes,10,rep,name=previous_order_ids,json=previousOrderIds,proto3" json:"previous_order_ids,omitempty"`
+	DuplicatePolicy       string              `protobuf:"bytes,11,opt,name=duplicate_policy,json=duplicatePolicy,proto3" json:"duplicate_policy,omitempty"`
+	DimFactor             int32               `protobuf:"varint,12,opt,name=dim_factor,json=dimFactor,proto3" json:"dim_factor,omitempty"`
+	LaneRisks             []*LaneRisk         `protobuf:"bytes,13,rep,name=lane_risks,json=laneRisks,proto3" json:"lane_risks,omitempty"`
+}
+
+func (x *OptimizeRequest) Reset() {
+	*x = OptimizeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptimizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeRequest) ProtoMessage() {}
+
+func (x *OptimizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeRequest.ProtoReflect.Descriptor instead.
+func (*OptimizeRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *OptimizeRequest) GetTruck() *Truck {
+	if x != nil {
+		return x.Truck
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetOptimizationConfig() *OptimizationConfig {
+	if x != nil {
+		return x.OptimizationConfig
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMustIncludeOrderIds() []string {
+	if x != nil {
+		return x.MustIncludeOrderIds
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetMustExcludeOrderIds() []string {
+	if x != nil {
+		return x.MustExcludeOrderIds
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetMinTotalPayoutCents() int64 {
+	if x != nil {
+		return x.MinTotalPayoutCents
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetMinUtilizationPercent() float64 {
+	if x != nil {
+		return x.MinUtilizationPercent
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetPreviousOrderIds() []string {
+	if x != nil {
+		return x.PreviousOrderIds
+	}
+	return nil
+}
+
+func (x *OptimizeRequest) GetDuplicatePolicy() string {
+	if x != nil {
+		return x.DuplicatePolicy
+	}
+	return ""
+}
+
+func (x *OptimizeRequest) GetDimFactor() int32 {
+	if x != nil {
+		return x.DimFactor
+	}
+	return 0
+}
+
+func (x *OptimizeRequest) GetLaneRisks() []*LaneRisk {
+	if x != nil {
+		return x.LaneRisks
+	}
+	return nil
+}
+
+type LaneRisk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Origin      string  `protobuf:"bytes,1,opt,name=origin,proto3" json:"origin,omitempty"`
+	Destination string  `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	RiskFactor  float64 `protobuf:"fixed64,3,opt,name=risk_factor,json=riskFactor,proto3" json:"risk_factor,omitempty"`
+}
+
+func (x *LaneRisk) Reset() {
+	*x = LaneRisk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaneRisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaneRisk) ProtoMessage() {}
+
+func (x *LaneRisk) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaneRisk.ProtoReflect.Descriptor instead.
+func (*LaneRisk) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LaneRisk) GetOrigin() string {
+	if x != nil {
+		return x.Origin
+	}
+	return ""
+}
+
+func (x *LaneRisk) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *LaneRisk) GetRiskFactor() float64 {
+	if x != nil {
+		return x.RiskFactor
+	}
+	return 0
+}
+
+type PlanSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rank                     int32    `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	SelectedOrderIds         []string `protobuf:"bytes,2,rep,name=selected_order_ids,json=selectedOrderIds,proto3" json:"selected_order_ids,omitempty"`
+	TotalPayoutCents         int64    `protobuf:"varint,3,opt,name=total_payout_cents,json=totalPayoutCents,proto3" json:"total_payout_cents,omitempty"`
+	TotalWeightLbs           int32    `protobuf:"varint,4,opt,name=total_weight_lbs,json=totalWeightLbs,proto3" json:"total_weight_lbs,omitempty"`
+	TotalVolumeCuft          int32    `protobuf:"varint,5,opt,name=total_volume_cuft,json=totalVolumeCuft,proto3" json:"total_volume_cuft,omitempty"`
+	UtilizationWeightPercent float64  `protobuf:"fixed64,6,opt,name=utilization_weight_percent,json=utilizationWeightPercent,proto3" json:"utilization_weight_percent,omitempty"`
+	UtilizationVolumePercent float64  `protobuf:"fixed64,7,opt,name=utilization_volume_percent,json=utilizationVolumePercent,proto3" json:"utilization_volume_percent,omitempty"`
+	NetProfitCents           int64    `protobuf:"varint,8,opt,name=net_profit_cents,json=netProfitCents,proto3" json:"net_profit_cents,omitempty"`
+	EmissionsKgCo2           float64  `protobuf:"fixed64,9,opt,name=emissions_kg_co2,json=emissionsKgCo2,proto3" json:"emissions_kg_co2,omitempty"`
+}
+
+func (x *PlanSummary) Reset() {
+	*x = PlanSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlanSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanSummary) ProtoMessage() {}
+
+func (x *PlanSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanSummary.ProtoReflect.Descriptor instead.
+func (*PlanSummary) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlanSummary) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetSelectedOrderIds() []string {
+	if x != nil {
+		return x.SelectedOrderIds
+	}
+	return nil
+}
+
+func (x *PlanSummary) GetTotalPayoutCents() int64 {
+	if x != nil {
+		return x.TotalPayoutCents
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetTotalWeightLbs() int32 {
+	if x != nil {
+		return x.TotalWeightLbs
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetTotalVolumeCuft() int32 {
+	if x != nil {
+		return x.TotalVolumeCuft
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetUtilizationWeightPercent() float64 {
+	if x != nil {
+		return x.UtilizationWeightPercent
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetUtilizationVolumePercent() float64 {
+	if x != nil {
+		return x.UtilizationVolumePercent
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetNetProfitCents() int64 {
+	if x != nil {
+		return x.NetProfitCents
+	}
+	return 0
+}
+
+func (x *PlanSummary) GetEmissionsKgCo2() float64 {
+	if x != nil {
+		return x.EmissionsKgCo2
+	}
+	return 0
+}
+
+type CostBreakdown struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FixedCents   int64 `protobuf:"varint,1,opt,name=fixed_cents,json=fixedCents,proto3" json:"fixed_cents,omitempty"`
+	DriverCents  int64 `protobuf:"varint,2,opt,name=driver_cents,json=driverCents,proto3" json:"driver_cents,omitempty"`
+	TollCents    int64 `protobuf:"varint,3,opt,name=toll_cents,json=tollCents,proto3" json:"toll_cents,omitempty"`
+	MileageCents int64 `protobuf:"varint,4,opt,name=mileage_cents,json=mileageCents,proto3" json:"mileage_cents,omitempty"`
+	FuelCents    int64 `protobuf:"varint,5,opt,name=fuel_cents,json=fuelCents,proto3" json:"fuel_cents,omitempty"`
+	StopFeeCents int64 `protobuf:"varint,6,opt,name=stop_fee_cents,json=stopFeeCents,proto3" json:"stop_fee_cents,omitempty"`
+	TotalCents   int64 `protobuf:"varint,7,opt,name=total_cents,json=totalCents,proto3" json:"total_cents,omitempty"`
+}
+
+func (x *CostBreakdown) Reset() {
+	*x = CostBreakdown{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CostBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CostBreakdown) ProtoMessage() {}
+
+func (x *CostBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CostBreakdown.ProtoReflect.Descriptor instead.
+func (*CostBreakdown) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CostBreakdown) GetFixedCents() int64 {
+	if x != nil {
+		return x.FixedCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetDriverCents() int64 {
+	if x != nil {
+		return x.DriverCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetTollCents() int64 {
+	if x != nil {
+		return x.TollCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetMileageCents() int64 {
+	if x != nil {
+		return x.MileageCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetFuelCents() int64 {
+	if x != nil {
+		return x.FuelCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetStopFeeCents() int64 {
+	if x != nil {
+		return x.StopFeeCents
+	}
+	return 0
+}
+
+func (x *CostBreakdown) GetTotalCents() int64 {
+	if x != nil {
+		return x.TotalCents
+	}
+	return 0
+}
+
+type OptimizeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SolutionId               string         `protobuf:"bytes,1,opt,name=solution_id,json=solutionId,proto3" json:"solution_id,omitempty"`
+	ProblemFingerprint       string         `protobuf:"bytes,2,opt,name=problem_fingerprint,json=problemFingerprint,proto3" json:"problem_fingerprint,omitempty"`
+	TruckId                  string         `protobuf:"bytes,3,opt,name=truck_id,json=truckId,proto3" json:"truck_id,omitempty"`
+	SelectedOrderIds         []string       `protobuf:"bytes,4,rep,name=selected_order_ids,json=selectedOrderIds,proto3" json:"selected_order_ids,omitempty"`
+	TotalPayoutCents         int64          `protobuf:"varint,5,opt,name=total_payout_cents,json=totalPayoutCents,proto3" json:"total_payout_cents,omitempty"`
+	TotalWeightLbs           int32          `protobuf:"varint,6,opt,name=total_weight_lbs,json=totalWeightLbs,proto3" json:"total_weight_lbs,omitempty"`
+	TotalVolumeCuft          int32          `protobuf:"varint,7,opt,name=total_volume_cuft,json=totalVolumeCuft,proto3" json:"total_volume_cuft,omitempty"`
+	UtilizationWeightPercent float64        `protobuf:"fixed64,8,opt,name=utilization_weight_percent,json=utilizationWeightPercent,proto3" json:"utilization_weight_percent,omitempty"`
+	UtilizationVolumePercent float64        `protobuf:"fixed64,9,opt,name=utilization_volume_percent,json=utilizationVolumePercent,proto3" json:"utilization_volume_percent,omitempty"`
+	CostBreakdown            *CostBreakdown `protobuf:"bytes,10,opt,name=cost_breakdown,json=costBreakdown,proto3" json:"cost_breakdown,omitempty"`
+	NetProfitCents           int64          `protobuf:"varint,11,opt,name=net_profit_cents,json=netProfitCents,proto3" json:"net_profit_cents,omitempty"`
+	Recommendation           string         `protobuf:"bytes,12,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
+	RecommendationReasons    []string       `protobuf:"bytes,13,rep,name=recommendation_reasons,json=recommendationReasons,proto3" json:"recommendation_reasons,omitempty"`
+	Currency                 string         `protobuf:"bytes,14,opt,name=currency,proto3" json:"currency,omitempty"`
+	Score                    int64          `protobuf:"varint,15,opt,name=score,proto3" json:"score,omitempty"`
+	EmissionsKgCo2           float64        `protobuf:"fixed64,16,opt,name=emissions_kg_co2,json=emissionsKgCo2,proto3" json:"emissions_kg_co2,omitempty"`
+	Alternatives             []*PlanSummary `protobuf:"bytes,17,rep,name=alternatives,proto3" json:"alternatives,omitempty"`
+	LoadingSequence          []string       `protobuf:"bytes,18,rep,name=loading_sequence,json=loadingSequence,proto3" json:"loading_sequence,omitempty"`
+	Excursion                *Excursion     `protobuf:"bytes,19,opt,name=excursion,proto3" json:"excursion,omitempty"`
+}
+
+func (x *OptimizeResponse) Reset() {
+	*x = OptimizeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OptimizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OptimizeResponse) ProtoMessage() {}
+
+func (x *OptimizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OptimizeResponse.ProtoReflect.Descriptor instead.
+func (*OptimizeResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OptimizeResponse) GetSolutionId() string {
+	if x != nil {
+		return x.SolutionId
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetProblemFingerprint() string {
+	if x != nil {
+		return x.ProblemFingerprint
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetTruckId() string {
+	if x != nil {
+		return x.TruckId
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetSelectedOrderIds() []string {
+	if x != nil {
+		return x.SelectedOrderIds
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetTotalPayoutCents() int64 {
+	if x != nil {
+		return x.TotalPayoutCents
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetTotalWeightLbs() int32 {
+	if x != nil {
+		return x.TotalWeightLbs
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetTotalVolumeCuft() int32 {
+	if x != nil {
+		return x.TotalVolumeCuft
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetUtilizationWeightPercent() float64 {
+	if x != nil {
+		return x.UtilizationWeightPercent
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetUtilizationVolumePercent() float64 {
+	if x != nil {
+		return x.UtilizationVolumePercent
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetCostBreakdown() *CostBreakdown {
+	if x != nil {
+		return x.CostBreakdown
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetNetProfitCents() int64 {
+	if x != nil {
+		return x.NetProfitCents
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetRecommendation() string {
+	if x != nil {
+		return x.Recommendation
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetRecommendationReasons() []string {
+	if x != nil {
+		return x.RecommendationReasons
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OptimizeResponse) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetEmissionsKgCo2() float64 {
+	if x != nil {
+		return x.EmissionsKgCo2
+	}
+	return 0
+}
+
+func (x *OptimizeResponse) GetAlternatives() []*PlanSummary {
+	if x != nil {
+		return x.Alternatives
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetLoadingSequence() []string {
+	if x != nil {
+		return x.LoadingSequence
+	}
+	return nil
+}
+
+func (x *OptimizeResponse) GetExcursion() *Excursion {
+	if x != nil {
+		return x.Excursion
+	}
+	return nil
+}
+
+type Excursion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExposureHours     float64 `protobuf:"fixed64,1,opt,name=exposure_hours,json=exposureHours,proto3" json:"exposure_hours,omitempty"`
+	SafeExposureHours float64 `protobuf:"fixed64,2,opt,name=safe_exposure_hours,json=safeExposureHours,proto3" json:"safe_exposure_hours,omitempty"`
+	ExcessHours       float64 `protobuf:"fixed64,3,opt,name=excess_hours,json=excessHours,proto3" json:"excess_hours,omitempty"`
+}
+
+func (x *Excursion) Reset() {
+	*x = Excursion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Excursion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Excursion) ProtoMessage() {}
+
+func (x *Excursion) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Excursion.ProtoReflect.Descriptor instead.
+func (*Excursion) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Excursion) GetExposureHours() float64 {
+	if x != nil {
+		return x.ExposureHours
+	}
+	return 0
+}
+
+func (x *Excursion) GetSafeExposureHours() float64 {
+	if x != nil {
+		return x.SafeExposureHours
+	}
+	return 0
+}
+
+func (x *Excursion) GetExcessHours() float64 {
+	if x != nil {
+		return x.ExcessHours
+	}
+	return 0
+}
+
+type ParetoSolutionsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request *OptimizeRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// limit caps the solutions returned; 0 means 20
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ParetoSolutionsRequest) Reset() {
+	*x = ParetoSolutionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParetoSolutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParetoSolutionsRequest) ProtoMessage() {}
+
+func (x *ParetoSolutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParetoSolutionsRequest.ProtoReflect.Descriptor instead.
+func (*ParetoSolutionsRequest) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ParetoSolutionsRequest) GetRequest() *OptimizeRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *ParetoSolutionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ParetoSolution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderIds                 []string `protobuf:"bytes,1,rep,name=order_ids,json=orderIds,proto3" json:"order_ids,omitempty"`
+	TotalPayoutCents         int64    `protobuf:"varint,2,opt,name=total_payout_cents,json=totalPayoutCents,proto3" json:"total_payout_cents,omitempty"`
+	TotalWeightLbs           int32    `protobuf:"varint,3,opt,name=total_weight_lbs,json=totalWeightLbs,proto3" json:"total_weight_lbs,omitempty"`
+	TotalVolumeCuft          int32    `protobuf:"varint,4,opt,name=total_volume_cuft,json=totalVolumeCuft,proto3" json:"total_volume_cuft,omitempty"`
+	UtilizationWeightPercent float64  `protobuf:"fixed64,5,opt,name=utilization_weight_percent,json=utilizationWeightPercent,proto3" json:"utilization_weight_percent,omitempty"`
+	UtilizationVolumePercent float64  `protobuf:"fixed64,6,opt,name=utilization_volume_percent,json=utilizationVolumePercent,proto3" json:"utilization_volume_percent,omitempty"`
+	Score                    float64  `protobuf:"fixed64,7,opt,name=score,proto3" json:"score,omitempty"`
+	EmissionsKgCo2           float64  `protobuf:"fixed64,8,opt,name=emissions_kg_co2,json=emissionsKgCo2,proto3" json:"emissions_kg_co2,omitempty"`
+}
+
+func (x *ParetoSolution) Reset() {
+	*x = ParetoSolution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParetoSolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParetoSolution) ProtoMessage() {}
+
+func (x *ParetoSolution) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParetoSolution.ProtoReflect.Descriptor instead.
+func (*ParetoSolution) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ParetoSolution) GetOrderIds() []string {
+	if x != nil {
+		return x.OrderIds
+	}
+	return nil
+}
+
+func (x *ParetoSolution) GetTotalPayoutCents() int64 {
+	if x != nil {
+		return x.TotalPayoutCents
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetTotalWeightLbs() int32 {
+	if x != nil {
+		return x.TotalWeightLbs
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetTotalVolumeCuft() int32 {
+	if x != nil {
+		return x.TotalVolumeCuft
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetUtilizationWeightPercent() float64 {
+	if x != nil {
+		return x.UtilizationWeightPercent
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetUtilizationVolumePercent() float64 {
+	if x != nil {
+		return x.UtilizationVolumePercent
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *ParetoSolution) GetEmissionsKgCo2() float64 {
+	if x != nil {
+		return x.EmissionsKgCo2
+	}
+	return 0
+}
+
+type ParetoSolutionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TruckId   string            `protobuf:"bytes,1,opt,name=truck_id,json=truckId,proto3" json:"truck_id,omitempty"`
+	Solutions []*ParetoSolution `protobuf:"bytes,2,rep,name=solutions,proto3" json:"solutions,omitempty"`
+	Exact     bool              `protobuf:"varint,3,opt,name=exact,proto3" json:"exact,omitempty"`
+	// total counts every solution found; over HTTP, solutions holds one page
+	// of them and next_cursor asks for the next. gRPC returns them all.
+	Total      int32  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	NextCursor string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// payout_redacted is set over HTTP when the request sealed its payouts
+	PayoutRedacted bool `protobuf:"varint,6,opt,name=payout_redacted,json=payoutRedacted,proto3" json:"payout_redacted,omitempty"`
+}
+
+func (x *ParetoSolutionsResponse) Reset() {
+	*x = ParetoSolutionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_optimizer_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParetoSolutionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParetoSolutionsResponse) ProtoMessage() {}
+
+func (x *ParetoSolutionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_optimizer_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParetoSolutionsResponse.ProtoReflect.Descriptor instead.
+func (*ParetoSolutionsResponse) Descriptor() ([]byte, []int) {
+	return file_optimizer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ParetoSolutionsResponse) GetTruckId() string {
+	if x != nil {
+		return x.TruckId
+	}
+	return ""
+}
+
+func (x *ParetoSolutionsResponse) GetSolutions() []*ParetoSolution {
+	if x != nil {
+		return x.Solutions
+	}
+	return nil
+}
+
+func (x *ParetoSolutionsResponse) GetExact() bool {
+	if x != nil {
+		return x.Exact
+	}
+	return false
+}
+
+func (x *ParetoSolutionsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ParetoSolutionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ParetoSolutionsResponse) GetPayoutRedacted() bool {
+	if x != nil {
+		return x.PayoutRedacted
+	}
+	return false
+}
+
+var File_optimizer_proto protoreflect.FileDescriptor
+
+var file_optimizer_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x0c, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x22,
+	0x2c, 0x0a, 0x06, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x61, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c,
+	0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6e, 0x67, 0x22, 0x97, 0x05,
+	0x0a, 0x05, 0x54, 0x72, 0x75, 0x63, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x61, 0x78, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x6d, 0x61, 0x78, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x62, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75, 0x66, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61, 0x78, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x43, 0x75, 0x66, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x66, 0x69, 0x78, 0x65, 0x64, 0x5f, 0x63,
+	0x6f, 0x73, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0e, 0x66, 0x69, 0x78, 0x65, 0x64, 0x43, 0x6f, 0x73, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x2d, 0x0a, 0x13, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6c, 0x65,
+	0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x63, 0x6f,
+	0x73, 0x74, 0x50, 0x65, 0x72, 0x4d, 0x69, 0x6c, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x24,
+	0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x46, 0x65, 0x65, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x61, 0x78, 0x5f, 0x6c, 0x69, 0x6e, 0x65, 0x61,
+	0x72, 0x5f, 0x66, 0x65, 0x65, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x6d, 0x61,
+	0x78, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x46, 0x65, 0x65, 0x74, 0x12, 0x30, 0x0a, 0x14, 0x6d,
+	0x61, 0x78, 0x5f, 0x70, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12, 0x6d, 0x61, 0x78, 0x50, 0x61,
+	0x6c, 0x6c, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x65, 0x71, 0x75, 0x69, 0x70, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x5f, 0x6c,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x69, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x49, 0x6e,
+	0x12, 0x2a, 0x0a, 0x11, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x5f, 0x77, 0x69, 0x64,
+	0x74, 0x68, 0x5f, 0x69, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x69, 0x6f, 0x72, 0x57, 0x69, 0x64, 0x74, 0x68, 0x49, 0x6e, 0x12, 0x2c, 0x0a, 0x12,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x69, 0x6f, 0x72, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x69, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x69,
+	0x6f, 0x72, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x49, 0x6e, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73,
+	0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c,
+	0x6e, 0x67, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0d,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e,
+	0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x0c, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xdc, 0x09, 0x0a, 0x05, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c,
+	0x62, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x4c, 0x62, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75,
+	0x66, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x43, 0x75, 0x66, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6e, 0x65, 0x61, 0x72, 0x5f, 0x66,
+	0x65, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6c, 0x69, 0x6e, 0x65, 0x61,
+	0x72, 0x46, 0x65, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1f, 0x0a, 0x0b, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72,
+	0x79, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x68, 0x61, 0x7a, 0x6d,
+	0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x48, 0x61, 0x7a, 0x6d,
+	0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6d, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x68, 0x69, 0x70,
+	0x70, 0x65, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x68, 0x69, 0x70, 0x70,
+	0x65, 0x72, 0x12, 0x37, 0x0a, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x0b,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x11, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x74, 0x4c, 0x6e, 0x67, 0x52, 0x10, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2e,
+	0x0a, 0x13, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x70, 0x69, 0x63,
+	0x6b, 0x75, 0x70, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2a,
+	0x0a, 0x11, 0x70, 0x69, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f,
+	0x65, 0x6e, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x69, 0x63, 0x6b, 0x75,
+	0x70, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e, 0x64, 0x12, 0x32, 0x0a, 0x15, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76,
+	0x65, 0x72, 0x79, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x2e,
+	0x0a, 0x13, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f,
+	0x77, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x64, 0x65, 0x6c,
+	0x69, 0x76, 0x65, 0x72, 0x79, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x45, 0x6e, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x68, 0x61, 0x7a, 0x6d, 0x61, 0x74, 0x5f, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x68, 0x61, 0x7a, 0x6d, 0x61, 0x74, 0x43, 0x6c, 0x61, 0x73,
+	0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x64, 0x69, 0x74, 0x79, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x64, 0x69, 0x74, 0x79, 0x54, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x11, 0x74, 0x65, 0x6d, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x66, 0x18, 0x15, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x0f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x4d, 0x69, 0x6e, 0x46, 0x88, 0x01, 0x01, 0x12, 0x2f, 0x0a, 0x11, 0x74, 0x65, 0x6d,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x0f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x4d, 0x61, 0x78, 0x46, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x16, 0x65, 0x71,
+	0x75, 0x69, 0x70, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x65, 0x71, 0x75, 0x69,
+	0x70, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x5f, 0x69, 0x6e, 0x18, 0x18,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x49, 0x6e, 0x12, 0x19,
+	0x0a, 0x08, 0x77, 0x69, 0x64, 0x74, 0x68, 0x5f, 0x69, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x77, 0x69, 0x64, 0x74, 0x68, 0x49, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x5f, 0x69, 0x6e, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x49, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x6c, 0x6c, 0x65, 0x74,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61,
+	0x6c, 0x6c, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x63, 0x6b, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x63, 0x6b, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x73, 0x69, 0x76, 0x65, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x1e,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x70, 0x6c, 0x69, 0x74, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x1f, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x12,
+	0x6d, 0x61, 0x78, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x68, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x70,
+	0x6f, 0x73, 0x75, 0x72, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f, 0x74,
+	0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x66,
+	0x42, 0x14, 0x0a, 0x12, 0x5f, 0x74, 0x65, 0x6d, 0x70, 0x65, 0x72, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x66, 0x22, 0xc3, 0x03, 0x0a, 0x12, 0x4f, 0x70, 0x74, 0x69, 0x6d,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1c, 0x0a,
+	0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72,
+	0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0d, 0x72, 0x65, 0x76, 0x65, 0x6e, 0x75, 0x65, 0x57, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x2d, 0x0a, 0x12, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11,
+	0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12,
+	0x23, 0x0a, 0x0d, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x6d, 0x6f, 0x64, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x62, 0x75, 0x5f, 0x74, 0x65, 0x6e,
+	0x75, 0x72, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x61, 0x62, 0x75, 0x54,
+	0x65, 0x6e, 0x75, 0x72, 0x65, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x61, 0x62, 0x75, 0x5f, 0x6e, 0x65,
+	0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f, 0x6f, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x10, 0x74, 0x61, 0x62, 0x75, 0x4e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x68, 0x6f,
+	0x6f, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x73, 0x74,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x29, 0x0a,
+	0x10, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x64, 0x65, 0x61, 0x64,
+	0x68, 0x65, 0x61, 0x64, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0e, 0x64, 0x65, 0x61, 0x64, 0x68, 0x65, 0x61, 0x64, 0x57, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x65, 0x78, 0x63,
+	0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xec, 0x04, 0x0a,
+	0x0f, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x29, 0x0a, 0x05, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x54,
+	0x72, 0x75, 0x63, 0x6b, 0x52, 0x05, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x12, 0x2b, 0x0a, 0x06, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x6d,
+	0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x06, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x73, 0x12, 0x51, 0x0a, 0x13, 0x6f, 0x70, 0x74, 0x69,
+	0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x6b, 0x12, 0x33, 0x0a, 0x16, 0x6d, 0x75, 0x73, 0x74, 0x5f, 0x69, 0x6e,
+	0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x75, 0x73, 0x74, 0x49, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x6d, 0x75,
+	0x73, 0x74, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x6d, 0x75, 0x73, 0x74,
+	0x45, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12,
+	0x33, 0x0a, 0x16, 0x6d, 0x69, 0x6e, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x13, 0x6d, 0x69, 0x6e, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x6d, 0x69, 0x6e, 0x5f, 0x75, 0x74, 0x69, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x15, 0x6d, 0x69, 0x6e, 0x55, 0x74, 0x69, 0x6c, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
+	0x75, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x69, 0x6d, 0x5f, 0x66, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x69, 0x6d, 0x46, 0x61,
+	0x63, 0x74, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x6c, 0x61, 0x6e, 0x65, 0x5f, 0x72, 0x69, 0x73,
+	0x6b, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x61, 0x6e, 0x65, 0x52, 0x69, 0x73, 0x6b,
+	0x52, 0x09, 0x6c, 0x61, 0x6e, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x73, 0x22, 0x65, 0x0a, 0x08, 0x4c,
+	0x61, 0x6e, 0x65, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x69, 0x73, 0x6b, 0x5f, 0x66, 0x61, 0x63, 0x74, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x72, 0x69, 0x73, 0x6b, 0x46, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x22, 0xa3, 0x03, 0x0a, 0x0b, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x6c, 0x62, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x62, 0x73, 0x12, 0x2a, 0x0a, 0x11,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75, 0x66,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x43, 0x75, 0x66, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x74, 0x69, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x75, 0x74,
+	0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x50,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x75, 0x74, 0x69, 0x6c,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x28, 0x0a, 0x10, 0x6e, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66,
+	0x69, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x6e, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28,
+	0x0a, 0x10, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6b, 0x67, 0x5f, 0x63,
+	0x6f, 0x32, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x4b, 0x67, 0x43, 0x6f, 0x32, 0x22, 0xfd, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x73,
+	0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x69,
+	0x78, 0x65, 0x64, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x66, 0x69, 0x78, 0x65, 0x64, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x64,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x74, 0x6f, 0x6c, 0x6c, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x74, 0x6f, 0x6c, 0x6c, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x23, 0x0a,
+	0x0d, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x69, 0x6c, 0x65, 0x61, 0x67, 0x65, 0x43, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x43, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x74, 0x6f, 0x70, 0x46,
+	0x65, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xf7, 0x06, 0x0a, 0x10, 0x4f, 0x70, 0x74,
+	0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x2f,
+	0x0a, 0x13, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x5f, 0x66, 0x69, 0x6e, 0x67, 0x65, 0x72,
+	0x70, 0x72, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x70, 0x72, 0x6f,
+	0x62, 0x6c, 0x65, 0x6d, 0x46, 0x69, 0x6e, 0x67, 0x65, 0x72, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x4f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x5f, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c, 0x62, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x62, 0x73,
+	0x12, 0x2a, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x5f, 0x63, 0x75, 0x66, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x43, 0x75, 0x66, 0x74, 0x12, 0x3c, 0x0a, 0x1a,
+	0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x18, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x74,
+	0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18,
+	0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x42, 0x0a, 0x0e, 0x63, 0x6f, 0x73, 0x74,
+	0x5f, 0x62, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x73, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x52, 0x0d, 0x63,
+	0x6f, 0x73, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x12, 0x28, 0x0a, 0x10,
+	0x6e, 0x65, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x6e, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x35,
+	0x0a, 0x16, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15,
+	0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6b, 0x67, 0x5f, 0x63, 0x6f, 0x32, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4b, 0x67, 0x43, 0x6f,
+	0x32, 0x12, 0x3d, 0x0a, 0x0c, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c,
+	0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x52, 0x0c, 0x61, 0x6c, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x74, 0x69, 0x76, 0x65, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x6c, 0x6f, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x6c, 0x6f, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x35, 0x0a, 0x09, 0x65,
+	0x78, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x65, 0x78, 0x63, 0x75, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x85, 0x01, 0x0a, 0x09, 0x45, 0x78, 0x63, 0x75, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x68, 0x6f, 0x75,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0d, 0x65, 0x78, 0x70, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x61, 0x66, 0x65, 0x5f,
+	0x65, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73, 0x61, 0x66, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75,
+	0x72, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x78, 0x63, 0x65, 0x73,
+	0x73, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x65,
+	0x78, 0x63, 0x65, 0x73, 0x73, 0x48, 0x6f, 0x75, 0x72, 0x73, 0x22, 0x67, 0x0a, 0x16, 0x50, 0x61,
+	0x72, 0x65, 0x74, 0x6f, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x37, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0xed, 0x02, 0x0a, 0x0e, 0x50, 0x61, 0x72, 0x65, 0x74, 0x6f, 0x53, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x61, 0x79,
+	0x6f, 0x75, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x43, 0x65, 0x6e, 0x74,
+	0x73, 0x12, 0x28, 0x0a, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x5f, 0x6c, 0x62, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x62, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x63, 0x75, 0x66, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x43, 0x75, 0x66, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x74, 0x69, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x70, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x75, 0x74, 0x69,
+	0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x3c, 0x0a, 0x1a, 0x75, 0x74, 0x69, 0x6c, 0x69, 0x7a, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x18, 0x75, 0x74, 0x69, 0x6c, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x65, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x6b, 0x67, 0x5f, 0x63, 0x6f, 0x32, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0e, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4b, 0x67,
+	0x43, 0x6f, 0x32, 0x22, 0xe6, 0x01, 0x0a, 0x17, 0x50, 0x61, 0x72, 0x65, 0x74, 0x6f, 0x53, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x74, 0x72, 0x75, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x3a, 0x0a, 0x09, 0x73, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72,
+	0x65, 0x74, 0x6f, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x6f, 0x6c,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x65, 0x78, 0x61, 0x63, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x5f, 0x72, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x70, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x32, 0xb6, 0x01, 0x0a,
+	0x09, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x72, 0x12, 0x49, 0x0a, 0x08, 0x4f, 0x70,
+	0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f,
+	0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61,
+	0x64, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6d, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x65, 0x74, 0x6f, 0x53,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x24, 0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74,
+	0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x72, 0x65, 0x74, 0x6f, 0x53, 0x6f,
+	0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25,
+	0x2e, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x72, 0x65, 0x74, 0x6f, 0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x2d, 0x6c,
+	0x6f, 0x61, 0x64, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72, 0x70,
+	0x63, 0x61, 0x70, 0x69, 0x2f, 0x73, 0x6d, 0x61, 0x72, 0x74, 0x6c, 0x6f, 0x61, 0x64, 0x70, 0x62,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_optimizer_proto_rawDescOnce sync.Once
+	file_optimizer_proto_rawDescData = file_optimizer_proto_rawDesc
+)
+
+func file_optimizer_proto_rawDescGZIP() []byte {
+	file_optimizer_proto_rawDescOnce.Do(func() {
+		file_optimizer_proto_rawDescData = protoimpl.X.CompressGZIP(file_optimizer_proto_rawDescData)
+	})
+	return file_optimizer_proto_rawDescData
+}
+
+var file_optimizer_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_optimizer_proto_goTypes = []interface{}{
+	(*LatLng)(nil),                  // 0: smartload.v1.LatLng
+	(*Truck)(nil),                   // 1: smartload.v1.Truck
+	(*Order)(nil),                   // 2: smartload.v1.Order
+	(*OptimizationConfig)(nil),      // 3: smartload.v1.OptimizationConfig
+	(*OptimizeRequest)(nil),         // 4: smartload.v1.OptimizeRequest
+	(*LaneRisk)(nil),                // 5: smartload.v1.LaneRisk
+	(*PlanSummary)(nil),             // 6: smartload.v1.PlanSummary
+	(*CostBreakdown)(nil),           // 7: smartload.v1.CostBreakdown
+	(*OptimizeResponse)(nil),        // 8: smartload.v1.OptimizeResponse
+	(*Excursion)(nil),               // 9: smartload.v1.Excursion
+	(*ParetoSolutionsRequest)(nil),  // 10: smartload.v1.ParetoSolutionsRequest
+	(*ParetoSolution)(nil),          // 11: smartload.v1.ParetoSolution
+	(*ParetoSolutionsResponse)(nil), // 12: smartload.v1.ParetoSolutionsResponse
+}
+var file_optimizer_proto_depIdxs = []int32{
+	0,  // 0: smartload.v1.Truck.position:type_name -> smartload.v1.LatLng
+	0,  // 1: smartload.v1.Truck.next_position:type_name -> smartload.v1.LatLng
+	0,  // 2: smartload.v1.Order.origin_point:type_name -> smartload.v1.LatLng
+	0,  // 3: smartload.v1.Order.destination_point:type_name -> smartload.v1.LatLng
+	1,  // 4: smartload.v1.OptimizeRequest.truck:type_name -> smartload.v1.Truck
+	2,  // 5: smartload.v1.OptimizeRequest.orders:type_name -> smartload.v1.Order
+	3,  // 6: smartload.v1.OptimizeRequest.optimization_config:type_name -> smartload.v1.OptimizationConfig
+	5,  // 7: smartload.v1.OptimizeRequest.lane_risks:type_name -> smartload.v1.LaneRisk
+	7,  // 8: smartload.v1.OptimizeResponse.cost_breakdown:type_name -> smartload.v1.CostBreakdown
+	6,  // 9: smartload.v1.OptimizeResponse.alternatives:type_name -> smartload.v1.PlanSummary
+	9,  // 10: smartload.v1.OptimizeResponse.excursion:type_name -> smartload.v1.Excursion
+	4,  // 11: smartload.v1.ParetoSolutionsRequest.request:type_name -> smartload.v1.OptimizeRequest
+	11, // 12: smartload.v1.ParetoSolutionsResponse.solutions:type_name -> smartload.v1.ParetoSolution
+	4,  // 13: smartload.v1.Optimizer.Optimize:input_type -> smartload.v1.OptimizeRequest
+	10, // 14: smartload.v1.Optimizer.ParetoSolutions:input_type -> smartload.v1.ParetoSolutionsRequest
+	8,  // 15: smartload.v1.Optimizer.Optimize:output_type -> smartload.v1.OptimizeResponse
+	12, // 16: smartload.v1.Optimizer.ParetoSolutions:output_type -> smartload.v1.ParetoSolutionsResponse
+	15, // [15:17] is the sub-list for method output_type
+	13, // [13:15] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_optimizer_proto_init() }
+func file_optimizer_proto_init() {
+	if File_optimizer_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_optimizer_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatLng); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Truck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Order); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OptimizationConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OptimizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaneRisk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlanSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CostBreakdown); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OptimizeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Excursion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParetoSolutionsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParetoSolution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_optimizer_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ParetoSolutionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_optimizer_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_optimizer_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_optimizer_proto_goTypes,
+		DependencyIndexes: file_optimizer_proto_depIdxs,
+		MessageInfos:      file_optimizer_proto_msgTypes,
+	}.Build()
+	File_optimizer_proto = out.File
+	file_optimizer_proto_rawDesc = nil
+	file_optimizer_proto_goTypes = nil
+	file_optimizer_proto_depIdxs = nil
+}
@@ -0,0 +1,113 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OrderRevenue is the payout of one selected order with the dates its revenue
+// can be recognized between: pickup at the earliest and delivery at the
+// latest. Dates are midnight UTC.
+type OrderRevenue struct {
+	OrderID            string    `json:"order_id"`
+	PayoutMinor        int64     `json:"payout_minor"`
+	EarliestRecognized time.Time `json:"earliest_recognized"`
+	LatestRecognized   time.Time `json:"latest_recognized"`
+}
+
+// Accrual periods and recognition bases
+const (
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+	// BasisDelivery recognizes revenue on delivery, the latest date
+	BasisDelivery = "delivery"
+	// BasisPickup recognizes revenue on pickup, the earliest date
+	BasisPickup = "pickup"
+)
+
+// Accrual is the planned revenue a tenant recognizes in one period and
+// currency. PeriodStart is the Monday of an ISO week or the first of a month.
+type Accrual struct {
+	TenantID            string `json:"tenant_id"`
+	Currency            string `json:"currency"`
+	PeriodStart         string `json:"period_start"`
+	Orders              int    `json:"orders"`
+	PlannedRevenueMinor int64  `json:"planned_revenue_minor"`
+}
+
+// ValidateAccrualOptions checks a period and basis for SummarizeAccruals
+func ValidateAccrualOptions(period, basis string) error {
+	if period != PeriodWeek && period != PeriodMonth {
+		return fmt.Errorf("invalid period: %s (must be week or month)", period)
+	}
+	if basis != BasisDelivery && basis != BasisPickup {
+		return fmt.Errorf("invalid basis: %s (must be delivery or pickup)", basis)
+	}
+	return nil
+}
+
+// SummarizeAccruals groups the planned revenue of records, oldest first, by
+// tenant, period and currency, sorted in that order. A truck re-optimized
+// in the records counts only with its latest plan, so re-solves do not book
+// the same load twice. Records with redacted payouts carry no revenue.
+func SummarizeAccruals(records []Record, period, basis string) []Accrual {
+	type truckKey struct{ tenantID, truckID string }
+	latest := make(map[truckKey]int)
+	for i, record := range records {
+		if record.TruckID != "" {
+			latest[truckKey{record.TenantID, record.TruckID}] = i
+		}
+	}
+	
+	type accrualKey struct{ tenantID, currency, periodStart string }
+	byKey := make(map[accrualKey]*Accrual)
+	for i, record := range records {
+		if record.TruckID != "" && latest[truckKey{record.TenantID, record.TruckID}] != i {
+			continue
+		}
+		for _, revenue := range record.Revenue {
+			recognized := revenue.LatestRecognized
+			if basis == BasisPickup {
+				recognized = revenue.EarliestRecognized
+			}
+			key := accrualKey{record.TenantID, record.Currency, periodStart(recognized, period)}
+			accrual, ok := byKey[key]
+			if !ok {
+				accrual = &Accrual{TenantID: key.tenantID, Currency: key.currency, PeriodStart: key.periodStart}
+				byKey[key] = accrual
+			}
+			accrual.Orders++
+			accrual.PlannedRevenueMinor += revenue.PayoutMinor
+		}
+	}
+	
+	accruals := make([]Accrual, 0, len(byKey))
+	for _, accrual := range byKey {
+		accruals = append(accruals, *accrual)
+	}
+	sort.Slice(accruals, func(i, j int) bool {
+		a, b := accruals[i], accruals[j]
+		if a.TenantID != b.TenantID {
+			return a.TenantID < b.TenantID
+		}
+		if a.PeriodStart != b.PeriodStart {
+			return a.PeriodStart < b.PeriodStart
+		}
+		return a.Currency < b.Currency
+	})
+	return accruals
+}
+
+// periodStart formats the first day of the day, week or month holding date
+func periodStart(date time.Time, period string) string {
+	date = date.UTC()
+	if period == PeriodDay {
+		return date.Format("2006-01-02")
+	}
+	if period == PeriodMonth {
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02")
+	}
+	daysSinceMonday := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -daysSinceMonday).Format("2006-01-02")
+}
@@ -0,0 +1,61 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func date(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestSummarizeAccruals(t *testing.T) {
+	records := []Record{
+		{TenantID: "acme", TruckID: "t1", Currency: "USD", Revenue: []OrderRevenue{
+			{OrderID: "replaced", PayoutMinor: 999, EarliestRecognized: date("2030-01-02"), LatestRecognized: date("2030-01-03")},
+		}},
+		{TenantID: "acme", TruckID: "t2", Currency: "USD", Revenue: []OrderRevenue{
+			// Wednesday pickup, delivered the next Monday in February
+			{OrderID: "a", PayoutMinor: 100, EarliestRecognized: date("2030-01-30"), LatestRecognized: date("2030-02-04")},
+			{OrderID: "b", PayoutMinor: 50, EarliestRecognized: date("2030-01-28"), LatestRecognized: date("2030-01-29")},
+		}},
+		{TenantID: "acme", TruckID: "t1", Currency: "USD", Revenue: []OrderRevenue{
+			{OrderID: "c", PayoutMinor: 10, EarliestRecognized: date("2030-01-01"), LatestRecognized: date("2030-01-02")},
+		}},
+		{TenantID: "acme", TruckID: "t3", Currency: "USD", PayoutRedacted: true},
+	}
+	
+	byDeliveryMonth := []Accrual{
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2030-01-01", Orders: 2, PlannedRevenueMinor: 60},
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2030-02-01", Orders: 1, PlannedRevenueMinor: 100},
+	}
+	if got := SummarizeAccruals(records, PeriodMonth, BasisDelivery); !reflect.DeepEqual(got, byDeliveryMonth) {
+		t.Errorf("by delivery month = %+v, want %+v", got, byDeliveryMonth)
+	}
+	
+	byPickupWeek := []Accrual{
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2029-12-31", Orders: 1, PlannedRevenueMinor: 10},
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2030-01-28", Orders: 2, PlannedRevenueMinor: 150},
+	}
+	if got := SummarizeAccruals(records, PeriodWeek, BasisPickup); !reflect.DeepEqual(got, byPickupWeek) {
+		t.Errorf("by pickup week = %+v, want %+v", got, byPickupWeek)
+	}
+	
+	if got := SummarizeAccruals(nil, PeriodWeek, BasisDelivery); len(got) != 0 {
+		t.Errorf("SummarizeAccruals(nil) = %+v, want none", got)
+	}
+}
+
+func TestValidateAccrualOptions(t *testing.T) {
+	if err := ValidateAccrualOptions(PeriodWeek, BasisPickup); err != nil {
+		t.Errorf("week by pickup: %v", err)
+	}
+	if err := ValidateAccrualOptions("quarter", BasisDelivery); err == nil {
+		t.Error("quarter accepted")
+	}
+	if err := ValidateAccrualOptions(PeriodMonth, "invoice"); err == nil {
+		t.Error("invoice basis accepted")
+	}
+}
@@ -0,0 +1,58 @@
+package history
+
+import "sort"
+
+// Duplicates measures how often a tenant solves a problem it has already
+// solved, judged by the problem fingerprint. Solves without a fingerprint
+// (sealed payouts) are counted in Solves only.
+type Duplicates struct {
+	TenantID         string  `json:"tenant_id"`
+	Solves           int     `json:"solves"`
+	Fingerprinted    int     `json:"fingerprinted_solves"`
+	DistinctProblems int     `json:"distinct_problems"`
+	DuplicateSolves  int     `json:"duplicate_solves"`
+	DuplicateRate    float64 `json:"duplicate_rate"`
+}
+
+// SummarizeDuplicates groups records by tenant, sorted by tenant
+func SummarizeDuplicates(records []Record) []Duplicates {
+	type tenantProblems struct {
+		stats    Duplicates
+		problems map[string]bool
+	}
+	byTenant := make(map[string]*tenantProblems)
+	for _, record := range records {
+		tenant, ok := byTenant[record.TenantID]
+		if !ok {
+			tenant = &tenantProblems{
+				stats:    Duplicates{TenantID: record.TenantID},
+				problems: make(map[string]bool),
+			}
+			byTenant[record.TenantID] = tenant
+		}
+		
+		tenant.stats.Solves++
+		if record.ProblemFingerprint == "" {
+			continue
+		}
+		tenant.stats.Fingerprinted++
+		if tenant.problems[record.ProblemFingerprint] {
+			tenant.stats.DuplicateSolves++
+		}
+		tenant.problems[record.ProblemFingerprint] = true
+	}
+	
+	summaries := make([]Duplicates, 0, len(byTenant))
+	for _, tenant := range byTenant {
+		stats := tenant.stats
+		stats.DistinctProblems = len(tenant.problems)
+		if stats.Fingerprinted > 0 {
+			stats.DuplicateRate = float64(stats.DuplicateSolves) / float64(stats.Fingerprinted)
+		}
+		summaries = append(summaries, stats)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].TenantID < summaries[j].TenantID
+	})
+	return summaries
+}
@@ -0,0 +1,25 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeDuplicates(t *testing.T) {
+	records := []Record{
+		{TenantID: "acme", ProblemFingerprint: "p1"},
+		{TenantID: "acme", ProblemFingerprint: "p1"},
+		{TenantID: "acme", ProblemFingerprint: "p2"},
+		{TenantID: "acme", ProblemFingerprint: "p1"},
+		{TenantID: "acme"},
+		{TenantID: "globex", ProblemFingerprint: "p1"},
+	}
+	
+	want := []Duplicates{
+		{TenantID: "acme", Solves: 5, Fingerprinted: 4, DistinctProblems: 2, DuplicateSolves: 2, DuplicateRate: 0.5},
+		{TenantID: "globex", Solves: 1, Fingerprinted: 1, DistinctProblems: 1},
+	}
+	if got := SummarizeDuplicates(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeDuplicates = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package history
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+)
+
+// batchSize is the number of rows per Arrow record batch / Parquet row group
+const batchSize = 65536
+
+// column describes one export column in every supported format
+type column struct {
+	field  arrow.Field
+	text   func(r Record) string
+	append func(b array.Builder, r Record)
+}
+
+func stringColumn(name string, value func(r Record) string) column {
+	return column{
+		field: arrow.Field{Name: name, Type: arrow.BinaryTypes.String},
+		text:  value,
+		append: func(b array.Builder, r Record) {
+			b.(*array.StringBuilder).Append(value(r))
+		},
+	}
+}
+
+func intColumn(name string, value func(r Record) int64) column {
+	return column{
+		field: arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Int64},
+		text:  func(r Record) string { return strconv.FormatInt(value(r), 10) },
+		append: func(b array.Builder, r Record) {
+			b.(*array.Int64Builder).Append(value(r))
+		},
+	}
+}
+
+func floatColumn(name string, value func(r Record) float64) column {
+	return column{
+		field: arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Float64},
+		text:  func(r Record) string { return strconv.FormatFloat(value(r), 'f', 2, 64) },
+		append: func(b array.Builder, r Record) {
+			b.(*array.Float64Builder).Append(value(r))
+		},
+	}
+}
+
+func boolColumn(name string, value func(r Record) bool) column {
+	return column{
+		field: arrow.Field{Name: name, Type: arrow.FixedWidthTypes.Boolean},
+		text:  func(r Record) string { return strconv.FormatBool(value(r)) },
+		append: func(b array.Builder, r Record) {
+			b.(*array.BooleanBuilder).Append(value(r))
+		},
+	}
+}
+
+// optionalFloatColumn is a nullable float column: an empty CSV cell and a
+// null Arrow value when value returns nil
+func optionalFloatColumn(name string, value func(r Record) *float64) column {
+	return column{
+		field: arrow.Field{Name: name, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		text: func(r Record) string {
+			if v := value(r); v != nil {
+				return strconv.FormatFloat(*v, 'f', 2, 64)
+			}
+			return ""
+		},
+		append: func(b array.Builder, r Record) {
+			if v := value(r); v != nil {
+				b.(*array.Float64Builder).Append(*v)
+			} else {
+				b.AppendNull()
+			}
+		},
+	}
+}
+
+var columns = []column{
+	stringColumn("solution_id", func(r Record) string { return r.SolutionID }),
+	{
+		field: arrow.Field{Name: "created_at", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}},
+		text:  func(r Record) string { return r.CreatedAt.UTC().Format(time.RFC3339) },
+		append: func(b array.Builder, r Record) {
+			b.(*array.TimestampBuilder).Append(arrow.Timestamp(r.CreatedAt.UnixMilli()))
+		},
+	},
+	stringColumn("tenant_id", func(r Record) string { return r.TenantID }),
+	stringColumn("api_key", func(r Record) string { return r.APIKey }),
+	stringColumn("truck_id", func(r Record) string { return r.TruckID }),
+	stringColumn("currency", func(r Record) string { return r.Currency }),
+	stringColumn("weight_unit", func(r Record) string { return r.WeightUnit }),
+	stringColumn("volume_unit", func(r Record) string { return r.VolumeUnit }),
+	intColumn("orders_considered", func(r Record) int64 { return int64(r.OrdersConsidered) }),
+	intColumn("orders_selected", func(r Record) int64 { return int64(r.OrdersSelected) }),
+	intColumn("total_payout_minor", func(r Record) int64 { return r.TotalPayoutMinor }),
+	intColumn("total_cost_minor", func(r Record) int64 { return r.TotalCostMinor }),
+	intColumn("net_profit_minor", func(r Record) int64 { return r.NetProfitMinor }),
+	boolColumn("payout_redacted", func(r Record) bool { return r.PayoutRedacted }),
+	intColumn("total_weight", func(r Record) int64 { return int64(r.TotalWeight) }),
+	intColumn("total_volume", func(r Record) int64 { return int64(r.TotalVolume) }),
+	floatColumn("utilization_weight_percent", func(r Record) float64 { return r.UtilizationWeightPercent }),
+	floatColumn("utilization_volume_percent", func(r Record) float64 { return r.UtilizationVolumePercent }),
+	stringColumn("algorithm", func(r Record) string { return r.Algorithm }),
+	boolColumn("optimal", func(r Record) bool { return r.Optimal }),
+	intColumn("compute_time_ms", func(r Record) int64 { return r.ComputeTimeMs }),
+	stringColumn("recommendation", func(r Record) string { return r.Recommendation }),
+	boolColumn("cache_hit", func(r Record) bool { return r.CacheHit }),
+	stringColumn("problem_fingerprint", func(r Record) string { return r.ProblemFingerprint }),
+	optionalFloatColumn("measured_gap_percent", func(r Record) *float64 { return r.MeasuredGapPercent }),
+}
+
+func arrowSchema() *arrow.Schema {
+	fields := make([]arrow.Field, len(columns))
+	for i, col := range columns {
+		fields[i] = col.field
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// WriteCSV writes records as CSV with a header row
+func WriteCSV(w io.Writer, records []Record) error {
+	writer := csv.NewWriter(w)
+	
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.field.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	
+	row := make([]string, len(columns))
+	for _, r := range records {
+		for i, col := range columns {
+			row[i] = col.text(r)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteArrow writes records as an Arrow IPC stream
+func WriteArrow(w io.Writer, records []Record) error {
+	schema := arrowSchema()
+	writer := ipc.NewWriter(w, ipc.WithSchema(schema))
+	
+	err := forEachBatch(schema, records, func(batch arrow.Record) error {
+		return writer.Write(batch)
+	})
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// WriteParquet writes records as a Snappy-compressed Parquet file
+func WriteParquet(w io.Writer, records []Record) error {
+	schema := arrowSchema()
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	
+	writer, err := pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	
+	err = forEachBatch(schema, records, func(batch arrow.Record) error {
+		return writer.Write(batch)
+	})
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+// forEachBatch converts records into Arrow record batches of at most batchSize rows
+func forEachBatch(schema *arrow.Schema, records []Record, write func(arrow.Record) error) error {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	
+	for start := 0; start < len(records) || start == 0; start += batchSize {
+		end := start + batchSize
+		if end > len(records) {
+			end = len(records)
+		}
+		
+		for _, r := range records[start:end] {
+			for i, col := range columns {
+				col.append(builder.Field(i), r)
+			}
+		}
+		
+		batch := builder.NewRecord()
+		err := write(batch)
+		batch.Release()
+		if err != nil {
+			return err
+		}
+		if end == len(records) {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package history
+
+import (
+	"sync"
+	"time"
+)
+
+// Record is a normalized summary of one completed solve. Amounts are always in
+// minor currency units and capacities in pounds and cubic feet, with the units
+// spelled out so downstream consumers never have to guess. Solves with sealed
+// payouts are stored with PayoutRedacted set and zero payout and profit.
+// APIKey is the name of the caller's API key, never the key itself.
+// SolutionID is the ID returned to the caller in the optimize response, and
+// ProblemFingerprint the canonical hash of the request (empty when sealed).
+type Record struct {
+	SolutionID               string    `json:"solution_id"`
+	CreatedAt                time.Time `json:"created_at"`
+	TenantID                 string    `json:"tenant_id"`
+	APIKey                   string    `json:"api_key"`
+	TruckID                  string    `json:"truck_id"`
+	Currency                 string    `json:"currency"`
+	WeightUnit               string    `json:"weight_unit"`
+	VolumeUnit               string    `json:"volume_unit"`
+	OrdersConsidered         int       `json:"orders_considered"`
+	OrdersSelected           int       `json:"orders_selected"`
+	TotalPayoutMinor         int64     `json:"total_payout_minor"`
+	TotalCostMinor           int64     `json:"total_cost_minor"`
+	NetProfitMinor           int64     `json:"net_profit_minor"`
+	PayoutRedacted           bool      `json:"payout_redacted"`
+	TotalWeight              int       `json:"total_weight"`
+	TotalVolume              int       `json:"total_volume"`
+	UtilizationWeightPercent float64   `json:"utilization_weight_percent"`
+	UtilizationVolumePercent float64   `json:"utilization_volume_percent"`
+	Algorithm                string    `json:"algorithm"`
+	Optimal                  bool      `json:"optimal"`
+	ComputeTimeMs            int64     `json:"compute_time_ms"`
+	Recommendation           string    `json:"recommendation"`
+	CacheHit                 bool      `json:"cache_hit"`
+	ProblemFingerprint       string    `json:"problem_fingerprint"`
+	// MeasuredGapPercent is how far below the exact optimum the plan's score
+	// was, set when a sampled heuristic solve is re-solved exactly afterwards
+	MeasuredGapPercent *float64 `json:"measured_gap_percent,omitempty"`
+	
+	// Revenue lists the selected orders' payouts for accrual reporting; it is
+	// empty when payouts are sealed
+	Revenue []OrderRevenue `json:"revenue,omitempty"`
+}
+
+// Store keeps solve history
+type Store interface {
+	Append(record Record)
+	// List returns the records of a tenant created in [from, to), oldest first.
+	// An empty tenant matches every tenant.
+	List(tenantID string, from, to time.Time) []Record
+	// Get returns the record of a solve by its solution ID
+	Get(solutionID string) (Record, bool)
+	// Delete removes every record expired reports true for and returns them,
+	// oldest first
+	Delete(expired func(Record) bool) []Record
+	// SetMeasuredGap records the optimality gap measured for a solve after it
+	// was stored, reporting false when the solve is no longer kept
+	SetMeasuredGap(solutionID string, gapPercent float64) bool
+}
+
+// MemoryStore keeps the most recent records in a fixed-size ring buffer
+type MemoryStore struct {
+	mu       sync.RWMutex
+	records  []Record
+	next     int
+	full     bool
+	capacity int
+}
+
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		records:  make([]Record, capacity),
+		capacity: capacity,
+	}
+}
+
+func (m *MemoryStore) Append(record Record) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	m.records[m.next] = record
+	m.next = (m.next + 1) % m.capacity
+	if m.next == 0 {
+		m.full = true
+	}
+}
+
+func (m *MemoryStore) Get(solutionID string) (Record, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	for _, record := range m.records {
+		if record.SolutionID != "" && record.SolutionID == solutionID {
+			return record, true
+		}
+	}
+	return Record{}, false
+}
+
+func (m *MemoryStore) SetMeasuredGap(solutionID string, gapPercent float64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	for i := range m.records {
+		if m.records[i].SolutionID != "" && m.records[i].SolutionID == solutionID {
+			m.records[i].MeasuredGapPercent = &gapPercent
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryStore) List(tenantID string, from, to time.Time) []Record {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	ordered := m.records[:m.next]
+	if m.full {
+		ordered = append(append([]Record{}, m.records[m.next:]...), m.records[:m.next]...)
+	}
+	
+	matched := make([]Record, 0)
+	for _, record := range ordered {
+		if tenantID != "" && record.TenantID != tenantID {
+			continue
+		}
+		if record.CreatedAt.Before(from) || !record.CreatedAt.Before(to) {
+			continue
+		}
+		matched = append(matched, record)
+	}
+	return matched
+}
+
+func (m *MemoryStore) Delete(expired func(Record) bool) []Record {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	ordered := m.records[:m.next]
+	if m.full {
+		ordered = append(append([]Record{}, m.records[m.next:]...), m.records[:m.next]...)
+	}
+	
+	var kept, deleted []Record
+	for _, record := range ordered {
+		if expired(record) {
+			deleted = append(deleted, record)
+		} else {
+			kept = append(kept, record)
+		}
+	}
+	if len(deleted) == 0 {
+		return nil
+	}
+	
+	// Compact the survivors to the front of a fresh buffer, so the deleted
+	// records do not linger in the old one
+	m.records = make([]Record, m.capacity)
+	copy(m.records, kept)
+	m.next = len(kept) % m.capacity
+	m.full = len(kept) == m.capacity
+	return deleted
+}
+
+// Discard is a store that keeps nothing, for embedders that solve without
+// a history
+type Discard struct{}
+
+func (Discard) Append(record Record) {}
+
+func (Discard) List(tenantID string, from, to time.Time) []Record { return nil }
+
+func (Discard) Get(solutionID string) (Record, bool) { return Record{}, false }
+
+func (Discard) Delete(expired func(Record) bool) []Record { return nil }
+
+func (Discard) SetMeasuredGap(solutionID string, gapPercent float64) bool { return false }
@@ -0,0 +1,47 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreDelete(t *testing.T) {
+	store := NewMemoryStore(3)
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"a", "b", "c", "d"} {
+		store.Append(Record{SolutionID: id, TenantID: "acme", CreatedAt: start.AddDate(0, 0, i)})
+	}
+	
+	deleted := store.Delete(func(record Record) bool { return record.SolutionID == "c" })
+	if len(deleted) != 1 || deleted[0].SolutionID != "c" {
+		t.Fatalf("deleted %+v, want c", deleted)
+	}
+	if _, ok := store.Get("c"); ok {
+		t.Error("deleted record still found")
+	}
+	
+	store.Append(Record{SolutionID: "e", TenantID: "acme", CreatedAt: start.AddDate(0, 0, 4)})
+	var ids []string
+	for _, record := range store.List("", start, start.AddDate(1, 0, 0)) {
+		ids = append(ids, record.SolutionID)
+	}
+	if len(ids) != 3 || ids[0] != "b" || ids[1] != "d" || ids[2] != "e" {
+		t.Errorf("listed %v, want [b d e] oldest first", ids)
+	}
+}
+
+func TestMemoryStoreSetMeasuredGap(t *testing.T) {
+	store := NewMemoryStore(2)
+	store.Append(Record{SolutionID: "a"})
+	
+	if !store.SetMeasuredGap("a", 1.5) {
+		t.Fatal("gap not set on a kept solve")
+	}
+	record, _ := store.Get("a")
+	if record.MeasuredGapPercent == nil || *record.MeasuredGapPercent != 1.5 {
+		t.Errorf("measured gap %v, want 1.5", record.MeasuredGapPercent)
+	}
+	if store.SetMeasuredGap("missing", 1) {
+		t.Error("gap set on an unknown solve")
+	}
+}
@@ -0,0 +1,50 @@
+package history
+
+import "sort"
+
+// Usage totals the solves one API key made for one tenant
+type Usage struct {
+	TenantID        string `json:"tenant_id"`
+	APIKey          string `json:"api_key"`
+	Solves          int    `json:"solves"`
+	OrdersProcessed int    `json:"orders_processed"`
+	ComputeMs       int64  `json:"compute_ms"`
+	CacheHits       int    `json:"cache_hits"`
+}
+
+// Add counts one record towards u
+func (u *Usage) Add(record Record) {
+	u.Solves++
+	u.OrdersProcessed += record.OrdersConsidered
+	u.ComputeMs += record.ComputeTimeMs
+	if record.CacheHit {
+		u.CacheHits++
+	}
+}
+
+// SummarizeUsage groups records by tenant and API key, sorted by tenant then key
+func SummarizeUsage(records []Record) []Usage {
+	type usageKey struct{ tenantID, apiKey string }
+	byKey := make(map[usageKey]*Usage)
+	for _, record := range records {
+		key := usageKey{record.TenantID, record.APIKey}
+		usage, ok := byKey[key]
+		if !ok {
+			usage = &Usage{TenantID: record.TenantID, APIKey: record.APIKey}
+			byKey[key] = usage
+		}
+		usage.Add(record)
+	}
+	
+	usages := make([]Usage, 0, len(byKey))
+	for _, usage := range byKey {
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].TenantID != usages[j].TenantID {
+			return usages[i].TenantID < usages[j].TenantID
+		}
+		return usages[i].APIKey < usages[j].APIKey
+	})
+	return usages
+}
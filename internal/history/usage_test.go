@@ -0,0 +1,27 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeUsage(t *testing.T) {
+	records := []Record{
+		{TenantID: "globex", APIKey: "ops", OrdersConsidered: 4, ComputeTimeMs: 3},
+		{TenantID: "acme", APIKey: "dispatch", OrdersConsidered: 5, ComputeTimeMs: 10},
+		{TenantID: "acme", APIKey: "dispatch", OrdersConsidered: 7, ComputeTimeMs: 2, CacheHit: true},
+		{TenantID: "acme", APIKey: "ops", OrdersConsidered: 1, ComputeTimeMs: 1},
+	}
+	
+	want := []Usage{
+		{TenantID: "acme", APIKey: "dispatch", Solves: 2, OrdersProcessed: 12, ComputeMs: 12, CacheHits: 1},
+		{TenantID: "acme", APIKey: "ops", Solves: 1, OrdersProcessed: 1, ComputeMs: 1},
+		{TenantID: "globex", APIKey: "ops", Solves: 1, OrdersProcessed: 4, ComputeMs: 3},
+	}
+	if got := SummarizeUsage(records); !reflect.DeepEqual(got, want) {
+		t.Errorf("SummarizeUsage = %+v, want %+v", got, want)
+	}
+	if got := SummarizeUsage(nil); len(got) != 0 {
+		t.Errorf("SummarizeUsage(nil) = %+v, want none", got)
+	}
+}
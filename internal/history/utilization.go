@@ -0,0 +1,104 @@
+package history
+
+import (
+	"fmt"
+	"sort"
+)
+
+// PeriodDay groups by calendar day, in UTC
+const PeriodDay = "day"
+
+// Utilization summarizes a tenant's solves in one period and currency, for
+// fleet managers. Solves and Algorithms count every solve. The plan figures
+// count each truck once, with its latest plan of the period, so a load
+// re-optimized several times is not added up more than once; solves without
+// a truck ID count on their own. Payouts of sealed solves are not known and
+// are left out of the total, which RedactedPlans then counts.
+type Utilization struct {
+	TenantID    string `json:"tenant_id"`
+	Currency    string `json:"currency"`
+	PeriodStart string `json:"period_start"`
+	Solves      int    `json:"solves"`
+	// Algorithms counts solves by the algorithm that produced their plan
+	Algorithms                      map[string]int `json:"algorithms"`
+	Plans                           int            `json:"plans"`
+	OrdersSelected                  int            `json:"orders_selected"`
+	TotalPayoutMinor                int64          `json:"total_payout_minor"`
+	RedactedPlans                   int            `json:"redacted_plans,omitempty"`
+	AverageWeightUtilizationPercent float64        `json:"average_weight_utilization_percent"`
+	AverageVolumeUtilizationPercent float64        `json:"average_volume_utilization_percent"`
+}
+
+// ValidateUtilizationPeriod checks a period for SummarizeUtilization
+func ValidateUtilizationPeriod(period string) error {
+	if period != PeriodDay && period != PeriodWeek && period != PeriodMonth {
+		return fmt.Errorf("invalid period: %s (must be day, week or month)", period)
+	}
+	return nil
+}
+
+// SummarizeUtilization groups records, oldest first, by tenant, the period
+// they were made in and currency, sorted in that order
+func SummarizeUtilization(records []Record, period string) []Utilization {
+	type utilizationKey struct{ tenantID, currency, periodStart string }
+	type truckKey struct {
+		utilizationKey
+		truckID string
+	}
+	// latest is the index of each truck's last record in its period
+	latest := make(map[truckKey]int)
+	keys := make([]utilizationKey, len(records))
+	for i, record := range records {
+		keys[i] = utilizationKey{record.TenantID, record.Currency, periodStart(record.CreatedAt, period)}
+		if record.TruckID != "" {
+			latest[truckKey{keys[i], record.TruckID}] = i
+		}
+	}
+	
+	byKey := make(map[utilizationKey]*Utilization)
+	for i, record := range records {
+		summary, ok := byKey[keys[i]]
+		if !ok {
+			summary = &Utilization{
+				TenantID:    keys[i].tenantID,
+				Currency:    keys[i].currency,
+				PeriodStart: keys[i].periodStart,
+				Algorithms:  make(map[string]int),
+			}
+			byKey[keys[i]] = summary
+		}
+		summary.Solves++
+		summary.Algorithms[record.Algorithm]++
+		if record.TruckID != "" && latest[truckKey{keys[i], record.TruckID}] != i {
+			continue
+		}
+		
+		summary.Plans++
+		summary.OrdersSelected += record.OrdersSelected
+		if record.PayoutRedacted {
+			summary.RedactedPlans++
+		}
+		summary.TotalPayoutMinor += record.TotalPayoutMinor
+		// Summed here, divided once every plan is in
+		summary.AverageWeightUtilizationPercent += record.UtilizationWeightPercent
+		summary.AverageVolumeUtilizationPercent += record.UtilizationVolumePercent
+	}
+	
+	summaries := make([]Utilization, 0, len(byKey))
+	for _, summary := range byKey {
+		summary.AverageWeightUtilizationPercent /= float64(summary.Plans)
+		summary.AverageVolumeUtilizationPercent /= float64(summary.Plans)
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.TenantID != b.TenantID {
+			return a.TenantID < b.TenantID
+		}
+		if a.PeriodStart != b.PeriodStart {
+			return a.PeriodStart < b.PeriodStart
+		}
+		return a.Currency < b.Currency
+	})
+	return summaries
+}
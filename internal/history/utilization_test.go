@@ -0,0 +1,59 @@
+package history
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSummarizeUtilization(t *testing.T) {
+	monday := date("2030-01-07")
+	records := []Record{
+		{TenantID: "acme", TruckID: "t1", Currency: "USD", CreatedAt: monday.Add(8 * time.Hour), Algorithm: "greedy",
+			OrdersSelected: 9, TotalPayoutMinor: 999, UtilizationWeightPercent: 10, UtilizationVolumePercent: 10},
+		{TenantID: "acme", TruckID: "t2", Currency: "USD", CreatedAt: monday.Add(9 * time.Hour), Algorithm: "dp",
+			OrdersSelected: 2, TotalPayoutMinor: 300, UtilizationWeightPercent: 90, UtilizationVolumePercent: 60},
+		// Replaces t1's first plan of the day
+		{TenantID: "acme", TruckID: "t1", Currency: "USD", CreatedAt: monday.Add(10 * time.Hour), Algorithm: "dp",
+			OrdersSelected: 3, TotalPayoutMinor: 500, UtilizationWeightPercent: 70, UtilizationVolumePercent: 40},
+		{TenantID: "acme", TruckID: "t3", Currency: "USD", CreatedAt: monday.AddDate(0, 0, 2), Algorithm: "dp",
+			OrdersSelected: 1, PayoutRedacted: true, UtilizationWeightPercent: 50, UtilizationVolumePercent: 50},
+		{TenantID: "acme", TruckID: "t1", Currency: "EUR", CreatedAt: monday.AddDate(0, 0, 2), Algorithm: "greedy",
+			OrdersSelected: 1, TotalPayoutMinor: 100, UtilizationWeightPercent: 20, UtilizationVolumePercent: 30},
+	}
+	
+	byDay := []Utilization{
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2030-01-07", Solves: 3, Algorithms: map[string]int{"greedy": 1, "dp": 2},
+			Plans: 2, OrdersSelected: 5, TotalPayoutMinor: 800, AverageWeightUtilizationPercent: 80, AverageVolumeUtilizationPercent: 50},
+		{TenantID: "acme", Currency: "EUR", PeriodStart: "2030-01-09", Solves: 1, Algorithms: map[string]int{"greedy": 1},
+			Plans: 1, OrdersSelected: 1, TotalPayoutMinor: 100, AverageWeightUtilizationPercent: 20, AverageVolumeUtilizationPercent: 30},
+		{TenantID: "acme", Currency: "USD", PeriodStart: "2030-01-09", Solves: 1, Algorithms: map[string]int{"dp": 1},
+			Plans: 1, OrdersSelected: 1, RedactedPlans: 1, AverageWeightUtilizationPercent: 50, AverageVolumeUtilizationPercent: 50},
+	}
+	if got := SummarizeUtilization(records, PeriodDay); !reflect.DeepEqual(got, byDay) {
+		t.Errorf("by day = %+v, want %+v", got, byDay)
+	}
+	
+	byWeek := SummarizeUtilization(records, PeriodWeek)
+	if len(byWeek) != 2 || byWeek[1].Currency != "USD" || byWeek[1].Solves != 4 || byWeek[1].Plans != 3 {
+		t.Fatalf("by week = %+v, want EUR then USD with 4 solves over 3 plans", byWeek)
+	}
+	if got := byWeek[1].AverageWeightUtilizationPercent; got != 70 {
+		t.Errorf("weekly average weight utilization = %v, want 70", got)
+	}
+	
+	if got := SummarizeUtilization(nil, PeriodDay); len(got) != 0 {
+		t.Errorf("SummarizeUtilization(nil) = %+v, want none", got)
+	}
+}
+
+func TestValidateUtilizationPeriod(t *testing.T) {
+	for _, period := range []string{PeriodDay, PeriodWeek, PeriodMonth} {
+		if err := ValidateUtilizationPeriod(period); err != nil {
+			t.Errorf("%s: %v", period, err)
+		}
+	}
+	if err := ValidateUtilizationPeriod("year"); err == nil {
+		t.Error("year accepted")
+	}
+}
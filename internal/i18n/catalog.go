@@ -0,0 +1,108 @@
+package i18n
+
+// message is one catalog entry: the English text as the code writes it and
+// its translations. Field names, codes and values clients send stay in
+// English in every language.
+type message struct {
+	en, es, fr string
+}
+
+// catalog is matched in order, so specific messages come before the
+// general patterns that would also match them
+var catalog = []message{
+	// Wrapping layers
+	{"validation failed", "validación fallida", "échec de la validation"},
+	{"conversion failed", "conversión fallida", "échec de la conversion"},
+	{"optimization aborted", "optimización interrumpida", "optimisation interrompue"},
+	{"truck %m", "camión: %m", "camion: %m"},
+	
+	// Requests and authentication
+	{"Invalid JSON format", "Formato JSON no válido", "Format JSON invalide"},
+	{"Invalid request body", "Cuerpo de la solicitud no válido", "Corps de la requête invalide"},
+	{"Request body too large", "Cuerpo de la solicitud demasiado grande", "Corps de la requête trop volumineux"},
+	{"Internal server error", "Error interno del servidor", "Erreur interne du serveur"},
+	{"API key required", "Se requiere una clave de API", "Clé d'API requise"},
+	{"invalid API key", "clave de API no válida", "clé d'API invalide"},
+	{"API key is not allowed to act for tenant %s", "la clave de API no puede actuar en nombre del tenant %s", "la clé d'API n'est pas autorisée à agir pour le tenant %s"},
+	{"API key lacks the %s scope", "a la clave de API le falta el alcance %s", "la clé d'API n'a pas la portée %s"},
+	{"usage of other API keys requires the admin-config scope", "consultar el uso de otras claves de API requiere el alcance admin-config", "consulter l'utilisation d'autres clés d'API nécessite la portée admin-config"},
+	{"X-JSON-Parsing must be strict or lenient", "X-JSON-Parsing debe ser strict o lenient", "X-JSON-Parsing doit valoir strict ou lenient"},
+	{"X-JSON-Parsing cannot loosen the server's strict parsing", "X-JSON-Parsing no puede relajar el análisis estricto del servidor", "X-JSON-Parsing ne peut pas assouplir l'analyse stricte du serveur"},
+	{"invalid cursor", "cursor no válido", "curseur invalide"},
+	{"cursor belongs to a different request", "el cursor pertenece a otra solicitud", "le curseur appartient à une autre requête"},
+	{"solution not found", "solución no encontrada", "solution introuvable"},
+	{"algorithm not found", "algoritmo no encontrado", "algorithme introuvable"},
+	{"job not found", "trabajo no encontrado", "tâche introuvable"},
+	{"invalid algorithm name", "nombre de algoritmo no válido", "nom d'algorithme invalide"},
+	{"shipper is not blocked", "el shipper no está bloqueado", "le shipper n'est pas bloqué"},
+	{"shipper is not preferred", "el shipper no es preferido", "le shipper n'est pas préféré"},
+	{"no validation profile is set", "no hay ningún perfil de validación definido", "aucun profil de validation n'est défini"},
+	{"set frozen_at or offset, not both", "indique frozen_at u offset, no ambos", "indiquez frozen_at ou offset, pas les deux"},
+	{"frozen_at or offset is required", "frozen_at u offset es obligatorio", "frozen_at ou offset est obligatoire"},
+	{"no plan is committed for this truck", "no hay ningún plan comprometido para este camión", "aucun plan n'est engagé pour ce camion"},
+	{"truck id must match the path", "el id del camión debe coincidir con la ruta", "l'id du camion doit correspondre au chemin"},
+	{"no retention policy is set", "no hay ninguna política de retención definida", "aucune politique de conservation n'est définie"},
+	{"from must be before to", "from debe ser anterior a to", "from doit précéder to"},
+	{"window cannot be combined with from or to", "window no se puede combinar con from ni con to", "window ne peut pas être combiné avec from ou to"},
+	{"expected RFC 3339 timestamp or YYYY-MM-DD", "se esperaba una marca de tiempo RFC 3339 o AAAA-MM-DD", "horodatage RFC 3339 ou AAAA-MM-JJ attendu"},
+	
+	// Request validation
+	{"orders list cannot exceed %d items (got %d)", "la lista de pedidos no puede superar %d elementos (se recibieron %d)", "la liste des commandes ne peut pas dépasser %d éléments (%d reçus)"},
+	{"orders list cannot exceed %d items for algorithm %s (got %d)", "la lista de pedidos no puede superar %d elementos con el algoritmo %s (se recibieron %d)", "la liste des commandes ne peut pas dépasser %d éléments avec l'algorithme %s (%d reçus)"},
+	{"duplicate order id", "id de pedido duplicado", "id de commande en double"},
+	{"duplicate facility", "instalación duplicada", "site en double"},
+	{"duplicate compartment id", "id de compartimento duplicado", "id de compartiment en double"},
+	{"currency must be a 3-letter ISO 4217 code", "currency debe ser un código ISO 4217 de 3 letras", "currency doit être un code ISO 4217 à 3 lettres"},
+	{"origin and destination are required", "origin y destination son obligatorios", "origin et destination sont obligatoires"},
+	{"origin and destination must be less than 200 characters", "origin y destination deben tener menos de 200 caracteres", "origin et destination doivent faire moins de 200 caractères"},
+	{"delivery_date cannot be before pickup_date", "delivery_date no puede ser anterior a pickup_date", "delivery_date ne peut pas précéder pickup_date"},
+	{"pickup_date and delivery_date must be within %d days of today", "pickup_date y delivery_date deben estar a menos de %d días de hoy", "pickup_date et delivery_date doivent être à moins de %d jours d'aujourd'hui"},
+	{"hazmat_class requires is_hazmat", "hazmat_class requiere is_hazmat", "hazmat_class nécessite is_hazmat"},
+	{"equipment_type must be dry or reefer", "equipment_type debe ser dry o reefer", "equipment_type doit valoir dry ou reefer"},
+	{"temperature_min_f cannot be above temperature_max_f", "temperature_min_f no puede ser mayor que temperature_max_f", "temperature_min_f ne peut pas dépasser temperature_max_f"},
+	{"payout_encrypted is not enabled on this server", "payout_encrypted no está habilitado en este servidor", "payout_encrypted n'est pas activé sur ce serveur"},
+	{"set payout_cents or payout_encrypted, not both", "indique payout_cents o payout_encrypted, no ambos", "indiquez payout_cents ou payout_encrypted, pas les deux"},
+	{"%s_window_start and %s_window_end must be set together", "%s_window_start y %s_window_end deben indicarse juntos", "%s_window_start et %s_window_end doivent être indiqués ensemble"},
+	{"%s_window_end must be after %s_window_start", "%s_window_end debe ser posterior a %s_window_start", "%s_window_end doit être postérieur à %s_window_start"},
+	{"order %s cannot be committed: %m", "el pedido %s no se puede comprometer: %m", "la commande %s ne peut pas être engagée: %m"},
+	{"bids[%d]: unknown order %s", "bids[%d]: pedido desconocido %s", "bids[%d]: commande inconnue %s"},
+	{"bids[%d]: duplicate order %s", "bids[%d]: pedido duplicado %s", "bids[%d]: commande en double %s"},
+	{"bids[%d]: order %s is bid on, so it takes no payout", "bids[%d]: el pedido %s se puja, así que no lleva payout", "bids[%d]: la commande %s fait l'objet d'une offre et ne prend donc pas de payout"},
+	{"bids[%d]: levels must be between 1 and %d", "bids[%d]: levels debe estar entre 1 y %d", "bids[%d]: levels doit être compris entre 1 et %d"},
+	{"bids[%d]: payout_cents must be between 1 and %d", "bids[%d]: payout_cents debe estar entre 1 y %d", "bids[%d]: payout_cents doit être compris entre 1 et %d"},
+	{"bids[%d]: win_probability must be above 0 and at most 1", "bids[%d]: win_probability debe ser mayor que 0 y como máximo 1", "bids[%d]: win_probability doit être supérieur à 0 et au plus 1"},
+	{"outbound_order_ids: unknown order %s", "outbound_order_ids: pedido desconocido %s", "outbound_order_ids: commande inconnue %s"},
+	{"outbound_order_ids: duplicate order %s", "outbound_order_ids: pedido duplicado %s", "outbound_order_ids: commande en double %s"},
+	{"invalid commodity_type: %s (must be general, food, pharma or hazmat)", "commodity_type no válido: %s (debe ser general, food, pharma o hazmat)", "commodity_type invalide: %s (doit être general, food, pharma ou hazmat)"},
+	{"commodity_type hazmat requires is_hazmat", "commodity_type hazmat requiere is_hazmat", "commodity_type hazmat nécessite is_hazmat"},
+	{"is_hazmat orders must have commodity_type hazmat", "los pedidos is_hazmat deben tener commodity_type hazmat", "les commandes is_hazmat doivent avoir commodity_type hazmat"},
+	{"rule %s cannot be disabled", "la regla %s no se puede desactivar", "la règle %s ne peut pas être désactivée"},
+	{"rule %s is listed twice", "la regla %s aparece dos veces", "la règle %s figure deux fois"},
+	{"lat must be between -90 and 90 and lng between -180 and 180", "lat debe estar entre -90 y 90 y lng entre -180 y 180", "lat doit être compris entre -90 et 90 et lng entre -180 et 180"},
+	{"max_exposure_hours requires temperature_min_f or temperature_max_f", "max_exposure_hours requiere temperature_min_f o temperature_max_f", "max_exposure_hours nécessite temperature_min_f ou temperature_max_f"},
+	{"origin and destination are required", "origin y destination son obligatorios", "origin et destination sont obligatoires"},
+	{"lane %s to %s is listed twice", "el carril %s a %s aparece dos veces", "la voie %s vers %s figure deux fois"},
+	{"Accept must allow application/json, application/x-protobuf or application/msgpack", "Accept debe permitir application/json, application/x-protobuf o application/msgpack", "Accept doit autoriser application/json, application/x-protobuf ou application/msgpack"},
+	{"XML tenders cannot be sent as NDJSON", "las licitaciones XML no se pueden enviar como NDJSON", "les appels d'offres XML ne peuvent pas être envoyés en NDJSON"},
+	{"orders must be a single CSV file", "orders debe ser un único archivo CSV", "orders doit être un seul fichier CSV"},
+	{"requests list cannot be empty", "la lista de solicitudes no puede estar vacía", "la liste des requêtes ne peut pas être vide"},
+	{"requests list cannot exceed %d items (got %d)", "la lista de solicitudes no puede superar %d elementos (se recibieron %d)", "la liste des requêtes ne peut pas dépasser %d éléments (%d reçues)"},
+	{"no run of this id is searching", "ninguna ejecución con este id está buscando", "aucune exécution de cet id n'est en recherche"},
+	{"Idempotency-Key cannot exceed 255 characters", "Idempotency-Key no puede superar 255 caracteres", "Idempotency-Key ne peut pas dépasser 255 caractères"},
+	{"Idempotency-Key cannot be used with NDJSON bodies", "Idempotency-Key no se puede usar con cuerpos NDJSON", "Idempotency-Key ne peut pas être utilisé avec des corps NDJSON"},
+	{"Idempotency-Key was already used for a different request", "Idempotency-Key ya se usó para otra solicitud", "Idempotency-Key a déjà été utilisé pour une autre requête"},
+	{"a request with this Idempotency-Key is still being handled", "una solicitud con esta Idempotency-Key aún se está procesando", "une requête avec cette Idempotency-Key est encore en cours de traitement"},
+	
+	// General patterns
+	{"%s must be positive", "%s debe ser positivo", "%s doit être positif"},
+	{"%s exceeds maximum allowed value", "%s supera el valor máximo permitido", "%s dépasse la valeur maximale autorisée"},
+	{"%s must be between %d and %d", "%s debe estar entre %d y %d", "%s doit être compris entre %d et %d"},
+	{"%s cannot be negative", "%s no puede ser negativo", "%s ne peut pas être négatif"},
+	{"%s is required", "%s es obligatorio", "%s est obligatoire"},
+	{"%s must be less than %d characters", "%s debe tener menos de %d caracteres", "%s doit faire moins de %d caractères"},
+	{"%s exceeds %d characters", "%s supera los %d caracteres", "%s dépasse %d caractères"},
+	{"at most %d %s allowed", "se permiten como máximo %d %s", "%d %s au maximum"},
+	{"invalid %s format (expected YYYY-MM-DD)", "formato de %s no válido (se esperaba AAAA-MM-DD)", "format de %s invalide (AAAA-MM-JJ attendu)"},
+	{"invalid %s: %s (must be %s)", "%s no válido: %s (valores admitidos: %s)", "%s invalide: %s (valeurs admises: %s)"},
+	{"invalid %s", "%s no válido", "%s invalide"},
+}
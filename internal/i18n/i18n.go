@@ -0,0 +1,142 @@
+// Package i18n translates the API's error messages. Messages are written in
+// English throughout the code base; the catalog maps them to the other
+// languages clients can ask for with Accept-Language.
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Languages the catalog has messages in
+const (
+	English = "en"
+	Spanish = "es"
+	French  = "fr"
+)
+
+// Languages lists every supported language, English, the source language,
+// first
+var Languages = []string{English, Spanish, French}
+
+// Negotiate picks the supported language the Accept-Language header value
+// prefers most, matching on the primary subtag so es-MX gets Spanish. Ties
+// go to the language listed first; a header naming no supported language,
+// or none at all, gets English.
+func Negotiate(acceptLanguage string) string {
+	best, bestQuality := English, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		primary, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if primary == "*" {
+			primary = English
+		}
+		if quality > bestQuality && supported(primary) {
+			best, bestQuality = primary, quality
+		}
+	}
+	return best
+}
+
+func supported(language string) bool {
+	return slices.Contains(Languages, language)
+}
+
+// Translate returns the message in the language. Errors are wrapped with
+// ": " between the layers, so a message the catalog does not know whole is
+// translated layer by layer; text no entry matches, such as order[3] or an
+// order id, stays as it is. English and unknown languages get the message
+// unchanged.
+func Translate(language, message string) string {
+	if language == English || !supported(language) {
+		return message
+	}
+	return translate(language, message)
+}
+
+func translate(language, message string) string {
+	if translated, ok := lookup(language, message); ok {
+		return translated
+	}
+	if head, tail, found := strings.Cut(message, ": "); found {
+		return translate(language, head) + ": " + translate(language, tail)
+	}
+	return message
+}
+
+func lookup(language, message string) (string, bool) {
+	for _, e := range entries {
+		match := e.pattern.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		args := make([]interface{}, len(e.verbs))
+		for i, verb := range e.verbs {
+			args[i] = match[i+1]
+			if verb == 'm' {
+				args[i] = translate(language, match[i+1])
+			}
+		}
+		return fmt.Sprintf(e.translations[language], args...), true
+	}
+	return "", false
+}
+
+// entry is a compiled catalog message. Catalog messages take %s for text
+// without a colon, %d for an integer and %m for a wrapped message, which is
+// translated in turn; translations repeat the verbs in the same order.
+type entry struct {
+	pattern      *regexp.Regexp
+	verbs        []byte
+	translations map[string]string
+}
+
+var verbPattern = regexp.MustCompile(`%[sdm]`)
+
+var entries = compile(catalog)
+
+func compile(messages []message) []entry {
+	compiled := make([]entry, 0, len(messages))
+	for _, m := range messages {
+		var pattern strings.Builder
+		var verbs []byte
+		last := 0
+		for _, loc := range verbPattern.FindAllStringIndex(m.en, -1) {
+			pattern.WriteString(regexp.QuoteMeta(m.en[last:loc[0]]))
+			verb := m.en[loc[0]+1]
+			switch verb {
+			case 'd':
+				pattern.WriteString(`(-?\d+)`)
+			case 's':
+				pattern.WriteString(`([^:]+)`)
+			default:
+				pattern.WriteString(`(.+)`)
+			}
+			verbs = append(verbs, verb)
+			last = loc[1]
+		}
+		pattern.WriteString(regexp.QuoteMeta(m.en[last:]))
+		
+		translations := map[string]string{Spanish: m.es, French: m.fr}
+		for language, text := range translations {
+			translations[language] = verbPattern.ReplaceAllString(text, "%s")
+		}
+		compiled = append(compiled, entry{
+			pattern:      regexp.MustCompile("^" + pattern.String() + "$"),
+			verbs:        verbs,
+			translations: translations,
+		})
+	}
+	return compiled
+}
@@ -0,0 +1,71 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+	}{
+		{"", English},
+		{"es", Spanish},
+		{"es-MX,es;q=0.9,en;q=0.8", Spanish},
+		{"FR-ca", French},
+		{"de-DE,fr;q=0.5,es;q=0.7", Spanish},
+		{"de-DE", English},
+		{"en;q=0.4, fr", French},
+		{"fr;q=0, es;q=0.1", Spanish},
+		{"fr;q=oops, es;q=0.2", Spanish},
+		{"*", English},
+	}
+	for _, tc := range cases {
+		if got := Negotiate(tc.header); got != tc.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tc.header, got, tc.want)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	cases := []struct {
+		language string
+		message  string
+		want     string
+	}{
+		{English, "validation failed: order[0]: payout_cents must be positive", "validation failed: order[0]: payout_cents must be positive"},
+		{"de", "invalid API key", "invalid API key"},
+		{Spanish, "validation failed: order[0]: payout_cents must be positive", "validación fallida: order[0]: payout_cents debe ser positivo"},
+		{French, "validation failed: order[0]: payout_cents must be positive", "échec de la validation: order[0]: payout_cents doit être positif"},
+		{Spanish, "validation failed: truck max_linear_feet must be between 0 and 100", "validación fallida: camión: max_linear_feet debe estar entre 0 y 100"},
+		{French, "validation failed: duplicate order id: ORD-1", "échec de la validation: id de commande en double: ORD-1"},
+		{Spanish, "validation failed: orders list cannot exceed 22 items (got 30)", "validación fallida: la lista de pedidos no puede superar 22 elementos (se recibieron 30)"},
+		{French, "invalid from: expected RFC 3339 timestamp or YYYY-MM-DD", "from invalide: horodatage RFC 3339 ou AAAA-MM-JJ attendu"},
+		{Spanish, "invalid objective: speed (must be revenue, utilization, balanced, or profit)", "objective no válido: speed (valores admitidos: revenue, utilization, balanced, or profit)"},
+		{French, "API key lacks the read-history scope", "la clé d'API n'a pas la portée read-history"},
+		// Messages the catalog lacks stay in English
+		{Spanish, "validation failed: must_include orders break a rule on orders sharing a truck", "validación fallida: must_include orders break a rule on orders sharing a truck"},
+	}
+	for _, tc := range cases {
+		if got := Translate(tc.language, tc.message); got != tc.want {
+			t.Errorf("Translate(%s, %q) = %q, want %q", tc.language, tc.message, got, tc.want)
+		}
+	}
+}
+
+func TestCatalogTranslationsKeepVerbs(t *testing.T) {
+	for _, m := range catalog {
+		want := verbPattern.FindAllString(m.en, -1)
+		for language, text := range map[string]string{Spanish: m.es, French: m.fr} {
+			got := verbPattern.FindAllString(text, -1)
+			if len(got) != len(want) {
+				t.Errorf("%s translation of %q has verbs %v, want %v", language, m.en, got, want)
+				continue
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("%s translation of %q has verbs %v, want %v", language, m.en, got, want)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package ids generates identifiers for solves and the records derived from them.
+package ids
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// Generator hands out unique identifiers
+type Generator interface {
+	NewID() string
+}
+
+// crockford is the Crockford base32 alphabet ULIDs are written in
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator produces ULIDs (https://github.com/ulid/spec): a 48-bit
+// millisecond timestamp followed by 80 random bits, 26 characters that sort by
+// creation time. IDs minted in the same millisecond increment the random part,
+// so they still sort in the order they were generated.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	lastMs  uint64
+	lastHi  uint16
+	lastLo  uint64
+	entropy func([]byte) error
+}
+
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{
+		now: time.Now,
+		entropy: func(b []byte) error {
+			_, err := rand.Read(b)
+			return err
+		},
+	}
+}
+
+func (g *ULIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	
+	ms := uint64(g.now().UnixMilli())
+	if ms > g.lastMs {
+		var random [10]byte
+		if err := g.entropy(random[:]); err != nil {
+			// crypto/rand does not fail on supported platforms
+			panic("ids: reading entropy: " + err.Error())
+		}
+		g.lastMs = ms
+		g.lastHi = binary.BigEndian.Uint16(random[:2])
+		g.lastLo = binary.BigEndian.Uint64(random[2:])
+	} else {
+		// Same millisecond, or the clock went backwards: stay monotonic
+		g.lastLo++
+		if g.lastLo == 0 {
+			g.lastHi++
+			if g.lastHi == 0 {
+				g.lastMs++
+			}
+		}
+	}
+	return encode(g.lastMs, g.lastHi, g.lastLo)
+}
+
+// encode writes the 128-bit value ms(48) | hi(16) | lo(64) as 26 base32 digits
+func encode(ms uint64, hi uint16, lo uint64) string {
+	var id [26]byte
+	// The upper 64 bits hold the timestamp and hi; the first digit only takes
+	// 3 bits because 26 digits carry 130 bits
+	upper := ms<<16 | uint64(hi)
+	for i := 25; i >= 0; i-- {
+		id[i] = crockford[lo&31]
+		lo = lo>>5 | upper<<59
+		upper >>= 5
+	}
+	return string(id[:])
+}
@@ -0,0 +1,58 @@
+package ids
+
+import (
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestULIDFormat(t *testing.T) {
+	id := NewULIDGenerator().NewID()
+	if len(id) != 26 {
+		t.Fatalf("len(%q) = %d, want 26", id, len(id))
+	}
+	for _, c := range id {
+		if !strings.ContainsRune(crockford, c) {
+			t.Fatalf("%q contains %q, outside the Crockford alphabet", id, c)
+		}
+	}
+	if id[0] > '7' {
+		t.Fatalf("%q overflows 128 bits", id)
+	}
+}
+
+func TestULIDEncodesTimestamp(t *testing.T) {
+	g := NewULIDGenerator()
+	g.now = func() time.Time { return time.UnixMilli(1469918176385) }
+	g.entropy = func(b []byte) error {
+		for i := range b {
+			b[i] = 0
+		}
+		return nil
+	}
+	
+	// Timestamp from the ULID specification's example
+	if id := g.NewID(); id != "01ARYZ6S410000000000000000" {
+		t.Errorf("NewID = %s, want 01ARYZ6S410000000000000000", id)
+	}
+	if id := g.NewID(); id != "01ARYZ6S410000000000000001" {
+		t.Errorf("second NewID in the same millisecond = %s, want 01ARYZ6S410000000000000001", id)
+	}
+}
+
+func TestULIDsSortInCreationOrder(t *testing.T) {
+	g := NewULIDGenerator()
+	generated := make([]string, 10000)
+	seen := make(map[string]bool)
+	for i := range generated {
+		generated[i] = g.NewID()
+		if seen[generated[i]] {
+			t.Fatalf("duplicate id %s", generated[i])
+		}
+		seen[generated[i]] = true
+	}
+	if !sort.StringsAreSorted(generated) {
+		t.Error("ids do not sort in creation order")
+	}
+}
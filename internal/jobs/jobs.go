@@ -0,0 +1,139 @@
+// Package jobs keeps optimize requests submitted to run in the background:
+// each job's request, its state, and once it has run, its response or error.
+package jobs
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"sync"
+	"time"
+)
+
+// Job states. A job is queued until a solver takes it, running while it is
+// solved, and then succeeded or failed.
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Job is an optimize request solved in the background. Response is set once
+// it has succeeded, and Error, with the status the API would have answered
+// with, once it has failed. APIKey is the name of the API key the job was
+// submitted with, never the key itself.
+type Job struct {
+	ID         string                   `json:"id"`
+	Status     string                   `json:"status"`
+	TenantID   string                   `json:"tenant_id,omitempty"`
+	APIKey     string                   `json:"api_key,omitempty"`
+	Request    domain.OptimizeRequest   `json:"request"`
+	Response   *domain.OptimizeResponse `json:"response,omitempty"`
+	Error      *domain.ErrorDetail      `json:"error,omitempty"`
+	CreatedAt  time.Time                `json:"created_at"`
+	StartedAt  *time.Time               `json:"started_at,omitempty"`
+	FinishedAt *time.Time               `json:"finished_at,omitempty"`
+}
+
+// Finished reports whether the job has succeeded or failed
+func (j Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusFailed
+}
+
+// Store keeps jobs
+type Store interface {
+	// Create stores a new job
+	Create(ctx context.Context, job Job) error
+	// Update stores a job's state, response, error and times; its request
+	// is kept as it was created
+	Update(ctx context.Context, job Job) error
+	// Get returns a job by its ID
+	Get(ctx context.Context, id string) (Job, bool, error)
+	// Unfinished lists the jobs still queued or running, oldest first
+	Unfinished(ctx context.Context) ([]Job, error)
+	// Delete deletes the finished jobs of each tenant created before the
+	// cutoff before returns for it, and returns them; a zero cutoff keeps
+	// all of the tenant's jobs
+	Delete(ctx context.Context, before func(tenantID string) time.Time) ([]Job, error)
+}
+
+// MemoryStore keeps the most recent jobs in memory; they are lost on
+// restart. Past its capacity, the oldest job is dropped for each new one.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	jobs     map[string]Job
+	order    []string
+	capacity int
+}
+
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{jobs: make(map[string]Job), capacity: capacity}
+}
+
+func (m *MemoryStore) Create(_ context.Context, job Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	// The request is kept as it was sent, whatever the solve does to its orders
+	job.Request.Orders = append([]domain.OrderInput(nil), job.Request.Orders...)
+	m.jobs[job.ID] = job
+	m.order = append(m.order, job.ID)
+	if len(m.order) > m.capacity {
+		delete(m.jobs, m.order[0])
+		m.order = m.order[1:]
+	}
+	return nil
+}
+
+func (m *MemoryStore) Update(_ context.Context, job Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	stored, ok := m.jobs[job.ID]
+	if !ok {
+		return nil
+	}
+	job.Request = stored.Request
+	m.jobs[job.ID] = job
+	return nil
+}
+
+func (m *MemoryStore) Get(_ context.Context, id string) (Job, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	job, ok := m.jobs[id]
+	return job, ok, nil
+}
+
+func (m *MemoryStore) Unfinished(_ context.Context) ([]Job, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	
+	var unfinished []Job
+	for _, id := range m.order {
+		if job := m.jobs[id]; !job.Finished() {
+			unfinished = append(unfinished, job)
+		}
+	}
+	return unfinished, nil
+}
+
+func (m *MemoryStore) Delete(_ context.Context, before func(tenantID string) time.Time) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	var deleted []Job
+	kept := m.order[:0]
+	for _, id := range m.order {
+		job := m.jobs[id]
+		if cutoff := before(job.TenantID); job.Finished() && job.CreatedAt.Before(cutoff) {
+			deleted = append(deleted, job)
+			delete(m.jobs, id)
+			continue
+		}
+		kept = append(kept, id)
+	}
+	m.order = kept
+	return deleted, nil
+}
@@ -0,0 +1,92 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(2)
+	created := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	request := domain.OptimizeRequest{Orders: []domain.OrderInput{{ID: "ord-1", PayoutEncrypted: "sealed"}}}
+	for _, id := range []string{"a", "b"} {
+		if err := store.Create(ctx, Job{ID: id, Status: StatusQueued, Request: request, CreatedAt: created}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A solve opening the payouts must not reach the stored request
+	request.Orders[0].PayoutCents = 100
+	
+	response := &domain.OptimizeResponse{SolutionID: "s"}
+	if err := store.Update(ctx, Job{ID: "a", Status: StatusSucceeded, Response: response}); err != nil {
+		t.Fatal(err)
+	}
+	job, ok, err := store.Get(ctx, "a")
+	if err != nil || !ok {
+		t.Fatalf("Get(a) = %v, %v", ok, err)
+	}
+	if job.Status != StatusSucceeded || job.Response != response || len(job.Request.Orders) != 1 || job.Request.Orders[0].PayoutCents != 0 {
+		t.Errorf("job = %+v, want it succeeded with its request as created", job)
+	}
+	
+	unfinished, _ := store.Unfinished(ctx)
+	if len(unfinished) != 1 || unfinished[0].ID != "b" {
+		t.Errorf("unfinished = %+v, want b", unfinished)
+	}
+	
+	if err := store.Create(ctx, Job{ID: "c", Status: StatusQueued}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Get(ctx, "a"); ok {
+		t.Error("oldest job kept past the capacity")
+	}
+	unfinished, _ = store.Unfinished(ctx)
+	if len(unfinished) != 2 || unfinished[0].ID != "b" || unfinished[1].ID != "c" {
+		t.Errorf("unfinished = %+v, want b then c", unfinished)
+	}
+}
+
+func TestMemoryStoreDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(10)
+	created := time.Date(2025, 12, 1, 9, 0, 0, 0, time.UTC)
+	for _, job := range []Job{
+		{ID: "acme-old", TenantID: "acme", Status: StatusSucceeded, CreatedAt: created},
+		{ID: "acme-queued", TenantID: "acme", Status: StatusQueued, CreatedAt: created},
+		{ID: "acme-new", TenantID: "acme", Status: StatusFailed, CreatedAt: created.AddDate(0, 0, 10)},
+		{ID: "globex-old", TenantID: "globex", Status: StatusSucceeded, CreatedAt: created},
+	} {
+		if err := store.Create(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	
+	// globex keeps its jobs forever
+	deleted, err := store.Delete(ctx, func(tenantID string) time.Time {
+		if tenantID == "acme" {
+			return created.AddDate(0, 0, 5)
+		}
+		return time.Time{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != "acme-old" {
+		t.Fatalf("deleted %+v, want acme-old", deleted)
+	}
+	if _, ok, _ := store.Get(ctx, "acme-old"); ok {
+		t.Error("deleted job still stored")
+	}
+	for _, id := range []string{"acme-queued", "acme-new", "globex-old"} {
+		if _, ok, _ := store.Get(ctx, id); !ok {
+			t.Errorf("%s deleted", id)
+		}
+	}
+	if unfinished, _ := store.Unfinished(ctx); len(unfinished) != 1 || unfinished[0].ID != "acme-queued" {
+		t.Errorf("unfinished = %+v, want acme-queued", unfinished)
+	}
+}
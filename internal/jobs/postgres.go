@@ -0,0 +1,224 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"smart-load/internal/domain"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// postgresSchema creates the jobs table when it does not exist. Requests,
+// responses and errors are kept as JSON, as the API sends them.
+const postgresSchema = `
+CREATE TABLE IF NOT EXISTS optimization_jobs (
+	id          TEXT PRIMARY KEY,
+	status      TEXT NOT NULL,
+	tenant_id   TEXT NOT NULL DEFAULT '',
+	api_key     TEXT NOT NULL DEFAULT '',
+	request     JSONB NOT NULL,
+	response    JSONB,
+	error       JSONB,
+	created_at  TIMESTAMPTZ NOT NULL,
+	started_at  TIMESTAMPTZ,
+	finished_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS optimization_jobs_unfinished
+	ON optimization_jobs (created_at) WHERE status IN ('queued', 'running');
+`
+
+const jobColumns = `id, status, tenant_id, api_key, request, response, error, created_at, started_at, finished_at`
+
+// PostgresStore keeps jobs in PostgreSQL, so they survive restarts and can be
+// audited later
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// OpenPostgres connects to the database at url, a postgres:// URL or DSN,
+// and creates the jobs table if needed
+func OpenPostgres(ctx context.Context, url string) (*PostgresStore, error) {
+	db, err := sql.Open("pgx", url)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, postgresSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("creating jobs table: %w", err)
+	}
+	return &PostgresStore{db: db}, nil
+}
+
+func (p *PostgresStore) Create(ctx context.Context, job Job) error {
+	request, err := json.Marshal(job.Request)
+	if err != nil {
+		return err
+	}
+	response, jobError, err := encodeOutcome(job)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx,
+		`INSERT INTO optimization_jobs (`+jobColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+		job.ID, job.Status, job.TenantID, job.APIKey, request, response, jobError, job.CreatedAt, job.StartedAt, job.FinishedAt)
+	return err
+}
+
+func (p *PostgresStore) Update(ctx context.Context, job Job) error {
+	response, jobError, err := encodeOutcome(job)
+	if err != nil {
+		return err
+	}
+	_, err = p.db.ExecContext(ctx,
+		`UPDATE optimization_jobs SET status = $2, response = $3, error = $4, started_at = $5, finished_at = $6 WHERE id = $1`,
+		job.ID, job.Status, response, jobError, job.StartedAt, job.FinishedAt)
+	return err
+}
+
+func (p *PostgresStore) Get(ctx context.Context, id string) (Job, bool, error) {
+	row := p.db.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM optimization_jobs WHERE id = $1`, id)
+	job, err := scanJob(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Job{}, false, nil
+	}
+	if err != nil {
+		return Job{}, false, err
+	}
+	return job, true, nil
+}
+
+func (p *PostgresStore) Unfinished(ctx context.Context) ([]Job, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT `+jobColumns+` FROM optimization_jobs WHERE status IN ($1, $2) ORDER BY created_at, id`,
+		StatusQueued, StatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	
+	var unfinished []Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		unfinished = append(unfinished, job)
+	}
+	return unfinished, rows.Err()
+}
+
+func (p *PostgresStore) Delete(ctx context.Context, before func(tenantID string) time.Time) ([]Job, error) {
+	rows, err := p.db.QueryContext(ctx,
+		`SELECT DISTINCT tenant_id FROM optimization_jobs WHERE status IN ($1, $2)`,
+		StatusSucceeded, StatusFailed)
+	if err != nil {
+		return nil, err
+	}
+	var tenants []string
+	for rows.Next() {
+		var tenantID string
+		if err := rows.Scan(&tenantID); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		tenants = append(tenants, tenantID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	
+	var deleted []Job
+	for _, tenantID := range tenants {
+		cutoff := before(tenantID)
+		if cutoff.IsZero() {
+			continue
+		}
+		rows, err := p.db.QueryContext(ctx,
+			`DELETE FROM optimization_jobs WHERE tenant_id = $1 AND status IN ($2, $3) AND created_at < $4 RETURNING `+jobColumns,
+			tenantID, StatusSucceeded, StatusFailed, cutoff)
+		if err != nil {
+			return deleted, err
+		}
+		for rows.Next() {
+			job, err := scanJob(rows)
+			if err != nil {
+				rows.Close()
+				return deleted, err
+			}
+			deleted = append(deleted, job)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+func (p *PostgresStore) Close() error {
+	return p.db.Close()
+}
+
+// encodeOutcome encodes a job's response and error, nil when unset so the
+// columns stay NULL
+func encodeOutcome(job Job) (response, jobError []byte, err error) {
+	if job.Response != nil {
+		if response, err = json.Marshal(job.Response); err != nil {
+			return nil, nil, err
+		}
+	}
+	if job.Error != nil {
+		if jobError, err = json.Marshal(job.Error); err != nil {
+			return nil, nil, err
+		}
+	}
+	return response, jobError, nil
+}
+
+// scanJob reads a row of jobColumns
+func scanJob(row interface{ Scan(dest ...any) error }) (Job, error) {
+	var job Job
+	var request, response, jobError []byte
+	var startedAt, finishedAt sql.NullTime
+	if err := row.Scan(&job.ID, &job.Status, &job.TenantID, &job.APIKey, &request, &response, &jobError,
+		&job.CreatedAt, &startedAt, &finishedAt); err != nil {
+		return Job{}, err
+	}
+	if err := json.Unmarshal(request, &job.Request); err != nil {
+		return Job{}, fmt.Errorf("job %s: %w", job.ID, err)
+	}
+	// The request's tenant and API key are not part of its JSON
+	job.Request.TenantID, job.Request.APIKey = job.TenantID, job.APIKey
+	if response != nil {
+		job.Response = new(domain.OptimizeResponse)
+		if err := json.Unmarshal(response, job.Response); err != nil {
+			return Job{}, fmt.Errorf("job %s: %w", job.ID, err)
+		}
+	}
+	if jobError != nil {
+		job.Error = new(domain.ErrorDetail)
+		if err := json.Unmarshal(jobError, job.Error); err != nil {
+			return Job{}, fmt.Errorf("job %s: %w", job.ID, err)
+		}
+	}
+	if startedAt.Valid {
+		job.StartedAt = timePointer(startedAt.Time)
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = timePointer(finishedAt.Time)
+	}
+	return job, nil
+}
+
+func timePointer(t time.Time) *time.Time {
+	return &t
+}
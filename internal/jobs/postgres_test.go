@@ -0,0 +1,94 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+)
+
+// TestPostgresStore runs against the database in SMARTLOAD_TEST_DATABASE_URL,
+// and is skipped without one. It leaves its jobs in the table.
+func TestPostgresStore(t *testing.T) {
+	url := os.Getenv("SMARTLOAD_TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("SMARTLOAD_TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+	store, err := OpenPostgres(ctx, url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	
+	id := "test-" + time.Now().Format("20060102150405.000000000")
+	created := time.Now().UTC().Truncate(time.Microsecond)
+	job := Job{
+		ID:        id,
+		Status:    StatusQueued,
+		TenantID:  "acme",
+		APIKey:    "ops",
+		Request:   domain.OptimizeRequest{Truck: domain.TruckInput{ID: "truck-1"}, Orders: []domain.OrderInput{{ID: "ord-1", PayoutCents: 100}}},
+		CreatedAt: created,
+	}
+	if err := store.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	unfinished, err := store.Unfinished(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, queued := range unfinished {
+		found = found || queued.ID == id
+	}
+	if !found {
+		t.Error("queued job not listed as unfinished")
+	}
+	
+	finished := created.Add(time.Second)
+	job.Status, job.StartedAt, job.FinishedAt = StatusFailed, &created, &finished
+	job.Error = &domain.ErrorDetail{Code: 503, Message: "solve timed out"}
+	job.Request = domain.OptimizeRequest{}
+	if err := store.Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err := store.Get(ctx, id)
+	if err != nil || !ok {
+		t.Fatalf("Get = %v, %v", ok, err)
+	}
+	if got.Status != StatusFailed || got.Error == nil || got.Error.Code != 503 || got.Response != nil {
+		t.Errorf("job = %+v, want it failed with 503", got)
+	}
+	if got.Request.Truck.ID != "truck-1" || got.Request.TenantID != "acme" || got.Request.APIKey != "ops" {
+		t.Errorf("request = %+v, want it as created", got.Request)
+	}
+	if !got.CreatedAt.Equal(created) || got.FinishedAt == nil || !got.FinishedAt.Equal(finished) {
+		t.Errorf("times = %v, %v; want %v, %v", got.CreatedAt, got.FinishedAt, created, finished)
+	}
+	if _, ok, err := store.Get(ctx, id+"-missing"); ok || err != nil {
+		t.Errorf("missing job: %v, %v", ok, err)
+	}
+	
+	deleted, err := store.Delete(ctx, func(tenantID string) time.Time {
+		if tenantID == "acme" {
+			return created.Add(time.Second)
+		}
+		return time.Time{}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found = false
+	for _, job := range deleted {
+		found = found || job.ID == id
+	}
+	if !found {
+		t.Error("finished job past its cutoff not deleted")
+	}
+	if _, ok, err := store.Get(ctx, id); ok || err != nil {
+		t.Errorf("deleted job: %v, %v; want it gone", ok, err)
+	}
+}
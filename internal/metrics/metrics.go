@@ -0,0 +1,137 @@
+// Package metrics exposes the server's request and solve metrics in the
+// Prometheus text format, for dashboards and alerts on optimizer regressions.
+package metrics
+
+import (
+	"net/http"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "smartload"
+
+// Metrics keeps the server's metrics in a registry of its own, so that tests
+// and embedders can make as many as they need
+type Metrics struct {
+	registry *prometheus.Registry
+	
+	requests    *prometheus.CounterVec
+	latency     *prometheus.HistogramVec
+	solves      *prometheus.CounterVec
+	computeTime *prometheus.HistogramVec
+	payout      *prometheus.HistogramVec
+	orders      prometheus.Histogram
+}
+
+// New registers the metrics, with the Go runtime and process collectors
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests answered, by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Time taken to answer HTTP requests, by method and route.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"method", "route"}),
+		solves: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "solves_total",
+			Help:      "Completed solves, by algorithm and whether they were served from the result cache.",
+		}, []string{"algorithm", "cached"}),
+		computeTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "solve_compute_seconds",
+			Help:      "Time the algorithm searched for each solve, by algorithm. Cached responses are left out.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		}, []string{"algorithm"}),
+		payout: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "solution_payout_cents",
+			Help:      "Total payout of each plan in minor units, by currency. Sealed payouts are left out.",
+			Buckets:   prometheus.ExponentialBuckets(10000, 2, 14),
+		}, []string{"currency"}),
+		orders: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "solve_orders",
+			Help:      "Orders considered by each solve.",
+			Buckets:   []float64{1, 2, 5, 10, 15, 22, 30, 44, 50, 100, 250, 500, 1000},
+		}),
+	}
+	m.registry.MustRegister(
+		m.requests, m.latency, m.solves, m.computeTime, m.payout, m.orders,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// ObserveRequest counts an HTTP request answered with status after elapsed.
+// route is the route's pattern, such as /api/v1/history/solutions/:solutionId,
+// so that IDs in paths do not each make a series.
+func (m *Metrics) ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	m.requests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	m.latency.WithLabelValues(method, route).Observe(elapsed.Seconds())
+}
+
+// ObserveSolve counts a solve from its history record
+func (m *Metrics) ObserveSolve(record history.Record) {
+	algorithm := record.Algorithm
+	if algorithm == "" {
+		algorithm = "unknown"
+	}
+	m.solves.WithLabelValues(algorithm, strconv.FormatBool(record.CacheHit)).Inc()
+	m.orders.Observe(float64(record.OrdersConsidered))
+	if !record.CacheHit {
+		m.computeTime.WithLabelValues(algorithm).Observe(float64(record.ComputeTimeMs) / 1000)
+	}
+	if !record.PayoutRedacted {
+		m.payout.WithLabelValues(record.Currency).Observe(float64(record.TotalPayoutMinor))
+	}
+}
+
+// WatchResultCache reports the result cache's lookups from stats, which
+// returns nil when no cache is set, each time the metrics are scraped
+func (m *Metrics) WatchResultCache(stats func() *domain.ResultCacheStats) {
+	read := func(field func(*domain.ResultCacheStats) float64) func() float64 {
+		return func() float64 {
+			if current := stats(); current != nil {
+				return field(current)
+			}
+			return 0
+		}
+	}
+	m.registry.MustRegister(
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "result_cache_hits_total",
+			Help:      "Optimize requests answered from the result cache.",
+		}, read(func(s *domain.ResultCacheStats) float64 { return float64(s.Hits) })),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "result_cache_misses_total",
+			Help:      "Optimize requests solved because the result cache held no response for them.",
+		}, read(func(s *domain.ResultCacheStats) float64 { return float64(s.Misses) })),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "result_cache_hit_ratio",
+			Help:      "Share of result cache lookups that hit, since the server started.",
+		}, read(func(s *domain.ResultCacheStats) float64 { return s.HitRate })),
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(recorder.Body)
+	return string(body)
+}
+
+func TestObserveSolve(t *testing.T) {
+	m := New()
+	m.ObserveSolve(history.Record{Algorithm: "dp", ComputeTimeMs: 40, OrdersConsidered: 12, Currency: "USD", TotalPayoutMinor: 250000})
+	m.ObserveSolve(history.Record{Algorithm: "dp", CacheHit: true, OrdersConsidered: 12, Currency: "USD", TotalPayoutMinor: 250000})
+	m.ObserveSolve(history.Record{Algorithm: "greedy", ComputeTimeMs: 1, OrdersConsidered: 3, Currency: "USD", PayoutRedacted: true})
+	
+	body := scrape(t, m)
+	for _, want := range []string{
+		`smartload_solves_total{algorithm="dp",cached="false"} 1`,
+		`smartload_solves_total{algorithm="dp",cached="true"} 1`,
+		`smartload_solves_total{algorithm="greedy",cached="false"} 1`,
+		// Cache hits take no compute time, so only the solve itself is timed
+		`smartload_solve_compute_seconds_count{algorithm="dp"} 1`,
+		`smartload_solve_compute_seconds_sum{algorithm="dp"} 0.04`,
+		`smartload_solve_orders_count 3`,
+		// The redacted payout is left out
+		`smartload_solution_payout_cents_count{currency="USD"} 2`,
+		`smartload_solution_payout_cents_sum{currency="USD"} 500000`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
+
+func TestWatchResultCache(t *testing.T) {
+	m := New()
+	var stats *domain.ResultCacheStats
+	m.WatchResultCache(func() *domain.ResultCacheStats { return stats })
+	if body := scrape(t, m); !strings.Contains(body, "smartload_result_cache_hits_total 0") {
+		t.Errorf("without a cache, hits are not reported as 0:\n%s", body)
+	}
+	
+	stats = &domain.ResultCacheStats{Hits: 3, Misses: 1, HitRate: 0.75}
+	body := scrape(t, m)
+	for _, want := range []string{
+		"smartload_result_cache_hits_total 3",
+		"smartload_result_cache_misses_total 1",
+		"smartload_result_cache_hit_ratio 0.75",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics lack %s", want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaSink writes messages to a Kafka topic
+type KafkaSink struct {
+	writer *kafka.Writer
+}
+
+// ParseAcks maps the delivery guarantee names none, one and all to Kafka acks
+func ParseAcks(acks string) (kafka.RequiredAcks, error) {
+	switch strings.ToLower(acks) {
+	case "none", "0":
+		return kafka.RequireNone, nil
+	case "one", "1":
+		return kafka.RequireOne, nil
+	case "", "all", "-1":
+		return kafka.RequireAll, nil
+	default:
+		return 0, fmt.Errorf("invalid acks %q (must be none, one, or all)", acks)
+	}
+}
+
+func NewKafkaSink(brokers []string, topic string, acks kafka.RequiredAcks) *KafkaSink {
+	return &KafkaSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Topic:        topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: acks,
+			BatchTimeout: 10 * time.Millisecond,
+			MaxAttempts:  3,
+		},
+	}
+}
+
+func (k *KafkaSink) Send(ctx context.Context, messages []Message) error {
+	kafkaMessages := make([]kafka.Message, len(messages))
+	for i, msg := range messages {
+		kafkaMessages[i] = kafka.Message{Key: msg.Key, Value: msg.Value}
+	}
+	return k.writer.WriteMessages(ctx, kafkaMessages...)
+}
+
+func (k *KafkaSink) Close() error {
+	return k.writer.Close()
+}
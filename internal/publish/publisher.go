@@ -0,0 +1,166 @@
+package publish
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Message is an encoded event ready for a broker
+type Message struct {
+	Key   []byte
+	Value []byte
+}
+
+// Sink delivers batches of messages to a broker
+type Sink interface {
+	Send(ctx context.Context, messages []Message) error
+	Close() error
+}
+
+// Publisher accepts events without ever blocking the caller
+type Publisher interface {
+	Publish(key string, event interface{})
+	Close() error
+}
+
+type AsyncConfig struct {
+	// BufferSize is how many messages may wait for the broker before new ones are dropped
+	BufferSize int
+	// BatchSize is the most messages sent to the sink at once
+	BatchSize int
+	// FlushInterval is how long a partial batch may wait before being sent
+	FlushInterval time.Duration
+	// SendTimeout bounds each delivery attempt to the sink
+	SendTimeout time.Duration
+	// SchemaID enables the schema-registry wire format (magic byte + schema id) when non-zero
+	SchemaID uint32
+}
+
+// AsyncPublisher buffers events in a bounded queue drained by a background
+// worker. When the broker is slow and the queue is full, new events are
+// dropped and counted instead of stalling solves.
+type AsyncPublisher struct {
+	sink    Sink
+	config  AsyncConfig
+	queue   chan Message
+	dropped atomic.Int64
+	
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+func NewAsyncPublisher(sink Sink, config AsyncConfig) *AsyncPublisher {
+	if config.BufferSize <= 0 {
+		config.BufferSize = 1000
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.SendTimeout <= 0 {
+		config.SendTimeout = 10 * time.Second
+	}
+	
+	p := &AsyncPublisher{
+		sink:   sink,
+		config: config,
+		queue:  make(chan Message, config.BufferSize),
+		done:   make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *AsyncPublisher) Publish(key string, event interface{}) {
+	value, err := Encode(event, p.config.SchemaID)
+	if err != nil {
+		log.Printf("  Failed to encode result event: %v", err)
+		return
+	}
+	
+	select {
+	case p.queue <- Message{Key: []byte(key), Value: value}:
+	default:
+		if dropped := p.dropped.Add(1); dropped%100 == 1 {
+			log.Printf("  Result publisher queue full, %d events dropped so far", dropped)
+		}
+	}
+}
+
+// Dropped is the number of events discarded because the queue was full
+func (p *AsyncPublisher) Dropped() int64 {
+	return p.dropped.Load()
+}
+
+// Close stops accepting events, flushes what is queued and closes the sink
+func (p *AsyncPublisher) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.queue)
+	})
+	<-p.done
+	return p.sink.Close()
+}
+
+func (p *AsyncPublisher) run() {
+	defer close(p.done)
+	
+	ticker := time.NewTicker(p.config.FlushInterval)
+	defer ticker.Stop()
+	
+	batch := make([]Message, 0, p.config.BatchSize)
+	for {
+		select {
+		case msg, ok := <-p.queue:
+			if !ok {
+				p.flush(batch)
+				return
+			}
+			batch = append(batch, msg)
+			if len(batch) >= p.config.BatchSize {
+				p.flush(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			p.flush(batch)
+			batch = batch[:0]
+		}
+	}
+}
+
+func (p *AsyncPublisher) flush(batch []Message) {
+	if len(batch) == 0 {
+		return
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.SendTimeout)
+	defer cancel()
+	
+	if err := p.sink.Send(ctx, batch); err != nil {
+		p.dropped.Add(int64(len(batch)))
+		log.Printf("  Failed to publish %d result events: %v", len(batch), err)
+	}
+}
+
+// Encode serializes an event as JSON. With a non-zero schema id the payload is
+// framed in the Confluent schema-registry wire format: a zero magic byte and the
+// 4-byte big-endian schema id, followed by the JSON document.
+func Encode(event interface{}, schemaID uint32) ([]byte, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	if schemaID == 0 {
+		return payload, nil
+	}
+	
+	framed := make([]byte, 5, 5+len(payload))
+	binary.BigEndian.PutUint32(framed[1:], schemaID)
+	return append(framed, payload...), nil
+}
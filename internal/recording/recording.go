@@ -0,0 +1,125 @@
+// Package recording keeps sanitized copies of production solves so that a
+// later build can solve them again and be compared against what was answered.
+// Entries are written as NDJSON, one batch per file or object, by the sinks
+// in this package behind a publish.AsyncPublisher.
+package recording
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"smart-load/internal/domain"
+	"smart-load/internal/tenant"
+	"strings"
+	"time"
+)
+
+// MinPseudonymKeyBytes is the shortest key pseudonyms may be made under
+const MinPseudonymKeyBytes = 32
+
+// Entry is one recorded solve: the request as it was validated, the tenant
+// settings it was solved under and what was answered. Tenant IDs and shipper
+// names are replaced with pseudonyms, consistently across entries made with
+// the same key, and the API key is not kept.
+type Entry struct {
+	// ID is the solution ID of the recorded solve
+	ID         string                 `json:"id"`
+	RecordedAt time.Time              `json:"recorded_at"`
+	TenantID   string                 `json:"tenant_id,omitempty"`
+	Settings   tenant.Settings        `json:"settings"`
+	Request    domain.OptimizeRequest `json:"request"`
+	Outcome    Outcome                `json:"outcome"`
+}
+
+// Outcome is the part of a response a replay compares. Free-text fields,
+// which may name shippers, are left out.
+type Outcome struct {
+	SelectedOrderIDs         []string `json:"selected_order_ids"`
+	TotalPayoutCents         int64    `json:"total_payout_cents"`
+	NetProfitCents           int64    `json:"net_profit_cents"`
+	UtilizationWeightPercent float64  `json:"utilization_weight_percent"`
+	UtilizationVolumePercent float64  `json:"utilization_volume_percent"`
+	Recommendation           string   `json:"recommendation"`
+	Algorithm                string   `json:"algorithm,omitempty"`
+	ComputeTimeMs            int64    `json:"compute_time_ms"`
+	StoppedEarly             bool     `json:"stopped_early,omitempty"`
+}
+
+// OutcomeOf takes the compared fields of a response solved by algorithm in
+// computeTimeMs
+func OutcomeOf(response *domain.OptimizeResponse, algorithm string, computeTimeMs int64) Outcome {
+	return Outcome{
+		SelectedOrderIDs:         append([]string{}, response.SelectedOrderIDs...),
+		TotalPayoutCents:         response.TotalPayoutCents,
+		NetProfitCents:           response.NetProfitCents,
+		UtilizationWeightPercent: response.UtilizationWeightPercent,
+		UtilizationVolumePercent: response.UtilizationVolumePercent,
+		Recommendation:           response.Recommendation,
+		Algorithm:                algorithm,
+		ComputeTimeMs:            computeTimeMs,
+		StoppedEarly:             response.StoppedEarly,
+	}
+}
+
+// NewEntry sanitizes a solve for recording, with pseudonyms made under key.
+// The request and settings are copied, so the caller's are left as they were.
+// Requests with sealed payouts must not be recorded: their payouts cannot be
+// replayed without the keys.
+func NewEntry(key []byte, id string, recordedAt time.Time, request domain.OptimizeRequest, settings tenant.Settings, outcome Outcome) Entry {
+	request.Orders = append([]domain.OrderInput(nil), request.Orders...)
+	for i := range request.Orders {
+		request.Orders[i].Shipper = Pseudonym(key, "shipper", request.Orders[i].Shipper)
+	}
+	tenantID := Pseudonym(key, "tenant", request.TenantID)
+	request.TenantID, request.APIKey = "", ""
+	
+	settings.BlockedShippers = append([]string(nil), settings.BlockedShippers...)
+	for i, shipper := range settings.BlockedShippers {
+		settings.BlockedShippers[i] = Pseudonym(key, "shipper", shipper)
+	}
+	settings.PreferredShippers = append([]domain.PreferredShipper(nil), settings.PreferredShippers...)
+	for i := range settings.PreferredShippers {
+		settings.PreferredShippers[i].Shipper = Pseudonym(key, "shipper", settings.PreferredShippers[i].Shipper)
+	}
+	
+	return Entry{
+		ID:         id,
+		RecordedAt: recordedAt.UTC(),
+		TenantID:   tenantID,
+		Settings:   settings,
+		Request:    request,
+		Outcome:    outcome,
+	}
+}
+
+// Pseudonym replaces a name with a stable stand-in: the same name, ignoring
+// case and surrounding space as tenant settings do, always gets the same one
+// under the same key. The stand-in is an HMAC-SHA256 keyed by key, so without
+// the key names cannot be recovered by hashing likely candidates. Empty names
+// stay empty.
+func Pseudonym(key []byte, kind, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(kind + "\x00" + name))
+	return kind + "-" + hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// LoadPseudonymKey reads the key pseudonyms are made under from a file,
+// ignoring surrounding whitespace
+func LoadPseudonymKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read pseudonym key: %w", err)
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) < MinPseudonymKeyBytes {
+		return nil, fmt.Errorf("pseudonym key must be at least %d bytes", MinPseudonymKeyBytes)
+	}
+	return key, nil
+}
@@ -0,0 +1,188 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/publish"
+	"smart-load/internal/tenant"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// testKey makes the pseudonyms of the tests
+var testKey = []byte("recording-test-key-0123456789abcdef")
+
+func TestPseudonym(t *testing.T) {
+	if Pseudonym(testKey, "shipper", "Acme Freight") != Pseudonym(testKey, "shipper", " acme freight ") {
+		t.Error("case and surrounding space change the pseudonym")
+	}
+	if Pseudonym(testKey, "shipper", "Acme") == Pseudonym(testKey, "tenant", "Acme") {
+		t.Error("a shipper and a tenant of the same name share a pseudonym")
+	}
+	if got := Pseudonym(testKey, "shipper", "Acme"); !strings.HasPrefix(got, "shipper-") || strings.Contains(got, "Acme") {
+		t.Errorf("Pseudonym = %q", got)
+	}
+	if Pseudonym(testKey, "shipper", "  ") != "" {
+		t.Error("blank name given a pseudonym")
+	}
+	// Without the key, hashing a guessed name must not reproduce the pseudonym
+	digest := sha256.Sum256([]byte("shipper\x00acme"))
+	if Pseudonym(testKey, "shipper", "Acme") == "shipper-"+hex.EncodeToString(digest[:8]) {
+		t.Error("the pseudonym is an unkeyed hash")
+	}
+	if Pseudonym(testKey, "shipper", "Acme") == Pseudonym([]byte("another-key-0123456789abcdefghij"), "shipper", "Acme") {
+		t.Error("two keys give the same pseudonym")
+	}
+}
+
+func TestNewEntryLeavesCallerUntouched(t *testing.T) {
+	request := domain.OptimizeRequest{
+		Orders:   []domain.OrderInput{{ID: "o1", Shipper: "Globex"}},
+		TenantID: "acme",
+		APIKey:   "ops",
+	}
+	settings := tenant.Settings{
+		BlockedShippers:   []string{"Initech"},
+		PreferredShippers: []domain.PreferredShipper{{Shipper: "Globex", BonusCents: 500}},
+	}
+	entry := NewEntry(testKey, "sol-1", time.Date(2030, 1, 1, 8, 0, 0, 0, time.FixedZone("EST", -5*3600)), request, settings, Outcome{})
+	
+	if request.Orders[0].Shipper != "Globex" || settings.BlockedShippers[0] != "Initech" || settings.PreferredShippers[0].Shipper != "Globex" {
+		t.Error("NewEntry changed the caller's request or settings")
+	}
+	if entry.Request.Orders[0].Shipper != entry.Settings.PreferredShippers[0].Shipper {
+		t.Error("the same shipper got different pseudonyms in the request and the settings")
+	}
+	if entry.Request.TenantID != "" || entry.Request.APIKey != "" || entry.TenantID != Pseudonym(testKey, "tenant", "acme") {
+		t.Errorf("entry tenant %q, request tenant %q, API key %q", entry.TenantID, entry.Request.TenantID, entry.Request.APIKey)
+	}
+	if entry.RecordedAt.Location() != time.UTC || entry.RecordedAt.Hour() != 13 {
+		t.Errorf("RecordedAt = %v, want 13:00 UTC", entry.RecordedAt)
+	}
+}
+
+func encodedEntries(t *testing.T, ids ...string) []publish.Message {
+	t.Helper()
+	messages := make([]publish.Message, len(ids))
+	for i, id := range ids {
+		value, err := json.Marshal(Entry{ID: id, Outcome: Outcome{SelectedOrderIDs: []string{"o1"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages[i] = publish.Message{Key: []byte("truck-1"), Value: value}
+	}
+	return messages
+}
+
+func TestDirSinkRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	sink, err := NewDirSink(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), encodedEntries(t, "a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Send(context.Background(), encodedEntries(t, "c")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a recording"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	
+	entries, err := ReadPaths([]string{dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+		t.Errorf("read %v, want [a b c] in the order written", ids)
+	}
+}
+
+func TestReadReportsLine(t *testing.T) {
+	_, err := Read(strings.NewReader("{\"id\":\"a\"}\n\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Read error = %v, want one naming line 3", err)
+	}
+}
+
+// fakeS3 keeps the objects put to it
+type fakeS3 struct {
+	objects map[string][]byte
+}
+
+func (f *fakeS3) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	var body bytes.Buffer
+	body.ReadFrom(params.Body)
+	f.objects[aws.ToString(params.Bucket)+"/"+aws.ToString(params.Key)] = body.Bytes()
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3Sink(t *testing.T) {
+	client := &fakeS3{objects: make(map[string][]byte)}
+	sink := &S3Sink{client: client, bucket: "replays", prefix: "prod"}
+	if err := sink.Send(context.Background(), encodedEntries(t, "a", "b")); err != nil {
+		t.Fatal(err)
+	}
+	
+	if len(client.objects) != 1 {
+		t.Fatalf("put %d objects, want 1", len(client.objects))
+	}
+	for key, body := range client.objects {
+		if !strings.HasPrefix(key, "replays/prod/") || !strings.HasSuffix(key, FileExtension) {
+			t.Errorf("object key %q", key)
+		}
+		entries, err := Read(bytes.NewReader(body))
+		if err != nil || len(entries) != 2 {
+			t.Errorf("object holds %d entries, %v; want 2", len(entries), err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	recorded := Outcome{SelectedOrderIDs: []string{"a", "b"}, TotalPayoutCents: 1000, NetProfitCents: 400, Algorithm: "dp", ComputeTimeMs: 5}
+	
+	same := recorded
+	same.ComputeTimeMs = 9
+	if diff := Compare(recorded, same); diff.Changed() || diff.Worse() {
+		t.Errorf("identical plan: %+v", diff)
+	}
+	
+	replayed := Outcome{SelectedOrderIDs: []string{"b", "c"}, TotalPayoutCents: 1200, NetProfitCents: 350, Algorithm: "dp"}
+	diff := Compare(recorded, replayed)
+	if !reflect.DeepEqual(diff.Added, []string{"c"}) || !reflect.DeepEqual(diff.Removed, []string{"a"}) {
+		t.Errorf("added %v, removed %v; want [c], [a]", diff.Added, diff.Removed)
+	}
+	if diff.PayoutDeltaCents != 200 || diff.NetProfitDeltaCents != -50 || !diff.Changed() || !diff.Worse() {
+		t.Errorf("diff = %+v, want a worse net profit", diff)
+	}
+}
+
+func TestLoadPseudonymKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key")
+	os.WriteFile(path, []byte(string(testKey)+"\n"), 0o600)
+	if key, err := LoadPseudonymKey(path); err != nil || !bytes.Equal(key, testKey) {
+		t.Errorf("LoadPseudonymKey = %q, %v; want the key without its newline", key, err)
+	}
+	os.WriteFile(path, []byte("short"), 0o600)
+	if _, err := LoadPseudonymKey(path); err == nil {
+		t.Error("short key accepted")
+	}
+}
@@ -0,0 +1,138 @@
+package recording
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxEntrySize bounds one NDJSON line; a request carries at most a few
+// thousand orders
+const maxEntrySize = 64 << 20
+
+// Read decodes NDJSON entries, skipping blank lines
+func Read(r io.Reader) ([]Entry, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	var entries []Entry
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// ReadPaths reads the entries of recorded files and of every recorded file
+// under directories, in name order, which is the order they were written in
+func ReadPaths(paths []string) ([]Entry, error) {
+	var files []string
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(name string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if name == root && !d.IsDir() || !d.IsDir() && strings.HasSuffix(name, FileExtension) {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+	
+	var entries []Entry
+	for _, name := range files {
+		file, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		read, err := Read(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		entries = append(entries, read...)
+	}
+	return entries, nil
+}
+
+// Diff is how a replayed outcome differs from the recorded one. Deltas are
+// replayed minus recorded.
+type Diff struct {
+	// Added and Removed are the orders the replay selected that the recorded
+	// solve did not, and the other way round
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	
+	PayoutDeltaCents       int64   `json:"payout_delta_cents"`
+	NetProfitDeltaCents    int64   `json:"net_profit_delta_cents"`
+	UtilizationWeightDelta float64 `json:"utilization_weight_delta"`
+	UtilizationVolumeDelta float64 `json:"utilization_volume_delta"`
+	ComputeTimeDeltaMs     int64   `json:"compute_time_delta_ms"`
+	RecommendationChanged  bool    `json:"recommendation_changed,omitempty"`
+	AlgorithmChanged       bool    `json:"algorithm_changed,omitempty"`
+}
+
+// Compare diffs a replayed outcome against the recorded one
+func Compare(recorded, replayed Outcome) Diff {
+	before := make(map[string]bool, len(recorded.SelectedOrderIDs))
+	for _, id := range recorded.SelectedOrderIDs {
+		before[id] = true
+	}
+	after := make(map[string]bool, len(replayed.SelectedOrderIDs))
+	for _, id := range replayed.SelectedOrderIDs {
+		after[id] = true
+	}
+	
+	diff := Diff{
+		PayoutDeltaCents:       replayed.TotalPayoutCents - recorded.TotalPayoutCents,
+		NetProfitDeltaCents:    replayed.NetProfitCents - recorded.NetProfitCents,
+		UtilizationWeightDelta: replayed.UtilizationWeightPercent - recorded.UtilizationWeightPercent,
+		UtilizationVolumeDelta: replayed.UtilizationVolumePercent - recorded.UtilizationVolumePercent,
+		ComputeTimeDeltaMs:     replayed.ComputeTimeMs - recorded.ComputeTimeMs,
+		RecommendationChanged:  replayed.Recommendation != recorded.Recommendation,
+		AlgorithmChanged:       replayed.Algorithm != recorded.Algorithm,
+	}
+	for _, id := range replayed.SelectedOrderIDs {
+		if !before[id] {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	for _, id := range recorded.SelectedOrderIDs {
+		if !after[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	return diff
+}
+
+// Changed reports whether the replay answered differently. Compute time is
+// left out, as it differs on every run.
+func (d Diff) Changed() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 ||
+		d.PayoutDeltaCents != 0 || d.NetProfitDeltaCents != 0 ||
+		d.UtilizationWeightDelta != 0 || d.UtilizationVolumeDelta != 0 ||
+		d.RecommendationChanged || d.AlgorithmChanged
+}
+
+// Worse reports whether the replay earned less than the recorded solve, in
+// payout or in net profit
+func (d Diff) Worse() bool {
+	return d.PayoutDeltaCents < 0 || d.NetProfitDeltaCents < 0
+}
@@ -0,0 +1,95 @@
+package recording
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"smart-load/internal/publish"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// FileExtension ends the name of every file and object the sinks write
+const FileExtension = ".ndjson"
+
+// batchName names a batch after when it was written, so that names sort in
+// the order batches were written, with a sequence number breaking ties
+func batchName(now time.Time, seq int64) string {
+	return fmt.Sprintf("%s-%06d%s", now.UTC().Format("20060102T150405.000000000Z"), seq, FileExtension)
+}
+
+// ndjson joins the messages of a batch one per line
+func ndjson(messages []publish.Message) []byte {
+	var body bytes.Buffer
+	for _, message := range messages {
+		body.Write(message.Value)
+		body.WriteByte('\n')
+	}
+	return body.Bytes()
+}
+
+// DirSink writes each batch of entries to a file of its own in a directory.
+// Files appear whole: each is written under a temporary name and renamed.
+type DirSink struct {
+	dir string
+	seq atomic.Int64
+}
+
+// NewDirSink writes batches to dir, creating it if needed
+func NewDirSink(dir string) (*DirSink, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirSink{dir: dir}, nil
+}
+
+func (d *DirSink) Send(ctx context.Context, messages []publish.Message) error {
+	name := filepath.Join(d.dir, batchName(time.Now(), d.seq.Add(1)))
+	temporary := name + ".tmp"
+	if err := os.WriteFile(temporary, ndjson(messages), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(temporary, name)
+}
+
+func (d *DirSink) Close() error {
+	return nil
+}
+
+// s3Client is the part of an *s3.Client an S3Sink uses
+type s3Client interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3Sink writes each batch of entries to an object of its own in a bucket,
+// under a prefix
+type S3Sink struct {
+	client s3Client
+	bucket string
+	prefix string
+	seq    atomic.Int64
+}
+
+func NewS3Sink(client *s3.Client, bucket, prefix string) *S3Sink {
+	return &S3Sink{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3Sink) Send(ctx context.Context, messages []publish.Message) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(path.Join(s.prefix, batchName(time.Now(), s.seq.Add(1)))),
+		Body:        bytes.NewReader(ndjson(messages)),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	return err
+}
+
+func (s *S3Sink) Close() error {
+	return nil
+}
@@ -0,0 +1,155 @@
+// Package sealing implements per-tenant envelope encryption for payout fields.
+//
+// A sealed payout is "v1.<wrapped key>.<ciphertext>", each part base64url
+// without padding. A fresh 256-bit data key encrypts the payout (decimal
+// cents) with AES-256-GCM, bound to the order ID as additional data; the data
+// key is in turn encrypted with the tenant's key, bound to the tenant ID. Both
+// ciphertexts are prefixed with their 12-byte nonce.
+package sealing
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const version = "v1"
+
+// Keyring holds each tenant's key-encryption key
+type Keyring struct {
+	keys map[string][]byte
+}
+
+// NewKeyring builds a keyring from 32-byte tenant keys
+func NewKeyring(keys map[string][]byte) (*Keyring, error) {
+	for tenantID, key := range keys {
+		if len(key) != 32 {
+			return nil, fmt.Errorf("key for tenant %s must be 32 bytes, got %d", tenantID, len(key))
+		}
+	}
+	return &Keyring{keys: keys}, nil
+}
+
+// LoadKeyring reads a JSON object mapping tenant IDs to base64-encoded keys
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keyring: %w", err)
+	}
+	
+	var encoded map[string]string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("parse keyring: %w", err)
+	}
+	
+	keys := make(map[string][]byte, len(encoded))
+	for tenantID, value := range encoded {
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("decode key for tenant %s: %w", tenantID, err)
+		}
+		keys[tenantID] = key
+	}
+	return NewKeyring(keys)
+}
+
+// Seal encrypts a payout for an order under the tenant's key
+func (k *Keyring) Seal(tenantID, orderID string, cents int64) (string, error) {
+	kek, ok := k.keys[tenantID]
+	if !ok {
+		return "", fmt.Errorf("no payout key for tenant %q", tenantID)
+	}
+	
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return "", err
+	}
+	wrapped, err := encrypt(kek, dek, []byte(tenantID))
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := encrypt(dek, []byte(strconv.FormatInt(cents, 10)), []byte(orderID))
+	if err != nil {
+		return "", err
+	}
+	
+	return strings.Join([]string{
+		version,
+		base64.RawURLEncoding.EncodeToString(wrapped),
+		base64.RawURLEncoding.EncodeToString(ciphertext),
+	}, "."), nil
+}
+
+// Open decrypts a sealed payout. Errors never include the plaintext.
+func (k *Keyring) Open(tenantID, orderID, sealed string) (int64, error) {
+	kek, ok := k.keys[tenantID]
+	if !ok {
+		return 0, fmt.Errorf("no payout key for tenant %q", tenantID)
+	}
+	
+	parts := strings.Split(sealed, ".")
+	if len(parts) != 3 || parts[0] != version {
+		return 0, fmt.Errorf("sealed payout must have the form %s.<wrapped key>.<ciphertext>", version)
+	}
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("sealed payout wrapped key is not base64url")
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return 0, fmt.Errorf("sealed payout ciphertext is not base64url")
+	}
+	
+	dek, err := decrypt(kek, wrapped, []byte(tenantID))
+	if err != nil {
+		return 0, fmt.Errorf("sealed payout key cannot be unwrapped with the tenant key")
+	}
+	plaintext, err := decrypt(dek, ciphertext, []byte(orderID))
+	if err != nil {
+		return 0, fmt.Errorf("sealed payout cannot be decrypted for this order")
+	}
+	
+	cents, err := strconv.ParseInt(string(plaintext), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("sealed payout does not contain an amount in cents")
+	}
+	return cents, nil
+}
+
+func encrypt(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func decrypt(key, sealed, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
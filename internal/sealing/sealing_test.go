@@ -0,0 +1,96 @@
+package sealing
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func newTestKeyring(t *testing.T) *Keyring {
+	t.Helper()
+	keyring, err := NewKeyring(map[string][]byte{
+		"acme":   bytes.Repeat([]byte{1}, 32),
+		"globex": bytes.Repeat([]byte{2}, 32),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keyring
+}
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	keyring := newTestKeyring(t)
+	for _, cents := range []int64{0, 1, 250000, 100000000000} {
+		sealed, err := keyring.Seal("acme", "ord-1", cents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(sealed, "v1.") {
+			t.Errorf("sealed payout %q lacks the version prefix", sealed)
+		}
+		
+		opened, err := keyring.Open("acme", "ord-1", sealed)
+		if err != nil {
+			t.Fatalf("open %d: %v", cents, err)
+		}
+		if opened != cents {
+			t.Errorf("opened %d, want %d", opened, cents)
+		}
+	}
+}
+
+func TestSealIsRandomized(t *testing.T) {
+	keyring := newTestKeyring(t)
+	first, _ := keyring.Seal("acme", "ord-1", 250000)
+	second, _ := keyring.Seal("acme", "ord-1", 250000)
+	if first == second {
+		t.Errorf("sealing the same payout twice gave identical output")
+	}
+}
+
+func TestOpenRejectsMisuse(t *testing.T) {
+	keyring := newTestKeyring(t)
+	sealed, err := keyring.Seal("acme", "ord-1", 250000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(sealed, ".")
+	
+	tests := []struct {
+		name     string
+		tenantID string
+		orderID  string
+		sealed   string
+	}{
+		{"other tenant", "globex", "ord-1", sealed},
+		{"unknown tenant", "initech", "ord-1", sealed},
+		{"other order", "acme", "ord-2", sealed},
+		{"wrong version", "acme", "ord-1", "v2." + parts[1] + "." + parts[2]},
+		{"missing part", "acme", "ord-1", parts[0] + "." + parts[1]},
+		{"tampered ciphertext", "acme", "ord-1", parts[0] + "." + parts[1] + "." + flipFirst(parts[2])},
+		{"not base64", "acme", "ord-1", "v1.!!!.???"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := keyring.Open(tt.tenantID, tt.orderID, tt.sealed); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestNewKeyringRejectsShortKeys(t *testing.T) {
+	if _, err := NewKeyring(map[string][]byte{"acme": make([]byte, 16)}); err == nil {
+		t.Errorf("expected an error for a 16-byte key")
+	}
+}
+
+// flipFirst changes the first base64url character, which lies in the nonce,
+// so the ciphertext no longer authenticates
+func flipFirst(s string) string {
+	replacement := "A"
+	if s[0] == 'A' {
+		replacement = "B"
+	}
+	return replacement + s[1:]
+}
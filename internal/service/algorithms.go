@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"sync"
+	"time"
+)
+
+// benchmarkSizes are the order counts runtime curves are measured at, up to
+// each algorithm's limit
+var benchmarkSizes = []int{5, 10, 15, 20, 22, 30, 44, 50, 100, 250, 500, 1000}
+
+// benchmarkBudget bounds how long one algorithm's curve takes to measure;
+// sizes that do not fit in it are left off the curve
+const benchmarkBudget = 3 * time.Second
+
+// runtimeCurves benchmarks each algorithm once, on first request, and keeps
+// the curve for the life of the process
+type runtimeCurves struct {
+	mu     sync.Mutex
+	curves map[string]*runtimeCurve
+}
+
+type runtimeCurve struct {
+	once       sync.Once
+	points     []domain.RuntimePoint
+	measuredAt time.Time
+}
+
+// get returns the curve for name, measuring it with measure the first time
+func (r *runtimeCurves) get(name string, measure func() []domain.RuntimePoint) *runtimeCurve {
+	r.mu.Lock()
+	if r.curves == nil {
+		r.curves = make(map[string]*runtimeCurve)
+	}
+	curve, ok := r.curves[name]
+	if !ok {
+		curve = &runtimeCurve{}
+		r.curves[name] = curve
+	}
+	r.mu.Unlock()
+	
+	curve.once.Do(func() {
+		curve.points = measure()
+		curve.measuredAt = time.Now().UTC()
+	})
+	return curve
+}
+
+// AlgorithmCapabilities describes the named algorithm along with its runtime
+// curve. The curve is benchmarked on this process the first time an
+// algorithm is asked for, which takes up to benchmarkBudget; later calls
+// reuse it. It reports false when no algorithm has that name.
+func (s *OptimizerService) AlgorithmCapabilities(name string) (*domain.AlgorithmCapabilities, bool) {
+	info, ok := domain.LookupAlgorithm(name)
+	if !ok {
+		return nil, false
+	}
+	
+	curve := s.curves.get(name, func() []domain.RuntimePoint {
+		sizes := make([]int, 0, len(benchmarkSizes))
+		for _, size := range benchmarkSizes {
+			if size <= info.MaxOrders {
+				sizes = append(sizes, size)
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), benchmarkBudget)
+		defer cancel()
+		optimizer := s.selectOptimizer(&domain.OptimizationConfig{Algorithm: name}, 0)
+		return algorithm.Benchmark(ctx, optimizer, sizes, 3)
+	})
+	return &domain.AlgorithmCapabilities{
+		AlgorithmInfo: info,
+		RuntimeCurve:  curve.points,
+		MeasuredAt:    curve.measuredAt,
+	}, true
+}
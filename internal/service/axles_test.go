@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+// axlesRequest loads two 20,000 lb orders, 10 ft each, that together put
+// 42,000 lbs on 34,000 lb drive axles; "light" is picked up first, at the nose
+func axlesRequest(onViolation string) domain.OptimizeRequest {
+	request := minimumsRequest()
+	request.Truck.MaxWeightLbs = 45000
+	request.Truck.Axles = &domain.AxleInput{
+		SteerMaxLbs: 12000, DriveMaxLbs: 34000, TandemMaxLbs: 34000,
+		SteerEmptyLbs: 10000, DriveEmptyLbs: 9000, TandemEmptyLbs: 6000,
+		KingpinIn: 36, TandemIn: 516,
+		OnViolation: onViolation,
+	}
+	for i := range request.Orders {
+		request.Orders[i].WeightLbs = 20000
+		request.Orders[i].VolumeCuft = 400
+		request.Orders[i].LinearFeet = 10
+	}
+	request.Orders[1].PickupDate = "2030-01-02"
+	return request
+}
+
+func TestAxleOverloadWarns(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), axlesRequest(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both orders", response.SelectedOrderIDs)
+	}
+	want := domain.AxleLoads{SteerLbs: 10000, DriveLbs: 42000, TandemLbs: 13000}
+	if response.AxleLoads == nil || *response.AxleLoads != want {
+		t.Errorf("axle loads = %+v, want %+v", response.AxleLoads, want)
+	}
+	if len(response.Warnings) != 1 || response.Warnings[0].Code != "axle_overweight" {
+		t.Errorf("warnings = %+v, want one axle_overweight", response.Warnings)
+	}
+}
+
+func TestAxleOverloadReoptimizes(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), axlesRequest(domain.AxleViolationReoptimize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want [light]", response.SelectedOrderIDs)
+	}
+	if response.AxleLoads.DriveLbs != 28000 || len(response.Warnings) != 0 {
+		t.Errorf("axle loads %+v with warnings %+v, want a legal plan", response.AxleLoads, response.Warnings)
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"smart-load/internal/domain"
+)
+
+// MatchBackhauls pairs an outbound plan with return loads from the request's
+// pool. The outbound orders are planned together as given. The orders that
+// can ride back are then solved with the rest of the request, up to k plans
+// deep, and each plan is paired with the outbound one.
+func (s *OptimizerService) MatchBackhauls(ctx context.Context, request domain.BackhaulRequest) (*domain.BackhaulResponse, error) {
+	// Validate a copy: the solves below open sealed payouts themselves
+	pool := request.OptimizeRequest
+	pool.Orders = append([]domain.OrderInput(nil), request.Orders...)
+	if err := s.ValidateRequest(&pool); err != nil {
+		return nil, err
+	}
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	truck, orders, err := pool.ToDomain()
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	outbound := request.Outbound()
+	outboundRequest := request.OptimizeRequest
+	outboundRequest.Orders = nil
+	outboundRequest.K = 0
+	outboundRequest.MustIncludeOrderIDs = request.OutboundOrderIDs
+	outboundRequest.MustExcludeOrderIDs = nil
+	outboundRequest.MinTotalPayoutCents = 0
+	outboundRequest.MinUtilizationPercent = 0
+	outboundOrders := make([]domain.Order, 0, len(request.OutboundOrderIDs))
+	for i, input := range request.Orders {
+		if outbound[input.ID] {
+			outboundRequest.Orders = append(outboundRequest.Orders, input)
+			outboundOrders = append(outboundOrders, orders[i])
+		}
+	}
+	outboundPlan, err := s.OptimizeLoad(ctx, outboundRequest)
+	if err != nil {
+		return nil, err
+	}
+	
+	trip := domain.NewRoundTrip(truck.Position, outboundOrders)
+	response := &domain.BackhaulResponse{
+		Outbound:          outboundPlan,
+		Home:              trip.Home.Location,
+		Destination:       trip.Destination.Location,
+		CandidateOrderIDs: make([]string, 0),
+		Pairs:             make([]domain.BackhaulPair, 0),
+		PayoutRedacted:    outboundPlan.PayoutRedacted,
+	}
+	
+	excluded := make(map[string]bool, len(request.MustExcludeOrderIDs))
+	for _, id := range request.MustExcludeOrderIDs {
+		excluded[id] = true
+	}
+	backhaulRequest := request.OptimizeRequest
+	backhaulRequest.Orders = nil
+	backhaulRequest.MustIncludeOrderIDs = nil
+	backhaulRequest.MustExcludeOrderIDs = nil
+	backhaulRequest.PreviousOrderIDs = nil
+	backhaulRequest.Truck.Position = trip.Destination.Point
+	for i, input := range request.Orders {
+		if outbound[input.ID] || excluded[input.ID] || !trip.Accepts(orders[i], request.RadiusMiles) {
+			continue
+		}
+		backhaulRequest.Orders = append(backhaulRequest.Orders, input)
+		response.CandidateOrderIDs = append(response.CandidateOrderIDs, input.ID)
+	}
+	if len(backhaulRequest.Orders) == 0 {
+		return response, nil
+	}
+	
+	backhaul, err := s.OptimizeLoad(ctx, backhaulRequest)
+	if err != nil {
+		return nil, err
+	}
+	plans := backhaul.Alternatives
+	if len(plans) == 0 {
+		plans = []domain.PlanSummary{{
+			Rank:             1,
+			SelectedOrderIDs: backhaul.SelectedOrderIDs,
+			TotalPayoutCents: backhaul.TotalPayoutCents,
+			NetProfitCents:   backhaul.NetProfitCents,
+		}}
+	}
+	response.Pairs = domain.RankBackhauls(outboundPlan, plans)
+	response.Backhaul = backhaul
+	response.PayoutRedacted = response.PayoutRedacted || backhaul.PayoutRedacted
+	return response, nil
+}
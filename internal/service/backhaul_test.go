@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestMatchBackhauls(t *testing.T) {
+	order := func(id string, payout int64, origin, destination, pickup, delivery string) domain.OrderInput {
+		return domain.OrderInput{
+			ID: id, PayoutCents: payout, WeightLbs: 6000, VolumeCuft: 100,
+			Origin: origin, Destination: destination, PickupDate: pickup, DeliveryDate: delivery,
+		}
+	}
+	request := domain.BackhaulRequest{
+		OptimizeRequest: domain.OptimizeRequest{
+			Truck: domain.TruckInput{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+			K:     3,
+			Orders: []domain.OrderInput{
+				order("out", 100000, "Los Angeles, CA", "Dallas, TX", "2030-01-01", "2030-01-03"),
+				order("small", 40000, "Dallas, TX", "Los Angeles, CA", "2030-01-04", "2030-01-06"),
+				order("large", 50000, "Dallas, TX", "Los Angeles, CA", "2030-01-03", "2030-01-05"),
+				order("early", 90000, "Dallas, TX", "Los Angeles, CA", "2030-01-02", "2030-01-04"),
+				order("elsewhere", 90000, "Dallas, TX", "Houston, TX", "2030-01-04", "2030-01-05"),
+			},
+		},
+		OutboundOrderIDs: []string{"out"},
+	}
+	
+	response, err := NewOptimizerService().MatchBackhauls(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Outbound.TotalPayoutCents != 100000 || response.Home != "Los Angeles, CA" || response.Destination != "Dallas, TX" {
+		t.Fatalf("outbound %d cents from %s to %s", response.Outbound.TotalPayoutCents, response.Home, response.Destination)
+	}
+	if len(response.CandidateOrderIDs) != 2 || response.CandidateOrderIDs[0] != "small" || response.CandidateOrderIDs[1] != "large" {
+		t.Fatalf("candidates %v, want [small large]", response.CandidateOrderIDs)
+	}
+	if len(response.Pairs) != 2 {
+		t.Fatalf("got %d pairs, want one per return load", len(response.Pairs))
+	}
+	best := response.Pairs[0]
+	if best.Rank != 1 || best.BackhaulOrderIDs[0] != "large" || best.RoundTripPayoutCents != 150000 {
+		t.Errorf("best pair %+v, want large for 150000", best)
+	}
+	if response.Pairs[1].RoundTripPayoutCents != 140000 {
+		t.Errorf("second pair %+v, want small for 140000", response.Pairs[1])
+	}
+	
+	request.OutboundOrderIDs = []string{"missing"}
+	if _, err := NewOptimizerService().MatchBackhauls(context.Background(), request); err == nil {
+		t.Error("unknown outbound order accepted")
+	}
+}
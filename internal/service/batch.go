@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime"
+	"smart-load/internal/domain"
+	"sync"
+)
+
+// BatchResult is the outcome of one request of a batch
+type BatchResult struct {
+	Response *domain.OptimizeResponse
+	Err      error
+}
+
+// OptimizeBatch solves independent requests concurrently, as many at a time
+// as there are CPUs, and returns their outcomes in the order of the
+// requests. Each request is solved as OptimizeLoad would on its own, with
+// its own timeout; one failing, or panicking, leaves the others untouched.
+func (s *OptimizerService) OptimizeBatch(ctx context.Context, request domain.BatchRequest) ([]BatchResult, error) {
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	results := make([]BatchResult, len(request.Requests))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(runtime.GOMAXPROCS(0), len(request.Requests)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = s.optimizeBatchItem(ctx, request.Requests[i])
+			}
+		}()
+	}
+	for i := range request.Requests {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results, nil
+}
+
+// optimizeBatchItem solves one request of a batch, turning a panic into its
+// error so the rest of the batch still completes
+func (s *OptimizerService) optimizeBatchItem(ctx context.Context, request domain.OptimizeRequest) (result BatchResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("  Batch request for truck %s panicked: %v", request.Truck.ID, r)
+			result = BatchResult{Err: fmt.Errorf("internal error solving truck %s", request.Truck.ID)}
+		}
+	}()
+	response, err := s.OptimizeLoad(ctx, request)
+	return BatchResult{Response: response, Err: err}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestOptimizeBatch(t *testing.T) {
+	var request domain.BatchRequest
+	for i := 0; i < 12; i++ {
+		item := minimumsRequest()
+		item.Truck.ID = "truck-" + string(rune('a'+i))
+		if i%4 == 3 {
+			item.Truck.MaxWeightLbs = 0
+		}
+		request.Requests = append(request.Requests, item)
+	}
+	
+	results, err := NewOptimizerService().OptimizeBatch(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(request.Requests) {
+		t.Fatalf("got %d results for %d requests", len(results), len(request.Requests))
+	}
+	for i, result := range results {
+		if i%4 == 3 {
+			if result.Err == nil || !strings.Contains(result.Err.Error(), "validation") {
+				t.Errorf("result %d: err = %v, want a validation error", i, result.Err)
+			}
+			continue
+		}
+		if result.Err != nil {
+			t.Fatalf("result %d: %v", i, result.Err)
+		}
+		if result.Response.TruckID != request.Requests[i].Truck.ID {
+			t.Errorf("result %d is for %s, want %s", i, result.Response.TruckID, request.Requests[i].Truck.ID)
+		}
+	}
+}
+
+func TestOptimizeBatchLimits(t *testing.T) {
+	svc := NewOptimizerService()
+	if _, err := svc.OptimizeBatch(context.Background(), domain.BatchRequest{}); err == nil {
+		t.Fatal("empty batch accepted")
+	}
+	request := domain.BatchRequest{Requests: make([]domain.OptimizeRequest, domain.MaxBatchRequests+1)}
+	if _, err := svc.OptimizeBatch(context.Background(), request); err == nil || !strings.Contains(err.Error(), "validation") {
+		t.Fatalf("err = %v, want the oversized batch rejected", err)
+	}
+}
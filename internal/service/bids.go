@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"smart-load/internal/domain"
+)
+
+// OptimizeBids finds the bid vector and load with the highest expected profit
+// for spot bidding. Each bid-on order is priced at the level with the highest
+// expected payout, and the load is then optimized like any request with those
+// expectations as payouts.
+func (s *OptimizerService) OptimizeBids(ctx context.Context, request domain.BidRequest) (*domain.BidResponse, error) {
+	if err := request.Validate(s.ValidationProfile(request.TenantID)); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	chosen := request.BestBids()
+	plan, err := s.OptimizeLoad(ctx, request.ExpectedRequest(chosen, s.rounding))
+	if err != nil {
+		return nil, err
+	}
+	
+	response := &domain.BidResponse{
+		Bids:                make([]domain.ChosenBid, 0, len(chosen)),
+		ExpectedPayoutCents: plan.TotalPayoutCents,
+		Plan:                plan,
+	}
+	// The load goes out unless every bid on it loses; orders already won
+	// send it out for certain
+	lose := 1.0
+	for _, id := range plan.SelectedOrderIDs {
+		level, ok := chosen[id]
+		if !ok {
+			lose = 0
+			continue
+		}
+		lose *= 1 - level.WinProbability
+		response.Bids = append(response.Bids, domain.ChosenBid{
+			OrderID:             id,
+			PayoutCents:         level.PayoutCents,
+			WinProbability:      level.WinProbability,
+			ExpectedPayoutCents: int64(s.rounding.RoundCents(level.ExpectedPayout())),
+		})
+	}
+	response.WinAnyProbability = s.rounding.Round(1-lose, 4)
+	response.ExpectedCostCents = int64(s.rounding.RoundCents(float64(plan.CostBreakdown.TotalCents) * (1 - lose)))
+	response.ExpectedProfitCents = response.ExpectedPayoutCents - response.ExpectedCostCents
+	if plan.PayoutRedacted {
+		response.ExpectedPayoutCents, response.ExpectedProfitCents = 0, 0
+	}
+	return response, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func bidRequest() domain.BidRequest {
+	request := minimumsRequest()
+	template := request.Orders[0]
+	order := func(id string, payout int64, weight int) domain.OrderInput {
+		order := template
+		order.ID, order.PayoutCents, order.WeightLbs = id, payout, weight
+		return order
+	}
+	request.Orders = []domain.OrderInput{order("won", 60000, 3000), order("a", 0, 5000), order("b", 0, 5000)}
+	request.Truck.FixedCostCents = 10000
+	return domain.BidRequest{
+		OptimizeRequest: request,
+		Bids: []domain.OrderBids{
+			{OrderID: "a", Levels: []domain.BidLevel{{PayoutCents: 120000, WinProbability: 0.3}, {PayoutCents: 100000, WinProbability: 0.5}}},
+			{OrderID: "b", Levels: []domain.BidLevel{{PayoutCents: 80000, WinProbability: 0.8}}},
+		},
+	}
+}
+
+func TestOptimizeBids(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeBids(context.Background(), bidRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// won and b expect $1,240; a and b only $1,140
+	if !reflect.DeepEqual(response.Plan.SelectedOrderIDs, []string{"won", "b"}) && !reflect.DeepEqual(response.Plan.SelectedOrderIDs, []string{"b", "won"}) {
+		t.Fatalf("selected %v, want won and b", response.Plan.SelectedOrderIDs)
+	}
+	want := []domain.ChosenBid{{OrderID: "b", PayoutCents: 80000, WinProbability: 0.8, ExpectedPayoutCents: 64000}}
+	if !reflect.DeepEqual(response.Bids, want) {
+		t.Errorf("bids %+v, want %+v", response.Bids, want)
+	}
+	if response.WinAnyProbability != 1 || response.ExpectedPayoutCents != 124000 || response.ExpectedProfitCents != 114000 {
+		t.Errorf("expected %+v, want certain dispatch and $1,140 profit", response)
+	}
+	
+	request := bidRequest()
+	request.Orders = request.Orders[1:]
+	response, err = NewOptimizerService().OptimizeBids(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Bids) != 2 || response.Bids[0].PayoutCents+response.Bids[1].PayoutCents != 180000 {
+		t.Fatalf("bids %+v, want a at $1,000 and b at $800", response.Bids)
+	}
+	// The truck stays home only when both bids lose: 0.5 * 0.2
+	if response.WinAnyProbability != 0.9 || response.ExpectedCostCents != 9000 || response.ExpectedProfitCents != 114000-9000 {
+		t.Errorf("expected %+v, want 90%% dispatch costing $90", response)
+	}
+}
+
+func TestOptimizeBidsValidation(t *testing.T) {
+	for name, mutate := range map[string]func(*domain.BidRequest){
+		"unknown order":   func(r *domain.BidRequest) { r.Bids[0].OrderID = "missing" },
+		"payout and bids": func(r *domain.BidRequest) { r.Orders[1].PayoutCents = 1000 },
+		"no levels":       func(r *domain.BidRequest) { r.Bids[0].Levels = nil },
+		"probability":     func(r *domain.BidRequest) { r.Bids[1].Levels[0].WinProbability = 1.5 },
+		"unbid order":     func(r *domain.BidRequest) { r.Bids = r.Bids[:1] },
+	} {
+		request := bidRequest()
+		mutate(&request)
+		if _, err := NewOptimizerService().OptimizeBids(context.Background(), request); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+}
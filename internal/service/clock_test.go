@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/clock"
+	"smart-load/internal/history"
+)
+
+func TestServiceFollowsItsClock(t *testing.T) {
+	frozen := time.Date(2030, 1, 5, 9, 0, 0, 0, time.UTC)
+	adjustable := clock.NewAdjustable(clock.System{})
+	adjustable.Freeze(frozen)
+	store := history.NewMemoryStore(10)
+	s := NewOptimizerService(WithClock(adjustable), WithHistoryStore(store))
+	
+	response, err := s.OptimizeLoad(context.Background(), minimumsRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	inPast := false
+	for _, warning := range response.Warnings {
+		inPast = inPast || warning.Code == "pickup_in_past"
+	}
+	if !inPast {
+		t.Errorf("no pickup_in_past warning with the clock at %s: %+v", frozen, response.Warnings)
+	}
+	record, ok := store.Get(response.SolutionID)
+	if !ok || !record.CreatedAt.Equal(frozen) {
+		t.Errorf("history record created at %s, want %s", record.CreatedAt, frozen)
+	}
+	
+	// A week earlier the same pickups lie ahead
+	adjustable.Freeze(frozen.AddDate(0, 0, -7))
+	response, err = s.OptimizeLoad(context.Background(), minimumsRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, warning := range response.Warnings {
+		if warning.Code == "pickup_in_past" {
+			t.Errorf("pickup_in_past with the clock before the pickups: %s", warning.Message)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func commodityRequest() domain.OptimizeRequest {
+	order := func(id string, payout int64, weight int, commodity string) domain.OrderInput {
+		return domain.OrderInput{
+			ID: id, PayoutCents: payout, WeightLbs: weight, VolumeCuft: 100,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+			PickupDate: "2030-01-01", DeliveryDate: "2030-01-03",
+			IsHazmat: commodity == domain.CommodityHazmat, CommodityType: commodity,
+		}
+	}
+	return domain.OptimizeRequest{
+		TenantID: "acme",
+		Truck:    domain.TruckInput{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+		Orders: []domain.OrderInput{
+			order("general", 100000, 4000, domain.CommodityGeneral),
+			order("food", 100000, 3000, domain.CommodityFood),
+			order("hazmat", 150000, 3000, domain.CommodityHazmat),
+		},
+	}
+}
+
+func TestHazmatIsolatedByDefault(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), commodityRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected := append([]string(nil), response.SelectedOrderIDs...)
+	sort.Strings(selected)
+	if len(selected) != 2 || selected[0] != "food" || selected[1] != "general" {
+		t.Fatalf("selected %v, want [food general]", selected)
+	}
+}
+
+func TestDisabledHazmatIsolationKeepsFoodApart(t *testing.T) {
+	svc := NewOptimizerService()
+	if err := svc.SetDisabledRules("acme", []string{"route", "hazmat"}); err == nil {
+		t.Fatal("route rule disabled")
+	}
+	if err := svc.SetDisabledRules("acme", []string{"hazmat"}); err != nil {
+		t.Fatal(err)
+	}
+	
+	response, err := svc.OptimizeLoad(context.Background(), commodityRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected := append([]string(nil), response.SelectedOrderIDs...)
+	sort.Strings(selected)
+	if len(selected) != 2 || selected[0] != "general" || selected[1] != "hazmat" {
+		t.Fatalf("selected %v, want [general hazmat]", selected)
+	}
+	
+	if got := svc.DisabledRules("other"); len(got) != 0 {
+		t.Errorf("other tenant disabled %v", got)
+	}
+}
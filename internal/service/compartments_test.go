@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestCompartmentsSeparateTemperatureZones(t *testing.T) {
+	request := minimumsRequest()
+	low, high := -10.0, 0.0
+	request.Orders[0].TemperatureMinF, request.Orders[0].TemperatureMaxF = &low, &high
+	chilledLow, chilledHigh := 33.0, 38.0
+	request.Orders[1].TemperatureMinF, request.Orders[1].TemperatureMaxF = &chilledLow, &chilledHigh
+	request.Orders[1].WeightLbs = 5000
+	request.Truck.Compartments = []domain.CompartmentInput{
+		{ID: "frozen", MaxWeightLbs: 6000, MaxVolumeCuft: 500, EquipmentType: domain.EquipmentReefer},
+		{ID: "chilled", MaxWeightLbs: 5000, MaxVolumeCuft: 500, EquipmentType: domain.EquipmentReefer},
+	}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both temperature zones filled", response.SelectedOrderIDs)
+	}
+	if len(response.Compartments) != 2 || response.Compartments[0].OrderIDs[0] != "light" || response.Compartments[1].OrderIDs[0] != "heavy" {
+		t.Errorf("compartments = %+v", response.Compartments)
+	}
+	
+	// With a single reefer zone they cannot ride together, as on a
+	// single-compartment reefer
+	request.Truck.Compartments[1].EquipmentType = domain.EquipmentDry
+	request.Truck.Compartments[1].MaxWeightLbs = 6000
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Errorf("selected %v, want [light]", response.SelectedOrderIDs)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestProfitObjectiveCountsFuelAndMileage(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].Miles = 1400
+	request.Orders[1].Destination = "Phoenix, AZ"
+	request.Orders[1].Miles = 400
+	request.Truck.CostPerMileCents = 20
+	request.Truck.Fuel = &domain.FuelInput{PriceCentsPerGallon: 500, MilesPerGallon: 6.5}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("revenue objective selected %v, want [light]", response.SelectedOrderIDs)
+	}
+	// $1,076.92 of fuel and $280 of running cost outweigh the $1,000 payout
+	if cost := response.CostBreakdown; cost.FuelCents != 107692 || cost.MileageCents != 28000 || cost.TotalCents != 135692 {
+		t.Errorf("cost %+v, want fuel 107692 and mileage 28000", cost)
+	}
+	
+	request.OptimizationConfig = &domain.OptimizationConfig{Objective: "profit"}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("profit objective selected %v, want the shorter [heavy]", response.SelectedOrderIDs)
+	}
+	if response.NetProfitCents != 90000-30769-8000 {
+		t.Errorf("net profit %d, want %d", response.NetProfitCents, 90000-30769-8000)
+	}
+}
+
+func TestFuelValidation(t *testing.T) {
+	request := minimumsRequest()
+	request.Truck.Fuel = &domain.FuelInput{PriceCentsPerGallon: 500}
+	if err := request.Validate(); err == nil {
+		t.Error("fuel without miles_per_gallon accepted")
+	}
+	request.Truck.Fuel.MilesPerGallon = 7
+	request.Truck.CostPerMileCents = -1
+	if err := request.Validate(); err == nil {
+		t.Error("negative cost_per_mile_cents accepted")
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestDeadheadWeightPrefersNearbyLoads(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].OriginPoint = &domain.LatLng{Lat: 34.05, Lng: -118.24}
+	request.Orders[0].DestinationPoint = &domain.LatLng{Lat: 32.78, Lng: -96.80}
+	request.Orders[1].PayoutCents = 80000
+	request.Orders[1].Origin, request.Orders[1].Destination = "Dallas, TX", "Houston, TX"
+	request.Orders[1].OriginPoint = &domain.LatLng{Lat: 32.78, Lng: -96.80}
+	request.Orders[1].DestinationPoint = &domain.LatLng{Lat: 29.76, Lng: -95.37}
+	request.Truck.Position = &domain.LatLng{Lat: 32.78, Lng: -96.80}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want the better paying [light]", response.SelectedOrderIDs)
+	}
+	if response.Deadhead == nil || response.Deadhead.ToFirstPickupMiles < 1400 {
+		t.Fatalf("deadhead %+v, want the run to Los Angeles", response.Deadhead)
+	}
+	
+	// Reaching Los Angeles empty costs about $740 at 50 cents a mile
+	request.OptimizationConfig = &domain.OptimizationConfig{DeadheadWeight: 50}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("selected %v, want the load at the truck, [heavy]", response.SelectedOrderIDs)
+	}
+	if response.Deadhead.TotalMiles != 0 || response.Score != 80000 {
+		t.Errorf("deadhead %+v score %d, want no empty miles and no charge", response.Deadhead, response.Score)
+	}
+	
+	// Nothing pays for the trip home from Houston at $10 a mile
+	request.Truck.NextPosition = request.Truck.Position
+	request.OptimizationConfig.DeadheadWeight = 1000
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 0 {
+		t.Errorf("selected %v, want the truck left empty", response.SelectedOrderIDs)
+	}
+}
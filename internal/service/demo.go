@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"smart-load/internal/demo"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/tenant"
+	"strings"
+	"time"
+)
+
+// DemoSeed is the demo data a reset restored
+type DemoSeed struct {
+	TenantID string      `json:"tenant_id"`
+	SeededAt time.Time   `json:"seeded_at"`
+	Trucks   []DemoTruck `json:"trucks"`
+}
+
+// DemoTruck is a demo truck with the solve of its order pool and the plan
+// committed from it
+type DemoTruck struct {
+	TruckID    string            `json:"truck_id"`
+	PoolOrders int               `json:"pool_orders"`
+	SolutionID string            `json:"solution_id"`
+	Plan       *domain.PlanState `json:"plan"`
+}
+
+// DemoRequests returns the demo trucks' optimize requests, with their order
+// pools dated from the service's clock
+func (s *OptimizerService) DemoRequests() []domain.OptimizeRequest {
+	return demo.Requests(s.clock.Now())
+}
+
+// ResetDemo wipes the demo tenant's committed plans, settings and history,
+// then seeds them afresh: every demo truck's pool is solved, and the orders
+// chosen are committed as the truck's plan. Other tenants are untouched.
+func (s *OptimizerService) ResetDemo(ctx context.Context) (*DemoSeed, error) {
+	s.plans.mu.Lock()
+	for key := range s.plans.plans {
+		if strings.HasPrefix(key, planKey(demo.TenantID, "")) {
+			delete(s.plans.plans, key)
+		}
+	}
+	s.plans.mu.Unlock()
+	s.tenants.Update(demo.TenantID, func(settings *tenant.Settings) {
+		*settings = tenant.Settings{}
+	})
+	s.history.Delete(func(record history.Record) bool {
+		return record.TenantID == demo.TenantID
+	})
+	
+	seed := &DemoSeed{TenantID: demo.TenantID, SeededAt: s.clock.Now()}
+	for _, request := range s.DemoRequests() {
+		response, err := s.OptimizeLoad(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("demo truck %s: %w", request.Truck.ID, err)
+		}
+		truck := DemoTruck{TruckID: request.Truck.ID, PoolOrders: len(request.Orders), SolutionID: response.SolutionID}
+		
+		selected := make(map[string]bool, len(response.SelectedOrderIDs))
+		for _, id := range response.SelectedOrderIDs {
+			selected[id] = true
+		}
+		plan := request
+		plan.Orders = nil
+		for _, order := range request.Orders {
+			if selected[order.ID] {
+				plan.Orders = append(plan.Orders, order)
+			}
+		}
+		if truck.Plan, err = s.CommitPlan(ctx, plan); err != nil {
+			return nil, fmt.Errorf("demo truck %s: %w", request.Truck.ID, err)
+		}
+		seed.Trucks = append(seed.Trucks, truck)
+	}
+	return seed, nil
+}
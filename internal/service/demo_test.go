@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/demo"
+	"smart-load/internal/domain"
+)
+
+func TestResetDemo(t *testing.T) {
+	service := NewOptimizerService()
+	request := minimumsRequest()
+	request.Orders = request.Orders[:1]
+	request.TenantID = "acme"
+	if _, err := service.CommitPlan(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	
+	seed, err := service.ResetDemo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seed.Trucks) != len(service.DemoRequests()) {
+		t.Fatalf("seeded %d trucks", len(seed.Trucks))
+	}
+	for _, truck := range seed.Trucks {
+		if len(truck.Plan.OrderIDs) == 0 || truck.SolutionID == "" {
+			t.Errorf("truck %+v, want a solved and committed plan", truck)
+		}
+		if _, ok := service.CommittedPlan(demo.TenantID, truck.TruckID); !ok {
+			t.Errorf("no plan committed for %s", truck.TruckID)
+		}
+	}
+	
+	if err := service.SetDisabledRules(demo.TenantID, []string{domain.HazmatMatch{}.Name()}); err != nil {
+		t.Fatal(err)
+	}
+	if !service.ReleasePlan(demo.TenantID, seed.Trucks[0].TruckID) {
+		t.Fatal("demo plan not released")
+	}
+	if _, err := service.ResetDemo(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := service.CommittedPlan(demo.TenantID, seed.Trucks[0].TruckID); !ok {
+		t.Error("reset did not restore the released plan")
+	}
+	if rules := service.DisabledRules(demo.TenantID); len(rules) != 0 {
+		t.Errorf("reset kept disabled rules %v", rules)
+	}
+	if records := service.History(demo.TenantID, time.Time{}, time.Now().Add(time.Hour)); len(records) != len(seed.Trucks) {
+		t.Errorf("%d demo solves in history after two resets, want one per truck", len(records))
+	}
+	if _, ok := service.CommittedPlan("acme", request.Truck.ID); !ok {
+		t.Error("reset released another tenant's plan")
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDimFactorChargesCapacity(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[1].WeightLbs = 4000
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 || response.TotalScaleWeightLbs != 0 {
+		t.Fatalf("selected %v with scale weight %d, want both orders by actual weight", response.SelectedOrderIDs, response.TotalScaleWeightLbs)
+	}
+	
+	// 100 cuft at 30 cubic inches per pound charges each order 5760 lbs
+	request.DimFactor = 30
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.TotalWeightLbs != 5760 {
+		t.Fatalf("selected %v charging %d lbs, want one order at 5760 lbs", response.SelectedOrderIDs, response.TotalWeightLbs)
+	}
+	if response.TotalScaleWeightLbs >= response.TotalWeightLbs {
+		t.Errorf("scale weight %d not below chargeable %d", response.TotalScaleWeightLbs, response.TotalWeightLbs)
+	}
+}
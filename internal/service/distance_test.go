@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/distance"
+	"smart-load/internal/domain"
+)
+
+func TestDistanceProviderFillsMissingMiles(t *testing.T) {
+	request := minimumsRequest()
+	request.Truck.DriverPay = &domain.DriverPayInput{PerMileCents: 100}
+	matrix := distance.NewStaticMatrix([]distance.LaneMiles{{Origin: "Los Angeles, CA", Destination: "Dallas, TX", Miles: 1435}})
+	
+	response, err := NewOptimizerService(WithDistanceProvider(matrix)).OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.CostBreakdown.DriverCents != 143500 {
+		t.Errorf("driver cost %d, want 1435 measured miles at 100 cents", response.CostBreakdown.DriverCents)
+	}
+	
+	// Miles given with the order win over the provider's
+	for i := range request.Orders {
+		request.Orders[i].Miles = 1000
+	}
+	response, err = NewOptimizerService(WithDistanceProvider(matrix)).OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.CostBreakdown.DriverCents != 100000 {
+		t.Errorf("driver cost %d, want the given 1000 miles at 100 cents", response.CostBreakdown.DriverCents)
+	}
+}
+
+func TestGivenPointsAreMeasuredByHaversine(t *testing.T) {
+	request := minimumsRequest()
+	request.Truck.DriverPay = &domain.DriverPayInput{PerMileCents: 100}
+	for i := range request.Orders {
+		request.Orders[i].OriginPoint = &domain.LatLng{Lat: 34.05, Lng: -118.24}
+		request.Orders[i].DestinationPoint = &domain.LatLng{Lat: 32.78, Lng: -96.80}
+	}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// About 1240 straight-line miles, stretched by road circuity
+	if cents := response.CostBreakdown.DriverCents; cents < 1230*120 || cents > 1250*120 {
+		t.Errorf("driver cost %d, want about 1490 estimated miles at 100 cents", cents)
+	}
+}
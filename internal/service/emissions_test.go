@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestEmissionsWeightTradesPayoutForCO2(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].PayoutCents = 95000
+	request.Orders[1].PayoutCents = 100000
+	for i := range request.Orders {
+		request.Orders[i].Miles = 1400
+	}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("selected %v, want [heavy] for its payout", response.SelectedOrderIDs)
+	}
+	if response.EmissionsKgCO2 != 2143.5 {
+		t.Errorf("emissions %.1f kg, want 2143.5", response.EmissionsKgCO2)
+	}
+	
+	// At 50 cents a kilogram, heavy's extra 112 kg outweigh its extra $50
+	request.OptimizationConfig = &domain.OptimizationConfig{EmissionsWeight: 50}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want the lighter [light]", response.SelectedOrderIDs)
+	}
+	if response.TotalPayoutCents != 95000 || response.Score != 95000-6254 {
+		t.Errorf("payout %d score %d, want the payout kept and the charge taken off the score", response.TotalPayoutCents, response.Score)
+	}
+	
+	request.OptimizationConfig.EmissionsWeight = 101
+	if _, err := NewOptimizerService().OptimizeLoad(context.Background(), request); err == nil {
+		t.Error("emissions_weight above the limit accepted")
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestOrdersTheTruckCannotCarryAreExplained(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].EquipmentRequirements = []string{domain.EquipmentLiftgate}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("selected %v, want [heavy] without a liftgate", response.SelectedOrderIDs)
+	}
+	if response.Explanation == nil || len(response.Explanation.ExcludedOrders) != 1 ||
+		response.Explanation.ExcludedOrders[0] != (domain.ExcludedOrder{OrderID: "light", Reason: "truck has no liftgate"}) {
+		t.Fatalf("explanation = %+v, want light excluded for the liftgate", response.Explanation)
+	}
+	
+	request.Truck.Equipment = []string{domain.EquipmentLiftgate}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" || response.Explanation != nil {
+		t.Fatalf("selected %v explanation %+v, want [light] with nothing excluded", response.SelectedOrderIDs, response.Explanation)
+	}
+}
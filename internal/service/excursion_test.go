@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func excursionRequest() domain.OptimizeRequest {
+	order := func(id string, payout int64) domain.OrderInput {
+		return domain.OrderInput{
+			ID: id, PayoutCents: payout, WeightLbs: 5000, VolumeCuft: 100,
+			Origin: "Phoenix, AZ", Destination: "Dallas, TX", Miles: 1000,
+			PickupDate: "2030-07-01", DeliveryDate: "2030-07-02",
+		}
+	}
+	cold := 38.0
+	produce := order("produce", 200000)
+	produce.TemperatureMaxF = &cold
+	produce.MaxExposureHours = 24
+	return domain.OptimizeRequest{
+		Truck:              domain.TruckInput{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000, EquipmentType: domain.EquipmentReefer},
+		Orders:             []domain.OrderInput{produce, order("dry-1", 150000), order("dry-2", 100000)},
+		OptimizationConfig: &domain.OptimizationConfig{Objective: "revenue", RevenueWeight: 1, ExcursionWeight: 50000},
+	}
+}
+
+func TestExcursionWithinSafeExposure(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), excursionRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected := append([]string(nil), response.SelectedOrderIDs...)
+	sort.Strings(selected)
+	if len(selected) != 2 || selected[0] != "dry-1" || selected[1] != "produce" {
+		t.Fatalf("selected %v, want [dry-1 produce]", selected)
+	}
+	if response.Excursion == nil || response.Excursion.ExposureHours != 22 || response.Excursion.ExcessHours != 0 {
+		t.Fatalf("excursion %+v, want 22 hours within 24", response.Excursion)
+	}
+}
+
+func TestExcursionWeightAvoidsRiskyLane(t *testing.T) {
+	request := excursionRequest()
+	request.LaneRisks = []domain.LaneRiskInput{{Origin: "Phoenix, AZ", Destination: "Dallas, TX", RiskFactor: 1.5}}
+	
+	// Summer heat makes the run 32 hours against 24: 8 hours at $500
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selected := append([]string(nil), response.SelectedOrderIDs...)
+	sort.Strings(selected)
+	if len(selected) != 2 || selected[0] != "dry-1" || selected[1] != "dry-2" {
+		t.Fatalf("selected %v, want the dry loads [dry-1 dry-2]", selected)
+	}
+	if response.Excursion != nil {
+		t.Errorf("excursion %+v reported without sensitive freight", response.Excursion)
+	}
+	
+	request.OptimizationConfig.ExcursionWeight = 0
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Excursion == nil || response.Excursion.ExcessHours != 8 {
+		t.Fatalf("excursion %+v without a weight, want 8 hours over reported", response.Excursion)
+	}
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestFacilityWindowsLimitPickupDays(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[1].WeightLbs = 4000
+	request.Orders[1].PayoutCents = 70000
+	request.Orders[1].PickupDate = "2030-01-02"
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both orders without facility limits", response.SelectedOrderIDs)
+	}
+	
+	request.Facilities = []domain.FacilityInput{{
+		Location: "Los Angeles, CA",
+		Windows:  []domain.FacilityWindowInput{{From: "2030-01-01", To: "2030-01-02", MaxTrucks: 1}},
+	}}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want [light] with one visit allowed", response.SelectedOrderIDs)
+	}
+}
+
+func TestGroundLevelDocksNeedALiftgate(t *testing.T) {
+	request := minimumsRequest()
+	request.Facilities = []domain.FacilityInput{{Location: "Los Angeles, CA", DockDoorTypes: []string{domain.DoorGroundLevel}}}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 0 || response.Explanation == nil || len(response.Explanation.ExcludedOrders) != 2 {
+		t.Fatalf("selected %v explanation %+v, want both orders excluded", response.SelectedOrderIDs, response.Explanation)
+	}
+}
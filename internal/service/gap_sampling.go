@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"sync"
+)
+
+// maxGapChecks bounds the exact re-solves running at once; samples drawn
+// while every slot is busy are skipped rather than queued
+const maxGapChecks = 2
+
+// gapSampler re-solves a sampled fraction of heuristic answers exactly in the
+// background, measuring their true optimality gap
+type gapSampler struct {
+	rate  float64
+	slots chan struct{}
+	// running tracks the re-solves in flight
+	running sync.WaitGroup
+}
+
+// WithGapSampling re-solves rate (0 to 1) of the heuristic solves the DP can
+// check exactly, in the background after the response is built. The measured
+// gap is written to the solve's history record and reported by HealthDetails.
+func WithGapSampling(rate float64) Option {
+	return func(s *OptimizerService) {
+		s.gaps.rate = rate
+		s.gaps.slots = make(chan struct{}, maxGapChecks)
+	}
+}
+
+// gapCheckable reports whether a solve can be re-solved exactly to measure its
+// gap: a plain revenue solve, few enough orders for the DP, and an optimizer
+// adding nothing beyond pins and pair rules to its algorithm
+func gapCheckable(request *domain.OptimizeRequest, truck domain.Truck, orders []domain.Order, checker *domain.RuleEngine) bool {
+	config := request.OptimizationConfig
+	if config != nil && (config.Objective != "revenue" || config.RevenueWeight != 1.0 || config.UtilizationWeight != 0) {
+		return false
+	}
+	if config.ByPriority() || config.Stability() != 0 || config.Deadhead() > 0 || config.Excursion() > 0 {
+		return false
+	}
+	if checker.HasSetRules() || truck.StopFee > 0 || !request.Minimums().Empty() {
+		return false
+	}
+	if len(orders) > domain.MaxOrdersForAlgorithm("dp") {
+		return false
+	}
+	for _, order := range orders {
+		if order.Splittable {
+			return false
+		}
+	}
+	return true
+}
+
+// sampleGap, for a sampled solve, re-solves it exactly in the background and
+// records how far below the optimum its score was
+func (s *OptimizerService) sampleGap(
+	solutionID string,
+	truck domain.Truck,
+	orders []domain.Order,
+	pinnedIDs []string,
+	checker *domain.RuleEngine,
+	score domain.Score,
+) {
+	if s.gaps.rate <= 0 || rand.Float64() >= s.gaps.rate {
+		return
+	}
+	select {
+	case s.gaps.slots <- struct{}{}:
+	default:
+		return
+	}
+	
+	s.gaps.running.Add(1)
+	go func() {
+		defer func() {
+			<-s.gaps.slots
+			s.gaps.running.Done()
+		}()
+		
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		exact := algorithm.WithChecker(algorithm.NewDPOptimizer(), checker)
+		if len(pinnedIDs) > 0 {
+			exact = algorithm.NewPinnedOptimizer(exact, pinnedIDs)
+		}
+		result := exact.Optimize(ctx, truck, orders)
+		if ctx.Err() != nil || !result.Optimal {
+			return
+		}
+		
+		gap := s.rounding.Round(measuredGapPercent(score, result.TotalScore), 2)
+		s.history.SetMeasuredGap(solutionID, gap)
+		s.health.measured(gap)
+		log.Printf(" Measured optimality gap %.2f%% for solve %s", gap, solutionID)
+	}()
+}
+
+// measuredGapPercent is how far a score falls below the exact optimum, in
+// percent of the optimum
+func measuredGapPercent(score, optimum domain.Score) float64 {
+	if optimum <= 0 || score >= optimum {
+		return 0
+	}
+	return float64(optimum-score) / float64(optimum) * 100
+}
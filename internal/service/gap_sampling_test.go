@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestGapSamplingMeasuresHeuristicGap(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders = []domain.OrderInput{request.Orders[0], request.Orders[0], request.Orders[0]}
+	for i, order := range []struct {
+		id     string
+		payout int64
+		weight int
+	}{{"dense", 70000, 6000}, {"half-1", 50000, 5000}, {"half-2", 50000, 5000}} {
+		request.Orders[i].ID, request.Orders[i].PayoutCents, request.Orders[i].WeightLbs = order.id, order.payout, order.weight
+	}
+	request.OptimizationConfig = &domain.OptimizationConfig{Algorithm: "greedy"}
+	
+	service := NewOptimizerService(WithGapSampling(1))
+	response, err := service.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.TotalPayoutCents != 70000 {
+		t.Fatalf("greedy paid %d, want the dense order's 70000", response.TotalPayoutCents)
+	}
+	service.gaps.running.Wait()
+	
+	record, ok := service.Solve(response.SolutionID)
+	if !ok {
+		t.Fatal("solve not in history")
+	}
+	if record.MeasuredGapPercent == nil || *record.MeasuredGapPercent != 30 {
+		t.Errorf("measured gap %v, want 30%% below the exact 100000", record.MeasuredGapPercent)
+	}
+	window := service.HealthDetails().Windows[0]
+	if window.MeasuredGapSamples != 1 || window.AverageMeasuredGapPercent != 30 {
+		t.Errorf("health window %+v, want one 30%% sample", window)
+	}
+}
+
+func TestGapSamplingSkipsUncheckableSolves(t *testing.T) {
+	request := minimumsRequest()
+	request.OptimizationConfig = &domain.OptimizationConfig{Algorithm: "greedy", Objective: "profit"}
+	
+	service := NewOptimizerService(WithGapSampling(1))
+	response, err := service.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.gaps.running.Wait()
+	if record, _ := service.Solve(response.SolutionID); record.MeasuredGapPercent != nil {
+		t.Errorf("profit solve measured at %.2f%%", *record.MeasuredGapPercent)
+	}
+}
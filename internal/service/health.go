@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// healthWindows are the rolling windows solver health is reported over; the
+// first decides the status
+var healthWindows = []struct {
+	name   string
+	length time.Duration
+}{
+	{"5m", 5 * time.Minute},
+	{"1h", time.Hour},
+	{"24h", 24 * time.Hour},
+}
+
+// Thresholds past which the shortest window reports the solver DEGRADED. A
+// window with fewer than minHealthSolves solves is never degraded.
+const (
+	minHealthSolves      = 5
+	maxHealthErrorRate   = 0.05
+	maxHealthTimeoutRate = 0.10
+	maxHealthGapPercent  = 5.0
+	// maxHealthEvents bounds the memory kept for the longest window
+	maxHealthEvents = 100000
+)
+
+type healthOutcome int
+
+const (
+	solveSucceeded healthOutcome = iota
+	solveFailed
+	solveTimedOut
+	// gapMeasured is an exact re-solve of an earlier solve, not a solve
+	gapMeasured
+)
+
+type healthEvent struct {
+	at      time.Time
+	outcome healthOutcome
+	// gapPercent is NaN when the solve's gap was not measured; for
+	// gapMeasured it is the measured gap
+	gapPercent float64
+}
+
+// solverHealth keeps the outcome of recent solves, oldest first
+type solverHealth struct {
+	mu     sync.Mutex
+	events []healthEvent
+}
+
+func (h *solverHealth) add(event healthEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	
+	cutoff := event.at.Add(-healthWindows[len(healthWindows)-1].length)
+	start := 0
+	for start < len(h.events) && (h.events[start].at.Before(cutoff) || len(h.events)-start >= maxHealthEvents) {
+		start++
+	}
+	h.events = append(h.events[start:], event)
+}
+
+// succeeded records a completed solve and, unless NaN, its gap
+func (h *solverHealth) succeeded(gapPercent float64) {
+	h.add(healthEvent{at: time.Now(), outcome: solveSucceeded, gapPercent: gapPercent})
+}
+
+// measured records the gap an exact re-solve found for an earlier solve
+func (h *solverHealth) measured(gapPercent float64) {
+	h.add(healthEvent{at: time.Now(), outcome: gapMeasured, gapPercent: gapPercent})
+}
+
+// failed records a solve that returned err. Rejected requests and solves the
+// caller cancelled say nothing about the solver and are not counted.
+func (h *solverHealth) failed(err error) {
+	switch {
+	case strings.Contains(err.Error(), "validation"), errors.Is(err, context.Canceled):
+		return
+	case errors.Is(err, context.DeadlineExceeded):
+		h.add(healthEvent{at: time.Now(), outcome: solveTimedOut, gapPercent: math.NaN()})
+	default:
+		h.add(healthEvent{at: time.Now(), outcome: solveFailed, gapPercent: math.NaN()})
+	}
+}
+
+func (h *solverHealth) details(now time.Time) domain.HealthDetails {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	
+	details := domain.HealthDetails{Status: domain.HealthUp, Windows: make([]domain.HealthWindow, 0, len(healthWindows))}
+	for _, window := range healthWindows {
+		summary := domain.HealthWindow{Window: window.name}
+		cutoff := now.Add(-window.length)
+		gapTotal, measuredTotal := 0.0, 0.0
+		for _, event := range h.events {
+			if event.at.Before(cutoff) || event.at.After(now) {
+				continue
+			}
+			if event.outcome == gapMeasured {
+				summary.MeasuredGapSamples++
+				measuredTotal += event.gapPercent
+				continue
+			}
+			summary.Solves++
+			switch event.outcome {
+			case solveFailed:
+				summary.Errors++
+			case solveTimedOut:
+				summary.Timeouts++
+			}
+			if !math.IsNaN(event.gapPercent) {
+				summary.GapSamples++
+				gapTotal += event.gapPercent
+			}
+		}
+		if summary.Solves > 0 {
+			summary.ErrorRate = float64(summary.Errors) / float64(summary.Solves)
+			summary.TimeoutRate = float64(summary.Timeouts) / float64(summary.Solves)
+		}
+		if summary.GapSamples > 0 {
+			summary.AverageGapPercent = gapTotal / float64(summary.GapSamples)
+		}
+		if summary.MeasuredGapSamples > 0 {
+			summary.AverageMeasuredGapPercent = measuredTotal / float64(summary.MeasuredGapSamples)
+		}
+		details.Windows = append(details.Windows, summary)
+	}
+	
+	shortest := details.Windows[0]
+	if shortest.Solves >= minHealthSolves {
+		if shortest.ErrorRate > maxHealthErrorRate {
+			details.Reasons = append(details.Reasons, fmt.Sprintf("error rate %.1f%% over %s", shortest.ErrorRate*100, shortest.Window))
+		}
+		if shortest.TimeoutRate > maxHealthTimeoutRate {
+			details.Reasons = append(details.Reasons, fmt.Sprintf("timeout rate %.1f%% over %s", shortest.TimeoutRate*100, shortest.Window))
+		}
+		if shortest.GapSamples > 0 && shortest.AverageGapPercent > maxHealthGapPercent {
+			details.Reasons = append(details.Reasons, fmt.Sprintf("average optimality gap %.1f%% over %s", shortest.AverageGapPercent, shortest.Window))
+		}
+		if shortest.MeasuredGapSamples > 0 && shortest.AverageMeasuredGapPercent > maxHealthGapPercent {
+			details.Reasons = append(details.Reasons, fmt.Sprintf("average measured optimality gap %.1f%% over %s", shortest.AverageMeasuredGapPercent, shortest.Window))
+		}
+	}
+	if len(details.Reasons) > 0 {
+		details.Status = domain.HealthDegraded
+	}
+	return details
+}
+
+// HealthDetails reports solver health over the last 5 minutes, hour and day,
+// and the result cache's hits and misses
+func (s *OptimizerService) HealthDetails() domain.HealthDetails {
+	details := s.health.details(time.Now())
+	details.ResultCache = s.ResultCacheStats()
+	details.Shadow = s.shadowStats()
+	return details
+}
+
+// planGapPercent bounds how far below the optimum, in percent of the bound,
+// the plan's payout is. It is NaN when the plan was not chosen on revenue,
+// since the payout gap says nothing about other objectives.
+func planGapPercent(config *domain.OptimizationConfig, truck domain.Truck, orders []domain.Order, result algorithm.OptimizationResult) float64 {
+	if result.Optimal {
+		return 0
+	}
+	if config != nil && (config.Objective != "revenue" || config.UtilizationWeight != 0) {
+		return math.NaN()
+	}
+	bound := algorithm.PayoutBound(truck, orders)
+	if bound <= 0 || result.TotalPayout >= bound {
+		return 0
+	}
+	return float64(bound-result.TotalPayout) / float64(bound) * 100
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"smart-load/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestHealthDetailsCountsSolverFailuresOnly(t *testing.T) {
+	service := NewOptimizerService()
+	if _, err := service.OptimizeLoad(context.Background(), minimumsRequest()); err != nil {
+		t.Fatal(err)
+	}
+	invalid := minimumsRequest()
+	invalid.Truck.MaxWeightLbs = 0
+	if _, err := service.OptimizeLoad(context.Background(), invalid); err == nil {
+		t.Fatal("invalid request solved")
+	}
+	
+	details := service.HealthDetails()
+	if details.Status != domain.HealthUp || len(details.Windows) != 3 {
+		t.Fatalf("details = %+v", details)
+	}
+	want := domain.HealthWindow{Window: "5m", Solves: 1, GapSamples: 1}
+	if details.Windows[0] != want {
+		t.Errorf("5m window = %+v, want %+v", details.Windows[0], want)
+	}
+}
+
+func TestHealthDegradesOnTimeouts(t *testing.T) {
+	var health solverHealth
+	now := time.Now()
+	for i := 0; i < 8; i++ {
+		health.add(healthEvent{at: now.Add(-time.Minute), outcome: solveSucceeded, gapPercent: 2})
+	}
+	health.failed(fmt.Errorf("optimization aborted: %w", context.DeadlineExceeded))
+	health.failed(fmt.Errorf("optimization aborted: %w", context.DeadlineExceeded))
+	health.failed(fmt.Errorf("optimization aborted: %w", context.Canceled))
+	health.failed(errors.New("validation failed: truck max_weight_lbs must be positive"))
+	// Old enough for the hour and day windows only
+	health.add(healthEvent{at: now.Add(-30 * time.Minute), outcome: solveFailed, gapPercent: math.NaN()})
+	
+	details := health.details(now.Add(time.Second))
+	if details.Status != domain.HealthDegraded || len(details.Reasons) != 1 || details.Reasons[0] != "timeout rate 20.0% over 5m" {
+		t.Fatalf("status %s, reasons %v", details.Status, details.Reasons)
+	}
+	shortest, hour := details.Windows[0], details.Windows[1]
+	if shortest.Solves != 10 || shortest.Timeouts != 2 || shortest.Errors != 0 || shortest.GapSamples != 8 || shortest.AverageGapPercent != 2 {
+		t.Errorf("5m window = %+v", shortest)
+	}
+	if hour.Solves != 11 || hour.Errors != 1 {
+		t.Errorf("1h window = %+v", hour)
+	}
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"smart-load/internal/domain"
+	"smart-load/internal/jobs"
+	"strings"
+)
+
+// SubmitJob validates a request and queues it to be solved in the
+// background, returning the queued job. Jobs are solved as OptimizeLoad
+// would, as many at a time as there are CPUs, and their state is kept in the
+// service's job store until they are fetched with Job.
+func (s *OptimizerService) SubmitJob(ctx context.Context, request domain.OptimizeRequest) (jobs.Job, error) {
+	// Opening sealed payouts writes them into the orders; the job keeps the
+	// request as it was sent
+	check := request
+	check.Orders = append([]domain.OrderInput(nil), request.Orders...)
+	if err := s.ValidateRequest(&check); err != nil {
+		return jobs.Job{}, err
+	}
+	
+	job := jobs.Job{
+		ID:        s.ids.NewID(),
+		Status:    jobs.StatusQueued,
+		TenantID:  request.TenantID,
+		APIKey:    request.APIKey,
+		Request:   request,
+		CreatedAt: s.clock.Now().UTC(),
+	}
+	if err := s.jobs.Create(ctx, job); err != nil {
+		return jobs.Job{}, fmt.Errorf("storing job: %w", err)
+	}
+	go s.runJob(job)
+	return job, nil
+}
+
+// Job returns a job submitted by the tenant and API key; other callers'
+// jobs are not found
+func (s *OptimizerService) Job(ctx context.Context, tenantID, apiKey, id string) (jobs.Job, bool, error) {
+	job, ok, err := s.jobs.Get(ctx, id)
+	if err != nil || !ok || job.TenantID != tenantID || job.APIKey != apiKey {
+		return jobs.Job{}, false, err
+	}
+	return job, true, nil
+}
+
+// ResumeJobs queues again the jobs a previous run of the server left queued
+// or running, so that none is lost to a restart, and returns how many there
+// were
+func (s *OptimizerService) ResumeJobs(ctx context.Context) (int, error) {
+	unfinished, err := s.jobs.Unfinished(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, job := range unfinished {
+		go s.runJob(job)
+	}
+	return len(unfinished), nil
+}
+
+// runJob waits for a free slot, solves a job and stores its outcome
+func (s *OptimizerService) runJob(job jobs.Job) {
+	s.jobSlots <- struct{}{}
+	defer func() { <-s.jobSlots }()
+	
+	started := s.clock.Now().UTC()
+	job.Status, job.StartedAt = jobs.StatusRunning, &started
+	s.updateJob(job)
+	
+	response, err := s.solveJob(job.Request)
+	finished := s.clock.Now().UTC()
+	job.FinishedAt = &finished
+	if err != nil {
+		job.Status, job.Error = jobs.StatusFailed, jobError(err)
+	} else {
+		job.Status, job.Response = jobs.StatusSucceeded, response
+	}
+	s.updateJob(job)
+}
+
+// solveJob solves a job's request, turning a panic into its error so the
+// job still finishes
+func (s *OptimizerService) solveJob(request domain.OptimizeRequest) (response *domain.OptimizeResponse, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("  Job for truck %s panicked: %v", request.Truck.ID, r)
+			response, err = nil, fmt.Errorf("internal error solving truck %s", request.Truck.ID)
+		}
+	}()
+	request.Orders = append([]domain.OrderInput(nil), request.Orders...)
+	return s.OptimizeLoad(context.Background(), request)
+}
+
+func (s *OptimizerService) updateJob(job jobs.Job) {
+	if err := s.jobs.Update(context.Background(), job); err != nil {
+		log.Printf("  Failed to store job %s as %s: %v", job.ID, job.Status, err)
+	}
+}
+
+// jobError is the error a failed job reports, with the status the API would
+// have answered its request with
+func jobError(err error) *domain.ErrorDetail {
+	code := http.StatusInternalServerError
+	switch {
+	case strings.Contains(err.Error(), "validation"):
+		code = http.StatusBadRequest
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusServiceUnavailable
+	}
+	return &domain.ErrorDetail{Code: code, Message: err.Error()}
+}
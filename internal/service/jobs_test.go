@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/jobs"
+)
+
+// finishedJob polls a job until it has run
+func finishedJob(t *testing.T, svc *OptimizerService, tenantID, id string) jobs.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		job, ok, err := svc.Job(context.Background(), tenantID, "", id)
+		if err != nil || !ok {
+			t.Fatalf("Job(%s) = %v, %v", id, ok, err)
+		}
+		if job.Finished() {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("job %s did not finish", id)
+	return jobs.Job{}
+}
+
+func TestSubmitJob(t *testing.T) {
+	svc := NewOptimizerService()
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	
+	job, err := svc.SubmitJob(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.ID == "" || job.Status != jobs.StatusQueued {
+		t.Fatalf("submitted job = %+v, want it queued", job)
+	}
+	if _, ok, _ := svc.Job(context.Background(), "other", "", job.ID); ok {
+		t.Error("another tenant sees the job")
+	}
+	
+	job = finishedJob(t, svc, "acme", job.ID)
+	want, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Status != jobs.StatusSucceeded || job.Response == nil || job.Response.TotalPayoutCents != want.TotalPayoutCents {
+		t.Errorf("job = %+v, want it succeeded with payout %d", job, want.TotalPayoutCents)
+	}
+	if job.StartedAt == nil || job.FinishedAt == nil {
+		t.Errorf("job times = %v, %v; want both set", job.StartedAt, job.FinishedAt)
+	}
+	
+	request.Truck.MaxWeightLbs = -1
+	if _, err := svc.SubmitJob(context.Background(), request); err == nil {
+		t.Error("invalid request queued")
+	}
+}
+
+func TestResumeJobs(t *testing.T) {
+	store := jobs.NewMemoryStore(10)
+	request := minimumsRequest()
+	for _, job := range []jobs.Job{
+		{ID: "queued", Status: jobs.StatusQueued, Request: request},
+		{ID: "running", Status: jobs.StatusRunning, Request: request},
+		{ID: "done", Status: jobs.StatusSucceeded, Request: request},
+	} {
+		if err := store.Create(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := NewOptimizerService(WithJobStore(store))
+	
+	resumed, err := svc.ResumeJobs(context.Background())
+	if err != nil || resumed != 2 {
+		t.Fatalf("ResumeJobs = %d, %v; want 2", resumed, err)
+	}
+	for _, id := range []string{"queued", "running"} {
+		if job := finishedJob(t, svc, "", id); job.Status != jobs.StatusSucceeded {
+			t.Errorf("job %s = %+v, want it succeeded", id, job)
+		}
+	}
+}
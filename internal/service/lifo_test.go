@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+// lifoRequest has two corridor orders where the first picked up is also the
+// first delivered, so carrying both means restacking
+func lifoRequest(mode string) domain.OptimizeRequest {
+	request := minimumsRequest()
+	request.Orders[1].WeightLbs = 4000
+	request.Orders[1].Origin = "San Diego, CA"
+	request.Orders[1].Destination = "Houston, TX"
+	request.Orders[1].PickupDate = "2030-01-02"
+	request.Orders[1].DeliveryDate = "2030-01-04"
+	request.MultiStop = &domain.MultiStopInput{MaxStops: 4, LIFO: mode}
+	return request
+}
+
+func TestLIFOSoftWarns(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), lifoRequest(domain.LIFOSoft))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both orders", response.SelectedOrderIDs)
+	}
+	if len(response.LoadingSequence) != 2 || response.LoadingSequence[0] != "light" {
+		t.Errorf("loading sequence %v, want light at the nose", response.LoadingSequence)
+	}
+	restack := false
+	for _, warning := range response.Warnings {
+		restack = restack || warning.Code == "lifo_restack"
+	}
+	if !restack {
+		t.Errorf("no lifo_restack warning in %+v", response.Warnings)
+	}
+}
+
+func TestLIFOHardKeepsOrdersApart(t *testing.T) {
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), lifoRequest(domain.LIFOHard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 {
+		t.Fatalf("selected %v, want the orders kept apart", response.SelectedOrderIDs)
+	}
+	for _, warning := range response.Warnings {
+		if warning.Code == "lifo_restack" {
+			t.Errorf("restack warning on a hard lifo plan: %s", warning.Message)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func minimumsRequest() domain.OptimizeRequest {
+	order := func(id string, payout int64, weight int) domain.OrderInput {
+		return domain.OrderInput{
+			ID: id, PayoutCents: payout, WeightLbs: weight, VolumeCuft: 100,
+			Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+			PickupDate: "2030-01-01", DeliveryDate: "2030-01-03",
+		}
+	}
+	return domain.OptimizeRequest{
+		Truck: domain.TruckInput{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+		Orders: []domain.OrderInput{
+			order("light", 100000, 5000),
+			order("heavy", 90000, 9500),
+		},
+	}
+}
+
+func TestMinimumsPreferBestPlan(t *testing.T) {
+	request := minimumsRequest()
+	request.MinTotalPayoutCents = 50000
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want the best plan [light]", response.SelectedOrderIDs)
+	}
+}
+
+func TestMinimumsFallBackToFullerPlan(t *testing.T) {
+	request := minimumsRequest()
+	request.MinUtilizationPercent = 90
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("selected %v, want [heavy]", response.SelectedOrderIDs)
+	}
+	if response.NoAcceptablePlan != nil || response.Score != 90000 {
+		t.Fatalf("no_acceptable_plan = %v, score = %d", response.NoAcceptablePlan, response.Score)
+	}
+}
+
+func TestMinimumsWithNoAcceptablePlan(t *testing.T) {
+	request := minimumsRequest()
+	request.MinTotalPayoutCents = 150000
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 0 || response.TotalPayoutCents != 0 {
+		t.Fatalf("selected %v for %d cents, want an empty plan", response.SelectedOrderIDs, response.TotalPayoutCents)
+	}
+	if response.NoAcceptablePlan == nil || len(response.NoAcceptablePlan.Reasons) != 1 {
+		t.Fatalf("no_acceptable_plan = %+v", response.NoAcceptablePlan)
+	}
+	if response.Recommendation != domain.RecommendationHold {
+		t.Fatalf("recommendation = %s, want hold", response.Recommendation)
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestMultiStopCombinesCorridorOrders(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[1].WeightLbs = 4000
+	request.Orders[1].Origin = "San Diego, CA"
+	request.Orders[1].Destination = "Houston, TX"
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 {
+		t.Fatalf("selected %v across routes without multi_stop", response.SelectedOrderIDs)
+	}
+	
+	request.MultiStop = &domain.MultiStopInput{MaxStops: 4}
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := slices.Clone(response.SelectedOrderIDs)
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"heavy", "light"}) {
+		t.Fatalf("selected %v, want both corridor orders", response.SelectedOrderIDs)
+	}
+	
+	request.MultiStop.MaxStops = 3
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The repair drops orders by density, so either may stay
+	if len(response.SelectedOrderIDs) != 1 {
+		t.Fatalf("selected %v with 3 stops, want one order", response.SelectedOrderIDs)
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import "smart-load/internal/history"
+
+// SolveObserver is told of every solve the history records, such as to meter
+// them. ObserveSolve is called on the solving goroutine, so it must not block.
+type SolveObserver interface {
+	ObserveSolve(record history.Record)
+}
+
+// WithSolveObserver tells observer of every solve, including those served
+// from the result cache, once its record is in the history
+func WithSolveObserver(observer SolveObserver) Option {
+	return func(s *OptimizerService) {
+		s.observer = observer
+	}
+}
+
+// observe tells the observer, if any, of a recorded solve
+func (s *OptimizerService) observe(record history.Record) {
+	if s.observer != nil {
+		s.observer.ObserveSolve(record)
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/history"
+)
+
+// observedSolves keeps every record it is told of
+type observedSolves []history.Record
+
+func (o *observedSolves) ObserveSolve(record history.Record) {
+	*o = append(*o, record)
+}
+
+func TestSolveObserverSeesSolvesAndCacheHits(t *testing.T) {
+	var observed observedSolves
+	svc := NewOptimizerService(WithResultCache(mapCache{}), WithSolveObserver(&observed))
+	for i := 0; i < 2; i++ {
+		if _, err := svc.OptimizeLoad(context.Background(), minimumsRequest()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(observed) != 2 {
+		t.Fatalf("observed %d solves, want 2", len(observed))
+	}
+	if observed[0].CacheHit || !observed[1].CacheHit {
+		t.Errorf("cache hits observed %v, %v; want only the second", observed[0].CacheHit, observed[1].CacheHit)
+	}
+	if observed[0].Algorithm == "" || observed[1].Algorithm != observed[0].Algorithm {
+		t.Errorf("algorithms %q, %q; want the cached solve's algorithm on both", observed[0].Algorithm, observed[1].Algorithm)
+	}
+}
@@ -1,31 +1,239 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"math"
+	"runtime"
 	"smart-load/internal/algorithm"
+	"smart-load/internal/cache"
+	"smart-load/internal/clock"
 	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/ids"
+	"smart-load/internal/jobs"
+	"smart-load/internal/publish"
+	"smart-load/internal/sealing"
+	"smart-load/internal/tenant"
+	"time"
 )
 
 type OptimizerService struct {
 	optimizer algorithm.Optimizer
+	tolls     domain.TollProvider
+	geocoder  domain.Geocoder
+	distances domain.DistanceProvider
+	tenants   tenant.Store
+	history   history.Store
+	publisher publish.Publisher
+	ids       ids.Generator
+	timeout   time.Duration
+	rounding  domain.RoundingMode
+	retention domain.RetentionPolicy
+	clock     clock.Clock
+	
+	payoutKeys    *sealing.Keyring
+	requireSealed bool
+	
+	curves runtimeCurves
+	health solverHealth
+	plans  planStore
+	gaps   gapSampler
+	shadow *shadowRunner
+	runs   runRegistry
+	
+	results     cache.Store
+	resultStats resultStats
+	
+	jobs jobs.Store
+	// jobSlots bounds the jobs solved at once
+	jobSlots chan struct{}
+	
+	recorder *recorder
+	observer SolveObserver
+}
+
+// Option customizes an OptimizerService at construction time
+type Option func(*OptimizerService)
+
+// WithTollProvider prices lane tolls into plan costs
+func WithTollProvider(provider domain.TollProvider) Option {
+	return func(s *OptimizerService) {
+		s.tolls = provider
+	}
+}
+
+// WithGeocoder places order locations, so route matching and multi-stop
+// corridors can compare them by distance
+func WithGeocoder(geocoder domain.Geocoder) Option {
+	return func(s *OptimizerService) {
+		s.geocoder = geocoder
+	}
+}
+
+// WithDistanceProvider measures the lanes of orders that give no miles, so
+// costs use real driving distances
+func WithDistanceProvider(provider domain.DistanceProvider) Option {
+	return func(s *OptimizerService) {
+		s.distances = provider
+	}
+}
+
+// WithClock replaces the real clock as the source of business time: date
+// checks, history timestamps and retention cutoffs
+func WithClock(c clock.Clock) Option {
+	return func(s *OptimizerService) {
+		s.clock = c
+	}
+}
+
+// Now is the service's business time
+func (s *OptimizerService) Now() time.Time {
+	return s.clock.Now()
+}
+
+// AdjustableClock returns the service's clock when it can be frozen or
+// offset, or nil
+func (s *OptimizerService) AdjustableClock() *clock.Adjustable {
+	adjustable, _ := s.clock.(*clock.Adjustable)
+	return adjustable
+}
+
+// WithTenantStore replaces the default in-memory tenant settings store
+func WithTenantStore(store tenant.Store) Option {
+	return func(s *OptimizerService) {
+		s.tenants = store
+	}
+}
+
+// WithHistoryStore replaces the default in-memory solve history
+func WithHistoryStore(store history.Store) Option {
+	return func(s *OptimizerService) {
+		s.history = store
+	}
+}
+
+// WithJobStore replaces the default in-memory job store
+func WithJobStore(store jobs.Store) Option {
+	return func(s *OptimizerService) {
+		s.jobs = store
+	}
+}
+
+// WithResultPublisher publishes every completed solve as a ResultEvent
+func WithResultPublisher(publisher publish.Publisher) Option {
+	return func(s *OptimizerService) {
+		s.publisher = publisher
+	}
 }
 
-func NewOptimizerService() *OptimizerService {
-	return &OptimizerService{
-		optimizer: algorithm.NewHybridOptimizer(),
+// WithIDGenerator replaces the ULID generator that names solves
+func WithIDGenerator(generator ids.Generator) Option {
+	return func(s *OptimizerService) {
+		s.ids = generator
 	}
 }
 
-func NewOptimizerServiceWithAlgorithm(optimizer algorithm.Optimizer) *OptimizerService {
-	return &OptimizerService{
+// WithPayoutKeyring accepts payouts sealed under tenant keys. When required is
+// set, plaintext payout_cents is rejected so payouts never reach the service in clear.
+func WithPayoutKeyring(keyring *sealing.Keyring, required bool) Option {
+	return func(s *OptimizerService) {
+		s.payoutKeys = keyring
+		s.requireSealed = required
+	}
+}
+
+// WithResultCache serves repeated requests from store instead of solving
+// them again; see resultKey for which requests are cached
+func WithResultCache(store cache.Store) Option {
+	return func(s *OptimizerService) {
+		s.results = store
+	}
+}
+
+// WithSolveTimeout bounds how long a single optimization may run
+func WithSolveTimeout(timeout time.Duration) Option {
+	return func(s *OptimizerService) {
+		s.timeout = timeout
+	}
+}
+
+// WithRounding sets how percentages and cents in responses are rounded
+func WithRounding(mode domain.RoundingMode) Option {
+	return func(s *OptimizerService) {
+		s.rounding = mode
+	}
+}
+
+func NewOptimizerService(opts ...Option) *OptimizerService {
+	return NewOptimizerServiceWithAlgorithm(algorithm.NewHybridOptimizer(), opts...)
+}
+
+func NewOptimizerServiceWithAlgorithm(optimizer algorithm.Optimizer, opts ...Option) *OptimizerService {
+	s := &OptimizerService{
 		optimizer: optimizer,
+		tolls:     domain.NoTolls{},
+		geocoder:  domain.NoGeocoder{},
+		distances: domain.HaversineDistances{},
+		tenants:   tenant.NewMemoryStore(),
+		history:   history.NewMemoryStore(10000),
+		jobs:      jobs.NewMemoryStore(10000),
+		jobSlots:  make(chan struct{}, runtime.GOMAXPROCS(0)),
+		ids:       ids.NewULIDGenerator(),
+		timeout:   10 * time.Second,
+		rounding:  domain.RoundHalfUp,
+		clock:     clock.System{},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *OptimizerService) OptimizeLoad(request domain.OptimizeRequest) (*domain.OptimizeResponse, error) {
-	if err := request.Validate(); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+// OptimizeLoad validates and solves a request. The solve stops when ctx is
+// cancelled or the service's solve timeout elapses, returning ctx's error.
+func (s *OptimizerService) OptimizeLoad(ctx context.Context, request domain.OptimizeRequest) (*domain.OptimizeResponse, error) {
+	return s.OptimizeLoadStream(ctx, request, Stream{})
+}
+
+// Stream receives the events of a solve as it runs, redacted like the
+// response when payouts are sealed. Every field may be nil.
+type Stream struct {
+	// Started is handed the id StopRun takes, before the search starts;
+	// solves streamed without it cannot be stopped
+	Started func(runID string)
+	// Progress is handed the search's best plan so far and how far it has
+	// got, by the algorithms that report it (see algorithm.WithProgress).
+	// Portfolio members report concurrently.
+	Progress func(domain.SolveProgress)
+	// Alternative is handed each of the request's alternatives as soon as it
+	// is ranked, before the response is complete
+	Alternative func(domain.PlanSummary)
+}
+
+// OptimizeLoadStream is OptimizeLoad that hands the solve's events to stream
+// as they happen
+func (s *OptimizerService) OptimizeLoadStream(ctx context.Context, request domain.OptimizeRequest, stream Stream) (*domain.OptimizeResponse, error) {
+	response, err := s.optimizeLoad(ctx, request, stream)
+	if err != nil {
+		s.health.failed(err)
+	}
+	return response, err
+}
+
+func (s *OptimizerService) optimizeLoad(ctx context.Context, request domain.OptimizeRequest, stream Stream) (*domain.OptimizeResponse, error) {
+	if err := s.ValidateRequest(&request); err != nil {
+		return nil, err
+	}
+	key := s.resultKey(&request, stream)
+	if key != "" {
+		if response, ok := s.results.Get(ctx, key); ok {
+			s.resultStats.hits.Add(1)
+			s.recordCacheHit(request, response)
+			return response, nil
+		}
+		s.resultStats.misses.Add(1)
 	}
 	
 	truck, orders, err := request.ToDomain()
@@ -33,30 +241,376 @@ func (s *OptimizerService) OptimizeLoad(request domain.OptimizeRequest) (*domain
 		return nil, fmt.Errorf("conversion failed: %w", err)
 	}
 	
-	orders = s.preprocessOrders(*truck, orders)
-	optimizer := s.selectOptimizer(request.OptimizationConfig, len(orders))
+	s.geocode(ctx, orders)
+	s.measure(ctx, orders)
+	
+	considered := len(orders)
+	orders, duplicates, duplicateWarnings := request.SplitDuplicates(orders)
+	orders, infeasible := s.preprocessOrders(*truck, orders)
+	infeasible = append(duplicates, infeasible...)
+	orders, undockable := request.SplitDockableOrders(*truck, orders)
+	infeasible = append(infeasible, undockable...)
+	
+	orders, adjustments := applyTenantSettings(orders, s.tenants.Get(request.TenantID))
+	if request.OptimizationConfig != nil {
+		orders = domain.ChargeEmissions(orders, request.OptimizationConfig.EmissionsWeight)
+	}
+	adjustments.excluded = append(infeasible, adjustments.excluded...)
+	pins := request.Pins()
+	disabledRules := s.tenants.Get(request.TenantID).DisabledRules
+	checker := requestChecker(&request, *truck, disabledRules)
+	orders, err = pins.Apply(checker, *truck, orders)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	byPriority := request.OptimizationConfig.ByPriority()
+	// wrap layers the request's pins, rules and penalties around an
+	// algorithm; a shadow algorithm is wrapped the same way
+	wrap := func(optimizer algorithm.Optimizer) algorithm.Optimizer {
+		if byPriority {
+			optimizer = algorithm.NewPriorityOptimizer(optimizer)
+		}
+		if len(pins.Include) > 0 {
+			optimizer = algorithm.NewPinnedOptimizer(optimizer, pins.Include)
+		}
+		optimizer = algorithm.NewSplittableOptimizer(optimizer, byPriority)
+		if len(request.Rules) > 0 || len(truck.Compartments) > 0 || request.MultiStop != nil || len(disabledRules) > 0 {
+			optimizer = algorithm.WithChecker(optimizer, checker)
+		}
+		if checker.HasSetRules() {
+			optimizer = algorithm.NewSetRuleOptimizer(optimizer, checker, pins.Include, byPriority)
+		}
+		if truck.StopFee > 0 {
+			optimizer = algorithm.NewStopFeeOptimizer(optimizer, checker, truck.StopFee)
+		}
+		if stability := request.OptimizationConfig.Stability(); stability != 0 {
+			optimizer = algorithm.NewStabilityOptimizer(optimizer, request.PreviousOrderIDs, stability)
+		}
+		if weight := request.OptimizationConfig.Deadhead(); weight > 0 && (truck.Position != nil || truck.NextPosition != nil) {
+			optimizer = algorithm.NewDeadheadOptimizer(optimizer, pins.Include, weight)
+		}
+		if weight := request.OptimizationConfig.Excursion(); weight > 0 {
+			optimizer = algorithm.NewExcursionOptimizer(optimizer, pins.Include, weight)
+		}
+		return optimizer
+	}
+	optimizer := wrap(s.selectOptimizer(request.OptimizationConfig, len(orders)))
+	
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	
+	// The algorithms search under search, which a stopped run cancels while
+	// ctx carries on, so the solve still answers with the best plan so far
+	sealed := request.PayoutsSealed()
+	search, stop := context.WithCancel(ctx)
+	defer stop()
+	if stream.Started != nil {
+		runID := s.ids.NewID()
+		s.runs.add(request.TenantID, request.APIKey, runID, stop)
+		defer s.runs.remove(request.TenantID, request.APIKey, runID)
+		stream.Started(runID)
+	}
+	if stream.Progress != nil {
+		search = algorithm.WithProgress(search, progressReporter(stream.Progress, sealed))
+	}
 	
-	var result algorithm.OptimizationResult
-	if request.OptimizationConfig != nil && 
-	   (request.OptimizationConfig.RevenueWeight != 1.0 || request.OptimizationConfig.UtilizationWeight != 0) {
-		result = s.optimizeWithWeights(*truck, orders, 
-			request.OptimizationConfig.RevenueWeight, 
-			request.OptimizationConfig.UtilizationWeight)
+	// objective solves for the request's objective with an optimizer from
+	// wrap; a shadow algorithm solves the same way
+	objective := func(ctx context.Context, optimizer algorithm.Optimizer, orders []domain.Order) algorithm.OptimizationResult {
+		if request.OptimizationConfig != nil && request.OptimizationConfig.Objective == "profit" {
+			return s.optimizeForProfit(ctx, *truck, orders, optimizer, byPriority)
+		} else if request.OptimizationConfig != nil && 
+		   (request.OptimizationConfig.RevenueWeight != 1.0 || request.OptimizationConfig.UtilizationWeight != 0) {
+			return s.optimizeWithWeights(ctx, *truck, orders, optimizer,
+				request.OptimizationConfig.RevenueWeight, 
+				request.OptimizationConfig.UtilizationWeight)
+		}
+		return optimizer.Optimize(ctx, *truck, orders)
+	}
+	if request.OptimizationConfig != nil && request.OptimizationConfig.Objective == "profit" {
+		log.Printf(" Optimizing %d orders for net profit on truck %s...", len(orders), truck.ID)
 	} else {
 		log.Printf(" Optimizing %d orders for truck %s...", len(orders), truck.ID)
-		result = optimizer.Optimize(*truck, orders)
 	}
+	result := objective(search, optimizer, orders)
+	// primary is the algorithm's plan before minimums reshape it, which a
+	// shadow algorithm's plan is compared against
+	primary := result
 	
-	log.Printf(" Found solution with %d orders, $%.2f payout in %dms",
-		len(result.SelectedOrders),
-		float64(result.TotalPayout)/100,
-		result.ComputeTimeMs,
-	)
+	minimums := request.Minimums()
+	var unmet []string
+	if !minimums.Empty() && ctx.Err() == nil {
+		result, unmet = s.meetMinimums(search, *truck, orders, optimizer, request.OptimizationConfig, minimums, result, sealed)
+	}
+	
+	stopped := search.Err() != nil && ctx.Err() == nil
+	if stopped {
+		log.Printf("  Search stopped early for truck %s after %dms", truck.ID, result.ComputeTimeMs)
+		result.Optimal = false
+	}
+	if err := ctx.Err(); err != nil {
+		log.Printf("  Optimization aborted for truck %s after %dms: %v", truck.ID, result.ComputeTimeMs, err)
+		return nil, fmt.Errorf("optimization aborted: %w", err)
+	}
+	
+	if sealed {
+		log.Printf(" Found solution with %d orders, sealed payout in %dms",
+			len(result.SelectedOrders),
+			result.ComputeTimeMs,
+		)
+	} else {
+		log.Printf(" Found solution with %d orders, $%.2f payout in %dms",
+			len(result.SelectedOrders),
+			float64(result.TotalPayout)/100,
+			result.ComputeTimeMs,
+		)
+	}
+	
+	cost := s.planCost(ctx, *truck, result.SelectedOrders)
+	if !sealed && len(result.SelectedOrders) > 0 && !cost.IsWorthDispatching(result.TotalPayout) {
+		log.Printf("  Payout %s does not cover operating cost %s for truck %s",
+			result.TotalPayout.ToDollars(), cost.Total().ToDollars(), truck.ID)
+	}
 	
 	response := s.buildResponse(*truck, result)
+	response.SolutionID = s.ids.NewID()
+	response.ProblemFingerprint = request.Fingerprint()
+	response.CostBreakdown = cost
+	response.NetProfitCents = int64(cost.NetProfit(result.TotalPayout))
+	recommend := domain.Recommend
+	if sealed {
+		recommend = domain.RecommendRedacted
+	}
+	response.Recommendation, response.RecommendationReasons = recommend(
+		request.DispatchThresholds,
+		result.TotalPayout,
+		cost,
+		response.UtilizationWeightPercent,
+		response.UtilizationVolumePercent,
+	)
+	if len(unmet) > 0 {
+		log.Printf("  No plan for truck %s meets the minimums: %v", truck.ID, unmet)
+		response.NoAcceptablePlan = &domain.NoAcceptablePlan{Reasons: unmet}
+		response.RecommendationReasons = append([]string{"no acceptable plan"}, unmet...)
+	}
+	response.Explanation = adjustments.explain(result)
+	response.PlanChanges = request.PlanChanges(response.SelectedOrderIDs)
+	response.Currency = request.Currency
+	response.StoppedEarly = stopped
+	if request.K > 1 {
+		onAlternative := stream.Alternative
+		if onAlternative != nil && sealed {
+			onAlternative = func(summary domain.PlanSummary) {
+				summary.RedactPayout()
+				stream.Alternative(summary)
+			}
+		}
+		response.Alternatives = s.alternatives(ctx, *truck, orders, pins.Include, request.K, minimums, checker, onAlternative)
+	}
+	response.AxleLoads = truck.AxleLoads(result.SelectedOrders)
+	response.Stops = domain.SequenceStops(truck.Position, result.SelectedOrders)
+	response.Deadhead = domain.PlanDeadhead(*truck, result.SelectedOrders)
+	if excursion := domain.PlanExcursion(result.SelectedOrders); excursion != nil {
+		excursion.ExposureHours = s.rounding.Round(excursion.ExposureHours, 2)
+		excursion.ExcessHours = s.rounding.Round(excursion.ExcessHours, 2)
+		response.Excursion = excursion
+	}
+	if request.MultiStop != nil && request.MultiStop.LIFO != "" {
+		for _, order := range domain.LoadingSequence(result.SelectedOrders) {
+			response.LoadingSequence = append(response.LoadingSequence, order.ID)
+		}
+	}
+	if len(truck.Compartments) > 0 {
+		response.Compartments, _ = domain.AssignCompartments(truck.Compartments, result.SelectedOrders)
+	}
+	warnings := append(request.Warnings(s.clock.Now()), truck.AxleWarnings(response.AxleLoads)...)
+	warnings = append(warnings, duplicateWarnings...)
+	warnings = append(warnings, request.LIFOWarnings(result.SelectedOrders)...)
+	if len(warnings) > 0 {
+		response.Warnings = warnings
+	}
+	
+	s.recordSolve(request, considered, result, response)
+	s.record(request, result, response)
+	s.health.succeeded(planGapPercent(request.OptimizationConfig, *truck, orders, result))
+	if !result.Optimal && gapCheckable(&request, *truck, orders, checker) {
+		s.sampleGap(response.SolutionID, *truck, orders, pins.Include, checker, result.TotalScore)
+	}
+	if !sealed && !stopped {
+		s.runShadow(response.SolutionID, request.OptimizationConfig, orders, primary, wrap, objective)
+	}
+	if sealed {
+		response.RedactPayouts()
+	}
+	if key != "" && !stopped {
+		s.results.Set(ctx, key, response)
+	}
 	return response, nil
 }
 
+// alternatives ranks the k best distinct plans by score, each carrying the
+// pinned orders; plans below the minimums or breaking a set rule are
+// dropped, so fewer than k may come back. Enumeration runs over the bitmask DP table, so it is bounded like "dp".
+// Each plan is handed to emit, when set, as soon as it is ranked.
+func (s *OptimizerService) alternatives(
+	ctx context.Context,
+	truck domain.Truck,
+	orders []domain.Order,
+	pinnedIDs []string,
+	k int,
+	minimums domain.Minimums,
+	checker *domain.RuleEngine,
+	emit func(domain.PlanSummary),
+) []domain.PlanSummary {
+	fixed, residual, rest := algorithm.SplitPinned(truck, orders, pinnedIDs)
+	dp := algorithm.WithChecker(algorithm.NewDPOptimizer(), checker).(*algorithm.DPOptimizer)
+	plans := dp.TopK(ctx, residual, rest, k)
+	if len(plans) == 0 && len(fixed) > 0 {
+		plans = []algorithm.OptimizationResult{{}}
+	}
+	for i := range plans {
+		plans[i] = algorithm.AddPinned(fixed, plans[i])
+	}
+	
+	summaries := make([]domain.PlanSummary, 0, len(plans))
+	for _, plan := range plans {
+		// TopK only knows the pair rules, so plans breaking a set rule are dropped
+		if !checker.ValidateOrderSet(plan.SelectedOrders) {
+			continue
+		}
+		response := s.buildResponse(truck, plan)
+		if len(minimums.Unmet(plan.TotalPayout, response.UtilizationWeightPercent, response.UtilizationVolumePercent, false)) > 0 {
+			continue
+		}
+		summaries = append(summaries, domain.PlanSummary{
+			Rank:                     len(summaries) + 1,
+			SelectedOrderIDs:         response.SelectedOrderIDs,
+			TotalPayoutCents:         response.TotalPayoutCents,
+			TotalWeightLbs:           response.TotalWeightLbs,
+			TotalVolumeCuft:          response.TotalVolumeCuft,
+			UtilizationWeightPercent: response.UtilizationWeightPercent,
+			UtilizationVolumePercent: response.UtilizationVolumePercent,
+			NetProfitCents:           int64(s.planCost(ctx, truck, plan.SelectedOrders).NetProfit(plan.TotalPayout)),
+			EmissionsKgCO2:           response.EmissionsKgCO2,
+		})
+		if emit != nil {
+			emit(summaries[len(summaries)-1])
+		}
+	}
+	return summaries
+}
+
+// ResultEvent is published for every completed solve
+type ResultEvent struct {
+	history.Record
+	SelectedOrderIDs []string `json:"selected_order_ids"`
+}
+
+// recordSolve appends the solve to history and hands it to the result publisher
+func (s *OptimizerService) recordSolve(
+	request domain.OptimizeRequest,
+	considered int,
+	result algorithm.OptimizationResult,
+	response *domain.OptimizeResponse,
+) {
+	record := history.Record{
+		SolutionID:               response.SolutionID,
+		ProblemFingerprint:       response.ProblemFingerprint,
+		CreatedAt:                s.clock.Now().UTC(),
+		TenantID:                 request.TenantID,
+		APIKey:                   request.APIKey,
+		TruckID:                  response.TruckID,
+		Currency:                 response.Currency,
+		WeightUnit:               "lb",
+		VolumeUnit:               "ft3",
+		OrdersConsidered:         considered,
+		OrdersSelected:           len(response.SelectedOrderIDs),
+		TotalPayoutMinor:         response.TotalPayoutCents,
+		TotalCostMinor:           response.CostBreakdown.TotalCents,
+		NetProfitMinor:           response.NetProfitCents,
+		TotalWeight:              response.TotalWeightLbs,
+		TotalVolume:              response.TotalVolumeCuft,
+		UtilizationWeightPercent: response.UtilizationWeightPercent,
+		UtilizationVolumePercent: response.UtilizationVolumePercent,
+		Algorithm:                result.Algorithm,
+		Optimal:                  result.Optimal,
+		ComputeTimeMs:            result.ComputeTimeMs,
+		Recommendation:           response.Recommendation,
+	}
+	if request.PayoutsSealed() {
+		record.TotalPayoutMinor = 0
+		record.NetProfitMinor = 0
+		record.PayoutRedacted = true
+	} else {
+		for _, order := range result.SelectedOrders {
+			record.Revenue = append(record.Revenue, history.OrderRevenue{
+				OrderID:            order.ID,
+				PayoutMinor:        int64(order.Payout),
+				EarliestRecognized: order.PickupDate,
+				LatestRecognized:   order.DeliveryDate,
+			})
+		}
+	}
+	s.history.Append(record)
+	s.observe(record)
+	
+	if s.publisher != nil {
+		s.publisher.Publish(response.TruckID, ResultEvent{
+			Record:           record,
+			SelectedOrderIDs: response.SelectedOrderIDs,
+		})
+	}
+}
+
+// History returns a tenant's solve records created in [from, to)
+func (s *OptimizerService) History(tenantID string, from, to time.Time) []history.Record {
+	return s.history.List(tenantID, from, to)
+}
+
+// Solve returns the history record of a solve, if it is still kept
+func (s *OptimizerService) Solve(solutionID string) (history.Record, bool) {
+	return s.history.Get(solutionID)
+}
+
+// Duplicates reports per tenant how often problems were solved again in [from, to)
+func (s *OptimizerService) Duplicates(tenantID string, from, to time.Time) []history.Duplicates {
+	return history.SummarizeDuplicates(s.history.List(tenantID, from, to))
+}
+
+// Accruals groups the planned revenue of solves created in [from, to) by the
+// period its orders are recognized in
+func (s *OptimizerService) Accruals(tenantID string, from, to time.Time, period, basis string) []history.Accrual {
+	return history.SummarizeAccruals(s.history.List(tenantID, from, to), period, basis)
+}
+
+// Utilization summarizes solves created in [from, to) by the day, week or
+// month they were made in
+func (s *OptimizerService) Utilization(tenantID string, from, to time.Time, period string) []history.Utilization {
+	summaries := history.SummarizeUtilization(s.history.List(tenantID, from, to), period)
+	for i := range summaries {
+		summaries[i].AverageWeightUtilizationPercent = s.rounding.Round(summaries[i].AverageWeightUtilizationPercent, 2)
+		summaries[i].AverageVolumeUtilizationPercent = s.rounding.Round(summaries[i].AverageVolumeUtilizationPercent, 2)
+	}
+	return summaries
+}
+
+// Usage totals solves created in [from, to) per tenant and API key. An empty
+// tenantID or apiKey matches every tenant or key.
+func (s *OptimizerService) Usage(tenantID, apiKey string, from, to time.Time) []history.Usage {
+	records := s.history.List(tenantID, from, to)
+	if apiKey != "" {
+		matched := make([]history.Record, 0, len(records))
+		for _, record := range records {
+			if record.APIKey == apiKey {
+				matched = append(matched, record)
+			}
+		}
+		records = matched
+	}
+	return history.SummarizeUsage(records)
+}
+
 func (s *OptimizerService) selectOptimizer(config *domain.OptimizationConfig, numOrders int) algorithm.Optimizer {
 	if config == nil || config.Algorithm == "auto" {
 		return s.optimizer
@@ -69,16 +623,197 @@ func (s *OptimizerService) selectOptimizer(config *domain.OptimizationConfig, nu
 		return algorithm.NewBacktrackingOptimizer()
 	case "greedy":
 		return algorithm.NewGreedyOptimizer()
+	case "knapsack":
+		return algorithm.NewKnapsackOptimizer()
+	case "branch_and_bound":
+		return algorithm.NewBranchAndBoundOptimizer()
+	case "meet_in_the_middle":
+		return algorithm.NewMeetInTheMiddleOptimizer()
+	case "greedy+ls":
+		return algorithm.NewGreedyLocalSearchOptimizer()
+	case "regret":
+		return algorithm.NewRegretGreedyOptimizer()
+	case "grasp":
+		return algorithm.NewGRASPOptimizer()
+	case "tabu":
+		return algorithm.NewTabuSearchOptimizer(config.TabuTenure, config.TabuNeighborhood)
+	case "portfolio":
+		return algorithm.NewPortfolioOptimizer()
 	default:
 		return s.optimizer
 	}
 }
 
-func (s *OptimizerService) preprocessOrders(truck domain.Truck, orders []domain.Order) []domain.Order {
-	orders = domain.FilterFeasibleOrders(truck, orders)
+// optimizeForProfit maximizes payout minus estimated operating cost. Plan cost
+// depends on the lane driven, so each route is solved on its own and the most
+// profitable lane wins. An empty plan (profit 0) is returned when no lane pays.
+// With byPriority the lane serving the higher priority tiers wins first, even
+// at a loss, and profit only decides between lanes that tie on priority.
+func (s *OptimizerService) optimizeForProfit(
+	ctx context.Context,
+	truck domain.Truck,
+	orders []domain.Order,
+	optimizer algorithm.Optimizer,
+	byPriority bool,
+) algorithm.OptimizationResult {
+	best := algorithm.OptimizationResult{SelectedOrders: []domain.Order{}}
+	bestProfit := domain.Money(0)
+	var computeTime int64
+	
+	for _, group := range domain.GroupOrdersByRoute(orders) {
+		result := optimizer.Optimize(ctx, truck, group)
+		computeTime += result.ComputeTimeMs
+		if best.Algorithm == "" {
+			best.Algorithm = result.Algorithm
+			best.Optimal = result.Optimal
+		}
+		
+		// Scored on TotalScore so tenant bonuses still tilt the choice. The
+		// score already has stop fees taken off, which the cost counts too.
+		cost := s.planCost(ctx, truck, result.SelectedOrders)
+		profit := cost.NetProfit(result.TotalScore.Money() + domain.Money(cost.StopFeeCents))
+		if byPriority {
+			if order := algorithm.ComparePriority(result, best); order != 0 {
+				if order > 0 {
+					best = result
+					bestProfit = profit
+				}
+				continue
+			}
+		}
+		if !cost.IsWorthDispatching(result.TotalScore.Money()) {
+			continue
+		}
+		if profit > bestProfit {
+			best = result
+			bestProfit = profit
+		}
+	}
+	
+	best.ComputeTimeMs = computeTime
+	return best
+}
+
+// planCost prices a plan including the tolls of every lane it drives. A failing
+// toll lookup is logged and priced as toll-free rather than failing the solve.
+func (s *OptimizerService) planCost(ctx context.Context, truck domain.Truck, orders []domain.Order) domain.CostBreakdown {
+	tolls := domain.Money(0)
+	unavailable := make([]string, 0)
+	for _, group := range domain.GroupOrdersByRoute(orders) {
+		toll, err := s.tolls.EstimateToll(ctx, group[0].Origin, group[0].Destination)
+		if err != nil {
+			log.Printf("  Toll estimate unavailable for %s: %v", group[0].Route(), err)
+			unavailable = append(unavailable, group[0].Route())
+			continue
+		}
+		tolls = tolls.Add(toll)
+	}
+	
+	cost := domain.EstimatePlanCost(truck, orders, tolls, s.rounding)
+	if len(unavailable) > 0 {
+		cost.TollsUnavailable = unavailable
+	}
+	return cost
+}
+
+// geocode places the orders' origins and destinations the request did not
+// place itself, asking once per distinct location. A failing lookup is logged and the location is left to
+// be compared by name rather than failing the solve.
+func (s *OptimizerService) geocode(ctx context.Context, orders []domain.Order) {
+	if _, ok := s.geocoder.(domain.NoGeocoder); ok {
+		return
+	}
+	points := make(map[string]*domain.LatLng)
+	lookup := func(location string) *domain.LatLng {
+		key := domain.NormalizeAddress(location)
+		if point, ok := points[key]; ok {
+			return point
+		}
+		point, found, err := s.geocoder.Geocode(ctx, location)
+		if err != nil {
+			log.Printf("  Geocoding unavailable for %s: %v", location, err)
+		}
+		var result *domain.LatLng
+		if err == nil && found {
+			result = &point
+		}
+		points[key] = result
+		return result
+	}
+	for i := range orders {
+		if orders[i].OriginPoint == nil {
+			orders[i].OriginPoint = lookup(orders[i].Origin)
+		}
+		if orders[i].DestinationPoint == nil {
+			orders[i].DestinationPoint = lookup(orders[i].Destination)
+		}
+	}
+}
+
+// measure fills in the miles of orders that give none from the distance
+// provider, asking once per distinct lane. A lane it cannot measure keeps 0
+// miles; a failing lookup is logged rather than failing the solve.
+func (s *OptimizerService) measure(ctx context.Context, orders []domain.Order) {
+	if _, ok := s.distances.(domain.NoDistances); ok {
+		return
+	}
+	lanes := make(map[string]int)
+	for i, order := range orders {
+		if order.Miles > 0 {
+			continue
+		}
+		key := waypointKey(order.OriginWaypoint()) + "->" + waypointKey(order.DestinationWaypoint())
+		miles, ok := lanes[key]
+		if !ok {
+			driving, found, err := s.distances.DrivingMiles(ctx, order.OriginWaypoint(), order.DestinationWaypoint())
+			if err != nil {
+				log.Printf("  Distance unavailable for %s: %v", order.Route(), err)
+			}
+			if err == nil && found {
+				miles = int(math.Round(driving))
+			}
+			lanes[key] = miles
+		}
+		orders[i].Miles = miles
+	}
+}
+
+// waypointKey identifies a waypoint by its point when it has one, since two
+// orders may give one name different coordinates
+func waypointKey(waypoint domain.Waypoint) string {
+	if waypoint.Point != nil {
+		return fmt.Sprintf("%.5f,%.5f", waypoint.Point.Lat, waypoint.Point.Lng)
+	}
+	return domain.NormalizeAddress(waypoint.Location)
+}
+
+// requestChecker is the rule engine a request's plans must satisfy: the
+// default and registered rules less those the tenant disabled, adjusted for
+// the truck, plus the request's own
+func requestChecker(request *domain.OptimizeRequest, truck domain.Truck, disabledRules []string) *domain.RuleEngine {
+	pairRules, setRules := request.RuleSet()
+	checker := domain.NewConstraintChecker()
+	for _, name := range disabledRules {
+		checker = checker.Without(name)
+	}
+	if len(truck.Compartments) > 0 {
+		// Compartments keep temperature zones apart; CompartmentFit checks them
+		checker = checker.Without(domain.TemperatureMatch{}.Name())
+	}
+	if request.MultiStop != nil {
+		// CorridorMatch from the request's rules takes the route rule's place
+		checker = checker.Without(domain.RouteMatch{}.Name())
+	}
+	return checker.With(pairRules, setRules)
+}
+
+// preprocessOrders drops the orders the truck cannot carry, returning them
+// with the reason so the response can explain their absence
+func (s *OptimizerService) preprocessOrders(truck domain.Truck, orders []domain.Order) ([]domain.Order, []domain.ExcludedOrder) {
+	orders, infeasible := domain.SplitFeasibleOrders(truck, orders)
 	
 	if len(orders) == 0 {
-		return orders
+		return orders, infeasible
 	}
 	
 	hazmat, nonHazmat := domain.SeparateHazmatOrders(orders)
@@ -88,7 +823,7 @@ func (s *OptimizerService) preprocessOrders(truck domain.Truck, orders []domain.
 			len(hazmat), len(nonHazmat))
 	}
 	
-	return orders
+	return orders, infeasible
 }
 
 func (s *OptimizerService) buildResponse(truck domain.Truck, result algorithm.OptimizationResult) *domain.OptimizeResponse {
@@ -107,22 +842,68 @@ func (s *OptimizerService) buildResponse(truck domain.Truck, result algorithm.Op
 		utilizationVolume = (float64(result.TotalVolume) / float64(truck.MaxVolumeCuft)) * 100
 	}
 	
-	utilizationWeight = roundToTwoDecimals(utilizationWeight)
-	utilizationVolume = roundToTwoDecimals(utilizationVolume)
+	utilizationWeight = s.rounding.Round(utilizationWeight, 2)
+	utilizationVolume = s.rounding.Round(utilizationVolume, 2)
+	floor := domain.TotalFloorSpace(result.SelectedOrders)
+	scaleWeight := domain.TotalScaleWeight(result.SelectedOrders)
+	if scaleWeight == result.TotalWeight {
+		scaleWeight = 0
+	}
 	
 	return &domain.OptimizeResponse{
 		TruckID:                  truck.ID,
 		SelectedOrderIDs:         orderIDs,
 		TotalPayoutCents:         int64(result.TotalPayout),
 		TotalWeightLbs:           result.TotalWeight,
+		TotalScaleWeightLbs:      scaleWeight,
 		TotalVolumeCuft:          result.TotalVolume,
+		TotalLinearFeet:          floor.LinearFeet,
+		TotalPallets:             domain.TotalPallets(result.SelectedOrders),
+		TotalPalletPositions:     floor.PalletPositions(),
 		UtilizationWeightPercent: utilizationWeight,
 		UtilizationVolumePercent: utilizationVolume,
+		FixedCostCents:           int64(truck.FixedCost),
+		Score:                    int64(result.TotalScore.Money()),
+		EmissionsKgCO2:           s.rounding.Round(domain.PlanEmissionsKg(result.SelectedOrders), 1),
+		PartialOrders:            partialOrders(result, s.rounding),
+		Portfolio:                portfolioOutcomes(result),
 	}
 }
 
-func roundToTwoDecimals(value float64) float64 {
-	return float64(int(value*100+0.5)) / 100
+// portfolioOutcomes lists how each algorithm of a portfolio solve fared
+func portfolioOutcomes(result algorithm.OptimizationResult) []domain.AlgorithmOutcome {
+	var outcomes []domain.AlgorithmOutcome
+	for _, outcome := range result.Portfolio {
+		outcomes = append(outcomes, domain.AlgorithmOutcome{
+			Algorithm:      outcome.Algorithm,
+			Status:         outcome.Status,
+			Selected:       outcome.Selected,
+			Optimal:        outcome.Optimal,
+			OrdersSelected: outcome.OrderCount,
+			Score:          int64(outcome.TotalScore.Money()),
+			ComputeTimeMs:  outcome.ComputeTimeMs,
+		})
+	}
+	return outcomes
+}
+
+// partialOrders lists the orders a plan loads in part, in plan order
+func partialOrders(result algorithm.OptimizationResult, rounding domain.RoundingMode) []domain.PartialOrder {
+	var parts []domain.PartialOrder
+	for _, order := range result.SelectedOrders {
+		fraction, ok := result.Fractions[order.ID]
+		if !ok {
+			continue
+		}
+		parts = append(parts, domain.PartialOrder{
+			OrderID:     order.ID,
+			Fraction:    rounding.Round(fraction, 4),
+			WeightLbs:   order.WeightLbs,
+			VolumeCuft:  order.VolumeCuft,
+			PayoutCents: int64(order.Payout),
+		})
+	}
+	return parts
 }
 
 type ParetoSolution struct {
@@ -133,13 +914,137 @@ type ParetoSolution struct {
 	UtilizationWeightPercent float64  `json:"utilization_weight_percent"`
 	UtilizationVolumePercent float64  `json:"utilization_volume_percent"`
 	Score                    float64  `json:"score"`
+	EmissionsKgCO2           float64  `json:"emissions_kg_co2"`
+}
+
+// RedactPayout zeroes the amounts derived from sealed payouts
+func (p *ParetoSolution) RedactPayout() {
+	p.TotalPayoutCents = 0
+	p.Score = 0
 }
 
+// GetParetoOptimalSolutions returns plans trading payout against utilization,
+// fullest first. Up to 22 orders the frontier is exact (epsilon-constraint over
+// the DP table) and evenly thinned to maxSolutions; beyond that it is sampled
+// with weighted objectives. The boolean reports whether the frontier is exact.
+// Every plan carries the pinned orders and none of the excluded ones. The
+// tenant's blocked shippers, bonuses and disabled rules apply as they do to
+// optimizeLoad.
 func (s *OptimizerService) GetParetoOptimalSolutions(
+	ctx context.Context,
+	tenantID string,
+	truck domain.Truck,
+	orders []domain.Order,
+	pins domain.Pins,
+	maxSolutions int,
+) ([]ParetoSolution, bool, error) {
+	return s.StreamParetoSolutions(ctx, tenantID, truck, orders, pins, maxSolutions, nil)
+}
+
+// StreamParetoSolutions is GetParetoOptimalSolutions that hands each plan to
+// emit, when set, as soon as it is found. Exact frontiers emit exactly the
+// plans returned. Sampled frontiers emit every distinct plan a weighting
+// finds, and a later plan can dominate one already emitted; the returned
+// slice is the final frontier.
+func (s *OptimizerService) StreamParetoSolutions(
+	ctx context.Context,
+	tenantID string,
+	truck domain.Truck,
+	orders []domain.Order,
+	pins domain.Pins,
+	maxSolutions int,
+	emit func(ParetoSolution),
+) ([]ParetoSolution, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	
+	s.geocode(ctx, orders)
+	s.measure(ctx, orders)
+	settings := s.tenants.Get(tenantID)
+	orders, _ = applyTenantSettings(domain.FilterFeasibleOrders(truck, orders), settings)
+	checker := domain.NewConstraintChecker()
+	for _, name := range settings.DisabledRules {
+		checker = checker.Without(name)
+	}
+	orders, err := pins.Apply(checker, truck, orders)
+	if err != nil {
+		return nil, false, fmt.Errorf("validation failed: %w", err)
+	}
+	fixed, residual, rest := algorithm.SplitPinned(truck, orders, pins.Include)
+	
+	if len(rest) > domain.MaxOrdersForAlgorithm("dp") {
+		optimizer := algorithm.WithChecker(s.optimizer, checker)
+		if len(fixed) > 0 {
+			optimizer = algorithm.NewPinnedOptimizer(optimizer, pins.Include)
+		}
+		solutions, err := s.sampleParetoSolutions(ctx, truck, orders, optimizer, maxSolutions, emit)
+		return solutions, false, err
+	}
+	
+	dp := algorithm.WithChecker(algorithm.NewDPOptimizer(), checker).(*algorithm.DPOptimizer)
+	frontier, ok := dp.ParetoFrontier(ctx, residual, rest)
+	if !ok {
+		return nil, false, fmt.Errorf("optimization aborted: %w", ctx.Err())
+	}
+	// Any residual order adds payout and fill, so the pinned orders alone are
+	// only on the frontier when nothing else fits
+	if len(frontier) == 0 && len(fixed) > 0 {
+		frontier = []algorithm.OptimizationResult{{}}
+	}
+	for i := range frontier {
+		frontier[i] = algorithm.AddPinned(fixed, frontier[i])
+	}
+	
+	solutions := make([]ParetoSolution, 0, maxSolutions)
+	for _, i := range spreadIndices(len(frontier), maxSolutions) {
+		response := s.buildResponse(truck, frontier[i])
+		solutions = append(solutions, ParetoSolution{
+			OrderIDs:                 response.SelectedOrderIDs,
+			TotalPayoutCents:         response.TotalPayoutCents,
+			TotalWeightLbs:           response.TotalWeightLbs,
+			TotalVolumeCuft:          response.TotalVolumeCuft,
+			UtilizationWeightPercent: response.UtilizationWeightPercent,
+			UtilizationVolumePercent: response.UtilizationVolumePercent,
+			Score:                    float64(response.TotalPayoutCents),
+			EmissionsKgCO2:           response.EmissionsKgCO2,
+		})
+		if emit != nil {
+			emit(solutions[len(solutions)-1])
+		}
+	}
+	return solutions, true, nil
+}
+
+// spreadIndices picks up to limit evenly spaced indices of n, keeping both ends
+func spreadIndices(n, limit int) []int {
+	if n <= limit {
+		indices := make([]int, n)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	if limit == 1 {
+		return []int{0}
+	}
+	
+	indices := make([]int, limit)
+	for i := range indices {
+		indices[i] = i * (n - 1) / (limit - 1)
+	}
+	return indices
+}
+
+// sampleParetoSolutions approximates the frontier by solving a handful of
+// revenue/utilization weightings and dropping dominated plans
+func (s *OptimizerService) sampleParetoSolutions(
+	ctx context.Context,
 	truck domain.Truck,
 	orders []domain.Order,
+	optimizer algorithm.Optimizer,
 	maxSolutions int,
-) []ParetoSolution {
+	emit func(ParetoSolution),
+) ([]ParetoSolution, error) {
 	solutions := make([]ParetoSolution, 0)
 	seen := make(map[string]bool)
 	
@@ -152,7 +1057,10 @@ func (s *OptimizerService) GetParetoOptimalSolutions(
 	}
 	
 	for _, w := range weights {
-		result := s.optimizeWithWeights(truck, orders, w.revenue, w.utilization)
+		result := s.optimizeWithWeights(ctx, truck, orders, optimizer, w.revenue, w.utilization)
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("optimization aborted: %w", err)
+		}
 		
 		key := ""
 		for _, order := range result.SelectedOrders {
@@ -186,38 +1094,112 @@ func (s *OptimizerService) GetParetoOptimalSolutions(
 			TotalPayoutCents:         int64(result.TotalPayout),
 			TotalWeightLbs:           result.TotalWeight,
 			TotalVolumeCuft:          result.TotalVolume,
-			UtilizationWeightPercent: roundToTwoDecimals(weightUtil),
-			UtilizationVolumePercent: roundToTwoDecimals(volumeUtil),
-			Score:                    roundToTwoDecimals(score),
+			UtilizationWeightPercent: s.rounding.Round(weightUtil, 2),
+			UtilizationVolumePercent: s.rounding.Round(volumeUtil, 2),
+			Score:                    s.rounding.Round(score, 2),
+			EmissionsKgCO2:           s.rounding.Round(domain.PlanEmissionsKg(result.SelectedOrders), 1),
 		})
+		if emit != nil {
+			emit(solutions[len(solutions)-1])
+		}
 		
 		if len(solutions) >= maxSolutions {
 			break
 		}
 	}
 	
-	return s.filterParetoOptimal(solutions)
+	return s.filterParetoOptimal(solutions), nil
 }
 
 func (s *OptimizerService) optimizeWithWeights(
+	ctx context.Context,
 	truck domain.Truck,
 	orders []domain.Order,
+	optimizer algorithm.Optimizer,
 	revenueWeight float64,
 	utilizationWeight float64,
 ) algorithm.OptimizationResult {
+	return optimizer.Optimize(ctx, truck, weighOrders(truck, orders, revenueWeight, utilizationWeight))
+}
+
+// weighOrders rewrites order scores as a blend of revenue and utilization.
+// Only Score is rewritten, so results still report true payouts. The blend
+// is computed in fixed point, so large payouts keep every digit.
+func weighOrders(truck domain.Truck, orders []domain.Order, revenueWeight, utilizationWeight float64) []domain.Order {
 	weighted := make([]domain.Order, len(orders))
 	copy(weighted, orders)
 	
+	revenue := domain.WeightFromFloat(revenueWeight)
+	utilization := domain.WeightFromFloat(utilizationWeight)
+	// Filling the truck is worth as much as $100 of revenue
+	fullTruck := domain.ScoreFromMoney(10000)
 	for i := range weighted {
-		revenue := float64(weighted[i].Payout)
-		utilization := (float64(weighted[i].WeightLbs)/float64(truck.MaxWeightLbs) + 
-		               float64(weighted[i].VolumeCuft)/float64(truck.MaxVolumeCuft)) / 2
-		
-		score := revenueWeight*revenue + utilizationWeight*utilization*10000
-		weighted[i].Payout = domain.Money(score)
+		// The mean of the weight and volume shares, as one ratio so neither
+		// share is rounded on its own
+		share := fullTruck.MulRatio(
+			int64(weighted[i].WeightLbs)*int64(truck.MaxVolumeCuft)+int64(weighted[i].VolumeCuft)*int64(truck.MaxWeightLbs),
+			2*int64(truck.MaxWeightLbs)*int64(truck.MaxVolumeCuft))
+		weighted[i].Score = weighted[i].Score.Mul(revenue).Add(share.Mul(utilization))
+	}
+	
+	return weighted
+}
+
+// meetMinimums returns result if it meets the request's minimums. Otherwise
+// the solve is repeated with the objective shifted step by step toward
+// utilization, which is what usually fails, and the highest-payout plan that
+// passes wins, scored under the request's own objective. When none passes,
+// an empty plan comes back with the reasons the original plan failed.
+func (s *OptimizerService) meetMinimums(
+	ctx context.Context,
+	truck domain.Truck,
+	orders []domain.Order,
+	optimizer algorithm.Optimizer,
+	config *domain.OptimizationConfig,
+	minimums domain.Minimums,
+	result algorithm.OptimizationResult,
+	redacted bool,
+) (algorithm.OptimizationResult, []string) {
+	unmet := func(plan algorithm.OptimizationResult) []string {
+		response := s.buildResponse(truck, plan)
+		return minimums.Unmet(plan.TotalPayout, response.UtilizationWeightPercent, response.UtilizationVolumePercent, redacted)
+	}
+	reasons := unmet(result)
+	if len(reasons) == 0 {
+		return result, nil
 	}
 	
-	return s.optimizer.Optimize(truck, weighted)
+	revenueWeight, utilizationWeight := 1.0, 0.0
+	if config != nil {
+		revenueWeight, utilizationWeight = config.RevenueWeight, config.UtilizationWeight
+	}
+	computeTime := result.ComputeTimeMs
+	var best *algorithm.OptimizationResult
+	for _, shift := range []float64{0.25, 0.5, 0.75, 1} {
+		candidate := s.optimizeWithWeights(ctx, truck, orders, optimizer,
+			revenueWeight*(1-shift), utilizationWeight+(1-utilizationWeight)*shift)
+		computeTime += candidate.ComputeTimeMs
+		if ctx.Err() != nil {
+			break
+		}
+		if len(unmet(candidate)) == 0 && (best == nil || candidate.TotalPayout > best.TotalPayout) {
+			best = &candidate
+		}
+	}
+	
+	if best == nil {
+		empty := algorithm.OptimizationResult{SelectedOrders: []domain.Order{}, Algorithm: result.Algorithm}
+		empty.ComputeTimeMs = computeTime
+		return empty, reasons
+	}
+	objective := orders
+	if revenueWeight != 1.0 || utilizationWeight != 0 {
+		objective = weighOrders(truck, orders, revenueWeight, utilizationWeight)
+	}
+	passing := algorithm.Rescore(*best, objective)
+	passing.Optimal = false
+	passing.ComputeTimeMs = computeTime
+	return passing, nil
 }
 
 func (s *OptimizerService) filterParetoOptimal(solutions []ParetoSolution) []ParetoSolution {
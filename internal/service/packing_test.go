@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPackingRejectsLoadsThatFitOnlyByCube(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].WeightLbs = 4000
+	request.Orders[1].WeightLbs = 4000
+	request.Orders[1].PayoutCents = 70000
+	for i := range request.Orders {
+		request.Orders[i].LengthIn, request.Orders[i].WidthIn, request.Orders[i].HeightIn = 400, 60, 60
+	}
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both orders without an interior", response.SelectedOrderIDs)
+	}
+	
+	// Side by side the orders need 120 inches of width, end to end 800 of length
+	request.Truck.InteriorLengthIn, request.Truck.InteriorWidthIn, request.Truck.InteriorHeightIn = 636, 100, 110
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want only [light] to pack", response.SelectedOrderIDs)
+	}
+}
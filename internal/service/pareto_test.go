@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParetoSolutionsSkipBlockedShippers(t *testing.T) {
+	svc := NewOptimizerService()
+	if err := svc.BlockShipper("acme", "Blocked Co"); err != nil {
+		t.Fatal(err)
+	}
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	request.Orders[0].Shipper = "blocked co"
+	truck, orders, err := request.ToDomain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	solutions, exact, err := svc.GetParetoOptimalSolutions(context.Background(), request.TenantID, *truck, orders, request.Pins(), 20)
+	if err != nil || !exact {
+		t.Fatalf("exact %v, err %v", exact, err)
+	}
+	for _, solution := range solutions {
+		for _, id := range solution.OrderIDs {
+			if id == "light" {
+				t.Errorf("solution %v loads the blocked shipper's order", solution.OrderIDs)
+			}
+		}
+	}
+	
+	// Other tenants do not block the shipper
+	solutions, _, err = svc.GetParetoOptimalSolutions(context.Background(), "other", *truck, orders, request.Pins(), 20)
+	if err != nil || len(solutions) == 0 || solutions[len(solutions)-1].OrderIDs[0] != "light" {
+		t.Errorf("other tenant's solutions %+v, %v; want the best-paying plan [light]", solutions, err)
+	}
+}
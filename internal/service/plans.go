@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"smart-load/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// committedPlan is a truck's committed plan with the request it was committed
+// under, whose truck, rules and facilities later orders are checked against.
+// Entries are never changed; adding an order replaces the entry.
+type committedPlan struct {
+	request     domain.OptimizeRequest
+	plan        *domain.CommittedPlan
+	committedAt time.Time
+	updatedAt   time.Time
+}
+
+func (p *committedPlan) state() *domain.PlanState {
+	orderIDs := make([]string, len(p.plan.Orders))
+	for i, order := range p.plan.Orders {
+		orderIDs[i] = order.ID
+	}
+	return &domain.PlanState{
+		TruckID:     p.plan.Truck.ID,
+		OrderIDs:    orderIDs,
+		Remaining:   p.plan.Remaining(),
+		CommittedAt: p.committedAt,
+		UpdatedAt:   p.updatedAt,
+	}
+}
+
+// planStore keeps the committed plans of every tenant's trucks in memory
+type planStore struct {
+	mu    sync.RWMutex
+	plans map[string]*committedPlan
+}
+
+func planKey(tenantID, truckID string) string {
+	return tenantID + "\x00" + truckID
+}
+
+func (p *planStore) get(tenantID, truckID string) (*committedPlan, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	plan, ok := p.plans[planKey(tenantID, truckID)]
+	return plan, ok
+}
+
+// CommitPlan makes the request's orders the committed plan of its truck,
+// replacing any plan committed before. The orders must fit the truck together
+// under the request's rules; the truck, rules and facilities then apply to
+// every order checked against the plan.
+func (s *OptimizerService) CommitPlan(ctx context.Context, request domain.OptimizeRequest) (*domain.PlanState, error) {
+	if err := s.ValidateRequest(&request); err != nil {
+		return nil, err
+	}
+	truck, orders, err := request.ToDomain()
+	if err != nil {
+		return nil, fmt.Errorf("conversion failed: %w", err)
+	}
+	s.geocode(ctx, orders)
+	s.measure(ctx, orders)
+	
+	now := s.clock.Now()
+	entry := &committedPlan{
+		request:     request,
+		plan:        domain.NewCommittedPlan(*truck, requestChecker(&request, *truck, s.tenants.Get(request.TenantID).DisabledRules)),
+		committedAt: now,
+		updatedAt:   now,
+	}
+	for _, order := range orders {
+		if reasons := s.additionBlockers(entry, order); len(reasons) > 0 {
+			return nil, fmt.Errorf("validation failed: order %s cannot be committed: %s", order.ID, strings.Join(reasons, "; "))
+		}
+		entry.plan = entry.plan.With(order)
+	}
+	// Later orders are converted one at a time; the committed ones are done
+	entry.request.Orders = nil
+	
+	s.plans.mu.Lock()
+	defer s.plans.mu.Unlock()
+	if s.plans.plans == nil {
+		s.plans.plans = make(map[string]*committedPlan)
+	}
+	s.plans.plans[planKey(request.TenantID, truck.ID)] = entry
+	return entry.state(), nil
+}
+
+// CommittedPlan returns the state of a truck's committed plan
+func (s *OptimizerService) CommittedPlan(tenantID, truckID string) (*domain.PlanState, bool) {
+	entry, ok := s.plans.get(tenantID, truckID)
+	if !ok {
+		return nil, false
+	}
+	return entry.state(), true
+}
+
+// ReleasePlan forgets a truck's committed plan, reporting whether it had one
+func (s *OptimizerService) ReleasePlan(tenantID, truckID string) bool {
+	s.plans.mu.Lock()
+	defer s.plans.mu.Unlock()
+	key := planKey(tenantID, truckID)
+	_, ok := s.plans.plans[key]
+	delete(s.plans.plans, key)
+	return ok
+}
+
+// CheckAddition answers whether an order can join a truck's committed plan,
+// and adds it when add is set and it can. It is the hot path for tendering:
+// nothing is solved, geocoded or measured, so orders compared by corridor
+// should carry their own points. The boolean reports whether the truck has a
+// committed plan.
+func (s *OptimizerService) CheckAddition(tenantID, truckID string, input domain.OrderInput, add bool) (*domain.AdditionCheck, bool, error) {
+	entry, ok := s.plans.get(tenantID, truckID)
+	if !ok {
+		return nil, false, nil
+	}
+	
+	single := entry.request
+	single.Orders = []domain.OrderInput{input}
+	if err := s.openPayouts(&single); err != nil {
+		return nil, true, fmt.Errorf("validation failed: %w", err)
+	}
+	order, err := entry.request.OrderToDomain(single.Orders[0], s.ValidationProfile(tenantID), s.clock.Now())
+	if err != nil {
+		return nil, true, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	if add {
+		s.plans.mu.Lock()
+		defer s.plans.mu.Unlock()
+		// The plan may have changed since it was read
+		if entry, ok = s.plans.plans[planKey(tenantID, truckID)]; !ok {
+			return nil, false, nil
+		}
+	}
+	check := &domain.AdditionCheck{
+		TruckID:   truckID,
+		OrderID:   order.ID,
+		Reasons:   s.additionBlockers(entry, order),
+		Remaining: entry.plan.Remaining(),
+	}
+	check.CanAdd = len(check.Reasons) == 0
+	if add && check.CanAdd {
+		added := *entry
+		added.plan = entry.plan.With(order)
+		added.updatedAt = s.clock.Now()
+		s.plans.plans[planKey(tenantID, truckID)] = &added
+		check.Added = true
+		check.Remaining = added.plan.Remaining()
+	}
+	return check, true, nil
+}
+
+// additionBlockers lists why an order cannot join a committed plan: the
+// plan's own checks, plus the request's facilities and the tenant's blocked
+// shippers, which filter orders before a solve
+func (s *OptimizerService) additionBlockers(entry *committedPlan, order domain.Order) []string {
+	var reasons []string
+	_, undockable := entry.request.SplitDockableOrders(entry.plan.Truck, []domain.Order{order})
+	_, adjustments := applyTenantSettings([]domain.Order{order}, s.tenants.Get(entry.request.TenantID))
+	for _, excluded := range append(undockable, adjustments.excluded...) {
+		reasons = append(reasons, excluded.Reason)
+	}
+	return append(reasons, entry.plan.Blockers(order)...)
+}
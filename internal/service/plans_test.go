@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestCommittedPlanAdditions(t *testing.T) {
+	service := NewOptimizerService()
+	request := minimumsRequest()
+	heavy := request.Orders[1]
+	request.Orders = request.Orders[:1]
+	request.TenantID = "acme"
+	
+	state, err := service.CommitPlan(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(state.OrderIDs, []string{"light"}) || state.Remaining.WeightLbs != 5000 || state.Remaining.VolumeCuft != 900 {
+		t.Fatalf("committed %+v, want light with 5000 lbs and 900 cuft left", state)
+	}
+	
+	check, ok, err := service.CheckAddition("acme", "truck-1", heavy, true)
+	if err != nil || !ok {
+		t.Fatal(ok, err)
+	}
+	if check.CanAdd || check.Added || len(check.Reasons) != 1 || !strings.Contains(check.Reasons[0], "9500 lbs") {
+		t.Fatalf("heavy order check %+v, want refused for weight", check)
+	}
+	
+	small := heavy
+	small.ID, small.WeightLbs = "small", 2000
+	check, _, err = service.CheckAddition("acme", "truck-1", small, false)
+	if err != nil || !check.CanAdd || check.Added || check.Remaining.WeightLbs != 5000 {
+		t.Fatalf("check %+v (%v), want addable without adding", check, err)
+	}
+	check, _, err = service.CheckAddition("acme", "truck-1", small, true)
+	if err != nil || !check.Added || check.Remaining.WeightLbs != 3000 {
+		t.Fatalf("add %+v (%v), want added with 3000 lbs left", check, err)
+	}
+	check, _, _ = service.CheckAddition("acme", "truck-1", small, true)
+	if check.Added {
+		t.Error("order added twice")
+	}
+	
+	elsewhere := small
+	elsewhere.ID, elsewhere.Destination = "elsewhere", "Chicago, IL"
+	check, _, _ = service.CheckAddition("acme", "truck-1", elsewhere, false)
+	if check.CanAdd || len(check.Reasons) != 2 || !strings.Contains(check.Reasons[0], "rule route") {
+		t.Errorf("order on another route %+v, want kept apart from both orders", check)
+	}
+	
+	if _, ok, _ := service.CheckAddition("other-tenant", "truck-1", small, false); ok {
+		t.Error("another tenant reached the plan")
+	}
+	if state, _ := service.CommittedPlan("acme", "truck-1"); !reflect.DeepEqual(state.OrderIDs, []string{"light", "small"}) {
+		t.Errorf("plan holds %v, want [light small]", state.OrderIDs)
+	}
+	if !service.ReleasePlan("acme", "truck-1") || service.ReleasePlan("acme", "truck-1") {
+		t.Error("release did not report the plan exactly once")
+	}
+}
+
+func TestCommitPlanRejectsOrdersThatDoNotFit(t *testing.T) {
+	_, err := NewOptimizerService().CommitPlan(context.Background(), minimumsRequest())
+	if err == nil || !strings.Contains(err.Error(), "order heavy cannot be committed") {
+		t.Fatalf("err = %v, want heavy rejected", err)
+	}
+	
+	request := minimumsRequest()
+	request.Orders = request.Orders[:1]
+	service := NewOptimizerService()
+	if _, err := service.CommitPlan(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := service.CheckAddition("", "truck-1", domain.OrderInput{ID: "bad"}, false); err == nil {
+		t.Error("invalid order checked")
+	}
+}
+
+func BenchmarkCheckAddition(b *testing.B) {
+	service := NewOptimizerService()
+	request := minimumsRequest()
+	request.Truck.MaxWeightLbs, request.Truck.MaxVolumeCuft = 80000, 100000
+	template := request.Orders[0]
+	request.Orders = nil
+	for i := 0; i < 50; i++ {
+		order := template
+		order.ID, order.WeightLbs = "committed-"+string(rune('A'+i)), 100
+		request.Orders = append(request.Orders, order)
+	}
+	if _, err := service.CommitPlan(context.Background(), request); err != nil {
+		b.Fatal(err)
+	}
+	candidate := template
+	candidate.ID = "candidate"
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if check, _, err := service.CheckAddition("", "truck-1", candidate, false); err != nil || !check.CanAdd {
+			b.Fatal(check, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"smart-load/internal/publish"
+	"smart-load/internal/recording"
+	"sync"
+)
+
+// RecordingState is whether solves are being recorded, and what share of them
+type RecordingState struct {
+	Enabled    bool    `json:"enabled"`
+	SampleRate float64 `json:"sample_rate"`
+}
+
+// recorder hands a sampled share of solves, sanitized, to a publisher whose
+// sink keeps them for replay
+type recorder struct {
+	publisher publish.Publisher
+	// key makes the pseudonyms of tenants and shippers
+	key []byte
+	
+	mu    sync.Mutex
+	state RecordingState
+}
+
+// WithRecorder records rate (0 to 1) of the solves to publisher as
+// recording.Entry events, keyed by truck ID, while the recorder is enabled;
+// SetRecording turns it on and off. Tenants and shippers are named by
+// pseudonyms made under pseudonymKey. Solves with sealed payouts, served from
+// the result cache or stopped early are not recorded.
+func WithRecorder(publisher publish.Publisher, rate float64, enabled bool, pseudonymKey []byte) Option {
+	return func(s *OptimizerService) {
+		s.recorder = &recorder{publisher: publisher, key: pseudonymKey, state: RecordingState{Enabled: enabled, SampleRate: rate}}
+	}
+}
+
+// Recording reports the recorder's state, false when there is no recorder
+func (s *OptimizerService) Recording() (RecordingState, bool) {
+	if s.recorder == nil {
+		return RecordingState{}, false
+	}
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	return s.recorder.state, true
+}
+
+// SetRecording turns the recorder on or off and sets its sample rate
+func (s *OptimizerService) SetRecording(state RecordingState) error {
+	if s.recorder == nil {
+		return fmt.Errorf("no recorder is configured")
+	}
+	if state.SampleRate < 0 || state.SampleRate > 1 {
+		return fmt.Errorf("validation failed: sample_rate must be between 0 and 1")
+	}
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.recorder.state = state
+	return nil
+}
+
+// record hands a sampled solve to the recorder. request must be the one the
+// solve validated, not yet changed by it.
+func (s *OptimizerService) record(request domain.OptimizeRequest, result algorithm.OptimizationResult, response *domain.OptimizeResponse) {
+	if s.recorder == nil || request.PayoutsSealed() || response.StoppedEarly {
+		return
+	}
+	state, _ := s.Recording()
+	if !state.Enabled || rand.Float64() >= state.SampleRate {
+		return
+	}
+	entry := recording.NewEntry(
+		s.recorder.key,
+		response.SolutionID,
+		s.clock.Now(),
+		request,
+		s.tenants.Get(request.TenantID),
+		recording.OutcomeOf(response, result.Algorithm, result.ComputeTimeMs),
+	)
+	s.recorder.publisher.Publish(response.TruckID, entry)
+}
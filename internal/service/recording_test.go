@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"smart-load/internal/recording"
+	"smart-load/internal/tenant"
+)
+
+// capturingPublisher keeps every event published to it
+type capturingPublisher struct {
+	mu     sync.Mutex
+	events []interface{}
+}
+
+func (p *capturingPublisher) Publish(key string, event interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *capturingPublisher) Close() error {
+	return nil
+}
+
+// recordingKey makes the pseudonyms of recorded solves
+var recordingKey = []byte("recording-test-key-0123456789abcdef")
+
+func TestRecorder(t *testing.T) {
+	publisher := &capturingPublisher{}
+	tenants := tenant.NewMemoryStore()
+	tenants.Update("acme", func(settings *tenant.Settings) {
+		settings.BlockedShippers = []string{"Umbrella Chemicals"}
+	})
+	svc := NewOptimizerService(WithRecorder(publisher, 1, false, recordingKey), WithTenantStore(tenants))
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	request.Orders[0].Shipper = "Globex"
+	
+	if _, err := svc.OptimizeLoad(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if len(publisher.events) != 0 {
+		t.Fatalf("recorded %d solves while disabled", len(publisher.events))
+	}
+	
+	if err := svc.SetRecording(RecordingState{Enabled: true, SampleRate: 1}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("recorded %d solves, want 1", len(publisher.events))
+	}
+	entry := publisher.events[0].(recording.Entry)
+	if entry.ID != response.SolutionID || entry.TenantID != recording.Pseudonym(recordingKey, "tenant", "acme") {
+		t.Errorf("entry id %q, tenant %q", entry.ID, entry.TenantID)
+	}
+	if entry.Request.Orders[0].Shipper != recording.Pseudonym(recordingKey, "shipper", "Globex") || request.Orders[0].Shipper != "Globex" {
+		t.Errorf("recorded shipper %q, caller's %q", entry.Request.Orders[0].Shipper, request.Orders[0].Shipper)
+	}
+	if got := entry.Settings.BlockedShippers; len(got) != 1 || got[0] != recording.Pseudonym(recordingKey, "shipper", "umbrella chemicals") {
+		t.Errorf("recorded blocked shippers %v", got)
+	}
+	if entry.Outcome.TotalPayoutCents != response.TotalPayoutCents || entry.Outcome.Algorithm == "" {
+		t.Errorf("outcome = %+v, want payout %d and the algorithm", entry.Outcome, response.TotalPayoutCents)
+	}
+	
+	if err := svc.SetRecording(RecordingState{Enabled: true, SampleRate: 1.5}); err == nil {
+		t.Error("sample rate above 1 accepted")
+	}
+	if err := svc.SetRecording(RecordingState{Enabled: true, SampleRate: 0}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.OptimizeLoad(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if len(publisher.events) != 1 {
+		t.Errorf("recorded %d solves at sample rate 0", len(publisher.events))
+	}
+}
+
+func TestRecordingWithoutRecorder(t *testing.T) {
+	svc := NewOptimizerService()
+	if _, ok := svc.Recording(); ok {
+		t.Error("recorder reported without one configured")
+	}
+	if err := svc.SetRecording(RecordingState{Enabled: true}); err == nil {
+		t.Error("recording enabled without a recorder")
+	}
+}
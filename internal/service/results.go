@@ -0,0 +1,102 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"sync/atomic"
+)
+
+// resultStats counts the result cache's hits and misses
+type resultStats struct {
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// resultKey is the result cache key of a validated request: its fingerprint
+// with the tenant and the tenant's current settings, which shape the plan,
+// and the truck ID, which the fingerprint leaves out but the response names.
+// Keying on the settings themselves rather than dropping entries when they
+// change keeps every instance sharing a cache from serving a plan made under
+// settings since replaced. It is empty when the request
+// must be solved: no cache is set, payouts are sealed and so have no
+// fingerprint, or the solve is streamed, whose events a cached response
+// cannot replay.
+func (s *OptimizerService) resultKey(request *domain.OptimizeRequest, stream Stream) string {
+	if s.results == nil || stream.Started != nil || stream.Progress != nil || stream.Alternative != nil {
+		return ""
+	}
+	fingerprint := request.Fingerprint()
+	if fingerprint == "" {
+		return ""
+	}
+	// The retention policy does not shape plans
+	settings := s.tenants.Get(request.TenantID)
+	settings.Retention = nil
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.New()
+	for _, part := range []string{request.TenantID, string(encoded), request.Truck.ID, fingerprint} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// recordCacheHit marks a response served from the result cache and records
+// it in the history as a solve of its own, under a new solution ID and taking
+// no compute time. The record copies the one of the solve that was cached,
+// with its revenue, while the history still keeps it.
+func (s *OptimizerService) recordCacheHit(request domain.OptimizeRequest, response *domain.OptimizeResponse) {
+	record, ok := s.history.Get(response.SolutionID)
+	if !ok {
+		record = history.Record{
+			TruckID:                  response.TruckID,
+			Currency:                 response.Currency,
+			WeightUnit:               "lb",
+			VolumeUnit:               "ft3",
+			OrdersConsidered:         len(request.Orders),
+			OrdersSelected:           len(response.SelectedOrderIDs),
+			TotalPayoutMinor:         response.TotalPayoutCents,
+			TotalCostMinor:           response.CostBreakdown.TotalCents,
+			NetProfitMinor:           response.NetProfitCents,
+			TotalWeight:              response.TotalWeightLbs,
+			TotalVolume:              response.TotalVolumeCuft,
+			UtilizationWeightPercent: response.UtilizationWeightPercent,
+			UtilizationVolumePercent: response.UtilizationVolumePercent,
+			Recommendation:           response.Recommendation,
+			ProblemFingerprint:       response.ProblemFingerprint,
+		}
+	}
+	response.SolutionID = s.ids.NewID()
+	response.Cached = true
+	record.SolutionID = response.SolutionID
+	record.CreatedAt = s.clock.Now().UTC()
+	record.TenantID, record.APIKey = request.TenantID, request.APIKey
+	record.CacheHit = true
+	record.ComputeTimeMs = 0
+	record.MeasuredGapPercent = nil
+	s.history.Append(record)
+	s.observe(record)
+}
+
+// ResultCacheStats reports the result cache's lookups, nil when no cache is
+// set
+func (s *OptimizerService) ResultCacheStats() *domain.ResultCacheStats {
+	if s.results == nil {
+		return nil
+	}
+	stats := &domain.ResultCacheStats{Hits: s.resultStats.hits.Load(), Misses: s.resultStats.misses.Load()}
+	if lookups := stats.Hits + stats.Misses; lookups > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(lookups)
+	}
+	if sized, ok := s.results.(interface{ Len() int }); ok {
+		entries := sized.Len()
+		stats.Entries = &entries
+	}
+	return stats
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/cache"
+	"smart-load/internal/domain"
+)
+
+// mapCache is a result cache that keeps every response
+type mapCache map[string]*domain.OptimizeResponse
+
+func (m mapCache) Get(_ context.Context, key string) (*domain.OptimizeResponse, bool) {
+	response, ok := m[key]
+	if !ok {
+		return nil, false
+	}
+	copied := *response
+	return &copied, true
+}
+
+func (m mapCache) Set(_ context.Context, key string, response *domain.OptimizeResponse) {
+	m[key] = response
+}
+
+func TestResultCache(t *testing.T) {
+	results := mapCache{}
+	svc := NewOptimizerService(WithResultCache(results))
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	
+	first, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Cached || len(results) != 1 {
+		t.Fatalf("first solve cached = %v with %d entries, want a fresh solve stored once", first.Cached, len(results))
+	}
+	
+	// The same problem with its orders in another sequence is the same key
+	reordered := minimumsRequest()
+	reordered.TenantID = "acme"
+	reordered.Orders[0], reordered.Orders[1] = reordered.Orders[1], reordered.Orders[0]
+	again, err := svc.OptimizeLoad(context.Background(), reordered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Cached || again.SelectedOrderIDs == nil || again.SolutionID == first.SolutionID {
+		t.Errorf("repeat = cached %v, solution %s; want the cached plan under a new solution ID", again.Cached, again.SolutionID)
+	}
+	record, ok := svc.Solve(again.SolutionID)
+	if !ok || !record.CacheHit || record.ComputeTimeMs != 0 || record.TenantID != "acme" || record.Algorithm == "" {
+		t.Errorf("cache hit record = %+v, %v; want the cached solve's record marked as a hit", record, ok)
+	}
+	
+	other := minimumsRequest()
+	other.TenantID = "other"
+	if response, err := svc.OptimizeLoad(context.Background(), other); err != nil || response.Cached {
+		t.Errorf("another tenant's request was served from the cache (err %v)", err)
+	}
+	
+	streamed, err := svc.OptimizeLoadStream(context.Background(), request, Stream{Progress: func(domain.SolveProgress) {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if streamed.Cached {
+		t.Error("a streamed solve was served from the cache")
+	}
+	if len(results) != 2 {
+		t.Errorf("cache holds %d entries, want one per tenant", len(results))
+	}
+}
+
+func TestResultCacheStats(t *testing.T) {
+	if NewOptimizerService().HealthDetails().ResultCache != nil {
+		t.Error("stats reported without a cache")
+	}
+	
+	svc := NewOptimizerService(WithResultCache(cache.NewLRU(10, time.Minute)))
+	for i := 0; i < 3; i++ {
+		if _, err := svc.OptimizeLoad(context.Background(), minimumsRequest()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := svc.HealthDetails().ResultCache
+	if stats == nil || stats.Hits != 2 || stats.Misses != 1 || stats.Entries == nil || *stats.Entries != 1 {
+		t.Fatalf("stats = %+v, want 2 hits, 1 miss and 1 entry", stats)
+	}
+	if stats.HitRate < 0.66 || stats.HitRate > 0.67 {
+		t.Errorf("hit rate = %v, want 2/3", stats.HitRate)
+	}
+}
+
+// A plan made before the tenant's settings changed must not be served after
+func TestResultCacheFollowsTenantSettings(t *testing.T) {
+	svc := NewOptimizerService(WithResultCache(mapCache{}))
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	request.Orders[0].Shipper = "Acme Freight"
+	
+	first, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.SelectedOrderIDs) != 1 || first.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("first solve selected %v, want light", first.SelectedOrderIDs)
+	}
+	if err := svc.BlockShipper("acme", "Acme Freight"); err != nil {
+		t.Fatal(err)
+	}
+	blocked, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocked.Cached || len(blocked.SelectedOrderIDs) != 1 || blocked.SelectedOrderIDs[0] != "heavy" {
+		t.Fatalf("after blocking the shipper: cached %v, selected %v; want a fresh solve of heavy", blocked.Cached, blocked.SelectedOrderIDs)
+	}
+	
+	// The retention policy does not shape plans, so changing it keeps them
+	if err := svc.SetRetentionPolicy("acme", domain.RetentionPolicy{HistoryDays: 30}); err != nil {
+		t.Fatal(err)
+	}
+	again, err := svc.OptimizeLoad(context.Background(), request)
+	if err != nil || !again.Cached {
+		t.Errorf("repeat after a retention change: err %v, want the cached plan", err)
+	}
+}
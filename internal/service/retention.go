@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/tenant"
+	"sort"
+	"time"
+)
+
+// WithRetention sets the retention policy of tenants that have none of their
+// own
+func WithRetention(policy domain.RetentionPolicy) Option {
+	return func(s *OptimizerService) {
+		s.retention = policy
+	}
+}
+
+// RetentionPolicy returns the policy in force for a tenant: its own, or the
+// server's
+func (s *OptimizerService) RetentionPolicy(tenantID string) domain.RetentionPolicy {
+	if policy := s.tenants.Get(tenantID).Retention; policy != nil {
+		return *policy
+	}
+	return s.retention
+}
+
+// SetRetentionPolicy gives a tenant a retention policy of its own
+func (s *OptimizerService) SetRetentionPolicy(tenantID string, policy domain.RetentionPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		settings.Retention = &policy
+	})
+	return nil
+}
+
+// ResetRetentionPolicy returns a tenant to the server's policy, reporting
+// whether it had one of its own
+func (s *OptimizerService) ResetRetentionPolicy(tenantID string) bool {
+	removed := false
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		removed = settings.Retention != nil
+		settings.Retention = nil
+	})
+	return removed
+}
+
+// PurgeReport records what a purge deleted, for retention audits
+type PurgeReport struct {
+	PurgedAt time.Time     `json:"purged_at"`
+	Tenants  []TenantPurge `json:"tenants"`
+}
+
+// TenantPurge is what a purge deleted of one tenant's data: the history
+// records and finished jobs created before Cutoff, under a policy of
+// HistoryDays
+type TenantPurge struct {
+	TenantID         string    `json:"tenant_id"`
+	HistoryDays      int       `json:"history_days"`
+	Cutoff           time.Time `json:"cutoff"`
+	DeletedSolutions int       `json:"deleted_solutions"`
+	SolutionIDs      []string  `json:"solution_ids"`
+	DeletedJobs      int       `json:"deleted_jobs"`
+	JobIDs           []string  `json:"job_ids"`
+}
+
+// PurgeExpired deletes every tenant's data older than its retention policy
+// allows. Tenants with nothing to delete are left out of the report.
+func (s *OptimizerService) PurgeExpired(now time.Time) PurgeReport {
+	return s.purge(now, func(string) bool { return true })
+}
+
+// PurgeTenant deletes one tenant's expired data now. The tenant is always in
+// the report, with nothing deleted when nothing had expired.
+func (s *OptimizerService) PurgeTenant(tenantID string, now time.Time) TenantPurge {
+	report := s.purge(now, func(id string) bool { return id == tenantID })
+	if len(report.Tenants) == 0 {
+		purge := s.newTenantPurge(tenantID, now)
+		purge.SolutionIDs, purge.JobIDs = []string{}, []string{}
+		return purge
+	}
+	return report.Tenants[0]
+}
+
+func (s *OptimizerService) purge(now time.Time, include func(tenantID string) bool) PurgeReport {
+	now = now.UTC()
+	purges := make(map[string]*TenantPurge)
+	purgeOf := func(tenantID string) *TenantPurge {
+		purge, ok := purges[tenantID]
+		if !ok {
+			tenantPurge := s.newTenantPurge(tenantID, now)
+			purge = &tenantPurge
+			purges[tenantID] = purge
+		}
+		return purge
+	}
+	
+	deleted := s.history.Delete(func(record history.Record) bool {
+		if !include(record.TenantID) {
+			return false
+		}
+		purge := purgeOf(record.TenantID)
+		return purge.HistoryDays > 0 && record.CreatedAt.Before(purge.Cutoff)
+	})
+	
+	// Jobs keep their requests, so they expire with the history
+	deletedJobs, err := s.jobs.Delete(context.Background(), func(tenantID string) time.Time {
+		if !include(tenantID) {
+			return time.Time{}
+		}
+		return purgeOf(tenantID).Cutoff
+	})
+	if err != nil {
+		log.Printf("Retention purge: deleting jobs: %v", err)
+	}
+	
+	report := PurgeReport{PurgedAt: now, Tenants: make([]TenantPurge, 0)}
+	for _, record := range deleted {
+		purge := purges[record.TenantID]
+		purge.DeletedSolutions++
+		purge.SolutionIDs = append(purge.SolutionIDs, record.SolutionID)
+	}
+	for _, job := range deletedJobs {
+		purge := purges[job.TenantID]
+		purge.DeletedJobs++
+		purge.JobIDs = append(purge.JobIDs, job.ID)
+	}
+	for _, purge := range purges {
+		if purge.DeletedSolutions > 0 || purge.DeletedJobs > 0 {
+			if purge.SolutionIDs == nil {
+				purge.SolutionIDs = []string{}
+			}
+			if purge.JobIDs == nil {
+				purge.JobIDs = []string{}
+			}
+			report.Tenants = append(report.Tenants, *purge)
+		}
+	}
+	sort.Slice(report.Tenants, func(i, j int) bool {
+		return report.Tenants[i].TenantID < report.Tenants[j].TenantID
+	})
+	return report
+}
+
+func (s *OptimizerService) newTenantPurge(tenantID string, now time.Time) TenantPurge {
+	policy := s.RetentionPolicy(tenantID)
+	purge := TenantPurge{TenantID: tenantID, HistoryDays: policy.HistoryDays}
+	if policy.HistoryDays > 0 {
+		purge.Cutoff = now.AddDate(0, 0, -policy.HistoryDays)
+	}
+	return purge
+}
+
+// RunPurgeJob purges expired data every interval of real time until ctx is
+// done, logging what each run deleted. Cutoffs follow the service's clock.
+func (s *OptimizerService) RunPurgeJob(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, purge := range s.PurgeExpired(s.clock.Now()).Tenants {
+				log.Printf("Retention purge: deleted %d solutions and %d jobs of tenant %q created before %s",
+					purge.DeletedSolutions, purge.DeletedJobs, purge.TenantID, purge.Cutoff.Format(time.RFC3339))
+			}
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/jobs"
+)
+
+func TestPurgeFollowsTenantPolicies(t *testing.T) {
+	now := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	store := history.NewMemoryStore(10)
+	for _, record := range []history.Record{
+		{SolutionID: "acme-old", TenantID: "acme", CreatedAt: now.AddDate(0, 0, -40)},
+		{SolutionID: "acme-new", TenantID: "acme", CreatedAt: now.AddDate(0, 0, -5)},
+		{SolutionID: "globex-old", TenantID: "globex", CreatedAt: now.AddDate(0, 0, -40)},
+		{SolutionID: "initech-old", TenantID: "initech", CreatedAt: now.AddDate(0, 0, -40)},
+	} {
+		store.Append(record)
+	}
+	s := NewOptimizerService(WithHistoryStore(store), WithRetention(domain.RetentionPolicy{HistoryDays: 30}))
+	if err := s.SetRetentionPolicy("globex", domain.RetentionPolicy{HistoryDays: 60}); err != nil {
+		t.Fatal(err)
+	}
+	
+	report := s.PurgeExpired(now)
+	if len(report.Tenants) != 2 {
+		t.Fatalf("purged %+v, want acme and initech", report.Tenants)
+	}
+	for i, want := range []string{"acme-old", "initech-old"} {
+		purge := report.Tenants[i]
+		if purge.DeletedSolutions != 1 || purge.SolutionIDs[0] != want || purge.HistoryDays != 30 {
+			t.Errorf("tenant %s purge %+v, want %s deleted under 30 days", purge.TenantID, purge, want)
+		}
+	}
+	for _, kept := range []string{"acme-new", "globex-old"} {
+		if _, ok := store.Get(kept); !ok {
+			t.Errorf("%s purged", kept)
+		}
+	}
+	
+	purge := s.PurgeTenant("globex", now.AddDate(0, 1, 0))
+	if purge.DeletedSolutions != 1 || purge.SolutionIDs[0] != "globex-old" {
+		t.Errorf("purge now %+v, want globex-old deleted", purge)
+	}
+	if err := s.SetRetentionPolicy("globex", domain.RetentionPolicy{HistoryDays: -1}); err == nil {
+		t.Error("negative history_days accepted")
+	}
+}
+
+func TestPurgeDeletesExpiredJobs(t *testing.T) {
+	now := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	store := jobs.NewMemoryStore(10)
+	for _, job := range []jobs.Job{
+		{ID: "acme-old", TenantID: "acme", Status: jobs.StatusSucceeded, Request: minimumsRequest(), CreatedAt: now.AddDate(0, 0, -40)},
+		{ID: "acme-new", TenantID: "acme", Status: jobs.StatusSucceeded, Request: minimumsRequest(), CreatedAt: now.AddDate(0, 0, -5)},
+	} {
+		if err := store.Create(context.Background(), job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := NewOptimizerService(WithJobStore(store), WithRetention(domain.RetentionPolicy{HistoryDays: 30}))
+	
+	purge := s.PurgeTenant("acme", now)
+	if purge.DeletedJobs != 1 || purge.JobIDs[0] != "acme-old" || purge.DeletedSolutions != 0 {
+		t.Fatalf("purge %+v, want acme-old's job deleted", purge)
+	}
+	if job, ok, err := s.Job(context.Background(), "acme", "", "acme-old"); ok || err != nil {
+		t.Errorf("purged job = %+v, %v, %v; want its request gone", job, ok, err)
+	}
+	if _, ok, _ := s.Job(context.Background(), "acme", "", "acme-new"); !ok {
+		t.Error("acme-new purged")
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"sync"
+)
+
+// runRegistry keeps the stop functions of the streamed solves that can be
+// stopped, by tenant, API key and run id
+type runRegistry struct {
+	mu   sync.Mutex
+	runs map[string]context.CancelFunc
+}
+
+func runKey(tenantID, apiKey, runID string) string {
+	return tenantID + "\x00" + apiKey + "\x00" + runID
+}
+
+func (r *runRegistry) add(tenantID, apiKey, runID string, stop context.CancelFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runs == nil {
+		r.runs = make(map[string]context.CancelFunc)
+	}
+	r.runs[runKey(tenantID, apiKey, runID)] = stop
+}
+
+func (r *runRegistry) remove(tenantID, apiKey, runID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.runs, runKey(tenantID, apiKey, runID))
+}
+
+// StopRun stops the search of a running streamed solve, which then answers
+// with the best plan found so far, marked stopped_early. Only the tenant and
+// API key that started a run can stop it; false means they have no run of
+// that id still searching or building its response.
+func (s *OptimizerService) StopRun(tenantID, apiKey, runID string) bool {
+	s.runs.mu.Lock()
+	stop, ok := s.runs.runs[runKey(tenantID, apiKey, runID)]
+	s.runs.mu.Unlock()
+	if ok {
+		stop()
+	}
+	return ok
+}
+
+// progressReporter turns the algorithms' progress into SolveProgress for
+// report, redacted when payouts are sealed
+func progressReporter(report func(domain.SolveProgress), sealed bool) func(algorithm.Progress) {
+	return func(progress algorithm.Progress) {
+		update := domain.SolveProgress{
+			Algorithm:        progress.Algorithm,
+			SelectedOrderIDs: make([]string, len(progress.SelectedOrders)),
+			BestScore:        int64(progress.Score),
+			NodesExplored:    progress.Nodes,
+			ElapsedMs:        progress.Elapsed.Milliseconds(),
+		}
+		var payout domain.Money
+		for i, order := range progress.SelectedOrders {
+			update.SelectedOrderIDs[i] = order.ID
+			payout = payout.Add(order.Payout)
+		}
+		update.BestPayoutCents = int64(payout)
+		if sealed {
+			update.RedactPayout()
+		}
+		report(update)
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+func TestStreamReportsProgress(t *testing.T) {
+	request := minimumsRequest()
+	request.OptimizationConfig = &domain.OptimizationConfig{Algorithm: "branch_and_bound", RevenueWeight: 1}
+	
+	var reports []domain.SolveProgress
+	response, err := NewOptimizerService().OptimizeLoadStream(context.Background(), request, Stream{
+		Progress: func(progress domain.SolveProgress) {
+			reports = append(reports, progress)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) == 0 {
+		t.Fatal("no progress reported")
+	}
+	last := reports[len(reports)-1]
+	if last.Algorithm != "branch_and_bound" || last.BestPayoutCents != response.TotalPayoutCents {
+		t.Errorf("last report = %+v, want branch_and_bound at the response's payout %d", last, response.TotalPayoutCents)
+	}
+	if len(last.SelectedOrderIDs) != len(response.SelectedOrderIDs) {
+		t.Errorf("last report selects %v, response %v", last.SelectedOrderIDs, response.SelectedOrderIDs)
+	}
+}
+
+func TestStopRun(t *testing.T) {
+	request := minimumsRequest()
+	request.TenantID = "acme"
+	request.OptimizationConfig = &domain.OptimizationConfig{Algorithm: "branch_and_bound", RevenueWeight: 1}
+	svc := NewOptimizerService()
+	
+	var runID string
+	stopped := false
+	response, err := svc.OptimizeLoadStream(context.Background(), request, Stream{
+		Started: func(id string) {
+			runID = id
+			if svc.StopRun("other", "", id) {
+				t.Error("another tenant stopped the run")
+			}
+		},
+		Progress: func(domain.SolveProgress) {
+			if !stopped {
+				stopped = svc.StopRun("acme", "", runID)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stopped {
+		t.Fatal("StopRun did not find the running solve")
+	}
+	if !response.StoppedEarly || len(response.SelectedOrderIDs) == 0 {
+		t.Errorf("response = %+v, want the best plan so far, stopped early", response)
+	}
+	if svc.StopRun("acme", "", runID) {
+		t.Error("StopRun found the run after it finished")
+	}
+}
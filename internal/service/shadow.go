@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"sync"
+	"sync/atomic"
+)
+
+// maxShadowRuns bounds the shadow solves running at once; solves drawn while
+// every slot is busy are skipped rather than queued
+const maxShadowRuns = 2
+
+// shadowRunner solves a sampled share of requests again with a second
+// algorithm in the background and meters how its plans compare
+type shadowRunner struct {
+	algorithm string
+	rate      float64
+	slots     chan struct{}
+	// running tracks the shadow solves in flight
+	running sync.WaitGroup
+	
+	runs, skipped, better, worse, same atomic.Int64
+	payoutDeltaCents                   atomic.Int64
+	primaryMs, shadowMs                atomic.Int64
+}
+
+// WithShadowAlgorithm solves rate (0 to 1) of the requests again with the
+// named algorithm, in the background after the response is built, with the
+// request's pins, rules and objective. Responses never change: each shadow
+// plan's payout is compared with the plan the request was answered with
+// before minimums were applied, logged, and totalled in HealthDetails.
+// Requests with sealed payouts, stopped runs, requests that chose the shadow
+// algorithm, and requests with more orders than it takes are not shadowed.
+func WithShadowAlgorithm(name string, rate float64) Option {
+	return func(s *OptimizerService) {
+		s.shadow = &shadowRunner{algorithm: name, rate: rate, slots: make(chan struct{}, maxShadowRuns)}
+	}
+}
+
+// runShadow, for a sampled solve, solves it again in the background with the
+// shadow algorithm wrapped by wrap, for the objective, and meters the payout
+// delta against primary
+func (s *OptimizerService) runShadow(
+	solutionID string,
+	config *domain.OptimizationConfig,
+	orders []domain.Order,
+	primary algorithm.OptimizationResult,
+	wrap func(algorithm.Optimizer) algorithm.Optimizer,
+	objective func(context.Context, algorithm.Optimizer, []domain.Order) algorithm.OptimizationResult,
+) {
+	shadow := s.shadow
+	if shadow == nil || shadow.rate <= 0 || rand.Float64() >= shadow.rate {
+		return
+	}
+	chosen := "auto"
+	if config != nil && config.Algorithm != "" {
+		chosen = config.Algorithm
+	}
+	if chosen == shadow.algorithm || len(orders) > domain.MaxOrdersForAlgorithm(shadow.algorithm) {
+		return
+	}
+	select {
+	case shadow.slots <- struct{}{}:
+	default:
+		shadow.skipped.Add(1)
+		return
+	}
+	
+	// The response is still being built from orders, so the shadow solves a
+	// copy
+	orders = append([]domain.Order(nil), orders...)
+	optimizer := wrap(s.selectOptimizer(&domain.OptimizationConfig{Algorithm: shadow.algorithm}, len(orders)))
+	shadow.running.Add(1)
+	go func() {
+		defer func() {
+			<-shadow.slots
+			shadow.running.Done()
+		}()
+		
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		defer cancel()
+		result := objective(ctx, optimizer, orders)
+		if ctx.Err() != nil {
+			shadow.skipped.Add(1)
+			log.Printf("  Shadow %s timed out on solve %s", shadow.algorithm, solutionID)
+			return
+		}
+		
+		delta := int64(result.TotalPayout - primary.TotalPayout)
+		shadow.runs.Add(1)
+		shadow.payoutDeltaCents.Add(delta)
+		shadow.primaryMs.Add(primary.ComputeTimeMs)
+		shadow.shadowMs.Add(result.ComputeTimeMs)
+		switch {
+		case delta > 0:
+			shadow.better.Add(1)
+		case delta < 0:
+			shadow.worse.Add(1)
+		default:
+			shadow.same.Add(1)
+		}
+		log.Printf(" Shadow %s on solve %s: payout delta %+d cents, %dms vs %dms",
+			shadow.algorithm, solutionID, delta, result.ComputeTimeMs, primary.ComputeTimeMs)
+	}()
+}
+
+// shadowStats reports the shadow solves so far, nil when no shadow algorithm
+// is set
+func (s *OptimizerService) shadowStats() *domain.ShadowStats {
+	shadow := s.shadow
+	if shadow == nil {
+		return nil
+	}
+	stats := &domain.ShadowStats{
+		Algorithm:        shadow.algorithm,
+		Runs:             shadow.runs.Load(),
+		Skipped:          shadow.skipped.Load(),
+		Better:           shadow.better.Load(),
+		Worse:            shadow.worse.Load(),
+		Same:             shadow.same.Load(),
+		PayoutDeltaCents: shadow.payoutDeltaCents.Load(),
+	}
+	if stats.Runs > 0 {
+		stats.AveragePayoutDeltaCents = s.rounding.Round(float64(stats.PayoutDeltaCents)/float64(stats.Runs), 2)
+		stats.AveragePrimaryComputeMs = s.rounding.Round(float64(shadow.primaryMs.Load())/float64(stats.Runs), 2)
+		stats.AverageShadowComputeMs = s.rounding.Round(float64(shadow.shadowMs.Load())/float64(stats.Runs), 2)
+	}
+	return stats
+}
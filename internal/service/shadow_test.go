@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"smart-load/internal/domain"
+)
+
+// shadowRequest is answered worse by greedy, which takes the dense order,
+// than by the exact algorithms, which take the two halves
+func shadowRequest() domain.OptimizeRequest {
+	request := minimumsRequest()
+	request.Orders = []domain.OrderInput{request.Orders[0], request.Orders[0], request.Orders[0]}
+	for i, order := range []struct {
+		id     string
+		payout int64
+		weight int
+	}{{"dense", 70000, 6000}, {"half-1", 50000, 5000}, {"half-2", 50000, 5000}} {
+		request.Orders[i].ID, request.Orders[i].PayoutCents, request.Orders[i].WeightLbs = order.id, order.payout, order.weight
+	}
+	request.OptimizationConfig = &domain.OptimizationConfig{Algorithm: "greedy"}
+	return request
+}
+
+func TestShadowAlgorithmMetersPayoutDelta(t *testing.T) {
+	service := NewOptimizerService(WithShadowAlgorithm("dp", 1))
+	response, err := service.OptimizeLoad(context.Background(), shadowRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.shadow.running.Wait()
+	
+	if response.TotalPayoutCents != 70000 {
+		t.Errorf("answered with payout %d, want greedy's 70000 whatever the shadow found", response.TotalPayoutCents)
+	}
+	stats := service.HealthDetails().Shadow
+	if stats == nil || stats.Algorithm != "dp" || stats.Runs != 1 || stats.Better != 1 || stats.PayoutDeltaCents != 30000 {
+		t.Errorf("shadow stats %+v, want one run 30000 cents better", stats)
+	}
+}
+
+func TestShadowAlgorithmSkipsItsOwnRequests(t *testing.T) {
+	service := NewOptimizerService(WithShadowAlgorithm("greedy", 1))
+	if _, err := service.OptimizeLoad(context.Background(), shadowRequest()); err != nil {
+		t.Fatal(err)
+	}
+	service.shadow.running.Wait()
+	if stats := service.HealthDetails().Shadow; stats.Runs != 0 || stats.Skipped != 0 {
+		t.Errorf("shadow stats %+v, want a request that chose greedy left alone", stats)
+	}
+	if NewOptimizerService().HealthDetails().Shadow != nil {
+		t.Error("shadow stats reported without a shadow algorithm")
+	}
+}
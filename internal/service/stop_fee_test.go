@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStopFeeDiscouragesSmallOrders(t *testing.T) {
+	request := minimumsRequest()
+	request.Orders[0].WeightLbs = 4000
+	request.Orders[1].WeightLbs = 4000
+	request.Orders[1].PayoutCents = 10000
+	
+	response, err := NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 {
+		t.Fatalf("selected %v, want both orders without a stop fee", response.SelectedOrderIDs)
+	}
+	
+	// The second order's pickup and delivery would cost $150, more than its $100
+	request.Truck.StopFeeCents = 7500
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 1 || response.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want only [light]", response.SelectedOrderIDs)
+	}
+	if response.CostBreakdown.StopFeeCents != 0 {
+		t.Errorf("stop fees = %d for one order, want 0", response.CostBreakdown.StopFeeCents)
+	}
+	
+	request.Orders[1].PayoutCents = 20000
+	response, err = NewOptimizerService().OptimizeLoad(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.SelectedOrderIDs) != 2 || response.CostBreakdown.StopFeeCents != 15000 || response.CostBreakdown.TotalCents != 15000 {
+		t.Fatalf("selected %v cost %+v, want both orders and $150 of stop fees", response.SelectedOrderIDs, response.CostBreakdown)
+	}
+}
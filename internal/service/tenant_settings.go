@@ -0,0 +1,279 @@
+package service
+
+import (
+	"fmt"
+	"smart-load/internal/algorithm"
+	"smart-load/internal/domain"
+	"smart-load/internal/tenant"
+	"strings"
+)
+
+// tenantAdjustments records how a tenant's settings changed a request
+type tenantAdjustments struct {
+	bonuses  map[string][]domain.AppliedBonus
+	excluded []domain.ExcludedOrder
+}
+
+// applyTenantSettings drops orders from blocked shippers and tilts scoring by
+// adding preferred lane and shipper bonuses to each order's score. The
+// adjustments are returned so they can be explained.
+func applyTenantSettings(orders []domain.Order, settings tenant.Settings) ([]domain.Order, tenantAdjustments) {
+	adjustments := tenantAdjustments{
+		bonuses:  make(map[string][]domain.AppliedBonus),
+		excluded: make([]domain.ExcludedOrder, 0),
+	}
+	
+	blocked := make(map[string]bool, len(settings.BlockedShippers))
+	for _, shipper := range settings.BlockedShippers {
+		blocked[normalizeShipper(shipper)] = true
+	}
+	
+	adjusted := make([]domain.Order, 0, len(orders))
+	for _, order := range orders {
+		if order.Shipper != "" && blocked[normalizeShipper(order.Shipper)] {
+			adjustments.excluded = append(adjustments.excluded, domain.ExcludedOrder{
+				OrderID: order.ID,
+				Reason:  "shipper " + order.Shipper + " is blocked",
+			})
+			continue
+		}
+		
+		for _, lane := range settings.PreferredLanes {
+			if lane.Matches(order) {
+				adjustments.bonus(&order, lane.Describe(), lane.BonusCents)
+			}
+		}
+		for _, preferred := range settings.PreferredShippers {
+			if order.Shipper != "" && normalizeShipper(preferred.Shipper) == normalizeShipper(order.Shipper) {
+				adjustments.bonus(&order, "preferred shipper "+preferred.Shipper, preferred.BonusCents)
+			}
+		}
+		
+		adjusted = append(adjusted, order)
+	}
+	
+	return adjusted, adjustments
+}
+
+func (a *tenantAdjustments) bonus(order *domain.Order, reason string, cents int64) {
+	order.Score = order.Score.Add(domain.ScoreFromMoney(domain.Money(cents)))
+	a.bonuses[order.ID] = append(a.bonuses[order.ID], domain.AppliedBonus{
+		OrderID:    order.ID,
+		Reason:     reason,
+		BonusCents: cents,
+	})
+}
+
+// explain lists the exclusions and the bonuses that apply to the selected orders
+func (a *tenantAdjustments) explain(result algorithm.OptimizationResult) *domain.Explanation {
+	explanation := &domain.Explanation{ExcludedOrders: a.excluded}
+	for _, order := range result.SelectedOrders {
+		explanation.AppliedBonuses = append(explanation.AppliedBonuses, a.bonuses[order.ID]...)
+	}
+	
+	if len(explanation.AppliedBonuses) == 0 && len(explanation.ExcludedOrders) == 0 {
+		return nil
+	}
+	return explanation
+}
+
+func normalizeShipper(shipper string) string {
+	return strings.ToLower(strings.TrimSpace(shipper))
+}
+
+// PreferredLanes returns the preferred lanes configured for a tenant
+func (s *OptimizerService) PreferredLanes(tenantID string) []domain.PreferredLane {
+	lanes := s.tenants.Get(tenantID).PreferredLanes
+	if lanes == nil {
+		return []domain.PreferredLane{}
+	}
+	return lanes
+}
+
+// SetPreferredLanes replaces a tenant's preferred lanes
+func (s *OptimizerService) SetPreferredLanes(tenantID string, lanes []domain.PreferredLane) error {
+	for i, lane := range lanes {
+		if err := lane.Validate(); err != nil {
+			return fmt.Errorf("validation failed: preferred_lanes[%d]: %w", i, err)
+		}
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		settings.PreferredLanes = lanes
+	})
+	return nil
+}
+
+// BlockedShippers returns the shippers whose orders a tenant never wants loaded
+func (s *OptimizerService) BlockedShippers(tenantID string) []string {
+	shippers := s.tenants.Get(tenantID).BlockedShippers
+	if shippers == nil {
+		return []string{}
+	}
+	return shippers
+}
+
+// BlockShipper adds a shipper to a tenant's block list
+func (s *OptimizerService) BlockShipper(tenantID string, shipper string) error {
+	if strings.TrimSpace(shipper) == "" {
+		return fmt.Errorf("validation failed: shipper is required")
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		for _, existing := range settings.BlockedShippers {
+			if normalizeShipper(existing) == normalizeShipper(shipper) {
+				return
+			}
+		}
+		settings.BlockedShippers = append(settings.BlockedShippers, shipper)
+	})
+	return nil
+}
+
+// UnblockShipper removes a shipper from a tenant's block list, reporting whether it was listed
+func (s *OptimizerService) UnblockShipper(tenantID string, shipper string) bool {
+	removed := false
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		kept := make([]string, 0, len(settings.BlockedShippers))
+		for _, existing := range settings.BlockedShippers {
+			if normalizeShipper(existing) == normalizeShipper(shipper) {
+				removed = true
+				continue
+			}
+			kept = append(kept, existing)
+		}
+		settings.BlockedShippers = kept
+	})
+	return removed
+}
+
+// PreferredShippers returns the shippers a tenant rewards with a score bonus
+func (s *OptimizerService) PreferredShippers(tenantID string) []domain.PreferredShipper {
+	shippers := s.tenants.Get(tenantID).PreferredShippers
+	if shippers == nil {
+		return []domain.PreferredShipper{}
+	}
+	return shippers
+}
+
+// SetPreferredShipper adds a preferred shipper or updates its bonus
+func (s *OptimizerService) SetPreferredShipper(tenantID string, preferred domain.PreferredShipper) error {
+	if err := preferred.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		for i, existing := range settings.PreferredShippers {
+			if normalizeShipper(existing.Shipper) == normalizeShipper(preferred.Shipper) {
+				settings.PreferredShippers[i] = preferred
+				return
+			}
+		}
+		settings.PreferredShippers = append(settings.PreferredShippers, preferred)
+	})
+	return nil
+}
+
+// RemovePreferredShipper drops a preferred shipper, reporting whether it was listed
+func (s *OptimizerService) RemovePreferredShipper(tenantID string, shipper string) bool {
+	removed := false
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		kept := make([]domain.PreferredShipper, 0, len(settings.PreferredShippers))
+		for _, existing := range settings.PreferredShippers {
+			if normalizeShipper(existing.Shipper) == normalizeShipper(shipper) {
+				removed = true
+				continue
+			}
+			kept = append(kept, existing)
+		}
+		settings.PreferredShippers = kept
+	})
+	return removed
+}
+
+// ValidateRequest validates a request against its tenant's validation profile
+func (s *OptimizerService) ValidateRequest(request *domain.OptimizeRequest) error {
+	if err := s.openPayouts(request); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if err := request.ValidateWith(s.ValidationProfile(request.TenantID), s.clock.Now()); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
+
+// ValidationProfile returns the limits in force for a tenant, with defaults filled in
+func (s *OptimizerService) ValidationProfile(tenantID string) domain.ValidationProfile {
+	return s.tenants.Get(tenantID).ValidationProfile.Resolve()
+}
+
+// SetValidationProfile replaces a tenant's validation profile
+func (s *OptimizerService) SetValidationProfile(tenantID string, profile domain.ValidationProfile) error {
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		settings.ValidationProfile = &profile
+	})
+	return nil
+}
+
+// ResetValidationProfile restores the built-in limits, reporting whether a profile was set
+func (s *OptimizerService) ResetValidationProfile(tenantID string) bool {
+	removed := false
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		removed = settings.ValidationProfile != nil
+		settings.ValidationProfile = nil
+	})
+	return removed
+}
+
+// DisabledRules returns the built-in rules a tenant switched off
+func (s *OptimizerService) DisabledRules(tenantID string) []string {
+	rules := s.tenants.Get(tenantID).DisabledRules
+	if rules == nil {
+		return []string{}
+	}
+	return rules
+}
+
+// SetDisabledRules replaces the built-in rules a tenant switches off; an empty
+// list turns every rule back on
+func (s *OptimizerService) SetDisabledRules(tenantID string, rules []string) error {
+	if err := domain.ValidateDisabledRules(rules); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	
+	s.tenants.Update(tenantID, func(settings *tenant.Settings) {
+		settings.DisabledRules = append([]string(nil), rules...)
+	})
+	return nil
+}
+
+// openPayouts decrypts sealed payouts into payout_cents. The plaintext stays in
+// the request passed to the solver and is never logged or written to history.
+func (s *OptimizerService) openPayouts(request *domain.OptimizeRequest) error {
+	for i := range request.Orders {
+		order := &request.Orders[i]
+		if order.PayoutEncrypted == "" {
+			if s.requireSealed {
+				return fmt.Errorf("order[%d]: payout_encrypted is required", i)
+			}
+			continue
+		}
+		
+		if s.payoutKeys == nil {
+			return fmt.Errorf("order[%d]: payout_encrypted is not enabled on this server", i)
+		}
+		if order.PayoutCents != 0 {
+			return fmt.Errorf("order[%d]: set payout_cents or payout_encrypted, not both", i)
+		}
+		cents, err := s.payoutKeys.Open(request.TenantID, order.ID, order.PayoutEncrypted)
+		if err != nil {
+			return fmt.Errorf("order[%d]: %w", i, err)
+		}
+		order.PayoutCents = cents
+	}
+	return nil
+}
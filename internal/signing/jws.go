@@ -0,0 +1,118 @@
+// Package signing produces detached JWS signatures (RFC 7515, Appendix F)
+// over response bodies with a server Ed25519 key.
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Signer signs payloads with EdDSA
+type Signer struct {
+	key ed25519.PrivateKey
+	kid string
+}
+
+func NewSigner(key ed25519.PrivateKey) *Signer {
+	public := key.Public().(ed25519.PublicKey)
+	// RFC 7638 thumbprint of the public JWK
+	thumbprint := sha256.Sum256([]byte(`{"crv":"Ed25519","kty":"OKP","x":"` + base64.RawURLEncoding.EncodeToString(public) + `"}`))
+	return &Signer{
+		key: key,
+		kid: base64.RawURLEncoding.EncodeToString(thumbprint[:]),
+	}
+}
+
+// LoadSigner reads a PEM-encoded PKCS #8 Ed25519 private key
+func LoadSigner(path string) (*Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key is not PEM encoded")
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %w", err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key must be Ed25519")
+	}
+	return NewSigner(key), nil
+}
+
+// KeyID identifies the signing key in the JWS header and the JWK set
+func (s *Signer) KeyID() string {
+	return s.kid
+}
+
+// SignDetached returns a compact JWS with the payload omitted: "<header>..<signature>".
+// The verifier re-attaches base64url(payload) between the dots. The protected
+// header carries the signing time (iat, Unix seconds) and, when requestID is
+// set, the request it answers (rid), so a signed body cannot be replayed as the
+// answer to a different request.
+func (s *Signer) SignDetached(payload []byte, issuedAt time.Time, requestID string) (string, error) {
+	fields := map[string]interface{}{"alg": "EdDSA", "kid": s.kid, "iat": issuedAt.Unix()}
+	if requestID != "" {
+		fields["rid"] = requestID
+	}
+	header, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	protected := base64.RawURLEncoding.EncodeToString(header)
+	signingInput := protected + "." + base64.RawURLEncoding.EncodeToString(payload)
+	
+	signature, err := s.key.Sign(nil, []byte(signingInput), crypto.Hash(0))
+	if err != nil {
+		return "", err
+	}
+	return protected + ".." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// JWKS returns the public key as a JSON Web Key Set
+func (s *Signer) JWKS() map[string]interface{} {
+	public := s.key.Public().(ed25519.PublicKey)
+	return map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"alg": "EdDSA",
+			"use": "sig",
+			"kid": s.kid,
+			"x":   base64.RawURLEncoding.EncodeToString(public),
+		}},
+	}
+}
+
+// Canonicalize rewrites a JSON document with sorted object keys, no
+// insignificant whitespace and no HTML escaping. Numbers keep their original
+// text, so integer amounts are never reformatted.
+func Canonicalize(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var document interface{}
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
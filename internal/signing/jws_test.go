@@ -0,0 +1,104 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(t *testing.T) *Signer {
+	t.Helper()
+	return NewSigner(ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize)))
+}
+
+// verify checks a detached JWS the way a client would, using only the JWK set
+func verify(t *testing.T, jwks map[string]interface{}, jws string, payload []byte) map[string]interface{} {
+	t.Helper()
+	parts := strings.Split(jws, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		t.Fatalf("not a detached compact JWS: %q", jws)
+	}
+	
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var header map[string]interface{}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		t.Fatal(err)
+	}
+	
+	key := jwks["keys"].([]map[string]string)[0]
+	if header["kid"] != key["kid"] || header["alg"] != "EdDSA" {
+		t.Fatalf("header %v does not match key %v", header, key)
+	}
+	public, err := base64.RawURLEncoding.DecodeString(key["x"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	
+	signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+	if !ed25519.Verify(ed25519.PublicKey(public), []byte(signingInput), signature) {
+		return nil
+	}
+	return header
+}
+
+func TestSignDetachedVerifies(t *testing.T) {
+	signer := newTestSigner(t)
+	payload := []byte(`{"total_payout_cents":630000,"truck_id":"truck-123"}`)
+	issuedAt := time.Unix(1760000000, 0)
+	
+	jws, err := signer.SignDetached(payload, issuedAt, "req-42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := verify(t, signer.JWKS(), jws, payload)
+	if header == nil {
+		t.Fatal("signature does not verify")
+	}
+	if header["iat"] != float64(issuedAt.Unix()) {
+		t.Errorf("iat = %v, want %d", header["iat"], issuedAt.Unix())
+	}
+	if header["rid"] != "req-42" {
+		t.Errorf("rid = %v, want req-42", header["rid"])
+	}
+	
+	if verify(t, signer.JWKS(), jws, []byte(`{"total_payout_cents":630001,"truck_id":"truck-123"}`)) != nil {
+		t.Error("signature verified a different payload")
+	}
+}
+
+func TestSignDetachedOmitsEmptyRequestID(t *testing.T) {
+	signer := newTestSigner(t)
+	jws, err := signer.SignDetached([]byte(`{}`), time.Now(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := verify(t, signer.JWKS(), jws, []byte(`{}`))
+	if header == nil {
+		t.Fatal("signature does not verify")
+	}
+	if _, ok := header["rid"]; ok {
+		t.Errorf("rid present without a request id: %v", header)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	got, err := Canonicalize([]byte("{\n  \"b\": 1.50,\n  \"a\": {\"d\": \"<x>\", \"c\": [3, 2]}\n}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"a":{"c":[3,2],"d":"<x>"},"b":1.50}`
+	if string(got) != want {
+		t.Errorf("Canonicalize = %s, want %s", got, want)
+	}
+}
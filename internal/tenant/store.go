@@ -0,0 +1,72 @@
+package tenant
+
+import (
+	"smart-load/internal/domain"
+	"sync"
+)
+
+// Settings holds the per-tenant preferences applied to every request from that tenant
+type Settings struct {
+	PreferredLanes    []domain.PreferredLane    `json:"preferred_lanes"`
+	BlockedShippers   []string                  `json:"blocked_shippers"`
+	PreferredShippers []domain.PreferredShipper `json:"preferred_shippers"`
+	// ValidationProfile tightens request limits; nil uses the built-in limits
+	ValidationProfile *domain.ValidationProfile `json:"validation_profile,omitempty"`
+	// Retention overrides the server's retention policy; nil uses the server's
+	Retention *domain.RetentionPolicy `json:"retention,omitempty"`
+	// DisabledRules names the domain.TenantToggleableRules switched off
+	DisabledRules []string `json:"disabled_rules,omitempty"`
+}
+
+// clone copies the slices, profile and policy so the copy can be changed without
+// touching the original
+func (s Settings) clone() Settings {
+	s.PreferredLanes = append([]domain.PreferredLane(nil), s.PreferredLanes...)
+	s.BlockedShippers = append([]string(nil), s.BlockedShippers...)
+	s.PreferredShippers = append([]domain.PreferredShipper(nil), s.PreferredShippers...)
+	s.DisabledRules = append([]string(nil), s.DisabledRules...)
+	if s.ValidationProfile != nil {
+		profile := *s.ValidationProfile
+		s.ValidationProfile = &profile
+	}
+	if s.Retention != nil {
+		retention := *s.Retention
+		s.Retention = &retention
+	}
+	return s
+}
+
+// Store keeps tenant settings. Get returns empty settings for unknown tenants.
+// Settings returned by Get are never modified afterwards, so callers may read
+// them without holding any lock.
+type Store interface {
+	Get(tenantID string) Settings
+	Update(tenantID string, update func(*Settings))
+}
+
+type MemoryStore struct {
+	mu       sync.RWMutex
+	settings map[string]Settings
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		settings: make(map[string]Settings),
+	}
+}
+
+func (m *MemoryStore) Get(tenantID string) Settings {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.settings[tenantID]
+}
+
+func (m *MemoryStore) Update(tenantID string, update func(*Settings)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	// Copy on write: readers may still hold the slices of the current settings
+	settings := m.settings[tenantID].clone()
+	update(&settings)
+	m.settings[tenantID] = settings
+}
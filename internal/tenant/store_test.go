@@ -0,0 +1,51 @@
+package tenant
+
+import (
+	"smart-load/internal/domain"
+	"testing"
+)
+
+func TestUpdateDoesNotChangeEarlierSettings(t *testing.T) {
+	store := NewMemoryStore()
+	store.Update("acme", func(settings *Settings) {
+		settings.PreferredShippers = append(settings.PreferredShippers, domain.PreferredShipper{Shipper: "a", BonusCents: 100})
+		settings.ValidationProfile = &domain.ValidationProfile{MaxOrders: 10}
+	})
+	
+	before := store.Get("acme")
+	store.Update("acme", func(settings *Settings) {
+		settings.PreferredShippers[0].BonusCents = 500
+		settings.ValidationProfile.MaxOrders = 20
+	})
+	
+	if before.PreferredShippers[0].BonusCents != 100 {
+		t.Errorf("earlier settings saw bonus %d, want 100", before.PreferredShippers[0].BonusCents)
+	}
+	if before.ValidationProfile.MaxOrders != 10 {
+		t.Errorf("earlier settings saw max orders %d, want 10", before.ValidationProfile.MaxOrders)
+	}
+	if got := store.Get("acme").PreferredShippers[0].BonusCents; got != 500 {
+		t.Errorf("updated bonus = %d, want 500", got)
+	}
+}
+
+func TestConcurrentGetAndUpdate(t *testing.T) {
+	store := NewMemoryStore()
+	store.Update("acme", func(settings *Settings) {
+		settings.PreferredShippers = []domain.PreferredShipper{{Shipper: "a", BonusCents: 1}}
+	})
+	
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			store.Update("acme", func(settings *Settings) {
+				settings.PreferredShippers[0].BonusCents = int64(i)
+			})
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		_ = store.Get("acme").PreferredShippers[0].BonusCents
+	}
+	<-done
+}
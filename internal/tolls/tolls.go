@@ -0,0 +1,189 @@
+package tolls
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"smart-load/internal/domain"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LaneToll is a single entry of a static toll table
+type LaneToll struct {
+	Origin      string `json:"origin"`
+	Destination string `json:"destination"`
+	TollCents   int64  `json:"toll_cents"`
+}
+
+// StaticTable prices tolls from a fixed per-lane table. Unknown lanes are toll-free.
+type StaticTable struct {
+	tolls map[string]domain.Money
+}
+
+func NewStaticTable(entries []LaneToll) *StaticTable {
+	table := &StaticTable{tolls: make(map[string]domain.Money)}
+	for _, entry := range entries {
+		table.tolls[laneKey(entry.Origin, entry.Destination)] = domain.Money(entry.TollCents)
+	}
+	return table
+}
+
+// LoadStaticTable reads a JSON array of LaneToll entries from disk
+func LoadStaticTable(path string) (*StaticTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read toll table: %w", err)
+	}
+	
+	var entries []LaneToll
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("parse toll table: %w", err)
+	}
+	return NewStaticTable(entries), nil
+}
+
+func (t *StaticTable) EstimateToll(ctx context.Context, origin, destination string) (domain.Money, error) {
+	return t.tolls[laneKey(origin, destination)], nil
+}
+
+// HTTPProvider asks an external toll API for lane prices and remembers the answers.
+// The API is called as GET <baseURL>?origin=..&destination=.. and must return
+// {"toll_cents": <int>}. Answers are kept in a bounded LRU cache; failures are
+// cached too, briefly, so an unavailable API is not retried for every request.
+type HTTPProvider struct {
+	baseURL string
+	client  *http.Client
+	cache   *tollCache
+	
+	// ttl is how long a price is trusted; failureTTL how long a failure is
+	ttl        time.Duration
+	failureTTL time.Duration
+}
+
+func NewHTTPProvider(baseURL string) *HTTPProvider {
+	return &HTTPProvider{
+		baseURL:    baseURL,
+		client:     &http.Client{Timeout: 2 * time.Second},
+		cache:      newTollCache(10000),
+		ttl:        24 * time.Hour,
+		failureTTL: 30 * time.Second,
+	}
+}
+
+func (h *HTTPProvider) EstimateToll(ctx context.Context, origin, destination string) (domain.Money, error) {
+	key := laneKey(origin, destination)
+	if entry, ok := h.cache.get(key, time.Now()); ok {
+		return entry.toll, entry.err
+	}
+	
+	toll, err := h.fetch(ctx, origin, destination)
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up; that says nothing about the API
+		return 0, err
+	}
+	
+	ttl := h.ttl
+	if err != nil {
+		ttl = h.failureTTL
+	}
+	h.cache.put(key, tollEntry{toll: toll, err: err, expires: time.Now().Add(ttl)})
+	return toll, err
+}
+
+func (h *HTTPProvider) fetch(ctx context.Context, origin, destination string) (domain.Money, error) {
+	query := url.Values{}
+	query.Set("origin", origin)
+	query.Set("destination", destination)
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.baseURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("toll api request invalid: %w", err)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("toll api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("toll api returned status %d", resp.StatusCode)
+	}
+	
+	var body struct {
+		TollCents int64 `json:"toll_cents"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, fmt.Errorf("toll api response invalid: %w", err)
+	}
+	return domain.Money(body.TollCents), nil
+}
+
+type tollEntry struct {
+	key     string
+	toll    domain.Money
+	err     error
+	expires time.Time
+}
+
+// tollCache is a fixed-size LRU of lane answers with per-entry expiry
+type tollCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+func newTollCache(capacity int) *tollCache {
+	return &tollCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+func (c *tollCache) get(key string, now time.Time) (tollEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	element, ok := c.entries[key]
+	if !ok {
+		return tollEntry{}, false
+	}
+	entry := element.Value.(tollEntry)
+	if now.After(entry.expires) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return tollEntry{}, false
+	}
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *tollCache) put(key string, entry tollEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	entry.key = key
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	
+	c.entries[key] = c.order.PushFront(entry)
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(tollEntry).key)
+	}
+}
+
+func laneKey(origin, destination string) string {
+	return strings.ToLower(strings.TrimSpace(origin)) + "->" + strings.ToLower(strings.TrimSpace(destination))
+}
@@ -0,0 +1,107 @@
+// Package smartload embeds the optimizer in another Go program: the same
+// validation, preprocessing, objective and algorithms as the server's
+// /api/v1/load-optimizer/optimize, called in process with no HTTP in
+// between. Problems and solutions are the API's request and response, so a
+// problem that solves here solves the same over the network.
+//
+//	solution, err := smartload.Solve(ctx, problem)
+//	if errors.Is(err, smartload.ErrInvalidProblem) {
+//		// fix the problem, do not retry it
+//	}
+package smartload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"smart-load/internal/domain"
+	"smart-load/internal/history"
+	"smart-load/internal/service"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Problem is a truck and the orders to choose its load from
+type Problem = domain.OptimizeRequest
+
+// Solution is the load chosen for a Problem
+type Solution = domain.OptimizeResponse
+
+// The parts of a Problem
+type (
+	Truck  = domain.TruckInput
+	Order  = domain.OrderInput
+	Config = domain.OptimizationConfig
+	LatLng = domain.LatLng
+)
+
+// ErrInvalidProblem is wrapped by the errors of problems that fail
+// validation; solving them again will not succeed
+var ErrInvalidProblem = errors.New("invalid problem")
+
+// Solver solves problems. A Solver is safe for concurrent use, and keeps no
+// record of what it solved.
+type Solver struct {
+	service *service.OptimizerService
+}
+
+// Option configures a Solver
+type Option func(*[]service.Option)
+
+// WithTimeout bounds how long a single solve may run; the default is ten
+// seconds. A deadline on the context passed to Solve also applies.
+func WithTimeout(timeout time.Duration) Option {
+	return func(opts *[]service.Option) {
+		*opts = append(*opts, service.WithSolveTimeout(timeout))
+	}
+}
+
+// WithClock sets the time dates are checked against, so a batch job that
+// re-solves the freight of a past day is not warned its pickups are past
+func WithClock(now func() time.Time) Option {
+	return func(opts *[]service.Option) {
+		*opts = append(*opts, service.WithClock(clockFunc(now)))
+	}
+}
+
+// clockFunc adapts a function to a clock.Clock
+type clockFunc func() time.Time
+
+func (f clockFunc) Now() time.Time { return f() }
+
+func NewSolver(opts ...Option) *Solver {
+	serviceOpts := []service.Option{service.WithHistoryStore(history.Discard{})}
+	for _, opt := range opts {
+		opt(&serviceOpts)
+	}
+	return &Solver{service: service.NewOptimizerService(serviceOpts...)}
+}
+
+// Solve chooses the load for a problem. Errors wrap ErrInvalidProblem when
+// the problem fails validation, and context.Canceled or
+// context.DeadlineExceeded when ctx or the timeout ends the solve first.
+func (s *Solver) Solve(ctx context.Context, problem Problem) (Solution, error) {
+	response, err := s.service.OptimizeLoad(ctx, problem)
+	if err != nil {
+		if strings.Contains(err.Error(), "validation") {
+			return Solution{}, fmt.Errorf("%w: %s", ErrInvalidProblem, err)
+		}
+		return Solution{}, err
+	}
+	return *response, nil
+}
+
+var (
+	defaultSolver     *Solver
+	defaultSolverOnce sync.Once
+)
+
+// Solve chooses the load for a problem with a Solver of the default
+// options
+func Solve(ctx context.Context, problem Problem) (Solution, error) {
+	defaultSolverOnce.Do(func() {
+		defaultSolver = NewSolver()
+	})
+	return defaultSolver.Solve(ctx, problem)
+}
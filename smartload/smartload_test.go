@@ -0,0 +1,109 @@
+package smartload
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func problem() Problem {
+	return Problem{
+		Truck: Truck{ID: "truck-1", MaxWeightLbs: 10000, MaxVolumeCuft: 1000},
+		Orders: []Order{
+			{
+				ID: "light", PayoutCents: 100000, WeightLbs: 5000, VolumeCuft: 100,
+				Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+				PickupDate: "2030-01-01", DeliveryDate: "2030-01-03",
+			},
+			{
+				ID: "heavy", PayoutCents: 90000, WeightLbs: 9500, VolumeCuft: 100,
+				Origin: "Los Angeles, CA", Destination: "Dallas, TX",
+				PickupDate: "2030-01-01", DeliveryDate: "2030-01-03",
+			},
+		},
+	}
+}
+
+func TestSolve(t *testing.T) {
+	solution, err := Solve(context.Background(), problem())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(solution.SelectedOrderIDs) != 1 || solution.SelectedOrderIDs[0] != "light" {
+		t.Fatalf("selected %v, want [light]", solution.SelectedOrderIDs)
+	}
+	if solution.SolutionID == "" {
+		t.Fatal("solution has no ID")
+	}
+}
+
+func TestSolveInvalidProblem(t *testing.T) {
+	p := problem()
+	p.Truck.MaxWeightLbs = 0
+	
+	_, err := Solve(context.Background(), p)
+	if !errors.Is(err, ErrInvalidProblem) {
+		t.Fatalf("err = %v, want ErrInvalidProblem", err)
+	}
+}
+
+func TestSolveCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	_, err := Solve(ctx, problem())
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	p := problem()
+	p.Orders = p.Orders[:1]
+	p.Orders[0].PickupDate, p.Orders[0].DeliveryDate = "2020-03-02", "2020-03-04"
+	
+	solution, err := Solve(context.Background(), p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hasWarning(solution, "pickup_in_past") {
+		t.Fatalf("warnings = %v, want pickup_in_past", solution.Warnings)
+	}
+	solver := NewSolver(WithClock(func() time.Time {
+		return time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	}))
+	if solution, err = solver.Solve(context.Background(), p); err != nil {
+		t.Fatal(err)
+	}
+	if hasWarning(solution, "pickup_in_past") {
+		t.Fatalf("solving the day before pickup warned %v", solution.Warnings)
+	}
+}
+
+func hasWarning(solution Solution, code string) bool {
+	for _, warning := range solution.Warnings {
+		if warning.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// The package is for programs that do not serve HTTP, so it must not pull
+// in the server's web stack
+func TestNoHTTPDependencies(t *testing.T) {
+	out, err := exec.Command("go", "list", "-deps", ".").Output()
+	if err != nil {
+		t.Skipf("go list: %v", err)
+	}
+	for _, path := range strings.Fields(string(out)) {
+		for _, banned := range []string{"github.com/gofiber/", "github.com/valyala/fasthttp", "smart-load/internal/api"} {
+			if strings.HasPrefix(path, banned) {
+				t.Errorf("smartload depends on %s", path)
+			}
+		}
+	}
+}